@@ -15,10 +15,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/s-mobi01/host/allwinner"
+	"github.com/s-mobi01/host/chip"
+	"github.com/s-mobi01/host/pine64"
 	"periph.io/x/conn/v3/gpio"
-	"periph.io/x/host/v3/allwinner"
-	"periph.io/x/host/v3/chip"
-	"periph.io/x/host/v3/pine64"
 )
 
 // SmokeTest is imported by periph-smoketest.
@@ -9,9 +9,9 @@ import (
 	"flag"
 	"fmt"
 
+	"github.com/s-mobi01/host/allwinner"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
-	"periph.io/x/host/v3/allwinner"
 )
 
 // Benchmark is imported by periph-smoketest.
@@ -0,0 +1,173 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// The D1 and T113 have 2 CAN 2.0B controllers, compatible with the one of the
+// A10. They are driven by the kernel driver sun4i_can, which exposes them as
+// SocketCAN interfaces; the registers are only read for diagnostics.
+
+package allwinner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/s-mobi01/host/netdev"
+	"github.com/s-mobi01/host/pmem"
+)
+
+// CANInterface returns the name of the SocketCAN interface of the CAN
+// controller n, 0 or 1, of a D1 or T113, e.g. "can0".
+//
+// The controller must be enabled in the device tree, with its pins muxed. The
+// kernel names the interfaces in probe order, so the name doesn't always
+// match n.
+func CANInterface(n int) (string, error) {
+	if n < 0 || n >= len(canBaseAddr) {
+		return "", fmt.Errorf("allwinner-can: invalid controller %d", n)
+	}
+	if !IsD1() {
+		return "", errors.New("allwinner-can: unsupported CPU")
+	}
+	items, err := filepath.Glob(sysClassNet + "*/device")
+	if err != nil {
+		return "", fmt.Errorf("allwinner-can: %v", err)
+	}
+	// The platform device is named after the address of the registers.
+	dev := fmt.Sprintf("%x.can", canBaseAddr[n])
+	for _, item := range items {
+		if t, err := os.Readlink(item); err == nil && filepath.Base(t) == dev {
+			return filepath.Base(filepath.Dir(item)), nil
+		}
+	}
+	return "", fmt.Errorf("allwinner-can: CAN%d is not enabled; check the device tree", n)
+}
+
+// OpenCAN sets the bitrate of the CAN controller n and brings its interface
+// up, ready to be used with a SocketCAN raw socket.
+//
+// restart is the delay before the controller restarts automatically after a
+// bus-off condition; 0 disables the automatic restart.
+//
+// It requires the CAP_NET_ADMIN capability, normally running as root.
+func OpenCAN(n, bitrate int, restart time.Duration) (*netdev.Interface, error) {
+	name, err := CANInterface(n)
+	if err != nil {
+		return nil, err
+	}
+	i, err := netdev.ByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.SetUp(false); err != nil {
+		return nil, err
+	}
+	if err := i.SetCANBitrate(bitrate, restart); err != nil {
+		return nil, err
+	}
+	if err := i.SetUp(true); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// CANState is the state of a CAN controller, as read from its registers.
+type CANState struct {
+	// Reset is true while the controller is held in reset, e.g. when its
+	// interface is down.
+	Reset bool
+	// BusOff is true when the controller left the bus after too many errors.
+	BusOff bool
+	// ErrorWarning is true when an error counter reached the warning limit.
+	ErrorWarning bool
+	// TxErrors and RxErrors are the transmit and receive error counters.
+	TxErrors int
+	RxErrors int
+}
+
+func (c CANState) String() string {
+	s := fmt.Sprintf("tx errors %d, rx errors %d", c.TxErrors, c.RxErrors)
+	if c.Reset {
+		s += ", reset"
+	}
+	if c.BusOff {
+		s += ", bus-off"
+	}
+	if c.ErrorWarning {
+		s += ", error warning"
+	}
+	return s
+}
+
+// ReadCANState reads the state of the CAN controller n directly from its
+// registers, without disturbing the kernel driver.
+//
+// It requires root level access to map the registers.
+func ReadCANState(n int) (CANState, error) {
+	if n < 0 || n >= len(canBaseAddr) {
+		return CANState{}, fmt.Errorf("allwinner-can: invalid controller %d", n)
+	}
+	if !IsD1() {
+		return CANState{}, errors.New("allwinner-can: unsupported CPU")
+	}
+	var m *canMap
+	if err := pmem.MapAsPOD(uint64(canBaseAddr[n]), &m); err != nil {
+		if os.IsPermission(err) {
+			return CANState{}, fmt.Errorf("allwinner-can: need more access, try as root: %v", err)
+		}
+		return CANState{}, fmt.Errorf("allwinner-can: %v", err)
+	}
+	return m.state(), nil
+}
+
+//
+
+// canBaseAddr is the address of the registers of each CAN controller of the
+// D1 and T113.
+var canBaseAddr = [...]uint32{0x02504000, 0x02504400}
+
+// sysClassNet is where the network interfaces are listed.
+var sysClassNet = "/sys/class/net/"
+
+// canMap is the registers of a CAN controller of the D1 and T113.
+//
+// The acceptance filter registers are at 0x28 instead of 0x40 on the A10.
+type canMap struct {
+	msel   uint32     // 0x00 CAN_MSEL_REG Mode Select
+	cmd    uint32     // 0x04 CAN_CMD_REG Command
+	sta    uint32     // 0x08 CAN_STA_REG Status
+	intr   uint32     // 0x0C CAN_INT_REG Interrupt Flag
+	inten  uint32     // 0x10 CAN_INTEN_REG Interrupt Enable
+	btime  uint32     // 0x14 CAN_BTIME_REG Bus Timing
+	tewl   uint32     // 0x18 CAN_TEWL_REG Tx Error Warning Limit
+	errc   uint32     // 0x1C CAN_ERRC_REG Error Counter
+	rmcnt  uint32     // 0x20 CAN_RMCNT_REG Receive Message Counter
+	rbufsa uint32     // 0x24 CAN_RBUFSA_REG Receive Buffer Start Address
+	acpc   uint32     // 0x28 CAN_ACPC_REG Acceptance Code
+	acpm   uint32     // 0x2C CAN_ACPM_REG Acceptance Mask
+	_      [4]uint32  // 0x30
+	buf    [13]uint32 // 0x40 CAN_TRBUF0~12_REG Tx/Rx Buffer
+}
+
+const (
+	canMselReset = 1 << 0 // Reset mode
+
+	canStaErr    = 1 << 6 // An error counter reached the warning limit
+	canStaBusOff = 1 << 7 // Bus-off
+)
+
+// state decodes the state of the controller.
+func (c *canMap) state() CANState {
+	sta := c.sta
+	errc := c.errc
+	return CANState{
+		Reset:        c.msel&canMselReset != 0,
+		BusOff:       sta&canStaBusOff != 0,
+		ErrorWarning: sta&canStaErr != 0,
+		TxErrors:     int(errc & 0xFF),
+		RxErrors:     int(errc >> 16 & 0xFF),
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package allwinner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/pmem"
+)
+
+// NEC is a frame decoded from the NEC infrared protocol, used by most remote
+// controls.
+type NEC struct {
+	// Address is 8 bits with the standard protocol and 16 bits with the
+	// extended protocol.
+	Address uint16
+	Command uint8
+	// Repeat is set for the frames sent while the key is held down; Address
+	// and Command are then the ones of the previous frame.
+	Repeat bool
+}
+
+func (n NEC) String() string {
+	if n.Repeat {
+		return fmt.Sprintf("NEC{%#x, %#x, repeat}", n.Address, n.Command)
+	}
+	return fmt.Sprintf("NEC{%#x, %#x}", n.Address, n.Command)
+}
+
+// CIR is the consumer infrared receiver block, wired to the onboard IR
+// receiver of many Orange Pi boards.
+//
+// The H2+, H3, H5 and A64 are supported. The receiver is accessed directly
+// through its registers, so the kernel sunxi-cir driver must not be bound to
+// it. Its input is PL11, which is muxed as S_CIR_RX.
+type CIR struct {
+	mu   sync.Mutex
+	m    *cirMap
+	prcm *prcmMap
+	last NEC // last frame, to fill repeat frames
+}
+
+// OpenCIR enables the infrared receiver.
+//
+// It requires root level access to map the registers.
+func OpenCIR() (*CIR, error) {
+	if !isArm || !hasRCIR() {
+		return nil, errors.New("allwinner-cir: unsupported CPU")
+	}
+	c := &CIR{}
+	if err := pmem.MapAsPOD(uint64(prcmBaseAddr), &c.prcm); err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("allwinner-cir: need more access, try as root: %v", err)
+		}
+		return nil, fmt.Errorf("allwinner-cir: %v", err)
+	}
+	if err := pmem.MapAsPOD(uint64(cirBaseAddr), &c.m); err != nil {
+		return nil, fmt.Errorf("allwinner-cir: %v", err)
+	}
+	c.prcm.setup()
+	c.m.setup()
+	return c, nil
+}
+
+func (c *CIR) String() string {
+	return "CIR"
+}
+
+// ReadNEC waits for a NEC frame, up to timeout.
+//
+// Frames that fail to decode, e.g. other protocols or noise, are skipped.
+func (c *CIR) ReadNEC(timeout time.Duration) (NEC, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.m == nil {
+		return NEC{}, errors.New("allwinner-cir: closed")
+	}
+	end := time.Now().Add(timeout)
+	var p pulses
+	for {
+		done := c.m.drain(&p)
+		if done {
+			if n, err := decodeNEC(p); err == nil {
+				if n.Repeat {
+					n.Address = c.last.Address
+					n.Command = c.last.Command
+				} else {
+					c.last = n
+				}
+				return n, nil
+			}
+			p = p[:0]
+		}
+		if time.Now().After(end) {
+			return NEC{}, errors.New("allwinner-cir: timed out")
+		}
+		// The FIFO holds 64 samples and NEC edges are at least 560µs apart.
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// Close disables the receiver.
+func (c *CIR) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.m == nil {
+		return nil
+	}
+	c.m.ctl = 0
+	c.m = nil
+	c.prcm = nil
+	return nil
+}
+
+//
+
+// The R_ block is at the same address on these CPUs.
+const (
+	prcmBaseAddr = 0x01F01400
+	cirBaseAddr  = 0x01F02000
+)
+
+// hasRCIR returns true if the CPU has the CIR receiver in the R_ block at
+// cirBaseAddr.
+func hasRCIR() bool {
+	if IsA64() {
+		return true
+	}
+	for _, c := range distro.DTCompatible() {
+		if strings.HasPrefix(c, "allwinner,sun8i-h") || c == "allwinner,sun50i-h5" {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	cirCtlGlobalEnable = 1 << 0 // GEN
+	cirCtlRXEnable     = 1 << 1 // RXEN
+	cirCtlModeCIR      = 3 << 4 // CIR mode
+
+	cirRXInvertPolarity = 1 << 2 // RPPI; the receivers are active low
+
+	cirStaOverflow  = 1 << 0 // ROI
+	cirStaPacketEnd = 1 << 1 // RPE
+	cirStaClear     = 0xFF
+
+	// The sample clock is 8MHz/64, so each sample is 8µs.
+	cirSample = 8 * time.Microsecond
+	// CIR_CFG: sample clock Fclk/64, noise threshold of 1 sample and idle
+	// threshold of (19+1)*128 samples, about 20ms, which ends a packet.
+	cirCfg = 19<<8 | 1<<2
+
+	// PL11 function 2 is S_CIR_RX.
+	cirPL11Shift = (11 - 8) * 4
+	cirPL11Func  = 2
+)
+
+// cirMap is the mapping of the CIR receiver registers, followed by the R_PIO
+// registers in the same page.
+//
+// H3: Page 527 and 316.
+type cirMap struct {
+	ctl    uint32    // 0x00 CIR_CTL Control
+	_      [3]uint32 //
+	rxPCfg uint32    // 0x10 CIR_RXPCFG Receiver Pulse Configure
+	_      [3]uint32 //
+	rxFIFO uint32    // 0x20 CIR_RXFIFO Receiver FIFO
+	_      [2]uint32 //
+	rxInt  uint32    // 0x2C CIR_RXINT Receiver Interrupt Control
+	rxSta  uint32    // 0x30 CIR_RXSTA Receiver Status
+	cfg    uint32    // 0x34 CIR_CFG Configure
+	_      [(0xC00 - 0x38) / 4]uint32
+	plCfg  [4]uint32 // 0xC00 R_PIO PL_CFG0-3 Configure
+}
+
+func (m *cirMap) setup() {
+	m.ctl = 0
+	m.plCfg[1] = m.plCfg[1]&^(7<<cirPL11Shift) | cirPL11Func<<cirPL11Shift
+	m.cfg = cirCfg
+	m.rxPCfg = cirRXInvertPolarity
+	// Polled; no interrupt.
+	m.rxInt = 0
+	m.rxSta = cirStaClear
+	m.ctl = cirCtlModeCIR
+	m.ctl = cirCtlModeCIR | cirCtlGlobalEnable | cirCtlRXEnable
+}
+
+// drain appends the samples in the FIFO to p and returns true at the end of
+// a packet.
+func (m *cirMap) drain(p *pulses) bool {
+	sta := m.rxSta
+	for n := (sta >> 8) & 0x7F; n > 0; n-- {
+		p.add(byte(m.rxFIFO))
+	}
+	m.rxSta = sta & (cirStaOverflow | cirStaPacketEnd)
+	if sta&cirStaOverflow != 0 {
+		*p = (*p)[:0]
+		return false
+	}
+	return sta&cirStaPacketEnd != 0
+}
+
+// prcmMap is the mapping of the R_PRCM registers controlling the CIR clock.
+//
+// H3: Page 229.
+type prcmMap struct {
+	_        [10]uint32 //
+	apb0Gate uint32     // 0x28 APB0_CLK_GATING
+	_        [10]uint32 //
+	cirClk   uint32     // 0x54 CIR_CLK
+	_        [22]uint32 //
+	apb0Rst  uint32     // 0xB0 APB0_SOFT_RST
+}
+
+func (p *prcmMap) setup() {
+	const cirBit = 1 << 1
+	// Deassert the reset then ungate the bus clock.
+	p.apb0Rst |= cirBit
+	p.apb0Gate |= cirBit
+	// 8MHz from the 24MHz oscillator: enable, source OSC24M, divided by 2+1.
+	p.cirClk = 1<<31 | 1<<24 | 2
+}
+
+// pulse is a mark (IR carrier on) or space of a given duration.
+type pulse struct {
+	mark bool
+	d    time.Duration
+}
+
+type pulses []pulse
+
+// add appends a FIFO sample: bit 7 is set for a mark and bits 6:0 are the
+// number of samples minus one. Consecutive samples of the same level are
+// merged.
+func (p *pulses) add(s byte) {
+	mark := s&0x80 != 0
+	d := time.Duration(s&0x7F+1) * cirSample
+	if l := len(*p); l != 0 && (*p)[l-1].mark == mark {
+		(*p)[l-1].d += d
+		return
+	}
+	*p = append(*p, pulse{mark: mark, d: d})
+}
+
+// decodeNEC decodes a NEC frame: a 9ms leader mark and a 4.5ms space, then
+// 32 bits LSB first as 560µs marks followed by a 560µs space for 0 or a
+// 1690µs space for 1. A repeat frame has a 2.25ms space and no bits.
+func decodeNEC(p pulses) (NEC, error) {
+	// Skip the leading space, if any.
+	if len(p) != 0 && !p[0].mark {
+		p = p[1:]
+	}
+	if len(p) < 3 || !within(p[0].d, 9*time.Millisecond, 1500*time.Microsecond) {
+		return NEC{}, errors.New("allwinner-cir: not a NEC frame")
+	}
+	if within(p[1].d, 2250*time.Microsecond, 500*time.Microsecond) {
+		return NEC{Repeat: true}, nil
+	}
+	if !within(p[1].d, 4500*time.Microsecond, 1000*time.Microsecond) || len(p) < 2+2*32 {
+		return NEC{}, errors.New("allwinner-cir: not a NEC frame")
+	}
+	var v uint32
+	for i := 0; i < 32; i++ {
+		m, s := p[2+2*i], p[3+2*i]
+		if !within(m.d, 560*time.Microsecond, 300*time.Microsecond) {
+			return NEC{}, fmt.Errorf("allwinner-cir: invalid NEC bit %d", i)
+		}
+		switch {
+		case within(s.d, 560*time.Microsecond, 300*time.Microsecond):
+		case within(s.d, 1690*time.Microsecond, 500*time.Microsecond):
+			v |= 1 << uint(i)
+		default:
+			return NEC{}, fmt.Errorf("allwinner-cir: invalid NEC bit %d", i)
+		}
+	}
+	cmd, ncmd := uint8(v>>16), uint8(v>>24)
+	if cmd != ^ncmd {
+		return NEC{}, errors.New("allwinner-cir: invalid NEC command")
+	}
+	n := NEC{Address: uint16(v), Command: cmd}
+	if a, na := uint8(v), uint8(v>>8); a == ^na {
+		// Standard protocol; the second byte is the inverse of the address.
+		n.Address = uint16(a)
+	}
+	return n, nil
+}
+
+// within returns true if d is within tolerance of want.
+func within(d, want, tolerance time.Duration) bool {
+	return d >= want-tolerance && d <= want+tolerance
+}
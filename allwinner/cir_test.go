@@ -0,0 +1,139 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package allwinner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecodeNEC(t *testing.T) {
+	data := []struct {
+		name  string
+		train []pulse
+		want  NEC
+		err   string
+	}{
+		{
+			"standard",
+			necFrame(0x04 | 0xFB<<8 | 0x08<<16 | 0xF7<<24),
+			NEC{Address: 0x04, Command: 0x08},
+			"",
+		},
+		{
+			"extended",
+			necFrame(0x1234 | 0x45<<16 | 0xBA<<24),
+			NEC{Address: 0x1234, Command: 0x45},
+			"",
+		},
+		{
+			"repeat",
+			[]pulse{{false, 30 * time.Millisecond}, {true, 9 * time.Millisecond}, {false, 2250 * time.Microsecond}, {true, 560 * time.Microsecond}},
+			NEC{Repeat: true},
+			"",
+		},
+		{
+			"bad inverted command",
+			necFrame(0x04 | 0xFB<<8 | 0x08<<16 | 0x08<<24),
+			NEC{},
+			"allwinner-cir: invalid NEC command",
+		},
+		{
+			"noise",
+			[]pulse{{true, 200 * time.Microsecond}, {false, 1 * time.Millisecond}, {true, 80 * time.Microsecond}, {false, 4 * time.Millisecond}},
+			NEC{},
+			"allwinner-cir: not a NEC frame",
+		},
+		{
+			"truncated",
+			necFrame(0x04 | 0xFB<<8 | 0x08<<16 | 0xF7<<24)[:40],
+			NEC{},
+			"allwinner-cir: not a NEC frame",
+		},
+		{
+			"bad bit",
+			badBit(necFrame(0), 4),
+			NEC{},
+			"allwinner-cir: invalid NEC bit 4",
+		},
+	}
+	for _, line := range data {
+		// Feed the train as FIFO samples, like the receiver does.
+		var p pulses
+		for _, s := range line.train {
+			for _, b := range samples(s) {
+				p.add(b)
+			}
+		}
+		got, err := decodeNEC(p)
+		if line.err != "" {
+			if err == nil || err.Error() != line.err {
+				t.Fatalf("%s: %v", line.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", line.name, err)
+		}
+		if got != line.want {
+			t.Fatalf("%s: %s != %s", line.name, got, line.want)
+		}
+	}
+}
+
+func TestPulses_add(t *testing.T) {
+	var p pulses
+	for _, s := range []byte{0x80 | 0x7F, 0x80 | 0x00, 0x45, 0x80 | 0x01} {
+		p.add(s)
+	}
+	want := pulses{{true, 129 * cirSample}, {false, 70 * cirSample}, {true, 2 * cirSample}}
+	if len(p) != len(want) {
+		t.Fatal(p)
+	}
+	for i := range p {
+		if p[i] != want[i] {
+			t.Fatal(i, p[i])
+		}
+	}
+}
+
+//
+
+// necFrame returns the pulse train of a NEC frame carrying v, preceded by the
+// idle space.
+func necFrame(v uint32) []pulse {
+	out := []pulse{{false, 30 * time.Millisecond}, {true, 9 * time.Millisecond}, {false, 4500 * time.Microsecond}}
+	for i := 0; i < 32; i++ {
+		s := 560 * time.Microsecond
+		if v&(1<<uint(i)) != 0 {
+			s = 1690 * time.Microsecond
+		}
+		out = append(out, pulse{true, 560 * time.Microsecond}, pulse{false, s})
+	}
+	return append(out, pulse{true, 560 * time.Microsecond})
+}
+
+// badBit returns f with the space of bit i too long for a 1.
+func badBit(f []pulse, i int) []pulse {
+	f[4+2*i].d = 3 * time.Millisecond
+	return f
+}
+
+// samples returns the FIFO samples encoding p, rounded to the sample period.
+func samples(p pulse) []byte {
+	var out []byte
+	for n := int((p.d + cirSample/2) / cirSample); n > 0; n -= 128 {
+		s := n
+		if s > 128 {
+			s = 128
+		}
+		b := byte(s - 1)
+		if p.mark {
+			b |= 0x80
+		}
+		out = append(out, b)
+	}
+	return out
+}
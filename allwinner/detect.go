@@ -8,7 +8,7 @@ import (
 	"strings"
 	"sync"
 
-	"periph.io/x/host/v3/distro"
+	"github.com/s-mobi01/host/distro"
 )
 
 // Present detects whether the host CPU is an Allwinner CPU.
@@ -44,6 +44,18 @@ func IsA64() bool {
 	return detection.isA64
 }
 
+// IsD1 detects whether the host CPU is an Allwinner D1 family CPU: the RISC-V
+// D1 and D1s or the ARM T113.
+//
+// It looks for the strings "sun20i-d1" or "sun8i-t113" in
+// /proc/device-tree/compatible.
+//
+// Only its CAN controllers are supported, see OpenCAN.
+func IsD1() bool {
+	detection.do()
+	return detection.isD1
+}
+
 //
 
 type detectionS struct {
@@ -53,6 +65,7 @@ type detectionS struct {
 	isR8        bool
 	isA20       bool
 	isA64       bool
+	isD1        bool
 }
 
 var detection detectionS
@@ -94,5 +107,12 @@ func (d *detectionS) do() {
 				}
 			}
 		}
+		// The D1 is a RISC-V CPU; its GPIOs are not supported, so it doesn't
+		// set isAllwinner.
+		for _, c := range distro.DTCompatible() {
+			if strings.Contains(c, "sun20i-d1") || strings.Contains(c, "sun8i-t113") {
+				d.isD1 = true
+			}
+		}
 	}
 }
@@ -21,8 +21,8 @@ import (
 	"log"
 	"os"
 
-	"periph.io/x/conn/v3/driver/driverreg"
-	"periph.io/x/host/v3/pmem"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pmem"
 )
 
 // dmaMap represents the DMA memory mapped CPU registers.
@@ -467,7 +467,7 @@ func (d *driverDMA) Close() error {
 func init() {
 	if false && isArm {
 		// TODO(maruel): This is intense, wait to be sure it works.
-		driverreg.MustRegister(&drvDMA)
+		hostcfg.MustRegister(&drvDMA)
 	}
 }
 
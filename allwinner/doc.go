@@ -8,6 +8,12 @@
 // This driver implements memory-mapped GPIO pin manipulation and leverages
 // sysfs-gpio for edge detection.
 //
+// OpenCIR gives access to the infrared receiver found on many Orange Pi
+// boards and decodes NEC frames.
+//
+// OpenCAN configures the CAN controllers of the D1 and T113 through their
+// SocketCAN interfaces.
+//
 // If you are looking at the actual implementation, open doc.go for further
 // implementation details.
 //
@@ -16,13 +16,13 @@ import (
 	"strings"
 	"time"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pmem"
+	"github.com/s-mobi01/host/sysfs"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/pin"
-	"periph.io/x/host/v3/pmem"
-	"periph.io/x/host/v3/sysfs"
 )
 
 // List of all known pins. These global variables can be used directly.
@@ -1030,7 +1030,7 @@ func (d *driverGPIO) Init() (bool, error) {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drvGPIO)
+		hostcfg.MustRegister(&drvGPIO)
 	}
 }
 
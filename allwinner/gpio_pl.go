@@ -13,13 +13,13 @@ import (
 	"strings"
 	"time"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pmem"
+	"github.com/s-mobi01/host/sysfs"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/pin"
-	"periph.io/x/host/v3/pmem"
-	"periph.io/x/host/v3/sysfs"
 )
 
 // All the pins in the PL group.
@@ -551,7 +551,7 @@ func (d *driverGPIOPL) Init() (bool, error) {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drvGPIOPL)
+		hostcfg.MustRegister(&drvGPIOPL)
 	}
 }
 
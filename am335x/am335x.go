@@ -8,8 +8,8 @@ import (
 	"errors"
 	"strings"
 
-	"periph.io/x/conn/v3/driver/driverreg"
-	"periph.io/x/host/v3/distro"
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
 )
 
 // Present returns true if a TM AM335x processor is detected.
@@ -22,6 +22,7 @@ func Present() bool {
 
 // driver implements periph.Driver.
 type driver struct {
+	dcans []DCAN
 }
 
 func (d *driver) String() string {
@@ -40,12 +41,13 @@ func (d *driver) Init() (bool, error) {
 	if !Present() {
 		return false, errors.New("am335x CPU not detected")
 	}
+	d.dcans = detectDCANs()
 	return true, nil
 }
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drv)
+		hostcfg.MustRegister(&drv)
 	}
 }
 
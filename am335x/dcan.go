@@ -0,0 +1,104 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package am335x
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/s-mobi01/host/netdev"
+)
+
+// DCAN is one of the two DCAN controllers of the AM335x.
+//
+// The kernel c_can driver exposes each enabled controller as a SocketCAN
+// network interface, e.g. "can0".
+type DCAN struct {
+	// Index is 0 for DCAN0 and 1 for DCAN1.
+	Index int
+	// Interface is the SocketCAN network interface. It is empty if the
+	// controller is not enabled in the device tree.
+	Interface string
+	// RX and TX are the BeagleBone header pins the CAN capes commonly use.
+	RX string
+	TX string
+}
+
+func (d *DCAN) String() string {
+	if d.Interface == "" {
+		return fmt.Sprintf("DCAN%d", d.Index)
+	}
+	return fmt.Sprintf("DCAN%d(%s)", d.Index, d.Interface)
+}
+
+// Mux sets RX and TX to the DCAN function via the pinmux helpers of the
+// cape-universal device tree overlay. It is the equivalent of running
+// config-pin <pin> can.
+func (d *DCAN) Mux() error {
+	for _, p := range []string{d.RX, d.TX} {
+		if err := ioutil.WriteFile(ocpRoot+"ocp:"+p+"_pinmux/state", []byte("can"), 0644); err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("am335x: %s: %s has no pinmux helper; is cape-universal loaded?", d, p)
+			}
+			return fmt.Errorf("am335x: %s: %v", d, err)
+		}
+	}
+	return nil
+}
+
+// Link returns the SocketCAN network interface, to bring it up or down and
+// monitor its state.
+//
+// The bitrate must be configured before bringing it up, e.g. with
+// "ip link set can0 type can bitrate 500000".
+func (d *DCAN) Link() (*netdev.Interface, error) {
+	if d.Interface == "" {
+		return nil, fmt.Errorf("am335x: %s is not enabled in the device tree", d)
+	}
+	return netdev.ByName(d.Interface)
+}
+
+// DCANs returns the DCAN controllers detected at initialization.
+func DCANs() []DCAN {
+	out := make([]DCAN, len(drv.dcans))
+	copy(out, drv.dcans)
+	return out
+}
+
+//
+
+// dcanCtrl describes a DCAN controller.
+type dcanCtrl struct {
+	addr   string // base address, as named in sysfs
+	rx, tx string // pins, in mode 2
+}
+
+// TRM Table 2-3 and the BeagleBone System Reference Manual P9 header.
+var dcanCtrls = [...]dcanCtrl{
+	{"481cc000", "P9_19", "P9_20"},
+	{"481d0000", "P9_24", "P9_26"},
+}
+
+// sysfs paths.
+var (
+	sysPlatform = "/sys/bus/platform/devices/"
+	ocpRoot     = "/sys/devices/platform/ocp/"
+)
+
+// detectDCANs lists both controllers and the network interface of the
+// enabled ones.
+func detectDCANs() []DCAN {
+	out := make([]DCAN, len(dcanCtrls))
+	for i, c := range dcanCtrls {
+		out[i] = DCAN{Index: i, RX: c.rx, TX: c.tx}
+		files, err := ioutil.ReadDir(sysPlatform + c.addr + ".can/net")
+		if err != nil || len(files) == 0 {
+			continue
+		}
+		out[i].Interface = files[0].Name()
+	}
+	return out
+}
@@ -13,6 +13,9 @@
 // GPIOx_y. To get the absolute number, as exposed by sysfs, use 32*x+y to get
 // the absolute number.
 //
+// The DCAN controllers enabled in the device tree are listed by DCANs(), with
+// the header pins the CAN capes use.
+//
 // Datasheet
 //
 // Technical Reference Manual
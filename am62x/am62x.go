@@ -0,0 +1,171 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package am62x
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pmem"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+)
+
+// GPIO controllers, identified by the label exported by the kernel.
+const (
+	MainGPIO0 = "600000.gpio"
+	MainGPIO1 = "601000.gpio"
+	MCUGPIO0  = "4201000.gpio"
+)
+
+// Present returns true if a TI AM62x processor is detected.
+func Present() bool {
+	if isArm {
+		for _, c := range distro.DTCompatible() {
+			if c == "ti,am625" || c == "ti,am623" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GPIOLine returns the pin at offset on the GPIO controller ctrl, e.g.
+// GPIOLine(MainGPIO1, 22) for GPIO1_22.
+//
+// It returns gpio.INVALID if the controller or the line is not exported by
+// sysfs-gpio.
+func GPIOLine(ctrl string, offset int) gpio.PinIO {
+	if base, ok := sysfs.GPIOChipBases()[ctrl]; ok {
+		if p, ok := sysfs.Pins[base+offset]; ok {
+			return p
+		}
+	}
+	return gpio.INVALID
+}
+
+// PadConfig is the content of a PADCONFIG register, which controls the pin
+// multiplexing and the electrical configuration of a pad.
+type PadConfig uint32
+
+// Mode returns the selected multiplexing mode. Mode 7 is GPIO on most pads.
+func (p PadConfig) Mode() int {
+	return int(p & 0xF)
+}
+
+// Pull returns the configured pull resistor.
+func (p PadConfig) Pull() gpio.Pull {
+	if p&padPullDisable != 0 {
+		return gpio.Float
+	}
+	if p&padPullUp != 0 {
+		return gpio.PullUp
+	}
+	return gpio.PullDown
+}
+
+// InputEnabled returns true if the receiver of the pad is enabled. It is
+// required for the pad to be used as an input, including as a GPIO input.
+func (p PadConfig) InputEnabled() bool {
+	return p&padRXActive != 0
+}
+
+// OutputEnabled returns true if the driver of the pad is enabled.
+func (p PadConfig) OutputEnabled() bool {
+	return p&padTXDisable == 0
+}
+
+func (p PadConfig) String() string {
+	dir := ""
+	if p.InputEnabled() {
+		dir += "I"
+	}
+	if p.OutputEnabled() {
+		dir += "O"
+	}
+	return fmt.Sprintf("MODE%d %s %s", p.Mode(), dir, p.Pull())
+}
+
+// ReadPad returns the PADCONFIG register at offset in the main domain pin
+// multiplexer.
+//
+// This function requires access to /dev/mem, so it normally requires running
+// as root.
+func ReadPad(offset uint32) (PadConfig, error) {
+	if !Present() {
+		return 0, errors.New("am62x: CPU not detected")
+	}
+	if offset%4 != 0 || offset >= mainPadSize {
+		return 0, fmt.Errorf("am62x: invalid pad offset 0x%x", offset)
+	}
+	padMu.Lock()
+	defer padMu.Unlock()
+	if pads == nil {
+		if err := pmem.MapAsPOD(mainPadBase, &pads); err != nil {
+			if os.IsPermission(err) {
+				return 0, fmt.Errorf("am62x: need more access, try as root: %v", err)
+			}
+			return 0, fmt.Errorf("am62x: %v", err)
+		}
+	}
+	return PadConfig(pads[offset/4]), nil
+}
+
+//
+
+// Bits of the PADCONFIG registers.
+const (
+	padPullDisable PadConfig = 1 << 16 // PULLUDEN, active low
+	padPullUp      PadConfig = 1 << 17 // PULLTYPESEL
+	padRXActive    PadConfig = 1 << 18 // RXACTIVE
+	padTXDisable   PadConfig = 1 << 21 // TX_DIS
+)
+
+// Main domain pin multiplexer, as described by the main_pmx0 node of the
+// kernel device tree.
+const (
+	mainPadBase = 0x000F4000
+	mainPadSize = 0x2AC
+)
+
+var (
+	padMu sync.Mutex
+	pads  *[mainPadSize / 4]uint32
+)
+
+// driver implements periph.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "am62x"
+}
+
+func (d *driver) Prerequisites() []string {
+	return nil
+}
+
+func (d *driver) After() []string {
+	return nil
+}
+
+func (d *driver) Init() (bool, error) {
+	if !Present() {
+		return false, errors.New("am62x CPU not detected")
+	}
+	return true, nil
+}
+
+func init() {
+	if isArm {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
@@ -0,0 +1,30 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package am62x exposes functionality for the Texas Instruments Sitara AM62x
+// processor family.
+//
+// This processor family is found on the BeaglePlay.
+//
+// The GPIO pins of the AM62x CPU are grouped into the controllers main_gpio0
+// (92 lines), main_gpio1 (52 lines) and mcu_gpio0 (24 lines). The CPU
+// documentation refers to GPIO in the form of GPIOx_y for the main domain and
+// MCU_GPIO0_y for the MCU domain. Unlike on the AM335x, the sysfs GPIO numbers
+// are allocated dynamically by the kernel, so use GPIOLine to retrieve a pin
+// by controller and line offset.
+//
+// The pin multiplexing of the main domain can be inspected with ReadPad, which
+// decodes the PADCONFIG registers. The offsets are the ones used in the
+// AM62X_IOPAD() entries of the kernel device tree.
+//
+// Datasheet
+//
+// Technical Reference Manual
+// https://www.ti.com/lit/ug/spruiv7/spruiv7.pdf
+//
+// Other
+//
+// Marketing page
+// https://www.ti.com/product/AM625
+package am62x
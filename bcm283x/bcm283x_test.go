@@ -4,7 +4,7 @@
 
 package bcm283x
 
-import "periph.io/x/host/v3/fs"
+import "github.com/s-mobi01/host/fs"
 
 func init() {
 	fs.Inhibit()
@@ -70,11 +70,11 @@ import (
 	"strings"
 	"time"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pmem"
+	"github.com/s-mobi01/host/videocore"
 	"periph.io/x/conn/v3/gpio/gpiostream"
 	"periph.io/x/conn/v3/physic"
-	"periph.io/x/host/v3/pmem"
-	"periph.io/x/host/v3/videocore"
 )
 
 const (
@@ -668,6 +668,20 @@ func runIO(pCB pmem.Mem, liteOk bool) error {
 	return ch.wait()
 }
 
+// allocDMA allocates a buffer from the VideoCore memory and checks that the
+// DMA controller can access it.
+func allocDMA(size int) (*videocore.Mem, error) {
+	m, err := videocore.Alloc(size)
+	if err != nil {
+		return nil, err
+	}
+	if err := videocore.CheckDMA(m.PhysAddr(), len(m.Bytes())); err != nil {
+		_ = m.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
 func allocateCB(size int) ([]controlBlock, *videocore.Mem, error) {
 	buf, err := drvDMA.dmaBufAllocator((size + 0xFFF) &^ 0xFFF)
 	if err != nil {
@@ -1088,12 +1102,12 @@ func smokeTest() error {
 	const holeSize = 1             // Minimum DMA alignment
 
 	alloc := func(s int) (pmem.Mem, error) {
-		return videocore.Alloc(s)
+		return allocDMA(s)
 	}
 
 	copyMem := func(pDst, pSrc uint64) error {
 		// Allocate a control block and initialize it.
-		pCB, err2 := videocore.Alloc(4096)
+		pCB, err2 := allocDMA(4096)
 		if err2 != nil {
 			return err2
 		}
@@ -1158,7 +1172,7 @@ type driverDMA struct {
 	pwmDMABuf   *videocore.Mem
 
 	// dmaBufAllocator is overridden for unit testing.
-	dmaBufAllocator func(s int) (*videocore.Mem, error) // Set to videocore.Alloc
+	dmaBufAllocator func(s int) (*videocore.Mem, error) // Set to allocDMA
 }
 
 func (d *driverDMA) Close() error {
@@ -1192,7 +1206,16 @@ func (d *driverDMA) After() []string {
 }
 
 func (d *driverDMA) Init() (bool, error) {
-	d.dmaBufAllocator = videocore.Alloc
+	// The DMA buffers are allocated from the VideoCore memory; fail early when
+	// the firmware reserved none.
+	vc, err := videocore.VCMemory()
+	if err != nil {
+		return true, err
+	}
+	if vc.Size == 0 {
+		return true, errors.New("bcm283x-dma: no VideoCore memory; set gpu_mem in config.txt")
+	}
+	d.dmaBufAllocator = allocDMA
 	d.pwmBaseFreq = 25 * physic.MegaHertz
 	d.pwmDMAFreq = 200 * physic.KiloHertz
 	// baseAddr is initialized by prerequisite driver bcm283x-gpio.
@@ -1251,7 +1274,7 @@ func resetDMA(ch int) error {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drvDMA)
+		hostcfg.MustRegister(&drvDMA)
 	}
 }
 
@@ -5,7 +5,8 @@
 // Package bcm283x exposes the BCM283x GPIO functionality.
 //
 // This driver implements memory-mapped GPIO pin manipulation and leverages
-// sysfs-gpio for edge detection.
+// the kernel for edge detection, via ioctl-gpio (/dev/gpiochipN) when
+// available and sysfs-gpio otherwise.
 //
 // If you are looking for the actual implementation, open doc.go for further
 // implementation details.
@@ -15,6 +16,20 @@
 // Aliases for GPCLK0, GPCLK1, GPCLK2 are created for corresponding CLKn pins.
 // Same for PWM0_OUT and PWM1_OUT, which point respectively to PWM0 and PWM1.
 //
+// Fan
+//
+// NewFan controls a fan with PWM, like the Raspberry Pi 4 case fan, measures
+// its speed from its tachometer and adjusts it from a temperature sensor. On
+// the Raspberry Pi 5, it uses the fan connector driven by the kernel. It
+// implements fan.Fan.
+//
+// Raspberry Pi 5
+//
+// OpenRTC exposes the battery backed real time clock, its charging
+// configuration and the wake alarm, and OpenPowerButton the power button
+// events, so a headless appliance can schedule its wake up and power off
+// cleanly.
+//
 // Datasheet
 //
 // https://www.raspberrypi.org/wp-content/uploads/2012/02/BCM2835-ARM-Peripherals.pdf
@@ -0,0 +1,217 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/s-mobi01/host/counter"
+	"github.com/s-mobi01/host/fan"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+// FanOpts configures NewFan.
+type FanOpts struct {
+	// PWM is the pin controlling the fan. It defaults to the Raspberry Pi 5
+	// fan connector when the kernel drives it, otherwise to GPIO14, the
+	// control pin of the Raspberry Pi 4 case fan.
+	PWM gpio.PinOut
+	// Tach is the pin connected to the tachometer output of the fan. It is
+	// optional; the Raspberry Pi 4 case fan doesn't have one. It is pulled up,
+	// as tachometer outputs are open collector.
+	Tach gpio.PinIn
+	// Frequency is the PWM frequency. It defaults to 25kHz, as specified for 4
+	// wire fans.
+	//
+	// The pins without a hardware PWM channel, including GPIO14, use DMA which
+	// only gives 8 steps at 25kHz. A lower frequency, like 1kHz, gives a finer
+	// control of 2 and 3 wire fans driven through a transistor.
+	Frequency physic.Frequency
+	// PulsesPerRevolution is the number of tachometer pulses per revolution.
+	// It defaults to 2, which most fans use.
+	PulsesPerRevolution int
+}
+
+// NewFan returns a fan controlled with PWM.
+//
+// The fan is stopped until SetDuty or Control is called.
+//
+// The Raspberry Pi 5 fan connector is wired to the RP1 south bridge, which
+// this driver doesn't support. Instead, the kernel drives it with its pwm-fan
+// driver as the cooling_fan device. When neither PWM nor Tach is set and such
+// a fan is exposed in /sys/class/hwmon, NewFan uses it through fan.OpenHwmon.
+// It then keeps following the kernel until SetDuty or Control is called and
+// Close gives it back to the kernel.
+func NewFan(o *FanOpts) (*Fan, error) {
+	var opts FanOpts
+	if o != nil {
+		opts = *o
+	}
+	if opts.PWM == nil && opts.Tach == nil {
+		if k, err := openKernelFan(); err == nil {
+			return newKernelFan(k)
+		}
+	}
+	var tach counter.Counter
+	if opts.Tach != nil {
+		c, err := counter.NewGPIO(opts.Tach, gpio.PullUp)
+		if err != nil {
+			return nil, err
+		}
+		tach = c
+	}
+	f, err := newFan(&opts, tach)
+	if c, ok := tach.(io.Closer); ok && err != nil {
+		c.Close()
+	}
+	return f, err
+}
+
+// Fan is a fan controlled with PWM, optionally with a tachometer.
+//
+// It implements fan.Fan on top of fan.GPIO, adding the control from a
+// temperature sensor. The resulting object is safe for concurrent use.
+type Fan struct {
+	fan.Fan
+	close func() error
+}
+
+// Close stops the fan and releases the pins.
+func (f *Fan) Close() error {
+	return f.close()
+}
+
+// FanCurve maps a temperature to a fan speed for Fan.Control.
+//
+// The speed increases linearly from MinDuty at Min to full speed at Max.
+type FanCurve struct {
+	// Min is the temperature at which the fan starts.
+	Min physic.Temperature
+	// Max is the temperature at which the fan runs at full speed.
+	Max physic.Temperature
+	// MinDuty is the speed at Min. Most fans don't start below 20~30%.
+	MinDuty gpio.Duty
+	// Hysteresis keeps the fan running until the temperature drops below
+	// Min-Hysteresis, so it doesn't start and stop continuously.
+	Hysteresis physic.Temperature
+}
+
+// Duty returns the fan speed at temperature t. running is true if the fan is
+// currently running, to apply the hysteresis.
+func (c *FanCurve) Duty(t physic.Temperature, running bool) gpio.Duty {
+	switch {
+	case t >= c.Max:
+		return gpio.DutyMax
+	case t >= c.Min:
+		return c.MinDuty + gpio.Duty(int64(gpio.DutyMax-c.MinDuty)*int64(t-c.Min)/int64(c.Max-c.Min))
+	case running && t >= c.Min-c.Hysteresis:
+		return c.MinDuty
+	default:
+		return 0
+	}
+}
+
+// Control adjusts the speed of the fan following c, from the temperature
+// read from s every interval, until ctx is canceled.
+//
+// It fails safe: when reading s fails, the fan is set to full speed and the
+// error is returned. It returns ctx.Err() once canceled, leaving the fan at
+// its last speed.
+func (f *Fan) Control(ctx context.Context, s physic.SenseEnv, c FanCurve, interval time.Duration) error {
+	if c.Max <= c.Min || c.Hysteresis < 0 || c.MinDuty < 0 || c.MinDuty > gpio.DutyMax || interval <= 0 {
+		return errors.New("bcm283x-fan: invalid fan curve")
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		var e physic.Env
+		if err := s.Sense(&e); err != nil {
+			_ = f.SetDuty(gpio.DutyMax)
+			return fmt.Errorf("bcm283x-fan: %v", err)
+		}
+		d, err := f.Duty()
+		if err != nil {
+			return err
+		}
+		if err := f.SetDuty(c.Duty(e.Temperature, d != 0)); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+//
+
+// newFan returns a fan driven by o.PWM, with its tachometer read by tach.
+func newFan(o *FanOpts, tach counter.Counter) (*Fan, error) {
+	if o.Frequency < 0 || o.PulsesPerRevolution < 0 {
+		return nil, errors.New("bcm283x-fan: invalid options")
+	}
+	p := o.PWM
+	if p == nil {
+		p = &cpuPins[14]
+	}
+	freq := o.Frequency
+	if freq == 0 {
+		freq = 25 * physic.KiloHertz
+	}
+	ppr := o.PulsesPerRevolution
+	if ppr == 0 {
+		ppr = 2
+	}
+	g, err := fan.NewGPIO(p, freq, tach, ppr)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Out(gpio.Low); err != nil {
+		g.Close()
+		return nil, err
+	}
+	return &Fan{Fan: g, close: func() error {
+		var err error
+		if c, ok := tach.(io.Closer); ok {
+			err = c.Close()
+		}
+		if err2 := p.Out(gpio.Low); err == nil {
+			err = err2
+		}
+		if err2 := g.Close(); err == nil {
+			err = err2
+		}
+		return err
+	}}, nil
+}
+
+// openKernelFan returns the fan driven by the kernel pwm-fan driver. It is
+// overridden in tests.
+var openKernelFan = func() (fan.Fan, error) {
+	return fan.OpenHwmon("pwmfan", 1)
+}
+
+// newKernelFan returns a fan driven by the kernel, restoring its mode on
+// Close.
+func newKernelFan(k fan.Fan) (*Fan, error) {
+	m, err := k.Mode()
+	if err != nil {
+		return nil, err
+	}
+	return &Fan{Fan: k, close: func() error {
+		if m != fan.Auto {
+			return nil
+		}
+		return k.SetMode(fan.Auto)
+	}}, nil
+}
+
+var _ fan.Fan = &Fan{}
@@ -0,0 +1,191 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/s-mobi01/host/fan"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestFan(t *testing.T) {
+	p := &gpiotest.Pin{N: "PWM", L: gpio.High}
+	f, err := NewFan(&FanOpts{PWM: p, Frequency: physic.KiloHertz})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.L != gpio.Low {
+		t.Fatal("fan must start stopped")
+	}
+	if _, err := NewFan(&FanOpts{PWM: p}); err == nil {
+		t.Fatal("pin already in use")
+	}
+	if err := f.SetDuty(gpio.DutyHalf); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := f.Duty(); err != nil || p.D != gpio.DutyHalf || p.F != physic.KiloHertz || d != gpio.DutyHalf {
+		t.Fatal(p.D, p.F, d, err)
+	}
+	if err := f.SetDuty(gpio.DutyMax + 1); err == nil {
+		t.Fatal("invalid duty")
+	}
+	if _, err := f.RPM(); err != fan.ErrNoTach {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if p.L != gpio.Low {
+		t.Fatal("fan must be stopped")
+	}
+	if _, err := NewFan(&FanOpts{PWM: p, PulsesPerRevolution: -1}); err == nil {
+		t.Fatal("invalid options")
+	}
+
+	// 50 pulses per second, 2 per revolution.
+	f, err = newFan(&FanOpts{PWM: p}, &fakeCounter{step: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if rpm, err := f.RPM(); err != nil || rpm < 1400 || rpm > 1500 {
+		t.Fatal(rpm, err)
+	}
+}
+
+func TestFan_kernel(t *testing.T) {
+	defer func(o func() (fan.Fan, error)) { openKernelFan = o }(openKernelFan)
+	k := &fakeFan{mode: fan.Auto}
+	openKernelFan = func() (fan.Fan, error) {
+		return k, nil
+	}
+	f, err := NewFan(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := f.String(); s != "pwmfan/pwm1" {
+		t.Fatal(s)
+	}
+	if err := f.SetDuty(gpio.DutyHalf); err != nil {
+		t.Fatal(err)
+	}
+	if k.mode != fan.Manual || k.duty != gpio.DutyHalf {
+		t.Fatal(k.mode, k.duty)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if k.mode != fan.Auto {
+		t.Fatal("fan must be given back to the kernel")
+	}
+}
+
+func TestFanCurve_Duty(t *testing.T) {
+	c := FanCurve{
+		Min:        50*physic.Celsius + physic.ZeroCelsius,
+		Max:        70*physic.Celsius + physic.ZeroCelsius,
+		MinDuty:    gpio.DutyMax / 4,
+		Hysteresis: 5 * physic.Celsius,
+	}
+	data := []struct {
+		t       physic.Temperature
+		running bool
+		want    gpio.Duty
+	}{
+		{40*physic.Celsius + physic.ZeroCelsius, true, 0},
+		{47*physic.Celsius + physic.ZeroCelsius, false, 0},
+		{47*physic.Celsius + physic.ZeroCelsius, true, gpio.DutyMax / 4},
+		{50*physic.Celsius + physic.ZeroCelsius, false, gpio.DutyMax / 4},
+		{60*physic.Celsius + physic.ZeroCelsius, true, gpio.DutyMax/4 + (gpio.DutyMax-gpio.DutyMax/4)/2},
+		{80*physic.Celsius + physic.ZeroCelsius, true, gpio.DutyMax},
+	}
+	for i, line := range data {
+		if d := c.Duty(line.t, line.running); d != line.want {
+			t.Fatalf("#%d: %s != %s", i, d, line.want)
+		}
+	}
+}
+
+func TestFan_Control(t *testing.T) {
+	p := &gpiotest.Pin{N: "PWM"}
+	f, err := NewFan(&FanOpts{PWM: p})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	c := FanCurve{Min: 50*physic.Celsius + physic.ZeroCelsius, Max: 70*physic.Celsius + physic.ZeroCelsius}
+	if err := f.Control(context.Background(), &fakeSensor{}, FanCurve{}, time.Millisecond); err == nil {
+		t.Fatal("invalid curve")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &fakeSensor{temps: []physic.Temperature{80*physic.Celsius + physic.ZeroCelsius}, cancel: cancel}
+	if err := f.Control(ctx, s, c, time.Millisecond); err != context.Canceled {
+		t.Fatal(err)
+	}
+	if p.D != gpio.DutyMax {
+		t.Fatal(p.D)
+	}
+	// Full speed on failure.
+	_ = f.SetDuty(0)
+	if err := f.Control(context.Background(), &fakeSensor{}, c, time.Millisecond); err == nil {
+		t.Fatal("expected error")
+	}
+	if d, _ := f.Duty(); d != gpio.DutyMax {
+		t.Fatal(d)
+	}
+}
+
+//
+
+type fakeCounter struct {
+	count uint64
+	step  uint64
+}
+
+func (f *fakeCounter) String() string { return "fake" }
+func (f *fakeCounter) Reset() error   { f.count = 0; return nil }
+
+func (f *fakeCounter) Count() (uint64, error) {
+	c := f.count
+	f.count += f.step
+	return c, nil
+}
+
+type fakeFan struct {
+	mode fan.Mode
+	duty gpio.Duty
+}
+
+func (f *fakeFan) String() string            { return "pwmfan/pwm1" }
+func (f *fakeFan) Duty() (gpio.Duty, error)  { return f.duty, nil }
+func (f *fakeFan) RPM() (int, error)         { return 0, fan.ErrNoTach }
+func (f *fakeFan) SetMode(m fan.Mode) error  { f.mode = m; return nil }
+func (f *fakeFan) Mode() (fan.Mode, error)   { return f.mode, nil }
+func (f *fakeFan) SetDuty(d gpio.Duty) error { f.mode, f.duty = fan.Manual, d; return nil }
+
+// fakeSensor returns temps, then cancels; it fails when temps is empty.
+type fakeSensor struct {
+	physic.SenseEnv
+	temps  []physic.Temperature
+	cancel func()
+}
+
+func (f *fakeSensor) Sense(e *physic.Env) error {
+	if len(f.temps) == 0 {
+		return errors.New("no sensor")
+	}
+	e.Temperature = f.temps[0]
+	f.temps = f.temps[1:]
+	if len(f.temps) == 0 {
+		f.cancel()
+	}
+	return nil
+}
@@ -12,16 +12,17 @@ import (
 	"strings"
 	"time"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/gpioioctl"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pmem"
+	"github.com/s-mobi01/host/sysfs"
+	"github.com/s-mobi01/host/videocore"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/gpio/gpiostream"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/pin"
-	"periph.io/x/host/v3/distro"
-	"periph.io/x/host/v3/pmem"
-	"periph.io/x/host/v3/sysfs"
-	"periph.io/x/host/v3/videocore"
 )
 
 // All the pins supported by the CPU.
@@ -195,10 +196,12 @@ type Pin struct {
 	defaultPull gpio.Pull // Default pull at system boot, as per datasheet.
 
 	// Immutable after driver initialization.
-	sysfsPin *sysfs.Pin // Set to the corresponding sysfs.Pin, if any.
+	sysfsPin    *sysfs.Pin // Set to the corresponding sysfs.Pin, if any.
+	chardevLine edgeLine   // Set to the corresponding /dev/gpiochip line, if any.
 
 	// Mutable.
 	usingEdge  bool           // Set when edge detection is enabled.
+	edge       edgeLine       // Line used for edge detection, when usingEdge.
 	usingClock bool           // Set when a CLK, PWM or I2S/PCM clock is used.
 	dmaCh      *dmaChannel    // Set when DMA is used for PWM or I2S/PCM.
 	dmaBuf     *videocore.Mem // Set when DMA is used for PWM or I2S/PCM.
@@ -216,11 +219,8 @@ func (p *Pin) String() string {
 // In the case of clock or PWM, all pins with this clock source are also
 // disabled.
 func (p *Pin) Halt() error {
-	if p.usingEdge {
-		if err := p.sysfsPin.Halt(); err != nil {
-			return p.wrap(err)
-		}
-		p.usingEdge = false
+	if err := p.haltEdge(); err != nil {
+		return err
 	}
 	return p.haltClock()
 }
@@ -370,18 +370,21 @@ func (p *Pin) SetFunc(f pin.Func) error {
 //
 // Will fail if requesting to change a pin that is set to special functionality.
 //
-// Using edge detection requires opening a gpio sysfs file handle. On Raspbian,
-// make sure the user is member of group 'gpio'. The pin will be exported at
-// /sys/class/gpio/gpio*/. Note that the pin will not be unexported at
-// shutdown.
+// Edge detection is done by the kernel, which owns the edge detect registers.
+// The line is requested through /dev/gpiochipN when ioctl-gpio is loaded, and
+// released on Halt. Reads and writes of the pin stay memory mapped. Otherwise
+// it falls back to a gpio sysfs file handle. On Raspbian, make sure the user
+// is member of group 'gpio'. The pin will be exported at /sys/class/gpio/gpio*/.
+// Note that the pin will not be unexported at shutdown.
 //
 // For edge detection, the processor samples the input at its CPU clock rate
 // and looks for '011' to rising and '100' for falling detection to avoid
-// glitches. Because gpio sysfs is used, the latency is unpredictable.
+// glitches. Because the events go through the kernel, the latency is
+// unpredictable.
 func (p *Pin) In(pull gpio.Pull, edge gpio.Edge) error {
 	if p.usingEdge && edge == gpio.NoEdge {
-		if err := p.sysfsPin.Halt(); err != nil {
-			return p.wrap(err)
+		if err := p.haltEdge(); err != nil {
+			return err
 		}
 	}
 	if drvGPIO.gpioMemory == nil {
@@ -395,6 +398,9 @@ func (p *Pin) In(pull gpio.Pull, edge gpio.Edge) error {
 			return p.wrap(err)
 		}
 		p.usingEdge = edge != gpio.NoEdge
+		if p.usingEdge {
+			p.edge = p.sysfsPin
+		}
 		return nil
 	}
 	if err := p.haltClock(); err != nil {
@@ -454,14 +460,19 @@ func (p *Pin) In(pull gpio.Pull, edge gpio.Edge) error {
 		}
 	}
 	if edge != gpio.NoEdge {
-		if p.sysfsPin == nil {
-			return p.wrap(fmt.Errorf("pin %d is not exported by sysfs", p.number))
+		l := p.chardevLine
+		if l == nil {
+			if p.sysfsPin == nil {
+				return p.wrap(fmt.Errorf("pin %d is not exported by sysfs nor by a GPIO chip", p.number))
+			}
+			l = p.sysfsPin
 		}
-		// This resets pending edges.
-		if err := p.sysfsPin.In(gpio.PullNoChange, edge); err != nil {
+		// This resets pending edges. The pull was set above.
+		if err := l.In(gpio.PullNoChange, edge); err != nil {
 			return p.wrap(err)
 		}
 		p.usingEdge = true
+		p.edge = l
 	}
 	return nil
 }
@@ -498,6 +509,9 @@ func (p *Pin) FastRead() gpio.Level {
 
 // WaitForEdge implements gpio.PinIn.
 func (p *Pin) WaitForEdge(timeout time.Duration) bool {
+	if l := p.edge; l != nil {
+		return l.WaitForEdge(timeout)
+	}
 	if p.sysfsPin != nil {
 		return p.sysfsPin.WaitForEdge(timeout)
 	}
@@ -532,7 +546,8 @@ func (p *Pin) Pull() gpio.Pull {
 
 // DefaultPull implements gpio.PinIn.
 //
-// The CPU doesn't return the current pull.
+// The CPU doesn't return the current pull. It is the pull at reset; see
+// PowerUpState.
 func (p *Pin) DefaultPull() gpio.Pull {
 	return p.defaultPull
 }
@@ -840,6 +855,19 @@ func (p *Pin) haltDMA() error {
 	return nil
 }
 
+// haltEdge stops edge detection, if enabled.
+func (p *Pin) haltEdge() error {
+	if !p.usingEdge {
+		return nil
+	}
+	if err := p.edge.Halt(); err != nil {
+		return p.wrap(err)
+	}
+	p.usingEdge = false
+	p.edge = nil
+	return nil
+}
+
 // haltClock disables the CLK/PWM clock if used.
 func (p *Pin) haltClock() error {
 	if err := p.haltDMA(); err != nil {
@@ -1237,6 +1265,38 @@ func sleep150cycles() uint32 {
 	return out
 }
 
+// edgeLine is the kernel interface used for edge detection on a pin.
+type edgeLine interface {
+	In(pull gpio.Pull, edge gpio.Edge) error
+	WaitForEdge(timeout time.Duration) bool
+	Halt() error
+}
+
+// chardevEdge is a /dev/gpiochip line used for edge detection.
+type chardevEdge struct {
+	*gpioioctl.GPIOLine
+}
+
+// Halt releases the line back to the kernel, so it doesn't keep a stale
+// direction while the pin is driven through the registers.
+func (c chardevEdge) Halt() error {
+	return c.Close()
+}
+
+// chardevLine returns the line of the GPIO chip for the pin number, if
+// ioctl-gpio found the chip.
+func chardevLine(number int) edgeLine {
+	for _, label := range []string{"pinctrl-bcm2711", "pinctrl-bcm2835"} {
+		if c := gpioioctl.ChipByLabel(label); c != nil {
+			if l := c.ByNumber(number); l != nil {
+				return chardevEdge{l}
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
 // driverGPIO implements periph.Driver.
 type driverGPIO struct {
 	// baseAddr is the base for all the CPU registers.
@@ -1270,8 +1330,10 @@ func (d *driverGPIO) Prerequisites() []string {
 	return nil
 }
 
+// After returns sysfs-gpio and ioctl-gpio, which are used for edge
+// detection.
 func (d *driverGPIO) After() []string {
-	return []string{"sysfs-gpio"}
+	return []string{"sysfs-gpio", "ioctl-gpio"}
 }
 
 func (d *driverGPIO) Init() (bool, error) {
@@ -1324,6 +1386,7 @@ func (d *driverGPIO) Init() (bool, error) {
 
 		// Initializes the sysfs corresponding pin right away.
 		cpuPins[i].sysfsPin = sysfs.Pins[cpuPins[i].number]
+		cpuPins[i].chardevLine = chardevLine(cpuPins[i].number)
 
 		// Unregister the pin if already registered. This happens with sysfs-gpio.
 		// Do not error on it, since sysfs-gpio may have failed to load.
@@ -1429,7 +1492,7 @@ func setSpeed(f physic.Frequency) error {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drvGPIO)
+		hostcfg.MustRegister(&drvGPIO)
 	}
 }
 
@@ -7,7 +7,10 @@ package bcm283x
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/s-mobi01/host/pmem"
+	"github.com/s-mobi01/host/videocore"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpiostream"
 	"periph.io/x/conn/v3/i2c"
@@ -15,8 +18,6 @@ import (
 	"periph.io/x/conn/v3/pin"
 	"periph.io/x/conn/v3/spi"
 	"periph.io/x/conn/v3/uart"
-	"periph.io/x/host/v3/pmem"
-	"periph.io/x/host/v3/videocore"
 )
 
 func TestPresent(t *testing.T) {
@@ -168,6 +169,39 @@ func TestPin(t *testing.T) {
 	}
 }
 
+func TestPin_EdgeChardev(t *testing.T) {
+	l := &fakeEdgeLine{}
+	p := Pin{name: "Foo", number: 17, chardevLine: l}
+	if err := p.In(gpio.PullUp, gpio.RisingEdge); err != nil {
+		t.Fatal(err)
+	}
+	// The pull is set through the registers.
+	if l.edge != gpio.RisingEdge || l.pull != gpio.PullNoChange {
+		t.Fatal(l)
+	}
+	l.events = 1
+	if !p.WaitForEdge(0) || p.WaitForEdge(0) {
+		t.Fatal("expected a single edge")
+	}
+	// The line is released when the pin is driven.
+	if err := p.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if !l.halted || p.usingEdge {
+		t.Fatal("edge detection not stopped")
+	}
+	if p.WaitForEdge(0) {
+		t.Fatal("not waiting for edges")
+	}
+}
+
+func TestPin_EdgeNoLine(t *testing.T) {
+	p := Pin{name: "Foo", number: 17}
+	if err := p.In(gpio.PullNoChange, gpio.BothEdges); err == nil {
+		t.Fatal("no sysfs nor chardev line")
+	}
+}
+
 func TestPin_SetFunc_25(t *testing.T) {
 	p := Pin{name: "Foo", number: 25, defaultPull: gpio.PullDown}
 	p.setFunction(alt0)
@@ -332,6 +366,33 @@ func init() {
 	reset()
 }
 
+type fakeEdgeLine struct {
+	pull   gpio.Pull
+	edge   gpio.Edge
+	events int
+	halted bool
+}
+
+func (f *fakeEdgeLine) In(pull gpio.Pull, edge gpio.Edge) error {
+	f.pull = pull
+	f.edge = edge
+	f.halted = false
+	return nil
+}
+
+func (f *fakeEdgeLine) WaitForEdge(timeout time.Duration) bool {
+	if f.events == 0 {
+		return false
+	}
+	f.events--
+	return true
+}
+
+func (f *fakeEdgeLine) Halt() error {
+	f.halted = true
+	return nil
+}
+
 func reset() {
 	drvGPIO.Close()
 	drvDMA.Close()
@@ -0,0 +1,56 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/pin"
+)
+
+// PowerUpState is the state of a pin at reset, from power up until the
+// firmware or the kernel configures it, as per the datasheet.
+//
+// The firmware applies the gpio= lines and the overlays of config.txt and the
+// kernel the device tree before any program runs, so a pin only keeps this
+// state until then unless the board configuration leaves it alone.
+type PowerUpState struct {
+	// Func is the function at reset; all the pins are inputs.
+	Func pin.Func
+	// Pull is the pull resistor at reset.
+	Pull gpio.Pull
+	// Note describes the known activity on the pin while booting, if any.
+	Note string
+}
+
+// Level returns the level an unconnected pin settles at after reset, and false
+// if the pin floats.
+//
+// A pin is safe for an active high enable line when it returns gpio.Low and
+// true, and for an active low one when it returns gpio.High and true. An
+// external resistor stronger than the internal one, around 50kΩ, is still
+// recommended.
+func (s PowerUpState) Level() (gpio.Level, bool) {
+	switch s.Pull {
+	case gpio.PullUp:
+		return gpio.High, true
+	case gpio.PullDown:
+		return gpio.Low, true
+	default:
+		return gpio.Low, false
+	}
+}
+
+// PowerUpState returns the state of the pin at reset.
+func (p *Pin) PowerUpState() PowerUpState {
+	return PowerUpState{Func: gpio.IN, Pull: p.defaultPull, Note: bootNotes[p.number]}
+}
+
+//
+
+// bootNotes is the known activity on the pins while booting a Raspberry Pi.
+var bootNotes = map[int]string{
+	0: "ID_SD: read as I2C by the firmware to probe the HAT EEPROM",
+	1: "ID_SC: clocked as I2C by the firmware to probe the HAT EEPROM",
+}
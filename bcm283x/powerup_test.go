@@ -0,0 +1,34 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+func TestPin_PowerUpState(t *testing.T) {
+	data := []struct {
+		p    *Pin
+		pull gpio.Pull
+		l    gpio.Level
+		ok   bool
+		note bool
+	}{
+		{&cpuPins[1], gpio.PullUp, gpio.High, true, true},
+		{&cpuPins[17], gpio.PullDown, gpio.Low, true, false},
+		{&cpuPins[28], gpio.Float, gpio.Low, false, false},
+	}
+	for i, line := range data {
+		s := line.p.PowerUpState()
+		if s.Func != gpio.IN || s.Pull != line.pull || (s.Note != "") != line.note {
+			t.Fatalf("#%d: %#v", i, s)
+		}
+		if l, ok := s.Level(); l != line.l || ok != line.ok {
+			t.Fatalf("#%d: %s %t", i, l, ok)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// OpenRTC returns the real time clock of the Raspberry Pi 5.
+//
+// The clock is kept running by a battery connected to the J5 connector while
+// the board is unpowered. It is accessed through the kernel rpi-rtc driver.
+func OpenRTC() (*RTC, error) {
+	items, err := filepath.Glob(rtcClass + "rtc*")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if b, err := ioutil.ReadFile(filepath.Join(item, "name")); err == nil && strings.HasPrefix(string(b), "rpi-rtc") {
+			return &RTC{root: item, name: filepath.Base(item)}, nil
+		}
+	}
+	return nil, errors.New("bcm283x-rtc: rpi-rtc not found; is this a Raspberry Pi 5?")
+}
+
+// RTC is the real time clock of the Raspberry Pi 5.
+//
+// The system clock is synchronized from it at boot by the kernel.
+type RTC struct {
+	root string
+	name string
+}
+
+func (r *RTC) String() string {
+	return "bcm283x-rtc(" + r.name + ")"
+}
+
+// Time returns the time of the clock.
+func (r *RTC) Time() (time.Time, error) {
+	s, err := r.readInt("since_epoch")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(s, 0), nil
+}
+
+// RTCCharging is the trickle charging state of the battery of the RTC.
+type RTCCharging struct {
+	// Voltage is the charging voltage, or 0 when charging is disabled.
+	Voltage physic.ElectricPotential
+	// Min and Max are the charging voltages supported.
+	Min physic.ElectricPotential
+	Max physic.ElectricPotential
+}
+
+// Charging returns the charging state of the battery.
+//
+// Charging is disabled by default, as required by non rechargeable lithium
+// cells. It is configured at boot by the firmware, see ChargingParam.
+func (r *RTC) Charging() (RTCCharging, error) {
+	var c RTCCharging
+	for _, f := range []struct {
+		name string
+		v    *physic.ElectricPotential
+	}{
+		{"charging_voltage", &c.Voltage},
+		{"charging_voltage_min", &c.Min},
+		{"charging_voltage_max", &c.Max},
+	} {
+		uv, err := r.readInt(f.name)
+		if err != nil {
+			return RTCCharging{}, err
+		}
+		*f.v = physic.ElectricPotential(uv) * physic.MicroVolt
+	}
+	return c, nil
+}
+
+// ChargingParam returns the config.txt line to charge the battery at v, or
+// to disable charging when v is 0. It takes effect at the next boot.
+//
+// Only enable charging with a rechargeable battery, like the official
+// lithium-manganese cell.
+func (r *RTC) ChargingParam(v physic.ElectricPotential) (string, error) {
+	if v != 0 {
+		c, err := r.Charging()
+		if err != nil {
+			return "", err
+		}
+		if v < c.Min || v > c.Max {
+			return "", fmt.Errorf("bcm283x-rtc: charging voltage %s out of range [%s, %s]", v, c.Min, c.Max)
+		}
+	}
+	return "dtparam=rtc_bbat_vchg=" + strconv.FormatInt(int64(v/physic.MicroVolt), 10), nil
+}
+
+// WakeAlarm returns the time the board is set to wake up at, or the zero
+// time if no alarm is set.
+func (r *RTC) WakeAlarm() (time.Time, error) {
+	b, err := ioutil.ReadFile(filepath.Join(r.root, "wakealarm"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("bcm283x-rtc: %v", err)
+	}
+	s := strings.TrimSpace(string(b))
+	if s == "" {
+		return time.Time{}, nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("bcm283x-rtc: wakealarm: %v", err)
+	}
+	return time.Unix(i, 0), nil
+}
+
+// SetWakeAlarm sets the board to wake up at t, replacing the previous alarm.
+// The zero time clears the alarm.
+//
+// For the board to wake up after a shutdown, the bootloader EEPROM must have
+// POWER_OFF_ON_HALT=1 and WAKE_ON_GPIO=0, so the power management chip is
+// turned off on halt.
+func (r *RTC) SetWakeAlarm(t time.Time) error {
+	// The kernel refuses to replace an alarm that is set; clear it first.
+	if err := r.write("wakealarm", "0"); err != nil {
+		return err
+	}
+	if t.IsZero() {
+		return nil
+	}
+	if !t.After(time.Now()) {
+		return fmt.Errorf("bcm283x-rtc: wake alarm %s is in the past", t)
+	}
+	return r.write("wakealarm", strconv.FormatInt(t.Unix(), 10))
+}
+
+// OpenPowerButton returns the power button of the Raspberry Pi 5.
+//
+// The button is also handled by systemd-logind, which shuts down the system
+// when it is pressed; set HandlePowerKey=ignore in /etc/systemd/logind.conf
+// to handle it in the application instead. Holding the button down for a few
+// seconds forces a power off regardless.
+func OpenPowerButton() (*PowerButton, error) {
+	items, err := filepath.Glob(inputClass + "event*")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		b, err := ioutil.ReadFile(filepath.Join(item, "device", "name"))
+		if err != nil || strings.TrimSpace(string(b)) != "pwr_button" {
+			continue
+		}
+		name := filepath.Base(item)
+		f, err := os.Open(inputDev + name)
+		if err != nil {
+			return nil, fmt.Errorf("bcm283x-button: %v", err)
+		}
+		p := &PowerButton{
+			name: name,
+			f:    f,
+			c:    make(chan PowerButtonEvent, 16),
+		}
+		p.wg.Add(1)
+		go p.run()
+		return p, nil
+	}
+	return nil, errors.New("bcm283x-button: pwr_button not found; is this a Raspberry Pi 5?")
+}
+
+// PowerButtonEvent is a press or release of the power button.
+type PowerButtonEvent struct {
+	// Pressed is true when the button was pressed and false when released.
+	Pressed bool
+	// Time is the time of the event according to the system clock.
+	Time time.Time
+}
+
+func (e PowerButtonEvent) String() string {
+	s := "Released"
+	if e.Pressed {
+		s = "Pressed"
+	}
+	return s + " " + e.Time.Format(time.RFC3339Nano)
+}
+
+// PowerButton is the power button of the Raspberry Pi 5.
+type PowerButton struct {
+	name string
+	f    io.ReadCloser
+	c    chan PowerButtonEvent
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+func (p *PowerButton) String() string {
+	return "bcm283x-button(" + p.name + ")"
+}
+
+// Events returns a channel receiving the presses and releases of the button.
+//
+// The channel is closed by Close. The events are dropped if the channel is not
+// drained.
+func (p *PowerButton) Events() <-chan PowerButtonEvent {
+	return p.c
+}
+
+// Close stops the events and closes the input device.
+func (p *PowerButton) Close() error {
+	var err error
+	p.once.Do(func() {
+		err = p.f.Close()
+		p.wg.Wait()
+	})
+	if err != nil {
+		return fmt.Errorf("bcm283x-button: %v", err)
+	}
+	return nil
+}
+
+//
+
+var (
+	rtcClass   = "/sys/class/rtc/"
+	inputClass = "/sys/class/input/"
+	inputDev   = "/dev/input/"
+)
+
+func (r *RTC) readInt(name string) (int64, error) {
+	b, err := ioutil.ReadFile(filepath.Join(r.root, name))
+	if err != nil {
+		return 0, fmt.Errorf("bcm283x-rtc: %v", err)
+	}
+	i, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bcm283x-rtc: %s: %v", name, err)
+	}
+	return i, nil
+}
+
+func (r *RTC) write(name, v string) error {
+	if err := ioutil.WriteFile(filepath.Join(r.root, name), []byte(v), 0o644); err != nil {
+		return fmt.Errorf("bcm283x-rtc: %v", err)
+	}
+	return nil
+}
+
+// Linux input event codes.
+//
+// https://www.kernel.org/doc/html/latest/input/event-codes.html
+const (
+	evKey    = 0x01
+	keyPower = 116
+)
+
+// inputEventSize is the size of struct input_event: a struct timeval of two
+// longs, then the type, code and value.
+const inputEventSize = 2*int(unsafe.Sizeof(uintptr(0))) + 8
+
+// run sends the events until the device is closed.
+func (p *PowerButton) run() {
+	defer p.wg.Done()
+	defer close(p.c)
+	buf := make([]byte, 16*inputEventSize)
+	for {
+		n, err := io.ReadAtLeast(p.f, buf, inputEventSize)
+		if err != nil {
+			return
+		}
+		for b := buf[:n-n%inputEventSize]; len(b) != 0; b = b[inputEventSize:] {
+			if e, ok := parseInputEvent(b[:inputEventSize]); ok {
+				select {
+				case p.c <- e:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// parseInputEvent returns the power button event in b, a struct input_event.
+// The auto-repeat events are ignored.
+func parseInputEvent(b []byte) (PowerButtonEvent, bool) {
+	var sec, usec int64
+	l := len(b) - 8
+	if l == 16 {
+		sec = int64(binary.LittleEndian.Uint64(b))
+		usec = int64(binary.LittleEndian.Uint64(b[8:]))
+	} else {
+		sec = int64(int32(binary.LittleEndian.Uint32(b)))
+		usec = int64(int32(binary.LittleEndian.Uint32(b[4:])))
+	}
+	typ := binary.LittleEndian.Uint16(b[l:])
+	code := binary.LittleEndian.Uint16(b[l+2:])
+	value := int32(binary.LittleEndian.Uint32(b[l+4:]))
+	if typ != evKey || code != keyPower || value > 1 {
+		return PowerButtonEvent{}, false
+	}
+	return PowerButtonEvent{Pressed: value == 1, Time: time.Unix(sec, usec*1000)}, true
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestRTC(t *testing.T) {
+	root, err := ioutil.TempDir("", "bcm283x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	defer func(s string) {
+		rtcClass = s
+	}(rtcClass)
+	rtcClass = root + "/"
+	if _, err := OpenRTC(); err == nil {
+		t.Fatal("no RTC")
+	}
+	writeFiles(t, filepath.Join(root, "rtc0"), map[string]string{"name": "rtc-ds1307 1-0068\n"})
+	writeFiles(t, filepath.Join(root, "rtc1"), map[string]string{
+		"name":                 "rpi-rtc soc:rpi_rtc\n",
+		"since_epoch":          "1790000000\n",
+		"charging_voltage":     "0\n",
+		"charging_voltage_min": "1300000\n",
+		"charging_voltage_max": "4400000\n",
+		"wakealarm":            "\n",
+	})
+	r, err := OpenRTC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := r.String(); s != "bcm283x-rtc(rtc1)" {
+		t.Fatal(s)
+	}
+	if now, err := r.Time(); err != nil || now.Unix() != 1790000000 {
+		t.Fatal(now, err)
+	}
+	want := RTCCharging{Min: 1300 * physic.MilliVolt, Max: 4400 * physic.MilliVolt}
+	if c, err := r.Charging(); err != nil || c != want {
+		t.Fatal(c, err)
+	}
+	if s, err := r.ChargingParam(3 * physic.Volt); err != nil || s != "dtparam=rtc_bbat_vchg=3000000" {
+		t.Fatal(s, err)
+	}
+	if _, err := r.ChargingParam(5 * physic.Volt); err == nil {
+		t.Fatal("out of range")
+	}
+	if s, err := r.ChargingParam(0); err != nil || s != "dtparam=rtc_bbat_vchg=0" {
+		t.Fatal(s, err)
+	}
+
+	if a, err := r.WakeAlarm(); err != nil || !a.IsZero() {
+		t.Fatal(a, err)
+	}
+	wake := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := r.SetWakeAlarm(wake); err != nil {
+		t.Fatal(err)
+	}
+	if a, err := r.WakeAlarm(); err != nil || !a.Equal(wake) {
+		t.Fatal(a, err)
+	}
+	if err := r.SetWakeAlarm(time.Now().Add(-time.Hour)); err == nil {
+		t.Fatal("in the past")
+	}
+	if err := r.SetWakeAlarm(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(root, "rtc1", "wakealarm")); string(b) != "0" {
+		t.Fatalf("%q", b)
+	}
+}
+
+func TestPowerButton(t *testing.T) {
+	root, err := ioutil.TempDir("", "bcm283x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	defer func(c, d string) {
+		inputClass = c
+		inputDev = d
+	}(inputClass, inputDev)
+	inputClass = filepath.Join(root, "class") + "/"
+	inputDev = root + "/"
+	if _, err := OpenPowerButton(); err == nil {
+		t.Fatal("no button")
+	}
+	writeFiles(t, filepath.Join(root, "class", "event0", "device"), map[string]string{"name": "vc4-hdmi-0\n"})
+	writeFiles(t, filepath.Join(root, "class", "event1", "device"), map[string]string{"name": "pwr_button\n"})
+	var events []byte
+	events = append(events, inputEvent(100, 5, evKey, keyPower, 1)...)
+	// Synchronization and auto-repeat events are ignored.
+	events = append(events, inputEvent(100, 5, 0, 0, 0)...)
+	events = append(events, inputEvent(100, 600000, evKey, keyPower, 2)...)
+	events = append(events, inputEvent(101, 0, evKey, keyPower, 0)...)
+	if err := ioutil.WriteFile(filepath.Join(root, "event1"), events, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := OpenPowerButton()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := p.String(); s != "bcm283x-button(event1)" {
+		t.Fatal(s)
+	}
+	var got []PowerButtonEvent
+	// The channel is closed at the end of the file.
+	for e := range p.Events() {
+		got = append(got, e)
+	}
+	want := []PowerButtonEvent{
+		{Pressed: true, Time: time.Unix(100, 5000)},
+		{Pressed: false, Time: time.Unix(101, 0)},
+	}
+	if len(got) != len(want) {
+		t.Fatal(got)
+	}
+	for i := range want {
+		if got[i].Pressed != want[i].Pressed || !got[i].Time.Equal(want[i].Time) {
+			t.Fatalf("#%d: %s != %s", i, got[i], want[i])
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// inputEvent returns a struct input_event for the native word size.
+func inputEvent(sec, usec int64, typ, code uint16, value int32) []byte {
+	b := make([]byte, inputEventSize)
+	l := inputEventSize - 8
+	if l == 16 {
+		binary.LittleEndian.PutUint64(b, uint64(sec))
+		binary.LittleEndian.PutUint64(b[8:], uint64(usec))
+	} else {
+		binary.LittleEndian.PutUint32(b, uint32(sec))
+		binary.LittleEndian.PutUint32(b[4:], uint32(usec))
+	}
+	binary.LittleEndian.PutUint16(b[l:], typ)
+	binary.LittleEndian.PutUint16(b[l+2:], code)
+	binary.LittleEndian.PutUint32(b[l+4:], uint32(value))
+	return b
+}
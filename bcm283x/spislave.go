@@ -0,0 +1,257 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// The BSC slave is an I²C/SPI slave controller. Only the SPI mode is
+// supported.
+
+package bcm283x
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/s-mobi01/host/pinuse"
+	"github.com/s-mobi01/host/pmem"
+	"github.com/s-mobi01/host/spislave"
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/spi"
+)
+
+// NewSPISlave returns the BSC slave controller in SPI mode.
+//
+// The pins are switched to their BSC slave function: GPIO18 (MOSI), GPIO19
+// (CLK), GPIO20 (MISO) and GPIO21 (CS) on BCM2835/6/7, GPIO10 (MOSI), GPIO11
+// (CLK), GPIO9 (MISO) and GPIO8 (CS) on BCM2711. Only 8 bits words, MSB first,
+// are supported and the FIFOs are 16 bytes deep, so the master shouldn't clock
+// faster than the process can poll them.
+//
+// Requires access to /dev/mem, normally running as root.
+func NewSPISlave() (*SPISlave, error) {
+	if drvGPIO.gpioMemory == nil {
+		return nil, errors.New("bcm283x-spislave: subsystem gpiomem not initialized")
+	}
+	s := &SPISlave{}
+	// baseAddr is initialized by the driver bcm283x-gpio.
+	if err := pmem.MapAsPOD(uint64(drvGPIO.baseAddr+0x214000), &s.m); err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("bcm283x-spislave: need more access, try as root: %v", err)
+		}
+		return nil, fmt.Errorf("bcm283x-spislave: %v", err)
+	}
+	pins := []int{18, 19, 20, 21}
+	if !drvGPIO.useLegacyPull {
+		// The controller was moved on BCM2711.
+		pins = []int{8, 9, 10, 11}
+	}
+	claimed := make([]pin.Pin, 0, len(pins))
+	for _, n := range pins {
+		claimed = append(claimed, &cpuPins[n])
+	}
+	if err := pinuse.Claim(s.String(), claimed...); err != nil {
+		return nil, fmt.Errorf("bcm283x-spislave: %v", err)
+	}
+	for _, n := range pins {
+		p := &cpuPins[n]
+		if err := p.Halt(); err != nil {
+			pinuse.Release(s.String(), claimed...)
+			return nil, err
+		}
+		p.setFunction(alt3)
+		s.pins = append(s.pins, p)
+	}
+	s.m.cr = 0
+	return s, nil
+}
+
+// SPISlave is the BSC slave controller in SPI mode.
+//
+// It implements spislave.PortCloser. The resulting object is safe for
+// concurrent use.
+type SPISlave struct {
+	closed int32
+
+	mu        sync.Mutex
+	m         *spiSlaveMap
+	pins      []*Pin
+	connected bool
+}
+
+// Close disables the controller and switches the pins back to input.
+//
+// A pending Tx is aborted.
+func (s *SPISlave) Close() error {
+	atomic.StoreInt32(&s.closed, 1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		return nil
+	}
+	s.m.cr = 0
+	s.m = nil
+	for _, p := range s.pins {
+		p.setFunction(in)
+		pinuse.Release(s.String(), p)
+	}
+	return nil
+}
+
+func (s *SPISlave) String() string {
+	return "bcm283x-spislave"
+}
+
+// Connect implements spislave.Port.
+//
+// Only 8 bits words, MSB first, are supported.
+func (s *SPISlave) Connect(mode spi.Mode, bits int) (conn.Conn, error) {
+	if mode&^spi.Mode3 != 0 {
+		return nil, fmt.Errorf("bcm283x-spislave: invalid mode %v", mode)
+	}
+	if bits != 8 {
+		return nil, fmt.Errorf("bcm283x-spislave: invalid bits %d; only 8 is supported", bits)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		return nil, errors.New("bcm283x-spislave: port is closed")
+	}
+	if s.connected {
+		return nil, errors.New("bcm283x-spislave: Connect() can only be called exactly once")
+	}
+	s.connected = true
+	cr := uint32(bscSlEnable | bscSlSPI | bscSlTXEnable | bscSlRXEnable)
+	if mode&1 != 0 {
+		cr |= bscSlCPHA
+	}
+	if mode&2 != 0 {
+		cr |= bscSlCPOL
+	}
+	// Flush the FIFOs and clear the errors before enabling.
+	s.m.cr = bscSlBreak
+	s.m.rsr = 0
+	s.m.cr = cr
+	return &spiSlaveConn{s}, nil
+}
+
+//
+
+// spiSlaveConn implements conn.Conn.
+type spiSlaveConn struct {
+	s *SPISlave
+}
+
+func (c *spiSlaveConn) String() string {
+	return c.s.String()
+}
+
+// Tx implements conn.Conn.
+//
+// It polls the FIFOs until the master has clocked len(w) or len(r) bytes, or
+// Close is called. Zeros are sent when w is nil.
+func (c *spiSlaveConn) Tx(w, r []byte) error {
+	l := len(w)
+	if l == 0 {
+		if l = len(r); l == 0 {
+			return errors.New("bcm283x-spislave: Tx() with empty buffers")
+		}
+	} else if len(r) != 0 && len(r) != l {
+		return fmt.Errorf("bcm283x-spislave: Tx(): when both w and r are used, they must be the same size; got %d and %d bytes", len(w), len(r))
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		return errors.New("bcm283x-spislave: port is closed")
+	}
+	for i, j := 0, 0; j < l; {
+		if atomic.LoadInt32(&s.closed) != 0 {
+			return errors.New("bcm283x-spislave: Tx() aborted by Close()")
+		}
+		idle := true
+		for ; i < l && s.m.fr&bscSlTXFull == 0; i++ {
+			var b byte
+			if len(w) != 0 {
+				b = w[i]
+			}
+			s.m.dr = uint32(b)
+			idle = false
+		}
+		for ; j < l && s.m.fr&bscSlRXEmpty == 0; j++ {
+			v := s.m.dr
+			if len(r) != 0 {
+				r[j] = byte(v)
+			}
+			idle = false
+		}
+		if idle {
+			runtime.Gosched()
+		}
+	}
+	if rsr := s.m.rsr; rsr&(bscSlOverrun|bscSlUnderrun) != 0 {
+		s.m.rsr = 0
+		if rsr&bscSlOverrun != 0 {
+			return errors.New("bcm283x-spislave: Tx(): RX FIFO overrun")
+		}
+		return errors.New("bcm283x-spislave: Tx(): TX FIFO underrun")
+	}
+	return nil
+}
+
+// Duplex implements conn.Conn.
+func (c *spiSlaveConn) Duplex() conn.Duplex {
+	return conn.Full
+}
+
+// Page 160-170.
+const (
+	// CR
+	bscSlEnable   = 1 << 0 // EN Enable the device
+	bscSlSPI      = 1 << 1 // SPI Enable the SPI mode
+	bscSlI2C      = 1 << 2 // I2C Enable the I2C mode
+	bscSlCPHA     = 1 << 3 // CPHA Clock phase
+	bscSlCPOL     = 1 << 4 // CPOL Clock polarity
+	bscSlBreak    = 1 << 7 // BRK Stop the operation and clear the FIFOs
+	bscSlTXEnable = 1 << 8 // TXE Enable the transmitter
+	bscSlRXEnable = 1 << 9 // RXE Enable the receiver
+
+	// FR
+	bscSlTXBusy  = 1 << 0 // TXBUSY Transmit operation in progress
+	bscSlRXEmpty = 1 << 1 // RXFE RX FIFO is empty
+	bscSlTXFull  = 1 << 2 // TXFF TX FIFO is full
+	bscSlRXFull  = 1 << 3 // RXFF RX FIFO is full
+	bscSlTXEmpty = 1 << 4 // TXFE TX FIFO is empty
+
+	// RSR
+	bscSlOverrun  = 1 << 0 // OE RX FIFO overrun
+	bscSlUnderrun = 1 << 1 // UE TX FIFO underrun
+)
+
+// spiSlaveMap is the BSC slave registers.
+//
+// Page 160.
+type spiSlaveMap struct {
+	dr      uint32 // DR Data; the read also has the status in the high bits
+	rsr     uint32 // RSR Operation status and errors
+	slv     uint32 // SLV I2C slave address
+	cr      uint32 // CR Control
+	fr      uint32 // FR Flags
+	ifls    uint32 // IFLS Interrupt FIFO level select
+	imsc    uint32 // IMSC Interrupt mask set clear
+	ris     uint32 // RIS Raw interrupt status
+	mis     uint32 // MIS Masked interrupt status
+	icr     uint32 // ICR Interrupt clear
+	dmacr   uint32 // DMACR DMA control
+	tdr     uint32 // TDR FIFO test data
+	gpustat uint32 // GPUSTAT GPU status
+	hctrl   uint32 // HCTRL Host control
+	debug1  uint32 // DEBUG1 I2C debug
+	debug2  uint32 // DEBUG2 SPI debug
+}
+
+var _ spislave.PortCloser = &SPISlave{}
+var _ conn.Conn = &spiSlaveConn{}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/spi"
+)
+
+func TestSPISlave_Connect(t *testing.T) {
+	s := SPISlave{m: &spiSlaveMap{rsr: bscSlOverrun}}
+	if _, err := s.Connect(spi.LSBFirst, 8); err == nil {
+		t.Fatal("LSBFirst is not supported")
+	}
+	if _, err := s.Connect(spi.Mode0, 16); err == nil {
+		t.Fatal("only 8 bits is supported")
+	}
+	c, err := s.Connect(spi.Mode3, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.m.cr != bscSlEnable|bscSlSPI|bscSlTXEnable|bscSlRXEnable|bscSlCPHA|bscSlCPOL {
+		t.Fatalf("cr = %#x", s.m.cr)
+	}
+	if s.m.rsr != 0 {
+		t.Fatal("errors not cleared")
+	}
+	if _, err := s.Connect(spi.Mode3, 8); err == nil {
+		t.Fatal("second Connect")
+	}
+	if str := c.String(); str != "bcm283x-spislave" {
+		t.Fatal(str)
+	}
+}
+
+func TestSPISlave_Tx(t *testing.T) {
+	// The flags say that the TX FIFO is never full and the RX FIFO never empty.
+	m := &spiSlaveMap{dr: 0x5A}
+	s := SPISlave{m: m}
+	c, err := s.Connect(spi.Mode0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Tx(nil, nil); err == nil {
+		t.Fatal("empty buffers")
+	}
+	if err := c.Tx([]byte{1}, []byte{1, 2}); err == nil {
+		t.Fatal("different lengths")
+	}
+	r := make([]byte, 3)
+	if err := c.Tx(nil, r); err != nil {
+		t.Fatal(err)
+	}
+	// The TX FIFO is filled before the RX FIFO is read, so zeros were read
+	// back from the fake data register.
+	if !bytes.Equal(r, []byte{0, 0, 0}) {
+		t.Fatalf("r = %#v", r)
+	}
+	if err := c.Tx([]byte{1, 2, 3}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{3, 3, 3}) || m.dr != 3 {
+		t.Fatalf("r = %#v", r)
+	}
+	m.rsr = bscSlUnderrun
+	if err := c.Tx([]byte{1}, nil); err == nil {
+		t.Fatal("underrun")
+	}
+	if m.rsr != 0 {
+		t.Fatal("errors not cleared")
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if m.cr != 0 {
+		t.Fatalf("cr = %#x", m.cr)
+	}
+	if err := c.Tx([]byte{1}, nil); err == nil {
+		t.Fatal("closed")
+	}
+}
+
+func TestNewSPISlave(t *testing.T) {
+	defer func(m *gpioMap) { drvGPIO.gpioMemory = m }(drvGPIO.gpioMemory)
+	drvGPIO.gpioMemory = nil
+	if _, err := NewSPISlave(); err == nil {
+		t.Fatal("gpio not initialized")
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"errors"
+	"runtime"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+// ErrPreempted is returned by TimedPin when the thread was preempted long
+// enough to break the timing of the operation. The transfer should be
+// retried, which Run does.
+var ErrPreempted = errors.New("bcm283x: preempted during a timed operation")
+
+// TimedPin bit-bangs an open drain line with microsecond accuracy by
+// busy-waiting on the 1MHz system timer, e.g. for 1-Wire or DHT22.
+//
+// The line is either driven low or released as an input and pulled high by
+// a resistor, so the device can drive it low in turn.
+//
+// Each wait is checked against the system timer; if it overshot by more than
+// the slack, typically because the kernel scheduled another thread, the
+// operation returns ErrPreempted.
+//
+// It requires both bcm283x-gpio with /dev/gpiomem and bcm283x-dma for the
+// system timer, the latter needs root level access.
+type TimedPin struct {
+	p     *Pin
+	slack time.Duration
+	now   func() time.Duration
+}
+
+// NewTimedPin returns a TimedPin over p. slack is the tolerated overshoot of
+// each wait; 0 defaults to 10µs.
+//
+// The pin is released as an input without changing its pull.
+func NewTimedPin(p *Pin, slack time.Duration) (*TimedPin, error) {
+	if drvGPIO.gpioMemory == nil {
+		return nil, p.wrap(errors.New("subsystem gpiomem not initialized"))
+	}
+	if drvDMA.timerMemory == nil {
+		return nil, p.wrap(errors.New("system timer not initialized; bcm283x-dma requires root"))
+	}
+	if slack <= 0 {
+		slack = 10 * time.Microsecond
+	}
+	if err := p.Halt(); err != nil {
+		return nil, err
+	}
+	p.FastOut(gpio.Low)
+	p.setFunction(in)
+	return &TimedPin{p: p, slack: slack, now: ReadTime}, nil
+}
+
+func (t *TimedPin) String() string {
+	return t.p.String()
+}
+
+// Run runs f with the goroutine locked to its OS thread, retrying up to
+// retries times when f returns ErrPreempted.
+//
+// Locking the thread avoids the Go scheduler migrating the goroutine in the
+// middle of a transfer. To also reduce the kernel preemption, dedicate a
+// goroutine to the transfers and call cpu.SetHighPriority from it.
+func (t *TimedPin) Run(retries int, f func() error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	for i := 0; ; i++ {
+		err := f()
+		if err != ErrPreempted || i >= retries {
+			return err
+		}
+		t.Release()
+	}
+}
+
+// Delay busy-waits for d.
+func (t *TimedPin) Delay(d time.Duration) error {
+	return t.spin(t.now(), d)
+}
+
+// Pulse drives the line low for d, then releases it.
+func (t *TimedPin) Pulse(d time.Duration) error {
+	t.Low()
+	err := t.spin(t.now(), d)
+	t.Release()
+	return err
+}
+
+// Low drives the line low.
+func (t *TimedPin) Low() {
+	t.p.setFunction(out)
+}
+
+// Release stops driving the line so it is pulled high unless a device drives
+// it low.
+func (t *TimedPin) Release() {
+	t.p.setFunction(in)
+}
+
+// Read returns the current level of the line.
+func (t *TimedPin) Read() gpio.Level {
+	return t.p.FastRead()
+}
+
+// SampleAfter busy-waits for d then returns the level of the line, e.g. to
+// sample a 1-Wire read slot.
+func (t *TimedPin) SampleAfter(d time.Duration) (gpio.Level, error) {
+	if err := t.spin(t.now(), d); err != nil {
+		return gpio.Low, err
+	}
+	return t.p.FastRead(), nil
+}
+
+// WaitFor busy-waits until the line is at level l and returns the time it
+// took, e.g. to measure the width of a DHT22 pulse.
+//
+// It returns an error if the level is not reached within timeout.
+func (t *TimedPin) WaitFor(l gpio.Level, timeout time.Duration) (time.Duration, error) {
+	start := t.now()
+	last := start
+	for {
+		now := t.now()
+		if t.p.FastRead() == l {
+			return now - start, nil
+		}
+		if now-last > t.slack {
+			return now - start, ErrPreempted
+		}
+		if now-start > timeout {
+			return now - start, t.p.wrap(errors.New("timed out waiting for level " + l.String()))
+		}
+		last = now
+	}
+}
+
+//
+
+// spin busy-waits until d elapsed since start, and reports if it overshot by
+// more than the slack.
+func (t *TimedPin) spin(start, d time.Duration) error {
+	for {
+		e := t.now() - start
+		if e >= d {
+			if e > d+t.slack {
+				return ErrPreempted
+			}
+			return nil
+		}
+	}
+}
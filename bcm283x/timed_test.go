@@ -0,0 +1,109 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bcm283x
+
+import (
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+func TestNewTimedPin(t *testing.T) {
+	defer reset()
+	p := Pin{name: "C1", number: 4, defaultPull: gpio.PullDown}
+	if _, err := NewTimedPin(&p, 0); err == nil || err.Error() != "bcm283x-gpio (C1): system timer not initialized; bcm283x-dma requires root" {
+		t.Fatal(err)
+	}
+	drvGPIO.gpioMemory = nil
+	if _, err := NewTimedPin(&p, 0); err == nil {
+		t.Fatal("gpiomem not initialized")
+	}
+}
+
+func TestTimedPin_Pulse(t *testing.T) {
+	defer reset()
+	tp := newTestTimedPin(t, time.Microsecond)
+	if err := tp.Pulse(5 * time.Microsecond); err != nil {
+		t.Fatal(err)
+	}
+	if f := tp.p.function(); f != in {
+		t.Fatal(f)
+	}
+	tp.Low()
+	if f := tp.p.function(); f != out {
+		t.Fatal(f)
+	}
+	if l, err := tp.SampleAfter(3 * time.Microsecond); err != nil || l != gpio.High {
+		t.Fatal(l, err)
+	}
+	// The thread was preempted for 50µs.
+	tp.now = newClock(50 * time.Microsecond)
+	if err := tp.Delay(5 * time.Microsecond); err != ErrPreempted {
+		t.Fatal(err)
+	}
+	if err := tp.Pulse(5 * time.Microsecond); err != ErrPreempted {
+		t.Fatal(err)
+	}
+	if f := tp.p.function(); f != in {
+		t.Fatal("must be released on failure")
+	}
+}
+
+func TestTimedPin_WaitFor(t *testing.T) {
+	defer reset()
+	tp := newTestTimedPin(t, time.Microsecond)
+	// GPIO4 is high in setMemory.
+	if d, err := tp.WaitFor(gpio.High, time.Millisecond); err != nil || d != time.Microsecond {
+		t.Fatal(d, err)
+	}
+	if _, err := tp.WaitFor(gpio.Low, 5*time.Microsecond); err == nil || err == ErrPreempted {
+		t.Fatal(err)
+	}
+	tp.now = newClock(50 * time.Microsecond)
+	if _, err := tp.WaitFor(gpio.Low, time.Millisecond); err != ErrPreempted {
+		t.Fatal(err)
+	}
+}
+
+func TestTimedPin_Run(t *testing.T) {
+	defer reset()
+	tp := newTestTimedPin(t, time.Microsecond)
+	n := 0
+	f := func() error {
+		n++
+		tp.Low()
+		return ErrPreempted
+	}
+	if err := tp.Run(2, f); err != ErrPreempted || n != 3 {
+		t.Fatal(err, n)
+	}
+	n = 0
+	if err := tp.Run(2, func() error { n++; return nil }); err != nil || n != 1 {
+		t.Fatal(err, n)
+	}
+}
+
+//
+
+// newTestTimedPin returns a TimedPin over GPIO4 with a clock advancing by
+// step on each read.
+func newTestTimedPin(t *testing.T, step time.Duration) *TimedPin {
+	drvDMA.timerMemory = &timerMap{}
+	tp, err := NewTimedPin(&Pin{name: "GPIO4", number: 4}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp.now = newClock(step)
+	return tp
+}
+
+func newClock(step time.Duration) func() time.Duration {
+	var now time.Duration
+	return func() time.Duration {
+		now += step
+		return now
+	}
+}
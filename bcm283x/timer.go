@@ -7,7 +7,7 @@ package bcm283x
 import (
 	"time"
 
-	"periph.io/x/host/v3/cpu"
+	"github.com/s-mobi01/host/cpu"
 )
 
 // ReadTime returns the time on a monotonic 1Mhz clock (1µs resolution).
@@ -0,0 +1,374 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package ai64 implements headers P8 and P9 found on the BeagleBone AI-64
+// micro-computer.
+//
+// The BeagleBone AI-64 is built around a TI TDA4VM (J721E) processor. The
+// headers are physically compatible with the BeagleBone Black but the pins are
+// routed to different GPIO controllers.
+//
+// Unlike on the AM335x, the sysfs GPIO numbers are not stable across kernel
+// versions, so the pins are looked up by GPIO controller and line offset.
+//
+// Reference
+//
+// https://beagleboard.org/ai-64
+//
+// Datasheet
+//
+// https://docs.beagleboard.org/latest/boards/beaglebone/ai-64/
+package ai64
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+)
+
+// Pin types found on the AI-64 headers.
+var (
+	PWR_BUT   = &pin.BasicPin{N: "PWR_BUT"}   //
+	RESET_OUT = &pin.BasicPin{N: "RESET_OUT"} // SYS_RESETn
+	VADC      = &pin.BasicPin{N: "VADC"}      // VDD_ADC, 1.8V
+	AGND      = &pin.BasicPin{N: "AGND"}      // GNDA_ADC
+	AIN0      = &pin.BasicPin{N: "AIN0"}      // AIN0
+	AIN1      = &pin.BasicPin{N: "AIN1"}      // AIN1
+	AIN2      = &pin.BasicPin{N: "AIN2"}      // AIN2
+	AIN3      = &pin.BasicPin{N: "AIN3"}      // AIN3
+	AIN4      = &pin.BasicPin{N: "AIN4"}      // AIN4
+	AIN5      = &pin.BasicPin{N: "AIN5"}      // AIN5
+	AIN6      = &pin.BasicPin{N: "AIN6"}      // AIN6
+)
+
+// Headers found on the BeagleBone AI-64.
+//
+// The comments list the main_gpio0 line and the default function.
+var (
+	P8_1  pin.Pin    = pin.GROUND
+	P8_2  pin.Pin    = pin.GROUND
+	P8_3  gpio.PinIO = gpio.INVALID // GPIO0_20
+	P8_4  gpio.PinIO = gpio.INVALID // GPIO0_48
+	P8_5  gpio.PinIO = gpio.INVALID // GPIO0_33
+	P8_6  gpio.PinIO = gpio.INVALID // GPIO0_34
+	P8_7  gpio.PinIO = gpio.INVALID // GPIO0_15
+	P8_8  gpio.PinIO = gpio.INVALID // GPIO0_14
+	P8_9  gpio.PinIO = gpio.INVALID // GPIO0_17
+	P8_10 gpio.PinIO = gpio.INVALID // GPIO0_16
+	P8_11 gpio.PinIO = gpio.INVALID // GPIO0_60
+	P8_12 gpio.PinIO = gpio.INVALID // GPIO0_59
+	P8_13 gpio.PinIO = gpio.INVALID // GPIO0_89, EHRPWM0B
+	P8_14 gpio.PinIO = gpio.INVALID // GPIO0_75
+	P8_15 gpio.PinIO = gpio.INVALID // GPIO0_61
+	P8_16 gpio.PinIO = gpio.INVALID // GPIO0_62
+	P8_17 gpio.PinIO = gpio.INVALID // GPIO0_3
+	P8_18 gpio.PinIO = gpio.INVALID // GPIO0_4
+	P8_19 gpio.PinIO = gpio.INVALID // GPIO0_88, EHRPWM0A
+	P8_20 gpio.PinIO = gpio.INVALID // GPIO0_76
+	P8_21 gpio.PinIO = gpio.INVALID // GPIO0_30
+	P8_22 gpio.PinIO = gpio.INVALID // GPIO0_5
+	P8_23 gpio.PinIO = gpio.INVALID // GPIO0_31
+	P8_24 gpio.PinIO = gpio.INVALID // GPIO0_6
+	P8_25 gpio.PinIO = gpio.INVALID // GPIO0_35
+	P8_26 gpio.PinIO = gpio.INVALID // GPIO0_51
+	P8_27 gpio.PinIO = gpio.INVALID // GPIO0_71
+	P8_28 gpio.PinIO = gpio.INVALID // GPIO0_72
+	P8_29 gpio.PinIO = gpio.INVALID // GPIO0_73
+	P8_30 gpio.PinIO = gpio.INVALID // GPIO0_74
+	P8_31 gpio.PinIO = gpio.INVALID // GPIO0_32
+	P8_32 gpio.PinIO = gpio.INVALID // GPIO0_26
+	P8_33 gpio.PinIO = gpio.INVALID // GPIO0_25
+	P8_34 gpio.PinIO = gpio.INVALID // GPIO0_7, EHRPWM1B
+	P8_35 gpio.PinIO = gpio.INVALID // GPIO0_24
+	P8_36 gpio.PinIO = gpio.INVALID // GPIO0_8, EHRPWM1A
+	P8_37 gpio.PinIO = gpio.INVALID // GPIO0_106
+	P8_38 gpio.PinIO = gpio.INVALID // GPIO0_105
+	P8_39 gpio.PinIO = gpio.INVALID // GPIO0_69
+	P8_40 gpio.PinIO = gpio.INVALID // GPIO0_70
+	P8_41 gpio.PinIO = gpio.INVALID // GPIO0_67
+	P8_42 gpio.PinIO = gpio.INVALID // GPIO0_68
+	P8_43 gpio.PinIO = gpio.INVALID // GPIO0_65
+	P8_44 gpio.PinIO = gpio.INVALID // GPIO0_66
+	P8_45 gpio.PinIO = gpio.INVALID // GPIO0_79
+	P8_46 gpio.PinIO = gpio.INVALID // GPIO0_80
+
+	P9_1  pin.Pin    = pin.GROUND
+	P9_2  pin.Pin    = pin.GROUND
+	P9_3  pin.Pin    = pin.V3_3
+	P9_4  pin.Pin    = pin.V3_3
+	P9_5  pin.Pin    = pin.V5
+	P9_6  pin.Pin    = pin.V5
+	P9_7  pin.Pin    = pin.V5
+	P9_8  pin.Pin    = pin.V5
+	P9_9  pin.Pin    = PWR_BUT      // PWR_BUT
+	P9_10 pin.Pin    = RESET_OUT    // SYS_RESETn
+	P9_11 gpio.PinIO = gpio.INVALID // GPIO0_1, UART
+	P9_12 gpio.PinIO = gpio.INVALID // GPIO0_45
+	P9_13 gpio.PinIO = gpio.INVALID // GPIO0_2, UART
+	P9_14 gpio.PinIO = gpio.INVALID // GPIO0_93, EHRPWM4A
+	P9_15 gpio.PinIO = gpio.INVALID // GPIO0_47
+	P9_16 gpio.PinIO = gpio.INVALID // GPIO0_94, EHRPWM4B
+	P9_17 gpio.PinIO = gpio.INVALID // GPIO0_28, I2C1_SCL, SPI6_CS0
+	P9_18 gpio.PinIO = gpio.INVALID // GPIO0_40, I2C1_SDA, SPI6_MOSI
+	P9_19 gpio.PinIO = gpio.INVALID // GPIO0_78, I2C2_SCL
+	P9_20 gpio.PinIO = gpio.INVALID // GPIO0_77, I2C2_SDA
+	P9_21 gpio.PinIO = gpio.INVALID // GPIO0_39, SPI6_MISO, EHRPWM2B
+	P9_22 gpio.PinIO = gpio.INVALID // GPIO0_38, SPI6_CLK, EHRPWM2A
+	P9_23 gpio.PinIO = gpio.INVALID // GPIO0_10
+	P9_24 gpio.PinIO = gpio.INVALID // GPIO0_13, I2C3_SCL
+	P9_25 gpio.PinIO = gpio.INVALID // GPIO0_127
+	P9_26 gpio.PinIO = gpio.INVALID // GPIO0_12, I2C3_SDA
+	P9_27 gpio.PinIO = gpio.INVALID // GPIO0_46
+	P9_28 gpio.PinIO = gpio.INVALID // GPIO0_43, SPI7_CS0
+	P9_29 gpio.PinIO = gpio.INVALID // GPIO0_53, SPI7_MISO
+	P9_30 gpio.PinIO = gpio.INVALID // GPIO0_44, SPI7_MOSI
+	P9_31 gpio.PinIO = gpio.INVALID // GPIO0_52, SPI7_CLK
+	P9_32 pin.Pin    = VADC         // VDD_ADC
+	P9_33 pin.Pin    = AIN4         // AIN4
+	P9_34 pin.Pin    = AGND         // GNDA_ADC
+	P9_35 pin.Pin    = AIN6         // AIN6
+	P9_36 pin.Pin    = AIN5         // AIN5
+	P9_37 pin.Pin    = AIN2         // AIN2
+	P9_38 pin.Pin    = AIN3         // AIN3
+	P9_39 pin.Pin    = AIN0         // AIN0
+	P9_40 pin.Pin    = AIN1         // AIN1
+	P9_41 gpio.PinIO = gpio.INVALID // GPIO1_0
+	P9_42 gpio.PinIO = gpio.INVALID // GPIO0_123
+	P9_43 pin.Pin    = pin.GROUND
+	P9_44 pin.Pin    = pin.GROUND
+	P9_45 pin.Pin    = pin.GROUND
+	P9_46 pin.Pin    = pin.GROUND
+)
+
+// Present returns true if the host is a BeagleBone AI-64.
+func Present() bool {
+	if isArm {
+		for _, c := range distro.DTCompatible() {
+			if c == "beagle,j721e-beagleboneai64" {
+				return true
+			}
+		}
+		return strings.Contains(distro.DTModel(), "BeagleBone AI-64")
+	}
+	return false
+}
+
+// GPIO controllers, identified by the label exported by the kernel.
+const (
+	mainGPIO0 = "600000.gpio"
+	mainGPIO1 = "601000.gpio"
+)
+
+// aliases are the function names of the main_gpio0 lines that are routed to
+// an I²C, SPI or PWM controller by the default device tree.
+var aliases = map[string]int{
+	"I2C1_SCL":  28,
+	"I2C1_SDA":  40,
+	"I2C2_SCL":  78,
+	"I2C2_SDA":  77,
+	"I2C3_SCL":  13,
+	"I2C3_SDA":  12,
+	"SPI6_CLK":  38,
+	"SPI6_MISO": 39,
+	"SPI6_MOSI": 40,
+	"SPI6_CS0":  28,
+	"SPI7_CLK":  52,
+	"SPI7_MISO": 53,
+	"SPI7_MOSI": 44,
+	"SPI7_CS0":  43,
+	"EHRPWM0A":  88,
+	"EHRPWM0B":  89,
+	"EHRPWM1A":  8,
+	"EHRPWM1B":  7,
+	"EHRPWM2A":  38,
+	"EHRPWM2B":  39,
+	"EHRPWM4A":  93,
+	"EHRPWM4B":  94,
+}
+
+// driver implements periph.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "beaglebone-ai64"
+}
+
+func (d *driver) Prerequisites() []string {
+	return []string{"sysfs-gpio"}
+}
+
+func (d *driver) After() []string {
+	return nil
+}
+
+func (d *driver) Init() (bool, error) {
+	if !Present() {
+		return false, errors.New("BeagleBone AI-64 board not detected")
+	}
+
+	bases := sysfs.GPIOChipBases()
+	line := func(chip string, offset int) gpio.PinIO {
+		if base, ok := bases[chip]; ok {
+			if p, ok := sysfs.Pins[base+offset]; ok {
+				return p
+			}
+		}
+		return gpio.INVALID
+	}
+	gpio0 := func(offset int) gpio.PinIO {
+		return line(mainGPIO0, offset)
+	}
+
+	P8_3 = gpio0(20)
+	P8_4 = gpio0(48)
+	P8_5 = gpio0(33)
+	P8_6 = gpio0(34)
+	P8_7 = gpio0(15)
+	P8_8 = gpio0(14)
+	P8_9 = gpio0(17)
+	P8_10 = gpio0(16)
+	P8_11 = gpio0(60)
+	P8_12 = gpio0(59)
+	P8_13 = gpio0(89)
+	P8_14 = gpio0(75)
+	P8_15 = gpio0(61)
+	P8_16 = gpio0(62)
+	P8_17 = gpio0(3)
+	P8_18 = gpio0(4)
+	P8_19 = gpio0(88)
+	P8_20 = gpio0(76)
+	P8_21 = gpio0(30)
+	P8_22 = gpio0(5)
+	P8_23 = gpio0(31)
+	P8_24 = gpio0(6)
+	P8_25 = gpio0(35)
+	P8_26 = gpio0(51)
+	P8_27 = gpio0(71)
+	P8_28 = gpio0(72)
+	P8_29 = gpio0(73)
+	P8_30 = gpio0(74)
+	P8_31 = gpio0(32)
+	P8_32 = gpio0(26)
+	P8_33 = gpio0(25)
+	P8_34 = gpio0(7)
+	P8_35 = gpio0(24)
+	P8_36 = gpio0(8)
+	P8_37 = gpio0(106)
+	P8_38 = gpio0(105)
+	P8_39 = gpio0(69)
+	P8_40 = gpio0(70)
+	P8_41 = gpio0(67)
+	P8_42 = gpio0(68)
+	P8_43 = gpio0(65)
+	P8_44 = gpio0(66)
+	P8_45 = gpio0(79)
+	P8_46 = gpio0(80)
+
+	P9_11 = gpio0(1)
+	P9_12 = gpio0(45)
+	P9_13 = gpio0(2)
+	P9_14 = gpio0(93)
+	P9_15 = gpio0(47)
+	P9_16 = gpio0(94)
+	P9_17 = gpio0(28)
+	P9_18 = gpio0(40)
+	P9_19 = gpio0(78)
+	P9_20 = gpio0(77)
+	P9_21 = gpio0(39)
+	P9_22 = gpio0(38)
+	P9_23 = gpio0(10)
+	P9_24 = gpio0(13)
+	P9_25 = gpio0(127)
+	P9_26 = gpio0(12)
+	P9_27 = gpio0(46)
+	P9_28 = gpio0(43)
+	P9_29 = gpio0(53)
+	P9_30 = gpio0(44)
+	P9_31 = gpio0(52)
+	P9_41 = line(mainGPIO1, 0)
+	P9_42 = gpio0(123)
+
+	hdr := [][]pin.Pin{
+		{P8_1, P8_2},
+		{P8_3, P8_4},
+		{P8_5, P8_6},
+		{P8_7, P8_8},
+		{P8_9, P8_10},
+		{P8_11, P8_12},
+		{P8_13, P8_14},
+		{P8_15, P8_16},
+		{P8_17, P8_18},
+		{P8_19, P8_20},
+		{P8_21, P8_22},
+		{P8_23, P8_24},
+		{P8_25, P8_26},
+		{P8_27, P8_28},
+		{P8_29, P8_30},
+		{P8_31, P8_32},
+		{P8_33, P8_34},
+		{P8_35, P8_36},
+		{P8_37, P8_38},
+		{P8_39, P8_40},
+		{P8_41, P8_42},
+		{P8_43, P8_44},
+		{P8_45, P8_46},
+	}
+	if err := pinreg.Register("P8", hdr); err != nil {
+		return true, err
+	}
+
+	hdr = [][]pin.Pin{
+		{P9_1, P9_2},
+		{P9_3, P9_4},
+		{P9_5, P9_6},
+		{P9_7, P9_8},
+		{P9_9, P9_10},
+		{P9_11, P9_12},
+		{P9_13, P9_14},
+		{P9_15, P9_16},
+		{P9_17, P9_18},
+		{P9_19, P9_20},
+		{P9_21, P9_22},
+		{P9_23, P9_24},
+		{P9_25, P9_26},
+		{P9_27, P9_28},
+		{P9_29, P9_30},
+		{P9_31, P9_32},
+		{P9_33, P9_34},
+		{P9_35, P9_36},
+		{P9_37, P9_38},
+		{P9_39, P9_40},
+		{P9_41, P9_42},
+		{P9_43, P9_44},
+		{P9_45, P9_46},
+	}
+	if err := pinreg.Register("P9", hdr); err != nil {
+		return true, err
+	}
+
+	for alias, offset := range aliases {
+		if p := gpio0(offset); p != gpio.INVALID {
+			if err := gpioreg.RegisterAlias(alias, p.Name()); err != nil {
+				return true, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func init() {
+	if isArm {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
@@ -7,7 +7,7 @@ package beagle
 import (
 	"strings"
 
-	"periph.io/x/host/v3/distro"
+	"github.com/s-mobi01/host/distro"
 )
 
 // Present returns true if the host is a BeagleBone.
@@ -21,7 +21,7 @@ package black
 import (
 	"strings"
 
-	"periph.io/x/host/v3/distro"
+	"github.com/s-mobi01/host/distro"
 )
 
 // Present returns true if the host is a BeagleBone Black or BeagleBone Black
@@ -16,13 +16,13 @@ package bone
 import (
 	"errors"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/beagle/black"
+	"github.com/s-mobi01/host/beagle/green"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/pin"
 	"periph.io/x/conn/v3/pin/pinreg"
-	"periph.io/x/host/v3/beagle/black"
-	"periph.io/x/host/v3/beagle/green"
-	"periph.io/x/host/v3/sysfs"
 )
 
 // TODO(maruel): Use specialized am335x or pru implementation once available.
@@ -311,7 +311,7 @@ func (d *driver) Init() (bool, error) {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drv)
+		hostcfg.MustRegister(&drv)
 	}
 }
 
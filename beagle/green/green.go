@@ -20,12 +20,12 @@ import (
 	"errors"
 	"strings"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/pin"
 	"periph.io/x/conn/v3/pin/pinreg"
-	"periph.io/x/host/v3/distro"
-	"periph.io/x/host/v3/sysfs"
 )
 
 // Headers found on BeagleBone Green.
@@ -88,7 +88,7 @@ func (d *driver) Init() (bool, error) {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drv)
+		hostcfg.MustRegister(&drv)
 	}
 }
 
@@ -0,0 +1,273 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package play implements the mikroBUS and Grove connectors and the onboard
+// LEDs and button found on the BeaglePlay micro-computer.
+//
+// The BeaglePlay is built around a TI AM625 processor, see package am62x.
+//
+// The mikroBUS connector is registered as header "MIKROBUS", with the pins 1
+// to 8 of the left column in the first row and 9 to 16 of the right column in
+// the second row, as per the mikroBUS standard. The AN, RST, INT and PWM
+// signals are not mapped to a GPIO line yet.
+//
+// The Grove connector is registered as header "GROVE".
+//
+// The I²C buses and the SPI port routed to the connectors are registered under
+// their connector name, e.g. "MIKROBUS_I2C", since their /dev/i2c-N number
+// depends on the probe order.
+//
+// Reference
+//
+// https://www.beagleboard.org/boards/beagleplay
+//
+// Datasheet
+//
+// https://docs.beagleboard.org/latest/boards/beagleplay/
+package play
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/s-mobi01/host/am62x"
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+	"periph.io/x/conn/v3/spi"
+	"periph.io/x/conn/v3/spi/spireg"
+)
+
+// Pin types found on the BeaglePlay mikroBUS connector that are not mapped to
+// a GPIO line.
+var (
+	AN  = &pin.BasicPin{N: "AN"}  // Analog input
+	RST = &pin.BasicPin{N: "RST"} // Reset
+	INT = &pin.BasicPin{N: "INT"} // Interrupt
+	PWM = &pin.BasicPin{N: "PWM"} // PWM output
+)
+
+// Onboard LEDs and button.
+//
+// The comments list the main_gpio0 line.
+var (
+	USR0        gpio.PinIO = gpio.INVALID // GPIO0_3, heartbeat
+	USR1        gpio.PinIO = gpio.INVALID // GPIO0_4, disk activity
+	USR2        gpio.PinIO = gpio.INVALID // GPIO0_5
+	USR3        gpio.PinIO = gpio.INVALID // GPIO0_6
+	USR4        gpio.PinIO = gpio.INVALID // GPIO0_9
+	BUTTON_USER gpio.PinIO = gpio.INVALID // GPIO0_18, active low
+)
+
+// Connectors found on the BeaglePlay.
+//
+// The comments list the main_gpio1 line and the default function.
+var (
+	MIKROBUS_1  pin.Pin    = AN
+	MIKROBUS_2  pin.Pin    = RST
+	MIKROBUS_3  gpio.PinIO = gpio.INVALID // GPIO1_13, SPI2_CS0
+	MIKROBUS_4  gpio.PinIO = gpio.INVALID // GPIO1_14, SPI2_CLK
+	MIKROBUS_5  gpio.PinIO = gpio.INVALID // GPIO1_7, SPI2_D0 (MISO)
+	MIKROBUS_6  gpio.PinIO = gpio.INVALID // GPIO1_8, SPI2_D1 (MOSI)
+	MIKROBUS_7  pin.Pin    = pin.V3_3
+	MIKROBUS_8  pin.Pin    = pin.GROUND
+	MIKROBUS_9  pin.Pin    = pin.GROUND
+	MIKROBUS_10 pin.Pin    = pin.V5
+	MIKROBUS_11 gpio.PinIO = gpio.INVALID // GPIO1_23, I2C3_SDA
+	MIKROBUS_12 gpio.PinIO = gpio.INVALID // GPIO1_22, I2C3_SCL
+	MIKROBUS_13 gpio.PinIO = gpio.INVALID // GPIO1_25, UART5_TXD
+	MIKROBUS_14 gpio.PinIO = gpio.INVALID // GPIO1_24, UART5_RXD
+	MIKROBUS_15 pin.Pin    = INT
+	MIKROBUS_16 pin.Pin    = PWM
+
+	GROVE_1 gpio.PinIO = gpio.INVALID // GPIO1_28, I2C1_SCL
+	GROVE_2 gpio.PinIO = gpio.INVALID // GPIO1_29, I2C1_SDA
+	GROVE_3 pin.Pin    = pin.V3_3
+	GROVE_4 pin.Pin    = pin.GROUND
+)
+
+// Present returns true if the host is a BeaglePlay.
+func Present() bool {
+	if isArm {
+		for _, c := range distro.DTCompatible() {
+			if c == "beagle,am625-beagleplay" {
+				return true
+			}
+		}
+		return strings.Contains(distro.DTModel(), "BeaglePlay")
+	}
+	return false
+}
+
+// aliases are the function names of the connector lines, as set by the
+// default device tree.
+var aliases = map[string]*gpio.PinIO{
+	"SPI2_CS0":    &MIKROBUS_3,
+	"SPI2_CLK":    &MIKROBUS_4,
+	"SPI2_MISO":   &MIKROBUS_5,
+	"SPI2_MOSI":   &MIKROBUS_6,
+	"I2C3_SDA":    &MIKROBUS_11,
+	"I2C3_SCL":    &MIKROBUS_12,
+	"UART5_TX":    &MIKROBUS_13,
+	"UART5_RX":    &MIKROBUS_14,
+	"I2C1_SCL":    &GROVE_1,
+	"I2C1_SDA":    &GROVE_2,
+	"LED_USR0":    &USR0,
+	"LED_USR1":    &USR1,
+	"LED_USR2":    &USR2,
+	"LED_USR3":    &USR3,
+	"LED_USR4":    &USR4,
+	"BUTTON_USER": &BUTTON_USER,
+}
+
+// Controllers routed to the connectors, identified by their device name.
+const (
+	mikrobusI2C = "20030000.i2c" // main_i2c3
+	mikrobusSPI = "20120000.spi" // main_spi2
+	groveI2C    = "20010000.i2c" // main_i2c1
+)
+
+// findDevices returns the items matching pattern whose sub path resolves into
+// the device dev.
+func findDevices(pattern, sub, dev string) []string {
+	var out []string
+	items, _ := filepath.Glob(pattern)
+	for _, item := range items {
+		if p, err := filepath.EvalSymlinks(item + sub); err == nil && strings.Contains(p, "/"+dev+"/") {
+			out = append(out, filepath.Base(item))
+		}
+	}
+	return out
+}
+
+// registerI2C registers the I²C bus of the controller dev by its friendly
+// name.
+func registerI2C(name, dev string) error {
+	for _, item := range findDevices("/sys/bus/i2c/devices/i2c-*", "", dev) {
+		var n int
+		if _, err := fmt.Sscanf(item, "i2c-%d", &n); err != nil {
+			continue
+		}
+		opener := func() (i2c.BusCloser, error) {
+			return sysfs.NewI2C(n)
+		}
+		return i2creg.Register(name, nil, -1, opener)
+	}
+	return nil
+}
+
+// registerSPI registers each chip select of the SPI controller dev by its
+// friendly name, e.g. "MIKROBUS_SPI.0".
+func registerSPI(name, dev string) error {
+	for _, item := range findDevices("/sys/class/spidev/spidev*", "/device", dev) {
+		var bus, cs int
+		if _, err := fmt.Sscanf(item, "spidev%d.%d", &bus, &cs); err != nil {
+			continue
+		}
+		opener := func() (spi.PortCloser, error) {
+			return sysfs.NewSPI(bus, cs)
+		}
+		if err := spireg.Register(fmt.Sprintf("%s.%d", name, cs), nil, -1, opener); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// driver implements periph.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "beagleplay"
+}
+
+func (d *driver) Prerequisites() []string {
+	return []string{"am62x", "sysfs-gpio"}
+}
+
+func (d *driver) After() []string {
+	return []string{"sysfs-i2c", "sysfs-spi"}
+}
+
+func (d *driver) Init() (bool, error) {
+	if !Present() {
+		return false, errors.New("BeaglePlay board not detected")
+	}
+
+	gpio0 := func(offset int) gpio.PinIO {
+		return am62x.GPIOLine(am62x.MainGPIO0, offset)
+	}
+	gpio1 := func(offset int) gpio.PinIO {
+		return am62x.GPIOLine(am62x.MainGPIO1, offset)
+	}
+
+	USR0 = gpio0(3)
+	USR1 = gpio0(4)
+	USR2 = gpio0(5)
+	USR3 = gpio0(6)
+	USR4 = gpio0(9)
+	BUTTON_USER = gpio0(18)
+
+	MIKROBUS_3 = gpio1(13)
+	MIKROBUS_4 = gpio1(14)
+	MIKROBUS_5 = gpio1(7)
+	MIKROBUS_6 = gpio1(8)
+	MIKROBUS_11 = gpio1(23)
+	MIKROBUS_12 = gpio1(22)
+	MIKROBUS_13 = gpio1(25)
+	MIKROBUS_14 = gpio1(24)
+
+	GROVE_1 = gpio1(28)
+	GROVE_2 = gpio1(29)
+
+	hdr := [][]pin.Pin{
+		{MIKROBUS_1, MIKROBUS_2, MIKROBUS_3, MIKROBUS_4, MIKROBUS_5, MIKROBUS_6, MIKROBUS_7, MIKROBUS_8},
+		{MIKROBUS_9, MIKROBUS_10, MIKROBUS_11, MIKROBUS_12, MIKROBUS_13, MIKROBUS_14, MIKROBUS_15, MIKROBUS_16},
+	}
+	if err := pinreg.Register("MIKROBUS", hdr); err != nil {
+		return true, err
+	}
+	hdr = [][]pin.Pin{
+		{GROVE_1},
+		{GROVE_2},
+		{GROVE_3},
+		{GROVE_4},
+	}
+	if err := pinreg.Register("GROVE", hdr); err != nil {
+		return true, err
+	}
+
+	for alias, p := range aliases {
+		if *p != gpio.INVALID {
+			if err := gpioreg.RegisterAlias(alias, (*p).Name()); err != nil {
+				return true, err
+			}
+		}
+	}
+
+	if err := registerI2C("MIKROBUS_I2C", mikrobusI2C); err != nil {
+		return true, err
+	}
+	if err := registerI2C("GROVE_I2C", groveI2C); err != nil {
+		return true, err
+	}
+	return true, registerSPI("MIKROBUS_SPI", mikrobusSPI)
+}
+
+func init() {
+	if isArm {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
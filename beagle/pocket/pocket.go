@@ -0,0 +1,300 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package pocket implements headers P1 and P2 found on the PocketBeagle
+// micro-computer.
+//
+// The PocketBeagle uses the Octavo Systems OSD3358 SiP, built around a TI
+// AM335x processor, so the GPIO numbering is the same as on the BeagleBone.
+//
+// Reference
+//
+// https://beagleboard.org/pocket
+//
+// Datasheet
+//
+// https://github.com/beagleboard/pocketbeagle/wiki/System-Reference-Manual
+package pocket
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+)
+
+// Pin types found on the PocketBeagle headers.
+var (
+	VIN_AC     = &pin.BasicPin{N: "VIN_AC"}     // VIN-AC
+	VIN_USB    = &pin.BasicPin{N: "VIN_USB"}    // VIN-USB
+	VIN_BAT    = &pin.BasicPin{N: "VIN_BAT"}    // BAT-VIN
+	BAT_TEMP   = &pin.BasicPin{N: "BAT_TEMP"}   // BAT-TEMP
+	PWR_BUT    = &pin.BasicPin{N: "PWR_BUT"}    //
+	RESET      = &pin.BasicPin{N: "RESET"}      // RESET#
+	USB1_DRVVB = &pin.BasicPin{N: "USB1_DRVVB"} // USB1_DRVVBUS
+	USB1_VBUS  = &pin.BasicPin{N: "USB1_VBUS"}  // USB1_VBUS_IN
+	USB1_DN    = &pin.BasicPin{N: "USB1_DN"}    // USB1_D-
+	USB1_DP    = &pin.BasicPin{N: "USB1_DP"}    // USB1_D+
+	USB1_ID    = &pin.BasicPin{N: "USB1_ID"}    //
+	VREFN      = &pin.BasicPin{N: "VREFN"}      // VREF-
+	VREFP      = &pin.BasicPin{N: "VREFP"}      // VREF+
+	AIN0       = &pin.BasicPin{N: "AIN0"}       // AIN0, 1.8V
+	AIN1       = &pin.BasicPin{N: "AIN1"}       // AIN1, 1.8V
+	AIN2       = &pin.BasicPin{N: "AIN2"}       // AIN2, 1.8V
+	AIN3       = &pin.BasicPin{N: "AIN3"}       // AIN3, 1.8V
+	AIN4       = &pin.BasicPin{N: "AIN4"}       // AIN4, 1.8V
+	AIN7       = &pin.BasicPin{N: "AIN7"}       // AIN7, 1.8V
+)
+
+// Headers found on the PocketBeagle.
+var (
+	P1_1  pin.Pin    = VIN_AC
+	P1_2  gpio.PinIO = gpio.INVALID // GPIO87, AIN6 (3.3V)
+	P1_3  pin.Pin    = USB1_DRVVB
+	P1_4  gpio.PinIO = gpio.INVALID // GPIO89
+	P1_5  pin.Pin    = USB1_VBUS
+	P1_6  gpio.PinIO = gpio.INVALID // GPIO5, SPI0_CS0
+	P1_7  pin.Pin    = VIN_USB
+	P1_8  gpio.PinIO = gpio.INVALID // GPIO2, SPI0_CLK, UART2_RX, EHRPWM0A
+	P1_9  pin.Pin    = USB1_DN
+	P1_10 gpio.PinIO = gpio.INVALID // GPIO3, SPI0_MISO, UART2_TX, EHRPWM0B
+	P1_11 pin.Pin    = USB1_DP
+	P1_12 gpio.PinIO = gpio.INVALID // GPIO4, SPI0_MOSI
+	P1_13 pin.Pin    = USB1_ID
+	P1_14 pin.Pin    = pin.V3_3
+	P1_15 pin.Pin    = pin.GROUND
+	P1_16 pin.Pin    = pin.GROUND
+	P1_17 pin.Pin    = VREFN
+	P1_18 pin.Pin    = VREFP
+	P1_19 pin.Pin    = AIN0
+	P1_20 gpio.PinIO = gpio.INVALID // GPIO20
+	P1_21 pin.Pin    = AIN1
+	P1_22 pin.Pin    = pin.GROUND
+	P1_23 pin.Pin    = AIN2
+	P1_24 pin.Pin    = pin.V5
+	P1_25 pin.Pin    = AIN3
+	P1_26 gpio.PinIO = gpio.INVALID // GPIO12, I2C2_SDA
+	P1_27 pin.Pin    = AIN4
+	P1_28 gpio.PinIO = gpio.INVALID // GPIO13, I2C2_SCL
+	P1_29 gpio.PinIO = gpio.INVALID // GPIO117
+	P1_30 gpio.PinIO = gpio.INVALID // GPIO43, UART0_TX
+	P1_31 gpio.PinIO = gpio.INVALID // GPIO114
+	P1_32 gpio.PinIO = gpio.INVALID // GPIO42, UART0_RX
+	P1_33 gpio.PinIO = gpio.INVALID // GPIO111, EHRPWM0B
+	P1_34 gpio.PinIO = gpio.INVALID // GPIO26
+	P1_35 gpio.PinIO = gpio.INVALID // GPIO88
+	P1_36 gpio.PinIO = gpio.INVALID // GPIO110, EHRPWM0A
+
+	P2_1  gpio.PinIO = gpio.INVALID // GPIO50, EHRPWM1A
+	P2_2  gpio.PinIO = gpio.INVALID // GPIO59
+	P2_3  gpio.PinIO = gpio.INVALID // GPIO23, EHRPWM2B
+	P2_4  gpio.PinIO = gpio.INVALID // GPIO58
+	P2_5  gpio.PinIO = gpio.INVALID // GPIO30, UART4_RX
+	P2_6  gpio.PinIO = gpio.INVALID // GPIO57
+	P2_7  gpio.PinIO = gpio.INVALID // GPIO31, UART4_TX
+	P2_8  gpio.PinIO = gpio.INVALID // GPIO60
+	P2_9  gpio.PinIO = gpio.INVALID // GPIO15, I2C1_SCL, UART1_TX
+	P2_10 gpio.PinIO = gpio.INVALID // GPIO52
+	P2_11 gpio.PinIO = gpio.INVALID // GPIO14, I2C1_SDA, UART1_RX
+	P2_12 pin.Pin    = PWR_BUT
+	P2_13 pin.Pin    = pin.V5
+	P2_14 pin.Pin    = VIN_BAT
+	P2_15 pin.Pin    = pin.GROUND
+	P2_16 pin.Pin    = BAT_TEMP
+	P2_17 gpio.PinIO = gpio.INVALID // GPIO65
+	P2_18 gpio.PinIO = gpio.INVALID // GPIO47
+	P2_19 gpio.PinIO = gpio.INVALID // GPIO27
+	P2_20 gpio.PinIO = gpio.INVALID // GPIO64
+	P2_21 pin.Pin    = pin.GROUND
+	P2_22 gpio.PinIO = gpio.INVALID // GPIO46
+	P2_23 pin.Pin    = pin.V3_3
+	P2_24 gpio.PinIO = gpio.INVALID // GPIO44
+	P2_25 gpio.PinIO = gpio.INVALID // GPIO41, SPI1_MOSI
+	P2_26 pin.Pin    = RESET
+	P2_27 gpio.PinIO = gpio.INVALID // GPIO40, SPI1_MISO
+	P2_28 gpio.PinIO = gpio.INVALID // GPIO116
+	P2_29 gpio.PinIO = gpio.INVALID // GPIO7, SPI1_CLK
+	P2_30 gpio.PinIO = gpio.INVALID // GPIO113
+	P2_31 gpio.PinIO = gpio.INVALID // GPIO19, SPI1_CS1
+	P2_32 gpio.PinIO = gpio.INVALID // GPIO112
+	P2_33 gpio.PinIO = gpio.INVALID // GPIO45
+	P2_34 gpio.PinIO = gpio.INVALID // GPIO115
+	P2_35 gpio.PinIO = gpio.INVALID // GPIO86, AIN5 (3.3V)
+	P2_36 pin.Pin    = AIN7
+)
+
+// Present returns true if the host is a PocketBeagle.
+func Present() bool {
+	if isArm {
+		return strings.HasPrefix(distro.DTModel(), "TI AM335x PocketBeagle")
+	}
+	return false
+}
+
+// aliases are the function names of the pins that are routed to an I²C, SPI
+// or PWM controller by the default device tree.
+var aliases = map[string]int{
+	"I2C1_SCL":  15,
+	"I2C1_SDA":  14,
+	"I2C2_SCL":  13,
+	"I2C2_SDA":  12,
+	"SPI0_CLK":  2,
+	"SPI0_MISO": 3,
+	"SPI0_MOSI": 4,
+	"SPI0_CS0":  5,
+	"SPI1_CLK":  7,
+	"SPI1_MISO": 40,
+	"SPI1_MOSI": 41,
+	"SPI1_CS1":  19,
+	"EHRPWM0A":  110,
+	"EHRPWM0B":  111,
+	"EHRPWM1A":  50,
+	"EHRPWM2B":  23,
+}
+
+// sysfsPin is a safe way to get a sysfs pin.
+func sysfsPin(n int) gpio.PinIO {
+	if p, ok := sysfs.Pins[n]; ok {
+		return p
+	}
+	return gpio.INVALID
+}
+
+// driver implements periph.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "pocketbeagle"
+}
+
+func (d *driver) Prerequisites() []string {
+	return []string{"am335x", "sysfs-gpio"}
+}
+
+func (d *driver) After() []string {
+	return nil
+}
+
+func (d *driver) Init() (bool, error) {
+	if !Present() {
+		return false, errors.New("PocketBeagle board not detected")
+	}
+
+	P1_2 = sysfsPin(87)
+	P1_4 = sysfsPin(89)
+	P1_6 = sysfsPin(5)
+	P1_8 = sysfsPin(2)
+	P1_10 = sysfsPin(3)
+	P1_12 = sysfsPin(4)
+	P1_20 = sysfsPin(20)
+	P1_26 = sysfsPin(12)
+	P1_28 = sysfsPin(13)
+	P1_29 = sysfsPin(117)
+	P1_30 = sysfsPin(43)
+	P1_31 = sysfsPin(114)
+	P1_32 = sysfsPin(42)
+	P1_33 = sysfsPin(111)
+	P1_34 = sysfsPin(26)
+	P1_35 = sysfsPin(88)
+	P1_36 = sysfsPin(110)
+
+	P2_1 = sysfsPin(50)
+	P2_2 = sysfsPin(59)
+	P2_3 = sysfsPin(23)
+	P2_4 = sysfsPin(58)
+	P2_5 = sysfsPin(30)
+	P2_6 = sysfsPin(57)
+	P2_7 = sysfsPin(31)
+	P2_8 = sysfsPin(60)
+	P2_9 = sysfsPin(15)
+	P2_10 = sysfsPin(52)
+	P2_11 = sysfsPin(14)
+	P2_17 = sysfsPin(65)
+	P2_18 = sysfsPin(47)
+	P2_19 = sysfsPin(27)
+	P2_20 = sysfsPin(64)
+	P2_22 = sysfsPin(46)
+	P2_24 = sysfsPin(44)
+	P2_25 = sysfsPin(41)
+	P2_27 = sysfsPin(40)
+	P2_28 = sysfsPin(116)
+	P2_29 = sysfsPin(7)
+	P2_30 = sysfsPin(113)
+	P2_31 = sysfsPin(19)
+	P2_32 = sysfsPin(112)
+	P2_33 = sysfsPin(45)
+	P2_34 = sysfsPin(115)
+	P2_35 = sysfsPin(86)
+
+	hdr := [][]pin.Pin{
+		{P1_1, P1_2},
+		{P1_3, P1_4},
+		{P1_5, P1_6},
+		{P1_7, P1_8},
+		{P1_9, P1_10},
+		{P1_11, P1_12},
+		{P1_13, P1_14},
+		{P1_15, P1_16},
+		{P1_17, P1_18},
+		{P1_19, P1_20},
+		{P1_21, P1_22},
+		{P1_23, P1_24},
+		{P1_25, P1_26},
+		{P1_27, P1_28},
+		{P1_29, P1_30},
+		{P1_31, P1_32},
+		{P1_33, P1_34},
+		{P1_35, P1_36},
+	}
+	if err := pinreg.Register("P1", hdr); err != nil {
+		return true, err
+	}
+
+	hdr = [][]pin.Pin{
+		{P2_1, P2_2},
+		{P2_3, P2_4},
+		{P2_5, P2_6},
+		{P2_7, P2_8},
+		{P2_9, P2_10},
+		{P2_11, P2_12},
+		{P2_13, P2_14},
+		{P2_15, P2_16},
+		{P2_17, P2_18},
+		{P2_19, P2_20},
+		{P2_21, P2_22},
+		{P2_23, P2_24},
+		{P2_25, P2_26},
+		{P2_27, P2_28},
+		{P2_29, P2_30},
+		{P2_31, P2_32},
+		{P2_33, P2_34},
+		{P2_35, P2_36},
+	}
+	if err := pinreg.Register("P2", hdr); err != nil {
+		return true, err
+	}
+
+	for alias, number := range aliases {
+		if err := gpioreg.RegisterAlias(alias, strconv.Itoa(number)); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func init() {
+	if isArm {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
@@ -0,0 +1,28 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bitbang
+
+import (
+	"time"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// halfPeriod returns the duration of half a clock cycle at frequency f.
+func halfPeriod(f physic.Frequency) time.Duration {
+	return f.Period() / 2
+}
+
+// spin busy loops for d.
+//
+// time.Sleep() has a resolution in the order of 50µs to 1ms depending on the
+// OS, which is too coarse for the bus timings.
+func spin(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	for start := time.Now(); time.Since(start) < d; {
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package bitbang implements buses in software on top of generic GPIO pins.
+//
+// It is useful on boards where the hardware controllers are already used, are
+// not routed to the header, or are broken. The pins can come from any driver,
+// including the USB bridges.
+//
+// The timing is generated by busy looping, so a CPU core is kept busy during
+// each transaction and the actual speed depends on the GPIO driver latency;
+// a memory mapped GPIO driver is recommended. Nothing is registered by
+// default; call the Register functions to expose a bus through the registries.
+package bitbang
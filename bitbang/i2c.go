@@ -0,0 +1,330 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bitbang
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/physic"
+)
+
+// DefaultStretchTimeout is the default maximum time a device can hold SCL low
+// to stretch the clock.
+const DefaultStretchTimeout = 10 * time.Millisecond
+
+// NewI2C returns an I²C master bit-banging on the pins scl and sda at the
+// speed f.
+//
+// The lines are driven as open drain: they are pulled low with Out(Low) and
+// released with In(PullUp), so they are never driven high. External pull-up
+// resistors are still recommended as the internal ones are weak, or not
+// supported by all GPIO drivers.
+//
+// Clock stretching by the devices is supported, up to DefaultStretchTimeout
+// by default. 7 and 10 bits addresses are supported.
+//
+// The resulting object is safe for concurrent use.
+func NewI2C(scl, sda gpio.PinIO, f physic.Frequency) (*I2C, error) {
+	if scl == nil || sda == nil {
+		return nil, errors.New("bitbang-i2c: pins are required")
+	}
+	i := &I2C{scl: scl, sda: sda, stretch: DefaultStretchTimeout}
+	if err := i.SetSpeed(f); err != nil {
+		return nil, err
+	}
+	if err := i.release(i.scl); err != nil {
+		return nil, err
+	}
+	if err := i.release(i.sda); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// RegisterI2C registers a bit-banged I²C bus in i2creg as name.
+//
+// The bus is created by NewI2C each time it is opened.
+func RegisterI2C(name string, scl, sda gpio.PinIO, f physic.Frequency) error {
+	opener := func() (i2c.BusCloser, error) {
+		return NewI2C(scl, sda, f)
+	}
+	return i2creg.Register(name, nil, -1, opener)
+}
+
+// I2C is a bit-banged I²C master.
+type I2C struct {
+	scl gpio.PinIO
+	sda gpio.PinIO
+
+	mu      sync.Mutex
+	f       physic.Frequency
+	half    time.Duration
+	stretch time.Duration
+}
+
+// Close releases both lines.
+func (i *I2C) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	err1 := i.release(i.sda)
+	err2 := i.release(i.scl)
+	if err1 != nil {
+		return err1
+	}
+	return err2
+}
+
+func (i *I2C) String() string {
+	return fmt.Sprintf("bitbang-i2c(%s,%s)", i.scl, i.sda)
+}
+
+// SetSpeed implements i2c.Bus.
+func (i *I2C) SetSpeed(f physic.Frequency) error {
+	if f <= 0 || f > physic.MegaHertz {
+		return fmt.Errorf("bitbang-i2c: invalid speed %s; maximum supported clock is 1MHz", f)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.f = f
+	i.half = halfPeriod(f)
+	return nil
+}
+
+// SetStretchTimeout sets the maximum time a device can stretch the clock
+// before the transaction is aborted.
+func (i *I2C) SetStretchTimeout(d time.Duration) error {
+	if d <= 0 {
+		return errors.New("bitbang-i2c: invalid clock stretching timeout")
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.stretch = d
+	return nil
+}
+
+// Tx implements i2c.Bus.
+//
+// w is written first, then r is read after a repeated start. An empty
+// transaction sends only the address, which can be used to probe a device.
+func (i *I2C) Tx(addr uint16, w, r []byte) error {
+	if addr >= 0x400 {
+		return errors.New("bitbang-i2c: invalid address")
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if err := i.start(); err != nil {
+		return err
+	}
+	err := i.tx(addr, w, r)
+	if err2 := i.stop(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+// SCL implements i2c.Pins.
+func (i *I2C) SCL() gpio.PinIO {
+	return i.scl
+}
+
+// SDA implements i2c.Pins.
+func (i *I2C) SDA() gpio.PinIO {
+	return i.sda
+}
+
+//
+
+// tx runs the transaction between the start and the stop conditions.
+func (i *I2C) tx(addr uint16, w, r []byte) error {
+	if len(w) != 0 || len(r) == 0 || addr >= 0x80 {
+		// 10 bits addresses always start with a write to send the low byte.
+		if err := i.writeAddr(addr, false); err != nil {
+			return err
+		}
+		for j, b := range w {
+			if err := i.writeByte(b); err != nil {
+				return fmt.Errorf("bitbang-i2c: device 0x%X: byte %d: %v", addr, j, err)
+			}
+		}
+		if len(r) == 0 {
+			return nil
+		}
+		if err := i.start(); err != nil {
+			return err
+		}
+	}
+	if addr >= 0x80 {
+		// A repeated start only needs the first byte of a 10 bits address.
+		if err := i.writeByte(byte(0xF0 | (addr>>7)&6 | 1)); err != nil {
+			return fmt.Errorf("bitbang-i2c: device 0x%X: %v", addr, err)
+		}
+	} else if err := i.writeAddr(addr, true); err != nil {
+		return err
+	}
+	for j := range r {
+		b, err := i.readByte(j != len(r)-1)
+		if err != nil {
+			return err
+		}
+		r[j] = b
+	}
+	return nil
+}
+
+// writeAddr sends the address of the device.
+func (i *I2C) writeAddr(addr uint16, read bool) error {
+	var rw byte
+	if read {
+		rw = 1
+	}
+	var err error
+	if addr < 0x80 {
+		err = i.writeByte(byte(addr<<1) | rw)
+	} else if err = i.writeByte(byte(0xF0 | (addr>>7)&6 | uint16(rw))); err == nil {
+		err = i.writeByte(byte(addr))
+	}
+	if err != nil {
+		return fmt.Errorf("bitbang-i2c: device 0x%X: %v", addr, err)
+	}
+	return nil
+}
+
+// start sends a start condition, or a repeated start condition.
+//
+// SCL is left low.
+func (i *I2C) start() error {
+	if err := i.release(i.sda); err != nil {
+		return err
+	}
+	spin(i.half)
+	if err := i.clockHigh(); err != nil {
+		return err
+	}
+	if i.sda.Read() == gpio.Low {
+		return errors.New("bitbang-i2c: SDA is held low, the bus is busy")
+	}
+	spin(i.half)
+	if err := i.sda.Out(gpio.Low); err != nil {
+		return err
+	}
+	spin(i.half)
+	return i.scl.Out(gpio.Low)
+}
+
+// stop sends a stop condition, leaving the bus idle.
+func (i *I2C) stop() error {
+	if err := i.sda.Out(gpio.Low); err != nil {
+		return err
+	}
+	spin(i.half)
+	if err := i.clockHigh(); err != nil {
+		return err
+	}
+	spin(i.half)
+	if err := i.release(i.sda); err != nil {
+		return err
+	}
+	spin(i.half)
+	return nil
+}
+
+// writeByte sends b MSB first and returns an error if the device did not
+// acknowledge it.
+func (i *I2C) writeByte(b byte) error {
+	for j := 7; j >= 0; j-- {
+		if err := i.writeBit(gpio.Level(b&(1<<uint(j)) != 0)); err != nil {
+			return err
+		}
+	}
+	ack, err := i.readBit()
+	if err != nil {
+		return err
+	}
+	if ack == gpio.High {
+		return errors.New("got NAK")
+	}
+	return nil
+}
+
+// readByte reads a byte MSB first then acknowledges it if ack is true; the
+// last byte read must not be acknowledged.
+func (i *I2C) readByte(ack bool) (byte, error) {
+	var b byte
+	for j := 0; j < 8; j++ {
+		l, err := i.readBit()
+		if err != nil {
+			return 0, err
+		}
+		b <<= 1
+		if l {
+			b |= 1
+		}
+	}
+	return b, i.writeBit(gpio.Level(!ack))
+}
+
+// writeBit sends one bit. SCL is low on entry and exit.
+func (i *I2C) writeBit(l gpio.Level) error {
+	var err error
+	if l {
+		err = i.release(i.sda)
+	} else {
+		err = i.sda.Out(gpio.Low)
+	}
+	if err != nil {
+		return err
+	}
+	spin(i.half)
+	if err := i.clockHigh(); err != nil {
+		return err
+	}
+	spin(i.half)
+	return i.scl.Out(gpio.Low)
+}
+
+// readBit reads one bit. SCL is low on entry and exit.
+func (i *I2C) readBit() (gpio.Level, error) {
+	if err := i.release(i.sda); err != nil {
+		return gpio.Low, err
+	}
+	spin(i.half)
+	if err := i.clockHigh(); err != nil {
+		return gpio.Low, err
+	}
+	l := i.sda.Read()
+	spin(i.half)
+	return l, i.scl.Out(gpio.Low)
+}
+
+// clockHigh releases SCL and waits for it to go high, as the device may
+// stretch the clock.
+func (i *I2C) clockHigh() error {
+	if err := i.release(i.scl); err != nil {
+		return err
+	}
+	if i.scl.Read() == gpio.High {
+		return nil
+	}
+	for start := time.Now(); i.scl.Read() == gpio.Low; {
+		if time.Since(start) > i.stretch {
+			return errors.New("bitbang-i2c: timed out waiting for the device to release SCL")
+		}
+	}
+	return nil
+}
+
+// release stops driving the line, letting the pull-up pull it high.
+func (i *I2C) release(p gpio.PinIO) error {
+	return p.In(gpio.PullUp, gpio.NoEdge)
+}
+
+var _ i2c.BusCloser = &I2C{}
+var _ i2c.Pins = &I2C{}
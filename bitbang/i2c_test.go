@@ -0,0 +1,339 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bitbang
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestI2C_write_read(t *testing.T) {
+	b := newI2CBus(0x42)
+	i := newTestI2C(t, b)
+	if err := i.Tx(0x42, []byte{1, 0xA5, 0x5A}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b.dev.regs[1:3], []byte{0xA5, 0x5A}) {
+		t.Fatalf("regs = %#v", b.dev.regs[:4])
+	}
+	r := make([]byte, 3)
+	if err := i.Tx(0x42, []byte{1}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{0xA5, 0x5A, 3}) {
+		t.Fatalf("read %#v", r)
+	}
+	// Read without a write continues from the current register.
+	if err := i.Tx(0x42, nil, r[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if r[0] != 0x04 {
+		t.Fatalf("read %#v", r[0])
+	}
+	if b.sdaLow() || b.sclLow() {
+		t.Fatal("the bus is not idle")
+	}
+}
+
+func TestI2C_10bits(t *testing.T) {
+	b := newI2CBus(0x2A5)
+	i := newTestI2C(t, b)
+	r := make([]byte, 2)
+	if err := i.Tx(0x2A5, []byte{2}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{2, 3}) {
+		t.Fatalf("read %#v", r)
+	}
+}
+
+func TestI2C_NAK(t *testing.T) {
+	b := newI2CBus(0x42)
+	i := newTestI2C(t, b)
+	if err := i.Tx(0x43, nil, nil); err == nil {
+		t.Fatal("no device")
+	}
+	if err := i.Tx(0x42, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := i.Tx(0x400, nil, nil); err == nil {
+		t.Fatal("invalid address")
+	}
+}
+
+func TestI2C_stretch(t *testing.T) {
+	b := newI2CBus(0x42)
+	i := newTestI2C(t, b)
+	b.stretch = 5
+	if err := i.Tx(0x42, []byte{1, 2}, nil); err != nil {
+		t.Fatal(err)
+	}
+	b.stretch = -1
+	if err := i.SetStretchTimeout(time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := i.Tx(0x42, []byte{1, 2}, nil); err == nil {
+		t.Fatal("stretching forever")
+	}
+}
+
+func TestI2C_busy(t *testing.T) {
+	b := newI2CBus(0x42)
+	i := newTestI2C(t, b)
+	b.dev.sda = true
+	if err := i.Tx(0x42, []byte{1}, nil); err == nil {
+		t.Fatal("SDA held low")
+	}
+}
+
+func TestNewI2C(t *testing.T) {
+	b := newI2CBus(0x42)
+	if _, err := NewI2C(nil, b.sdaPin, physic.KiloHertz); err == nil {
+		t.Fatal("missing pin")
+	}
+	if _, err := NewI2C(b.sclPin, b.sdaPin, 2*physic.MegaHertz); err == nil {
+		t.Fatal("too fast")
+	}
+}
+
+//
+
+func newTestI2C(t *testing.T, b *i2cBus) *I2C {
+	// Use a high frequency to not slow down the tests.
+	i, err := NewI2C(b.sclPin, b.sdaPin, physic.MegaHertz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := i.String(); s != "bitbang-i2c(SCL,SDA)" {
+		t.Fatal(s)
+	}
+	return i
+}
+
+// i2cBus simulates the two open drain lines and a device with 256 registers
+// initialized to their index.
+type i2cBus struct {
+	sclPin *i2cPin
+	sdaPin *i2cPin
+	dev    i2cDevice
+	// stretch is the number of SCL reads returning Low after the master
+	// released it; -1 stretches forever.
+	stretch int
+}
+
+func newI2CBus(addr uint16) *i2cBus {
+	b := &i2cBus{dev: i2cDevice{addr: addr}}
+	for j := range b.dev.regs {
+		b.dev.regs[j] = byte(j)
+	}
+	b.sclPin = &i2cPin{b: b, name: "SCL"}
+	b.sdaPin = &i2cPin{b: b, name: "SDA"}
+	return b
+}
+
+func (b *i2cBus) sclLow() bool {
+	return b.sclPin.low
+}
+
+func (b *i2cBus) sdaLow() bool {
+	return b.sdaPin.low || b.dev.sda
+}
+
+// update is called each time the master changes a line.
+func (b *i2cBus) update(p *i2cPin, wasLow bool) {
+	if wasLow == p.low {
+		return
+	}
+	if p == b.sclPin {
+		if p.low {
+			b.dev.sclFall()
+		} else {
+			b.dev.sclRise(!b.sdaLow())
+		}
+		return
+	}
+	if !b.sclLow() {
+		if p.low {
+			b.dev.startCond()
+		} else {
+			b.dev.stopCond()
+		}
+	}
+}
+
+type i2cPin struct {
+	gpio.PinIO
+	b    *i2cBus
+	name string
+	low  bool // driven low by the master
+}
+
+func (p *i2cPin) String() string {
+	return p.name
+}
+
+func (p *i2cPin) In(pull gpio.Pull, edge gpio.Edge) error {
+	was := p.low
+	p.low = false
+	p.b.update(p, was)
+	return nil
+}
+
+func (p *i2cPin) Out(l gpio.Level) error {
+	was := p.low
+	p.low = !bool(l)
+	p.b.update(p, was)
+	return nil
+}
+
+func (p *i2cPin) Read() gpio.Level {
+	if p == p.b.sclPin {
+		if !p.low && p.b.stretch != 0 {
+			if p.b.stretch > 0 {
+				p.b.stretch--
+			}
+			return gpio.Low
+		}
+		return gpio.Level(!p.low)
+	}
+	return gpio.Level(!p.b.sdaLow())
+}
+
+// i2cDevice is a device with an 8 bits register pointer that auto-increments.
+type i2cDevice struct {
+	addr uint16
+	regs [256]byte
+	ptr  byte
+
+	sda bool // driven low by the device
+
+	active    bool // a transaction is in progress
+	selected  bool // the address matched
+	transmit  bool // the device sends data
+	nbytes    int  // number of bytes received in this transaction
+	header10  bool // the 10 bits header was received with a write
+	bit       int  // bit index in the current byte; 8 is the acknowledge
+	shift     byte
+	masterNAK bool
+}
+
+func (d *i2cDevice) startCond() {
+	d.active = true
+	d.transmit = false
+	d.nbytes = 0
+	d.bit = 0
+	d.shift = 0
+	d.sda = false
+}
+
+func (d *i2cDevice) stopCond() {
+	d.active = false
+	d.selected = false
+	d.header10 = false
+	d.sda = false
+}
+
+func (d *i2cDevice) sclRise(sda bool) {
+	if !d.active {
+		return
+	}
+	if d.bit < 8 {
+		if !d.transmit {
+			d.shift <<= 1
+			if sda {
+				d.shift |= 1
+			}
+		}
+		d.bit++
+		return
+	}
+	// Acknowledge clock.
+	if d.transmit {
+		d.masterNAK = sda
+	}
+	d.bit++
+}
+
+func (d *i2cDevice) sclFall() {
+	if !d.active {
+		return
+	}
+	switch {
+	case d.bit == 8 && !d.transmit:
+		// Byte received; decide whether to acknowledge it.
+		if d.receive(d.shift) {
+			d.sda = true
+		} else {
+			d.active = false
+		}
+	case d.bit == 8:
+		// Byte sent; release SDA for the master acknowledge.
+		d.sda = false
+	case d.bit == 9:
+		d.bit = 0
+		d.shift = 0
+		d.sda = false
+		if d.transmit {
+			if d.masterNAK {
+				d.active = false
+				return
+			}
+			d.shift = d.regs[d.ptr]
+			d.ptr++
+		}
+		fallthrough
+	default:
+		if d.transmit {
+			d.sda = d.shift&(0x80>>uint(d.bit)) == 0
+		}
+	}
+}
+
+// receive handles a byte written by the master and returns true to
+// acknowledge it.
+func (d *i2cDevice) receive(b byte) bool {
+	n := d.nbytes
+	d.nbytes++
+	if n == 0 {
+		read := b&1 != 0
+		if d.addr >= 0x80 {
+			if b&0xF8 != 0xF0 || uint16(b>>1)&3 != d.addr>>8 {
+				return false
+			}
+			if read {
+				// Only valid after a write selected the device.
+				d.transmit = d.header10
+				return d.header10
+			}
+			d.header10 = true
+			return true
+		}
+		if uint16(b>>1) != d.addr {
+			return false
+		}
+		d.selected = true
+		d.transmit = read
+		return true
+	}
+	if d.addr >= 0x80 && !d.selected {
+		if n == 1 && b == byte(d.addr) {
+			d.selected = true
+			return true
+		}
+		d.header10 = false
+		return false
+	}
+	if (d.addr >= 0x80 && n == 2) || (d.addr < 0x80 && n == 1) {
+		d.ptr = b
+		return true
+	}
+	d.regs[d.ptr] = b
+	d.ptr++
+	return true
+}
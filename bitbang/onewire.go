@@ -0,0 +1,255 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bitbang
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/onewire"
+	"periph.io/x/conn/v3/onewire/onewirereg"
+)
+
+// NewOneWire returns a 1-wire master bit-banging on the pin q.
+//
+// The line is driven as open drain: it is pulled low with Out(Low) and
+// released with In(PullUp). An external pull-up resistor, usually 4.7kΩ, is
+// required. A strong pull-up is emulated by driving the line high with
+// Out(High) until the next transaction, so the pin must be able to source the
+// current needed by the devices.
+//
+// The standard speed timings are used. A slot must not be interrupted for
+// more than a few µs, so bits can be corrupted when the process is preempted;
+// check the CRC returned by the devices and retry on failure.
+//
+// The resulting object is safe for concurrent use.
+func NewOneWire(q gpio.PinIO) (*OneWire, error) {
+	if q == nil {
+		return nil, errors.New("bitbang-onewire: pin is required")
+	}
+	o := &OneWire{q: q, delay: spin}
+	if err := o.release(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// RegisterOneWire registers a bit-banged 1-wire bus in onewirereg as name.
+//
+// The bus is created by NewOneWire each time it is opened.
+func RegisterOneWire(name string, q gpio.PinIO) error {
+	opener := func() (onewire.BusCloser, error) {
+		return NewOneWire(q)
+	}
+	return onewirereg.Register(name, nil, -1, opener)
+}
+
+// OneWire is a bit-banged 1-wire master.
+type OneWire struct {
+	q gpio.PinIO
+
+	mu    sync.Mutex
+	delay func(time.Duration)
+}
+
+// Close releases the line, stopping any strong pull-up.
+func (o *OneWire) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.release()
+}
+
+func (o *OneWire) String() string {
+	return fmt.Sprintf("bitbang-onewire(%s)", o.q)
+}
+
+// Tx implements onewire.Bus.
+//
+// It resets the bus, writes w then reads into r. With onewire.StrongPullup,
+// the line is driven high right after the last bit until the next
+// transaction.
+func (o *OneWire) Tx(w, r []byte, power onewire.Pullup) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := o.reset(); err != nil {
+		return err
+	}
+	for _, b := range w {
+		if err := o.writeByte(b); err != nil {
+			return err
+		}
+	}
+	for i := range r {
+		b, err := o.readByte()
+		if err != nil {
+			return err
+		}
+		r[i] = b
+	}
+	if power == onewire.StrongPullup {
+		return o.q.Out(gpio.High)
+	}
+	return nil
+}
+
+// Search implements onewire.Bus.
+func (o *OneWire) Search(alarmOnly bool) ([]onewire.Address, error) {
+	return onewire.Search(o, alarmOnly)
+}
+
+// SearchTriplet implements onewire.BusSearcher.
+func (o *OneWire) SearchTriplet(direction byte) (onewire.TripletResult, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var res onewire.TripletResult
+	id, err := o.readBit()
+	if err != nil {
+		return res, err
+	}
+	cmp, err := o.readBit()
+	if err != nil {
+		return res, err
+	}
+	res.GotZero = id == gpio.Low
+	res.GotOne = cmp == gpio.Low
+	switch {
+	case res.GotZero && res.GotOne:
+		res.Taken = direction & 1
+	case res.GotOne:
+		res.Taken = 1
+	case !res.GotZero:
+		// No device answered; let the caller abort the search.
+		return res, nil
+	}
+	return res, o.writeBit(res.Taken == 1)
+}
+
+// Q implements onewire.Pins.
+func (o *OneWire) Q() gpio.PinIO {
+	return o.q
+}
+
+//
+
+// Standard speed timings, from Maxim's application note 126.
+const (
+	owA = 6 * time.Microsecond   // write 1 and read low time
+	owB = 64 * time.Microsecond  // write 1 recovery
+	owC = 60 * time.Microsecond  // write 0 low time
+	owD = 10 * time.Microsecond  // write 0 recovery
+	owE = 9 * time.Microsecond   // read sample delay
+	owF = 55 * time.Microsecond  // read recovery
+	owH = 480 * time.Microsecond // reset low time
+	owI = 70 * time.Microsecond  // presence sample delay
+	owJ = 410 * time.Microsecond // reset recovery
+)
+
+// reset sends a reset pulse and checks for a presence pulse.
+func (o *OneWire) reset() error {
+	if err := o.release(); err != nil {
+		return err
+	}
+	if o.q.Read() == gpio.Low {
+		return shortedBusError("bitbang-onewire: bus is shorted")
+	}
+	if err := o.q.Out(gpio.Low); err != nil {
+		return err
+	}
+	o.delay(owH)
+	if err := o.release(); err != nil {
+		return err
+	}
+	o.delay(owI)
+	present := o.q.Read() == gpio.Low
+	o.delay(owJ)
+	if !present {
+		return noDevicesError("bitbang-onewire: no device present")
+	}
+	return nil
+}
+
+// writeByte sends b LSB first.
+func (o *OneWire) writeByte(b byte) error {
+	for i := 0; i < 8; i++ {
+		if err := o.writeBit(b&(1<<uint(i)) != 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readByte reads a byte LSB first.
+func (o *OneWire) readByte() (byte, error) {
+	var b byte
+	for i := 0; i < 8; i++ {
+		l, err := o.readBit()
+		if err != nil {
+			return 0, err
+		}
+		if l {
+			b |= 1 << uint(i)
+		}
+	}
+	return b, nil
+}
+
+func (o *OneWire) writeBit(one bool) error {
+	if err := o.q.Out(gpio.Low); err != nil {
+		return err
+	}
+	if one {
+		o.delay(owA)
+	} else {
+		o.delay(owC)
+	}
+	if err := o.release(); err != nil {
+		return err
+	}
+	if one {
+		o.delay(owB)
+	} else {
+		o.delay(owD)
+	}
+	return nil
+}
+
+func (o *OneWire) readBit() (gpio.Level, error) {
+	if err := o.q.Out(gpio.Low); err != nil {
+		return gpio.Low, err
+	}
+	o.delay(owA)
+	if err := o.release(); err != nil {
+		return gpio.Low, err
+	}
+	o.delay(owE)
+	l := o.q.Read()
+	o.delay(owF)
+	return l, nil
+}
+
+// release stops driving the line, letting the pull-up pull it high.
+func (o *OneWire) release() error {
+	return o.q.In(gpio.PullUp, gpio.NoEdge)
+}
+
+// noDevicesError implements error and onewire.NoDevicesError.
+type noDevicesError string
+
+func (e noDevicesError) Error() string   { return string(e) }
+func (e noDevicesError) NoDevices() bool { return true }
+
+// shortedBusError implements error and onewire.ShortedBusError.
+type shortedBusError string
+
+func (e shortedBusError) Error() string   { return string(e) }
+func (e shortedBusError) IsShorted() bool { return true }
+func (e shortedBusError) BusError() bool  { return true }
+
+var _ onewire.BusCloser = &OneWire{}
+var _ onewire.BusSearcher = &OneWire{}
+var _ onewire.Pins = &OneWire{}
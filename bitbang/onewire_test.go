@@ -0,0 +1,256 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bitbang
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/onewire"
+)
+
+func TestOneWire_Search(t *testing.T) {
+	a1 := owAddr(0x28, 0x000001318252)
+	a2 := owAddr(0x28, 0x000001318253)
+	a3 := owAddr(0x10, 0x0000A0000001)
+	b := newOWBus(a1, a2, a3)
+	o := newTestOneWire(t, b)
+	got, err := o.Search(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []onewire.Address{a3, a1, a2}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Search() = %#x, want %#x", got, want)
+	}
+}
+
+func TestOneWire_Tx(t *testing.T) {
+	b := newOWBus(owAddr(0x28, 1))
+	b.devs[0].scratchpad = []byte{0x50, 0x05, 0x4B, 0x46, 0x7F, 0xFF, 0x0C, 0x10, 0x1C}
+	o := newTestOneWire(t, b)
+	// Skip ROM, Convert T with a strong pull-up.
+	if err := o.Tx([]byte{0xCC, 0x44}, nil, onewire.StrongPullup); err != nil {
+		t.Fatal(err)
+	}
+	if !b.strong {
+		t.Fatal("expected strong pull-up")
+	}
+	// Skip ROM, Read Scratchpad.
+	r := make([]byte, 9)
+	if err := o.Tx([]byte{0xCC, 0xBE}, r, onewire.WeakPullup); err != nil {
+		t.Fatal(err)
+	}
+	if b.strong {
+		t.Fatal("strong pull-up not released")
+	}
+	if !bytes.Equal(r, b.devs[0].scratchpad) {
+		t.Fatalf("read %#v", r)
+	}
+	if !reflect.DeepEqual(b.devs[0].cmds, []byte{0x44, 0xBE}) {
+		t.Fatalf("device got %#v", b.devs[0].cmds)
+	}
+}
+
+func TestOneWire_errors(t *testing.T) {
+	b := newOWBus()
+	o := newTestOneWire(t, b)
+	err := o.Tx([]byte{0xCC}, nil, onewire.WeakPullup)
+	if e, ok := err.(onewire.NoDevicesError); !ok || !e.NoDevices() {
+		t.Fatalf("expected no devices, got %v", err)
+	}
+	b.shorted = true
+	err = o.Tx([]byte{0xCC}, nil, onewire.WeakPullup)
+	if e, ok := err.(onewire.ShortedBusError); !ok || !e.IsShorted() {
+		t.Fatalf("expected shorted bus, got %v", err)
+	}
+	if _, err := NewOneWire(nil); err == nil {
+		t.Fatal("missing pin")
+	}
+}
+
+//
+
+func newTestOneWire(t *testing.T, b *owBus) *OneWire {
+	o, err := NewOneWire(b.q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.delay = b.delay
+	if s := o.String(); s != "bitbang-onewire(Q)" {
+		t.Fatal(s)
+	}
+	return o
+}
+
+// owAddr returns a valid address for the family and serial number.
+func owAddr(family byte, serial uint64) onewire.Address {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], serial<<8|uint64(family))
+	buf[7] = onewire.CalcCRC(buf[:7])
+	return onewire.Address(binary.LittleEndian.Uint64(buf[:]))
+}
+
+// owBus simulates the line with a virtual clock advanced by the master's
+// delays; the devices decode the slots from the length of the low pulses.
+type owBus struct {
+	q       *owPin
+	devs    []*owDevice
+	now     time.Duration
+	lowAt   time.Duration
+	low     bool // driven low by the master
+	devLow  bool // driven low by a device
+	strong  bool
+	shorted bool
+}
+
+func newOWBus(addrs ...onewire.Address) *owBus {
+	b := &owBus{}
+	b.q = &owPin{b: b}
+	for _, a := range addrs {
+		b.devs = append(b.devs, &owDevice{addr: uint64(a)})
+	}
+	return b
+}
+
+func (b *owBus) delay(d time.Duration) {
+	b.now += d
+}
+
+// pulse is called when the master releases the line after a low pulse.
+func (b *owBus) pulse(d time.Duration) {
+	b.devLow = false
+	for _, dev := range b.devs {
+		var drive bool
+		if d >= 400*time.Microsecond {
+			drive = dev.reset()
+		} else {
+			drive = dev.slot(d < 15*time.Microsecond)
+		}
+		b.devLow = b.devLow || drive
+	}
+}
+
+type owPin struct {
+	gpio.PinIO
+	b *owBus
+}
+
+func (p *owPin) String() string {
+	return "Q"
+}
+
+func (p *owPin) In(pull gpio.Pull, edge gpio.Edge) error {
+	p.b.strong = false
+	if p.b.low {
+		p.b.low = false
+		p.b.pulse(p.b.now - p.b.lowAt)
+	}
+	return nil
+}
+
+func (p *owPin) Out(l gpio.Level) error {
+	if l {
+		p.b.strong = true
+		p.b.low = false
+		return nil
+	}
+	p.b.low = true
+	p.b.devLow = false
+	p.b.lowAt = p.b.now
+	return nil
+}
+
+func (p *owPin) Read() gpio.Level {
+	return gpio.Level(!p.b.shorted && !p.b.low && !p.b.devLow)
+}
+
+// owDevice supports Search ROM, Skip ROM and a function command 0xBE
+// returning its scratchpad.
+type owDevice struct {
+	addr       uint64
+	scratchpad []byte
+	cmds       []byte
+
+	state int
+	bits  int // bits received or sent in the current state
+	shift byte
+}
+
+const (
+	owIdle = iota
+	owROMCmd
+	owSearch
+	owFuncCmd
+	owSend
+)
+
+func (d *owDevice) reset() bool {
+	d.state = owROMCmd
+	d.bits = 0
+	d.shift = 0
+	return true
+}
+
+// slot handles a time slot and returns true to pull the line low.
+func (d *owDevice) slot(one bool) bool {
+	switch d.state {
+	case owROMCmd, owFuncCmd:
+		if one {
+			d.shift |= 1 << uint(d.bits)
+		}
+		if d.bits++; d.bits == 8 {
+			d.command(d.shift)
+		}
+	case owSearch:
+		bit := d.addr&(1<<uint(d.bits/3)) != 0
+		switch d.bits % 3 {
+		case 0:
+			d.bits++
+			return !bit
+		case 1:
+			d.bits++
+			return bit
+		default:
+			if one != bit {
+				d.state = owIdle
+			} else if d.bits++; d.bits == 64*3 {
+				d.state = owIdle
+			}
+		}
+	case owSend:
+		i := d.bits / 8
+		if i >= len(d.scratchpad) {
+			return false
+		}
+		d.bits++
+		return d.scratchpad[i]&(1<<uint((d.bits-1)%8)) == 0
+	}
+	return false
+}
+
+func (d *owDevice) command(c byte) {
+	state := d.state
+	d.bits = 0
+	d.shift = 0
+	d.state = owIdle
+	if state == owROMCmd {
+		switch c {
+		case 0xF0:
+			d.state = owSearch
+		case 0xCC:
+			d.state = owFuncCmd
+		}
+		return
+	}
+	d.cmds = append(d.cmds, c)
+	if c == 0xBE {
+		d.state = owSend
+	}
+}
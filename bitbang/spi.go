@@ -0,0 +1,314 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bitbang
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+	"periph.io/x/conn/v3/spi/spireg"
+)
+
+// NewSPI returns an SPI master bit-banging on the pins clk, mosi, miso and
+// cs.
+//
+// miso can be nil for devices that are only written to, in which case the
+// read buffers must be empty. cs can be nil for a single device with its chip
+// select tied low, or to manage it separately; it is active low.
+//
+// The modes 0 to 3 are supported, along with spi.LSBFirst and spi.NoCS. Words
+// can be 1 to 8 bits; words shorter than 8 bits use the low bits of each
+// byte.
+//
+// The resulting object is safe for concurrent use.
+func NewSPI(clk, mosi gpio.PinOut, miso gpio.PinIn, cs gpio.PinOut) (*SPI, error) {
+	if clk == nil || mosi == nil {
+		return nil, errors.New("bitbang-spi: CLK and MOSI are required")
+	}
+	s := &SPI{clk: clk, mosi: mosi, miso: miso, cs: cs, bits: 8}
+	if cs != nil {
+		if err := cs.Out(gpio.High); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// RegisterSPI registers a bit-banged SPI port in spireg as name.
+//
+// The port is created by NewSPI each time it is opened.
+func RegisterSPI(name string, clk, mosi gpio.PinOut, miso gpio.PinIn, cs gpio.PinOut) error {
+	opener := func() (spi.PortCloser, error) {
+		return NewSPI(clk, mosi, miso, cs)
+	}
+	return spireg.Register(name, nil, -1, opener)
+}
+
+// SPI is a bit-banged SPI master.
+//
+// It implements both spi.PortCloser and spi.Conn.
+type SPI struct {
+	clk  gpio.PinOut
+	mosi gpio.PinOut
+	miso gpio.PinIn
+	cs   gpio.PinOut
+
+	mu      sync.Mutex
+	maxFreq physic.Frequency // Frequency specified at LimitSpeed()
+	half    time.Duration
+	mode    spi.Mode
+	bits    int
+}
+
+// Close releases the chip select line.
+func (s *SPI) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cs != nil {
+		return s.cs.Out(gpio.High)
+	}
+	return nil
+}
+
+func (s *SPI) String() string {
+	return fmt.Sprintf("bitbang-spi(%s,%s,%s,%s)", s.clk, s.mosi, s.miso, s.cs)
+}
+
+// Connect implements spi.Port.
+//
+// The default speed is 1MHz. The actual speed is usually lower, as it is
+// limited by the GPIO driver latency.
+func (s *SPI) Connect(f physic.Frequency, m spi.Mode, bits int) (spi.Conn, error) {
+	if f < 0 || (f != 0 && f < 100*physic.Hertz) {
+		return nil, fmt.Errorf("bitbang-spi: invalid speed %s; did you forget to multiply by physic.MegaHertz?", f)
+	}
+	if m&^(spi.Mode3|spi.NoCS|spi.LSBFirst) != 0 {
+		return nil, fmt.Errorf("bitbang-spi: unsupported mode %s", m)
+	}
+	if bits < 1 || bits > 8 {
+		return nil, errors.New("bitbang-spi: only 1 to 8 bits per word are supported")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxFreq != 0 && (f == 0 || f > s.maxFreq) {
+		f = s.maxFreq
+	}
+	if f == 0 {
+		f = physic.MegaHertz
+	}
+	s.half = halfPeriod(f)
+	s.mode = m
+	s.bits = bits
+	// Set the clock idle level before asserting the chip select.
+	if err := s.clk.Out(s.idle()); err != nil {
+		return nil, err
+	}
+	if s.cs != nil {
+		if err := s.cs.Out(gpio.High); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// LimitSpeed implements spi.Port.
+func (s *SPI) LimitSpeed(f physic.Frequency) error {
+	if f < 100*physic.Hertz {
+		return errors.New("bitbang-spi: minimum supported clock is 100Hz; did you forget to multiply by physic.MegaHertz?")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxFreq = f
+	return nil
+}
+
+// Duplex implements conn.Conn.
+func (s *SPI) Duplex() conn.Duplex {
+	return conn.Full
+}
+
+// Tx implements conn.Conn.
+func (s *SPI) Tx(w, r []byte) error {
+	var p = [1]spi.Packet{{W: w, R: r}}
+	return s.TxPackets(p[:])
+}
+
+// TxPackets implements spi.Conn.
+//
+// The chip select is kept asserted between packets with KeepCS set, and is
+// always released at the end.
+func (s *SPI) TxPackets(pkts []spi.Packet) error {
+	for _, p := range pkts {
+		if p.BitsPerWord > 8 {
+			return errors.New("bitbang-spi: only 1 to 8 bits per word are supported")
+		}
+		if len(p.W) != 0 && len(p.R) != 0 && len(p.W) != len(p.R) {
+			return errors.New("bitbang-spi: both buffers must have the same size")
+		}
+		if len(p.R) != 0 && s.miso == nil {
+			return errors.New("bitbang-spi: can't read without MISO")
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.half == 0 {
+		return errors.New("bitbang-spi: call Connect() first")
+	}
+	selected := false
+	for j, p := range pkts {
+		if !selected {
+			if err := s.selectCS(true); err != nil {
+				return err
+			}
+			selected = true
+		}
+		if err := s.txPacket(&p); err != nil {
+			_ = s.selectCS(false)
+			return err
+		}
+		if !p.KeepCS || j == len(pkts)-1 {
+			if err := s.selectCS(false); err != nil {
+				return err
+			}
+			selected = false
+		}
+	}
+	return nil
+}
+
+// CLK implements spi.Pins.
+func (s *SPI) CLK() gpio.PinOut {
+	return s.clk
+}
+
+// MOSI implements spi.Pins.
+func (s *SPI) MOSI() gpio.PinOut {
+	return s.mosi
+}
+
+// MISO implements spi.Pins.
+func (s *SPI) MISO() gpio.PinIn {
+	if s.miso == nil {
+		return gpio.INVALID
+	}
+	return s.miso
+}
+
+// CS implements spi.Pins.
+func (s *SPI) CS() gpio.PinOut {
+	if s.cs == nil {
+		return gpio.INVALID
+	}
+	return s.cs
+}
+
+//
+
+// idle returns the clock level when idle, the clock polarity.
+func (s *SPI) idle() gpio.Level {
+	return s.mode&spi.Mode2 != 0
+}
+
+func (s *SPI) selectCS(active bool) error {
+	if s.cs == nil || s.mode&spi.NoCS != 0 {
+		return nil
+	}
+	if err := s.cs.Out(gpio.Level(!active)); err != nil {
+		return err
+	}
+	spin(s.half)
+	return nil
+}
+
+func (s *SPI) txPacket(p *spi.Packet) error {
+	bits := s.bits
+	if p.BitsPerWord != 0 {
+		bits = int(p.BitsPerWord)
+	}
+	n := len(p.W)
+	if len(p.R) > n {
+		n = len(p.R)
+	}
+	for j := 0; j < n; j++ {
+		var w byte
+		if len(p.W) != 0 {
+			w = p.W[j]
+		}
+		r, err := s.txWord(w, bits)
+		if err != nil {
+			return err
+		}
+		if len(p.R) != 0 {
+			p.R[j] = r
+		}
+	}
+	return nil
+}
+
+// txWord clocks out the low bits of w and returns the bits read.
+func (s *SPI) txWord(w byte, bits int) (byte, error) {
+	idle := s.idle()
+	cpha := s.mode&spi.Mode1 != 0
+	var r byte
+	for j := 0; j < bits; j++ {
+		shift := uint(bits - 1 - j)
+		if s.mode&spi.LSBFirst != 0 {
+			shift = uint(j)
+		}
+		out := gpio.Level(w&(1<<shift) != 0)
+		var in gpio.Level
+		if cpha {
+			// Data changes on the leading edge and is sampled on the trailing
+			// edge.
+			if err := s.clk.Out(!idle); err != nil {
+				return 0, err
+			}
+			if err := s.mosi.Out(out); err != nil {
+				return 0, err
+			}
+			spin(s.half)
+			if err := s.clk.Out(idle); err != nil {
+				return 0, err
+			}
+			in = s.read()
+			spin(s.half)
+		} else {
+			// Data is valid before the leading edge, where it is sampled.
+			if err := s.mosi.Out(out); err != nil {
+				return 0, err
+			}
+			spin(s.half)
+			if err := s.clk.Out(!idle); err != nil {
+				return 0, err
+			}
+			in = s.read()
+			spin(s.half)
+			if err := s.clk.Out(idle); err != nil {
+				return 0, err
+			}
+		}
+		if in {
+			r |= 1 << shift
+		}
+	}
+	return r, nil
+}
+
+func (s *SPI) read() gpio.Level {
+	if s.miso == nil {
+		return gpio.Low
+	}
+	return s.miso.Read()
+}
+
+var _ spi.PortCloser = &SPI{}
+var _ spi.Conn = &SPI{}
+var _ spi.Pins = &SPI{}
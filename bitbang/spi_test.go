@@ -0,0 +1,221 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bitbang
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+func TestSPI_modes(t *testing.T) {
+	for _, m := range []spi.Mode{spi.Mode0, spi.Mode1, spi.Mode2, spi.Mode3} {
+		d := newSPIDevice(m, []byte{0x3C, 0x81, 0xF0})
+		s := newTestSPI(t, d)
+		c, err := s.Connect(physic.MegaHertz, m, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := make([]byte, 3)
+		if err := c.Tx([]byte{0xA5, 0x01, 0x80}, r); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(d.got, []byte{0xA5, 0x01, 0x80}) {
+			t.Fatalf("%s: device got %#v", m, d.got)
+		}
+		if !bytes.Equal(r, []byte{0x3C, 0x81, 0xF0}) {
+			t.Fatalf("%s: read %#v", m, r)
+		}
+		if d.selected || d.clk.l != gpio.Level(m&spi.Mode2 != 0) {
+			t.Fatalf("%s: bus is not idle", m)
+		}
+	}
+}
+
+func TestSPI_LSBFirst(t *testing.T) {
+	d := newSPIDevice(spi.Mode0, []byte{0x01})
+	s := newTestSPI(t, d)
+	c, err := s.Connect(physic.MegaHertz, spi.Mode0|spi.LSBFirst, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := make([]byte, 1)
+	if err := c.Tx([]byte{0x03}, r); err != nil {
+		t.Fatal(err)
+	}
+	if d.got[0] != 0xC0 || r[0] != 0x80 {
+		t.Fatalf("device got %#x, read %#x", d.got[0], r[0])
+	}
+}
+
+func TestSPI_packets(t *testing.T) {
+	d := newSPIDevice(spi.Mode0, nil)
+	s := newTestSPI(t, d)
+	c, err := s.Connect(0, spi.Mode0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := []spi.Packet{
+		{W: []byte{0x01}, BitsPerWord: 1, KeepCS: true},
+		{W: []byte{0x55}, BitsPerWord: 7},
+		{W: []byte{0xFF}},
+	}
+	if err := c.TxPackets(p); err != nil {
+		t.Fatal(err)
+	}
+	// The first two packets form one 8 bits transaction.
+	if !bytes.Equal(d.got, []byte{0xD5, 0xFF}) || d.selects != 2 {
+		t.Fatalf("device got %#v in %d transactions", d.got, d.selects)
+	}
+}
+
+func TestSPI_invalid(t *testing.T) {
+	d := newSPIDevice(spi.Mode0, nil)
+	s, err := NewSPI(d.clk, d.mosi, nil, d.cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Tx([]byte{1}, nil); err == nil {
+		t.Fatal("not connected")
+	}
+	if _, err := s.Connect(10*physic.Hertz, spi.Mode0, 8); err == nil {
+		t.Fatal("too slow")
+	}
+	if _, err := s.Connect(physic.MegaHertz, spi.HalfDuplex, 8); err == nil {
+		t.Fatal("half duplex")
+	}
+	if _, err := s.Connect(physic.MegaHertz, spi.Mode0, 9); err == nil {
+		t.Fatal("9 bits")
+	}
+	if _, err := s.Connect(physic.MegaHertz, spi.Mode0, 8); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Tx([]byte{1}, make([]byte, 1)); err == nil {
+		t.Fatal("no MISO")
+	}
+	if err := s.Tx([]byte{1, 2}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSPI(nil, d.mosi, nil, nil); err == nil {
+		t.Fatal("no CLK")
+	}
+	if s.MISO() != gpio.INVALID {
+		t.Fatal("MISO")
+	}
+}
+
+//
+
+func newTestSPI(t *testing.T, d *spiDevice) *SPI {
+	s, err := NewSPI(d.clk, d.mosi, d.miso, d.cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.String(); got != "bitbang-spi(CLK,MOSI,MISO,CS)" {
+		t.Fatal(got)
+	}
+	return s
+}
+
+// spiDevice simulates an SPI device in the mode m, recording the bits
+// received MSB first and sending out.
+type spiDevice struct {
+	m    spi.Mode
+	clk  *spiPin
+	mosi *spiPin
+	miso *spiPin
+	cs   *spiPin
+
+	selected bool
+	selects  int
+	nbits    int
+	got      []byte
+	out      []byte
+	misoBit  gpio.Level
+}
+
+func newSPIDevice(m spi.Mode, out []byte) *spiDevice {
+	d := &spiDevice{m: m, out: out}
+	d.clk = &spiPin{d: d, name: "CLK"}
+	d.mosi = &spiPin{d: d, name: "MOSI"}
+	d.miso = &spiPin{d: d, name: "MISO"}
+	d.cs = &spiPin{d: d, name: "CS", l: gpio.High}
+	return d
+}
+
+// shiftOut puts the next bit on MISO.
+func (d *spiDevice) shiftOut() {
+	i := d.nbits / 8
+	if i < len(d.out) {
+		d.misoBit = d.out[i]&(0x80>>uint(d.nbits%8)) != 0
+	}
+}
+
+func (d *spiDevice) sample() {
+	if d.nbits%8 == 0 {
+		d.got = append(d.got, 0)
+	}
+	if d.mosi.l {
+		d.got[len(d.got)-1] |= 0x80 >> uint(d.nbits%8)
+	}
+	d.nbits++
+}
+
+func (d *spiDevice) changed(p *spiPin) {
+	switch p {
+	case d.cs:
+		d.selected = p.l == gpio.Low
+		if d.selected {
+			d.selects++
+			if d.m&spi.Mode1 == 0 {
+				d.shiftOut()
+			}
+		}
+	case d.clk:
+		if !d.selected {
+			return
+		}
+		leading := p.l != gpio.Level(d.m&spi.Mode2 != 0)
+		if d.m&spi.Mode1 == 0 {
+			if leading {
+				d.sample()
+			} else {
+				d.shiftOut()
+			}
+		} else {
+			if leading {
+				d.shiftOut()
+			} else {
+				d.sample()
+			}
+		}
+	}
+}
+
+type spiPin struct {
+	gpio.PinIO
+	d    *spiDevice
+	name string
+	l    gpio.Level
+}
+
+func (p *spiPin) String() string {
+	return p.name
+}
+
+func (p *spiPin) Out(l gpio.Level) error {
+	if p.l != l {
+		p.l = l
+		p.d.changed(p)
+	}
+	return nil
+}
+
+func (p *spiPin) Read() gpio.Level {
+	return p.d.misoBit
+}
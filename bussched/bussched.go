@@ -0,0 +1,177 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bussched
+
+import (
+	"fmt"
+	"sync"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+// Priority is the priority of a lane. The transactions with a higher
+// priority are granted the bus first.
+//
+// A lane that keeps the bus busy starves the lanes with a lower priority.
+type Priority int
+
+// Common priorities.
+const (
+	Normal Priority = 0
+	High   Priority = 10
+)
+
+// Scheduler grants a bus to one transaction at a time.
+//
+// The transactions waiting are granted the bus by decreasing priority, then
+// in the order they were started.
+//
+// The zero value is ready to use. It is safe for concurrent use.
+type Scheduler struct {
+	mu      sync.Mutex
+	busy    bool
+	seq     uint64
+	waiting []*waiter
+}
+
+// Do runs f with exclusive access to the bus, once its turn comes.
+func (s *Scheduler) Do(p Priority, f func() error) error {
+	s.acquire(p)
+	defer s.release()
+	return f()
+}
+
+// I2C returns a view of b whose transactions are scheduled at priority p.
+//
+// All the devices on b must use a view from the same Scheduler.
+func (s *Scheduler) I2C(b i2c.Bus, p Priority) *I2C {
+	return &I2C{s: s, b: b, p: p}
+}
+
+// SPI returns a view of c whose transactions are scheduled at priority p.
+//
+// The connections of all the devices on the same bus, e.g. on different chip
+// selects, must use a view from the same Scheduler.
+func (s *Scheduler) SPI(c spi.Conn, p Priority) *SPI {
+	return &SPI{s: s, c: c, p: p}
+}
+
+// I2C is an i2c.Bus scheduled by a Scheduler.
+type I2C struct {
+	s *Scheduler
+	b i2c.Bus
+	p Priority
+}
+
+func (i *I2C) String() string {
+	return fmt.Sprintf("bussched(%s)", i.b)
+}
+
+// Tx implements i2c.Bus.
+func (i *I2C) Tx(addr uint16, w, r []byte) error {
+	return i.s.Do(i.p, func() error { return i.b.Tx(addr, w, r) })
+}
+
+// SetSpeed implements i2c.Bus.
+func (i *I2C) SetSpeed(f physic.Frequency) error {
+	return i.s.Do(i.p, func() error { return i.b.SetSpeed(f) })
+}
+
+// SCL implements i2c.Pins.
+func (i *I2C) SCL() gpio.PinIO {
+	if p, ok := i.b.(i2c.Pins); ok {
+		return p.SCL()
+	}
+	return gpio.INVALID
+}
+
+// SDA implements i2c.Pins.
+func (i *I2C) SDA() gpio.PinIO {
+	if p, ok := i.b.(i2c.Pins); ok {
+		return p.SDA()
+	}
+	return gpio.INVALID
+}
+
+// SPI is a spi.Conn scheduled by a Scheduler.
+type SPI struct {
+	s *Scheduler
+	c spi.Conn
+	p Priority
+}
+
+func (c *SPI) String() string {
+	return fmt.Sprintf("bussched(%s)", c.c)
+}
+
+// Duplex implements conn.Conn.
+func (c *SPI) Duplex() conn.Duplex {
+	return c.c.Duplex()
+}
+
+// Tx implements conn.Conn.
+func (c *SPI) Tx(w, r []byte) error {
+	return c.s.Do(c.p, func() error { return c.c.Tx(w, r) })
+}
+
+// TxPackets implements spi.Conn.
+//
+// The packets are sent as a single transaction.
+func (c *SPI) TxPackets(p []spi.Packet) error {
+	return c.s.Do(c.p, func() error { return c.c.TxPackets(p) })
+}
+
+//
+
+// waiter is a transaction waiting for the bus.
+type waiter struct {
+	p     Priority
+	seq   uint64
+	ready chan struct{}
+}
+
+func (s *Scheduler) acquire(p Priority) {
+	s.mu.Lock()
+	if !s.busy {
+		s.busy = true
+		s.mu.Unlock()
+		return
+	}
+	w := &waiter{p: p, seq: s.seq, ready: make(chan struct{})}
+	s.seq++
+	s.waiting = append(s.waiting, w)
+	s.mu.Unlock()
+	<-w.ready
+}
+
+// release hands the bus over to the next waiter, if any.
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.waiting) == 0 {
+		s.busy = false
+		return
+	}
+	next := 0
+	for i, w := range s.waiting[1:] {
+		if n := s.waiting[next]; w.p > n.p || (w.p == n.p && w.seq < n.seq) {
+			next = i + 1
+		}
+	}
+	w := s.waiting[next]
+	copy(s.waiting[next:], s.waiting[next+1:])
+	s.waiting[len(s.waiting)-1] = nil
+	s.waiting = s.waiting[:len(s.waiting)-1]
+	// The bus stays busy, owned by w.
+	close(w.ready)
+}
+
+var _ i2c.Bus = &I2C{}
+var _ i2c.Pins = &I2C{}
+var _ spi.Conn = &SPI{}
@@ -0,0 +1,103 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package bussched
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/i2c/i2ctest"
+	"periph.io/x/conn/v3/spi/spitest"
+)
+
+func TestScheduler_order(t *testing.T) {
+	var s Scheduler
+	hold := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		_ = s.Do(Normal, func() error {
+			<-hold
+			return nil
+		})
+		close(done)
+	}()
+	waitQueued(t, &s, 0)
+
+	var mu sync.Mutex
+	var got []int
+	var wg sync.WaitGroup
+	for i, p := range []Priority{Normal, High, Normal, High} {
+		wg.Add(1)
+		go func(i int, p Priority) {
+			defer wg.Done()
+			_ = s.Do(p, func() error {
+				mu.Lock()
+				got = append(got, i)
+				mu.Unlock()
+				return nil
+			})
+		}(i, p)
+		// Start them one at a time so their order is known.
+		waitQueued(t, &s, i+1)
+	}
+	close(hold)
+	wg.Wait()
+	<-done
+	if want := []int{1, 3, 0, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatal(got)
+	}
+	if s.busy || len(s.waiting) != 0 {
+		t.Fatal("bus still held")
+	}
+}
+
+func TestI2C(t *testing.T) {
+	var s Scheduler
+	r := &i2ctest.Record{}
+	b := s.I2C(r, High)
+	if err := b.Tx(0x40, []byte{1}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Ops) != 1 || r.Ops[0].Addr != 0x40 {
+		t.Fatal(r.Ops)
+	}
+	if str := b.String(); str != "bussched(record)" {
+		t.Fatal(str)
+	}
+}
+
+func TestSPI(t *testing.T) {
+	var s Scheduler
+	r := &spitest.Record{}
+	c, err := r.Connect(0, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := s.SPI(c, Normal)
+	if err := b.Tx([]byte{1, 2}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Ops) != 1 {
+		t.Fatal(r.Ops)
+	}
+}
+
+//
+
+// waitQueued waits until n transactions are waiting for the bus.
+func waitQueued(t *testing.T, s *Scheduler, n int) {
+	for end := time.Now().Add(time.Second); time.Now().Before(end); time.Sleep(time.Millisecond) {
+		s.mu.Lock()
+		l := len(s.waiting)
+		busy := s.busy
+		s.mu.Unlock()
+		if busy && l == n {
+			return
+		}
+	}
+	t.Fatalf("expected %d waiters", n)
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package bussched schedules the transactions of the devices sharing a bus.
+//
+// The bus drivers serialize the transactions with a plain mutex, which
+// doesn't guarantee any order between the goroutines waiting for the bus. A
+// Scheduler grants the bus in FIFO order instead, and lets the latency
+// sensitive devices skip the queue with a higher priority.
+//
+// Each device gets its own view of the bus, wrapping an i2c.Bus or a
+// spi.Conn, with its priority. Any other bus can be scheduled with Do().
+package bussched
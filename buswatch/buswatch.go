@@ -0,0 +1,272 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package buswatch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/spi"
+)
+
+// Check is a health check of a bus or a pin.
+type Check interface {
+	String() string
+	// Check returns nil when the resource is healthy.
+	Check() error
+}
+
+// Recoverer is implemented by the checks that can try to recover from a
+// failure.
+//
+// It is also implemented by the buses with a driver-specific recovery, like
+// the I²C bus of the ftdi package.
+type Recoverer interface {
+	Recover() error
+}
+
+// I2C returns a check that each device at addrs acknowledges a one byte read
+// on b.
+//
+// When b implements Recoverer, it is used to recover the bus.
+func I2C(b i2c.Bus, addrs ...uint16) Check {
+	c := &i2cCheck{b: b, addrs: addrs}
+	if r, ok := b.(Recoverer); ok {
+		return WithRecovery(c, r.Recover)
+	}
+	return c
+}
+
+// SPILoopback returns a check that the bytes written on c are read back, for
+// a port whose MISO is wired to MOSI.
+func SPILoopback(c spi.Conn) Check {
+	return &spiCheck{c: c}
+}
+
+// GPIO returns a check that p reads want, e.g. a power good signal.
+func GPIO(p gpio.PinIn, want gpio.Level) Check {
+	return &gpioCheck{p: p, want: want}
+}
+
+// GPIOOut returns a check that the output p reads back want, e.g. an enable
+// line. The recovery drives p to want again.
+func GPIOOut(p gpio.PinIO, want gpio.Level) Check {
+	c := &gpioCheck{p: p, want: want}
+	return WithRecovery(c, func() error { return p.Out(want) })
+}
+
+// WithRecovery returns c with the recovery f, e.g. power cycling a device
+// through a GPIO.
+func WithRecovery(c Check, f func() error) Check {
+	return &recoverable{c: c, f: f}
+}
+
+// Event is the result of a check that failed, or that passes again.
+type Event struct {
+	// Check is the check's String().
+	Check string
+	Time  time.Time
+	// Err is the failure, or nil when the check passes again after failing.
+	Err error
+	// Recovered is true when the check passes after the recovery.
+	Recovered bool
+	// RecoverErr is the error returned by the recovery, if it was attempted.
+	RecoverErr error
+}
+
+func (e *Event) String() string {
+	if e.Err == nil {
+		return e.Check + ": healthy"
+	}
+	s := fmt.Sprintf("%s: %v", e.Check, e.Err)
+	switch {
+	case e.Recovered:
+		s += "; recovered"
+	case e.RecoverErr != nil:
+		s += fmt.Sprintf("; recovery failed: %v", e.RecoverErr)
+	}
+	return s
+}
+
+// New starts a Watchdog running checks every period, starting immediately.
+//
+// The checks run one after the other in the same goroutine, so a check
+// doesn't need to be safe for concurrent use with the others.
+func New(period time.Duration, checks ...Check) (*Watchdog, error) {
+	if period <= 0 {
+		return nil, errors.New("buswatch: invalid period")
+	}
+	if len(checks) == 0 {
+		return nil, errors.New("buswatch: no check")
+	}
+	w := &Watchdog{
+		checks:  checks,
+		failing: make([]bool, len(checks)),
+		c:       make(chan Event, 16),
+		done:    make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.run(period)
+	return w, nil
+}
+
+// Watchdog runs health checks periodically and attempts to recover the
+// resources failing them.
+type Watchdog struct {
+	checks  []Check
+	failing []bool
+	c       chan Event
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// Events returns the channel receiving an Event each time a check fails, and
+// when it passes again.
+//
+// A check failing persistently emits an Event on each period, with the
+// outcome of its recovery. The channel is closed by Close. The events are
+// dropped if the channel is not drained.
+func (w *Watchdog) Events() <-chan Event {
+	return w.c
+}
+
+// Close stops the checks.
+func (w *Watchdog) Close() error {
+	w.once.Do(func() {
+		close(w.done)
+		w.wg.Wait()
+	})
+	return nil
+}
+
+//
+
+func (w *Watchdog) run(period time.Duration) {
+	defer w.wg.Done()
+	defer close(w.c)
+	t := time.NewTicker(period)
+	defer t.Stop()
+	for {
+		w.checkAll()
+		select {
+		case <-w.done:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// checkAll runs each check once.
+func (w *Watchdog) checkAll() {
+	for i, c := range w.checks {
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+		e := Event{Check: c.String(), Time: time.Now(), Err: c.Check()}
+		if e.Err == nil {
+			if w.failing[i] {
+				w.failing[i] = false
+				w.emit(e)
+			}
+			continue
+		}
+		if r, ok := c.(Recoverer); ok {
+			if e.RecoverErr = r.Recover(); e.RecoverErr == nil {
+				e.Recovered = c.Check() == nil
+			}
+		}
+		w.failing[i] = !e.Recovered
+		w.emit(e)
+	}
+}
+
+func (w *Watchdog) emit(e Event) {
+	select {
+	case w.c <- e:
+	default:
+	}
+}
+
+type recoverable struct {
+	c Check
+	f func() error
+}
+
+func (r *recoverable) String() string {
+	return r.c.String()
+}
+
+func (r *recoverable) Check() error {
+	return r.c.Check()
+}
+
+func (r *recoverable) Recover() error {
+	return r.f()
+}
+
+type i2cCheck struct {
+	b     i2c.Bus
+	addrs []uint16
+}
+
+func (c *i2cCheck) String() string {
+	return fmt.Sprintf("i2c(%s)", c.b)
+}
+
+func (c *i2cCheck) Check() error {
+	var r [1]byte
+	for _, a := range c.addrs {
+		if err := c.b.Tx(a, nil, r[:]); err != nil {
+			return fmt.Errorf("buswatch: device %#x: %v", a, err)
+		}
+	}
+	return nil
+}
+
+// spiPattern toggles each bit both ways.
+var spiPattern = []byte{0x55, 0xAA, 0x00, 0xFF, 0x0F, 0xF0}
+
+type spiCheck struct {
+	c spi.Conn
+}
+
+func (c *spiCheck) String() string {
+	return fmt.Sprintf("spi(%s)", c.c)
+}
+
+func (c *spiCheck) Check() error {
+	r := make([]byte, len(spiPattern))
+	if err := c.c.Tx(spiPattern, r); err != nil {
+		return fmt.Errorf("buswatch: %v", err)
+	}
+	if !bytes.Equal(r, spiPattern) {
+		return fmt.Errorf("buswatch: loopback read %#x, expected %#x", r, spiPattern)
+	}
+	return nil
+}
+
+type gpioCheck struct {
+	p    gpio.PinIn
+	want gpio.Level
+}
+
+func (c *gpioCheck) String() string {
+	return fmt.Sprintf("gpio(%s)", c.p)
+}
+
+func (c *gpioCheck) Check() error {
+	if l := c.p.Read(); l != c.want {
+		return fmt.Errorf("buswatch: read %s, expected %s", l, c.want)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package buswatch
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+func TestNew_errors(t *testing.T) {
+	if _, err := New(0, GPIO(&gpiotest.Pin{N: "P"}, gpio.Low)); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := New(time.Second); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWatchdog_I2C(t *testing.T) {
+	b := &fakeI2C{stuck: true}
+	w, err := New(time.Millisecond, I2C(b, 0x20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := <-w.Events()
+	if e.Check != "i2c(fake)" || e.Err == nil || !e.Recovered || e.RecoverErr != nil {
+		t.Fatalf("unexpected event %s", &e)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-w.Events(); ok {
+		t.Fatal("expected no event")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.recovered != 1 {
+		t.Fatalf("expected one recovery, got %d", b.recovered)
+	}
+}
+
+func TestWatchdog_GPIOOut(t *testing.T) {
+	p := &gpiotest.Pin{N: "EN", L: gpio.Low}
+	c := GPIOOut(p, gpio.High)
+	if err := c.Check(); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := c.(Recoverer).Recover(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Check(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchdog_failing(t *testing.T) {
+	fail := make(chan error, 1)
+	fail <- errors.New("stuck")
+	c := &funcCheck{f: func() error {
+		select {
+		case err := <-fail:
+			return err
+		default:
+			return nil
+		}
+	}}
+	w, err := New(time.Millisecond, WithRecovery(c, func() error { return errors.New("no luck") }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	e := <-w.Events()
+	if e.Err == nil || e.Recovered || e.RecoverErr == nil {
+		t.Fatalf("unexpected event %s", &e)
+	}
+	if s := e.String(); s != "func: stuck; recovery failed: no luck" {
+		t.Fatal(s)
+	}
+	e = <-w.Events()
+	if e.Err != nil {
+		t.Fatalf("unexpected event %s", &e)
+	}
+	if s := e.String(); s != "func: healthy" {
+		t.Fatal(s)
+	}
+}
+
+func TestSPILoopback(t *testing.T) {
+	c := &fakeSPI{}
+	if err := SPILoopback(c).Check(); err == nil {
+		t.Fatal("expected error")
+	}
+	c.loop = true
+	if err := SPILoopback(c).Check(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//
+
+type funcCheck struct {
+	f func() error
+}
+
+func (f *funcCheck) String() string {
+	return "func"
+}
+
+func (f *funcCheck) Check() error {
+	return f.f()
+}
+
+// fakeI2C is a bus held by a device until recovered.
+type fakeI2C struct {
+	mu        sync.Mutex
+	stuck     bool
+	recovered int
+}
+
+func (f *fakeI2C) String() string {
+	return "fake"
+}
+
+func (f *fakeI2C) Tx(addr uint16, w, r []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.stuck {
+		return errors.New("nack")
+	}
+	return nil
+}
+
+func (f *fakeI2C) SetSpeed(physic.Frequency) error {
+	return nil
+}
+
+func (f *fakeI2C) Recover() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stuck = false
+	f.recovered++
+	return nil
+}
+
+// fakeSPI echoes the written bytes when loop is set.
+type fakeSPI struct {
+	loop bool
+}
+
+func (f *fakeSPI) String() string {
+	return "fake"
+}
+
+func (f *fakeSPI) Tx(w, r []byte) error {
+	if f.loop {
+		copy(r, w)
+	}
+	return nil
+}
+
+func (f *fakeSPI) Duplex() conn.Duplex {
+	return conn.Full
+}
+
+func (f *fakeSPI) TxPackets(p []spi.Packet) error {
+	return errors.New("not implemented")
+}
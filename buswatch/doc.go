@@ -0,0 +1,16 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package buswatch supervises the health of the buses and pins of an
+// unattended device, like a kiosk or a gateway, and tries to recover them.
+//
+// A Watchdog runs its checks periodically: an I²C probe of the devices
+// expected on a bus, a SPI loopback when MISO is wired to MOSI, or the
+// readback of a GPIO. When a check fails, the driver-specific recovery is
+// attempted, e.g. clocking out a device holding the I²C bus and
+// resynchronizing the MPSSE of a FTDI adapter, and an Event is emitted so the
+// application can log it or escalate.
+//
+// The package is optional; nothing is checked unless a Watchdog is created.
+package buswatch
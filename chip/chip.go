@@ -13,14 +13,14 @@ import (
 	"strconv"
 	"strings"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/allwinner"
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/fs"
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/pin"
 	"periph.io/x/conn/v3/pin/pinreg"
-	"periph.io/x/host/v3/allwinner"
-	"periph.io/x/host/v3/distro"
-	"periph.io/x/host/v3/fs"
 )
 
 // C.H.I.P. hardware pins.
@@ -351,7 +351,7 @@ func (d *driver) Init() (bool, error) {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drv)
+		hostcfg.MustRegister(&drv)
 	}
 }
 
@@ -13,11 +13,11 @@ import (
 	"sort"
 	"strconv"
 
+	"github.com/s-mobi01/host/allwinner"
+	"github.com/s-mobi01/host/chip"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/pin/pinreg"
-	"periph.io/x/host/v3/allwinner"
-	"periph.io/x/host/v3/chip"
 )
 
 // SmokeTest is imported by periph-smoketest.
@@ -0,0 +1,97 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package counter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+// Counter counts the edges of a signal.
+type Counter interface {
+	String() string
+	// Count returns the number of edges counted since the last Reset.
+	Count() (uint64, error)
+	// Reset sets the count back to 0.
+	Reset() error
+}
+
+// DutyCounter is a Counter that also tracks the time the signal is high.
+type DutyCounter interface {
+	Counter
+	// HighTime returns the total time the signal was high since the last
+	// Reset.
+	HighTime() (time.Duration, error)
+}
+
+// Measurement is the result of Measure.
+type Measurement struct {
+	// Count is the number of edges counted during the gate time.
+	Count uint64
+	// Gate is the effective gate time.
+	Gate time.Duration
+	// Frequency is Count divided by Gate.
+	Frequency physic.Frequency
+	// Duty is only set when the counter is a DutyCounter.
+	Duty gpio.Duty
+}
+
+func (m Measurement) String() string {
+	if m.Duty == 0 {
+		return fmt.Sprintf("%d in %s (%s)", m.Count, m.Gate, m.Frequency)
+	}
+	return fmt.Sprintf("%d in %s (%s, %s)", m.Count, m.Gate, m.Frequency, m.Duty)
+}
+
+// Measure counts the edges during the gate time and derives the frequency,
+// and the duty cycle when c is a DutyCounter.
+//
+// The counter is not reset, so it can be shared with a user of the total
+// count. The longer the gate time, the better the resolution: a 1s gate gives
+// a 1Hz resolution.
+func Measure(c Counter, gate time.Duration) (Measurement, error) {
+	var m Measurement
+	if gate <= 0 {
+		return m, fmt.Errorf("counter: invalid gate time %s", gate)
+	}
+	d, isDuty := c.(DutyCounter)
+	var h0 time.Duration
+	c0, err := c.Count()
+	if err != nil {
+		return m, err
+	}
+	start := time.Now()
+	if isDuty {
+		if h0, err = d.HighTime(); err != nil {
+			return m, err
+		}
+	}
+	time.Sleep(gate)
+	c1, err := c.Count()
+	if err != nil {
+		return m, err
+	}
+	m.Gate = time.Since(start)
+	if isDuty {
+		h1, err := d.HighTime()
+		if err != nil {
+			return m, err
+		}
+		m.Duty = gpio.Duty((int64(h1-h0)*int64(gpio.DutyMax) + int64(m.Gate)/2) / int64(m.Gate))
+		if m.Duty > gpio.DutyMax {
+			m.Duty = gpio.DutyMax
+		}
+	}
+	if c1 < c0 {
+		return m, errors.New("counter: counter was reset or wrapped during the measurement")
+	}
+	m.Count = c1 - c0
+	m.Frequency = physic.Frequency(float64(m.Count)*float64(physic.Hertz)/m.Gate.Seconds() + 0.5)
+	return m, nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package counter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestMeasure(t *testing.T) {
+	f := &fakeCounter{step: 10, highStep: 5 * time.Millisecond}
+	m, err := Measure(f, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Count != 10 {
+		t.Fatalf("Count = %d", m.Count)
+	}
+	// The gate time is at least the requested time.
+	if m.Frequency <= 0 || m.Frequency > physic.KiloHertz {
+		t.Fatalf("Frequency = %s", m.Frequency)
+	}
+	if m.Duty <= 0 || m.Duty > gpio.DutyHalf {
+		t.Fatalf("Duty = %s", m.Duty)
+	}
+	if _, err := Measure(f, 0); err == nil {
+		t.Fatal("invalid gate")
+	}
+}
+
+func TestKernel(t *testing.T) {
+	_, cleanup := fakeSysfs(t, map[string]string{
+		"counter0/name":                      "48302180.counter",
+		"counter0/count0/count":              "42\n",
+		"counter0/count0/function":           "quadrature x4\n",
+		"counter0/count0/function_available": "quadrature x4\npulse-direction\n",
+		"counter0/count0/enable":             "0\n",
+		"counter1/name":                      "interrupt-counter\n",
+		"counter1/count0/count":              "3\n",
+		"counter1/count0/function":           "increase\n",
+	})
+	defer cleanup()
+
+	devs, err := Enumerate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]string{0: "48302180.counter", 1: "interrupt-counter"}; !reflect.DeepEqual(devs, want) {
+		t.Fatal(devs)
+	}
+	if _, err := OpenKernel(1, 1); err == nil {
+		t.Fatal("count1 doesn't exist")
+	}
+	k, err := OpenKernel(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := k.String(); s != "counter0/count0" {
+		t.Fatal(s)
+	}
+	if v, err := k.Count(); err != nil || v != 42 {
+		t.Fatal(v, err)
+	}
+	if err := k.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := k.Count(); err != nil || v != 0 {
+		t.Fatal(v, err)
+	}
+	if f, err := k.Functions(); err != nil || !reflect.DeepEqual(f, []string{"quadrature x4", "pulse-direction"}) {
+		t.Fatal(f, err)
+	}
+	if err := k.SetFunction("pulse-direction"); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := k.Function(); err != nil || f != "pulse-direction" {
+		t.Fatal(f, err)
+	}
+	if err := k.SetCeiling(1000); err == nil {
+		t.Fatal("ceiling isn't supported")
+	}
+}
+
+func TestOpenEQEP(t *testing.T) {
+	root, cleanup := fakeSysfs(t, map[string]string{
+		"counter0/name":            "interrupt-counter",
+		"counter0/count0/count":    "0",
+		"counter2/name":            "48302180.counter",
+		"counter2/count0/count":    "0",
+		"counter2/count0/function": "increase",
+		"counter2/count0/enable":   "0",
+	})
+	defer cleanup()
+
+	if _, err := OpenEQEP(3); err == nil {
+		t.Fatal("invalid eQEP")
+	}
+	if _, err := OpenEQEP(0); err == nil {
+		t.Fatal("eQEP0 doesn't exist")
+	}
+	k, err := OpenEQEP(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := k.String(); s != "eQEP1" {
+		t.Fatal(s)
+	}
+	if f, err := k.Function(); err != nil || f != "quadrature x4" {
+		t.Fatal(f, err)
+	}
+	if s, err := readAttr(filepath.Join(root, "counter2/count0/enable")); err != nil || s != "1" {
+		t.Fatal(s, err)
+	}
+}
+
+func TestGPIO(t *testing.T) {
+	p := &gpiotest.Pin{N: "GPIO1", EdgesChan: make(chan gpio.Level)}
+	g, err := NewGPIO(p, gpio.PullDown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := g.String(); s != "counter(GPIO1(0))" {
+		t.Fatal(s)
+	}
+	if _, err := NewGPIO(p, gpio.PullDown); err == nil {
+		t.Fatal("pin is busy")
+	}
+	// The channel is unbuffered so the goroutine has started processing each
+	// edge when the send returns.
+	for _, l := range []gpio.Level{gpio.High, gpio.Low, gpio.High, gpio.Low} {
+		p.EdgesChan <- l
+	}
+	if err := g.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := g.Count(); err != nil || c != 2 {
+		t.Fatal(c, err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if o := pinuse.Owner(p); o != "" {
+		t.Fatal("pin not released", o)
+	}
+	if _, err := NewGPIO(nil, gpio.PullNoChange); err == nil {
+		t.Fatal("nil pin")
+	}
+}
+
+func TestGPIO_edge(t *testing.T) {
+	t0 := time.Unix(100, 0)
+	g := &GPIO{level: gpio.Low, since: t0}
+	g.edge(gpio.High, t0.Add(time.Second))
+	g.edge(gpio.Low, t0.Add(3*time.Second))
+	// Missed the falling edge.
+	g.edge(gpio.Low, t0.Add(4*time.Second))
+	g.edge(gpio.High, t0.Add(5*time.Second))
+	if g.count != 3 || g.high != 2*time.Second {
+		t.Fatal(g.count, g.high)
+	}
+	if h, err := g.HighTime(); err != nil || h < 2*time.Second {
+		t.Fatal(h, err)
+	}
+	if err := g.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if g.count != 0 || g.high != 0 {
+		t.Fatal(g.count, g.high)
+	}
+}
+
+func TestMeasurement_String(t *testing.T) {
+	m := Measurement{Count: 10, Gate: time.Second, Frequency: 10 * physic.Hertz}
+	if s := m.String(); s != "10 in 1s (10Hz)" {
+		t.Fatal(s)
+	}
+	m.Duty = gpio.DutyHalf
+	if s := m.String(); s != "10 in 1s (10Hz, 50%)" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+// fakeSysfs creates the files in a temporary directory used as devicesRoot.
+// The returned function restores devicesRoot.
+func fakeSysfs(t *testing.T, files map[string]string) (string, func()) {
+	root, err := ioutil.TempDir("", "counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := devicesRoot
+	devicesRoot = root + "/"
+	return root, func() {
+		devicesRoot = old
+		os.RemoveAll(root)
+	}
+}
+
+// fakeCounter advances by step and highStep on each call.
+type fakeCounter struct {
+	mu       sync.Mutex
+	step     uint64
+	highStep time.Duration
+	count    uint64
+	high     time.Duration
+}
+
+func (f *fakeCounter) String() string {
+	return "fake"
+}
+
+func (f *fakeCounter) Count() (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v := f.count
+	f.count += f.step
+	return v, nil
+}
+
+func (f *fakeCounter) Reset() error {
+	f.count = 0
+	return nil
+}
+
+func (f *fakeCounter) HighTime() (time.Duration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v := f.high
+	f.high += f.highStep
+	return v, nil
+}
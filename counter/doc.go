@@ -0,0 +1,18 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package counter counts the edges of a signal and measures its frequency and
+// duty cycle over a gate time, e.g. for flow meters and tachometers.
+//
+// Three implementations are provided: Kernel uses the Linux generic counter
+// subsystem exposed in /sys/bus/counter, OpenEQEP configures the enhanced
+// quadrature encoder pulse module of the TI AM335x through it, and GPIO
+// counts in software using the edge detection of any gpio.PinIn.
+//
+// Reference
+//
+// https://www.kernel.org/doc/html/latest/driver-api/generic-counter.html
+//
+// https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-bus-counter
+package counter
@@ -0,0 +1,60 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package counter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OpenEQEP opens the enhanced quadrature encoder pulse module n (0 to 2) of
+// the TI AM335x, as found on the BeagleBone, via the ti-eqep counter driver.
+//
+// The count is set to the "quadrature x4" function, counting each edge of
+// the A and B inputs up or down depending on the direction, and enabled. Use
+// SetFunction("pulse-direction") to count the pulses on A with B as the
+// direction. The pins must be muxed to the eQEP, e.g. with config-pin.
+//
+// Technical Reference Manual, section 15.4
+// https://www.ti.com/lit/ug/spruh73p/spruh73p.pdf
+func OpenEQEP(n int) (*Kernel, error) {
+	if n < 0 || n >= len(eqepAddrs) {
+		return nil, fmt.Errorf("counter: invalid eQEP %d", n)
+	}
+	devs, err := Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	// Make the search deterministic.
+	ids := make([]int, 0, len(devs))
+	for id := range devs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		// The ti-eqep driver names the counter after its platform device, e.g.
+		// "48300180.counter".
+		if !strings.HasPrefix(devs[id], eqepAddrs[n]+".") {
+			continue
+		}
+		k, err := OpenKernel(id, 0)
+		if err != nil {
+			return nil, err
+		}
+		k.name = fmt.Sprintf("eQEP%d", n)
+		if err := k.SetFunction("quadrature x4"); err != nil {
+			return nil, err
+		}
+		if err := k.Enable(true); err != nil {
+			return nil, err
+		}
+		return k, nil
+	}
+	return nil, fmt.Errorf("counter: eQEP%d not found; is the ti-eqep driver enabled in the device tree?", n)
+}
+
+// eqepAddrs are the eQEP register addresses of the PWMSS 0 to 2.
+var eqepAddrs = []string{"48300180", "48302180", "48304180"}
@@ -0,0 +1,147 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package counter
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+)
+
+// NewGPIO returns a counter counting the rising edges of p in software, using
+// its edge detection.
+//
+// Both edges are detected to also track the time the signal is high, so the
+// result implements DutyCounter. The edges are timestamped by a goroutine
+// when it is woken up, so the duty cycle is only accurate for slow signals;
+// the count is reliable up to a few kHz depending on the host, edges are
+// missed above. Prefer a Kernel counter when available.
+//
+// Call Close to stop counting and release the pin. The resulting object is
+// safe for concurrent use.
+func NewGPIO(p gpio.PinIn, pull gpio.Pull) (*GPIO, error) {
+	if p == nil {
+		return nil, errors.New("counter: pin is required")
+	}
+	g := &GPIO{p: p, done: make(chan struct{})}
+	if err := pinuse.Claim(g.String(), p); err != nil {
+		return nil, fmt.Errorf("counter: %v", err)
+	}
+	if err := p.In(pull, gpio.BothEdges); err != nil {
+		pinuse.Release(g.String(), p)
+		return nil, fmt.Errorf("counter: %v", err)
+	}
+	g.level = p.Read()
+	g.since = time.Now()
+	g.wg.Add(1)
+	go g.run()
+	return g, nil
+}
+
+// GPIO is a counter using the edge detection of a GPIO pin.
+type GPIO struct {
+	p    gpio.PinIn
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	mu    sync.Mutex
+	count uint64
+	high  time.Duration // high time accumulated before since
+	level gpio.Level
+	since time.Time // time of the last edge or Reset
+}
+
+func (g *GPIO) String() string {
+	return fmt.Sprintf("counter(%s)", g.p)
+}
+
+// Close stops counting and disables the edge detection.
+func (g *GPIO) Close() error {
+	select {
+	case <-g.done:
+		return nil
+	default:
+	}
+	close(g.done)
+	g.wg.Wait()
+	defer pinuse.Release(g.String(), g.p)
+	if err := g.p.In(gpio.PullNoChange, gpio.NoEdge); err != nil {
+		return fmt.Errorf("counter: %v", err)
+	}
+	return nil
+}
+
+// Count implements Counter.
+func (g *GPIO) Count() (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.count, nil
+}
+
+// Reset implements Counter.
+func (g *GPIO) Reset() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.count = 0
+	g.high = 0
+	g.since = time.Now()
+	return nil
+}
+
+// HighTime implements DutyCounter.
+func (g *GPIO) HighTime() (time.Duration, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	h := g.high
+	if g.level == gpio.High {
+		h += time.Since(g.since)
+	}
+	return h, nil
+}
+
+//
+
+// pollPeriod is the maximum time spent waiting for an edge, as Close can't
+// interrupt WaitForEdge.
+const pollPeriod = 100 * time.Millisecond
+
+func (g *GPIO) run() {
+	defer g.wg.Done()
+	for {
+		select {
+		case <-g.done:
+			return
+		default:
+		}
+		if g.p.WaitForEdge(pollPeriod) {
+			g.edge(g.p.Read(), time.Now())
+		}
+	}
+}
+
+// edge records the level l at time now.
+func (g *GPIO) edge(l gpio.Level, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if l == g.level {
+		// The opposite edge of a short pulse was missed; there was a rising
+		// edge either way.
+		g.count++
+		return
+	}
+	if g.level == gpio.High {
+		g.high += now.Sub(g.since)
+	} else {
+		g.count++
+	}
+	g.level = l
+	g.since = now
+}
+
+var _ DutyCounter = &GPIO{}
@@ -0,0 +1,174 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package counter
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Enumerate returns the counter devices N as in
+// /sys/bus/counter/devices/counterN, with their driver provided name.
+func Enumerate() (map[int]string, error) {
+	const prefix = "counter"
+	items, err := filepath.Glob(devicesRoot + prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+	out := map[int]string{}
+	for _, item := range items {
+		n, err := strconv.Atoi(filepath.Base(item)[len(prefix):])
+		if err != nil {
+			continue
+		}
+		name, err := readAttr(filepath.Join(item, "name"))
+		if err != nil {
+			return nil, fmt.Errorf("counter: %v", err)
+		}
+		out[n] = name
+	}
+	return out, nil
+}
+
+// OpenKernel opens the count /sys/bus/counter/devices/counter<device>/count<count>.
+//
+// The function selecting the counted edges is left as configured; see
+// Functions and SetFunction.
+func OpenKernel(device, count int) (*Kernel, error) {
+	if device < 0 || count < 0 {
+		return nil, errors.New("counter: invalid device or count")
+	}
+	dev := fmt.Sprintf("counter%d", device)
+	root := filepath.Join(devicesRoot, dev, fmt.Sprintf("count%d", count))
+	if _, err := os.Stat(filepath.Join(root, "count")); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("counter: %s/count%d doesn't exist", dev, count)
+		}
+		return nil, fmt.Errorf("counter: %v", err)
+	}
+	return &Kernel{name: fmt.Sprintf("%s/count%d", dev, count), root: root}, nil
+}
+
+// Kernel is a count of the Linux generic counter subsystem.
+//
+// The resulting object is safe for concurrent use.
+type Kernel struct {
+	name string
+	root string
+
+	mu sync.Mutex
+}
+
+func (k *Kernel) String() string {
+	return k.name
+}
+
+// Count implements Counter.
+func (k *Kernel) Count() (uint64, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	s, err := readAttr(filepath.Join(k.root, "count"))
+	if err != nil {
+		return 0, fmt.Errorf("counter: %v", err)
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("counter: %v", err)
+	}
+	return v, nil
+}
+
+// Reset implements Counter.
+func (k *Kernel) Reset() error {
+	return k.write("count", "0")
+}
+
+// Function returns the function of the count, e.g. "increase" or
+// "quadrature x4".
+func (k *Kernel) Function() (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	s, err := readAttr(filepath.Join(k.root, "function"))
+	if err != nil {
+		return "", fmt.Errorf("counter: %v", err)
+	}
+	return s, nil
+}
+
+// Functions returns the functions supported by the count.
+func (k *Kernel) Functions() ([]string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	s, err := readAttr(filepath.Join(k.root, "function_available"))
+	if err != nil {
+		return nil, fmt.Errorf("counter: %v", err)
+	}
+	return strings.Split(s, "\n"), nil
+}
+
+// SetFunction sets the function of the count. It must be one of Functions.
+func (k *Kernel) SetFunction(f string) error {
+	return k.write("function", f)
+}
+
+// SetCeiling sets the value at which the count wraps around, when supported
+// by the driver.
+func (k *Kernel) SetCeiling(v uint64) error {
+	return k.write("ceiling", strconv.FormatUint(v, 10))
+}
+
+// Enable enables or disables counting, when supported by the driver.
+func (k *Kernel) Enable(on bool) error {
+	v := "0"
+	if on {
+		v = "1"
+	}
+	return k.write("enable", v)
+}
+
+//
+
+// devicesRoot is overridden in tests.
+var devicesRoot = "/sys/bus/counter/devices/"
+
+func (k *Kernel) write(attr, v string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err := writeAttr(filepath.Join(k.root, attr), v); err != nil {
+		return fmt.Errorf("counter: %v", err)
+	}
+	return nil
+}
+
+func readAttr(p string) (string, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func writeAttr(p, v string) error {
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("need more access, try as root: %v", err)
+		}
+		return err
+	}
+	_, err = f.Write([]byte(v))
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+var _ Counter = &Kernel{}
@@ -5,6 +5,7 @@
 package cpu
 
 import (
+	"errors"
 	"io"
 	"io/ioutil"
 	"os"
@@ -13,7 +14,7 @@ import (
 	"sync"
 	"time"
 
-	"periph.io/x/host/v3/fs"
+	"github.com/s-mobi01/host/fs"
 )
 
 // MaxSpeed returns the processor maximum speed in Hz.
@@ -38,6 +39,20 @@ func Nanospin(d time.Duration) {
 	}
 }
 
+// SetHighPriority switches the calling OS thread to the real-time FIFO
+// scheduler so it preempts normal processes, reducing the timing jitter of
+// bit banging loops.
+//
+// Call runtime.LockOSThread first and never unlock the goroutine from its
+// thread, otherwise the Go runtime reuses the high priority thread for other
+// goroutines. It requires CAP_SYS_NICE, normally running as root.
+func SetHighPriority() error {
+	if isLinux {
+		return setHighPriorityLinux()
+	}
+	return errors.New("cpu: high priority is not supported on this OS")
+}
+
 //
 
 var (
@@ -5,8 +5,10 @@
 package cpu
 
 import (
+	"fmt"
 	"syscall"
 	"time"
+	"unsafe"
 )
 
 const isLinux = true
@@ -20,3 +22,19 @@ func nanospinLinux(d time.Duration) {
 		time = leftover
 	}
 }
+
+func setHighPriorityLinux() error {
+	// struct sched_param. The priority is below the threaded interrupt
+	// handlers, which run at 50.
+	p := struct{ priority int32 }{40}
+	if _, _, errno := syscall.Syscall(syscall.SYS_SCHED_SETSCHEDULER, 0, schedFIFO, uintptr(unsafe.Pointer(&p))); errno != 0 {
+		if errno == syscall.EPERM {
+			return fmt.Errorf("cpu: need more access, try as root: %w", errno)
+		}
+		return fmt.Errorf("cpu: %w", errno)
+	}
+	return nil
+}
+
+// schedFIFO is SCHED_FIFO from include/uapi/linux/sched.h.
+const schedFIFO = 1
@@ -0,0 +1,45 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package cpu
+
+import (
+	"errors"
+	"runtime"
+	"syscall"
+	"testing"
+)
+
+func TestSetHighPriority(t *testing.T) {
+	type result struct {
+		err    error
+		policy uintptr
+		errno  syscall.Errno
+	}
+	// Keep the thread out of the Go runtime pool by not unlocking it; it is
+	// terminated when the goroutine exits.
+	done := make(chan result)
+	go func() {
+		runtime.LockOSThread()
+		var r result
+		if r.err = SetHighPriority(); r.err == nil {
+			r.policy, _, r.errno = syscall.RawSyscall(syscall.SYS_SCHED_GETSCHEDULER, 0, 0, 0)
+		}
+		done <- r
+	}()
+	r := <-done
+	if r.err != nil {
+		// It fails without privilege.
+		if !errors.Is(r.err, syscall.EPERM) {
+			t.Fatal(r.err)
+		}
+		return
+	}
+	if r.errno != 0 {
+		t.Fatal(r.errno)
+	}
+	if r.policy != schedFIFO {
+		t.Fatalf("policy %d, want SCHED_FIFO", r.policy)
+	}
+}
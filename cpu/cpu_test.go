@@ -12,7 +12,7 @@ import (
 	"testing"
 	"time"
 
-	"periph.io/x/host/v3/fs"
+	"github.com/s-mobi01/host/fs"
 )
 
 func TestMaxSpeed_fail(t *testing.T) {
@@ -0,0 +1,212 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package cpu
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+)
+
+// Kicker is a watchdog that resets the host unless it is kicked regularly.
+type Kicker interface {
+	Kick() error
+}
+
+// Watchdog is the hardware watchdog exposed by the kernel as /dev/watchdog.
+type Watchdog struct {
+	mu sync.Mutex
+	f  *fs.File
+}
+
+// OpenWatchdog opens the hardware watchdog, which starts it.
+//
+// When timeout is not zero, the watchdog timeout is set to it, rounded to the
+// second. Not all drivers support changing the timeout.
+func OpenWatchdog(timeout time.Duration) (*Watchdog, error) {
+	f, err := fs.Open("/dev/watchdog", os.O_WRONLY)
+	if err != nil {
+		return nil, err
+	}
+	if timeout != 0 {
+		s := int32((timeout + time.Second - 1) / time.Second)
+		if err := f.Ioctl(wdiocSetTimeout, uintptr(unsafe.Pointer(&s))); err != nil {
+			_ = f.Close()
+			return nil, errors.New("cpu: failed to set the watchdog timeout: " + err.Error())
+		}
+	}
+	return &Watchdog{f: f}, nil
+}
+
+// Kick resets the watchdog timer.
+func (w *Watchdog) Kick() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.f == nil {
+		return errors.New("cpu: watchdog is closed")
+	}
+	_, err := w.f.Write([]byte{0})
+	return err
+}
+
+// Close disarms the watchdog, if the driver permits it, and closes it.
+func (w *Watchdog) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.f == nil {
+		return nil
+	}
+	// The magic close character.
+	_, err := w.f.Write([]byte{'V'})
+	if err2 := w.f.Close(); err == nil {
+		err = err2
+	}
+	w.f = nil
+	return err
+}
+
+// LoopWatchdog kicks a watchdog as long as all the loops registered with it
+// are alive.
+//
+// Each realtime loop calls Heartbeat.Beat() on every iteration. When a loop
+// doesn't beat within its deadline, the watchdog stops being kicked so the
+// host is reset.
+type LoopWatchdog struct {
+	k      Kicker
+	period time.Duration
+
+	mu      sync.Mutex
+	beats   []*Heartbeat
+	stalled *Heartbeat
+	err     error
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewLoopWatchdog starts kicking k every period.
+//
+// period must be well below the watchdog timeout.
+func NewLoopWatchdog(k Kicker, period time.Duration) (*LoopWatchdog, error) {
+	if period <= 0 {
+		return nil, errors.New("cpu: period must be positive")
+	}
+	l := &LoopWatchdog{k: k, period: period, done: make(chan struct{})}
+	l.wg.Add(1)
+	go l.run()
+	return l, nil
+}
+
+// Register adds a loop that must call Beat() at most every deadline.
+//
+// The deadline starts immediately.
+func (l *LoopWatchdog) Register(name string, deadline time.Duration) *Heartbeat {
+	h := &Heartbeat{name: name, deadline: int64(deadline)}
+	h.Beat()
+	l.mu.Lock()
+	l.beats = append(l.beats, h)
+	l.mu.Unlock()
+	return h
+}
+
+// Unregister removes a loop, e.g. when it terminates normally.
+func (l *LoopWatchdog) Unregister(h *Heartbeat) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, b := range l.beats {
+		if b == h {
+			copy(l.beats[i:], l.beats[i+1:])
+			l.beats = l.beats[:len(l.beats)-1]
+			return
+		}
+	}
+}
+
+// Err returns why the watchdog is not being kicked anymore, if so.
+func (l *LoopWatchdog) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stalled != nil {
+		return errors.New("cpu: loop " + l.stalled.name + " stalled")
+	}
+	return l.err
+}
+
+// Stop stops kicking the watchdog.
+//
+// It doesn't close the watchdog; close it to disarm it.
+func (l *LoopWatchdog) Stop() {
+	l.mu.Lock()
+	select {
+	case <-l.done:
+	default:
+		close(l.done)
+	}
+	l.mu.Unlock()
+	l.wg.Wait()
+}
+
+// Heartbeat is the liveness of a loop monitored by a LoopWatchdog.
+type Heartbeat struct {
+	last     int64 // time.Now().UnixNano(); first for 64 bits alignment.
+	deadline int64
+	name     string
+}
+
+// Beat signals that the loop is alive.
+//
+// It is cheap and safe to call from a realtime loop.
+func (h *Heartbeat) Beat() {
+	atomic.StoreInt64(&h.last, time.Now().UnixNano())
+}
+
+func (h *Heartbeat) String() string {
+	return h.name
+}
+
+//
+
+// wdiocSetTimeout is WDIOC_SETTIMEOUT from include/uapi/linux/watchdog.h.
+var wdiocSetTimeout = fs.IOWR('W', 6, 4)
+
+func (l *LoopWatchdog) run() {
+	defer l.wg.Done()
+	t := time.NewTicker(l.period)
+	defer t.Stop()
+	for {
+		if !l.check(time.Now()) {
+			return
+		}
+		select {
+		case <-l.done:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// check kicks the watchdog if all the loops are alive. It returns false once
+// the watchdog must not be kicked anymore.
+func (l *LoopWatchdog) check(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := now.UnixNano()
+	for _, h := range l.beats {
+		if n-atomic.LoadInt64(&h.last) > h.deadline {
+			// Once stalled, never kick again so the host is reset.
+			l.stalled = h
+			return false
+		}
+	}
+	if err := l.k.Kick(); err != nil {
+		l.err = err
+		return false
+	}
+	return true
+}
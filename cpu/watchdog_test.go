@@ -0,0 +1,116 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package cpu
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOpenWatchdog_fail(t *testing.T) {
+	if w, err := OpenWatchdog(time.Second); w != nil || err == nil {
+		t.Fatal("fs is inhibited")
+	}
+	w := &Watchdog{}
+	if err := w.Kick(); err == nil {
+		t.Fatal("closed")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoopWatchdog(t *testing.T) {
+	k := &fakeKicker{}
+	l, err := NewLoopWatchdog(k, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Stop()
+	h1 := l.Register("pid", 10*time.Millisecond)
+	h2 := l.Register("io", 10*time.Millisecond)
+	now := time.Now()
+	if !l.check(now) || l.Err() != nil {
+		t.Fatal(l.Err())
+	}
+	// A loop that stopped normally doesn't count.
+	l.Unregister(h2)
+	atomic.StoreInt64(&h2.last, now.Add(-time.Second).UnixNano())
+	h1.Beat()
+	if !l.check(now) {
+		t.Fatal(l.Err())
+	}
+	kicks := k.count()
+	atomic.StoreInt64(&h1.last, now.Add(-time.Second).UnixNano())
+	if l.check(now) {
+		t.Fatal("expected stall")
+	}
+	if err := l.Err(); err == nil || err.Error() != "cpu: loop pid stalled" {
+		t.Fatal(err)
+	}
+	if k.count() != kicks {
+		t.Fatal("kicked a stalled loop")
+	}
+	if s := h1.String(); s != "pid" {
+		t.Fatal(s)
+	}
+}
+
+func TestLoopWatchdog_run(t *testing.T) {
+	k := &fakeKicker{}
+	l, err := NewLoopWatchdog(k, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := l.Register("loop", time.Hour)
+	for k.count() < 3 {
+		h.Beat()
+		time.Sleep(time.Millisecond)
+	}
+	l.Stop()
+	l.Stop()
+	if l.Err() != nil {
+		t.Fatal(l.Err())
+	}
+}
+
+func TestLoopWatchdog_err(t *testing.T) {
+	if _, err := NewLoopWatchdog(&fakeKicker{}, 0); err == nil {
+		t.Fatal("invalid period")
+	}
+	k := &fakeKicker{err: errors.New("oops")}
+	l, err := NewLoopWatchdog(k, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Stop()
+	if err := l.Err(); err == nil || err.Error() != "oops" {
+		t.Fatal(err)
+	}
+}
+
+//
+
+type fakeKicker struct {
+	mu    sync.Mutex
+	kicks int
+	err   error
+}
+
+func (f *fakeKicker) Kick() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.kicks++
+	return f.err
+}
+
+func (f *fakeKicker) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.kicks
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package debounce
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+// Debouncer is implemented by pins that can filter their input in hardware
+// or in the kernel.
+type Debouncer interface {
+	// SetDebounce sets the period the input must be stable before a change is
+	// reported. 0 disables debouncing.
+	SetDebounce(period time.Duration) error
+}
+
+// New returns p debounced with the period; a level change is only reported
+// once the input has been stable for the period.
+//
+// Call In on the returned pin to start using it as an input.
+func New(p gpio.PinIO, period time.Duration) (*Pin, error) {
+	if period <= 0 {
+		return nil, errors.New("debounce: period must be positive")
+	}
+	d := &Pin{PinIO: p, period: period}
+	if k, ok := p.(Debouncer); ok {
+		d.kernel = k.SetDebounce(period) == nil
+	}
+	return d, nil
+}
+
+// Pin is a debounced gpio.PinIO.
+//
+// In software mode, Read and WaitForEdge must not be called concurrently.
+type Pin struct {
+	gpio.PinIO
+	period time.Duration
+	kernel bool
+
+	mu    sync.Mutex
+	edge  gpio.Edge  // edges requested by the user
+	level gpio.Level // last stable level
+}
+
+func (d *Pin) String() string {
+	return fmt.Sprintf("%s(debounced %s)", d.PinIO, d.period)
+}
+
+// Kernel returns true if the debouncing is done by the pin itself rather
+// than in software.
+func (d *Pin) Kernel() bool {
+	return d.kernel
+}
+
+// In implements gpio.PinIn.
+//
+// In software mode, the underlying pin always detects both edges and the
+// ones not requested are filtered out.
+func (d *Pin) In(pull gpio.Pull, edge gpio.Edge) error {
+	if d.kernel {
+		return d.PinIO.In(pull, edge)
+	}
+	if err := d.PinIO.In(pull, gpio.BothEdges); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.edge = edge
+	d.level = d.PinIO.Read()
+	d.mu.Unlock()
+	return nil
+}
+
+// Read implements gpio.PinIn.
+//
+// In software mode, it waits for the input to settle if it changed since the
+// last call.
+func (d *Pin) Read() gpio.Level {
+	if d.kernel {
+		return d.PinIO.Read()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.PinIO.WaitForEdge(0) {
+		d.settle()
+		d.level = d.PinIO.Read()
+	}
+	return d.level
+}
+
+// WaitForEdge implements gpio.PinIn.
+//
+// In software mode, it returns once the input is stable on the new level, so
+// up to the debounce period after the edge.
+func (d *Pin) WaitForEdge(timeout time.Duration) bool {
+	if d.kernel {
+		return d.PinIO.WaitForEdge(timeout)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.edge == gpio.NoEdge {
+		return false
+	}
+	start := time.Now()
+	for {
+		t := timeout
+		if timeout != -1 {
+			if t -= time.Since(start); t < 0 {
+				t = 0
+			}
+		}
+		if !d.PinIO.WaitForEdge(t) {
+			return false
+		}
+		d.settle()
+		l := d.PinIO.Read()
+		if l == d.level {
+			// It bounced back.
+			continue
+		}
+		d.level = l
+		if d.edge == gpio.BothEdges || (d.edge == gpio.RisingEdge) == bool(l) {
+			return true
+		}
+	}
+}
+
+// Halt implements conn.Resource.
+func (d *Pin) Halt() error {
+	return d.PinIO.Halt()
+}
+
+// Real implements gpio.RealPin.
+func (d *Pin) Real() gpio.PinIO {
+	if r, ok := d.PinIO.(gpio.RealPin); ok {
+		return r.Real()
+	}
+	return d.PinIO
+}
+
+//
+
+// settle waits until no edge happened for the period.
+//
+// lock must be held.
+func (d *Pin) settle() {
+	for d.PinIO.WaitForEdge(d.period) {
+	}
+}
+
+var _ gpio.PinIO = &Pin{}
+var _ gpio.RealPin = &Pin{}
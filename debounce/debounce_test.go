@@ -0,0 +1,89 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package debounce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/s-mobi01/host/gpioioctl"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+)
+
+var _ Debouncer = &gpioioctl.GPIOLine{}
+
+func TestNew(t *testing.T) {
+	if _, err := New(&gpiotest.Pin{}, 0); err == nil {
+		t.Fatal("invalid period")
+	}
+	p := &kernelPin{}
+	d, err := New(p, 5*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Kernel() || p.period != 5*time.Millisecond {
+		t.Fatal("expected kernel debouncing")
+	}
+	if r := d.Real(); r != p {
+		t.Fatal(r)
+	}
+}
+
+func TestPin_WaitForEdge(t *testing.T) {
+	p := &gpiotest.Pin{N: "GPIO1", EdgesChan: make(chan gpio.Level, 16)}
+	d, err := New(p, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Kernel() {
+		t.Fatal("expected software debouncing")
+	}
+	if d.WaitForEdge(0) {
+		t.Fatal("edge detection not enabled")
+	}
+	if err := d.In(gpio.PullDown, gpio.RisingEdge); err != nil {
+		t.Fatal(err)
+	}
+	// A press that bounces is a single rising edge.
+	for _, l := range []gpio.Level{gpio.High, gpio.Low, gpio.High} {
+		p.EdgesChan <- l
+	}
+	if !d.WaitForEdge(time.Second) {
+		t.Fatal("expected an edge")
+	}
+	if d.Read() != gpio.High {
+		t.Fatal("expected high")
+	}
+	// A glitch is ignored.
+	p.EdgesChan <- gpio.Low
+	p.EdgesChan <- gpio.High
+	if d.WaitForEdge(30 * time.Millisecond) {
+		t.Fatal("glitch reported")
+	}
+	// The release is a falling edge, which is filtered out.
+	p.EdgesChan <- gpio.Low
+	if d.WaitForEdge(30 * time.Millisecond) {
+		t.Fatal("falling edge reported")
+	}
+	if d.Read() != gpio.Low {
+		t.Fatal("expected low")
+	}
+	if s := d.String(); s != "GPIO1(0)(debounced 10ms)" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+type kernelPin struct {
+	gpiotest.Pin
+	period time.Duration
+}
+
+func (k *kernelPin) SetDebounce(period time.Duration) error {
+	k.period = period
+	return nil
+}
@@ -0,0 +1,11 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package debounce wraps a gpio.PinIO so that Read and WaitForEdge ignore
+// the bounces of mechanical buttons and switches.
+//
+// When the pin implements Debouncer, like the lines of the GPIO character
+// device in package gpioioctl, the kernel filters the line. Otherwise the
+// filtering is done in software over the pin's edge detection.
+package debounce
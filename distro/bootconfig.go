@@ -0,0 +1,301 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package distro
+
+import (
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// BootConfig is the parsed Raspberry Pi firmware configuration, as found in
+// config.txt.
+//
+// Only the settings applying to the board, as selected by the conditional
+// filters, are included.
+//
+// https://www.raspberrypi.com/documentation/computers/config_txt.html
+type BootConfig struct {
+	// Params are the settings other than dtoverlay, dtparam and include. The
+	// last value wins.
+	Params map[string]string
+	// DTParams are the parameters of the base device tree.
+	DTParams map[string]string
+	// Overlays are the device tree overlays loaded, in order.
+	Overlays []BootOverlay
+}
+
+// BootOverlay is a device tree overlay loaded with dtoverlay.
+type BootOverlay struct {
+	Name   string
+	Params map[string]string
+}
+
+// Overlay returns the last overlay loaded with this name, if any.
+func (b *BootConfig) Overlay(name string) *BootOverlay {
+	for i := len(b.Overlays) - 1; i >= 0; i-- {
+		if b.Overlays[i].Name == name {
+			return &b.Overlays[i]
+		}
+	}
+	return nil
+}
+
+// BootFilter is the board state the conditional filters of config.txt are
+// evaluated against.
+type BootFilter struct {
+	// Models are the model filters matching the board, e.g. "pi4" and "pi400"
+	// on a Raspberry Pi 400.
+	Models []string
+	// TryBoot is true when the board was booted in tryboot mode.
+	TryBoot bool
+}
+
+// RPiBootConfig returns the firmware configuration of the Raspberry Pi the
+// program is running on.
+//
+// When the board was booted in tryboot mode, tryboot.txt is parsed instead
+// of config.txt.
+func RPiBootConfig() (*BootConfig, error) {
+	if !isLinux {
+		return nil, errors.New("distro: config.txt is only supported on linux")
+	}
+	f := RPiBootFilter()
+	name := "config.txt"
+	if f.TryBoot {
+		name = "tryboot.txt"
+	}
+	// Bookworm moved the boot partition to /boot/firmware.
+	for _, dir := range []string{"/boot/firmware", "/boot"} {
+		b, err := ReadBootConfig(filepath.Join(dir, name), f)
+		if err == nil {
+			return b, nil
+		}
+	}
+	return nil, errors.New("distro: " + name + " not found")
+}
+
+// RPiBootFilter returns the filter matching the Raspberry Pi the program is
+// running on.
+func RPiBootFilter() BootFilter {
+	f := BootFilter{Models: rpiModelFilters(DTModel())}
+	if b, err := readFile("/proc/device-tree/chosen/bootloader/tryboot"); err == nil && len(b) >= 4 {
+		f.TryBoot = binary.BigEndian.Uint32(b) != 0
+	}
+	return f
+}
+
+// ReadBootConfig parses the config.txt file at path, following include
+// directives relative to its directory.
+//
+// Conditional filters not described by f, e.g. [gpio4=1] or [EDID=...], are
+// considered not to match.
+func ReadBootConfig(path string, f BootFilter) (*BootConfig, error) {
+	p := bootParser{
+		dir: filepath.Dir(path),
+		f:   f,
+		b:   &BootConfig{Params: map[string]string{}, DTParams: map[string]string{}},
+	}
+	if err := p.parse(path, 0); err != nil {
+		return nil, err
+	}
+	return p.b, nil
+}
+
+// ParseBootConfig parses the content of a config.txt file.
+//
+// include directives are ignored.
+func ParseBootConfig(content string, f BootFilter) *BootConfig {
+	p := bootParser{
+		f: f,
+		b: &BootConfig{Params: map[string]string{}, DTParams: map[string]string{}},
+	}
+	p.parseContent(content, -1)
+	return p.b
+}
+
+//
+
+// rpiModels maps the model reported by the device tree, after the
+// "Raspberry Pi " prefix, to the config.txt model filters. The first prefix
+// match wins.
+var rpiModels = []struct {
+	prefix  string
+	filters []string
+}{
+	{"Compute Module 5", []string{"pi5", "cm5"}},
+	{"Compute Module 4S", []string{"pi4", "cm4s"}},
+	{"Compute Module 4", []string{"pi4", "cm4"}},
+	{"Compute Module 3", []string{"pi3"}},
+	{"Compute Module", []string{"pi1"}},
+	{"Zero 2", []string{"pi0", "pi02"}},
+	{"Zero W", []string{"pi0", "pi0w"}},
+	{"Zero", []string{"pi0"}},
+	{"500", []string{"pi5", "pi500"}},
+	{"400", []string{"pi4", "pi400"}},
+	{"5", []string{"pi5"}},
+	{"4", []string{"pi4"}},
+	{"3 Model B Plus", []string{"pi3", "pi3+"}},
+	{"3 Model A Plus", []string{"pi3", "pi3+"}},
+	{"3", []string{"pi3"}},
+	{"2", []string{"pi2"}},
+	{"Model", []string{"pi1"}},
+}
+
+func rpiModelFilters(model string) []string {
+	const prefix = "Raspberry Pi "
+	if !strings.HasPrefix(model, prefix) {
+		return nil
+	}
+	model = model[len(prefix):]
+	for _, m := range rpiModels {
+		if strings.HasPrefix(model, m.prefix) {
+			return m.filters
+		}
+	}
+	return nil
+}
+
+// isModelFilter returns true if s is a config.txt model filter.
+func isModelFilter(s string) bool {
+	for _, m := range rpiModels {
+		for _, f := range m.filters {
+			if f == s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bootParser holds the state carried across included files.
+type bootParser struct {
+	dir string
+	f   BootFilter
+	b   *BootConfig
+
+	model   string          // Current model filter; empty means any.
+	conds   map[string]bool // Other conditional filters, all must match.
+	none    bool            // [none] is in effect.
+	overlay int             // Index of the overlay dtparam applies to; -1 is the base device tree.
+}
+
+// maxIncludeDepth limits include recursion.
+const maxIncludeDepth = 8
+
+func (p *bootParser) parse(path string, depth int) error {
+	b, err := readFile(path)
+	if err != nil {
+		return err
+	}
+	p.parseContent(string(b), depth)
+	return nil
+}
+
+// parseContent parses a file. depth is -1 to ignore include directives.
+func (p *bootParser) parseContent(content string, depth int) {
+	if depth <= 0 {
+		p.overlay = -1
+	}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' && line[len(line)-1] == ']' {
+			p.section(strings.ToLower(line[1 : len(line)-1]))
+			continue
+		}
+		if !p.active() {
+			continue
+		}
+		key, value := line, ""
+		if i := strings.IndexAny(line, "= \t"); i != -1 {
+			key, value = line[:i], strings.TrimSpace(line[i+1:])
+		}
+		switch key {
+		case "include":
+			if depth >= 0 && depth < maxIncludeDepth {
+				// Missing files are ignored, like the firmware does.
+				_ = p.parse(filepath.Join(p.dir, value), depth+1)
+			}
+		case "dtoverlay":
+			if value == "" {
+				p.overlay = -1
+				continue
+			}
+			parts := strings.Split(value, ",")
+			o := BootOverlay{Name: parts[0], Params: map[string]string{}}
+			addDTParams(o.Params, parts[1:])
+			p.b.Overlays = append(p.b.Overlays, o)
+			p.overlay = len(p.b.Overlays) - 1
+		case "dtparam":
+			m := p.b.DTParams
+			if p.overlay != -1 {
+				m = p.b.Overlays[p.overlay].Params
+			}
+			addDTParams(m, strings.Split(value, ","))
+		default:
+			p.b.Params[key] = value
+		}
+	}
+}
+
+// section processes a conditional filter.
+func (p *bootParser) section(s string) {
+	switch {
+	case s == "all":
+		p.model = ""
+		p.conds = nil
+		p.none = false
+	case s == "none":
+		p.none = true
+	case isModelFilter(s):
+		p.model = s
+	default:
+		if p.conds == nil {
+			p.conds = map[string]bool{}
+		}
+		p.conds[s] = s == "tryboot" && p.f.TryBoot
+	}
+}
+
+// active returns true if the current conditional filters match.
+func (p *bootParser) active() bool {
+	if p.none {
+		return false
+	}
+	for _, ok := range p.conds {
+		if !ok {
+			return false
+		}
+	}
+	if p.model == "" {
+		return true
+	}
+	for _, m := range p.f.Models {
+		if m == p.model {
+			return true
+		}
+	}
+	return false
+}
+
+// addDTParams adds the name=value parameters to m. A parameter without a
+// value is a boolean set to "on".
+func addDTParams(m map[string]string, params []string) {
+	for _, param := range params {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+		if i := strings.IndexByte(param, '='); i != -1 {
+			m[param[:i]] = param[i+1:]
+		} else {
+			m[param] = "on"
+		}
+	}
+}
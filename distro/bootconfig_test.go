@@ -0,0 +1,138 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package distro
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseBootConfig(t *testing.T) {
+	data := `# For more options and information see
+# http://rptl.io/configtxt
+dtparam=i2c_arm=on,spi=on
+dtparam=audio
+
+camera_auto_detect=1
+display_auto_detect=1
+
+[pi4]
+arm_boost=1
+dtoverlay=vc4-kms-v3d
+max_framebuffers=2
+
+[pi5]
+dtoverlay=vc4-kms-v3d-pi5
+
+[cm4]
+otg_mode=1
+
+[tryboot]
+kernel=kernel8-new.img
+
+[all]
+dtoverlay=i2c-rtc,ds3231
+dtparam=wakeup-source
+dtoverlay=
+dtparam=act_led_trigger=heartbeat
+initramfs initrd.img followkernel
+[gpio4=1]
+enable_uart=1
+`
+	b := ParseBootConfig(data, BootFilter{Models: []string{"pi4", "pi400"}})
+	want := &BootConfig{
+		Params: map[string]string{
+			"camera_auto_detect":  "1",
+			"display_auto_detect": "1",
+			"arm_boost":           "1",
+			"max_framebuffers":    "2",
+			"initramfs":           "initrd.img followkernel",
+		},
+		DTParams: map[string]string{
+			"i2c_arm":         "on",
+			"spi":             "on",
+			"audio":           "on",
+			"act_led_trigger": "heartbeat",
+		},
+		Overlays: []BootOverlay{
+			{Name: "vc4-kms-v3d", Params: map[string]string{}},
+			{Name: "i2c-rtc", Params: map[string]string{"ds3231": "on", "wakeup-source": "on"}},
+		},
+	}
+	if !reflect.DeepEqual(b, want) {
+		t.Fatalf("%+v", b)
+	}
+	if o := b.Overlay("i2c-rtc"); o == nil || o.Params["ds3231"] != "on" {
+		t.Fatal(o)
+	}
+	if o := b.Overlay("vc4-kms-v3d-pi5"); o != nil {
+		t.Fatal(o)
+	}
+
+	b = ParseBootConfig(data, BootFilter{Models: []string{"pi4", "cm4"}, TryBoot: true})
+	if b.Params["otg_mode"] != "1" || b.Params["kernel"] != "kernel8-new.img" {
+		t.Fatalf("%+v", b.Params)
+	}
+}
+
+func TestParseBootConfig_none(t *testing.T) {
+	b := ParseBootConfig("[none]\na=1\n[pi5]\nb=1\n[all]\nc=1\n", BootFilter{Models: []string{"pi5"}})
+	if !reflect.DeepEqual(b.Params, map[string]string{"c": "1"}) {
+		t.Fatal(b.Params)
+	}
+}
+
+func TestReadBootConfig(t *testing.T) {
+	defer reset()
+	files := map[string]string{
+		"/boot/firmware/config.txt": "a=1\ninclude extra.txt\ninclude missing.txt\ninclude config.txt\n",
+		"/boot/firmware/extra.txt":  "dtoverlay=w1-gpio\ndtparam=gpiopin=4\n",
+	}
+	readFile = func(filename string) ([]byte, error) {
+		if c, ok := files[filename]; ok {
+			return []byte(c), nil
+		}
+		return nil, errors.New("not found")
+	}
+	b, err := ReadBootConfig("/boot/firmware/config.txt", BootFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Params["a"] != "1" {
+		t.Fatal(b.Params)
+	}
+	// The recursive include is bounded.
+	if len(b.Overlays) != maxIncludeDepth || b.Overlays[0].Params["gpiopin"] != "4" {
+		t.Fatalf("%+v", b.Overlays)
+	}
+	if _, err := ReadBootConfig("/boot/config.txt", BootFilter{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRPiModelFilters(t *testing.T) {
+	data := []struct {
+		model string
+		want  []string
+	}{
+		{"Raspberry Pi 5 Model B Rev 1.0", []string{"pi5"}},
+		{"Raspberry Pi 500 Rev 1.0", []string{"pi5", "pi500"}},
+		{"Raspberry Pi 4 Model B Rev 1.4", []string{"pi4"}},
+		{"Raspberry Pi 400 Rev 1.0", []string{"pi4", "pi400"}},
+		{"Raspberry Pi Compute Module 4 Rev 1.0", []string{"pi4", "cm4"}},
+		{"Raspberry Pi 3 Model B Plus Rev 1.3", []string{"pi3", "pi3+"}},
+		{"Raspberry Pi 3 Model B Rev 1.2", []string{"pi3"}},
+		{"Raspberry Pi Zero 2 W Rev 1.0", []string{"pi0", "pi02"}},
+		{"Raspberry Pi Zero W Rev 1.1", []string{"pi0", "pi0w"}},
+		{"Raspberry Pi Model B Rev 2", []string{"pi1"}},
+		{"Pine64+", nil},
+	}
+	for i, line := range data {
+		if f := rpiModelFilters(line.model); !reflect.DeepEqual(f, line.want) {
+			t.Fatalf("#%d: %v", i, f)
+		}
+	}
+}
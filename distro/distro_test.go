@@ -9,7 +9,7 @@ import (
 	"reflect"
 	"testing"
 
-	"periph.io/x/host/v3/fs"
+	"github.com/s-mobi01/host/fs"
 )
 
 func TestSplitSemiColon(t *testing.T) {
@@ -149,6 +149,7 @@ func reset() {
 	dtCompatible = nil
 	dtModel = ""
 	osRelease = nil
+	uniqueID = ""
 	readFile = func(filename string) ([]byte, error) {
 		return nil, errors.New("no file can be opened in unit tests")
 	}
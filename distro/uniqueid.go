@@ -0,0 +1,85 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package distro
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// UniqueID returns an identifier unique to the board, stable across reboots
+// and OS reinstalls.
+//
+// The first one found is used, in order:
+//
+// - the serial number in the device tree, set by the firmware or the
+// bootloader, e.g. the Raspberry Pi serial number.
+//
+// - the Serial line of /proc/cpuinfo, used by older Raspberry Pi kernels.
+//
+// - the Allwinner SID (security ID) eFuses, as a hex string.
+//
+// - the SMBIOS system UUID on x86; reading it requires root.
+//
+// It returns an error if none is available. The format depends on the source,
+// so it should be treated as opaque.
+func UniqueID() (string, error) {
+	mu.Lock()
+	id := uniqueID
+	mu.Unlock()
+	if id != "" {
+		return id, nil
+	}
+	if !isLinux {
+		return "", errors.New("distro: unique ID is only supported on linux")
+	}
+	id = makeUniqueIDLinux()
+	if id == "" {
+		return "", errors.New("distro: no unique ID found")
+	}
+	mu.Lock()
+	uniqueID = id
+	mu.Unlock()
+	return id, nil
+}
+
+//
+
+// uniqueID is the cached UniqueID.
+var uniqueID string
+
+func makeUniqueIDLinux() string {
+	if b, err := readFile("/proc/device-tree/serial-number"); err == nil {
+		if s := splitNull(b); len(s) > 0 && validID(s[0]) {
+			return strings.TrimSpace(s[0])
+		}
+	}
+	if s := CPUInfo()["Serial"]; validID(s) {
+		return s
+	}
+	if b, err := readFile("/sys/bus/nvmem/devices/sunxi-sid0/nvmem"); err == nil && len(b) >= 16 {
+		// The first 128 bits are the chip ID.
+		if s := hex.EncodeToString(b[:16]); validID(s) {
+			return s
+		}
+	}
+	if b, err := readFile("/sys/class/dmi/id/product_uuid"); err == nil {
+		if s := strings.ToLower(strings.TrimSpace(string(b))); validID(s) {
+			return s
+		}
+	}
+	return ""
+}
+
+// validID returns false for the placeholder values left by the vendors.
+func validID(s string) bool {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "", "not settable", "default string", "to be filled by o.e.m.", "none":
+		return false
+	}
+	return strings.Trim(s, "0-") != "" && strings.Trim(strings.ToLower(s), "f-") != ""
+}
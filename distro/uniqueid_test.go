@@ -0,0 +1,83 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package distro
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUniqueID(t *testing.T) {
+	defer reset()
+	data := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			"device tree",
+			map[string]string{
+				"/proc/device-tree/serial-number": "10000000a1b2c3d4\x00",
+				"/proc/cpuinfo":                   "Serial\t\t: 00000000deadbeef\n",
+			},
+			"10000000a1b2c3d4",
+		},
+		{
+			"cpuinfo",
+			map[string]string{
+				"/proc/device-tree/serial-number": "0000000000000000\x00",
+				"/proc/cpuinfo":                   "Hardware\t: BCM2835\nSerial\t\t: 00000000deadbeef\n",
+			},
+			"00000000deadbeef",
+		},
+		{
+			"allwinner",
+			map[string]string{
+				"/sys/bus/nvmem/devices/sunxi-sid0/nvmem": "\x02\xc0\x00\x42\x00\x00\x00\x00\x01\x02\x03\x04\x05\x06\x07\x08\x00\x00",
+			},
+			"02c00042000000000102030405060708",
+		},
+		{
+			"smbios",
+			map[string]string{
+				"/sys/class/dmi/id/product_uuid": "4C4C4544-0042-3510-8052-B4C04F564232\n",
+			},
+			"4c4c4544-0042-3510-8052-b4c04f564232",
+		},
+		{
+			"placeholders",
+			map[string]string{
+				"/sys/class/dmi/id/product_uuid": "FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF\n",
+				"/proc/cpuinfo":                  "Serial\t\t: Not Settable\n",
+			},
+			"",
+		},
+	}
+	for _, line := range data {
+		reset()
+		files := line.files
+		readFile = func(filename string) ([]byte, error) {
+			if s, ok := files[filename]; ok {
+				return []byte(s), nil
+			}
+			return nil, errors.New("not found")
+		}
+		id, err := UniqueID()
+		if line.want == "" {
+			if err == nil {
+				t.Fatalf("%s: expected error, got %q", line.name, id)
+			}
+			continue
+		}
+		if err != nil || id != line.want {
+			t.Fatalf("%s: %q, %v", line.name, id, err)
+		}
+		// It is cached.
+		readFile = nil
+		if id, err := UniqueID(); err != nil || id != line.want {
+			t.Fatalf("%s: %q, %v", line.name, id, err)
+		}
+	}
+}
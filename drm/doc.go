@@ -0,0 +1,22 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package drm displays images on a monitor through the Linux DRM/KMS
+// subsystem, without X or Wayland.
+//
+// A dumb buffer, a linear framebuffer in system memory supported by all KMS
+// drivers, is mapped in the process and shown on the first connected display.
+// The Dev implements display.Drawer so it can be used like any other display,
+// e.g. for kiosk or status screen applications.
+//
+// The process must be the DRM master of the card, which means no display
+// server must be running on it, and have access to /dev/dri/cardN, usually by
+// being in the video group.
+//
+// Reference
+//
+// https://www.kernel.org/doc/html/latest/gpu/drm-kms.html
+//
+// https://www.kernel.org/doc/html/latest/gpu/drm-uapi.html
+package drm
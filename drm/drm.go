@@ -0,0 +1,576 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package drm
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+	"periph.io/x/conn/v3/display"
+	"periph.io/x/conn/v3/physic"
+)
+
+// Enumerate returns the DRM cards N as in /dev/dri/cardN.
+func Enumerate() ([]int, error) {
+	const prefix = "/dev/dri/card"
+	items, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int, 0, len(items))
+	for _, item := range items {
+		i, err := strconv.Atoi(item[len(prefix):])
+		if err != nil {
+			continue
+		}
+		out = append(out, i)
+	}
+	sort.Ints(out)
+	return out, nil
+}
+
+// Open opens the DRM card /dev/dri/card<n> and shows a black framebuffer on
+// the first connected display, in its preferred mode.
+//
+// The previous content of the display is restored on Close.
+func Open(n int) (*Dev, error) {
+	if !isLinux {
+		return nil, errors.New("drm: is not supported on this platform")
+	}
+	name := fmt.Sprintf("card%d", n)
+	f, err := fs.Open("/dev/dri/"+name, os.O_RDWR)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("drm: need more access, try as root or add the user to the video group: %v", err)
+		}
+		return nil, fmt.Errorf("drm: %v", err)
+	}
+	d := &Dev{name: name, f: f}
+	if err := d.init(); err != nil {
+		_ = d.release()
+		return nil, err
+	}
+	return d, nil
+}
+
+// Mode is a display mode.
+type Mode struct {
+	Width   int
+	Height  int
+	Refresh physic.Frequency
+	// Name is the name reported by the driver, e.g. "1920x1080".
+	Name string
+}
+
+func (m Mode) String() string {
+	return fmt.Sprintf("%dx%d@%s", m.Width, m.Height, m.Refresh)
+}
+
+// Dev is a framebuffer shown on a display.
+//
+// The pixels are stored as 32 bits XRGB. The resulting object is safe for
+// concurrent use.
+type Dev struct {
+	name string
+	f    *fs.File
+
+	mu      sync.Mutex
+	rect    image.Rectangle
+	mode    modeModeInfo
+	connID  uint32
+	crtcID  uint32
+	saved   modeCRTC // CRTC configuration restored on Close
+	handle  uint32   // dumb buffer
+	fbID    uint32
+	pitch   int
+	buf     []byte // mapped dumb buffer
+	dirtyFB bool   // the driver needs to be told about changes
+}
+
+func (d *Dev) String() string {
+	return fmt.Sprintf("drm-%s", d.name)
+}
+
+// Mode returns the display mode in use.
+func (d *Dev) Mode() Mode {
+	return Mode{
+		Width:   int(d.mode.hdisplay),
+		Height:  int(d.mode.vdisplay),
+		Refresh: physic.Frequency(d.mode.vrefresh) * physic.Hertz,
+		Name:    cString(d.mode.name[:]),
+	}
+}
+
+// Halt implements conn.Resource.
+//
+// It clears the display to black.
+func (d *Dev) Halt() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range d.buf {
+		d.buf[i] = 0
+	}
+	return d.dirty(d.rect)
+}
+
+// Close restores the previous content of the display and releases the
+// framebuffer.
+func (d *Dev) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.release()
+}
+
+// ColorModel implements display.Drawer.
+//
+// The alpha channel is ignored.
+func (d *Dev) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds implements display.Drawer.
+func (d *Dev) Bounds() image.Rectangle {
+	return d.rect
+}
+
+// Draw implements display.Drawer.
+//
+// The image is drawn directly in the framebuffer, which may show tearing.
+// *image.RGBA is the fastest source.
+func (d *Dev) Draw(r image.Rectangle, src image.Image, sp image.Point) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.buf == nil {
+		return errors.New("drm: device is closed")
+	}
+	// off is the offset from the display to the source coordinates.
+	off := sp.Sub(r.Min)
+	r = r.Intersect(d.rect).Intersect(src.Bounds().Sub(off))
+	if r.Empty() {
+		return nil
+	}
+	w := r.Dx()
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		row := d.buf[y*d.pitch+4*r.Min.X : y*d.pitch+4*r.Max.X]
+		if s, ok := src.(*image.RGBA); ok {
+			pix := s.Pix[s.PixOffset(r.Min.X+off.X, y+off.Y):]
+			for x := 0; x < w; x++ {
+				row[4*x] = pix[4*x+2]
+				row[4*x+1] = pix[4*x+1]
+				row[4*x+2] = pix[4*x]
+				row[4*x+3] = 0
+			}
+			continue
+		}
+		for x := 0; x < w; x++ {
+			c := color.RGBAModel.Convert(src.At(r.Min.X+x+off.X, y+off.Y)).(color.RGBA)
+			row[4*x] = c.B
+			row[4*x+1] = c.G
+			row[4*x+2] = c.R
+			row[4*x+3] = 0
+		}
+	}
+	return d.dirty(r)
+}
+
+//
+
+// ioctl runs the DRM ioctl op with the argument v, retrying when interrupted
+// like libdrm does.
+func (d *Dev) ioctl(op uint, v unsafe.Pointer) error {
+	for {
+		err := d.f.Ioctl(op, uintptr(v))
+		if err != syscall.EINTR && err != syscall.EAGAIN {
+			return err
+		}
+	}
+}
+
+func (d *Dev) init() error {
+	var res modeCardRes
+	if err := d.ioctl(modeGetResources, unsafe.Pointer(&res)); err != nil {
+		return fmt.Errorf("drm: %s doesn't support mode setting: %v", d.name, err)
+	}
+	crtcs := make([]uint32, res.countCrtcs)
+	conns := make([]uint32, res.countConnectors)
+	res = modeCardRes{
+		crtcIDPtr:       u32Ptr(crtcs),
+		connectorIDPtr:  u32Ptr(conns),
+		countCrtcs:      uint32(len(crtcs)),
+		countConnectors: uint32(len(conns)),
+	}
+	if err := d.ioctl(modeGetResources, unsafe.Pointer(&res)); err != nil {
+		return fmt.Errorf("drm: %v", err)
+	}
+	for _, id := range conns {
+		c, modes, encoders, err := d.getConnector(id)
+		if err != nil {
+			return err
+		}
+		if c.connection != connected || len(modes) == 0 {
+			continue
+		}
+		crtc, err := d.findCRTC(&c, encoders, crtcs)
+		if err != nil {
+			return err
+		}
+		d.connID = id
+		d.crtcID = crtc
+		d.mode = modes[preferredMode(modes)]
+		return d.setup()
+	}
+	return fmt.Errorf("drm: no display connected to %s", d.name)
+}
+
+// getConnector returns the connector id, its modes and its encoders.
+func (d *Dev) getConnector(id uint32) (modeGetConnector, []modeModeInfo, []uint32, error) {
+	// The first call probes the display, the second one retrieves the lists.
+	c := modeGetConnector{connectorID: id}
+	if err := d.ioctl(modeGetConnectorOp, unsafe.Pointer(&c)); err != nil {
+		return c, nil, nil, fmt.Errorf("drm: %v", err)
+	}
+	if c.countModes == 0 || c.countEncoders == 0 {
+		return c, nil, nil, nil
+	}
+	modes := make([]modeModeInfo, c.countModes)
+	encoders := make([]uint32, c.countEncoders)
+	c = modeGetConnector{
+		modesPtr:      uint64(uintptr(unsafe.Pointer(&modes[0]))),
+		encodersPtr:   u32Ptr(encoders),
+		countModes:    uint32(len(modes)),
+		countEncoders: uint32(len(encoders)),
+		connectorID:   id,
+	}
+	if err := d.ioctl(modeGetConnectorOp, unsafe.Pointer(&c)); err != nil {
+		return c, nil, nil, fmt.Errorf("drm: %v", err)
+	}
+	// The display may have been unplugged in between.
+	if int(c.countModes) < len(modes) {
+		modes = modes[:c.countModes]
+	}
+	if int(c.countEncoders) < len(encoders) {
+		encoders = encoders[:c.countEncoders]
+	}
+	return c, modes, encoders, nil
+}
+
+// findCRTC returns the CRTC to drive the connector c, preferably the one
+// currently used.
+func (d *Dev) findCRTC(c *modeGetConnector, encoders, crtcs []uint32) (uint32, error) {
+	if c.encoderID != 0 {
+		e := modeGetEncoder{encoderID: c.encoderID}
+		if err := d.ioctl(modeGetEncoderOp, unsafe.Pointer(&e)); err != nil {
+			return 0, fmt.Errorf("drm: %v", err)
+		}
+		if e.crtcID != 0 {
+			return e.crtcID, nil
+		}
+	}
+	for _, id := range encoders {
+		e := modeGetEncoder{encoderID: id}
+		if err := d.ioctl(modeGetEncoderOp, unsafe.Pointer(&e)); err != nil {
+			return 0, fmt.Errorf("drm: %v", err)
+		}
+		for i, crtc := range crtcs {
+			if e.possibleCrtcs&(1<<uint(i)) != 0 {
+				return crtc, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("drm: no CRTC available for connector %d", c.connectorID)
+}
+
+// setup creates the framebuffer and shows it.
+func (d *Dev) setup() error {
+	d.saved = modeCRTC{crtcID: d.crtcID}
+	if err := d.ioctl(modeGetCRTC, unsafe.Pointer(&d.saved)); err != nil {
+		return fmt.Errorf("drm: %v", err)
+	}
+	w, h := uint32(d.mode.hdisplay), uint32(d.mode.vdisplay)
+	cd := modeCreateDumb{width: w, height: h, bpp: 32}
+	if err := d.ioctl(modeCreateDumbOp, unsafe.Pointer(&cd)); err != nil {
+		return fmt.Errorf("drm: failed to create a %dx%d buffer: %v", w, h, err)
+	}
+	d.handle = cd.handle
+	d.pitch = int(cd.pitch)
+	fb := modeFBCmd{width: w, height: h, pitch: cd.pitch, bpp: 32, depth: 24, handle: cd.handle}
+	if err := d.ioctl(modeAddFB, unsafe.Pointer(&fb)); err != nil {
+		return fmt.Errorf("drm: %v", err)
+	}
+	d.fbID = fb.fbID
+	md := modeMapDumb{handle: cd.handle}
+	if err := d.ioctl(modeMapDumbOp, unsafe.Pointer(&md)); err != nil {
+		return fmt.Errorf("drm: %v", err)
+	}
+	buf, err := mmap(d.f, int64(md.offset), int(cd.size))
+	if err != nil {
+		return fmt.Errorf("drm: %v", err)
+	}
+	d.buf = buf
+	d.rect = image.Rect(0, 0, int(w), int(h))
+	conn := d.connID
+	set := modeCRTC{
+		setConnectorsPtr: uint64(uintptr(unsafe.Pointer(&conn))),
+		countConnectors:  1,
+		crtcID:           d.crtcID,
+		fbID:             d.fbID,
+		modeValid:        1,
+		mode:             d.mode,
+	}
+	if err := d.ioctl(modeSetCRTC, unsafe.Pointer(&set)); err != nil {
+		if err == syscall.EACCES {
+			return fmt.Errorf("drm: %s is used by a display server: %v", d.name, err)
+		}
+		return fmt.Errorf("drm: %v", err)
+	}
+	// Some drivers, e.g. for USB displays, only update the display when told.
+	d.dirtyFB = true
+	if err := d.dirty(d.rect); err != nil {
+		d.dirtyFB = false
+	}
+	return nil
+}
+
+// dirty tells the driver that r was modified, when needed.
+func (d *Dev) dirty(r image.Rectangle) error {
+	if !d.dirtyFB {
+		return nil
+	}
+	clip := clipRect{x1: uint16(r.Min.X), y1: uint16(r.Min.Y), x2: uint16(r.Max.X), y2: uint16(r.Max.Y)}
+	c := modeFBDirtyCmd{fbID: d.fbID, numClips: 1, clipsPtr: uint64(uintptr(unsafe.Pointer(&clip)))}
+	if err := d.ioctl(modeDirtyFB, unsafe.Pointer(&c)); err != nil {
+		return fmt.Errorf("drm: %v", err)
+	}
+	return nil
+}
+
+// release frees everything that was allocated, in reverse order.
+func (d *Dev) release() error {
+	if d.f == nil {
+		return nil
+	}
+	var errs []error
+	if d.saved.fbID != 0 {
+		conn := d.connID
+		d.saved.setConnectorsPtr = uint64(uintptr(unsafe.Pointer(&conn)))
+		d.saved.countConnectors = 1
+		if err := d.ioctl(modeSetCRTC, unsafe.Pointer(&d.saved)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if d.buf != nil {
+		if err := munmap(d.buf); err != nil {
+			errs = append(errs, err)
+		}
+		d.buf = nil
+	}
+	if d.fbID != 0 {
+		if err := d.ioctl(modeRmFB, unsafe.Pointer(&d.fbID)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if d.handle != 0 {
+		dd := modeDestroyDumb{handle: d.handle}
+		if err := d.ioctl(modeDestroyDumbOp, unsafe.Pointer(&dd)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := d.f.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	d.f = nil
+	if len(errs) != 0 {
+		return fmt.Errorf("drm: %v", errs[0])
+	}
+	return nil
+}
+
+// preferredMode returns the index of the mode flagged as preferred by the
+// display, or the first one, which is the largest.
+func preferredMode(modes []modeModeInfo) int {
+	for i := range modes {
+		if modes[i].typ&modeTypePreferred != 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
+
+func u32Ptr(s []uint32) uint64 {
+	if len(s) == 0 {
+		return 0
+	}
+	return uint64(uintptr(unsafe.Pointer(&s[0])))
+}
+
+// Structures and constants from include/uapi/drm/drm_mode.h.
+
+const (
+	connected         = 1
+	modeTypePreferred = 1 << 3
+)
+
+var (
+	modeGetResources   = fs.IOWR('d', 0xA0, uint(unsafe.Sizeof(modeCardRes{})))
+	modeGetCRTC        = fs.IOWR('d', 0xA1, uint(unsafe.Sizeof(modeCRTC{})))
+	modeSetCRTC        = fs.IOWR('d', 0xA2, uint(unsafe.Sizeof(modeCRTC{})))
+	modeGetEncoderOp   = fs.IOWR('d', 0xA6, uint(unsafe.Sizeof(modeGetEncoder{})))
+	modeGetConnectorOp = fs.IOWR('d', 0xA7, uint(unsafe.Sizeof(modeGetConnector{})))
+	modeAddFB          = fs.IOWR('d', 0xAE, uint(unsafe.Sizeof(modeFBCmd{})))
+	modeRmFB           = fs.IOWR('d', 0xAF, 4)
+	modeDirtyFB        = fs.IOWR('d', 0xB1, uint(unsafe.Sizeof(modeFBDirtyCmd{})))
+	modeCreateDumbOp   = fs.IOWR('d', 0xB2, uint(unsafe.Sizeof(modeCreateDumb{})))
+	modeMapDumbOp      = fs.IOWR('d', 0xB3, uint(unsafe.Sizeof(modeMapDumb{})))
+	modeDestroyDumbOp  = fs.IOWR('d', 0xB4, uint(unsafe.Sizeof(modeDestroyDumb{})))
+)
+
+// modeCardRes is struct drm_mode_card_res.
+type modeCardRes struct {
+	fbIDPtr         uint64
+	crtcIDPtr       uint64
+	connectorIDPtr  uint64
+	encoderIDPtr    uint64
+	countFbs        uint32
+	countCrtcs      uint32
+	countConnectors uint32
+	countEncoders   uint32
+	minWidth        uint32
+	maxWidth        uint32
+	minHeight       uint32
+	maxHeight       uint32
+}
+
+// modeModeInfo is struct drm_mode_modeinfo.
+type modeModeInfo struct {
+	clock      uint32
+	hdisplay   uint16
+	hsyncStart uint16
+	hsyncEnd   uint16
+	htotal     uint16
+	hskew      uint16
+	vdisplay   uint16
+	vsyncStart uint16
+	vsyncEnd   uint16
+	vtotal     uint16
+	vscan      uint16
+	vrefresh   uint32
+	flags      uint32
+	typ        uint32
+	name       [32]byte
+}
+
+// modeGetConnector is struct drm_mode_get_connector.
+type modeGetConnector struct {
+	encodersPtr     uint64
+	modesPtr        uint64
+	propsPtr        uint64
+	propValuesPtr   uint64
+	countModes      uint32
+	countProps      uint32
+	countEncoders   uint32
+	encoderID       uint32
+	connectorID     uint32
+	connectorType   uint32
+	connectorTypeID uint32
+	connection      uint32
+	mmWidth         uint32
+	mmHeight        uint32
+	subpixel        uint32
+	pad             uint32
+}
+
+// modeGetEncoder is struct drm_mode_get_encoder.
+type modeGetEncoder struct {
+	encoderID      uint32
+	encoderType    uint32
+	crtcID         uint32
+	possibleCrtcs  uint32
+	possibleClones uint32
+}
+
+// modeCRTC is struct drm_mode_crtc.
+type modeCRTC struct {
+	setConnectorsPtr uint64
+	countConnectors  uint32
+	crtcID           uint32
+	fbID             uint32
+	x                uint32
+	y                uint32
+	gammaSize        uint32
+	modeValid        uint32
+	mode             modeModeInfo
+}
+
+// modeCreateDumb is struct drm_mode_create_dumb.
+type modeCreateDumb struct {
+	height uint32
+	width  uint32
+	bpp    uint32
+	flags  uint32
+	handle uint32
+	pitch  uint32
+	size   uint64
+}
+
+// modeMapDumb is struct drm_mode_map_dumb.
+type modeMapDumb struct {
+	handle uint32
+	pad    uint32
+	offset uint64
+}
+
+// modeDestroyDumb is struct drm_mode_destroy_dumb.
+type modeDestroyDumb struct {
+	handle uint32
+}
+
+// modeFBCmd is struct drm_mode_fb_cmd.
+type modeFBCmd struct {
+	fbID   uint32
+	width  uint32
+	height uint32
+	pitch  uint32
+	bpp    uint32
+	depth  uint32
+	handle uint32
+}
+
+// modeFBDirtyCmd is struct drm_mode_fb_dirty_cmd.
+type modeFBDirtyCmd struct {
+	fbID     uint32
+	flags    uint32
+	color    uint32
+	numClips uint32
+	clipsPtr uint64
+}
+
+// clipRect is struct drm_clip_rect.
+type clipRect struct {
+	x1 uint16
+	y1 uint16
+	x2 uint16
+	y2 uint16
+}
+
+var _ display.Drawer = &Dev{}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package drm
+
+import (
+	"errors"
+
+	"github.com/s-mobi01/host/fs"
+)
+
+const isLinux = false
+
+func mmap(f *fs.File, offset int64, size int) ([]byte, error) {
+	return nil, errors.New("unreachable code")
+}
+
+func munmap(b []byte) error {
+	return errors.New("unreachable code")
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package drm
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+	"unsafe"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestSizes(t *testing.T) {
+	data := []struct {
+		name string
+		got  uintptr
+		want uintptr
+	}{
+		{"drm_mode_card_res", unsafe.Sizeof(modeCardRes{}), 64},
+		{"drm_mode_modeinfo", unsafe.Sizeof(modeModeInfo{}), 68},
+		{"drm_mode_get_connector", unsafe.Sizeof(modeGetConnector{}), 80},
+		{"drm_mode_get_encoder", unsafe.Sizeof(modeGetEncoder{}), 20},
+		{"drm_mode_crtc", unsafe.Sizeof(modeCRTC{}), 104},
+		{"drm_mode_create_dumb", unsafe.Sizeof(modeCreateDumb{}), 32},
+		{"drm_mode_map_dumb", unsafe.Sizeof(modeMapDumb{}), 16},
+		{"drm_mode_fb_cmd", unsafe.Sizeof(modeFBCmd{}), 28},
+		{"drm_mode_fb_dirty_cmd", unsafe.Sizeof(modeFBDirtyCmd{}), 24},
+	}
+	for _, l := range data {
+		if l.got != l.want {
+			t.Errorf("%s is %d bytes, want %d", l.name, l.got, l.want)
+		}
+	}
+}
+
+func TestDev_Draw(t *testing.T) {
+	d := newTestDev(4, 2)
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.RGBA{R: 1, G: 2, B: 3, A: 255})
+	src.Set(1, 1, color.RGBA{R: 4, G: 5, B: 6, A: 255})
+	// Drawn at x=3; the second column is clipped.
+	if err := d.Draw(image.Rect(3, 0, 5, 2), src, image.Point{}); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 2, 1, 0, 0xEE, 0xEE, 0xEE, 0xEE,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xEE, 0xEE, 0xEE, 0xEE,
+	}
+	if !bytes.Equal(d.buf, want) {
+		t.Fatalf("%v", d.buf)
+	}
+	// Use the generic path, taking the source at an offset.
+	gray := image.NewGray(image.Rect(0, 0, 2, 2))
+	gray.SetGray(1, 1, color.Gray{Y: 9})
+	if err := d.Draw(d.Bounds(), gray, image.Pt(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(d.buf[:4], []byte{9, 9, 9, 0}) || !bytes.Equal(d.buf[12:16], []byte{3, 2, 1, 0}) {
+		t.Fatalf("%v", d.buf)
+	}
+	if err := d.Halt(); err != nil {
+		t.Fatal(err)
+	}
+	if d.buf[0] != 0 {
+		t.Fatal("not cleared")
+	}
+}
+
+func TestDev_Draw_closed(t *testing.T) {
+	d := &Dev{}
+	if err := d.Draw(image.Rect(0, 0, 1, 1), image.NewRGBA(image.Rect(0, 0, 1, 1)), image.Point{}); err == nil {
+		t.Fatal("closed")
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDev_Mode(t *testing.T) {
+	d := &Dev{name: "card1"}
+	d.mode.hdisplay = 800
+	d.mode.vdisplay = 480
+	d.mode.vrefresh = 60
+	copy(d.mode.name[:], "800x480")
+	m := d.Mode()
+	if m != (Mode{Width: 800, Height: 480, Refresh: 60 * physic.Hertz, Name: "800x480"}) {
+		t.Fatalf("%#v", m)
+	}
+	if s := m.String(); s != "800x480@60Hz" {
+		t.Fatal(s)
+	}
+	if s := d.String(); s != "drm-card1" {
+		t.Fatal(s)
+	}
+}
+
+func TestPreferredMode(t *testing.T) {
+	modes := make([]modeModeInfo, 3)
+	if i := preferredMode(modes); i != 0 {
+		t.Fatal(i)
+	}
+	modes[2].typ = modeTypePreferred
+	if i := preferredMode(modes); i != 2 {
+		t.Fatal(i)
+	}
+}
+
+//
+
+// newTestDev returns a Dev with an in-memory w×h buffer with a padding of one
+// pixel per line, filled with 0xEE.
+func newTestDev(w, h int) *Dev {
+	d := &Dev{name: "card0", pitch: 4 * (w + 1), rect: image.Rect(0, 0, w, h)}
+	d.buf = make([]byte, d.pitch*h)
+	for i := range d.buf {
+		d.buf[i] = 0xEE
+	}
+	for y := 0; y < h; y++ {
+		for i := 0; i < 4*w; i++ {
+			d.buf[y*d.pitch+i] = 0
+		}
+	}
+	return d
+}
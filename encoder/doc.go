@@ -0,0 +1,21 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package encoder decodes incremental rotary encoders, exposing their
+// position and velocity.
+//
+// The A and B quadrature outputs are decoded either by a kernel counter, like
+// the eQEP of the TI AM335x via package counter, or in software using the
+// edge detection of any gpio.PinIn. Each edge of A and B counts, so the
+// position is in quarters of a cycle, 4 times the number of cycles per
+// revolution of the encoder.
+//
+// The software decoder is limited by the latency of the edge detection of the
+// host, typically a few kHz; above, pairs of edges are missed and the position
+// drifts. It is suitable for hand operated knobs, not for motor shafts; use a
+// kernel counter for these.
+//
+// The optional index output, pulsing once per revolution, latches the
+// position and optionally resets it, to home an axis.
+package encoder
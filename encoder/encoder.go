@@ -0,0 +1,354 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package encoder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/s-mobi01/host/counter"
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/pin"
+)
+
+// Opts configures New.
+type Opts struct {
+	// A and B are the quadrature outputs of the encoder, decoded in software.
+	// They are not used when Counter is set.
+	A, B gpio.PinIn
+	// Counter is a kernel counter decoding the quadrature outputs, set to a
+	// quadrature function, e.g. as returned by counter.OpenEQEP. Its count is
+	// interpreted as a 32 bits two's complement value.
+	Counter counter.Counter
+	// Index is the optional index output of the encoder.
+	Index gpio.PinIn
+	// Pull is the pull applied to A, B and Index, e.g. gpio.PullUp for open
+	// collector outputs.
+	Pull gpio.Pull
+	// ResetOnIndex resets the position to 0 on each index pulse.
+	ResetOnIndex bool
+}
+
+// New returns an encoder decoded by o.Counter, or in software from o.A and
+// o.B.
+//
+// A positive position means that A leads B.
+//
+// Call Close to stop decoding and release the pins. The resulting object is
+// safe for concurrent use.
+func New(o *Opts) (*Encoder, error) {
+	e := &Encoder{done: make(chan struct{}), index: o.Index, resetOnIndex: o.ResetOnIndex}
+	if o.Counter != nil {
+		if c, ok := o.Counter.(ceilinger); ok {
+			// Wrap at 32 bits. Not all drivers support it.
+			_ = c.SetCeiling(0xFFFFFFFF)
+		}
+		k := &kernelSource{k: o.Counter}
+		if err := k.init(); err != nil {
+			return nil, err
+		}
+		e.src = k
+	} else {
+		if o.A == nil || o.B == nil {
+			return nil, errors.New("encoder: A and B or Counter are required")
+		}
+		e.src = &gpioSource{a: o.A, b: o.B}
+	}
+	if err := pinuse.Claim(e.String(), e.pins()...); err != nil {
+		return nil, fmt.Errorf("encoder: %v", err)
+	}
+	if g, ok := e.src.(*gpioSource); ok {
+		if err := g.init(o.Pull); err != nil {
+			pinuse.Release(e.String(), e.pins()...)
+			return nil, err
+		}
+		e.wg.Add(2)
+		go e.watch(g.a, g.edgeA)
+		go e.watch(g.b, g.edgeB)
+	}
+	if e.index != nil {
+		if err := e.index.In(o.Pull, gpio.RisingEdge); err != nil {
+			_ = e.Close()
+			return nil, fmt.Errorf("encoder: %v", err)
+		}
+		e.wg.Add(1)
+		go e.watch(e.index, e.indexEdge)
+	}
+	return e, nil
+}
+
+// OpenEQEP returns an encoder decoded by the eQEP n of the TI AM335x, with
+// the options o, which may be nil. o.A, o.B and o.Counter are ignored.
+func OpenEQEP(n int, o *Opts) (*Encoder, error) {
+	k, err := counter.OpenEQEP(n)
+	if err != nil {
+		return nil, err
+	}
+	var c Opts
+	if o != nil {
+		c = *o
+	}
+	c.A = nil
+	c.B = nil
+	c.Counter = k
+	return New(&c)
+}
+
+// Encoder is an incremental rotary encoder.
+type Encoder struct {
+	src          source
+	index        gpio.PinIn
+	resetOnIndex bool
+	done         chan struct{}
+	wg           sync.WaitGroup
+
+	mu      sync.Mutex
+	offset  int64 // raw position at the last Reset
+	latched int64 // position at the last index pulse
+	indexed bool
+}
+
+func (e *Encoder) String() string {
+	return fmt.Sprintf("encoder(%s)", e.src)
+}
+
+// Position returns the position since the last Reset, in quarters of a cycle.
+func (e *Encoder) Position() (int64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	p, err := e.src.read()
+	return p - e.offset, err
+}
+
+// Reset sets the position back to 0.
+func (e *Encoder) Reset() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	p, err := e.src.read()
+	if err != nil {
+		return err
+	}
+	e.offset = p
+	return nil
+}
+
+// Index returns the position latched at the last index pulse, before it was
+// reset when ResetOnIndex is set. It returns false if there was no index
+// pulse yet.
+func (e *Encoder) Index() (int64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.latched, e.indexed
+}
+
+// Velocity measures the velocity in quarters of a cycle per second during the
+// gate time. It is negative when turning backward.
+//
+// Divide by 4 times the cycles per revolution of the encoder to get
+// revolutions per second.
+func (e *Encoder) Velocity(gate time.Duration) (float64, error) {
+	if gate <= 0 {
+		return 0, fmt.Errorf("encoder: invalid gate time %s", gate)
+	}
+	e.mu.Lock()
+	p0, err := e.src.read()
+	e.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	time.Sleep(gate)
+	e.mu.Lock()
+	p1, err := e.src.read()
+	e.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	return float64(p1-p0) / time.Since(start).Seconds(), nil
+}
+
+// Close stops decoding and disables the edge detection.
+func (e *Encoder) Close() error {
+	select {
+	case <-e.done:
+		return nil
+	default:
+	}
+	close(e.done)
+	e.wg.Wait()
+	defer pinuse.Release(e.String(), e.pins()...)
+	var err error
+	if g, ok := e.src.(*gpioSource); ok {
+		err = g.close()
+	}
+	if e.index != nil {
+		if err2 := e.index.In(gpio.PullNoChange, gpio.NoEdge); err == nil && err2 != nil {
+			err = fmt.Errorf("encoder: %v", err2)
+		}
+	}
+	return err
+}
+
+//
+
+// pollPeriod is the maximum time spent waiting for an edge, as Close can't
+// interrupt WaitForEdge.
+const pollPeriod = 100 * time.Millisecond
+
+// source decodes the quadrature outputs.
+type source interface {
+	String() string
+	// read returns the position. The Encoder lock is held.
+	read() (int64, error)
+}
+
+func (e *Encoder) pins() []pin.Pin {
+	var out []pin.Pin
+	if g, ok := e.src.(*gpioSource); ok {
+		out = append(out, g.a, g.b)
+	}
+	if e.index != nil {
+		out = append(out, e.index)
+	}
+	return out
+}
+
+// watch calls f with the level of p on each edge until Close is called.
+func (e *Encoder) watch(p gpio.PinIn, f func(l gpio.Level)) {
+	defer e.wg.Done()
+	for {
+		select {
+		case <-e.done:
+			return
+		default:
+		}
+		if p.WaitForEdge(pollPeriod) {
+			l := p.Read()
+			e.mu.Lock()
+			f(l)
+			e.mu.Unlock()
+		}
+	}
+}
+
+// indexEdge latches the position on an index pulse. The lock is held.
+func (e *Encoder) indexEdge(l gpio.Level) {
+	p, err := e.src.read()
+	if err != nil {
+		return
+	}
+	e.latched = p - e.offset
+	e.indexed = true
+	if e.resetOnIndex {
+		e.offset = p
+	}
+}
+
+// ceilinger is implemented by counter.Kernel.
+type ceilinger interface {
+	SetCeiling(v uint64) error
+}
+
+// kernelSource is a kernel counter with a quadrature function.
+type kernelSource struct {
+	k    counter.Counter
+	last uint32
+	pos  int64
+}
+
+func (k *kernelSource) String() string {
+	return k.k.String()
+}
+
+func (k *kernelSource) init() error {
+	c, err := k.k.Count()
+	k.last = uint32(c)
+	return err
+}
+
+// read accumulates the change of the count since the last read, so the
+// position is signed and doesn't wrap.
+//
+// It must be called at least once per 2³¹ counts.
+func (k *kernelSource) read() (int64, error) {
+	c, err := k.k.Count()
+	if err != nil {
+		return k.pos, err
+	}
+	k.pos += int64(int32(uint32(c) - k.last))
+	k.last = uint32(c)
+	return k.pos, nil
+}
+
+// gpioSource decodes A and B in software.
+//
+// Each edge is decoded against the last level of the other output, so a
+// pair of missed edges on the same output cancels out.
+type gpioSource struct {
+	a, b   gpio.PinIn
+	la, lb gpio.Level
+	pos    int64
+}
+
+func (g *gpioSource) String() string {
+	return fmt.Sprintf("%s, %s", g.a, g.b)
+}
+
+func (g *gpioSource) init(pull gpio.Pull) error {
+	if err := g.a.In(pull, gpio.BothEdges); err != nil {
+		return fmt.Errorf("encoder: %v", err)
+	}
+	if err := g.b.In(pull, gpio.BothEdges); err != nil {
+		_ = g.a.In(gpio.PullNoChange, gpio.NoEdge)
+		return fmt.Errorf("encoder: %v", err)
+	}
+	g.la = g.a.Read()
+	g.lb = g.b.Read()
+	return nil
+}
+
+func (g *gpioSource) close() error {
+	err := g.a.In(gpio.PullNoChange, gpio.NoEdge)
+	if err2 := g.b.In(gpio.PullNoChange, gpio.NoEdge); err == nil {
+		err = err2
+	}
+	if err != nil {
+		return fmt.Errorf("encoder: %v", err)
+	}
+	return nil
+}
+
+func (g *gpioSource) read() (int64, error) {
+	return g.pos, nil
+}
+
+// edgeA decodes an edge of A. The lock is held.
+func (g *gpioSource) edgeA(l gpio.Level) {
+	if l == g.la {
+		return
+	}
+	g.la = l
+	if g.la != g.lb {
+		g.pos++
+	} else {
+		g.pos--
+	}
+}
+
+// edgeB decodes an edge of B. The lock is held.
+func (g *gpioSource) edgeB(l gpio.Level) {
+	if l == g.lb {
+		return
+	}
+	g.lb = l
+	if g.la == g.lb {
+		g.pos++
+	} else {
+		g.pos--
+	}
+}
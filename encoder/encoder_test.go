@@ -0,0 +1,151 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package encoder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+)
+
+func TestNew_gpio(t *testing.T) {
+	a := &gpiotest.Pin{N: "A", Num: 1, EdgesChan: make(chan gpio.Level)}
+	b := &gpiotest.Pin{N: "B", Num: 2, EdgesChan: make(chan gpio.Level)}
+	idx := &gpiotest.Pin{N: "I", Num: 3, EdgesChan: make(chan gpio.Level)}
+	if _, err := New(&Opts{A: a}); err == nil {
+		t.Fatal("B is required")
+	}
+	e, err := New(&Opts{A: a, B: b, Index: idx, Pull: gpio.PullDown})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := e.String(); s != "encoder(A(1), B(2))" {
+		t.Fatal(s)
+	}
+	if o := pinuse.Owner(a); o != e.String() {
+		t.Fatal(o)
+	}
+	// The channels are unbuffered so each edge is received before the next
+	// one is sent, but it may not be decoded yet.
+	wait := func(want int64) {
+		t.Helper()
+		for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+			if p, err := e.Position(); err != nil || p == want {
+				return
+			}
+		}
+		p, _ := e.Position()
+		t.Fatalf("position %d, want %d", p, want)
+	}
+	// A full cycle forward: A leads B.
+	a.EdgesChan <- gpio.High
+	wait(1)
+	b.EdgesChan <- gpio.High
+	wait(2)
+	a.EdgesChan <- gpio.Low
+	wait(3)
+	b.EdgesChan <- gpio.Low
+	wait(4)
+	// Backward: B leads A.
+	b.EdgesChan <- gpio.High
+	wait(3)
+	a.EdgesChan <- gpio.High
+	wait(2)
+	// A repeated level, i.e. a missed pair of edges, is ignored.
+	a.EdgesChan <- gpio.High
+	b.EdgesChan <- gpio.Low
+	wait(1)
+
+	if _, ok := e.Index(); ok {
+		t.Fatal("no index yet")
+	}
+	idx.EdgesChan <- gpio.High
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		if p, ok := e.Index(); ok {
+			if p != 1 {
+				t.Fatal(p)
+			}
+			break
+		}
+		if time.Since(start) > time.Second {
+			t.Fatal("index not latched")
+		}
+	}
+	if err := e.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	wait(0)
+	if v, err := e.Velocity(time.Millisecond); err != nil || v != 0 {
+		t.Fatal(v, err)
+	}
+	if _, err := e.Velocity(0); err == nil {
+		t.Fatal("invalid gate")
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if o := pinuse.Owner(a); o != "" {
+		t.Fatal(o)
+	}
+}
+
+func TestNew_counter(t *testing.T) {
+	c := &fakeCounter{count: 0xFFFFFFFE}
+	e, err := New(&Opts{Counter: c})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	if c.ceiling != 0xFFFFFFFF {
+		t.Fatalf("ceiling %#x", c.ceiling)
+	}
+	// The count wraps around forward.
+	c.count = 3
+	if p, err := e.Position(); err != nil || p != 5 {
+		t.Fatal(p, err)
+	}
+	if err := e.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	// Then backward.
+	c.count = 0xFFFFFFF0
+	if p, err := e.Position(); err != nil || p != -19 {
+		t.Fatal(p, err)
+	}
+	if s := e.String(); s != "encoder(fake)" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+type fakeCounter struct {
+	count   uint64
+	ceiling uint64
+}
+
+func (f *fakeCounter) String() string {
+	return "fake"
+}
+
+func (f *fakeCounter) Count() (uint64, error) {
+	return f.count, nil
+}
+
+func (f *fakeCounter) Reset() error {
+	f.count = 0
+	return nil
+}
+
+func (f *fakeCounter) SetCeiling(v uint64) error {
+	f.ceiling = v
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 	"fmt"
 	"log"
 
-	"periph.io/x/host/v3"
+	"github.com/s-mobi01/host"
 )
 
 func ExampleInit() {
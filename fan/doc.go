@@ -0,0 +1,18 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package fan controls cooling fans: their duty cycle, their speed and whether
+// the kernel or the program drives them, so thermal management code is
+// portable across boards.
+//
+// Three implementations of Fan are provided: Hwmon uses the fans exposed by
+// the Linux hwmon subsystem in /sys/class/hwmon, e.g. the pwm-fan driver used
+// by the Raspberry Pi 5 active cooler; OpenPoEHAT returns the fan of the
+// Raspberry Pi PoE HAT; and GPIO drives a fan with a PWM pin and reads its
+// tachometer with a counter.Counter. bcm283x.NewFan builds on GPIO.
+//
+// Reference
+//
+// https://www.kernel.org/doc/Documentation/hwmon/sysfs-interface
+package fan
@@ -0,0 +1,56 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fan
+
+import (
+	"errors"
+	"strconv"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+// Fan is a cooling fan.
+type Fan interface {
+	String() string
+	// SetDuty switches the fan to Manual mode and drives it at duty d.
+	SetDuty(d gpio.Duty) error
+	// Duty returns the duty cycle the fan is driven at, including in Auto
+	// mode when the implementation can read it back.
+	Duty() (gpio.Duty, error)
+	// RPM returns the measured speed in revolutions per minute.
+	//
+	// It returns ErrNoTach when the fan has no tachometer.
+	RPM() (int, error)
+	// SetMode selects who drives the fan.
+	SetMode(m Mode) error
+	// Mode returns who drives the fan.
+	Mode() (Mode, error)
+}
+
+// Mode is who drives a fan.
+type Mode int
+
+// Fan modes.
+const (
+	// Manual means the duty cycle is set by SetDuty.
+	Manual Mode = iota
+	// Auto means the duty cycle is set by the kernel or the fan controller,
+	// usually following the temperature.
+	Auto
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Manual:
+		return "Manual"
+	case Auto:
+		return "Auto"
+	default:
+		return "Mode(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// ErrNoTach is returned by RPM when the fan has no tachometer.
+var ErrNoTach = errors.New("fan: no tachometer")
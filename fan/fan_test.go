@@ -0,0 +1,269 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fan
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestHwmon(t *testing.T) {
+	root, cleanup := fakeSysfs(t, map[string]string{
+		"hwmon0/name":        "cpu_thermal\n",
+		"hwmon0/temp1_input": "45000\n",
+		"hwmon1/name":        "pwmfan\n",
+		"hwmon1/pwm1":        "0\n",
+		"hwmon1/pwm1_enable": "2\n",
+		"hwmon1/fan1_input":  "2400\n",
+		"hwmon2/name":        "rpipoefan\n",
+		"hwmon2/pwm1":        "255\n",
+		"hwmon3/name":        "pwmfan\n",
+		"hwmon3/pwm1":        "0\n",
+		"hwmon3/pwm1_enable": "1\n",
+		"hwmon3/fan1_input":  "0\n",
+		"hwmon4/name":        "nct6775\n",
+		"hwmon4/pwm1":        "128\n",
+		"hwmon4/pwm2":        "64\n",
+		"hwmon4/pwm2_enable": "5\n",
+		"hwmon4/fan2_input":  "900\n",
+		"hwmon4/temp1_input": "30000\n",
+	})
+	defer cleanup()
+
+	devs, err := Enumerate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"hwmon1": "pwmfan", "hwmon2": "rpipoefan", "hwmon3": "pwmfan", "hwmon4": "nct6775"}
+	if !reflect.DeepEqual(devs, want) {
+		t.Fatal(devs)
+	}
+	if _, err := OpenHwmon("cpu_thermal", 1); err == nil {
+		t.Fatal("not a fan")
+	}
+	if _, err := OpenHwmon("pwmfan", 0); err == nil {
+		t.Fatal("invalid channel")
+	}
+	if _, err := OpenHwmon("pwmfan", 2); err == nil {
+		t.Fatal("pwm2 doesn't exist")
+	}
+	// The first device is used when several share the same name.
+	h, err := OpenHwmon("pwmfan", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := h.String(); s != "pwmfan/pwm1" {
+		t.Fatal(s)
+	}
+	if m, err := h.Mode(); err != nil || m != Auto {
+		t.Fatal(m, err)
+	}
+	if v, err := h.RPM(); err != nil || v != 2400 {
+		t.Fatal(v, err)
+	}
+	if err := h.SetDuty(gpio.DutyHalf); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := readAttr(filepath.Join(root, "hwmon1/pwm1")); err != nil || s != "128" {
+		t.Fatal(s, err)
+	}
+	if d, err := h.Duty(); err != nil || d != gpio.Duty((128*int64(gpio.DutyMax)+127)/255) {
+		t.Fatal(d, err)
+	}
+	// SetDuty switches to Manual.
+	if m, err := h.Mode(); err != nil || m != Manual {
+		t.Fatal(m, err)
+	}
+	if err := h.SetMode(Auto); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := readAttr(filepath.Join(root, "hwmon1/pwm1_enable")); err != nil || s != "2" {
+		t.Fatal(s, err)
+	}
+	if err := h.SetDuty(gpio.DutyMax + 1); err == nil {
+		t.Fatal("invalid duty")
+	}
+	if err := h.SetMode(Mode(3)); err == nil {
+		t.Fatal("invalid mode")
+	}
+
+	h, err = OpenHwmon("nct6775", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, err := h.Mode(); err != nil || m != Auto {
+		t.Fatal(m, err)
+	}
+	if v, err := h.RPM(); err != nil || v != 900 {
+		t.Fatal(v, err)
+	}
+}
+
+func TestOpenPoEHAT(t *testing.T) {
+	root, cleanup := fakeSysfs(t, map[string]string{
+		"hwmon0/name": "rpipoefan\n",
+		"hwmon0/pwm1": "255\n",
+	})
+	defer cleanup()
+
+	h, err := OpenPoEHAT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, err := h.Duty(); err != nil || d != gpio.DutyMax {
+		t.Fatal(d, err)
+	}
+	if _, err := h.RPM(); err != ErrNoTach {
+		t.Fatal(err)
+	}
+	// No pwm1_enable.
+	if m, err := h.Mode(); err != nil || m != Manual {
+		t.Fatal(m, err)
+	}
+	if err := h.SetMode(Auto); err == nil {
+		t.Fatal("no mode control")
+	}
+	if err := h.SetDuty(0); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := readAttr(filepath.Join(root, "hwmon0/pwm1")); err != nil || s != "0" {
+		t.Fatal(s, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "hwmon0/pwm1_enable")); !os.IsNotExist(err) {
+		t.Fatal("pwm1_enable must not be created", err)
+	}
+}
+
+func TestGPIO(t *testing.T) {
+	defer func(g time.Duration) { tachGate = g }(tachGate)
+	tachGate = time.Millisecond
+	p := &gpiotest.Pin{N: "GPIO18"}
+	c := &fakeCounter{}
+	g, err := NewGPIO(p, 25*physic.KiloHertz, c, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := g.String(); s != "fan(GPIO18(0))" {
+		t.Fatal(s)
+	}
+	if _, err := NewGPIO(p, 25*physic.KiloHertz, nil, 0); err == nil {
+		t.Fatal("pin is busy")
+	}
+	if err := g.SetDuty(gpio.DutyHalf); err != nil {
+		t.Fatal(err)
+	}
+	if p.D != gpio.DutyHalf || p.F != 25*physic.KiloHertz {
+		t.Fatal(p.D, p.F)
+	}
+	if d, err := g.Duty(); err != nil || d != gpio.DutyHalf {
+		t.Fatal(d, err)
+	}
+	// Whatever the gate time, the RPM is positive.
+	c.step = 100
+	if v, err := g.RPM(); err != nil || v <= 0 {
+		t.Fatal(v, err)
+	}
+	if m, err := g.Mode(); err != nil || m != Manual {
+		t.Fatal(m, err)
+	}
+	if err := g.SetMode(Auto); err == nil {
+		t.Fatal("Manual only")
+	}
+	if err := g.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if o := pinuse.Owner(p); o != "" {
+		t.Fatal("pin not released", o)
+	}
+
+	g, err = NewGPIO(p, 25*physic.KiloHertz, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.RPM(); err != ErrNoTach {
+		t.Fatal(err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewGPIO(nil, 25*physic.KiloHertz, nil, 0); err == nil {
+		t.Fatal("nil pin")
+	}
+	if _, err := NewGPIO(p, 0, nil, 0); err == nil {
+		t.Fatal("invalid frequency")
+	}
+	if _, err := NewGPIO(p, 25*physic.KiloHertz, c, 0); err == nil {
+		t.Fatal("invalid pulses")
+	}
+}
+
+func TestMode_String(t *testing.T) {
+	if s := Auto.String(); s != "Auto" {
+		t.Fatal(s)
+	}
+	if s := Mode(3).String(); s != "Mode(3)" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+// fakeSysfs creates the files in a temporary directory used as hwmonRoot.
+// The returned function restores hwmonRoot.
+func fakeSysfs(t *testing.T, files map[string]string) (string, func()) {
+	root, err := ioutil.TempDir("", "fan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := hwmonRoot
+	hwmonRoot = root + "/"
+	return root, func() {
+		hwmonRoot = old
+		os.RemoveAll(root)
+	}
+}
+
+// fakeCounter advances by step on each call.
+type fakeCounter struct {
+	mu    sync.Mutex
+	step  uint64
+	count uint64
+}
+
+func (f *fakeCounter) String() string {
+	return "fake"
+}
+
+func (f *fakeCounter) Count() (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v := f.count
+	f.count += f.step
+	return v, nil
+}
+
+func (f *fakeCounter) Reset() error {
+	f.count = 0
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fan
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/s-mobi01/host/counter"
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+// NewGPIO returns a fan driven by the PWM output p at frequency f, with its
+// tachometer read by tach, e.g. a counter.NewGPIO on the tachometer pin.
+//
+// The usual 4 wires PC fans expect 25kHz, which requires a hardware PWM. tach
+// may be nil when the fan has no tachometer; pulses is the number of
+// tachometer pulses per revolution, usually 2.
+//
+// The fan is stopped until SetDuty is called. Call Close to stop the fan and
+// release the pin. The resulting object is safe for concurrent use.
+func NewGPIO(p gpio.PinOut, f physic.Frequency, tach counter.Counter, pulses int) (*GPIO, error) {
+	if p == nil {
+		return nil, errors.New("fan: pin is required")
+	}
+	if f <= 0 {
+		return nil, errors.New("fan: invalid frequency")
+	}
+	if tach != nil && pulses < 1 {
+		return nil, errors.New("fan: invalid pulses per revolution")
+	}
+	g := &GPIO{p: p, f: f, tach: tach, pulses: pulses}
+	if err := pinuse.Claim(g.String(), p); err != nil {
+		return nil, fmt.Errorf("fan: %v", err)
+	}
+	return g, nil
+}
+
+// GPIO is a fan driven by a PWM pin.
+//
+// It only supports the Manual mode.
+type GPIO struct {
+	p      gpio.PinOut
+	f      physic.Frequency
+	tach   counter.Counter
+	pulses int
+
+	mu   sync.Mutex
+	duty gpio.Duty
+}
+
+func (g *GPIO) String() string {
+	return fmt.Sprintf("fan(%s)", g.p)
+}
+
+// Close stops the fan and releases the pin.
+func (g *GPIO) Close() error {
+	defer pinuse.Release(g.String(), g.p)
+	if err := g.p.Halt(); err != nil {
+		return fmt.Errorf("fan: %v", err)
+	}
+	return nil
+}
+
+// SetDuty implements Fan.
+func (g *GPIO) SetDuty(d gpio.Duty) error {
+	if d < 0 || d > gpio.DutyMax {
+		return fmt.Errorf("fan: invalid duty %s", d)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := g.p.PWM(d, g.f); err != nil {
+		return fmt.Errorf("fan: %v", err)
+	}
+	g.duty = d
+	return nil
+}
+
+// Duty implements Fan.
+func (g *GPIO) Duty() (gpio.Duty, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.duty, nil
+}
+
+// RPM implements Fan.
+//
+// It counts the tachometer pulses for one second, which gives a resolution of
+// 60/pulses RPM.
+func (g *GPIO) RPM() (int, error) {
+	if g.tach == nil {
+		return 0, ErrNoTach
+	}
+	m, err := counter.Measure(g.tach, tachGate)
+	if err != nil {
+		return 0, fmt.Errorf("fan: %v", err)
+	}
+	return int(int64(m.Count) * int64(time.Minute) / int64(m.Gate) / int64(g.pulses)), nil
+}
+
+// SetMode implements Fan.
+func (g *GPIO) SetMode(m Mode) error {
+	if m != Manual {
+		return fmt.Errorf("fan: %s only supports Manual mode", g)
+	}
+	return nil
+}
+
+// Mode implements Fan.
+func (g *GPIO) Mode() (Mode, error) {
+	return Manual, nil
+}
+
+//
+
+// tachGate is the time the tachometer pulses are counted. It is overridden in
+// tests.
+var tachGate = time.Second
+
+var _ Fan = &GPIO{}
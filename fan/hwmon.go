@@ -0,0 +1,233 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fan
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+// Enumerate returns the hwmon devices exposing a fan control, as their
+// directory in /sys/class/hwmon mapped to their driver provided name, e.g.
+// "hwmon2": "pwmfan".
+func Enumerate() (map[string]string, error) {
+	items, err := filepath.Glob(hwmonRoot + "hwmon*")
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]string{}
+	for _, item := range items {
+		if _, err := os.Stat(filepath.Join(item, "pwm1")); err != nil {
+			continue
+		}
+		name, err := readAttr(filepath.Join(item, "name"))
+		if err != nil {
+			return nil, fmt.Errorf("fan: %v", err)
+		}
+		out[filepath.Base(item)] = name
+	}
+	return out, nil
+}
+
+// OpenHwmon opens the fan control pwm<n> of the first hwmon device named
+// name, e.g. OpenHwmon("pwmfan", 1) for the pwm-fan driver.
+//
+// The fan speed is read from fan<n>_input, when present.
+func OpenHwmon(name string, n int) (*Hwmon, error) {
+	if n < 1 {
+		return nil, errors.New("fan: invalid channel")
+	}
+	devs, err := Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for dir, s := range devs {
+		if s == name {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("fan: no hwmon device %q", name)
+	}
+	// Sort for a stable result when multiple devices share the same name.
+	sort.Strings(dirs)
+	root := filepath.Join(hwmonRoot, dirs[0])
+	pwm := "pwm" + strconv.Itoa(n)
+	if _, err := os.Stat(filepath.Join(root, pwm)); err != nil {
+		return nil, fmt.Errorf("fan: %s/%s doesn't exist", name, pwm)
+	}
+	return &Hwmon{name: name + "/" + pwm, root: root, n: strconv.Itoa(n)}, nil
+}
+
+// OpenPoEHAT returns the fan of the Raspberry Pi PoE HAT, driven by the
+// rpi-poe-fan kernel driver.
+//
+// The fan has no tachometer. The kernel also changes its duty cycle at the
+// temperatures set with the poe_fan_temp device tree parameters. On the
+// kernels driving the PoE+ HAT fan with pwm-fan, use OpenHwmon("pwmfan", 1).
+func OpenPoEHAT() (*Hwmon, error) {
+	return OpenHwmon("rpipoefan", 1)
+}
+
+// Hwmon is a fan control of the Linux hwmon subsystem.
+//
+// The resulting object is safe for concurrent use.
+type Hwmon struct {
+	name string
+	root string
+	n    string
+
+	mu sync.Mutex
+}
+
+func (h *Hwmon) String() string {
+	return h.name
+}
+
+// SetDuty implements Fan.
+//
+// The duty cycle is rounded to the 256 steps of the hwmon interface.
+func (h *Hwmon) SetDuty(d gpio.Duty) error {
+	if d < 0 || d > gpio.DutyMax {
+		return fmt.Errorf("fan: invalid duty %s", d)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.hasEnable() {
+		if err := h.write("pwm"+h.n+"_enable", "1"); err != nil {
+			return err
+		}
+	}
+	v := (int64(d)*255 + int64(gpio.DutyMax)/2) / int64(gpio.DutyMax)
+	return h.write("pwm"+h.n, strconv.FormatInt(v, 10))
+}
+
+// Duty implements Fan.
+func (h *Hwmon) Duty() (gpio.Duty, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, err := h.readInt("pwm" + h.n)
+	if err != nil {
+		return 0, err
+	}
+	return gpio.Duty((int64(v)*int64(gpio.DutyMax) + 127) / 255), nil
+}
+
+// RPM implements Fan.
+func (h *Hwmon) RPM() (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, err := h.readInt("fan" + h.n + "_input")
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, ErrNoTach
+	}
+	return v, err
+}
+
+// SetMode implements Fan.
+//
+// It fails when the driver doesn't expose pwm<n>_enable.
+func (h *Hwmon) SetMode(m Mode) error {
+	var v string
+	switch m {
+	case Manual:
+		v = "1"
+	case Auto:
+		v = "2"
+	default:
+		return fmt.Errorf("fan: invalid mode %s", m)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.hasEnable() {
+		return fmt.Errorf("fan: %s has no mode control", h.name)
+	}
+	return h.write("pwm"+h.n+"_enable", v)
+}
+
+// Mode implements Fan.
+//
+// It returns Manual when the driver doesn't expose pwm<n>_enable.
+func (h *Hwmon) Mode() (Mode, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.hasEnable() {
+		return Manual, nil
+	}
+	v, err := h.readInt("pwm" + h.n + "_enable")
+	if err != nil {
+		return Manual, err
+	}
+	// 0 means the fan is at full speed without control and 2 and higher are
+	// the driver specific automatic modes.
+	if v >= 2 {
+		return Auto, nil
+	}
+	return Manual, nil
+}
+
+//
+
+// hwmonRoot is overridden in tests.
+var hwmonRoot = "/sys/class/hwmon/"
+
+func (h *Hwmon) hasEnable() bool {
+	_, err := os.Stat(filepath.Join(h.root, "pwm"+h.n+"_enable"))
+	return err == nil
+}
+
+func (h *Hwmon) readInt(attr string) (int, error) {
+	s, err := readAttr(filepath.Join(h.root, attr))
+	if err != nil {
+		return 0, fmt.Errorf("fan: %w", err)
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("fan: %v", err)
+	}
+	return v, nil
+}
+
+func (h *Hwmon) write(attr, v string) error {
+	if err := writeAttr(filepath.Join(h.root, attr), v); err != nil {
+		return fmt.Errorf("fan: %v", err)
+	}
+	return nil
+}
+
+func readAttr(p string) (string, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func writeAttr(p, v string) error {
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("need more access, try as root: %v", err)
+		}
+		return err
+	}
+	_, err = f.Write([]byte(v))
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+var _ Fan = &Hwmon{}
@@ -0,0 +1,220 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Raw bit modes other than MPSSE.
+//
+// Bit bang modes:
+// http://www.ftdichip.com/Support/Documents/AppNotes/AN_232R-01_Bit_Bang_Mode_Available_For_FT232R_and_Ft245R.pdf
+//
+// MCU host bus emulation, section 6:
+// http://www.ftdichip.com/Support/Documents/AppNotes/AN_108_Command_Processor_for_MPSSE_and_MCU_Host_Bus_Emulation_Modes.pdf
+
+package ftdi
+
+import (
+	"errors"
+)
+
+// AsyncBitBang switches D0~D7 to asynchronous bit-bang mode, leaving MPSSE.
+//
+// mask sets the direction of each pin, 1 means output. Each byte written is
+// output on the pins, paced by the rate set with SetSpeed.
+//
+// I²C, SPI and the other GPIOs can't be used until Close is called, which
+// returns the device to MPSSE mode.
+func (f *FT232H) AsyncBitBang(mask byte) (*BitBang, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.canUseBitMode(); err != nil {
+		return nil, err
+	}
+	if err := f.h.SetBitMode(mask, bitModeAsyncBitbang); err != nil {
+		return nil, err
+	}
+	f.usingBitMode = true
+	return &BitBang{f: f}, nil
+}
+
+// MCUHost switches the device to MCU host bus emulation mode, leaving MPSSE.
+//
+// The device then drives an 8051 style bus with multiplexed address and data
+// lines, a latch enable and read and write strobes, to access legacy parallel
+// bus chips. See the section "MCU Host Bus Emulation Mode" of the datasheet
+// for the pinout.
+//
+// I²C, SPI and the GPIOs can't be used until Close is called, which returns
+// the device to MPSSE mode.
+func (f *FT232H) MCUHost() (*MCUHost, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.canUseBitMode(); err != nil {
+		return nil, err
+	}
+	if err := f.h.SetBitMode(0, bitModeMcuHost); err != nil {
+		return nil, err
+	}
+	f.usingBitMode = true
+	return &MCUHost{f: f}, nil
+}
+
+// BitBang is D0~D7 in asynchronous bit-bang mode.
+type BitBang struct {
+	f *FT232H
+}
+
+func (b *BitBang) String() string {
+	return b.f.String()
+}
+
+// Close returns the device to MPSSE mode.
+func (b *BitBang) Close() error {
+	return b.f.closeBitMode()
+}
+
+// SetDirection changes the direction of the pins; 1 means output.
+func (b *BitBang) SetDirection(mask byte) error {
+	b.f.mu.Lock()
+	defer b.f.mu.Unlock()
+	if !b.f.usingBitMode {
+		return errors.New("d2xx: bit mode is closed")
+	}
+	return b.f.h.SetBitMode(mask, bitModeAsyncBitbang)
+}
+
+// Write outputs each byte in turn on the pins set as output.
+func (b *BitBang) Write(p []byte) (int, error) {
+	b.f.mu.Lock()
+	defer b.f.mu.Unlock()
+	if !b.f.usingBitMode {
+		return 0, errors.New("d2xx: bit mode is closed")
+	}
+	return b.f.h.Write(p)
+}
+
+// Read returns the current level of D0~D7.
+func (b *BitBang) Read() (byte, error) {
+	b.f.mu.Lock()
+	defer b.f.mu.Unlock()
+	if !b.f.usingBitMode {
+		return 0, errors.New("d2xx: bit mode is closed")
+	}
+	return b.f.h.GetBitMode()
+}
+
+// MCUHost is the device in MCU host bus emulation mode.
+type MCUHost struct {
+	f *FT232H
+}
+
+func (m *MCUHost) String() string {
+	return m.f.String()
+}
+
+// Close returns the device to MPSSE mode.
+func (m *MCUHost) Close() error {
+	return m.f.closeBitMode()
+}
+
+// Read reads the byte at a 16 bits address.
+func (m *MCUHost) Read(addr uint16) (byte, error) {
+	var b [1]byte
+	err := m.tx([]byte{cpuReadFar, byte(addr >> 8), byte(addr), flush}, b[:])
+	return b[0], err
+}
+
+// ReadShort reads the byte at an 8 bits address; the high address lines are
+// left unchanged.
+func (m *MCUHost) ReadShort(addr byte) (byte, error) {
+	var b [1]byte
+	err := m.tx([]byte{cpuReadShort, addr, flush}, b[:])
+	return b[0], err
+}
+
+// ReadBlock reads len(b) bytes at consecutive addresses starting at addr, in
+// a single USB transfer.
+func (m *MCUHost) ReadBlock(addr uint16, b []byte) error {
+	if int(addr)+len(b) > 0x10000 {
+		return errors.New("d2xx: block crosses the end of the address space")
+	}
+	cmd := make([]byte, 0, 3*len(b)+1)
+	for i := range b {
+		a := addr + uint16(i)
+		cmd = append(cmd, cpuReadFar, byte(a>>8), byte(a))
+	}
+	return m.tx(append(cmd, flush), b)
+}
+
+// Write writes the byte at a 16 bits address.
+func (m *MCUHost) Write(addr uint16, v byte) error {
+	return m.tx([]byte{cpuWriteFar, byte(addr >> 8), byte(addr), v}, nil)
+}
+
+// WriteShort writes the byte at an 8 bits address; the high address lines
+// are left unchanged.
+func (m *MCUHost) WriteShort(addr, v byte) error {
+	return m.tx([]byte{cpuWriteShort, addr, v}, nil)
+}
+
+func (m *MCUHost) tx(cmd, r []byte) error {
+	m.f.mu.Lock()
+	defer m.f.mu.Unlock()
+	if !m.f.usingBitMode {
+		return errors.New("d2xx: bit mode is closed")
+	}
+	if _, err := m.f.h.Write(cmd); err != nil {
+		return err
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	ctx, cancel := context200ms()
+	defer cancel()
+	_, err := m.f.h.ReadAll(ctx, r)
+	return err
+}
+
+//
+
+// canUseBitMode returns an error if the pins are in use.
+//
+// f.mu must be held.
+func (f *FT232H) canUseBitMode() error {
+	if f.usingI2C {
+		return errors.New("d2xx: already using I²C")
+	}
+	if f.usingSPI {
+		return errors.New("d2xx: already using SPI")
+	}
+	if f.usingBitMode {
+		return errors.New("d2xx: already using a bit mode")
+	}
+	if f.usingOneWire {
+		return errors.New("d2xx: already using 1-Wire")
+	}
+	if f.usingUART {
+		return errors.New("d2xx: already using the UART")
+	}
+	if f.usingJTAG {
+		return errors.New("d2xx: already using JTAG")
+	}
+	if f.usingSWD {
+		return errors.New("d2xx: already using SWD")
+	}
+	return nil
+}
+
+// closeBitMode returns the device to MPSSE mode.
+func (f *FT232H) closeBitMode() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.usingBitMode {
+		return nil
+	}
+	f.usingBitMode = false
+	if err := f.h.SetBitMode(0, bitModeMpsse); err != nil {
+		return err
+	}
+	// This resets the clock and all the GPIOs as inputs.
+	return f.h.InitMPSSE()
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFT232H_AsyncBitBang(t *testing.T) {
+	h := &recordHandle{fakeHandle: fakeHandle{Data: mpsseVerifyReplies()}}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	b, err := f.AsyncBitBang(0x0F)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0x0F || bitMode(h.mode) != bitModeAsyncBitbang {
+		t.Fatalf("mask %#x mode %#x", h.mask, h.mode)
+	}
+	if _, err := f.MCUHost(); err == nil {
+		t.Fatal("already in bit mode")
+	}
+	if _, err := f.SPI(); err == nil {
+		t.Fatal("already in bit mode")
+	}
+	if _, err := b.Write([]byte{1, 2, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(h.w, []byte{1, 2, 4}) {
+		t.Fatalf("%#x", h.w)
+	}
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if bitMode(h.mode) != bitModeMpsse {
+		t.Fatalf("mode %#x", h.mode)
+	}
+	if _, err := b.Write([]byte{1}); err == nil {
+		t.Fatal("closed")
+	}
+}
+
+func TestFT232H_MCUHost(t *testing.T) {
+	h := &recordHandle{fakeHandle: fakeHandle{Data: [][]byte{{0x42}, {1, 2}}}}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	m, err := f.MCUHost()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bitMode(h.mode) != bitModeMcuHost {
+		t.Fatalf("mode %#x", h.mode)
+	}
+	if v, err := m.Read(0x1234); err != nil || v != 0x42 {
+		t.Fatal(v, err)
+	}
+	if err := m.Write(0x1234, 0x55); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteShort(0x34, 0x56); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 2)
+	if err := m.ReadBlock(0x00FF, b); err != nil || !bytes.Equal(b, []byte{1, 2}) {
+		t.Fatal(b, err)
+	}
+	want := []byte{
+		cpuReadFar, 0x12, 0x34, flush,
+		0x93, 0x12, 0x34, 0x55,
+		cpuWriteShort, 0x34, 0x56,
+		cpuReadFar, 0x00, 0xFF, cpuReadFar, 0x01, 0x00, flush,
+	}
+	if !bytes.Equal(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+	if err := m.ReadBlock(0xFFFF, b); err == nil {
+		t.Fatal("crosses the end of the address space")
+	}
+}
+
+//
+
+// recordHandle records the writes and the bit mode.
+//
+// Each write queues the next reply, if any, for reading. bits is returned by
+// GetBitMode.
+type recordHandle struct {
+	fakeHandle
+	w       []byte
+	replies [][]byte
+	mask    byte
+	mode    byte
+	bits    byte
+}
+
+func (r *recordHandle) Write(b []byte) (int, d2xxErr) {
+	r.w = append(r.w, b...)
+	if len(r.replies) != 0 {
+		r.Data = append(r.Data, r.replies[0])
+		r.replies = r.replies[1:]
+	}
+	return len(b), 0
+}
+
+func (r *recordHandle) SetBitMode(mask, mode byte) d2xxErr {
+	r.mask = mask
+	r.mode = mode
+	return 0
+}
+
+func (r *recordHandle) GetBitMode() (byte, d2xxErr) {
+	return r.bits, 0
+}
+
+// mpsseVerifyReplies returns the replies to the bad commands sent by
+// mpsseVerify.
+func mpsseVerifyReplies() [][]byte {
+	return [][]byte{{0xFA, 0xAA}, {0xFA, 0xAB}}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import "strconv"
+
+// d2xxErr is a FT_STATUS value, as returned by the D2XX library and mimicked
+// by the usbfs backend.
+type d2xxErr int
+
+// d2xxMissing is returned when the D2XX library couldn't be loaded at
+// runtime.
+const d2xxMissing d2xxErr = -1
+
+func (e d2xxErr) String() string {
+	switch e {
+	case d2xxMissing:
+		return "couldn't load driver; visit https://periph.io/device/ftdi/ for help"
+	case 0: // FT_OK
+		return ""
+	case 1: // FT_INVALID_HANDLE
+		return "invalid handle"
+	case 2: // FT_DEVICE_NOT_FOUND
+		return "device not found; see https://periph.io/device/ftdi/ for help"
+	case 3: // FT_DEVICE_NOT_OPENED
+		return "device busy; see https://periph.io/device/ftdi/ for help"
+	case 4: // FT_IO_ERROR
+		return "I/O error"
+	case 5: // FT_INSUFFICIENT_RESOURCES
+		return "insufficient resources"
+	case 6: // FT_INVALID_PARAMETER
+		return "invalid parameter"
+	case 7: // FT_INVALID_BAUD_RATE
+		return "invalid baud rate"
+	case 8: // FT_DEVICE_NOT_OPENED_FOR_ERASE
+		return "device not opened for erase"
+	case 9: // FT_DEVICE_NOT_OPENED_FOR_WRITE
+		return "device not opened for write"
+	case 10: // FT_FAILED_TO_WRITE_DEVICE
+		return "failed to write device"
+	case 11: // FT_EEPROM_READ_FAILED
+		return "eeprom read failed"
+	case 12: // FT_EEPROM_WRITE_FAILED
+		return "eeprom write failed"
+	case 13: // FT_EEPROM_ERASE_FAILED
+		return "eeprom erase failed"
+	case 14: // FT_EEPROM_NOT_PRESENT
+		return "eeprom not present"
+	case 15: // FT_EEPROM_NOT_PROGRAMMED
+		return "eeprom not programmed"
+	case 16: // FT_INVALID_ARGS
+		return "invalid argument"
+	case 17: // FT_NOT_SUPPORTED
+		return "not supported"
+	case 18: // FT_OTHER_ERROR
+		return "other error"
+	case 19: // FT_DEVICE_LIST_NOT_READY
+		return "device list not ready"
+	default:
+		return "unknown status " + strconv.Itoa(int(e))
+	}
+}
+
+// d2xxEEPROM is the unprocessed EEPROM content, as exchanged with the D2XX
+// library. It excludes the strings from Raw.
+type d2xxEEPROM struct {
+	Raw            []byte
+	Manufacturer   string
+	ManufacturerID string
+	Desc           string
+	Serial         string
+}
+
+// d2xxHandle is a device handle of one of the backends.
+//
+// It mirrors periph.io/x/d2xx.Handle, so the package only depends on
+// periph.io/x/d2xx when the D2XX backend is built in.
+type d2xxHandle interface {
+	Close() d2xxErr
+	// ResetDevice takes >1.2ms
+	ResetDevice() d2xxErr
+	GetDeviceInfo() (uint32, uint16, uint16, d2xxErr)
+	EEPROMRead(devType uint32, e *d2xxEEPROM) d2xxErr
+	EEPROMProgram(e *d2xxEEPROM) d2xxErr
+	EraseEE() d2xxErr
+	WriteEE(offset uint8, value uint16) d2xxErr
+	EEUASize() (int, d2xxErr)
+	EEUARead(ua []byte) d2xxErr
+	EEUAWrite(ua []byte) d2xxErr
+	SetChars(eventChar byte, eventEn bool, errorChar byte, errorEn bool) d2xxErr
+	SetUSBParameters(in, out int) d2xxErr
+	SetFlowControl() d2xxErr
+	SetTimeouts(readMS, writeMS int) d2xxErr
+	SetLatencyTimer(delayMS uint8) d2xxErr
+	SetBaudRate(hz uint32) d2xxErr
+	// GetQueueStatus takes >60µs
+	GetQueueStatus() (uint32, d2xxErr)
+	// Read takes <5µs if GetQueueStatus was called just before,
+	// 300µs~800µs otherwise (!)
+	Read(b []byte) (int, d2xxErr)
+	// Write takes >0.1ms
+	Write(b []byte) (int, d2xxErr)
+	GetBitMode() (byte, d2xxErr)
+	// SetBitMode takes >0.1ms
+	SetBitMode(mask, mode byte) d2xxErr
+}
@@ -0,0 +1,13 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build (cgo || windows) && !no_d2xx
+// +build cgo windows
+// +build !no_d2xx
+
+package ftdi
+
+// d2xxBuilt is true when package d2xx was built with the D2XX library, which
+// requires cgo except on Windows.
+const d2xxBuilt = true
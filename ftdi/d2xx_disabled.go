@@ -0,0 +1,24 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build (!periph_host_ftdi_d2xx && !windows && !darwin) || no_d2xx
+// +build !periph_host_ftdi_d2xx,!windows,!darwin no_d2xx
+
+package ftdi
+
+// d2xxEnabled is false when the D2XX backend is left out, so periph.io/x/d2xx
+// is not linked in. It is the default on Linux, where usbfs is used.
+const d2xxEnabled = false
+
+func d2xxAvailable() bool {
+	return false
+}
+
+func d2xxNumDevices() (int, d2xxErr) {
+	return 0, d2xxMissing
+}
+
+func d2xxOpenDevice(i int) (d2xxHandle, d2xxErr) {
+	return nil, d2xxMissing
+}
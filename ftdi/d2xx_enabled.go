@@ -0,0 +1,137 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build (periph_host_ftdi_d2xx || windows || darwin) && !no_d2xx
+// +build periph_host_ftdi_d2xx windows darwin
+// +build !no_d2xx
+
+package ftdi
+
+import "periph.io/x/d2xx"
+
+// d2xxEnabled is true when the D2XX backend is built in: by default on
+// Windows and macOS where usbfs doesn't exist, with the build tag
+// periph_host_ftdi_d2xx elsewhere.
+const d2xxEnabled = true
+
+// d2xxAvailable returns true if the D2XX library can be used.
+//
+// On Windows, the DLL is loaded at runtime.
+func d2xxAvailable() bool {
+	return d2xx.Available
+}
+
+// d2xxNumDevices returns the number of devices detected by D2XX.
+func d2xxNumDevices() (int, d2xxErr) {
+	num, e := d2xx.CreateDeviceInfoList()
+	return num, d2xxErr(e)
+}
+
+// d2xxOpenDevice opens the ith device with D2XX.
+func d2xxOpenDevice(i int) (d2xxHandle, d2xxErr) {
+	h, e := d2xx.Open(i)
+	if e != 0 {
+		return nil, d2xxErr(e)
+	}
+	return &d2xxLib{h: h}, 0
+}
+
+// d2xxLib implements d2xxHandle with the D2XX library.
+type d2xxLib struct {
+	h d2xx.Handle
+}
+
+func (l *d2xxLib) Close() d2xxErr {
+	return d2xxErr(l.h.Close())
+}
+
+func (l *d2xxLib) ResetDevice() d2xxErr {
+	return d2xxErr(l.h.ResetDevice())
+}
+
+func (l *d2xxLib) GetDeviceInfo() (uint32, uint16, uint16, d2xxErr) {
+	t, vid, pid, e := l.h.GetDeviceInfo()
+	return t, vid, pid, d2xxErr(e)
+}
+
+func (l *d2xxLib) EEPROMRead(devType uint32, e *d2xxEEPROM) d2xxErr {
+	ee := d2xx.EEPROM{Raw: e.Raw}
+	s := l.h.EEPROMRead(devType, &ee)
+	*e = d2xxEEPROM(ee)
+	return d2xxErr(s)
+}
+
+func (l *d2xxLib) EEPROMProgram(e *d2xxEEPROM) d2xxErr {
+	ee := d2xx.EEPROM(*e)
+	return d2xxErr(l.h.EEPROMProgram(&ee))
+}
+
+func (l *d2xxLib) EraseEE() d2xxErr {
+	return d2xxErr(l.h.EraseEE())
+}
+
+func (l *d2xxLib) WriteEE(offset uint8, value uint16) d2xxErr {
+	return d2xxErr(l.h.WriteEE(offset, value))
+}
+
+func (l *d2xxLib) EEUASize() (int, d2xxErr) {
+	size, e := l.h.EEUASize()
+	return size, d2xxErr(e)
+}
+
+func (l *d2xxLib) EEUARead(ua []byte) d2xxErr {
+	return d2xxErr(l.h.EEUARead(ua))
+}
+
+func (l *d2xxLib) EEUAWrite(ua []byte) d2xxErr {
+	return d2xxErr(l.h.EEUAWrite(ua))
+}
+
+func (l *d2xxLib) SetChars(eventChar byte, eventEn bool, errorChar byte, errorEn bool) d2xxErr {
+	return d2xxErr(l.h.SetChars(eventChar, eventEn, errorChar, errorEn))
+}
+
+func (l *d2xxLib) SetUSBParameters(in, out int) d2xxErr {
+	return d2xxErr(l.h.SetUSBParameters(in, out))
+}
+
+func (l *d2xxLib) SetFlowControl() d2xxErr {
+	return d2xxErr(l.h.SetFlowControl())
+}
+
+func (l *d2xxLib) SetTimeouts(readMS, writeMS int) d2xxErr {
+	return d2xxErr(l.h.SetTimeouts(readMS, writeMS))
+}
+
+func (l *d2xxLib) SetLatencyTimer(delayMS uint8) d2xxErr {
+	return d2xxErr(l.h.SetLatencyTimer(delayMS))
+}
+
+func (l *d2xxLib) SetBaudRate(hz uint32) d2xxErr {
+	return d2xxErr(l.h.SetBaudRate(hz))
+}
+
+func (l *d2xxLib) GetQueueStatus() (uint32, d2xxErr) {
+	n, e := l.h.GetQueueStatus()
+	return n, d2xxErr(e)
+}
+
+func (l *d2xxLib) Read(b []byte) (int, d2xxErr) {
+	n, e := l.h.Read(b)
+	return n, d2xxErr(e)
+}
+
+func (l *d2xxLib) Write(b []byte) (int, d2xxErr) {
+	n, e := l.h.Write(b)
+	return n, d2xxErr(e)
+}
+
+func (l *d2xxLib) GetBitMode() (byte, d2xxErr) {
+	b, e := l.h.GetBitMode()
+	return b, d2xxErr(e)
+}
+
+func (l *d2xxLib) SetBitMode(mask, mode byte) d2xxErr {
+	return d2xxErr(l.h.SetBitMode(mask, mode))
+}
@@ -0,0 +1,12 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build (!cgo && !windows) || no_d2xx
+// +build !cgo,!windows no_d2xx
+
+package ftdi
+
+// d2xxBuilt is false when package d2xx was built without the D2XX library,
+// i.e. without cgo or with the build tag no_d2xx.
+const d2xxBuilt = false
@@ -9,9 +9,7 @@ package ftdi
 
 import (
 	"log"
-
-	"periph.io/x/d2xx"
-	"periph.io/x/d2xx/d2xxtest"
+	"time"
 )
 
 // logf is enabled when the build tag host_ftdi_debug is specified.
@@ -20,11 +18,132 @@ func logf(fmt string, v ...interface{}) {
 }
 
 func (d *driver) resetLog() {
-	d.d2xxOpen = func(i int) (d2xx.Handle, d2xx.Err) {
-		h, e := d2xx.Open(i)
+	d.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
+		h, e := openDevice(i)
 		if e != 0 {
 			return h, e
 		}
-		return &d2xxtest.Log{H: h, Printf: logf}, e
+		return &logHandle{h: h}, e
+	}
+}
+
+// logHandle logs the calls to a d2xxHandle to help diagnose issues with the
+// backends.
+type logHandle struct {
+	h d2xxHandle
+}
+
+func (l *logHandle) Close() d2xxErr {
+	defer l.logDefer("Close()")()
+	return l.h.Close()
+}
+
+func (l *logHandle) ResetDevice() d2xxErr {
+	defer l.logDefer("ResetDevice()")()
+	return l.h.ResetDevice()
+}
+
+func (l *logHandle) GetDeviceInfo() (uint32, uint16, uint16, d2xxErr) {
+	defer l.logDefer("GetDeviceInfo()")()
+	return l.h.GetDeviceInfo()
+}
+
+func (l *logHandle) EEPROMRead(devType uint32, e *d2xxEEPROM) d2xxErr {
+	defer l.logDefer("EEPROMRead(%d, %d bytes)")(devType, len(e.Raw))
+	return l.h.EEPROMRead(devType, e)
+}
+
+func (l *logHandle) EEPROMProgram(e *d2xxEEPROM) d2xxErr {
+	defer l.logDefer("EEPROMProgram(%#x)")(e.Raw)
+	return l.h.EEPROMProgram(e)
+}
+
+func (l *logHandle) EraseEE() d2xxErr {
+	defer l.logDefer("EraseEE()")()
+	return l.h.EraseEE()
+}
+
+func (l *logHandle) WriteEE(offset uint8, value uint16) d2xxErr {
+	defer l.logDefer("WriteEE(%d, %d)")(offset, value)
+	return l.h.WriteEE(offset, value)
+}
+
+func (l *logHandle) EEUASize() (int, d2xxErr) {
+	defer l.logDefer("EEUASize()")()
+	return l.h.EEUASize()
+}
+
+func (l *logHandle) EEUARead(ua []byte) d2xxErr {
+	defer l.logDefer("EEUARead(%d bytes)")(len(ua))
+	return l.h.EEUARead(ua)
+}
+
+func (l *logHandle) EEUAWrite(ua []byte) d2xxErr {
+	defer l.logDefer("EEUAWrite(%#x)")(ua)
+	return l.h.EEUAWrite(ua)
+}
+
+func (l *logHandle) SetChars(eventChar byte, eventEn bool, errorChar byte, errorEn bool) d2xxErr {
+	defer l.logDefer("SetChars(%d, %t, %d, %t)")(eventChar, eventEn, errorChar, errorEn)
+	return l.h.SetChars(eventChar, eventEn, errorChar, errorEn)
+}
+
+func (l *logHandle) SetUSBParameters(in, out int) d2xxErr {
+	defer l.logDefer("SetUSBParameters(%d, %d)")(in, out)
+	return l.h.SetUSBParameters(in, out)
+}
+
+func (l *logHandle) SetFlowControl() d2xxErr {
+	defer l.logDefer("SetFlowControl()")()
+	return l.h.SetFlowControl()
+}
+
+func (l *logHandle) SetTimeouts(readMS, writeMS int) d2xxErr {
+	defer l.logDefer("SetTimeouts(%d, %d)")(readMS, writeMS)
+	return l.h.SetTimeouts(readMS, writeMS)
+}
+
+func (l *logHandle) SetLatencyTimer(delayMS uint8) d2xxErr {
+	defer l.logDefer("SetLatencyTimer(%d)")(delayMS)
+	return l.h.SetLatencyTimer(delayMS)
+}
+
+func (l *logHandle) SetBaudRate(hz uint32) d2xxErr {
+	defer l.logDefer("SetBaudRate(%d)")(hz)
+	return l.h.SetBaudRate(hz)
+}
+
+func (l *logHandle) GetQueueStatus() (uint32, d2xxErr) {
+	defer l.logDefer("GetQueueStatus()")()
+	return l.h.GetQueueStatus()
+}
+
+func (l *logHandle) Read(b []byte) (int, d2xxErr) {
+	start := time.Now()
+	n, e := l.h.Read(b)
+	logf("%7s Read(%d bytes) = %#x, %s", time.Since(start).Round(time.Microsecond), len(b), b[:n], e)
+	return n, e
+}
+
+func (l *logHandle) Write(b []byte) (int, d2xxErr) {
+	defer l.logDefer("Write(%#x)")(b)
+	return l.h.Write(b)
+}
+
+func (l *logHandle) GetBitMode() (byte, d2xxErr) {
+	defer l.logDefer("GetBitMode()")()
+	return l.h.GetBitMode()
+}
+
+func (l *logHandle) SetBitMode(mask, mode byte) d2xxErr {
+	defer l.logDefer("SetBitMode(%#x, %#x)")(mask, mode)
+	return l.h.SetBitMode(mask, mode)
+}
+
+// logDefer returns a function logging the call with its duration.
+func (l *logHandle) logDefer(fmt string) func(args ...interface{}) {
+	start := time.Now()
+	return func(args ...interface{}) {
+		logf("%7s "+fmt, append([]interface{}{time.Since(start).Round(time.Microsecond)}, args...)...)
 	}
 }
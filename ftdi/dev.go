@@ -14,6 +14,7 @@ import (
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpiostream"
 	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/onewire"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/spi"
 )
@@ -25,6 +26,13 @@ type PinStreamOut interface {
 	StreamOut(s gpiostream.Stream) error
 }
 
+// OpenDrainer is implemented by the D0~D7 and C0~C7 pins of the FT232H to
+// only drive the line low, so it can be shared with other devices.
+type OpenDrainer interface {
+	SetOpenDrain(on bool) error
+	OpenDrain() bool
+}
+
 // Info is the information gathered about the connected FTDI device.
 //
 // The data is gathered from the USB descriptor.
@@ -214,13 +222,36 @@ func (f *generic) WriteUserArea(ua []byte) error {
 //
 
 func newFT232H(g generic) (*FT232H, error) {
-	f := &FT232H{
-		generic: g,
-		cbus:    gpiosMPSSE{h: g.h, cbus: true},
-		dbus:    gpiosMPSSE{h: g.h},
-		c8:      invalidPin{num: 16, n: g.name + ".C8"}, // , dp: gpio.PullUp
-		c9:      invalidPin{num: 17, n: g.name + ".C9"}, // , dp: gpio.PullUp
+	f := &FT232H{}
+	if err := f.init(g); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func newFT2232H(g generic) (*FT2232H, error) {
+	f := &FT2232H{}
+	if err := f.init(g); err != nil {
+		return nil, err
 	}
+	return f, nil
+}
+
+func newFT4232H(g generic) (*FT4232H, error) {
+	f := &FT4232H{}
+	if err := f.init(g); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// init initializes f in place, as the pins and buses point back to it.
+func (f *FT232H) init(g generic) error {
+	f.generic = g
+	f.cbus = gpiosMPSSE{h: g.h, f: f, cbus: true}
+	f.dbus = gpiosMPSSE{h: g.h, f: f}
+	f.c8 = invalidPin{num: 16, n: g.name + ".C8"} // , dp: gpio.PullUp
+	f.c9 = invalidPin{num: 17, n: g.name + ".C9"} // , dp: gpio.PullUp
 	f.cbus.init(f.name)
 	f.dbus.init(f.name)
 
@@ -255,11 +286,12 @@ func newFT232H(g generic) (*FT232H, error) {
 
 	// This function forces all pins as inputs.
 	if err := f.h.InitMPSSE(); err != nil {
-		return nil, err
+		return err
 	}
 	f.s.c.f = f
 	f.i.f = f
-	return f, nil
+	f.o.f = f
+	return nil
 }
 
 // FT232H represents a FT232H device.
@@ -269,19 +301,28 @@ func newFT232H(g generic) (*FT232H, error) {
 // The FT232H has 1024 bytes output buffer and 1024 bytes input buffer. It
 // supports 512 bytes USB packets.
 //
-// The device can be used in a few different modes, two modes are supported:
+// The device can be used in a few different modes, four modes are supported:
+//
+// - D0~D3 as a serial protocol (MPSEE), supporting I²C, SPI, 1-Wire, JTAG and
+// SWD. In this mode, D4~D7 and C0~C7 can be used as synchronized GPIO.
+//
+// - D0~D3 as a UART via UART().
 //
-// - D0~D3 as a serial protocol (MPSEE), supporting I²C and SPI (and eventually
-// UART), In this mode, D4~D7 and C0~C7 can be used as synchronized GPIO.
+// - D0~D7 as an asynchronous 8 bits bit-bang port via AsyncBitBang(). In this
+// mode, only a few pins on CBus are usable in slow mode.
 //
-// - D0~D7 as a synchronous 8 bits bit-bang port. In this mode, only a few pins
-// on CBus are usable in slow mode.
+// - MCU host bus emulation via MCUHost(), to drive parallel bus chips.
 //
 // Each group of pins D0~D7 and C0~C7 can be changed at once in one pass via
-// DBus() or CBus().
+// DGroup() and CGroup(), or DBus() and CBus().
 //
 // This enables usage as an 8 bit parallel port.
 //
+// In MPSSE mode, the D and C pins support WaitForEdge by polling; see
+// SetEdgeSampleRate. They implement OpenDrainer and gpiostream.PinOut, to
+// output a BitStream at a fixed rate generated by the MPSSE engine, e.g. for
+// WS2812 LEDs. D1 supports up to 30MHz.
+//
 // Pins C8 and C9 can only be used in 'slow' mode via EEPROM and are currently
 // not implemented.
 //
@@ -316,11 +357,20 @@ type FT232H struct {
 	c8   invalidPin // gpio.PullUp
 	c9   invalidPin // gpio.PullUp
 
-	mu       sync.Mutex
-	usingI2C bool
-	usingSPI bool
-	i        i2cBus
-	s        spiMPSEEPort
+	mu           sync.Mutex
+	usingI2C     bool
+	usingSPI     bool
+	usingBitMode bool
+	usingOneWire bool
+	usingUART    bool
+	usingJTAG    bool
+	usingSWD     bool
+	edgeRate     physic.Frequency // see SetEdgeSampleRate
+	edgePolling  bool             // pollEdges is running
+	i            i2cBus
+	s            spiMPSEEPort
+	o            oneWireBus
+	u            uartPort
 	// TODO(maruel): Technically speaking, a SPI port could be hacked up too in
 	// sync bit-bang but there's less point when MPSEE is available.
 }
@@ -366,6 +416,22 @@ func (f *FT232H) DBusRead() (byte, error) {
 	return f.h.MPSSEDBusRead()
 }
 
+// CGroup returns C0~C7 as a group to change them together.
+//
+// Unlike CBus, it only changes the pins selected and keeps the state of the
+// pins in sync.
+func (f *FT232H) CGroup() *Group {
+	return &Group{g: &f.cbus}
+}
+
+// DGroup returns D0~D7 as a group to change them together.
+//
+// Unlike DBus, it only changes the pins selected and keeps the state of the
+// pins in sync.
+func (f *FT232H) DGroup() *Group {
+	return &Group{g: &f.dbus}
+}
+
 // I2C returns an I²C bus over the AD bus.
 //
 // pull can be either gpio.PullUp or gpio.Float. The recommended pull up
@@ -375,10 +441,42 @@ func (f *FT232H) DBusRead() (byte, error) {
 //
 // It uses D0, D1 and D2.
 //
-// D0 is SCL. It must to be pulled up externally.
+// D0 is SCL. It must to be pulled up externally when using Float.
 //
 // D1 and D2 are used for SDA. D1 is the output using open drain, D2 is the
-// input. D1 and D2 must be wired together and must be pulled up externally.
+// input. D1 and D2 must be wired together and must be pulled up externally
+// when using Float.
+//
+// With PullUp, the lines alternate between Out(Low) and In(PullUp) using the
+// GPIO's pull up, for boards without pull up resistors.
+//
+// The returned bus implements RepeatedStarter; write-then-read transactions
+// use a repeated start by default.
+//
+// The returned bus implements ClockStretcher; clock stretching requires SCL to
+// be wired to D7.
+//
+// The returned bus implements ClockTuner, to select 2 phase clocking for long
+// wires.
+//
+// The returned bus implements DeviceSpeeder, to clock each device at its own
+// speed.
+//
+// The returned bus implements ContextTxer, to abort the transactions that
+// don't complete in time.
+//
+// The returned bus implements Pipeliner, to run several transactions in a
+// single USB round trip.
+//
+// The returned bus implements NAKHandler, to retry or ignore the transactions
+// that are not acknowledged.
+//
+// The returned bus implements Arbitrator, to detect and retry the
+// transactions that lost the arbitration to another master.
+//
+// The returned bus implements Scanner, to list the devices on the bus.
+//
+// The returned bus implements BusRecoverer, to free a bus held by a device.
 //
 // It is recommended to set the mode to ‘245 FIFO’ in the EEPROM of the FT232H.
 //
@@ -400,6 +498,21 @@ func (f *FT232H) I2C(pull gpio.Pull) (i2c.BusCloser, error) {
 	if f.usingSPI {
 		return nil, errors.New("d2xx: already using SPI")
 	}
+	if f.usingBitMode {
+		return nil, errors.New("d2xx: already using a bit mode")
+	}
+	if f.usingOneWire {
+		return nil, errors.New("d2xx: already using 1-Wire")
+	}
+	if f.usingUART {
+		return nil, errors.New("d2xx: already using the UART")
+	}
+	if f.usingJTAG {
+		return nil, errors.New("d2xx: already using JTAG")
+	}
+	if f.usingSWD {
+		return nil, errors.New("d2xx: already using SWD")
+	}
 	if err := f.i.setupI2C(pull == gpio.PullUp); err != nil {
 		_ = f.i.stopI2C()
 		return nil, err
@@ -411,6 +524,15 @@ func (f *FT232H) I2C(pull gpio.Pull) (i2c.BusCloser, error) {
 //
 // It uses D0, D1, D2 and D3. D0 is the clock, D1 the output (MOSI), D2 is the
 // input (MISO) and D3 is CS line.
+//
+// All 4 modes are supported. Mode 1 and 3 are limited to 20MHz.
+//
+// The returned port implements ChipSelecter to drive more devices, using any
+// of D4~D7 and C0~C7 as additional chip select lines.
+//
+// Packets are not limited in size; they are split in chunks as needed. A
+// transaction failing midway returns a *SPITxError telling how much was
+// transferred.
 func (f *FT232H) SPI() (spi.PortCloser, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -420,64 +542,244 @@ func (f *FT232H) SPI() (spi.PortCloser, error) {
 	if f.usingSPI {
 		return nil, errors.New("d2xx: already using SPI")
 	}
+	if f.usingBitMode {
+		return nil, errors.New("d2xx: already using a bit mode")
+	}
+	if f.usingOneWire {
+		return nil, errors.New("d2xx: already using 1-Wire")
+	}
+	if f.usingUART {
+		return nil, errors.New("d2xx: already using the UART")
+	}
+	if f.usingJTAG {
+		return nil, errors.New("d2xx: already using JTAG")
+	}
+	if f.usingSWD {
+		return nil, errors.New("d2xx: already using SWD")
+	}
 	// Don't mark it as being used yet. It only become used once Connect() is
 	// called.
 	return &f.s, nil
 }
 
+// OneWire returns a 1-Wire bus over the AD bus.
+//
+// D1 drives the line in open drain mode and D2 samples it. Connect both to
+// the 1-Wire data line, with a 4.7kΩ pull up resistor to 3.3V. D0 toggles as
+// the MPSSE clock and must be left unconnected.
+//
+// The time slots are generated by the MPSSE clocked at 1MHz, so they don't
+// depend on the host's scheduling. Strong pull up is supported by driving D1
+// high.
+//
+// Only the FT232H supports open drain outputs.
+func (f *FT232H) OneWire() (onewire.BusCloser, error) {
+	if f.h.t != DevTypeFT232H {
+		return nil, errors.New("d2xx: 1-Wire requires open drain outputs, only supported on the FT232H")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.canUseBitMode(); err != nil {
+		return nil, err
+	}
+	if err := f.o.setupOneWire(); err != nil {
+		return nil, err
+	}
+	return &f.o, nil
+}
+
+//
+
+// FT2232H represents one channel of a FT2232H device.
+//
+// It implements Dev.
+//
+// The FT2232H has two identical channels, A and B, each with its own MPSSE
+// controller, 4096 bytes buffers and USB endpoints. The driver enumerates each
+// channel as a separate device with its own handle and lock, so both channels
+// can be used concurrently from different goroutines, e.g. I²C on channel A
+// and SPI on channel B.
+//
+// D0~D7 are the ADBUS or BDBUS pins and C0~C7 are the ACBUS or BCBUS pins.
+// C8 and C9 do not exist and are not part of the header.
+//
+// Unlike the FT232H, the FT2232H doesn't support open drain outputs, so I²C
+// only works with gpio.PullUp.
+//
+// The EEPROM is shared by both channels.
+//
+// Datasheet
+//
+// https://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT2232H.pdf
+type FT2232H struct {
+	FT232H
+
+	// Channel is 'A' or 'B'.
+	Channel byte
+}
+
+// Header returns the GPIO pins exposed on the channel.
+func (f *FT2232H) Header() []gpio.PinIO {
+	out := make([]gpio.PinIO, 16)
+	copy(out, f.hdr[:16])
+	return out
+}
+
+// I2C returns an I²C bus over the AD bus of the channel.
+//
+// pull must be gpio.PullUp, as the FT2232H doesn't support open drain. See
+// FT232H.I2C() for the wiring.
+func (f *FT2232H) I2C(pull gpio.Pull) (i2c.BusCloser, error) {
+	if pull != gpio.PullUp {
+		return nil, errors.New("d2xx: FT2232H I²C requires gpio.PullUp as it doesn't support open drain")
+	}
+	return f.FT232H.I2C(pull)
+}
+
+// FT4232H represents channel A or B of a FT4232H device.
+//
+// It implements Dev.
+//
+// The FT4232H has four channels but only A and B have a MPSSE controller;
+// channels C and D only support UART and bit-bang and are enumerated as
+// FT4232HSerial. Like the FT2232H, each channel is a separate device with its
+// own handle and lock, so all the channels can be used concurrently.
+//
+// D0~D7 are the ADBUS or BDBUS pins. The FT4232H has no C bus, so C0~C9 do
+// not exist and are not part of the header.
+//
+// Like the FT2232H, the FT4232H doesn't support open drain outputs, so I²C
+// only works with gpio.PullUp.
+//
+// The EEPROM is shared by all channels.
+//
+// Datasheet
+//
+// https://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT4232H.pdf
+type FT4232H struct {
+	FT232H
+
+	// Channel is 'A' or 'B'.
+	Channel byte
+}
+
+// Header returns the GPIO pins exposed on the channel.
+func (f *FT4232H) Header() []gpio.PinIO {
+	out := make([]gpio.PinIO, 8)
+	copy(out, f.hdr[:8])
+	return out
+}
+
+// CBus returns an error, as the FT4232H has no C bus.
+func (f *FT4232H) CBus(direction, value byte) error {
+	return errors.New("d2xx: FT4232H has no C bus")
+}
+
+// CBusRead returns an error, as the FT4232H has no C bus.
+func (f *FT4232H) CBusRead() (byte, error) {
+	return 0, errors.New("d2xx: FT4232H has no C bus")
+}
+
+// I2C returns an I²C bus over the D bus of the channel.
+//
+// pull must be gpio.PullUp, as the FT4232H doesn't support open drain. See
+// FT232H.I2C() for the wiring.
+func (f *FT4232H) I2C(pull gpio.Pull) (i2c.BusCloser, error) {
+	if pull != gpio.PullUp {
+		return nil, errors.New("d2xx: FT4232H I²C requires gpio.PullUp as it doesn't support open drain")
+	}
+	return f.FT232H.I2C(pull)
+}
+
+func newFT4232HSerial(g generic) (*FT4232HSerial, error) {
+	f := &FT4232HSerial{}
+	if err := f.init(g); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// init initializes f in place, as the pins point back to it.
+func (f *FT4232HSerial) init(g generic) error {
+	f.generic = g
+	f.initDBus()
+	if err := f.h.InitNonMPSSE(); err != nil {
+		return err
+	}
+	// Default to 3MHz.
+	if err := f.h.SetBaudRate(3 * physic.MegaHertz); err != nil {
+		return err
+	}
+	return f.initAsyncBitbang()
+}
+
+// FT4232HSerial represents channel C or D of a FT4232H device.
 //
+// It implements Dev.
+//
+// These channels have no MPSSE controller. D0~D7 are the CDBUS or DDBUS pins
+// and are used like the D0~D7 pins of the FT232R: as GPIOs in asynchronous
+// bit-bang mode, as a paced stream with Tx(), as a SPI port in synchronous
+// bit-bang mode or as a UART. The FT4232H has no C bus, so C0~C3 do not exist
+// and are not part of the header.
+type FT4232HSerial struct {
+	FT232R
+
+	// Channel is 'C' or 'D'.
+	Channel byte
+}
+
+// Header returns the GPIO pins exposed on the channel.
+func (f *FT4232HSerial) Header() []gpio.PinIO {
+	out := make([]gpio.PinIO, 8)
+	copy(out, f.hdr[:8])
+	return out
+}
 
 func newFT232R(g generic) (*FT232R, error) {
-	f := &FT232R{
-		generic: g,
-		dbus:    [...]dbusPinSync{{num: 0}, {num: 1}, {num: 2}, {num: 3}, {num: 4}, {num: 5}, {num: 6}, {num: 7}},
-		cbus:    [...]cbusPin{{num: 8, p: gpio.PullUp}, {num: 9, p: gpio.PullUp}, {num: 10, p: gpio.PullUp}, {num: 11, p: gpio.Float}},
+	f := &FT232R{}
+	if err := f.init(g); err != nil {
+		return nil, err
 	}
-	// Use the UART names, as this is how all FT232R boards are marked.
-	dnames := [...]string{"TX", "RX", "RTS", "CTS", "DTR", "DSR", "DCD", "RI"}
-	for i := range f.dbus {
-		f.dbus[i].n = f.name + "." + dnames[i]
-		f.dbus[i].bus = f
-		f.hdr[i] = &f.dbus[i]
+	return f, nil
+}
+
+func newFTX(g generic) (*FTX, error) {
+	f := &FTX{}
+	if err := f.init(g); err != nil {
+		return nil, err
 	}
+	return f, nil
+}
+
+// init initializes f in place, as the pins point back to it.
+func (f *FT232R) init(g generic) error {
+	f.generic = g
+	f.initDBus()
+	f.cbus = [...]cbusPin{{num: 8, p: gpio.PullUp}, {num: 9, p: gpio.PullUp}, {num: 10, p: gpio.PullUp}, {num: 11, p: gpio.Float}}
 	for i := range f.cbus {
 		f.cbus[i].n = f.name + ".C" + strconv.Itoa(i)
 		f.cbus[i].bus = f
 		f.hdr[i+8] = &f.cbus[i]
 	}
-	f.D0 = f.hdr[0]
-	f.D1 = f.hdr[1]
-	f.D2 = f.hdr[2]
-	f.D3 = f.hdr[3]
-	f.D4 = f.hdr[4]
-	f.D5 = f.hdr[5]
-	f.D6 = f.hdr[6]
-	f.D7 = f.hdr[7]
-	f.TX = f.hdr[0]
-	f.RX = f.hdr[1]
-	f.RTS = f.hdr[2]
-	f.CTS = f.hdr[3]
-	f.DTR = f.hdr[4]
-	f.DSR = f.hdr[5]
-	f.DCD = f.hdr[6]
-	f.RI = f.hdr[7]
 	f.C0 = f.hdr[8]
 	f.C1 = f.hdr[9]
 	f.C2 = f.hdr[10]
 	f.C3 = f.hdr[11]
 
 	if err := f.h.InitNonMPSSE(); err != nil {
-		return nil, err
+		return err
 	}
+	f.readCBusMux()
 
 	// Default to 3MHz.
 	if err := f.h.SetBaudRate(3 * physic.MegaHertz); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Set all CBus pins as input.
 	if err := f.h.SetBitMode(0, bitModeCbusBitbang); err != nil {
-		return nil, err
+		return err
 	}
 	// And read their value.
 	// TODO(maruel): Sadly this is impossible to know which pin is input or
@@ -485,20 +787,52 @@ func newFT232R(g generic) (*FT232R, error) {
 	// the line which could interfere with the device connected.
 	var err error
 	if f.cbusnibble, err = f.h.GetBitMode(); err != nil {
-		return nil, err
+		return err
 	}
+	return f.initAsyncBitbang()
+}
+
+// initDBus initializes D0~D7.
+func (f *FT232R) initDBus() {
+	f.dbus = [...]dbusPinSync{{num: 0}, {num: 1}, {num: 2}, {num: 3}, {num: 4}, {num: 5}, {num: 6}, {num: 7}}
+	// Use the UART names, as this is how all FT232R boards are marked.
+	dnames := [...]string{"TX", "RX", "RTS", "CTS", "DTR", "DSR", "DCD", "RI"}
+	for i := range f.dbus {
+		f.dbus[i].n = f.name + "." + dnames[i]
+		f.dbus[i].bus = f
+		f.hdr[i] = &f.dbus[i]
+	}
+	f.D0 = f.hdr[0]
+	f.D1 = f.hdr[1]
+	f.D2 = f.hdr[2]
+	f.D3 = f.hdr[3]
+	f.D4 = f.hdr[4]
+	f.D5 = f.hdr[5]
+	f.D6 = f.hdr[6]
+	f.D7 = f.hdr[7]
+	f.TX = f.hdr[0]
+	f.RX = f.hdr[1]
+	f.RTS = f.hdr[2]
+	f.CTS = f.hdr[3]
+	f.DTR = f.hdr[4]
+	f.DSR = f.hdr[5]
+	f.DCD = f.hdr[6]
+	f.RI = f.hdr[7]
+}
+
+// initAsyncBitbang sets D0~D7 as inputs in asynchronous bit-bang mode.
+func (f *FT232R) initAsyncBitbang() error {
 	// Set all DBus as asynchronous bitbang, everything as input.
 	if err := f.h.SetBitMode(0, bitModeAsyncBitbang); err != nil {
-		return nil, err
+		return err
 	}
 	// And read their value.
-	var b [1]byte
-	if _, err := f.h.ReadAll(context.Background(), b[:]); err != nil {
-		return nil, err
+	var err error
+	if f.dvalue, err = f.h.GetBitMode(); err != nil {
+		return err
 	}
-	f.dvalue = b[0]
 	f.s.c.f = f
-	return f, nil
+	return nil
 }
 
 // FT232R represents a FT232RL/FT232RQ device.
@@ -514,6 +848,15 @@ func newFT232R(g generic) (*FT232R, error) {
 //
 // The FT232R has 128 bytes output buffer and 256 bytes input buffer.
 //
+// D0~D7 are used as GPIOs in asynchronous bit-bang mode. Each change is a
+// single USB transfer; use DBus() and DBusRead() to access all the pins at
+// once, and Tx() to output a paced stream. UART() returns D0~D3 to their UART
+// function.
+//
+// Using C0~C3 switches the device to CBus bit-bang mode, during which D0~D7
+// revert to their UART function. D0~D7 are restored to their last direction
+// and value on their next use.
+//
 // Pin C4 can only be used in 'slow' mode via EEPROM and is currently not
 // implemented.
 //
@@ -535,6 +878,9 @@ type FT232R struct {
 	D7, RI  gpio.PinIO // Ring Indicator Control Input. When remote wake up is enabled in the internal EEPROM taking RI# low can be used to resume the PC USB host controller from suspend.
 
 	// The CBus pins are slower to use, but can drive an high load, like a LED.
+	//
+	// They are only usable once set to I/O mode in the EEPROM; otherwise
+	// Function() returns their EEPROM function and In() and Out() fail.
 	C0 gpio.PinIO
 	C1 gpio.PinIO
 	C2 gpio.PinIO
@@ -547,11 +893,16 @@ type FT232R struct {
 	// Mutable.
 	mu         sync.Mutex
 	usingSPI   bool
-	usingCBus  bool
+	usingUART  bool
+	usingCBus  bool // CBus bit-bang mode instead of asynchronous bit-bang
 	s          spiSyncPort
+	u          uartPort
 	dmask      uint8 // 0 input, 1 output
 	dvalue     uint8
 	cbusnibble uint8 // upper nibble is I/O control, lower nibble is values.
+
+	// Immutable after initialization.
+	cbusMux [4]string // EEPROM function of C0~C3 when not set to I/O mode
 }
 
 // Header returns the GPIO pins exposed on the chip.
@@ -561,6 +912,26 @@ func (f *FT232R) Header() []gpio.PinIO {
 	return out
 }
 
+// FTX represents a FT-X series device, e.g. a FT230X or a FT231X.
+//
+// It implements Dev.
+//
+// The FT-X series uses the same bit-bang modes as the FT232R, so D0~D7 and
+// C0~C3 are used the same way. The FT231X exposes all the pins while the
+// FT230X only has D0(TX), D1(RX), D2(RTS), D3(CTS) and C0~C3.
+//
+// C0~C3 are only usable as GPIOs once configured as "GPIO" in the EEPROM,
+// e.g. with FT_PROG. The other CBus pins are not supported in bit-bang mode.
+//
+// Datasheet
+//
+// https://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT230X.pdf
+//
+// https://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT231X.pdf
+type FTX struct {
+	FT232R
+}
+
 // SetDBusMask sets all D0~D7 input or output mode at once.
 //
 // mask is the input/output pins to use. A bit value of 0 sets the
@@ -604,9 +975,39 @@ func (f *FT232R) Tx(w, r []byte) error {
 	if f.usingSPI {
 		return errors.New("d2xx: already using SPI")
 	}
+	if err := f.setDBusMaskLocked(f.dmask); err != nil {
+		return err
+	}
 	return f.txLocked(w, r)
 }
 
+// DBus sets the direction and the value of D0~D7 at once, in a single USB
+// transfer.
+//
+// 0 direction means input, 1 means output. The bits of value for the inputs
+// are ignored.
+func (f *FT232R) DBus(direction, value byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.usingSPI {
+		return errors.New("d2xx: already using SPI")
+	}
+	if err := f.setDBusMaskLocked(direction); err != nil {
+		return err
+	}
+	return f.dbusWriteLocked(value)
+}
+
+// DBusRead reads the levels of D0~D7 at once.
+func (f *FT232R) DBusRead() (byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.setDBusMaskLocked(f.dmask); err != nil {
+		return 0, err
+	}
+	return f.h.GetBitMode()
+}
+
 // SPI returns a SPI port over the first 4 pins.
 //
 // It uses D0(TX), D1(RX), D2(RTS) and D3(CTS). D2(RTS) is the clock, D0(TX)
@@ -617,22 +1018,45 @@ func (f *FT232R) SPI() (spi.PortCloser, error) {
 	if f.usingSPI {
 		return nil, errors.New("d2xx: already using SPI")
 	}
+	if f.usingUART {
+		return nil, errors.New("d2xx: already using the UART")
+	}
 	// Don't mark it as being used yet. It only become used once Connect() is
 	// called.
 	return &f.s, nil
 }
 
 // setDBusMaskLocked is the locked version of SetDBusMask.
+//
+// It also switches back to asynchronous bit-bang mode after C0~C3 were used.
 func (f *FT232R) setDBusMaskLocked(mask uint8) error {
-	if mask != f.dmask {
+	if f.usingUART {
+		return errors.New("d2xx: already using the UART")
+	}
+	if mask != f.dmask || f.usingCBus {
 		if err := f.h.SetBitMode(mask, bitModeAsyncBitbang); err != nil {
 			return err
 		}
 		f.dmask = mask
+		if f.usingCBus {
+			f.usingCBus = false
+			// Restore the outputs.
+			return f.dbusWriteLocked(f.dvalue)
+		}
 	}
 	return nil
 }
 
+// dbusWriteLocked sets the value of the D0~D7 outputs.
+func (f *FT232R) dbusWriteLocked(v uint8) error {
+	b := [1]byte{v}
+	if _, err := f.h.Write(b[:]); err != nil {
+		return err
+	}
+	f.dvalue = v
+	return nil
+}
+
 func (f *FT232R) txLocked(w, r []byte) error {
 	// Investigate FT232R clock issue:
 	// http://developer.intra2net.com/mailarchive/html/libftdi/2010/msg00240.html
@@ -753,17 +1177,7 @@ func (f *FT232R) dbusSyncGPIOIn(n int) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	// TODO(maruel): if f.usingSPI && n < 4.
-	mask := uint8(1 << uint(n))
-	if f.dmask&mask == 0 {
-		// Already input.
-		return nil
-	}
-	v := f.dmask &^ mask
-	if err := f.h.SetBitMode(v, bitModeAsyncBitbang); err != nil {
-		return err
-	}
-	f.dmask = v
-	return nil
+	return f.setDBusMaskLocked(f.dmask &^ (1 << uint(n)))
 }
 
 // dbusSyncGPIORead implements dbusSync.
@@ -774,50 +1188,80 @@ func (f *FT232R) dbusSyncGPIORead(n int) gpio.Level {
 }
 
 func (f *FT232R) dbusSyncReadLocked(n int) gpio.Level {
-	// In synchronous mode, to read we must write first to for a sample.
-	b := [1]byte{f.dvalue}
-	if _, err := f.h.Write(b[:]); err != nil {
+	if err := f.setDBusMaskLocked(f.dmask); err != nil {
 		return gpio.Low
 	}
-	mask := uint8(1 << uint(n))
-	if _, err := f.h.ReadAll(context.Background(), b[:]); err != nil {
+	// In asynchronous mode, the instantaneous value of the pins is read
+	// directly.
+	v, err := f.h.GetBitMode()
+	if err != nil {
 		return gpio.Low
 	}
-	f.dvalue = b[0]
-	return f.dvalue&mask != 0
+	return v&(1<<uint(n)) != 0
 }
 
 // dbusSyncGPIOOut implements dbusSync.
 func (f *FT232R) dbusSyncGPIOOut(n int, l gpio.Level) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	mask := uint8(1 << uint(n))
-	if f.dmask&mask != 1 {
-		// Was input.
-		v := f.dmask | mask
-		if err := f.h.SetBitMode(v, bitModeAsyncBitbang); err != nil {
-			return err
-		}
-		f.dmask = v
+	if err := f.setDBusMaskLocked(f.dmask | 1<<uint(n)); err != nil {
+		return err
 	}
 	return f.dbusSyncGPIOOutLocked(n, l)
 }
 
 func (f *FT232R) dbusSyncGPIOOutLocked(n int, l gpio.Level) error {
-	b := [1]byte{f.dvalue}
-	if _, err := f.h.Write(b[:]); err != nil {
-		return err
+	mask := uint8(1 << uint(n))
+	v := f.dvalue &^ mask
+	if l {
+		v |= mask
 	}
-	f.dvalue = b[0]
-	// In synchronous mode, we must read after writing to flush the buffer.
-	if _, err := f.h.Write(b[:]); err != nil {
-		return err
+	return f.dbusWriteLocked(v)
+}
+
+// readCBusMux reads the function of C0~C3 set in the EEPROM, to detect the
+// pins that can't be used as GPIOs.
+//
+// The pins are assumed to be usable when the EEPROM can't be read.
+func (f *FT232R) readCBusMux() {
+	var ee EEPROM
+	if err := f.h.ReadEEPROM(&ee); err != nil {
+		return
+	}
+	switch f.h.t {
+	case DevTypeFT232R:
+		if e := ee.AsFT232R(); e != nil {
+			for i, m := range [...]FT232rCBusMux{e.Cbus0, e.Cbus1, e.Cbus2, e.Cbus3} {
+				if m != FT232rCBusIOMode {
+					f.cbusMux[i] = m.String()
+				}
+			}
+		}
+	case DevTypeFTXSeries:
+		if e := ee.AsFTX(); e != nil {
+			for i, m := range [...]FTxCBusMux{e.Cbus0, e.Cbus1, e.Cbus2, e.Cbus3} {
+				if m != FTxCBusIOMode {
+					f.cbusMux[i] = m.String()
+				}
+			}
+		}
+	}
+}
+
+// cBusUsable returns an error if the pin is not set to I/O mode in the
+// EEPROM.
+func (f *FT232R) cBusUsable(n int) error {
+	if m := f.cbusMux[n]; m != "" {
+		return errors.New("d2xx: C" + strconv.Itoa(n) + " is set to " + m + " in the EEPROM; program it to I/O mode to use it as a GPIO")
 	}
 	return nil
 }
 
 // cBusGPIOFunc implements cBusGPIO.
 func (f *FT232R) cBusGPIOFunc(n int) string {
+	if m := f.cbusMux[n]; m != "" {
+		return m
+	}
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	fmask := uint8(0x10 << uint(n))
@@ -830,40 +1274,44 @@ func (f *FT232R) cBusGPIOFunc(n int) string {
 
 // cBusGPIOIn implements cBusGPIO.
 func (f *FT232R) cBusGPIOIn(n int) error {
+	if err := f.cBusUsable(n); err != nil {
+		return err
+	}
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	fmask := uint8(0x10 << uint(n))
-	if f.cbusnibble&fmask == 0 {
-		// Already input.
-		return nil
-	}
-	v := f.cbusnibble &^ fmask
-	if err := f.h.SetBitMode(v, bitModeCbusBitbang); err != nil {
-		return err
-	}
-	f.cbusnibble = v
-	return nil
+	return f.setCBusLocked(f.cbusnibble &^ fmask)
 }
 
 // cBusGPIORead implements cBusGPIO.
 func (f *FT232R) cBusGPIORead(n int) gpio.Level {
+	if f.cbusMux[n] != "" {
+		return gpio.Low
+	}
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	return f.cBusReadLocked(n)
 }
 
 func (f *FT232R) cBusReadLocked(n int) gpio.Level {
+	if err := f.setCBusLocked(f.cbusnibble); err != nil {
+		return gpio.Low
+	}
 	v, err := f.h.GetBitMode()
 	if err != nil {
 		return gpio.Low
 	}
-	f.cbusnibble = v
+	// Only the values are read back; keep the I/O control.
+	f.cbusnibble = f.cbusnibble&0xF0 | v&0x0F
 	vmask := uint8(1 << uint(n))
 	return f.cbusnibble&vmask != 0
 }
 
 // cBusGPIOOut implements cBusGPIO.
 func (f *FT232R) cBusGPIOOut(n int, l gpio.Level) error {
+	if err := f.cBusUsable(n); err != nil {
+		return err
+	}
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	fmask := uint8(0x10 << uint(n))
@@ -874,7 +1322,13 @@ func (f *FT232R) cBusGPIOOut(n int, l gpio.Level) error {
 	} else {
 		v &^= vmask
 	}
-	if f.cbusnibble == v {
+	return f.setCBusLocked(v)
+}
+
+// setCBusLocked sets the I/O control and values of C0~C3, switching to CBus
+// bit-bang mode if needed.
+func (f *FT232R) setCBusLocked(v uint8) error {
+	if f.usingCBus && f.cbusnibble == v {
 		// Was already in the right mode.
 		return nil
 	}
@@ -882,6 +1336,7 @@ func (f *FT232R) cBusGPIOOut(n int, l gpio.Level) error {
 		return err
 	}
 	f.cbusnibble = v
+	f.usingCBus = true
 	return nil
 }
 
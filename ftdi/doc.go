@@ -4,8 +4,40 @@
 
 // Package ftdi implements support for popular FTDI devices.
 //
-// The supported devices (FT232h/FT232r) implement support for various
-// protocols like the GPIO, I²C, SPI, UART, JTAG.
+// The supported devices (FT232h/FT2232h/FT4232h/FT232r/FT230x/FT231x)
+// implement support for various protocols like the GPIO, I²C, SPI, 1-Wire, UART,
+// JTAG, SWD. Each channel of a FT2232h or FT4232h is exposed as its own device.
+//
+// The configuration EEPROM (strings, CBus pin functions, drive options) can be
+// read, decoded with EEPROM.Config, modified and programmed back after
+// EEPROM.SetConfig, without needing FT_PROG. ProgramTemplate programs a
+// ready-made configuration and re-enumerates the device, and ProgramDrive the
+// drive current, slew rate and Schmitt trigger options alone. DumpEEPROM and
+// RestoreEEPROM back up and clone the whole EEPROM, including the user area.
+//
+// Watch reports the devices plugged in or unplugged at runtime and keeps their
+// registration up to date.
+//
+// By default, the devices are accessed by a pure Go backend talking to them
+// through the Linux usbfs interface. It doesn't support reading or programming
+// the EEPROM. The user needs read and write access to the
+// /dev/bus/usb/BBB/DDD device nodes, and the ftdi_sio kernel driver is
+// detached from the interfaces used.
+//
+// FTDI's D2XX library is used instead on Windows and macOS, where usbfs
+// doesn't exist, and on Linux when enabled with the build tag
+// periph_host_ftdi_d2xx. It needs cgo, except on Windows where the DLL is
+// loaded at runtime. On Linux, usbfs is still used when D2XX is unavailable,
+// e.g. when built without cgo or against musl. usbfs can also be forced with
+// the build tag periph_host_ftdi_usbfs or with "ftdi_backend = usbfs" in the
+// host configuration; see package hostcfg.
+//
+// The operations needing D2XX, including the driver itself outside of Linux,
+// fail with ErrD2XXNotEnabled when it is not enabled, instead of "not
+// supported". Package periph.io/x/d2xx is only linked in when D2XX is
+// enabled; the build tag no_d2xx leaves it out everywhere. no_d2xx is needed
+// for cgo builds with periph_host_ftdi_d2xx on the platforms for which no
+// static D2XX library is bundled, unless libftd2xx is installed.
 //
 // Use build tag periph_host_ftdi_debug to enable verbose debugging.
 //
@@ -18,5 +50,11 @@
 //
 // http://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT232R.pdf
 //
+// https://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT230X.pdf
+//
 // http://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT232H.pdf
+//
+// https://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT2232H.pdf
+//
+// https://www.ftdichip.com/Support/Documents/DataSheets/ICs/DS_FT4232H.pdf
 package ftdi
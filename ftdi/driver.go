@@ -5,18 +5,20 @@
 package ftdi
 
 import (
+	"errors"
 	"strconv"
 	"sync"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/i2c"
 	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/onewire/onewirereg"
 	"periph.io/x/conn/v3/pin"
 	"periph.io/x/conn/v3/pin/pinreg"
 	"periph.io/x/conn/v3/spi/spireg"
-	"periph.io/x/d2xx"
+	"periph.io/x/conn/v3/uart/uartreg"
 )
 
 // All enumerates all the connected FTDI devices.
@@ -28,16 +30,42 @@ func All() []Dev {
 	return out
 }
 
+// ErrD2XXNotEnabled is returned when an operation needs the D2XX library but
+// it was not enabled at build time, i.e. the program was built without cgo
+// (except on Windows), with the build tag no_d2xx, or on Linux and the other
+// OSes without the build tag periph_host_ftdi_d2xx.
+//
+// It is distinct from the operations that D2XX or the usbfs backend don't
+// support, which fail with "not supported".
+var ErrD2XXNotEnabled = errors.New("ftdi: D2XX is not enabled at build time; build with cgo, and with the periph_host_ftdi_d2xx tag on Linux")
+
 //
 
 // open opens a FTDI device.
 //
+// channels counts the devices opened so far per type, to find out the channel
+// of multi-channel devices as their channels are enumerated in order. It is
+// only used when the backend doesn't report the channel, i.e. with D2XX.
+//
 // Must be called with mu held.
-func open(opener func(i int) (d2xx.Handle, d2xx.Err), i int) (Dev, error) {
+func open(opener func(i int) (d2xxHandle, d2xxErr), i int, channels map[DevType]int) (Dev, error) {
 	h, err := openHandle(opener, i)
 	if err != nil {
 		return nil, err
 	}
+	// Count the channel before anything can fail, so a channel that is
+	// excluded or fails to initialize doesn't shift the next ones.
+	channel := channels[h.t]
+	channels[h.t]++
+	if c, ok := h.channel(); ok {
+		channel = c
+	}
+	// Leave the devices excluded by the host configuration untouched, e.g. for
+	// another process to use.
+	if cfg, _ := hostcfg.Get(); !cfg.FTDIAllowed(h.venID, h.devID) {
+		_ = h.Close()
+		return nil, errExcluded
+	}
 	if err := h.Init(); err != nil {
 		// setupCommon() takes the device in its previous state. It could be in an
 		// unexpected state, so try resetting it first.
@@ -68,11 +96,30 @@ func open(opener func(i int) (d2xx.Handle, d2xx.Err), i int) (Dev, error) {
 		}
 		return f, nil
 	case DevTypeFT2232H:
-		f, err := newFT232H(g)
+		f, err := newFT2232H(g)
+		if err != nil {
+			_ = h.Close()
+			return nil, err
+		}
+		f.Channel = 'A' + byte(channel%2)
+		return f, nil
+	case DevTypeFT4232H:
+		if channel%4 >= 2 {
+			// Channels C and D have no MPSSE.
+			f, err := newFT4232HSerial(g)
+			if err != nil {
+				_ = h.Close()
+				return nil, err
+			}
+			f.Channel = 'A' + byte(channel%4)
+			return f, nil
+		}
+		f, err := newFT4232H(g)
 		if err != nil {
 			_ = h.Close()
 			return nil, err
 		}
+		f.Channel = 'A' + byte(channel%4)
 		return f, nil
 	case DevTypeFT232R:
 		f, err := newFT232R(g)
@@ -81,6 +128,13 @@ func open(opener func(i int) (d2xx.Handle, d2xx.Err), i int) (Dev, error) {
 			return nil, err
 		}
 		return f, nil
+	case DevTypeFTXSeries:
+		f, err := newFTX(g)
+		if err != nil {
+			_ = h.Close()
+			return nil, err
+		}
+		return f, nil
 	default:
 		return &g, nil
 	}
@@ -128,19 +182,70 @@ func registerDev(d Dev, multi bool) error {
 		if err := spireg.Register(name, nil, -1, t.SPI); err != nil {
 			return err
 		}
-		// TODO(maruel): UART
+		if err := onewirereg.Register(name, nil, -1, t.OneWire); err != nil {
+			return err
+		}
+		if err := uartreg.Register(name, nil, -1, t.UART); err != nil {
+			return err
+		}
+	case *FT2232H:
+		// The FT2232H doesn't support open drain.
+		if err := i2creg.Register(name, nil, -1, func() (i2c.BusCloser, error) { return t.I2C(gpio.PullUp) }); err != nil {
+			return err
+		}
+		if err := spireg.Register(name, nil, -1, t.SPI); err != nil {
+			return err
+		}
+	case *FT4232H:
+		// The FT4232H doesn't support open drain.
+		if err := i2creg.Register(name, nil, -1, func() (i2c.BusCloser, error) { return t.I2C(gpio.PullUp) }); err != nil {
+			return err
+		}
+		if err := spireg.Register(name, nil, -1, t.SPI); err != nil {
+			return err
+		}
+	case *FT4232HSerial:
+		if err := spireg.Register(name, nil, -1, t.SPI); err != nil {
+			return err
+		}
+		if err := uartreg.Register(name, nil, -1, t.UART); err != nil {
+			return err
+		}
 	case *FT232R:
-		// TODO(maruel): SPI, UART
+		if err := spireg.Register(name, nil, -1, t.SPI); err != nil {
+			return err
+		}
+		if err := uartreg.Register(name, nil, -1, t.UART); err != nil {
+			return err
+		}
+	case *FTX:
+		if err := spireg.Register(name, nil, -1, t.SPI); err != nil {
+			return err
+		}
+		if err := uartreg.Register(name, nil, -1, t.UART); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// errExcluded is returned by open for a device not allowed by the host
+// configuration.
+var errExcluded = errors.New("d2xx: excluded by host configuration")
+
 // driver implements driver.Impl.
 type driver struct {
 	mu         sync.Mutex
 	all        []Dev
-	d2xxOpen   func(i int) (d2xx.Handle, d2xx.Err)
+	d2xxOpen   func(i int) (d2xxHandle, d2xxErr)
 	numDevices func() (int, error)
+	// opened is the number of devices enumerated last.
+	opened int
+
+	// Hot-plug watchers, see Watch.
+	watchers    []*Watcher
+	hotplugDone chan struct{}
+	hotplugWG   sync.WaitGroup
 }
 
 func (d *driver) String() string {
@@ -156,14 +261,28 @@ func (d *driver) After() []string {
 }
 
 func (d *driver) Init() (bool, error) {
+	if !usbfsUsed() {
+		if err := d2xxUnavailable(); err != nil {
+			return false, err
+		}
+	} else if !isLinux {
+		// usbfs is used when D2XX is not built in, but it only exists on Linux.
+		return false, ErrD2XXNotEnabled
+	}
 	num, err := d.numDevices()
 	if err != nil {
 		return true, err
 	}
+	d.opened = num
 	multi := num > 1
+	channels := map[DevType]int{}
 	for i := 0; i < num; i++ {
 		// TODO(maruel): Close the device one day. :)
-		if dev, err1 := open(d.d2xxOpen, i); err1 == nil {
+		dev, err1 := open(d.d2xxOpen, i, channels)
+		if err1 == errExcluded {
+			continue
+		}
+		if err1 == nil {
 			d.all = append(d.all, dev)
 			if err = registerDev(dev, multi); err != nil {
 				return true, err
@@ -189,19 +308,20 @@ func (d *driver) reset() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	d.all = nil
-	// open is mocked in tests. You can also wrap d2xx.Open to return a wrapped
-	// d2xxtest.Log.
-	d.d2xxOpen = d2xx.Open
+	d.opened = 0
+	// open is mocked in tests. You can also wrap openDevice to return a wrapped
+	// logHandle, see debug.go.
+	d.d2xxOpen = openDevice
 	// numDevices is mocked in tests.
 	d.numDevices = numDevices
 }
 
 func init() {
-	if d2xx.Available {
-		drv.reset()
-		drv.resetLog()
-		driverreg.MustRegister(&drv)
-	}
+	// The driver is registered even when no backend is usable so Init reports
+	// why it is skipped.
+	drv.reset()
+	drv.resetLog()
+	hostcfg.MustRegister(&drv)
 }
 
 var drv driver
@@ -5,10 +5,15 @@
 package ftdi
 
 import (
+	"sync"
 	"testing"
+	"time"
+	"unsafe"
 
-	"periph.io/x/d2xx"
-	"periph.io/x/d2xx/d2xxtest"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/spi/spireg"
+	"periph.io/x/conn/v3/uart/uartreg"
 )
 
 func TestDriver(t *testing.T) {
@@ -16,11 +21,11 @@ func TestDriver(t *testing.T) {
 	drv.numDevices = func() (int, error) {
 		return 1, nil
 	}
-	drv.d2xxOpen = func(i int) (d2xx.Handle, d2xx.Err) {
+	drv.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
 		if i != 0 {
 			t.Fatalf("unexpected index %d", i)
 		}
-		d := &d2xxtest.Fake{
+		d := &fakeHandle{
 			DevType: uint32(DevTypeFT232R),
 			Vid:     0x0403,
 			Pid:     0x6014,
@@ -33,10 +38,327 @@ func TestDriver(t *testing.T) {
 	}
 }
 
+func TestDriver_FTX(t *testing.T) {
+	defer reset(t)
+	drv.numDevices = func() (int, error) {
+		return 1, nil
+	}
+	raw := make([]byte, 56)
+	raw[0x16] = byte(FTxCBusTxLED)
+	raw[0x18] = byte(FTxCBusIOMode)
+	h := &recordHandle{fakeHandle: fakeHandle{DevType: uint32(DevTypeFTXSeries), Vid: 0x0403, Pid: 0x6015, E: d2xxEEPROM{Raw: raw}}}
+	drv.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
+		return h, 0
+	}
+	if b, err := drv.Init(); !b || err != nil {
+		t.Fatalf("Init() = %t, %v", b, err)
+	}
+	f, ok := drv.all[0].(*FTX)
+	if !ok {
+		t.Fatal(drv.all)
+	}
+	if s := f.C0.Name(); s != "FTXSeries.C0" {
+		t.Fatal(s)
+	}
+	if err := f.C2.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0x44 || bitMode(h.mode) != bitModeCbusBitbang {
+		t.Fatalf("mask %#x mode %#x", h.mask, h.mode)
+	}
+	// C0 is not set to I/O mode in the EEPROM.
+	if s := f.C0.Function(); s != "FTxCBusTxLED" {
+		t.Fatal(s)
+	}
+	if err := f.C0.Out(gpio.High); err == nil {
+		t.Fatal("not in I/O mode")
+	}
+	if s := unsafe.Sizeof(EEPROMFTX{}); s != 56 {
+		t.Fatal(s)
+	}
+}
+
+func TestDriver_FT2232H(t *testing.T) {
+	defer reset(t)
+	drv.numDevices = func() (int, error) {
+		return 2, nil
+	}
+	drv.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
+		d := &recordHandle{
+			fakeHandle: fakeHandle{DevType: uint32(DevTypeFT2232H), Vid: 0x0403, Pid: 0x6010},
+			replies:    mpsseVerifyReplies(),
+		}
+		return d, 0
+	}
+	if b, err := drv.Init(); !b || err != nil {
+		t.Fatalf("Init() = %t, %v", b, err)
+	}
+	if len(drv.all) != 2 {
+		t.Fatal(drv.all)
+	}
+	// Each channel is an independent device.
+	a, ok1 := drv.all[0].(*FT2232H)
+	b, ok2 := drv.all[1].(*FT2232H)
+	if !ok1 || !ok2 || a.Channel != 'A' || b.Channel != 'B' || a.h == b.h {
+		t.Fatal(drv.all)
+	}
+	if s := b.String(); s != "FT2232H(1)" {
+		t.Fatal(s)
+	}
+	if h := a.Header(); len(h) != 16 || h[15] != a.C7 {
+		t.Fatal(h)
+	}
+	if _, err := a.I2C(gpio.Float); err == nil {
+		t.Fatal("open drain isn't supported")
+	}
+	i, err := a.I2C(gpio.PullUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The other channel is not affected.
+	if _, err := b.SPI(); err != nil {
+		t.Fatal(err)
+	}
+	if err := i.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDriver_FT2232H_failed(t *testing.T) {
+	defer reset(t)
+	drv.numDevices = func() (int, error) {
+		return 2, nil
+	}
+	drv.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
+		if i == 0 {
+			return &failInit{fakeHandle: fakeHandle{DevType: uint32(DevTypeFT2232H), Vid: 0x0403, Pid: 0x6010}}, 0
+		}
+		d := &recordHandle{
+			fakeHandle: fakeHandle{DevType: uint32(DevTypeFT2232H), Vid: 0x0403, Pid: 0x6010},
+			replies:    mpsseVerifyReplies(),
+		}
+		return d, 0
+	}
+	if b, _ := drv.Init(); !b {
+		t.Fatal("Init() = false")
+	}
+	if _, ok := drv.all[0].(*broken); !ok {
+		t.Fatal(drv.all)
+	}
+	// The channel A failing doesn't shift the channel B.
+	if b, ok := drv.all[1].(*FT2232H); !ok || b.Channel != 'B' {
+		t.Fatal(drv.all)
+	}
+}
+
+func TestDriver_FT4232H(t *testing.T) {
+	defer reset(t)
+	drv.numDevices = func() (int, error) {
+		return 4, nil
+	}
+	drv.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
+		d := &recordHandle{
+			fakeHandle: fakeHandle{DevType: uint32(DevTypeFT4232H), Vid: 0x0403, Pid: 0x6011},
+		}
+		if i < 2 {
+			d.replies = mpsseVerifyReplies()
+		}
+		return d, 0
+	}
+	if b, err := drv.Init(); !b || err != nil {
+		t.Fatalf("Init() = %t, %v", b, err)
+	}
+	if len(drv.all) != 4 {
+		t.Fatal(drv.all)
+	}
+	a, ok1 := drv.all[0].(*FT4232H)
+	b, ok2 := drv.all[1].(*FT4232H)
+	if !ok1 || !ok2 || a.Channel != 'A' || b.Channel != 'B' {
+		t.Fatal(drv.all)
+	}
+	// Channels C and D have no MPSSE.
+	c, ok1 := drv.all[2].(*FT4232HSerial)
+	d, ok2 := drv.all[3].(*FT4232HSerial)
+	if !ok1 || !ok2 || c.Channel != 'C' || d.Channel != 'D' {
+		t.Fatal(drv.all)
+	}
+	if s := d.String(); s != "FT4232H(3)" {
+		t.Fatal(s)
+	}
+	if h := d.Header(); len(h) != 8 || h[7] != d.RI || h[0].Name() != "FT4232H(3).TX" {
+		t.Fatal(h)
+	}
+	if p := spireg.All(); len(p) != 4 {
+		t.Fatal(p)
+	}
+	if p := uartreg.All(); len(p) != 2 {
+		t.Fatal(p)
+	}
+	if h := b.Header(); len(h) != 8 || h[7] != b.D7 {
+		t.Fatal(h)
+	}
+	if err := a.CBus(0, 0); err == nil {
+		t.Fatal("there's no C bus")
+	}
+	if _, err := a.I2C(gpio.Float); err == nil {
+		t.Fatal("open drain isn't supported")
+	}
+	i, err := a.I2C(gpio.PullUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.SPI(); err != nil {
+		t.Fatal(err)
+	}
+	if err := i.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// failInit fails handle.Init.
+type failInit struct {
+	fakeHandle
+}
+
+func (f *failInit) SetTimeouts(readMS, writeMS int) d2xxErr {
+	return 1
+}
+
 func reset(t *testing.T) {
+	// Unregister the devices so the next test can register them again.
+	for _, d := range drv.all {
+		unregisterDev(d, false)
+	}
 	drv.reset()
 }
 
 func init() {
 	reset(nil)
 }
+
+func TestDriver_hotplug(t *testing.T) {
+	defer reset(t)
+	// The devices plugged in, in the enumeration order.
+	plugged := []*plugHandle{newPlugHandle()}
+	drv.numDevices = func() (int, error) {
+		return len(plugged), nil
+	}
+	drv.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
+		if i >= len(plugged) || plugged[i].open {
+			// FT_DEVICE_NOT_OPENED
+			return nil, 3
+		}
+		plugged[i].open = true
+		return plugged[i], 0
+	}
+	if b, err := drv.Init(); !b || err != nil {
+		t.Fatalf("Init() = %t, %v", b, err)
+	}
+	if e := drv.hotplug(); len(e) != 0 {
+		t.Fatal(e)
+	}
+	// A second device is plugged in.
+	plugged = append(plugged, newPlugHandle())
+	e := drv.hotplug()
+	if len(e) != 1 || e[0].Removed || e[0].Dev.String() != "FTXSeries(1)" {
+		t.Fatal(e)
+	}
+	if len(drv.all) != 2 || gpioreg.ByName("FTXSeries(1).C0") == nil {
+		t.Fatal(drv.all)
+	}
+	// The first one is unplugged.
+	first := drv.all[0]
+	plugged[0].gone = true
+	plugged = plugged[1:]
+	e = drv.hotplug()
+	if len(e) != 1 || !e[0].Removed || e[0].Dev != first || e[0].String() != "FTXSeries removed" {
+		t.Fatal(e)
+	}
+	if len(drv.all) != 1 || gpioreg.ByName("FTXSeries.C0") != nil || gpioreg.ByName("C0") != nil {
+		t.Fatal(drv.all)
+	}
+	if gpioreg.ByName("FTXSeries(1).C0") == nil {
+		t.Fatal("the other device must stay registered")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	defer reset(t)
+	old := hotplugPeriod
+	defer func() { hotplugPeriod = old }()
+	hotplugPeriod = time.Millisecond
+	num := 0
+	var mu sync.Mutex
+	drv.numDevices = func() (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return num, nil
+	}
+	h := newPlugHandle()
+	drv.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
+		if h.open {
+			return nil, 3
+		}
+		h.open = true
+		return h, 0
+	}
+	w, err := Watch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2, err := Watch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w2.Close(); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	num = 1
+	mu.Unlock()
+	e := <-w.Events()
+	if e.Removed || e.String() != "FTXSeries arrived" {
+		t.Fatal(e)
+	}
+	drv.mu.Lock()
+	h.gone = true
+	drv.mu.Unlock()
+	mu.Lock()
+	num = 0
+	mu.Unlock()
+	if e := <-w.Events(); !e.Removed {
+		t.Fatal(e)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-w.Events(); ok {
+		t.Fatal("expected closed")
+	}
+}
+
+//
+
+// plugHandle is a device that can be unplugged.
+type plugHandle struct {
+	fakeHandle
+	open bool
+	gone bool
+}
+
+func newPlugHandle() *plugHandle {
+	return &plugHandle{fakeHandle: fakeHandle{DevType: uint32(DevTypeFTXSeries), Vid: 0x0403, Pid: 0x6015, E: d2xxEEPROM{Raw: make([]byte, 56)}}}
+}
+
+func (p *plugHandle) GetQueueStatus() (uint32, d2xxErr) {
+	if p.gone {
+		// FT_IO_ERROR
+		return 0, 4
+	}
+	return p.fakeHandle.GetQueueStatus()
+}
+
+func (p *plugHandle) Close() d2xxErr {
+	p.open = false
+	return 0
+}
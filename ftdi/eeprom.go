@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"unsafe"
+
+	"periph.io/x/conn/v3/physic"
 )
 
 // EEPROM is the unprocessed EEPROM content.
@@ -73,6 +75,15 @@ func (e *EEPROM) AsFT2232H() *EEPROMFT2232H {
 	return (*EEPROMFT2232H)(unsafe.Pointer(&e.Raw[0]))
 }
 
+// AsFTX returns the Raw data aliased as EEPROMFTX.
+func (e *EEPROM) AsFTX() *EEPROMFTX {
+	// sizeof(EEPROMFTX)
+	if len(e.Raw) < 56 {
+		return nil
+	}
+	return (*EEPROMFTX)(unsafe.Pointer(&e.Raw[0]))
+}
+
 // AsFT232R returns the Raw data aliased as EEPROMFT232R.
 func (e *EEPROM) AsFT232R() *EEPROMFT232R {
 	// sizeof(EEPROMFT232R)
@@ -179,6 +190,68 @@ func (f FT232rCBusMux) String() string {
 	return ft232rCBusMuxName[ft232rCBusMuxIndex[f]:ft232rCBusMuxIndex[f+1]]
 }
 
+// FTxCBusMux is stored in the FT-X series EEPROM to control each CBus pin.
+type FTxCBusMux uint8
+
+const (
+	// TriSt-PU; Sets in Tristate (pull up) (C0~C6).
+	FTxCBusTristate FTxCBusMux = 0x00
+	// TXLED#; Pulses low when transmitting data (C0~C6).
+	FTxCBusTxLED FTxCBusMux = 0x01
+	// RXLED#; Pulses low when receiving data (C0~C6).
+	FTxCBusRxLED FTxCBusMux = 0x02
+	// TX&RXLED#; Pulses low when either receiving or transmitting data (C0~C6).
+	FTxCBusTxRxLED FTxCBusMux = 0x03
+	// PWREN#; Output is low after the device has been configured by USB, then
+	// high during USB suspend mode (C0~C6).
+	FTxCBusPwrEnable FTxCBusMux = 0x04
+	// SLEEP#; Goes low during USB suspend mode (C0~C6).
+	FTxCBusSleep FTxCBusMux = 0x05
+	// DRIVE0; Drives pin to logic 0 (C0~C6).
+	FTxCBusDrive0 FTxCBusMux = 0x06
+	// DRIVE1; Drives pin to logic 1 (C0~C6).
+	FTxCBusDrive1 FTxCBusMux = 0x07
+	// GPIO; CBus bit-bang mode option (C0~C3).
+	FTxCBusIOMode FTxCBusMux = 0x08
+	// TXDEN; Tx Data Enable, for RS485 level converters (C0~C6).
+	FTxCBusTxdEnable FTxCBusMux = 0x09
+	// CLK24MHz; 24MHz clock output (C0~C6).
+	FTxCBusClk24 FTxCBusMux = 0x0A
+	// CLK12MHz; 12MHz clock output (C0~C6).
+	FTxCBusClk12 FTxCBusMux = 0x0B
+	// CLK6MHz; 6MHz clock output (C0~C6).
+	FTxCBusClk6 FTxCBusMux = 0x0C
+	// BCD_Charger; Battery charger detected (C0~C6).
+	FTxCBusBCDCharger FTxCBusMux = 0x0D
+	// BCD_Charger#; Battery charger detected, active low (C0~C6).
+	FTxCBusBCDChargerN FTxCBusMux = 0x0E
+	// I2C_TXE#; I²C transmit buffer empty (C0~C6).
+	FTxCBusI2CTxEmpty FTxCBusMux = 0x0F
+	// I2C_RXF#; I²C receive buffer full (C0~C6).
+	FTxCBusI2CRxFull FTxCBusMux = 0x10
+	// VBUS_Sense; Detects when VBUS is present (C0~C6).
+	FTxCBusVBusSense FTxCBusMux = 0x11
+	// BitBang_WR#; CBus WR# strobe output (C0~C6).
+	FTxCBusBitBangWR FTxCBusMux = 0x12
+	// BitBang_RD#; CBus RD# strobe output (C0~C6).
+	FTxCBusBitBangRD FTxCBusMux = 0x13
+	// Time_Stamp; Toggles on each USB SOF (C0~C6).
+	FTxCBusTimeStamp FTxCBusMux = 0x14
+	// Keep_Awake#; Prevents the chip from entering suspend (C0~C6).
+	FTxCBusKeepAwake FTxCBusMux = 0x15
+)
+
+const ftxCBusMuxName = "FTxCBusTristateFTxCBusTxLEDFTxCBusRxLEDFTxCBusTxRxLEDFTxCBusPwrEnableFTxCBusSleepFTxCBusDrive0FTxCBusDrive1FTxCBusIOModeFTxCBusTxdEnableFTxCBusClk24FTxCBusClk12FTxCBusClk6FTxCBusBCDChargerFTxCBusBCDChargerNFTxCBusI2CTxEmptyFTxCBusI2CRxFullFTxCBusVBusSenseFTxCBusBitBangWRFTxCBusBitBangRDFTxCBusTimeStampFTxCBusKeepAwake"
+
+var ftxCBusMuxIndex = [...]uint16{0, 15, 27, 39, 53, 69, 81, 94, 107, 120, 136, 148, 160, 171, 188, 206, 223, 239, 255, 271, 287, 303, 319}
+
+func (f FTxCBusMux) String() string {
+	if f >= FTxCBusMux(len(ftxCBusMuxIndex)-1) {
+		return fmt.Sprintf("FTxCBusMux(%d)", f)
+	}
+	return ftxCBusMuxName[ftxCBusMuxIndex[f]:ftxCBusMuxIndex[f+1]]
+}
+
 // EEPROMHeader is the common header found on FTDI devices.
 //
 // It is 16 bytes long.
@@ -314,6 +387,278 @@ func (e *EEPROMFT232R) Defaults() {
 	e.DriverType = 1
 }
 
+// EEPROMFTX is the EEPROM layout of a FT-X series device, e.g. FT230X or
+// FT231X.
+//
+// It is 56 bytes long.
+type EEPROMFTX struct {
+	EEPROMHeader
+
+	// FT-X specific.
+	ACSlowSlew        uint8      // 0x10 bool Non-zero if CBus pins have slow slew
+	ACSchmittInput    uint8      // 0x11 bool Non-zero if CBus pins are Schmitt input
+	ACDriveCurrent    uint8      // 0x12 Valid values are 4mA, 8mA, 12mA, 16mA in 2mA units
+	ADSlowSlew        uint8      // 0x13 bool Non-zero if DBus pins have slow slew
+	ADSchmittInput    uint8      // 0x14 bool Non-zero if DBus pins are Schmitt input
+	ADDriveCurrent    uint8      // 0x15 Valid values are 4mA, 8mA, 12mA, 16mA in 2mA units
+	Cbus0             FTxCBusMux // 0x16
+	Cbus1             FTxCBusMux // 0x17
+	Cbus2             FTxCBusMux // 0x18
+	Cbus3             FTxCBusMux // 0x19
+	Cbus4             FTxCBusMux // 0x1A
+	Cbus5             FTxCBusMux // 0x1B
+	Cbus6             FTxCBusMux // 0x1C
+	InvertTXD         uint8      // 0x1D bool
+	InvertRXD         uint8      // 0x1E bool
+	InvertRTS         uint8      // 0x1F bool
+	InvertCTS         uint8      // 0x20 bool
+	InvertDTR         uint8      // 0x21 bool
+	InvertDSR         uint8      // 0x22 bool
+	InvertDCD         uint8      // 0x23 bool
+	InvertRI          uint8      // 0x24 bool
+	BCDEnable         uint8      // 0x25 bool Battery charge detection
+	BCDForceCbusPWREN uint8      // 0x26 bool
+	BCDDisableSleep   uint8      // 0x27 bool
+	I2CSlaveAddress   uint16     // 0x28
+	Unused0           uint16     // 0x2A For alignment.
+	I2CDeviceID       uint32     // 0x2C
+	I2CDisableSchmitt uint8      // 0x30 bool
+	FT1248Cpol        uint8      // 0x31 bool Clock polarity
+	FT1248Lsb         uint8      // 0x32 bool LSB first
+	FT1248FlowControl uint8      // 0x33 bool
+	RS485EchoSuppress uint8      // 0x34 bool
+	PowerSaveEnable   uint8      // 0x35 bool
+	DriverType        uint8      // 0x36 bool 0 is D2XX, 1 is VCP
+	Unused1           uint8      // 0x37 For alignment.
+}
+
+// EEPROMConfig is the decoded EEPROM content.
+//
+// It is the device independent view of the EEPROM: read the EEPROM, decode it
+// with EEPROM.Config, modify the values, encode them back with
+// EEPROM.SetConfig then program it with WriteEEPROM.
+type EEPROMConfig struct {
+	// The following condition must be true: len(Manufacturer) + len(Desc) <= 40.
+	Manufacturer   string
+	ManufacturerID string
+	Desc           string
+	Serial         string
+
+	VendorID     uint16
+	ProductID    uint16
+	SerialEnable bool                   // Report Serial to the host
+	MaxPower     physic.ElectricCurrent // Up to 500mA
+	SelfPowered  bool
+	RemoteWakeup bool
+	VCP          bool // Load the Virtual COM Port driver instead of D2XX
+
+	// CBus is the function of each CBus pin. The values are FT232hCBusMux on a
+	// FT232H, FT232rCBusMux on a FT232R and FTxCBusMux on a FT-X device.
+	//
+	// It is empty on other devices.
+	CBus []uint8
+	// Drive is the drive options of each group of pins: AD then AC on a FT232H
+	// and a FT-X device, AL, AH, BL then BH on a FT2232H.
+	//
+	// It is empty on other devices.
+	Drive []EEPROMDrive
+}
+
+// EEPROMDrive is the drive options of a group of pins.
+type EEPROMDrive struct {
+	Current  physic.ElectricCurrent // 4, 8, 12 or 16mA
+	SlowSlew bool
+	Schmitt  bool
+}
+
+// Config decodes the EEPROM content.
+//
+// The device type is the one stored in the EEPROM header.
+func (e *EEPROM) Config() (*EEPROMConfig, error) {
+	h := e.AsHeader()
+	if h == nil {
+		return nil, errors.New("ftdi: EEPROM is too short")
+	}
+	c := &EEPROMConfig{
+		Manufacturer:   e.Manufacturer,
+		ManufacturerID: e.ManufacturerID,
+		Desc:           e.Desc,
+		Serial:         e.Serial,
+		VendorID:       h.VendorID,
+		ProductID:      h.ProductID,
+		SerialEnable:   h.SerNumEnable != 0,
+		MaxPower:       physic.ElectricCurrent(h.MaxPower) * physic.MilliAmpere,
+		SelfPowered:    h.SelfPowered != 0,
+		RemoteWakeup:   h.RemoteWakeup != 0,
+	}
+	switch h.DeviceType {
+	case DevTypeFT232H:
+		x := e.AsFT232H()
+		if x == nil {
+			return nil, errors.New("ftdi: EEPROM is too short")
+		}
+		c.VCP = x.DriverType != 0
+		c.CBus = []uint8{
+			uint8(x.Cbus0), uint8(x.Cbus1), uint8(x.Cbus2), uint8(x.Cbus3), uint8(x.Cbus4),
+			uint8(x.Cbus5), uint8(x.Cbus6), uint8(x.Cbus7), uint8(x.Cbus8), uint8(x.Cbus9),
+		}
+		c.Drive = []EEPROMDrive{
+			toDrive(x.ADDriveCurrent, x.ADSlowSlew, x.ADSchmittInput),
+			toDrive(x.ACDriveCurrent, x.ACSlowSlew, x.ACSchmittInput),
+		}
+	case DevTypeFT2232H:
+		x := e.AsFT2232H()
+		if x == nil {
+			return nil, errors.New("ftdi: EEPROM is too short")
+		}
+		c.VCP = x.ADriverType != 0
+		c.Drive = []EEPROMDrive{
+			toDrive(x.ALDriveCurrent, x.ALSlowSlew, x.ALSchmittInput),
+			toDrive(x.AHDriveCurrent, x.AHSlowSlew, x.AHSchmittInput),
+			toDrive(x.BLDriveCurrent, x.BLSlowSlew, x.BLSchmittInput),
+			toDrive(x.BHDriveCurrent, x.BHSlowSlew, x.BHSchmittInput),
+		}
+	case DevTypeFT232R:
+		x := e.AsFT232R()
+		if x == nil {
+			return nil, errors.New("ftdi: EEPROM is too short")
+		}
+		c.VCP = x.DriverType != 0
+		c.CBus = []uint8{uint8(x.Cbus0), uint8(x.Cbus1), uint8(x.Cbus2), uint8(x.Cbus3), uint8(x.Cbus4)}
+	case DevTypeFTXSeries:
+		x := e.AsFTX()
+		if x == nil {
+			return nil, errors.New("ftdi: EEPROM is too short")
+		}
+		c.VCP = x.DriverType != 0
+		c.CBus = []uint8{
+			uint8(x.Cbus0), uint8(x.Cbus1), uint8(x.Cbus2), uint8(x.Cbus3),
+			uint8(x.Cbus4), uint8(x.Cbus5), uint8(x.Cbus6),
+		}
+		c.Drive = []EEPROMDrive{
+			toDrive(x.ADDriveCurrent, x.ADSlowSlew, x.ADSchmittInput),
+			toDrive(x.ACDriveCurrent, x.ACSlowSlew, x.ACSchmittInput),
+		}
+	}
+	return c, nil
+}
+
+// SetConfig encodes c into the EEPROM content.
+//
+// The device type, vendor ID and product ID stored in the EEPROM header are
+// kept; WriteEEPROM refuses to program values not matching the device.
+func (e *EEPROM) SetConfig(c *EEPROMConfig) error {
+	h := e.AsHeader()
+	if h == nil {
+		return errors.New("ftdi: EEPROM is too short")
+	}
+	if c.VendorID != h.VendorID || c.ProductID != h.ProductID {
+		return errors.New("ftdi: VendorID and ProductID can't be changed")
+	}
+	if c.MaxPower < 0 || c.MaxPower > 500*physic.MilliAmpere {
+		return fmt.Errorf("ftdi: invalid MaxPower %s", c.MaxPower)
+	}
+	n := EEPROM{Manufacturer: c.Manufacturer, ManufacturerID: c.ManufacturerID, Desc: c.Desc, Serial: c.Serial}
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	// Encode in a copy, so e is left untouched on failure.
+	n.Raw = append([]byte(nil), e.Raw...)
+	nh := n.AsHeader()
+	nh.SerNumEnable = boolToUint8(c.SerialEnable)
+	nh.MaxPower = uint16(c.MaxPower / physic.MilliAmpere)
+	nh.SelfPowered = boolToUint8(c.SelfPowered)
+	nh.RemoteWakeup = boolToUint8(c.RemoteWakeup)
+	var cbus []*uint8
+	var drive [][3]*uint8
+	switch h.DeviceType {
+	case DevTypeFT232H:
+		x := n.AsFT232H()
+		if x == nil {
+			return errors.New("ftdi: EEPROM is too short")
+		}
+		x.DriverType = boolToUint8(c.VCP)
+		for _, p := range []*FT232hCBusMux{&x.Cbus0, &x.Cbus1, &x.Cbus2, &x.Cbus3, &x.Cbus4, &x.Cbus5, &x.Cbus6, &x.Cbus7, &x.Cbus8, &x.Cbus9} {
+			cbus = append(cbus, (*uint8)(p))
+		}
+		drive = [][3]*uint8{
+			{&x.ADDriveCurrent, &x.ADSlowSlew, &x.ADSchmittInput},
+			{&x.ACDriveCurrent, &x.ACSlowSlew, &x.ACSchmittInput},
+		}
+	case DevTypeFT2232H:
+		x := n.AsFT2232H()
+		if x == nil {
+			return errors.New("ftdi: EEPROM is too short")
+		}
+		x.ADriverType = boolToUint8(c.VCP)
+		x.BDriverType = boolToUint8(c.VCP)
+		drive = [][3]*uint8{
+			{&x.ALDriveCurrent, &x.ALSlowSlew, &x.ALSchmittInput},
+			{&x.AHDriveCurrent, &x.AHSlowSlew, &x.AHSchmittInput},
+			{&x.BLDriveCurrent, &x.BLSlowSlew, &x.BLSchmittInput},
+			{&x.BHDriveCurrent, &x.BHSlowSlew, &x.BHSchmittInput},
+		}
+	case DevTypeFT232R:
+		x := n.AsFT232R()
+		if x == nil {
+			return errors.New("ftdi: EEPROM is too short")
+		}
+		x.DriverType = boolToUint8(c.VCP)
+		for _, p := range []*FT232rCBusMux{&x.Cbus0, &x.Cbus1, &x.Cbus2, &x.Cbus3, &x.Cbus4} {
+			cbus = append(cbus, (*uint8)(p))
+		}
+	case DevTypeFTXSeries:
+		x := n.AsFTX()
+		if x == nil {
+			return errors.New("ftdi: EEPROM is too short")
+		}
+		x.DriverType = boolToUint8(c.VCP)
+		for _, p := range []*FTxCBusMux{&x.Cbus0, &x.Cbus1, &x.Cbus2, &x.Cbus3, &x.Cbus4, &x.Cbus5, &x.Cbus6} {
+			cbus = append(cbus, (*uint8)(p))
+		}
+		drive = [][3]*uint8{
+			{&x.ADDriveCurrent, &x.ADSlowSlew, &x.ADSchmittInput},
+			{&x.ACDriveCurrent, &x.ACSlowSlew, &x.ACSchmittInput},
+		}
+	}
+	if len(c.CBus) != len(cbus) {
+		return fmt.Errorf("ftdi: %s has %d CBus pins, got %d", h.DeviceType, len(cbus), len(c.CBus))
+	}
+	for i, v := range c.CBus {
+		*cbus[i] = v
+	}
+	if len(c.Drive) != len(drive) {
+		return fmt.Errorf("ftdi: %s has %d groups of pins, got %d", h.DeviceType, len(drive), len(c.Drive))
+	}
+	for i, d := range c.Drive {
+		switch d.Current {
+		case 4 * physic.MilliAmpere, 8 * physic.MilliAmpere, 12 * physic.MilliAmpere, 16 * physic.MilliAmpere:
+		default:
+			return fmt.Errorf("ftdi: invalid drive current %s; valid values are 4mA, 8mA, 12mA and 16mA", d.Current)
+		}
+		*drive[i][0] = uint8(d.Current / physic.MilliAmpere)
+		*drive[i][1] = boolToUint8(d.SlowSlew)
+		*drive[i][2] = boolToUint8(d.Schmitt)
+	}
+	*e = n
+	return nil
+}
+
+func toDrive(current, slowSlew, schmitt uint8) EEPROMDrive {
+	return EEPROMDrive{
+		Current:  physic.ElectricCurrent(current) * physic.MilliAmpere,
+		SlowSlew: slowSlew != 0,
+		Schmitt:  schmitt != 0,
+	}
+}
+
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 //
 
 // DevType is the FTDI device type.
@@ -351,6 +696,9 @@ func (d DevType) EEPROMSize() int {
 	case DevTypeFT232R:
 		// sizeof(EEPROMFT232R)
 		return 32
+	case DevTypeFTXSeries:
+		// sizeof(EEPROMFTX)
+		return 56
 	default:
 		return 256
 	}
@@ -0,0 +1,201 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// EEPROMBackup is the whole EEPROM content of a device, as saved by
+// DumpEEPROM.
+type EEPROMBackup struct {
+	// DevType is the type of the device the EEPROM was read from.
+	DevType DevType
+	// EEPROM is the EEPROM content, including the strings.
+	EEPROM EEPROM
+	// UserArea is the user area content. It is nil when the user area was
+	// empty.
+	UserArea []byte
+	// Config is EEPROM decoded. It is nil when the EEPROM couldn't be decoded.
+	//
+	// It is informational only; MarshalBinary() uses EEPROM.
+	Config *EEPROMConfig
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The format is versioned and checksummed, so it can be archived and
+// restored with RestoreEEPROM later on.
+func (b *EEPROMBackup) MarshalBinary() ([]byte, error) {
+	if err := b.EEPROM.Validate(); err != nil {
+		return nil, err
+	}
+	if len(b.EEPROM.Raw) > 0xFFFF || len(b.UserArea) > 0xFFFF {
+		return nil, errors.New("ftdi: EEPROM is too large")
+	}
+	out := make([]byte, 0, 4+1+4+2+len(b.EEPROM.Raw)+4*41+2+len(b.UserArea)+4)
+	out = append(out, eepromBackupMagic...)
+	out = append(out, eepromBackupVersion)
+	out = appendUint32(out, uint32(b.DevType))
+	out = appendUint16(out, uint16(len(b.EEPROM.Raw)))
+	out = append(out, b.EEPROM.Raw...)
+	for _, s := range []string{b.EEPROM.Manufacturer, b.EEPROM.ManufacturerID, b.EEPROM.Desc, b.EEPROM.Serial} {
+		out = append(out, byte(len(s)))
+		out = append(out, s...)
+	}
+	out = appendUint16(out, uint16(len(b.UserArea)))
+	out = append(out, b.UserArea...)
+	return appendUint32(out, crc32.ChecksumIEEE(out)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// Config is decoded from the EEPROM content.
+func (b *EEPROMBackup) UnmarshalBinary(data []byte) error {
+	if len(data) < len(eepromBackupMagic)+1+4 || string(data[:len(eepromBackupMagic)]) != eepromBackupMagic {
+		return errors.New("ftdi: not an EEPROM backup")
+	}
+	if v := data[len(eepromBackupMagic)]; v != eepromBackupVersion {
+		return fmt.Errorf("ftdi: unsupported EEPROM backup version %d", v)
+	}
+	l := len(data) - 4
+	if crc32.ChecksumIEEE(data[:l]) != binary.LittleEndian.Uint32(data[l:]) {
+		return errors.New("ftdi: EEPROM backup is corrupted")
+	}
+	r := backupReader{b: data[len(eepromBackupMagic)+1 : l]}
+	n := EEPROMBackup{DevType: DevType(r.uint32())}
+	n.EEPROM.Raw = r.bytes(int(r.uint16()))
+	n.EEPROM.Manufacturer = string(r.bytes(int(r.byte())))
+	n.EEPROM.ManufacturerID = string(r.bytes(int(r.byte())))
+	n.EEPROM.Desc = string(r.bytes(int(r.byte())))
+	n.EEPROM.Serial = string(r.bytes(int(r.byte())))
+	if ua := r.bytes(int(r.uint16())); len(ua) != 0 {
+		n.UserArea = ua
+	}
+	if r.err || len(r.b) != 0 {
+		return errors.New("ftdi: EEPROM backup is truncated")
+	}
+	n.Config, _ = n.EEPROM.Config()
+	*b = n
+	return nil
+}
+
+// DumpEEPROM reads the EEPROM and the user area of d.
+//
+// It returns the backup both encoded, as returned by
+// EEPROMBackup.MarshalBinary, and decoded.
+func DumpEEPROM(d Dev) ([]byte, *EEPROMBackup, error) {
+	g := devGeneric(d)
+	if g == nil {
+		return nil, nil, errors.New("d2xx: can't read the EEPROM of " + d.String())
+	}
+	b := &EEPROMBackup{DevType: g.h.t}
+	if err := d.EEPROM(&b.EEPROM); err != nil {
+		return nil, nil, err
+	}
+	ua, err := d.UserArea()
+	if err != nil {
+		return nil, nil, err
+	}
+	b.UserArea = ua
+	b.Config, _ = b.EEPROM.Config()
+	raw, err := b.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, b, nil
+}
+
+// RestoreEEPROM programs the EEPROM and the user area of d with a backup
+// returned by DumpEEPROM, then cycles the USB port like ProgramTemplate does.
+//
+// The device must be of the same type, vendor ID and product ID as the one
+// the backup was taken from. The serial number is restored too; to
+// provision many devices from one backup, decode it with
+// EEPROMBackup.UnmarshalBinary, change EEPROM.Serial and encode it again.
+//
+// It returns the re-enumerated device, registered in place of d; d must not
+// be used afterward.
+func RestoreEEPROM(d Dev, blob []byte) (Dev, error) {
+	var b EEPROMBackup
+	if err := b.UnmarshalBinary(blob); err != nil {
+		return d, err
+	}
+	g := devGeneric(d)
+	if g == nil {
+		return d, errors.New("d2xx: can't program " + d.String())
+	}
+	if b.DevType != g.h.t {
+		return d, fmt.Errorf("ftdi: backup of a %s can't be restored on a %s", b.DevType, g.h.t)
+	}
+	if err := d.WriteEEPROM(&b.EEPROM); err != nil {
+		return d, err
+	}
+	// The user area size depends on the strings, so it is written last.
+	if len(b.UserArea) != 0 {
+		if err := d.WriteUserArea(b.UserArea); err != nil {
+			return d, err
+		}
+	}
+	return cyclePort(d, g)
+}
+
+//
+
+const (
+	eepromBackupMagic   = "FTEE"
+	eepromBackupVersion = 1
+)
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// backupReader decodes an EEPROM backup. err is set when reading past the
+// end.
+type backupReader struct {
+	b   []byte
+	err bool
+}
+
+func (r *backupReader) bytes(n int) []byte {
+	if len(r.b) < n {
+		r.err = true
+		r.b = nil
+		return nil
+	}
+	out := make([]byte, n)
+	copy(out, r.b)
+	r.b = r.b[n:]
+	return out
+}
+
+func (r *backupReader) byte() byte {
+	if b := r.bytes(1); len(b) == 1 {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *backupReader) uint16() uint16 {
+	if b := r.bytes(2); len(b) == 2 {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *backupReader) uint32() uint32 {
+	if b := r.bytes(4); len(b) == 4 {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/onewire/onewirereg"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/pin/pinreg"
+	"periph.io/x/conn/v3/spi/spireg"
+	"periph.io/x/conn/v3/uart/uartreg"
+)
+
+// EEPROMTemplate is a ready-made EEPROM configuration for a use case.
+//
+// The strings, the vendor ID and the product ID are left untouched.
+type EEPROMTemplate struct {
+	Name    string
+	DevType DevType
+
+	apply func(c *EEPROMConfig)
+}
+
+// Apply modifies the EEPROM content according to the template.
+func (t *EEPROMTemplate) Apply(ee *EEPROM) error {
+	c, err := ee.Config()
+	if err != nil {
+		return err
+	}
+	if typ := ee.AsHeader().DeviceType; typ != t.DevType {
+		return errors.New("ftdi: template " + strconv.Quote(t.Name) + " is for " + t.DevType.String() + ", got " + typ.String())
+	}
+	t.apply(c)
+	return ee.SetConfig(c)
+}
+
+// Ready-made templates.
+var (
+	// TemplateFT232HI2C configures a FT232H for I²C with D1 and D2 tied, using
+	// Schmitt trigger inputs on the AD bus to cope with slow rising edges.
+	TemplateFT232HI2C = &EEPROMTemplate{
+		Name:    "FT232H for I²C with D1/D2 tied",
+		DevType: DevTypeFT232H,
+		apply: func(c *EEPROMConfig) {
+			c.VCP = false
+			c.Drive[0] = EEPROMDrive{Current: 8 * physic.MilliAmpere, Schmitt: true}
+			c.Drive[1] = EEPROMDrive{Current: 4 * physic.MilliAmpere}
+		},
+	}
+	// TemplateFT232HMPSSE configures a FT232H for SPI, JTAG or SWD, with a
+	// stronger drive on the AD bus for the faster clocks.
+	TemplateFT232HMPSSE = &EEPROMTemplate{
+		Name:    "FT232H for SPI, JTAG and SWD",
+		DevType: DevTypeFT232H,
+		apply: func(c *EEPROMConfig) {
+			c.VCP = false
+			c.Drive[0] = EEPROMDrive{Current: 16 * physic.MilliAmpere}
+			c.Drive[1] = EEPROMDrive{Current: 4 * physic.MilliAmpere}
+		},
+	}
+	// TemplateFT232HUART configures a FT232H as a serial port, loading the
+	// Virtual COM Port driver.
+	TemplateFT232HUART = &EEPROMTemplate{
+		Name:    "FT232H as a serial port",
+		DevType: DevTypeFT232H,
+		apply: func(c *EEPROMConfig) {
+			c.VCP = true
+			c.Drive[0] = EEPROMDrive{Current: 4 * physic.MilliAmpere}
+			c.Drive[1] = EEPROMDrive{Current: 4 * physic.MilliAmpere}
+		},
+	}
+	// TemplateFT232RGPIO configures C0~C3 of a FT232R as GPIOs.
+	TemplateFT232RGPIO = &EEPROMTemplate{
+		Name:    "FT232R with C0~C3 as GPIOs",
+		DevType: DevTypeFT232R,
+		apply: func(c *EEPROMConfig) {
+			c.VCP = false
+			for i := 0; i < 4; i++ {
+				c.CBus[i] = uint8(FT232rCBusIOMode)
+			}
+		},
+	}
+	// TemplateFTXGPIO configures C0~C3 of a FT-X device as GPIOs.
+	TemplateFTXGPIO = &EEPROMTemplate{
+		Name:    "FT-X with C0~C3 as GPIOs",
+		DevType: DevTypeFTXSeries,
+		apply: func(c *EEPROMConfig) {
+			c.VCP = false
+			for i := 0; i < 4; i++ {
+				c.CBus[i] = uint8(FTxCBusIOMode)
+			}
+		},
+	}
+)
+
+// ProgramTemplate programs the template in the EEPROM of d, then cycles the
+// USB port so the device re-enumerates with the new configuration without
+// being replugged.
+//
+// It returns the re-enumerated device, registered in place of d; d must not
+// be used afterward.
+//
+// When the port can't be cycled, the EEPROM is still programmed and d is
+// returned along an error asking to replug the device.
+func ProgramTemplate(d Dev, t *EEPROMTemplate) (Dev, error) {
+	g := devGeneric(d)
+	if g == nil {
+		return d, errors.New("d2xx: can't program " + d.String())
+	}
+	var ee EEPROM
+	if err := d.EEPROM(&ee); err != nil {
+		return d, err
+	}
+	if err := t.Apply(&ee); err != nil {
+		return d, err
+	}
+	if err := d.WriteEEPROM(&ee); err != nil {
+		return d, err
+	}
+	return cyclePort(d, g)
+}
+
+// ProgramDrive programs the drive options of each group of pins of d, in the
+// order of EEPROMConfig.Drive, e.g. the AD then the AC bus of a FT232H, then
+// cycles the USB port like ProgramTemplate.
+//
+// A stronger drive current and fast slew give sharper edges on long cables and
+// LED strips, at the cost of more ringing; Schmitt trigger inputs cope with
+// slow or noisy edges.
+//
+// The EEPROM is not written when it already has these options, to spare its
+// limited write cycles; d is then returned as is.
+func ProgramDrive(d Dev, drive ...EEPROMDrive) (Dev, error) {
+	g := devGeneric(d)
+	if g == nil {
+		return d, errors.New("d2xx: can't program " + d.String())
+	}
+	var ee EEPROM
+	if err := d.EEPROM(&ee); err != nil {
+		return d, err
+	}
+	c, err := ee.Config()
+	if err != nil {
+		return d, err
+	}
+	if len(c.Drive) == len(drive) {
+		same := true
+		for i := range drive {
+			same = same && c.Drive[i] == drive[i]
+		}
+		if same {
+			return d, nil
+		}
+	}
+	c.Drive = drive
+	if err := ee.SetConfig(c); err != nil {
+		return d, err
+	}
+	if err := d.WriteEEPROM(&ee); err != nil {
+		return d, err
+	}
+	return cyclePort(d, g)
+}
+
+//
+
+// cycleDelay is the time to wait for the device to re-enumerate after the USB
+// port was cycled, and cycleRetry the number of times to wait.
+var (
+	cycleDelay = 500 * time.Millisecond
+	cycleRetry = 10
+)
+
+// cyclePort cycles the USB port of d, whose EEPROM was just programmed, and
+// returns the device it re-enumerated as.
+func cyclePort(d Dev, g *generic) (Dev, error) {
+	c, ok := g.h.h.(portCycler)
+	if !ok {
+		return d, errors.New("d2xx: EEPROM programmed but the USB port can't be cycled; replug the device for the new configuration to take effect")
+	}
+	if e := c.CyclePort(); e != 0 {
+		return d, toErr("CyclePort", e)
+	}
+	return drv.reenumerate(d, g)
+}
+
+// reenumerate replaces d, whose USB port was cycled, with the device it
+// re-enumerated as.
+func (d *driver) reenumerate(old Dev, g *generic) (Dev, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_ = g.h.Close()
+	pos := -1
+	for i, dev := range d.all {
+		if dev == old {
+			pos = i
+			break
+		}
+	}
+	if pos == -1 {
+		return nil, errors.New("d2xx: " + old.String() + " is not enumerated")
+	}
+	multi := len(d.all) > 1
+	unregisterDev(old, multi)
+	// The device keeps its channel.
+	channels := map[DevType]int{g.h.t: devChannel(old)}
+	var err error
+	for i := 0; i < cycleRetry; i++ {
+		// The device first disappears then comes back.
+		time.Sleep(cycleDelay)
+		var num int
+		if num, err = d.numDevices(); err == nil && num > g.index {
+			var dev Dev
+			if dev, err = open(d.d2xxOpen, g.index, channels); err == nil {
+				d.all[pos] = dev
+				return dev, registerDev(dev, multi)
+			}
+		}
+	}
+	if err == nil {
+		err = errors.New("d2xx: " + old.String() + " didn't re-enumerate")
+	}
+	d.all[pos] = &broken{index: g.index, err: err, name: "broken#" + strconv.Itoa(g.index) + ": " + err.Error()}
+	return nil, err
+}
+
+// devChannel returns the channel of d, starting at 0. It is 0 for the single
+// channel devices.
+func devChannel(d Dev) int {
+	switch t := d.(type) {
+	case *FT2232H:
+		return int(t.Channel - 'A')
+	case *FT4232H:
+		return int(t.Channel - 'A')
+	case *FT4232HSerial:
+		return int(t.Channel - 'A')
+	default:
+		return 0
+	}
+}
+
+// devGeneric returns the generic device embedded in d, if any.
+func devGeneric(d Dev) *generic {
+	switch t := d.(type) {
+	case *generic:
+		return t
+	case *FT232H:
+		return &t.generic
+	case *FT2232H:
+		return &t.generic
+	case *FT4232H:
+		return &t.generic
+	case *FT4232HSerial:
+		return &t.generic
+	case *FT232R:
+		return &t.generic
+	case *FTX:
+		return &t.generic
+	default:
+		return nil
+	}
+}
+
+// unregisterDev reverts registerDev.
+func unregisterDev(d Dev, multi bool) {
+	name := d.String()
+	for _, p := range d.Header() {
+		n := p.Name()
+		_ = gpioreg.Unregister(n)
+		if !multi {
+			// Only remove the alias if it points to this device, as a device
+			// plugged in later doesn't register aliases.
+			if a := n[len(name)+1:]; gpioreg.ByName(a) == p {
+				_ = gpioreg.Unregister(a)
+			}
+		}
+	}
+	_ = pinreg.Unregister(name)
+	_ = i2creg.Unregister(name)
+	_ = spireg.Unregister(name)
+	_ = onewirereg.Unregister(name)
+	_ = uartreg.Unregister(name)
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"hash/crc32"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestEEPROM_Config(t *testing.T) {
+	raw := make([]byte, 44)
+	ee := EEPROM{Raw: raw, Manufacturer: "Adafruit", Desc: "FT232H Breakout", Serial: "FT1"}
+	h := ee.AsHeader()
+	h.DeviceType = DevTypeFT232H
+	h.VendorID = 0x0403
+	h.ProductID = 0x6014
+	h.MaxPower = 100
+	x := ee.AsFT232H()
+	x.Defaults()
+	x.ADSchmittInput = 1
+	c, err := ee.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Manufacturer != "Adafruit" || c.MaxPower != 100*physic.MilliAmpere || c.VCP {
+		t.Fatalf("%+v", c)
+	}
+	if len(c.CBus) != 10 || FT232hCBusMux(c.CBus[8]) != FT232hCBusDrive1 {
+		t.Fatalf("%v", c.CBus)
+	}
+	want := []EEPROMDrive{{Current: 4 * physic.MilliAmpere, Schmitt: true}, {Current: 4 * physic.MilliAmpere}}
+	if len(c.Drive) != 2 || c.Drive[0] != want[0] || c.Drive[1] != want[1] {
+		t.Fatalf("%+v", c.Drive)
+	}
+
+	// Encoding back is a no-op.
+	if err := ee.SetConfig(c); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(ee.Raw, raw) {
+		t.Fatalf("%#x", ee.Raw)
+	}
+
+	c.Desc = "JTAG probe"
+	c.VCP = true
+	c.CBus[5] = uint8(FT232hCBusIOMode)
+	c.Drive[1].Current = 16 * physic.MilliAmpere
+	c.Drive[1].SlowSlew = true
+	if err := ee.SetConfig(c); err != nil {
+		t.Fatal(err)
+	}
+	x = ee.AsFT232H()
+	if ee.Desc != "JTAG probe" || x.DriverType != 1 || x.Cbus5 != FT232hCBusIOMode || x.ACDriveCurrent != 16 || x.ACSlowSlew != 1 {
+		t.Fatalf("%+v %+v", ee, x)
+	}
+}
+
+func TestEEPROM_Config_FTX(t *testing.T) {
+	ee := EEPROM{Raw: make([]byte, 56)}
+	ee.AsHeader().DeviceType = DevTypeFTXSeries
+	ee.AsFTX().Cbus6 = FTxCBusKeepAwake
+	c, err := ee.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.CBus) != 7 || FTxCBusMux(c.CBus[6]) != FTxCBusKeepAwake || len(c.Drive) != 2 {
+		t.Fatalf("%+v", c)
+	}
+}
+
+func TestEEPROM_SetConfig_err(t *testing.T) {
+	if _, err := (&EEPROM{}).Config(); err == nil {
+		t.Fatal("too short")
+	}
+	if err := (&EEPROM{}).SetConfig(&EEPROMConfig{}); err == nil {
+		t.Fatal("too short")
+	}
+	ee := EEPROM{Raw: make([]byte, 32)}
+	ee.AsHeader().DeviceType = DevTypeFT232R
+	ee.AsHeader().VendorID = 0x0403
+	c, err := ee.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []func(c *EEPROMConfig){
+		func(c *EEPROMConfig) { c.VendorID = 1 },
+		func(c *EEPROMConfig) { c.MaxPower = physic.Ampere },
+		func(c *EEPROMConfig) { c.Desc = string(make([]byte, 41)) },
+		func(c *EEPROMConfig) { c.CBus = c.CBus[:4] },
+		func(c *EEPROMConfig) { c.Drive = []EEPROMDrive{{Current: 4 * physic.MilliAmpere}} },
+	}
+	for i, f := range data {
+		d := *c
+		f(&d)
+		if err := ee.SetConfig(&d); err == nil {
+			t.Fatalf("#%d: expected error", i)
+		}
+	}
+	// Invalid drive current.
+	ee = EEPROM{Raw: make([]byte, 40)}
+	ee.AsHeader().DeviceType = DevTypeFT2232H
+	if c, err = ee.Config(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ee.SetConfig(c); err == nil {
+		t.Fatal("0mA is invalid")
+	}
+}
+
+func TestEEPROM_program(t *testing.T) {
+	raw := make([]byte, 32)
+	h := &recordHandle{fakeHandle: fakeHandle{DevType: uint32(DevTypeFT232R), Vid: 0x0403, Pid: 0x6001, E: d2xxEEPROM{Raw: raw}}}
+	f := &FT232R{generic: generic{h: &handle{h: h, t: DevTypeFT232R, venID: 0x0403, devID: 0x6001}, name: "ft232r"}}
+	hdr := (&EEPROM{Raw: raw}).AsHeader()
+	hdr.DeviceType = DevTypeFT232R
+	hdr.VendorID = 0x0403
+	hdr.ProductID = 0x6001
+
+	var ee EEPROM
+	if err := f.EEPROM(&ee); err != nil {
+		t.Fatal(err)
+	}
+	c, err := ee.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Serial = "A1"
+	c.SerialEnable = true
+	c.CBus[0] = uint8(FT232rCBusIOMode)
+	if err := ee.SetConfig(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WriteEEPROM(&ee); err != nil {
+		t.Fatal(err)
+	}
+	if h.E.Serial != "A1" || h.E.Raw[0x08] != 1 || FT232rCBusMux(h.E.Raw[0x1A]) != FT232rCBusIOMode {
+		t.Fatalf("%+v", h.E)
+	}
+}
+
+func TestEEPROMTemplate_Apply(t *testing.T) {
+	ee := EEPROM{Raw: make([]byte, 44)}
+	ee.AsHeader().DeviceType = DevTypeFT232H
+	ee.AsFT232H().Defaults()
+	ee.AsFT232H().DriverType = 1
+	if err := TemplateFT232HI2C.Apply(&ee); err != nil {
+		t.Fatal(err)
+	}
+	if x := ee.AsFT232H(); x.DriverType != 0 || x.ADSchmittInput != 1 || x.ADDriveCurrent != 8 || x.ACDriveCurrent != 4 {
+		t.Fatalf("%+v", x)
+	}
+	if err := TemplateFT232RGPIO.Apply(&ee); err == nil || err.Error() != "ftdi: template \"FT232R with C0~C3 as GPIOs\" is for FT232R, got FT232H" {
+		t.Fatal(err)
+	}
+}
+
+func TestProgramTemplate(t *testing.T) {
+	defer reset(t)
+	defer func(d time.Duration) { cycleDelay = d }(cycleDelay)
+	cycleDelay = 0
+	raw := make([]byte, 32)
+	hdr := (&EEPROM{Raw: raw}).AsHeader()
+	hdr.DeviceType = DevTypeFT232R
+	hdr.VendorID = 0x0403
+	hdr.ProductID = 0x6001
+	h := &cycleHandle{recordHandle: recordHandle{fakeHandle: fakeHandle{DevType: uint32(DevTypeFT232R), Vid: 0x0403, Pid: 0x6001, E: d2xxEEPROM{Raw: raw}}}}
+	h.Data = [][]byte{{}, {0}}
+	// The second device, so its name doesn't clash with the other tests.
+	drv.numDevices = func() (int, error) {
+		return 2, nil
+	}
+	drv.d2xxOpen = func(i int) (d2xxHandle, d2xxErr) {
+		if i == 0 {
+			return nil, 1
+		}
+		return h, 0
+	}
+	if b, _ := drv.Init(); !b {
+		t.Fatal("Init() = false")
+	}
+	old := drv.all[1]
+	if err := old.(*FT232R).C0.Out(gpio.High); err == nil {
+		t.Fatal("C0 is not in I/O mode")
+	}
+	d, err := ProgramTemplate(old, TemplateFT232RGPIO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d == old || drv.all[1] != d || h.cycled != 1 {
+		t.Fatal("expected re-enumeration")
+	}
+	if FT232rCBusMux(h.E.Raw[0x1A]) != FT232rCBusIOMode || h.E.Raw[0x1E] != 0 {
+		t.Fatalf("%#x", h.E.Raw)
+	}
+	if p := gpioreg.ByName("FT232R(1).C0"); p != d.(*FT232R).C0 {
+		t.Fatal(p)
+	}
+	if err := d.(*FT232R).C0.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ProgramTemplate(drv.all[0], TemplateFT232RGPIO); err == nil {
+		t.Fatal("broken device")
+	}
+	if _, err := ProgramTemplate(d, TemplateFTXGPIO); err == nil {
+		t.Fatal("wrong device type")
+	}
+}
+
+func TestProgramTemplate_noCycle(t *testing.T) {
+	raw := make([]byte, 44)
+	(&EEPROM{Raw: raw}).AsHeader().DeviceType = DevTypeFT232H
+	h := &recordHandle{fakeHandle: fakeHandle{E: d2xxEEPROM{Raw: raw}}}
+	f := &FT232H{generic: generic{h: &handle{h: h, t: DevTypeFT232H}, name: "ft232h"}}
+	d, err := ProgramTemplate(f, TemplateFT232HMPSSE)
+	if d != f || err == nil {
+		t.Fatal("expected error asking to replug")
+	}
+	if x := (&EEPROM{Raw: h.E.Raw}).AsFT232H(); x.ADDriveCurrent != 16 || x.ACDriveCurrent != 4 {
+		t.Fatalf("%+v", x)
+	}
+}
+
+func TestProgramDrive(t *testing.T) {
+	raw := make([]byte, 44)
+	(&EEPROM{Raw: raw}).AsHeader().DeviceType = DevTypeFT232H
+	h := &recordHandle{fakeHandle: fakeHandle{E: d2xxEEPROM{Raw: raw}}}
+	f := &FT232H{generic: generic{h: &handle{h: h, t: DevTypeFT232H}, name: "ft232h"}}
+	ad := EEPROMDrive{Current: 16 * physic.MilliAmpere, SlowSlew: true}
+	ac := EEPROMDrive{Current: 4 * physic.MilliAmpere, Schmitt: true}
+	if _, err := ProgramDrive(f, ad); err == nil {
+		t.Fatal("expected 2 groups")
+	}
+	if _, err := ProgramDrive(f, ad, EEPROMDrive{Current: 5 * physic.MilliAmpere}); err == nil {
+		t.Fatal("invalid current")
+	}
+	if d, err := ProgramDrive(f, ad, ac); d != f || err == nil {
+		t.Fatal("expected error asking to replug")
+	}
+	x := (&EEPROM{Raw: h.E.Raw}).AsFT232H()
+	if x.ADDriveCurrent != 16 || x.ADSlowSlew != 1 || x.ADSchmittInput != 0 || x.ACDriveCurrent != 4 || x.ACSchmittInput != 1 {
+		t.Fatalf("%+v", x)
+	}
+	// Unchanged, so not written.
+	if d, err := ProgramDrive(f, ad, ac); d != f || err != nil {
+		t.Fatal(err)
+	}
+}
+
+//
+
+// cycleHandle records the cycles of the USB port.
+type cycleHandle struct {
+	recordHandle
+	cycled int
+}
+
+func (c *cycleHandle) CyclePort() d2xxErr {
+	c.cycled++
+	// Replies read when the device is opened again.
+	c.Data = [][]byte{{}, {0}}
+	return 0
+}
+
+func TestEEPROM_DumpRestore(t *testing.T) {
+	raw := make([]byte, 44)
+	hdr := (&EEPROM{Raw: raw}).AsHeader()
+	hdr.DeviceType = DevTypeFT232H
+	hdr.VendorID = 0x0403
+	hdr.ProductID = 0x6014
+	src := &recordHandle{fakeHandle: fakeHandle{E: d2xxEEPROM{Raw: raw, Manufacturer: "FTDI", Desc: "Probe", Serial: "FT1"}, UA: []byte{1, 2, 3}}}
+	f := &FT232H{generic: generic{h: &handle{h: src, t: DevTypeFT232H, venID: 0x0403, devID: 0x6014}, name: "ft232h"}}
+	blob, b, err := DumpEEPROM(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.DevType != DevTypeFT232H || b.EEPROM.Serial != "FT1" || !bytes.Equal(b.UserArea, []byte{1, 2, 3}) || b.Config == nil || b.Config.Desc != "Probe" {
+		t.Fatalf("%+v", b)
+	}
+	var b2 EEPROMBackup
+	if err := b2.UnmarshalBinary(blob); err != nil {
+		t.Fatal(err)
+	}
+	if b2.EEPROM.Desc != "Probe" || !bytes.Equal(b2.EEPROM.Raw, raw) || b2.Config == nil || b2.Config.ProductID != 0x6014 {
+		t.Fatalf("%+v", b2)
+	}
+
+	// Restore on a blank device. It can't cycle its port.
+	dst := &recordHandle{fakeHandle: fakeHandle{UA: make([]byte, 8)}}
+	f2 := &FT232H{generic: generic{h: &handle{h: dst, t: DevTypeFT232H, venID: 0x0403, devID: 0x6014}, name: "ft232h"}}
+	if d, err := RestoreEEPROM(f2, blob); d != f2 || err == nil {
+		t.Fatal("expected error asking to replug")
+	}
+	if dst.E.Serial != "FT1" || !bytes.Equal(dst.E.Raw, raw) || !bytes.Equal(dst.UA, []byte{1, 2, 3, 0, 0, 0, 0, 0}) {
+		t.Fatalf("%+v %#x", dst.E, dst.UA)
+	}
+
+	// Wrong device type.
+	r := &FT232R{generic: generic{h: &handle{h: dst, t: DevTypeFT232R}, name: "ft232r"}}
+	if _, err := RestoreEEPROM(r, blob); err == nil || err.Error() != "ftdi: backup of a FT232H can't be restored on a FT232R" {
+		t.Fatal(err)
+	}
+}
+
+func TestEEPROMBackup_UnmarshalBinary_err(t *testing.T) {
+	b := EEPROMBackup{DevType: DevTypeFT232R, EEPROM: EEPROM{Raw: make([]byte, 32), Serial: "A"}}
+	blob, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		blob []byte
+		err  string
+	}{
+		{nil, "ftdi: not an EEPROM backup"},
+		{append([]byte("FTEE\x02"), blob[5:]...), "ftdi: unsupported EEPROM backup version 2"},
+		{append(append([]byte{}, blob[:10]...), 0xFF), "ftdi: EEPROM backup is corrupted"},
+	}
+	for i, line := range data {
+		var b2 EEPROMBackup
+		if err := b2.UnmarshalBinary(line.blob); err == nil || err.Error() != line.err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+	}
+	// Truncated, with a valid checksum.
+	short := blob[:20]
+	short = appendUint32(short[:len(short):len(short)], crc32.ChecksumIEEE(short))
+	var b2 EEPROMBackup
+	if err := b2.UnmarshalBinary(short); err == nil || err.Error() != "ftdi: EEPROM backup is truncated" {
+		t.Fatal(err)
+	}
+}
@@ -8,8 +8,8 @@ import (
 	"fmt"
 	"log"
 
-	"periph.io/x/host/v3"
-	"periph.io/x/host/v3/ftdi"
+	"github.com/s-mobi01/host"
+	"github.com/s-mobi01/host/ftdi"
 )
 
 func Example() {
@@ -0,0 +1,128 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+// fakeHandle implements a fake d2xxHandle.
+type fakeHandle struct {
+	DevType uint32
+	Vid     uint16
+	Pid     uint16
+	Data    [][]byte
+	UA      []byte
+	E       d2xxEEPROM
+}
+
+func (f *fakeHandle) Close() d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) ResetDevice() d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) GetDeviceInfo() (uint32, uint16, uint16, d2xxErr) {
+	return f.DevType, f.Vid, f.Pid, 0
+}
+
+func (f *fakeHandle) EEPROMRead(devType uint32, e *d2xxEEPROM) d2xxErr {
+	*e = f.E
+	return 0
+}
+
+func (f *fakeHandle) EEPROMProgram(e *d2xxEEPROM) d2xxErr {
+	f.E = *e
+	return 0
+}
+
+func (f *fakeHandle) EraseEE() d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) WriteEE(offset uint8, value uint16) d2xxErr {
+	return 1
+}
+
+func (f *fakeHandle) EEUASize() (int, d2xxErr) {
+	return len(f.UA), 0
+}
+
+func (f *fakeHandle) EEUARead(ua []byte) d2xxErr {
+	copy(ua, f.UA)
+	return 0
+}
+
+func (f *fakeHandle) EEUAWrite(ua []byte) d2xxErr {
+	f.UA = make([]byte, len(ua))
+	copy(f.UA, ua)
+	return 0
+}
+
+func (f *fakeHandle) SetChars(eventChar byte, eventEn bool, errorChar byte, errorEn bool) d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) SetUSBParameters(in, out int) d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) SetFlowControl() d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) SetTimeouts(readMS, writeMS int) d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) SetLatencyTimer(delayMS uint8) d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) SetBaudRate(hz uint32) d2xxErr {
+	return 0
+}
+
+func (f *fakeHandle) GetQueueStatus() (uint32, d2xxErr) {
+	if len(f.Data) == 0 {
+		return 0, 0
+	}
+	// This is to work around flushPending().
+	l := len(f.Data[0])
+	if l == 0 {
+		f.Data = f.Data[1:]
+	}
+	return uint32(l), 0
+}
+
+func (f *fakeHandle) Read(b []byte) (int, d2xxErr) {
+	if len(f.Data) == 0 {
+		return 0, 0
+	}
+	l := len(b)
+	if j := len(f.Data[0]); j < l {
+		l = j
+	}
+	if l == 0 {
+		f.Data = f.Data[1:]
+		return 0, 0
+	}
+	copy(b, f.Data[0])
+	f.Data[0] = f.Data[0][l:]
+	if len(f.Data[0]) == 0 {
+		f.Data = f.Data[1:]
+	}
+	return l, 0
+}
+
+func (f *fakeHandle) Write(b []byte) (int, d2xxErr) {
+	return 0, 0
+}
+
+func (f *fakeHandle) GetBitMode() (byte, d2xxErr) {
+	return 0, 0
+}
+
+func (f *fakeHandle) SetBitMode(mask, mode byte) d2xxErr {
+	return 0
+}
@@ -12,8 +12,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/s-mobi01/host/ftdi"
 	"periph.io/x/conn/v3/gpio"
-	"periph.io/x/host/v3/ftdi"
 )
 
 // SmokeTest is imported by periph-smoketest.
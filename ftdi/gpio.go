@@ -131,6 +131,8 @@ func (s *dbusPinSync) Hysteresis() bool {
 //
 // More details at:
 // http://www.ftdichip.com/Support/Knowledgebase/index.html?cbusbitbangmode.htm
+//
+// n is the index on the CBus, starting at 0.
 type cBusGPIO interface {
 	cBusGPIOFunc(n int) string
 	cBusGPIOIn(n int) error
@@ -143,7 +145,7 @@ type cBusGPIO interface {
 // It is immutable and stateless.
 type cbusPin struct {
 	n   string
-	num int
+	num int // pin number in the header; C0 is 8
 	p   gpio.Pull
 	bus cBusGPIO
 }
@@ -170,7 +172,7 @@ func (c *cbusPin) Number() int {
 
 // Function implements pin.Pin.
 func (c *cbusPin) Function() string {
-	return c.bus.cBusGPIOFunc(c.num)
+	return c.bus.cBusGPIOFunc(c.num - 8)
 }
 
 // In implements gpio.PinIn.
@@ -183,12 +185,12 @@ func (c *cbusPin) In(pull gpio.Pull, e gpio.Edge) error {
 		// EEPROM has a PullDownEnable flag.
 		return errors.New("d2xx: pull is not supported")
 	}
-	return c.bus.cBusGPIOIn(c.num)
+	return c.bus.cBusGPIOIn(c.num - 8)
 }
 
 // Read implements gpio.PinIn.
 func (c *cbusPin) Read() gpio.Level {
-	return c.bus.cBusGPIORead(c.num)
+	return c.bus.cBusGPIORead(c.num - 8)
 }
 
 // WaitForEdge implements gpio.PinIn.
@@ -211,7 +213,7 @@ func (c *cbusPin) Pull() gpio.Pull {
 
 // Out implements gpio.PinOut.
 func (c *cbusPin) Out(l gpio.Level) error {
-	return c.bus.cBusGPIOOut(c.num, l)
+	return c.bus.cBusGPIOOut(c.num-8, l)
 }
 
 // PWM implements gpio.PinOut.
@@ -0,0 +1,411 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiostream"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestFT232R_DBus(t *testing.T) {
+	h := &recordHandle{fakeHandle: fakeHandle{DevType: uint32(DevTypeFT232R)}, bits: 0x02}
+	f, err := newFT232R(generic{h: &handle{h: h, t: DevTypeFT232R}, name: "FT232R"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0 || bitMode(h.mode) != bitModeAsyncBitbang {
+		t.Fatalf("mask %#x mode %#x", h.mask, h.mode)
+	}
+
+	// Single pins.
+	if err := f.DTR.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.TX.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.DTR.Out(gpio.Low); err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0x11 || !bytes.Equal(h.w, []byte{0x12, 0x13, 0x03}) {
+		t.Fatalf("mask %#x w %#x", h.mask, h.w)
+	}
+	if s := f.TX.Function(); s != "Out/High" {
+		t.Fatal(s)
+	}
+	if err := f.TX.In(gpio.PullUp, gpio.NoEdge); err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0x10 {
+		t.Fatalf("mask %#x", h.mask)
+	}
+	h.bits = 0x01
+	if l := f.TX.Read(); l != gpio.High {
+		t.Fatal(l)
+	}
+	if l := f.RX.Read(); l != gpio.Low {
+		t.Fatal(l)
+	}
+
+	// All at once.
+	h.w = nil
+	if err := f.DBus(0xF0, 0xA5); err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0xF0 || !bytes.Equal(h.w, []byte{0xA5}) {
+		t.Fatalf("mask %#x w %#x", h.mask, h.w)
+	}
+	h.bits = 0x5A
+	if v, err := f.DBusRead(); v != 0x5A || err != nil {
+		t.Fatal(v, err)
+	}
+}
+
+func TestFT232R_CBus(t *testing.T) {
+	h := &recordHandle{fakeHandle: fakeHandle{DevType: uint32(DevTypeFT232R)}}
+	f, err := newFT232R(generic{h: &handle{h: h, t: DevTypeFT232R}, name: "FT232R"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.DBus(0x01, 0x01); err != nil {
+		t.Fatal(err)
+	}
+	// C0~C3 switch to CBus bit-bang mode.
+	if err := f.C1.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0x22 || bitMode(h.mode) != bitModeCbusBitbang {
+		t.Fatalf("mask %#x mode %#x", h.mask, h.mode)
+	}
+	// The direction is kept when reading.
+	h.bits = 0x0A
+	if l := f.C3.Read(); l != gpio.High {
+		t.Fatal(l)
+	}
+	if err := f.C1.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0x22 || bitMode(h.mode) != bitModeCbusBitbang {
+		t.Fatalf("mask %#x mode %#x", h.mask, h.mode)
+	}
+	// D0~D7 are restored on their next use.
+	h.w = nil
+	if l := f.D3.Read(); l != gpio.High {
+		t.Fatal(l)
+	}
+	if h.mask != 0x01 || bitMode(h.mode) != bitModeAsyncBitbang || !bytes.Equal(h.w, []byte{0x01}) {
+		t.Fatalf("mask %#x mode %#x w %#x", h.mask, h.mode, h.w)
+	}
+}
+
+func TestFT232R_CBusEEPROM(t *testing.T) {
+	raw := make([]byte, 32)
+	raw[0x1A] = byte(FT232rCBusIOMode)
+	raw[0x1B] = byte(FT232rCBusTxLED)
+	raw[0x1C] = byte(FT232rCBusIOMode)
+	raw[0x1D] = byte(FT232rCBusIOMode)
+	h := &recordHandle{fakeHandle: fakeHandle{DevType: uint32(DevTypeFT232R), E: d2xxEEPROM{Raw: raw}}}
+	f, err := newFT232R(generic{h: &handle{h: h, t: DevTypeFT232R}, name: "FT232R"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := f.C1.Function(); s != "FT232rCBusTxLED" {
+		t.Fatal(s)
+	}
+	if err := f.C1.Out(gpio.High); err == nil || err.Error() != "d2xx: C1 is set to FT232rCBusTxLED in the EEPROM; program it to I/O mode to use it as a GPIO" {
+		t.Fatal(err)
+	}
+	if err := f.C1.In(gpio.PullUp, gpio.NoEdge); err == nil {
+		t.Fatal("not in I/O mode")
+	}
+	if err := f.C0.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFT232H_WaitForEdge(t *testing.T) {
+	h := &levelHandle{recordHandle: recordHandle{replies: mpsseVerifyReplies()}}
+	f, err := newFT232H(generic{h: &handle{h: h}, name: "ft232h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetEdgeSampleRate(0); err == nil {
+		t.Fatal("invalid rate")
+	}
+	if err := f.SetEdgeSampleRate(10 * physic.KiloHertz); err != nil {
+		t.Fatal(err)
+	}
+	if f.D5.WaitForEdge(-1) {
+		t.Fatal("edge detection not enabled")
+	}
+	if err := f.D5.In(gpio.PullUp, gpio.RisingEdge); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.C2.In(gpio.PullUp, gpio.BothEdges); err != nil {
+		t.Fatal(err)
+	}
+	waitArmed(t, f)
+
+	h.set(0x20, 0)
+	if !f.D5.WaitForEdge(time.Second) {
+		t.Fatal("expected rising edge")
+	}
+	h.set(0, 0x04)
+	if !f.C2.WaitForEdge(time.Second) {
+		t.Fatal("expected rising edge")
+	}
+	h.set(0, 0)
+	if !f.C2.WaitForEdge(time.Second) {
+		t.Fatal("expected falling edge")
+	}
+	if f.D5.WaitForEdge(10 * time.Millisecond) {
+		t.Fatal("falling edge not enabled")
+	}
+
+	// Disabling edge detection on all the pins stops the polling.
+	if err := f.D5.In(gpio.PullUp, gpio.NoEdge); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.C2.Out(gpio.Low); err != nil {
+		t.Fatal(err)
+	}
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		f.mu.Lock()
+		polling := f.edgePolling
+		f.mu.Unlock()
+		if !polling {
+			break
+		}
+		if time.Since(start) > time.Second {
+			t.Fatal("polling didn't stop")
+		}
+	}
+	if f.D5.WaitForEdge(0) {
+		t.Fatal("edge detection disabled")
+	}
+}
+
+func TestFT232H_Group(t *testing.T) {
+	h := &recordHandle{replies: mpsseVerifyReplies()}
+	f, err := newFT232H(generic{h: &handle{h: h}, name: "ft232h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := f.DGroup()
+	if s := d.String(); s != "ft232h.D0~7" {
+		t.Fatal(s)
+	}
+	if p := d.ByOffset(3); p != f.D3 {
+		t.Fatal(p)
+	}
+	if d.ByOffset(8) != nil || len(d.Pins()) != 8 {
+		t.Fatal("expected 8 pins")
+	}
+	h.w = nil
+	if err := d.Out(0xA5, 0xF0); err != nil {
+		t.Fatal(err)
+	}
+	// Only the pins in the mask are changed, in a single command.
+	if err := f.D0.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Out(0x00, 0x30); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.In(0x01); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.CGroup().Out(0xFF, 0x81); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		gpioSetD, 0xA0, 0xF0,
+		gpioSetD, 0xA1, 0xF1,
+		gpioSetD, 0x81, 0xF1,
+		gpioSetD, 0x81, 0xF0,
+		gpioSetC, 0x81, 0x81,
+	}
+	if !bytes.Equal(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+	if s := f.D5.Function(); s != "Out/Low" {
+		t.Fatal(s)
+	}
+	h.w = nil
+	h.replies = [][]byte{{0x5A}}
+	if v, err := d.Read(0x0F); v != 0x0A || err != nil {
+		t.Fatal(v, err)
+	}
+	if !bytes.Equal(h.w, []byte{gpioReadD, flush}) {
+		t.Fatalf("%#x", h.w)
+	}
+}
+
+func TestFT232H_OpenDrain(t *testing.T) {
+	h := &recordHandle{replies: mpsseVerifyReplies()}
+	f, err := newFT232H(generic{h: &handle{h: h, t: DevTypeFT232H}, name: "ft232h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.w = nil
+	if err := f.D4.(OpenDrainer).SetOpenDrain(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.C1.(OpenDrainer).SetOpenDrain(true); err != nil {
+		t.Fatal(err)
+	}
+	if !f.D4.(OpenDrainer).OpenDrain() || f.D5.(OpenDrainer).OpenDrain() {
+		t.Fatal("unexpected open drain state")
+	}
+	want := []byte{dataTristate, 0x10, 0x00, dataTristate, 0x10, 0x02}
+	if !bytes.Equal(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+	// I²C keeps the open drain pins and the other way around.
+	h.w = nil
+	b, err := f.I2C(gpio.Float)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(h.w, []byte{dataTristate, 0x17, 0x02}) {
+		t.Fatalf("%#x", h.w)
+	}
+	h.w = nil
+	if err := f.D4.(OpenDrainer).SetOpenDrain(false); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(h.w, []byte{dataTristate, 0x07, 0x02}) {
+		t.Fatalf("%#x", h.w)
+	}
+	h.w = nil
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(h.w, []byte{dataTristate, 0x00, 0x02}) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	h2 := &recordHandle{replies: mpsseVerifyReplies()}
+	f2, err := newFT2232H(generic{h: &handle{h: h2, t: DevTypeFT2232H}, name: "ft2232h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f2.D4.(OpenDrainer).SetOpenDrain(true); err == nil {
+		t.Fatal("FT232H only")
+	}
+}
+
+func TestFT232H_StreamOut(t *testing.T) {
+	h := &recordHandle{replies: mpsseVerifyReplies()}
+	f, err := newFT232H(generic{h: &handle{h: h, t: DevTypeFT232H}, name: "ft232h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// D1 shifts the bits out as MPSSE data.
+	h.w = nil
+	h.replies = [][]byte{nil, {0x00}}
+	d1 := f.D1.(gpiostream.PinOut)
+	if err := d1.StreamOut(&gpiostream.BitStream{Bits: []byte{0xA5}, Freq: physic.MegaHertz}); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		clock30MHz, clockSetDivisor, 29, 0,
+		clock2Phase, gpioSetD, 0x00, 0x02,
+		dataOut, 0, 0, 0xA5,
+		gpioSetD, 0x02, 0x02,
+		gpioReadD, flush,
+	}
+	if !bytes.Equal(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	// The other pins are paced by clocking without data; 3MHz is 10 cycles.
+	h.w = nil
+	h.replies = [][]byte{nil, {0x00}}
+	c2 := f.C2.(gpiostream.PinOut)
+	if err := c2.StreamOut(&gpiostream.BitStream{Bits: []byte{0x01}, Freq: 3 * physic.MegaHertz, LSBF: true}); err != nil {
+		t.Fatal(err)
+	}
+	want = []byte{clock30MHz, clockSetDivisor, 0, 0, clock2Phase}
+	for i := 0; i < 8; i++ {
+		v := byte(0)
+		if i == 0 {
+			v = 0x04
+		}
+		want = append(want, gpioSetC, v, 0x04, clockOnLong, 0, 0, clockOnShort, 1)
+	}
+	want = append(want, gpioReadC, flush)
+	if !bytes.Equal(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+	if s := f.C2.Function(); s != "Out/Low" {
+		t.Fatal(s)
+	}
+
+	// Invalid streams.
+	if err := c2.StreamOut(&gpiostream.EdgeStream{}); err == nil {
+		t.Fatal("only BitStream")
+	}
+	if err := c2.StreamOut(&gpiostream.BitStream{Bits: []byte{0x01}}); err == nil {
+		t.Fatal("Freq is required")
+	}
+	if err := f.D0.(gpiostream.PinOut).StreamOut(&gpiostream.BitStream{Bits: []byte{0x01}, Freq: physic.KiloHertz}); err == nil {
+		t.Fatal("D0 outputs the clock")
+	}
+	if err := f.D0.Out(gpio.Low); err != nil {
+		t.Fatal(err)
+	}
+	if err := c2.StreamOut(&gpiostream.BitStream{Bits: []byte{0x01}, Freq: physic.KiloHertz}); err == nil {
+		t.Fatal("D0 must be an input")
+	}
+}
+
+//
+
+// levelHandle replies to the MPSSE commands reading both buses with the
+// levels set via set.
+type levelHandle struct {
+	recordHandle
+	mu   sync.Mutex
+	d, c byte
+}
+
+func (l *levelHandle) Write(b []byte) (int, d2xxErr) {
+	if bytes.Equal(b, []byte{gpioReadD, gpioReadC, flush}) {
+		l.mu.Lock()
+		l.Data = append(l.Data, []byte{l.d, l.c})
+		l.mu.Unlock()
+		return len(b), 0
+	}
+	return l.recordHandle.Write(b)
+}
+
+func (l *levelHandle) set(d, c byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.d = d
+	l.c = c
+}
+
+// waitArmed waits for the edge detection to have sampled the pins enabled.
+func waitArmed(t *testing.T, f *FT232H) {
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		f.mu.Lock()
+		armed := f.dbus.armed == f.dbus.rising|f.dbus.falling && f.cbus.armed == f.cbus.rising|f.cbus.falling
+		f.mu.Unlock()
+		if armed {
+			return
+		}
+		if time.Since(start) > time.Second {
+			t.Fatal("edge detection didn't start")
+		}
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"io"
 
 	"periph.io/x/conn/v3/physic"
-	"periph.io/x/d2xx"
 )
 
 //
@@ -42,22 +41,62 @@ const (
 	bitModeSyncFifo bitMode = 0x40
 )
 
+// Flow control modes for SetUARTFormat.
+const (
+	flowNone    uint16 = 0x0000
+	flowRTSCTS  uint16 = 0x0100
+	flowXOnXOff uint16 = 0x0400
+)
+
+// uartFramer is implemented by the d2xx handles that can change the UART
+// framing and the flow control mode.
+//
+// The D2XX backend doesn't expose FT_SetDataCharacteristics, and its
+// SetFlowControl() always selects RTS/CTS.
+type uartFramer interface {
+	SetDataCharacteristics(bits, stop, parity byte) d2xxErr
+	SetFlowControlMode(flow uint16, xon, xoff byte) d2xxErr
+}
+
+// portCycler is implemented by the d2xx handles that can cycle the USB port,
+// forcing the device to re-enumerate and reload its EEPROM.
+//
+// The D2XX backend doesn't expose FT_CyclePort.
+type portCycler interface {
+	CyclePort() d2xxErr
+}
+
+// d2xxUnavailable returns why the D2XX library can't be used, or nil.
+func d2xxUnavailable() error {
+	if !d2xxEnabled || !d2xxBuilt {
+		return ErrD2XXNotEnabled
+	}
+	if !d2xxAvailable() {
+		// On Windows, the DLL is loaded at runtime.
+		return toErr("D2XX", d2xxMissing)
+	}
+	return nil
+}
+
 // numDevices returns the number of detected devices.
 func numDevices() (int, error) {
-	num, e := d2xx.CreateDeviceInfoList()
+	if usbfsUsed() {
+		return usbfsNumDevices()
+	}
+	num, e := d2xxNumDevices()
 	if e != 0 {
 		return 0, toErr("GetNumDevices initialization failed", e)
 	}
 	return num, nil
 }
 
-func openHandle(opener func(i int) (d2xx.Handle, d2xx.Err), i int) (*handle, error) {
+func openHandle(opener func(i int) (d2xxHandle, d2xxErr), i int) (*handle, error) {
 	h, e := opener(i)
 	if e != 0 {
 		return nil, toErr("Open", e)
 	}
 	// For debugging:
-	// d := &handle{h: &d2xxtest.Log{H: h, Printf: log.Printf}}
+	// d := &handle{h: &logHandle{h: h}}
 	d := &handle{h: h}
 	t, vid, did, e := h.GetDeviceInfo()
 	if e != 0 {
@@ -70,6 +109,17 @@ func openHandle(opener func(i int) (d2xx.Handle, d2xx.Err), i int) (*handle, err
 	return d, nil
 }
 
+// channel returns the channel of a multi-channel device, starting at 0, when
+// the backend knows it.
+//
+// usbfs opens a channel as its USB interface. D2XX doesn't report it.
+func (h *handle) channel() (int, bool) {
+	if u, ok := h.h.(*usbfsHandle); ok {
+		return int(u.index) - 1, true
+	}
+	return 0, false
+}
+
 // handle is a thin wrapper around the low level d2xx device handle to make it
 // more go-idiomatic.
 //
@@ -81,11 +131,13 @@ type handle struct {
 	// Dev converts the int error type into Go native error and handles higher
 	// level functionality like reading and writing to the USB connection.
 	//
-	// The content of the struct is immutable after initialization.
-	h     d2xx.Handle
+	// The content of the struct is immutable after initialization, except usb
+	// which is set by SetUSBConfig.
+	h     d2xxHandle
 	t     DevType
 	venID uint16
 	devID uint16
+	usb   USBConfig
 }
 
 func (h *handle) Close() error {
@@ -100,13 +152,18 @@ func (h *handle) Close() error {
 // effort basis. On all devices, the GPIOs are still reset as inputs, since
 // there is no way to determine if each GPIO is an input or output.
 func (h *handle) Init() error {
-	// Driver: maximum packet size. Note that this clears any data in the buffer,
-	// so it is good to do it immediately after a reset. The 'out' parameter is
-	// ignored.
+	// Driver: maximum packet size and latency timer, as set with SetUSBConfig.
+	// Note that this clears any data in the buffer, so it is good to do it
+	// immediately after a reset.
+	//
 	// TODO(maruel): The FT232H doc claims a 512 byte packets support in hi-speed
 	// mode, which means that this would likely be better to use this value.
-	if e := h.h.SetUSBParameters(65536, 0); e != 0 {
-		return toErr("SetUSBParameters", e)
+	//
+	// The documentation recommends to use at least 2ms of latency as 1ms is the
+	// frame length. It doesn't make sense to me, as we should only want to read
+	// what was already pushed.
+	if err := h.setUSBConfig(h.usb); err != nil {
+		return err
 	}
 	// Driver: Set I/O timeouts to 15 sec. The reason is that we want the
 	// timeouts to be very visible, at least as the driver is being developed.
@@ -117,18 +174,6 @@ func (h *handle) Init() error {
 	if e := h.h.SetChars(0, false, 0, false); e != 0 {
 		return toErr("SetChars", e)
 	}
-	// TODO(maruel): Set latency timer at 255ms in MPSSE mode? The rationale is
-	// that for FT232H, the MPSSE command 'flush' should consistently used
-	// whenever needed and we want this to be apparent. Investigate the effect
-	// for FT232R so potentially move to InitNonMPSSE and InitMPSSE with
-	// different values.
-	//
-	// The documentation recommends to use at least 2ms as 1ms is the frame
-	// length. It doesn't make sense to me, as we should only want to read what
-	// was already pushed.
-	if e := h.h.SetLatencyTimer(1); e != 0 {
-		return toErr("SetLatencyTimer", e)
-	}
 	return nil
 }
 
@@ -272,8 +317,9 @@ func (h *handle) ReadEEPROM(ee *EEPROM) error {
 	} else if len(ee.Raw) > eepromSize {
 		ee.Raw = ee.Raw[:eepromSize]
 	}
-	ee2 := d2xx.EEPROM{Raw: ee.Raw}
+	ee2 := d2xxEEPROM{Raw: ee.Raw}
 	e := h.h.EEPROMRead(uint32(h.t), &ee2)
+	ee.Raw = ee2.Raw
 	ee.Manufacturer = ee2.Manufacturer
 	ee.ManufacturerID = ee2.ManufacturerID
 	ee.Desc = ee2.Desc
@@ -320,7 +366,7 @@ func (h *handle) WriteEEPROM(ee *EEPROM) error {
 			return errors.New("ftdi: unexpected DevID set while programming EEPROM")
 		}
 	}
-	ee2 := d2xx.EEPROM{
+	ee2 := d2xxEEPROM{
 		Raw:            ee.Raw,
 		Manufacturer:   ee.Manufacturer,
 		ManufacturerID: ee.ManufacturerID,
@@ -388,11 +434,37 @@ func (h *handle) SetBaudRate(f physic.Frequency) error {
 	return toErr("SetBaudRate", h.h.SetBaudRate(v))
 }
 
+// SetUARTFormat sets the UART framing and flow control.
+//
+// bits is 7 or 8, stop is 0 for 1 stop bit or 2 for 2 stop bits, parity is
+// 0 for none, then odd, even, mark and space. flow is one of the
+// FT_FLOW_XXX values.
+//
+// When the d2xx library can't change the framing, only 8N1 with RTS/CTS flow
+// control is supported, as this is what SetFlowControl() sets.
+func (h *handle) SetUARTFormat(bits, stop, parity byte, flow uint16, xon, xoff byte) error {
+	if u, ok := h.h.(uartFramer); ok {
+		if e := u.SetDataCharacteristics(bits, stop, parity); e != 0 {
+			return toErr("SetDataCharacteristics", e)
+		}
+		return toErr("SetFlowControl", u.SetFlowControlMode(flow, xon, xoff))
+	}
+	if bits != 8 || stop != 0 || parity != 0 || flow != flowRTSCTS {
+		return errors.New("ftdi: the d2xx library only supports 8N1 with RTS/CTS flow control")
+	}
+	return toErr("SetFlowControl", h.h.SetFlowControl())
+}
+
 //
 
-func toErr(s string, e d2xx.Err) error {
+func toErr(s string, e d2xxErr) error {
 	if e == 0 {
 		return nil
 	}
+	if e == 17 && (!d2xxEnabled || !d2xxBuilt) {
+		// FT_NOT_SUPPORTED from the usbfs backend, for an operation that D2XX
+		// would support.
+		return fmt.Errorf("ftdi: %s: %w", s, ErrD2XXNotEnabled)
+	}
 	return errors.New("ftdi: " + s + ": " + e.String())
 }
@@ -0,0 +1,182 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Event is a device plugged in or unplugged, as reported by a Watcher.
+type Event struct {
+	// Dev is the device. Once removed, it must not be used anymore.
+	Dev Dev
+	// Removed is true when the device was unplugged, false when it was plugged
+	// in.
+	Removed bool
+}
+
+func (e Event) String() string {
+	if e.Removed {
+		return e.Dev.String() + " removed"
+	}
+	return e.Dev.String() + " arrived"
+}
+
+// Watch returns a Watcher reporting the devices plugged in or unplugged.
+//
+// The USB bus is polled every second, as D2XX doesn't expose the OS
+// notifications. The devices plugged in are opened and registered like at
+// initialization, the GPIOs, I²C, SPI, 1-Wire and UART included, and listed
+// by All; their GPIOs don't get the short aliases like "D0". The devices
+// unplugged are unregistered and removed from All. A service can thus open
+// its bus by name again once the adapter was replugged.
+//
+// All the watchers share the polling, which only runs while at least one is
+// open. The driver must have been initialized, e.g. with host.Init().
+func Watch() (*Watcher, error) {
+	drv.mu.Lock()
+	defer drv.mu.Unlock()
+	if drv.d2xxOpen == nil {
+		return nil, errors.New("d2xx: driver not initialized")
+	}
+	w := &Watcher{c: make(chan Event, 16)}
+	drv.watchers = append(drv.watchers, w)
+	if len(drv.watchers) == 1 {
+		drv.hotplugDone = make(chan struct{})
+		drv.hotplugWG.Add(1)
+		go drv.pollHotplug(drv.hotplugDone)
+	}
+	return w, nil
+}
+
+// Watcher reports the devices plugged in or unplugged.
+type Watcher struct {
+	c    chan Event
+	once sync.Once
+}
+
+// Events returns the channel receiving the events. It is closed by Close.
+//
+// The events are dropped if the channel is not drained.
+func (w *Watcher) Events() <-chan Event {
+	return w.c
+}
+
+// Close stops reporting events and closes the channel.
+func (w *Watcher) Close() error {
+	w.once.Do(func() {
+		drv.mu.Lock()
+		for i, o := range drv.watchers {
+			if o == w {
+				copy(drv.watchers[i:], drv.watchers[i+1:])
+				drv.watchers = drv.watchers[:len(drv.watchers)-1]
+				break
+			}
+		}
+		var done chan struct{}
+		if len(drv.watchers) == 0 {
+			done = drv.hotplugDone
+			drv.hotplugDone = nil
+		}
+		// Closed with the lock held so pollHotplug doesn't send to it.
+		close(w.c)
+		drv.mu.Unlock()
+		if done != nil {
+			close(done)
+			drv.hotplugWG.Wait()
+		}
+	})
+	return nil
+}
+
+//
+
+// hotplugPeriod is the period at which the USB bus is polled.
+var hotplugPeriod = time.Second
+
+// pollHotplug polls the devices until done is closed.
+func (d *driver) pollHotplug(done <-chan struct{}) {
+	defer d.hotplugWG.Done()
+	t := time.NewTicker(hotplugPeriod)
+	defer t.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+		}
+		d.mu.Lock()
+		for _, e := range d.hotplug() {
+			for _, w := range d.watchers {
+				select {
+				case w.c <- e:
+				default:
+				}
+			}
+		}
+		d.mu.Unlock()
+	}
+}
+
+// hotplug removes the devices unplugged and adds the devices plugged in since
+// the last call.
+//
+// Must be called with mu held.
+func (d *driver) hotplug() []Event {
+	var out []Event
+	live := d.all[:0]
+	// The live devices fail to open again below, so count their channels here
+	// for the channels of the new devices to be counted as on the first
+	// enumeration.
+	channels := map[DevType]int{}
+	for _, dev := range d.all {
+		g := devGeneric(dev)
+		if g == nil {
+			// Broken devices are left as is.
+			live = append(live, dev)
+			continue
+		}
+		if _, e := g.h.h.GetQueueStatus(); e != 0 {
+			// The device is gone.
+			unregisterDev(dev, false)
+			_ = g.h.Close()
+			out = append(out, Event{Dev: dev, Removed: true})
+			continue
+		}
+		channels[g.h.t]++
+		live = append(live, dev)
+	}
+	for i := len(live); i < len(d.all); i++ {
+		d.all[i] = nil
+	}
+	d.all = live
+	num, err := d.numDevices()
+	if err != nil || (num == d.opened && len(out) == 0) {
+		// Nothing changed.
+		return out
+	}
+	d.opened = num
+	// D2XX only returns the number of devices. The devices already open,
+	// including by this process, fail to open again and are skipped.
+	for i := 0; i < num; i++ {
+		dev, err := open(d.d2xxOpen, i, channels)
+		if err != nil {
+			continue
+		}
+		if err := registerDev(dev, true); err != nil {
+			// The name is still in use, e.g. after the indexes shifted. Nothing
+			// was registered as the GPIOs are registered first.
+			if g := devGeneric(dev); g != nil {
+				_ = g.h.Close()
+			}
+			continue
+		}
+		d.all = append(d.all, dev)
+		out = append(out, Event{Dev: dev})
+	}
+	return out
+}
@@ -11,6 +11,8 @@
 // http://www.ftdichip.com/Support/Documents/AppNotes/AN_255_USB%20to%20I2C%20Example%20using%20the%20FT232H%20and%20FT201X%20devices.pdf
 //
 // Page 18: MPSSE does not automatically support clock stretching for I²C.
+// It is instead supported with adaptive clocking, which waits for each clock
+// edge to be reflected on D7, so SCL must be wired to D7.
 
 package ftdi
 
@@ -18,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"periph.io/x/conn/v3"
 	"periph.io/x/conn/v3/gpio"
@@ -25,13 +28,182 @@ import (
 	"periph.io/x/conn/v3/physic"
 )
 
-const i2cSCL = 1    // D0
-const i2cSDAOut = 2 // D1
-const i2cSDAIn = 4  // D2
+const i2cSCL = 1     // D0
+const i2cSDAOut = 2  // D1
+const i2cSDAIn = 4   // D2
+const i2cRTCK = 0x80 // D7
+
+// RepeatedStarter is implemented by the I²C bus returned by FT232H.I2C() to
+// select how the read phase of a write-then-read transaction is started.
+type RepeatedStarter interface {
+	SetRepeatedStart(enable bool)
+}
+
+// ClockStretcher is implemented by the I²C bus returned by FT232H.I2C() to
+// support devices that stretch the clock.
+type ClockStretcher interface {
+	SetClockStretching(enable bool) error
+}
+
+// ClockTuner is implemented by the I²C bus returned by FT232H.I2C() to adjust
+// the SCL waveform for marginal devices and long wires.
+type ClockTuner interface {
+	SetClockTuning(t ClockTuning) (ClockTiming, error)
+}
+
+// ClockTuning is the SCL waveform selected with ClockTuner.
+//
+// The zero value is the default: 3 phase clocking.
+type ClockTuning struct {
+	// TwoPhase disables the 3 phase clocking.
+	//
+	// With 3 phase clocking, SDA changes a half clock period after SCL falls
+	// and SCL is high a third of the period, which meets the minimum low time
+	// of fast mode. With 2 phase clocking, SCL is high half of the period,
+	// which gives more time to the slow rising edges of long wires and weak
+	// pull ups, but SDA changes as SCL falls, with no hold time. The MPSSE
+	// supports no other duty cycle.
+	TwoPhase bool
+}
+
+// ClockTiming is the effective SCL waveform, as reported by ClockTuner.
+type ClockTiming struct {
+	// Frequency is the SCL frequency; the speed set with SetSpeed() is
+	// rounded down to the closest supported frequency.
+	Frequency physic.Frequency
+	// Duty is the fraction of the period SCL is high.
+	Duty gpio.Duty
+}
+
+// DeviceSpeeder is implemented by the I²C bus returned by FT232H.I2C() to
+// clock each device at its own speed, e.g. a 100kHz only device sharing the
+// bus with faster ones.
+type DeviceSpeeder interface {
+	SetDeviceSpeed(addr uint16, f physic.Frequency) error
+}
+
+// Scanner is implemented by the I²C bus returned by FT232H.I2C() to list the
+// devices on the bus.
+type Scanner interface {
+	Scan() ([]uint16, error)
+}
+
+// BusRecoverer is implemented by the I²C bus returned by FT232H.I2C() to free
+// the bus when a device holds SDA low, e.g. after the host was reset in the
+// middle of a read.
+type BusRecoverer interface {
+	Recover() error
+}
+
+// NAKHandler is implemented by the I²C bus returned by FT232H.I2C() to select
+// how a byte that is not acknowledged is handled.
+type NAKHandler interface {
+	SetNAKPolicy(p NAKPolicy) error
+}
+
+// NAKPolicy is the handling of NAKs selected with NAKHandler.
+//
+// The zero value returns a NAKError on the first NAK, which is the default.
+type NAKPolicy struct {
+	// Retries is the number of times Tx() retries a transaction that was not
+	// acknowledged. Other errors are not retried.
+	Retries int
+	// Backoff is the delay before the first retry; it doubles on each retry.
+	Backoff time.Duration
+	// Ignore ignores the NAKs; the transaction continues as if the bytes were
+	// acknowledged and no error is returned. It is useful to ack-poll an
+	// EEPROM while it completes a write.
+	Ignore bool
+}
+
+// NAKError is returned when a byte of an I²C transaction is not acknowledged.
+type NAKError struct {
+	// Addr is the address of the device.
+	Addr uint16
+	// Index is the index in w of the byte that was not acknowledged. It is -1
+	// when it is the address.
+	Index int
+	// Read is true when the address of the read phase was not acknowledged.
+	Read bool
+}
+
+func (e *NAKError) Error() string {
+	switch {
+	case e.Read:
+		return fmt.Sprintf("d2xx: I²C device %#x got NAK on the read address", e.Addr)
+	case e.Index == -1:
+		return fmt.Sprintf("d2xx: I²C device %#x got NAK on the address", e.Addr)
+	default:
+		return fmt.Sprintf("d2xx: I²C device %#x got NAK on byte %d", e.Addr, e.Index)
+	}
+}
+
+// Arbitrator is implemented by the I²C bus returned by FT232H.I2C() to share
+// the bus with other masters.
+type Arbitrator interface {
+	SetArbitration(p ArbitrationPolicy) error
+}
+
+// ArbitrationPolicy is the multi-master handling selected with Arbitrator.
+//
+// The zero value disables the arbitration detection, which is the default.
+type ArbitrationPolicy struct {
+	// Detect verifies that the bus is idle before each transaction and reads
+	// back SDA while the address and the data are written. When another
+	// master drives SDA low while the bus releases it, the transaction
+	// returns an error wrapping ErrArbitrationLost.
+	//
+	// It requires the open drain lines of gpio.Float. The whole transaction
+	// is queued to the MPSSE at once, so the loss is detected after the
+	// transaction completes; the bytes following the one that lost the
+	// arbitration are still clocked out, which the other master sees as a
+	// bus error.
+	Detect bool
+	// Retries is the number of times Tx() retries a transaction that lost
+	// the arbitration.
+	Retries int
+	// Backoff is the delay before the first retry; it doubles on each retry.
+	Backoff time.Duration
+}
+
+// ErrArbitrationLost is wrapped by the error returned when another master
+// used the bus during a transaction, as detected with ArbitrationPolicy.
+var ErrArbitrationLost = errors.New("d2xx: I²C arbitration lost")
+
+// ContextTxer is implemented by the I²C bus returned by FT232H.I2C() to abort
+// the transactions that don't complete in time, e.g. when a device holds SCL
+// low forever while clock stretching is enabled.
+type ContextTxer interface {
+	TxContext(ctx context.Context, addr uint16, w, r []byte) error
+	SetTimeout(d time.Duration) error
+}
+
+// Pipeliner is implemented by the I²C bus returned by FT232H.I2C() to run
+// several transactions in a single USB round trip.
+type Pipeliner interface {
+	TxPipelined(txs []I2CTx) error
+}
+
+// I2CTx is a transaction run by Pipeliner.TxPipelined. Its fields are the
+// arguments of i2c.Bus.Tx().
+type I2CTx struct {
+	Addr uint16
+	W    []byte
+	R    []byte
+}
 
 type i2cBus struct {
-	f      *FT232H
-	pullUp bool
+	f              *FT232H
+	pullUp         bool
+	stopBeforeRead bool
+	stretch        bool
+	twoPhase       bool
+	devSpeed       map[uint16]physic.Frequency
+	clk            physic.Frequency // speed currently set on the MPSSE; -1 if unknown
+	nak            NAKPolicy
+	arb            ArbitrationPolicy
+	timeout        time.Duration
+	speed          physic.Frequency
 }
 
 // Close stops I²C mode, returns to high speed mode, disable tri-state.
@@ -52,74 +224,247 @@ func (d *i2cBus) String() string {
 }
 
 // SetSpeed implements i2c.Bus.
+//
+// The SCL frequency is rounded down to the closest supported one, as reported
+// by SetClockTuning().
 func (d *i2cBus) SetSpeed(f physic.Frequency) error {
-	if f > 10*physic.MegaHertz {
-		return fmt.Errorf("d2xx: invalid speed %s; maximum supported clock is 10MHz", f)
-	}
-	if f < 100*physic.Hertz {
-		return fmt.Errorf("d2xx: invalid speed %s; minimum supported clock is 100Hz; did you forget to multiply by physic.KiloHertz?", f)
+	if err := checkI2CSpeed(f); err != nil {
+		return err
 	}
 	d.f.mu.Lock()
 	defer d.f.mu.Unlock()
-	_, err := d.f.h.MPSSEClock(f * 2 / 3)
-	return err
+	cmd, _ := d.clockCmd(f, d.twoPhase)
+	if _, err := d.f.h.Write(cmd); err != nil {
+		return err
+	}
+	d.speed = f
+	d.clk = f
+	return nil
 }
 
-// Tx implements i2c.Bus.
-func (d *i2cBus) Tx(addr uint16, w, r []byte) error {
+// SetDeviceSpeed sets the speed of the transactions to the device at addr,
+// overriding the speed set with SetSpeed(). 0 reverts to the bus speed.
+//
+// The clock is switched in the same USB write as the transaction, only when
+// the previous transaction ran at another speed.
+func (d *i2cBus) SetDeviceSpeed(addr uint16, f physic.Frequency) error {
+	if err := checkI2CAddr(addr); err != nil {
+		return err
+	}
+	if f != 0 {
+		if err := checkI2CSpeed(f); err != nil {
+			return err
+		}
+	}
 	d.f.mu.Lock()
 	defer d.f.mu.Unlock()
+	if f == 0 {
+		delete(d.devSpeed, addr)
+		return nil
+	}
+	if d.devSpeed == nil {
+		d.devSpeed = map[uint16]physic.Frequency{}
+	}
+	d.devSpeed[addr] = f
+	return nil
+}
 
-	//defer d.setI2CLinesIdle() // エラーチェックしない
-
-	var	cmdFull		[]byte
-	var	cmd			[]byte
-
-	cmd     = d.setI2CStart()
-	cmdFull = append(cmdFull, cmd...)
-
-	var byWrite		[]byte
-	var byRead		[]byte
-	var	iReadCnt	int
-	var err error
-
-	byWrite = append(byWrite, d.address_byte(addr, false))
-	if (len(w) != 0) {
-		byWrite = append(byWrite, w...)
+// SetClockTuning implements ClockTuner.
+func (d *i2cBus) SetClockTuning(t ClockTuning) (ClockTiming, error) {
+	d.f.mu.Lock()
+	defer d.f.mu.Unlock()
+	cmd, c := d.clockCmd(d.speed, t.TwoPhase)
+	if _, err := d.f.h.Write(cmd); err != nil {
+		return ClockTiming{}, err
 	}
+	d.twoPhase = t.TwoPhase
+	d.clk = d.speed
+	return c, nil
+}
 
-	cmd     = d.setI2CWriteBytes(byWrite)
-	cmdFull = append(cmdFull, cmd...)
-	iReadCnt = len(byWrite)
+// Tx implements i2c.Bus.
+//
+// When both w and r are provided, the read phase is preceded by a repeated
+// start condition, without an intermediate stop, unless disabled with
+// SetRepeatedStart(false).
+//
+// Addresses from 0x80 to 0x3FF are sent as 10 bits addresses. Their read
+// phase is always preceded by a repeated start, as required by the I²C
+// specification.
+//
+// A NAK is returned as a *NAKError, unless the policy set with SetNAKPolicy()
+// specifies otherwise. The arbitration lost to another master is detected and
+// retried as specified with SetArbitration().
+//
+// It is aborted after the timeout set with SetTimeout(), if any.
+func (d *i2cBus) Tx(addr uint16, w, r []byte) error {
+	return d.TxContext(context.Background(), addr, w, r)
+}
 
-	if ((len(r) != 0) && (len(w) != 0)) { // len(w)はレジスタアドレス指定済みを判定するため
-		cmd     = d.setI2CStop()
-		cmdFull = append(cmdFull, cmd...)
+// TxContext is Tx() aborted when ctx is done.
+//
+// When aborted while waiting for the device, the MPSSE engine is reset and
+// resynchronized so the bus can be used again, and the returned error wraps
+// ctx.Err(); use errors.Is(err, context.DeadlineExceeded) to detect a
+// timeout. The commands already sent to the MPSSE can't be recalled, so the
+// device may have received part of the transaction. The USB write itself is
+// not aborted; it is bound by the driver's timeout.
+func (d *i2cBus) TxContext(ctx context.Context, addr uint16, w, r []byte) error {
+	if err := checkI2CAddr(addr); err != nil {
+		return err
+	}
+	d.f.mu.Lock()
+	defer d.f.mu.Unlock()
+	cmd, readCnt := d.txCmd(addr, w, r)
+	cmd = append(d.speedCmd(addr), cmd...)
+	nakDelay := d.nak.Backoff
+	arbDelay := d.arb.Backoff
+	for nakTries, arbTries := 0, 0; ; {
+		err := d.transactionEnd(ctx, cmd, readCnt, addr, w, r)
+		var delay time.Duration
+		if _, ok := err.(*NAKError); ok && nakTries < d.nak.Retries {
+			nakTries++
+			delay = nakDelay
+			nakDelay *= 2
+		} else if errors.Is(err, ErrArbitrationLost) && arbTries < d.arb.Retries {
+			arbTries++
+			delay = arbDelay
+			arbDelay *= 2
+		} else {
+			return err
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return fmt.Errorf("d2xx: I²C transaction aborted: %w", ctx.Err())
+		case <-t.C:
+		}
+	}
+}
 
-		cmd     = d.setI2CLinesIdle()
-		cmdFull = append(cmdFull, cmd...)
+// SetTimeout sets the maximum duration of a transaction, after which it is
+// aborted like TxContext() does. It applies to Tx(), TxContext(),
+// TxPipelined() and Scan(). 0, the default, disables the timeout.
+func (d *i2cBus) SetTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return errors.New("d2xx: invalid I²C timeout")
+	}
+	d.f.mu.Lock()
+	d.timeout = timeout
+	d.f.mu.Unlock()
+	return nil
+}
 
-		cmd     = d.setI2CStart()
-		cmdFull = append(cmdFull, cmd...)
+// TxPipelined runs all the transactions in txs in a single USB round trip.
+//
+// The commands of all the transactions are queued to the MPSSE at once, then
+// the ACKs and the data read are parsed in one pass. This saves the round trip
+// per transaction of Tx(), which dominates the time spent polling registers.
+//
+// Each transaction behaves as a Tx() and ends with a stop condition. Since
+// all the transactions are already queued, a NAK doesn't stop the following
+// ones; the data read by the transactions that were acknowledged is still
+// returned and the error reports the first transaction that was not. The
+// transactions are not retried but NAKPolicy.Ignore and
+// ArbitrationPolicy.Detect are honored.
+func (d *i2cBus) TxPipelined(txs []I2CTx) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	for i := range txs {
+		if err := checkI2CAddr(txs[i].Addr); err != nil {
+			return err
+		}
+	}
+	d.f.mu.Lock()
+	defer d.f.mu.Unlock()
+	var cmd []byte
+	readCnts := make([]int, len(txs))
+	total := 0
+	for i := range txs {
+		c, n := d.txCmd(txs[i].Addr, txs[i].W, txs[i].R)
+		cmd = append(cmd, d.speedCmd(txs[i].Addr)...)
+		cmd = append(cmd, c...)
+		readCnts[i] = n
+		total += n
+	}
+	readBuff, err := d.exchange(context.Background(), cmd, total)
+	if err != nil {
+		return err
+	}
+	var nak error
+	for i := range txs {
+		n := readCnts[i]
+		if err := d.txReply(readBuff[:n], txs[i].Addr, txs[i].W, txs[i].R); err != nil && nak == nil {
+			nak = fmt.Errorf("%w in transaction %d", err, i)
+		}
+		readBuff = readBuff[n:]
+	}
+	return nak
+}
 
-		byRead   = append(byRead, d.address_byte(addr, true))
-		cmd      = d.setI2CWriteBytes(byRead)
-		cmdFull  = append(cmdFull, cmd...)
-		iReadCnt += len(byRead)
+// SetRepeatedStart selects how the read phase of a Tx() with both w and r is
+// started.
+//
+// When enabled, which is the default, a repeated start condition (Sr) is
+// generated. When disabled, a stop condition is generated and the lines are
+// idled before a new start condition, which some old devices require.
+func (d *i2cBus) SetRepeatedStart(enable bool) {
+	d.f.mu.Lock()
+	d.stopBeforeRead = !enable
+	d.f.mu.Unlock()
+}
 
-		cmd      = d.setI2CReadBytes(len(r))
-		cmdFull  = append(cmdFull, cmd...)
-		iReadCnt += len(r)
+// SetNAKPolicy selects how a byte that is not acknowledged is handled.
+func (d *i2cBus) SetNAKPolicy(p NAKPolicy) error {
+	if p.Retries < 0 || p.Backoff < 0 {
+		return errors.New("d2xx: invalid I²C NAK policy")
 	}
+	d.f.mu.Lock()
+	d.nak = p
+	d.f.mu.Unlock()
+	return nil
+}
 
-	cmd     = d.setI2CStop()
-	cmdFull = append(cmdFull, cmd...)
+// SetArbitration selects how the bus is shared with other masters.
+func (d *i2cBus) SetArbitration(p ArbitrationPolicy) error {
+	if p.Retries < 0 || p.Backoff < 0 {
+		return errors.New("d2xx: invalid I²C arbitration policy")
+	}
+	d.f.mu.Lock()
+	defer d.f.mu.Unlock()
+	if p.Detect && d.pullUp {
+		return errors.New("d2xx: I²C arbitration requires open drain lines; use gpio.Float")
+	}
+	d.arb = p
+	return nil
+}
 
-	err = d.transactionEnd(cmdFull, iReadCnt, r)
-	if (nil != err){
+// SetClockStretching enables or disables support for devices holding SCL low
+// to slow down the transfer, e.g. some EEPROMs and sensor hubs.
+//
+// When enabled, SCL must be wired to D7 and D7 is used as an input. The MPSSE
+// then uses adaptive clocking: each clock edge waits until SCL reflects it,
+// so a device stretching the clock pauses the transfer. It is disabled by
+// default and not supported when the bus uses gpio.PullUp.
+func (d *i2cBus) SetClockStretching(enable bool) error {
+	d.f.mu.Lock()
+	defer d.f.mu.Unlock()
+	if enable && d.pullUp {
+		return errors.New("d2xx: I²C clock stretching requires open drain lines; use gpio.Float")
+	}
+	var cmd []byte
+	if enable {
+		d.f.dbus.direction &^= i2cRTCK
+		cmd = append(d.setI2CLinesIdle(), clockAdaptive)
+	} else {
+		cmd = []byte{clockNormal}
+	}
+	if _, err := d.f.h.Write(cmd); err != nil {
 		return err
 	}
-
+	d.stretch = enable
 	return nil
 }
 
@@ -133,45 +478,127 @@ func (d *i2cBus) SDA() gpio.PinIO {
 	return d.f.D1
 }
 
+// Scan probes the addresses 0x08 to 0x77 and returns the ones that were
+// acknowledged, to verify the wiring.
+//
+// Each address is probed with an empty write, in a single USB round trip.
+// Most devices ignore it but a few, e.g. some write-only devices, may
+// misbehave; see package i2cprobe for a more careful probe. The NAK policy is
+// not applied.
+func (d *i2cBus) Scan() ([]uint16, error) {
+	const first, last = 0x08, 0x77
+	d.f.mu.Lock()
+	defer d.f.mu.Unlock()
+	var cmd []byte
+	n := 0
+	for addr := uint16(first); addr <= last; addr++ {
+		c, readCnt := d.txCmd(addr, nil, nil)
+		cmd = append(cmd, d.speedCmd(addr)...)
+		cmd = append(cmd, c...)
+		n = readCnt
+	}
+	readBuff, err := d.exchange(context.Background(), cmd, n*(last-first+1))
+	if err != nil {
+		return nil, err
+	}
+	var out []uint16
+	for addr := uint16(first); addr <= last; addr++ {
+		acks, err := d.arbitration(readBuff[:n], addr, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if acks[0]&0x01 == 0 {
+			out = append(out, addr)
+		}
+		readBuff = readBuff[n:]
+	}
+	return out, nil
+}
+
+// txCmd returns the commands of a transaction, ended by a stop condition,
+// and the number of bytes it reads back.
+func (d *i2cBus) txCmd(addr uint16, w, r []byte) ([]byte, int) {
+	cmd := d.setI2CStart()
+	readCnt := 0
+	tenBits := addr >= 0x80
+	if len(w) != 0 || len(r) == 0 || tenBits {
+		// Write phase; it is also used to probe the address when both w and r
+		// are empty, and to send the second byte of a 10 bits address.
+		b := []byte{d.address_byte(addr, false)}
+		if tenBits {
+			b = append(b, byte(addr))
+		}
+		b = append(b, w...)
+		cmd = append(cmd, d.setI2CWriteBytes(b)...)
+		readCnt += len(b)
+		if len(r) != 0 {
+			if d.stopBeforeRead && !tenBits {
+				cmd = append(cmd, d.setI2CStop()...)
+				cmd = append(cmd, d.setI2CLinesIdle()...)
+				cmd = append(cmd, d.setI2CStart()...)
+			} else {
+				cmd = append(cmd, d.setI2CRepeatedStart()...)
+			}
+		}
+	}
+	if len(r) != 0 {
+		cmd = append(cmd, d.setI2CWriteBytes([]byte{d.address_byte(addr, true)})...)
+		cmd = append(cmd, d.setI2CReadBytes(len(r))...)
+		readCnt += 1 + len(r)
+	}
+	if d.arb.Detect {
+		// Each byte written is also read back.
+		readCnt += readCnt - len(r)
+	}
+	return append(cmd, d.setI2CStop()...), readCnt
+}
+
+// txBytes returns the bytes written by txCmd for a transaction to addr.
+func (d *i2cBus) txBytes(addr uint16, w, r []byte) []byte {
+	var b []byte
+	tenBits := addr >= 0x80
+	if len(w) != 0 || len(r) == 0 || tenBits {
+		b = append(b, d.address_byte(addr, false))
+		if tenBits {
+			b = append(b, byte(addr))
+		}
+		b = append(b, w...)
+	}
+	if len(r) != 0 {
+		b = append(b, d.address_byte(addr, true))
+	}
+	return b
+}
+
 // setupI2C initializes the MPSSE to the state to run an I²C transaction.
 //
 // Defaults to 400kHz.
 //
-// When pullUp is true; output alternates between Out(Low) and In(PullUp).
+// When pullUp is true; output alternates between Out(Low) and In(PullUp). The
+// MPSSE still drives D0 while clocking and D1 while shifting bits out, since
+// it can't shift through the direction register.
 //
 // when pullUp is false; pins are set in Tristate so Out(High) becomes float
 // instead of drive High. Low still drives low. That's called open collector.
 func (d *i2cBus) setupI2C(pullUp bool) error {
-	if pullUp {
-		return errors.New("d2xx: PullUp will soon be implemented")
-	}
 	// TODO(maruel): We could set these only *during* the I²C operation, which
 	// would make more sense.
-	f := 400 * physic.KiloHertz
-	clk := ((30 * physic.MegaHertz / f) - 1) * 2 / 3
-
 	var cmd []byte
 	cmd = append(cmd,
-		clock30MHz,              // 0x8A; Disable clock divide-by-5 for 60Mhz master clock
 		clockNormal,             // 0x97; Ensure adaptive clocking is off
-		clock3Phase,             // 0x8C; Enable 3 phase data clocking, data valid on both clock edges for I2C
-		dataTristate,            // 0x9E; Enable drive-zero mode on the lines used for I2C ...
-		0x07,                    // 0x07; ... on the bits AD0, 1 and 2 of the lower port...
-		0x00,                    // 0x00; ...not required on the upper port AC 0-7
 		internalLoopbackDisable, // 0x85; Ensure internal loopback is off
 	)
+	if !pullUp {
+		// 0x9E; Enable drive-zero mode on the lines used for I2C on the bits AD0,
+		// 1 and 2 of the lower port, keeping the other open drain GPIOs.
+		cmd = append(cmd, d.f.tristateCmd(0x07)...)
+	}
+	// Enable 3 phase data clocking unless disabled, data valid on both clock
+	// edges for I2C.
+	clk, _ := d.clockCmd(d.speed, d.twoPhase)
+	cmd = append(cmd, clk...)
+	d.clk = d.speed
 
-	cmd = append(cmd,
-		clockSetDivisor,
-		byte(clk),
-		byte(clk>>8),
-	)
-
-	//cmd := buf[:4]
-	//if !d.pullUp {
-	//	// TODO(maruel): Do not mess with other GPIOs tristate.
-	//	cmd = append(cmd, dataTristate, 7, 0)
-	//}
 	if _, err := d.f.h.Write(cmd); err != nil {
 		return err
 	}
@@ -195,225 +622,413 @@ func (d *i2cBus) stopI2C() error {
 	}
 	cmd := buf[:4]
 	if !d.pullUp {
-		// TODO(maruel): Do not mess with other GPIOs tristate.
-		cmd = append(cmd, dataTristate, 0, 0)
+		cmd = append(cmd, d.f.tristateCmd(0)...)
+	}
+	if d.stretch {
+		cmd = append(cmd, clockNormal)
 	}
 	_, err := d.f.h.Write(cmd)
 	d.f.usingI2C = false
+	d.stretch = false
 	return err
 }
 
 // setI2CLinesIdle sets all D0 and D1 lines high.
 //
 // Does not touch D3~D7.
-func (d *i2cBus) setI2CLinesIdle() ([]byte) {
+func (d *i2cBus) setI2CLinesIdle() []byte {
 	const mask = 0xFF &^ (i2cSCL | i2cSDAOut | i2cSDAIn)
-	// TODO(maruel): d.pullUp
 	d.f.dbus.direction = d.f.dbus.direction&mask | i2cSCL | i2cSDAOut
-	//d.f.dbus.value = d.f.dbus.value & mask
-	cmd := []byte{
-		//gpioSetD, d.f.dbus.value | i2cSCL | i2cSDAOut, d.f.dbus.direction,
-		gpioSetD, i2cSCL | i2cSDAOut, d.f.dbus.direction,
-		gpioSetD, i2cSCL | i2cSDAOut, d.f.dbus.direction,
-		gpioSetD, i2cSCL | i2cSDAOut, d.f.dbus.direction,
-		gpioSetD, i2cSCL | i2cSDAOut, d.f.dbus.direction,
-
-		//flush,
-	}
-	//_, err := d.f.h.Write(cmd[:])
-	return cmd
+	return d.setI2CLines(i2cSCL | i2cSDAOut)
 }
 
 // setI2CStart starts an I²C transaction.
 //
 // Does not touch D3~D7.
-func (d *i2cBus) setI2CStart() ([]byte) {
-	// TODO(maruel): d.pullUp
-	dir := d.f.dbus.direction
-	//v := d.f.dbus.value
+func (d *i2cBus) setI2CStart() []byte {
 	// Assumes last setup was d.setI2CLinesIdle(), e.g. D0 and D1 are high, so
 	// skip this.
 	//
-	// Runs the command 4 times as a way to delay execution.
-	cmd := []byte{
-		// SCL high, SDA low for 600ns
-		//gpioSetD, v | i2cSCL, dir,
-		gpioSetD, i2cSCL, dir,
-		gpioSetD, i2cSCL, dir,
-		gpioSetD, i2cSCL, dir,
-		gpioSetD, i2cSCL, dir,
-
-		// SCL low, SDA low
-		//gpioSetD, v, dir,
-		gpioSetD, 0x00, dir,
-		gpioSetD, 0x00, dir,
-		gpioSetD, 0x00, dir,
-		gpioSetD, 0x00, dir,
+	// SCL high, SDA low for 600ns
+	cmd := d.setI2CLines(i2cSCL)
+	// SCL low, SDA low
+	return append(cmd, d.setI2CLines(0)...)
+}
 
-		//gpioSetC, 0xFB, 0x40,	//LED setting?
-	}
-	//_, err := d.f.h.Write(cmd[:])
-	//return err
-	return cmd
+// setI2CRepeatedStart generates a repeated start condition within an I²C
+// transaction.
+//
+// Assumes the last byte was written and its ACK was read, e.g. SCL is low and
+// SDA is released. Does not touch D3~D7.
+func (d *i2cBus) setI2CRepeatedStart() []byte {
+	// SCL low, SDA high
+	cmd := d.setI2CLines(i2cSDAOut)
+	// SCL high, SDA high
+	cmd = append(cmd, d.setI2CLines(i2cSCL|i2cSDAOut)...)
+	// Then SDA falls while SCL is high.
+	return append(cmd, d.setI2CStart()...)
 }
 
 // setI2CStop completes an I²C transaction.
 //
 // Does not touch D3~D7.
-func (d *i2cBus) setI2CStop() ([]byte) {
-	// TODO(maruel): d.pullUp
-	dir := d.f.dbus.direction
-	//v := d.f.dbus.value
-	// Runs the command 4 times as a way to delay execution.
-	cmd := []byte{
-		// SCL low, SDA low
-		//gpioSetD, v, dir,
-		gpioSetD, 0x00, dir,
-		gpioSetD, 0x00, dir,
-		gpioSetD, 0x00, dir,
-		gpioSetD, 0x00, dir,
-
-		// SCL high, SDA low
-		//gpioSetD, v | i2cSCL, dir,
-		gpioSetD, i2cSCL, dir,
-		gpioSetD, i2cSCL, dir,
-		gpioSetD, i2cSCL, dir,
-		gpioSetD, i2cSCL, dir,
-
-		// SCL high, SDA high
-		//gpioSetD, v | i2cSCL | i2cSDAOut, dir,
-		gpioSetD, i2cSCL | i2cSDAOut, dir,
-		gpioSetD, i2cSCL | i2cSDAOut, dir,
-		gpioSetD, i2cSCL | i2cSDAOut, dir,
-		gpioSetD, i2cSCL | i2cSDAOut, dir,
-	}
-
-	return cmd
+func (d *i2cBus) setI2CStop() []byte {
+	// SCL low, SDA low
+	cmd := d.setI2CLines(0)
+	// SCL high, SDA low
+	cmd = append(cmd, d.setI2CLines(i2cSCL)...)
+	// SCL high, SDA high
+	return append(cmd, d.setI2CLines(i2cSCL|i2cSDAOut)...)
 }
 
-func (d *i2cBus) setI2CWriteBytes(w []byte) ([]byte) {
-	// TODO(maruel): d.pullUp
-	dir := d.f.dbus.direction
-	//v := d.f.dbus.value
-
-	var cmdfull []byte
-
-	// TODO(maruel): Implement both with and without NAK check.
-	cmd1 := []byte{
-		// Data out, the 0 will be replaced with the byte.
-		dataOut | dataOutFall, 0, 0, //0,
+func (d *i2cBus) setI2CWriteBytes(w []byte) []byte {
+	op := dataOut | dataOutFall
+	if d.arb.Detect {
+		// Also sample SDA on the rising edge, to read back the bits that
+		// another master may have driven low.
+		op |= dataIn
 	}
-
-	cmd2 := []byte{
+	var cmdfull []byte
+	for _, c := range w {
+		cmdfull = append(cmdfull, d.setI2CDriveSDA()...)
+		// TODO(maruel): Implement both with and without NAK check.
+		cmdfull = append(cmdfull, op, 0, 0, c)
 		// Set back to idle.
-		//gpioSetD, v | i2cSDAOut, dir,
-		gpioSetD, i2cSDAOut, dir,
-		gpioSetD, i2cSDAOut, dir,
-		gpioSetD, i2cSDAOut, dir,
-		gpioSetD, i2cSDAOut, dir,
-
+		cmdfull = append(cmdfull, d.setI2CLines(i2cSDAOut)...)
 		// Read ACK/NAK.
-		dataIn | dataBit, 0,
+		cmdfull = append(cmdfull, dataIn|dataBit, 0)
 	}
+	return cmdfull
+}
 
-	for _, c := range w {
-		cmdfull = append(cmdfull, cmd1...)
-		cmdfull = append(cmdfull, c)
-		cmdfull = append(cmdfull, cmd2...)
+func (d *i2cBus) setI2CReadBytes(setCnt int) []byte {
+	var cmdfull []byte
+	for iCnt := 0; iCnt < setCnt; iCnt++ {
+		// Read 8 bits.
+		cmdfull = append(cmdfull, dataIn, 0, 0)
+		// Send ACK/NAK.
+		cmdfull = append(cmdfull, d.setI2CDriveSDA()...)
+		cmdfull = append(cmdfull, dataOut|dataOutFall|dataBit, 0)
+		if iCnt != setCnt-1 {
+			cmdfull = append(cmdfull, 0x00) // ACK
+		} else {
+			cmdfull = append(cmdfull, 0xFF) // NAK on the last byte
+		}
+		// Set back to idle.
+		cmdfull = append(cmdfull, d.setI2CLines(i2cSDAOut)[:3]...)
 	}
-
 	return cmdfull
 }
 
-func (d *i2cBus) setI2CReadBytes(setCnt int) ([]byte) {
-	// TODO(maruel): d.pullUp
+// setI2CLines sets D0 and D1 to the levels in v, using i2cSCL and i2cSDAOut.
+//
+// The command runs 4 times as a way to delay execution.
+//
+// In open collector mode, high is floating via tristate. In pull up mode, low
+// is Out(Low) and high is In(PullUp).
+//
+// Does not touch D3~D7.
+func (d *i2cBus) setI2CLines(v byte) []byte {
 	dir := d.f.dbus.direction
-	//v := d.f.dbus.value
-
-	cmd1 := []byte{
-		// Read 8 bits.
-		//dataIn | dataBit, 0, 0,				// 0x22, 0x00, 0x00
-		dataIn, 0, 0		,					// 0x20, 0x00, 0x00
+	if d.pullUp {
+		dir = dir&^(i2cSCL|i2cSDAOut) | ^v&(i2cSCL|i2cSDAOut)
+		v = 0
+	}
+	return []byte{
+		gpioSetD, v, dir,
+		gpioSetD, v, dir,
+		gpioSetD, v, dir,
+		gpioSetD, v, dir,
+	}
+}
 
-		// Send ACK/NAK.
-		dataOut | dataOutFall | dataBit, 0,		// 0x13, 0x00
+// setI2CDriveSDA sets D1 as an output before the MPSSE shifts bits out, while
+// SCL is low.
+//
+// It is only needed in pull up mode as D1 is always an output in open
+// collector mode.
+func (d *i2cBus) setI2CDriveSDA() []byte {
+	if !d.pullUp {
+		return nil
 	}
+	return []byte{gpioSetD, i2cSDAOut, d.f.dbus.direction | i2cSCL | i2cSDAOut}
+}
 
-	cmd2 := []byte{
-		// Set back to idle.
-		//gpioSetD, v | i2cSCL | i2cSDAOut, dir,	// 0x80, 0x03, 0x03
-		//gpioSetD, v | i2cSDAOut, dir,	// 0x80, 0x02, 0x03
-		gpioSetD, i2cSDAOut, dir,		// 0x80, 0x02, 0x03
-		// Force read buffer flush. This is only necessary if NAK are not ignored.
+func (d *i2cBus) transactionEnd(ctx context.Context, cmd []byte, readCnt int, addr uint16, w, r []byte) error {
+	readBuff, err := d.exchange(ctx, cmd, readCnt)
+	if err != nil {
+		return err
 	}
+	return d.txReply(readBuff, addr, w, r)
+}
 
-	var cmdfull []byte
+// txReply verifies the arbitration and the ACKs of a transaction to addr
+// read back in readBuff, then copies the data read into r.
+func (d *i2cBus) txReply(readBuff []byte, addr uint16, w, r []byte) error {
+	readBuff, err := d.arbitration(readBuff, addr, w, r)
+	if err != nil {
+		return err
+	}
+	return d.reply(readBuff, addr, r)
+}
 
-	for iCnt := 0; iCnt < setCnt; iCnt ++ {
-		cmdfull = append(cmdfull, cmd1...)
-		if (iCnt != (setCnt - 1)) { // 最終データでないか?
-			cmdfull = append(cmdfull, 0x00) // ACK
-		} else {
-			cmdfull = append(cmdfull, 0xFF) // NAK (0x80?)
+// arbitration verifies that the bytes written by a transaction to addr were
+// read back unchanged when the arbitration detection is enabled.
+//
+// It returns readBuff without them, as expected by reply.
+func (d *i2cBus) arbitration(readBuff []byte, addr uint16, w, r []byte) ([]byte, error) {
+	if !d.arb.Detect {
+		return readBuff, nil
+	}
+	sent := d.txBytes(addr, w, r)
+	out := make([]byte, 0, len(readBuff)-len(sent))
+	for i, b := range sent {
+		if readBuff[2*i] != b {
+			return nil, fmt.Errorf("%w on byte %d of the transaction to %#x", ErrArbitrationLost, i, addr)
 		}
-		cmdfull = append(cmdfull, cmd2...)
+		out = append(out, readBuff[2*i+1])
 	}
-
-	return cmdfull
+	return append(out, readBuff[2*len(sent):]...), nil
 }
 
-func (d *i2cBus) transactionEnd(w []byte, readCnt int, r []byte) (error) {
+// exchange sends the commands w and reads back readCnt bytes.
+//
+// When ctx is done or the bus timeout expires before all the bytes are read
+// back, the MPSSE is resynchronized.
+func (d *i2cBus) exchange(ctx context.Context, w []byte, readCnt int) (_ []byte, err error) {
+	defer func() {
+		if err != nil {
+			// The clock switch of w, if any, may not have been sent.
+			d.clk = -1
+		}
+	}()
+	if d.timeout != 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("d2xx: I²C transaction aborted: %w", err)
+	}
 	// TODO(maruel): WAT?
-	var	err		error
-	err = d.f.h.Flush()
-	if (nil != err) {
-		return err
+	if err := d.f.h.Flush(); err != nil {
+		return nil, err
 	}
-
-	readBuff := make([]byte, readCnt)
-
-	var cmdfull []byte
+	if d.arb.Detect {
+		v, err := d.readD([]byte{gpioReadD, flush})
+		if err != nil {
+			return nil, err
+		}
+		if v&(i2cSCL|i2cSDAIn) != i2cSCL|i2cSDAIn {
+			return nil, fmt.Errorf("%w; the bus is busy", ErrArbitrationLost)
+		}
+	}
+	cmdfull := make([]byte, 0, len(w)+1)
 	cmdfull = append(cmdfull, w...)
 	cmdfull = append(cmdfull, flush)
+	if _, err := d.f.h.Write(cmdfull); err != nil {
+		return nil, err
+	}
+	readBuff := make([]byte, readCnt)
+	if _, err := d.f.h.mpsseReadAll(ctx, readBuff); err != nil {
+		if ctx.Err() == nil {
+			return nil, err
+		}
+		if err := d.resync(); err != nil {
+			return nil, fmt.Errorf("d2xx: I²C transaction aborted: %v; failed to resynchronize: %w", ctx.Err(), err)
+		}
+		return nil, fmt.Errorf("d2xx: I²C transaction aborted: %w", ctx.Err())
+	}
+	return readBuff, nil
+}
 
-	_, err = d.f.h.Write(cmdfull[:])
-	if (nil != err) {
+// Recover implements BusRecoverer.
+//
+// It resynchronizes the MPSSE engine, then clocks SCL up to 9 times with SDA
+// released until the device in the middle of a byte completes it and releases
+// SDA, and sends a STOP condition. It returns an error if SDA is still held
+// low.
+func (d *i2cBus) Recover() error {
+	d.f.mu.Lock()
+	defer d.f.mu.Unlock()
+	if err := d.resync(); err != nil {
 		return err
 	}
+	cmd := []byte{gpioReadD, flush}
+	for i := 0; ; i++ {
+		v, err := d.readD(cmd)
+		if err != nil {
+			return err
+		}
+		if v&i2cSDAIn != 0 {
+			break
+		}
+		if i == 9 {
+			if _, err := d.f.h.Write(d.setI2CStop()); err != nil {
+				return err
+			}
+			return errors.New("d2xx: I²C SDA is still held low after 9 clocks")
+		}
+		cmd = append(d.setI2CLines(i2cSDAOut), d.setI2CLines(i2cSCL|i2cSDAOut)...)
+		cmd = append(cmd, gpioReadD, flush)
+	}
+	_, err := d.f.h.Write(d.setI2CStop())
+	return err
+}
+
+// readD sends cmd, which must end with a read of the D bus, and returns the
+// levels of D0~D7.
+func (d *i2cBus) readD(cmd []byte) (byte, error) {
+	if _, err := d.f.h.Write(cmd); err != nil {
+		return 0, err
+	}
+	ctx, cancel := context200ms()
+	defer cancel()
+	var b [1]byte
+	if _, err := d.f.h.mpsseReadAll(ctx, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
 
-	_, err = d.f.h.ReadAll(context.Background(), readBuff[:])
-	if (nil != err) {
+// resync resets the MPSSE engine after an aborted transaction and restores
+// the I²C configuration.
+//
+// The commands still queued, e.g. waiting on a clock held low, are discarded
+// with the replies not read yet.
+func (d *i2cBus) resync() error {
+	if err := d.f.h.SetBitMode(0, bitModeReset); err != nil {
 		return err
 	}
+	if err := d.f.h.SetBitMode(0, bitModeMpsse); err != nil {
+		return err
+	}
+	if err := d.f.h.Flush(); err != nil {
+		return err
+	}
+	if err := d.f.h.mpsseVerify(); err != nil {
+		return err
+	}
+	if err := d.setupI2C(d.pullUp); err != nil {
+		return err
+	}
+	cmd := []byte{gpioSetC, d.f.cbus.value, d.f.cbus.direction}
+	if d.stretch {
+		cmd = append(cmd, clockAdaptive)
+	}
+	_, err := d.f.h.Write(cmd)
+	return err
+}
 
-	// verify acks
-	var	iCnt		int
-	for iCnt = 0; iCnt < (readCnt - len(r)); iCnt ++ {
-		if (readBuff[iCnt] & 0x01) != 0 {
-			return errors.New("got NAK")
-		}
+// speedCmd returns the commands switching the clock to the speed of the
+// device at addr, if it is not already set.
+func (d *i2cBus) speedCmd(addr uint16) []byte {
+	f, ok := d.devSpeed[addr]
+	if !ok {
+		f = d.speed
 	}
+	if f == d.clk {
+		return nil
+	}
+	d.clk = f
+	cmd, _ := d.clockCmd(f, d.twoPhase)
+	return cmd
+}
 
-	// set Recv Data
-	for iCnt = 0; iCnt < len(r); iCnt ++ {
-		r[iCnt] = readBuff[(readCnt - len(r)) + iCnt]
+// clockCmd returns the commands setting SCL to f, or 400kHz when f is 0, and
+// the resulting waveform.
+//
+// With 3 phase clocking, each bit lasts 3 half periods of the MPSSE clock:
+// SCL is low for 2 of them and high for the last one.
+func (d *i2cBus) clockCmd(f physic.Frequency, twoPhase bool) ([]byte, ClockTiming) {
+	if f == 0 {
+		f = 400 * physic.KiloHertz
+	}
+	clk, phases := clock30MHz, clock3Phase
+	// base is the SCL frequency with a divisor of 1.
+	base := 30 * physic.MegaHertz * 2 / 3
+	c := ClockTiming{Duty: gpio.DutyMax / 3}
+	if twoPhase {
+		phases = clock2Phase
+		base = 30 * physic.MegaHertz
+		c.Duty = gpio.DutyHalf
 	}
+	// Round up the divisor so SCL doesn't exceed f.
+	div := (base + f - 1) / f
+	if div > 65536 {
+		// Enable the clock divide-by-5.
+		clk = clock6MHz
+		base /= 5
+		div = (base + f - 1) / f
+	}
+	c.Frequency = base / div
+	return []byte{clk, phases, clockSetDivisor, byte(div - 1), byte((div - 1) >> 8)}, c
+}
 
+// reply verifies the ACKs of a transaction to addr read back in readBuff,
+// then copies the data read into r.
+//
+// readBuff starts with the ACKs of the write phase, the address, 2 bytes for
+// a 10 bits address, then each byte, followed by the ACK of the read address
+// if r is not empty.
+func (d *i2cBus) reply(readBuff []byte, addr uint16, r []byte) error {
+	acks := len(readBuff) - len(r)
+	addrLen := 1
+	if addr >= 0x80 {
+		addrLen = 2
+	}
+	for i, b := range readBuff[:acks] {
+		if b&0x01 != 0 && !d.nak.Ignore {
+			if len(r) != 0 && i == acks-1 {
+				return &NAKError{Addr: addr, Index: -1, Read: true}
+			}
+			if i < addrLen {
+				return &NAKError{Addr: addr, Index: -1}
+			}
+			return &NAKError{Addr: addr, Index: i - addrLen}
+		}
+	}
+	copy(r, readBuff[acks:])
 	return nil
 }
 
+// address_byte returns the address byte, or the first byte of a 10 bits
+// address, which is 0b11110 followed by the 2 high bits of the address.
 func (d *i2cBus) address_byte(uiAddr uint16, bRead bool) byte {
 	var byAddr byte
 
-	if (bRead == true) {
-		byAddr = byte((uiAddr << 1) | 0x01)
+	if uiAddr >= 0x80 {
+		byAddr = byte(0xF0 | (uiAddr>>7)&6)
 	} else {
-		byAddr = byte((uiAddr << 1) & 0xFE)
+		byAddr = byte(uiAddr << 1)
+	}
+	if bRead {
+		byAddr |= 0x01
 	}
 
 	return byAddr
 }
 
+// checkI2CSpeed returns an error if f is not a supported I²C speed.
+func checkI2CSpeed(f physic.Frequency) error {
+	if f > 10*physic.MegaHertz {
+		return fmt.Errorf("d2xx: invalid speed %s; maximum supported clock is 10MHz", f)
+	}
+	if f < 100*physic.Hertz {
+		return fmt.Errorf("d2xx: invalid speed %s; minimum supported clock is 100Hz; did you forget to multiply by physic.KiloHertz?", f)
+	}
+	return nil
+}
+
+// checkI2CAddr returns an error if addr is neither a 7 bits nor a 10 bits
+// address.
+func checkI2CAddr(addr uint16) error {
+	if addr >= 0x400 {
+		return fmt.Errorf("d2xx: invalid I²C address %#x; 10 bits addresses are at most 0x3ff", addr)
+	}
+	return nil
+}
+
 // writeBytes writes multiple bytes within an I²C transaction.
 //
 // Does not touch D3~D7.
@@ -478,7 +1093,7 @@ func (d *i2cBus) writeBytes(w []byte) error {
 	if _, err := d.f.h.Write(cmdfull[:]); err != nil {
 		return err
 	}
-	if _, err := d.f.h.ReadAll(context.Background(), readBuff[:]); err != nil {
+	if _, err := d.f.h.mpsseReadAll(context.Background(), readBuff[:]); err != nil {
 		return err
 	}
 	//if r[0]&1 == 0 {
@@ -546,7 +1161,7 @@ func (d *i2cBus) readBytes(r []byte) error {
 	if _, err := d.f.h.Write(cmdfull[:]); err != nil {
 		return err
 	}
-	if _, err := d.f.h.ReadAll(context.Background(), r[:]); err != nil {
+	if _, err := d.f.h.mpsseReadAll(context.Background(), r[:]); err != nil {
 		return err
 	}
 	return nil
@@ -554,3 +1169,13 @@ func (d *i2cBus) readBytes(r []byte) error {
 
 var _ i2c.BusCloser = &i2cBus{}
 var _ i2c.Pins = &i2cBus{}
+var _ RepeatedStarter = &i2cBus{}
+var _ ClockStretcher = &i2cBus{}
+var _ ContextTxer = &i2cBus{}
+var _ Pipeliner = &i2cBus{}
+var _ NAKHandler = &i2cBus{}
+var _ Scanner = &i2cBus{}
+var _ BusRecoverer = &i2cBus{}
+var _ Arbitrator = &i2cBus{}
+var _ ClockTuner = &i2cBus{}
+var _ DeviceSpeeder = &i2cBus{}
@@ -0,0 +1,476 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestI2CBus_Tx_repeatedStart(t *testing.T) {
+	// 3 ACKs for the address and the register, the read address, then 2 bytes.
+	h := &recordHandle{replies: [][]byte{{0, 0, 0, 0x12, 0x34}}}
+	d := newTestI2CBus(h)
+	r := make([]byte, 2)
+	if err := d.Tx(0x76, []byte{0xD0}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{0x12, 0x34}) {
+		t.Fatalf("%#x", r)
+	}
+	// Only the final stop.
+	if n := bytes.Count(h.w, d.setI2CStop()); n != 1 {
+		t.Fatalf("%d stops", n)
+	}
+	if !bytes.Contains(h.w, d.setI2CRepeatedStart()) {
+		t.Fatal("missing repeated start")
+	}
+	if !bytes.Contains(h.w, []byte{dataOut | dataOutFall, 0, 0, 0x76<<1 | 1}) {
+		t.Fatal("missing read address")
+	}
+}
+
+func TestI2CBus_Tx_stopBeforeRead(t *testing.T) {
+	h := &recordHandle{replies: [][]byte{{0, 0, 0, 0x12}}}
+	d := newTestI2CBus(h)
+	var s RepeatedStarter = d
+	s.SetRepeatedStart(false)
+	r := make([]byte, 1)
+	if err := d.Tx(0x76, []byte{0xD0}, r); err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(h.w, d.setI2CStop()); n != 2 {
+		t.Fatalf("%d stops", n)
+	}
+	if bytes.Contains(h.w, d.setI2CRepeatedStart()) {
+		t.Fatal("unexpected repeated start")
+	}
+}
+
+func TestI2CBus_Tx_read(t *testing.T) {
+	// Reads without a register address use the read address right away.
+	h := &recordHandle{replies: [][]byte{{0, 0xAB}}}
+	d := newTestI2CBus(h)
+	r := make([]byte, 1)
+	if err := d.Tx(0x40, nil, r); err != nil {
+		t.Fatal(err)
+	}
+	if r[0] != 0xAB {
+		t.Fatalf("%#x", r)
+	}
+	if bytes.Contains(h.w, []byte{dataOut | dataOutFall, 0, 0, 0x40 << 1}) {
+		t.Fatal("unexpected write address")
+	}
+}
+
+func TestI2CBus_Tx_NAK(t *testing.T) {
+	h := &recordHandle{replies: [][]byte{{1, 0}}}
+	d := newTestI2CBus(h)
+	err := d.Tx(0x40, []byte{0}, nil)
+	if e, ok := err.(*NAKError); !ok || *e != (NAKError{Addr: 0x40, Index: -1}) {
+		t.Fatal(err)
+	}
+	// The second byte written.
+	h.replies = [][]byte{{0, 0, 1}}
+	err = d.Tx(0x40, []byte{0, 1}, nil)
+	if err == nil || err.Error() != "d2xx: I²C device 0x40 got NAK on byte 1" {
+		t.Fatal(err)
+	}
+	// The read address.
+	h.replies = [][]byte{{0, 0, 1, 0x12}}
+	r := []byte{0}
+	err = d.Tx(0x40, []byte{0}, r)
+	if e, ok := err.(*NAKError); !ok || !e.Read || r[0] != 0 {
+		t.Fatal(err, r)
+	}
+}
+
+func TestI2CBus_Tx_tenBits(t *testing.T) {
+	// 2 address bytes, the register, the read address, then 1 byte.
+	h := &recordHandle{replies: [][]byte{{0, 0, 0, 0, 0x12}}}
+	d := newTestI2CBus(h)
+	// A repeated start is required even if disabled.
+	d.SetRepeatedStart(false)
+	r := make([]byte, 1)
+	if err := d.Tx(0x2A5, []byte{0xD0}, r); err != nil {
+		t.Fatal(err)
+	}
+	if r[0] != 0x12 {
+		t.Fatalf("%#x", r)
+	}
+	for _, b := range []byte{0xF4, 0xA5, 0xD0, 0xF5} {
+		if !bytes.Contains(h.w, []byte{dataOut | dataOutFall, 0, 0, b}) {
+			t.Fatalf("missing %#x", b)
+		}
+	}
+	if n := bytes.Count(h.w, d.setI2CStop()); n != 1 {
+		t.Fatalf("%d stops", n)
+	}
+	// The low byte of the address is not acknowledged.
+	h.replies = [][]byte{{0, 1, 0}}
+	err := d.Tx(0x2A5, []byte{0xD0}, nil)
+	if e, ok := err.(*NAKError); !ok || e.Index != -1 || e.Read {
+		t.Fatal(err)
+	}
+	if err := d.Tx(0x400, nil, nil); err == nil || err.Error() != "d2xx: invalid I²C address 0x400; 10 bits addresses are at most 0x3ff" {
+		t.Fatal(err)
+	}
+	if err := d.TxPipelined([]I2CTx{{Addr: 0x400}}); err == nil {
+		t.Fatal("invalid address")
+	}
+}
+
+func TestI2CBus_Scan(t *testing.T) {
+	reply := make([]byte, 0x77-0x08+1)
+	for i := range reply {
+		reply[i] = 1
+	}
+	reply[0x3C-0x08] = 0
+	reply[0x76-0x08] = 0
+	h := &recordHandle{replies: [][]byte{reply}}
+	d := newTestI2CBus(h)
+	// The policy is not applied.
+	if err := d.SetNAKPolicy(NAKPolicy{Retries: 3}); err != nil {
+		t.Fatal(err)
+	}
+	var s Scanner = d
+	got, err := s.Scan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != 0x3C || got[1] != 0x76 {
+		t.Fatalf("%#x", got)
+	}
+	if n := bytes.Count(h.w, d.setI2CStop()); n != len(reply) {
+		t.Fatalf("%d stops", n)
+	}
+}
+
+func TestI2CBus_SetNAKPolicy(t *testing.T) {
+	h := &recordHandle{replies: [][]byte{{1, 0}, {1, 0}, {0, 0}}}
+	d := newTestI2CBus(h)
+	var n NAKHandler = d
+	if err := n.SetNAKPolicy(NAKPolicy{Retries: -1}); err == nil {
+		t.Fatal("invalid policy")
+	}
+	if err := n.SetNAKPolicy(NAKPolicy{Retries: 2, Backoff: time.Microsecond}); err != nil {
+		t.Fatal(err)
+	}
+	// Succeeds on the third attempt.
+	if err := d.Tx(0x50, []byte{0}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(h.replies) != 0 {
+		t.Fatal(len(h.replies))
+	}
+	h.replies = [][]byte{{1, 0}, {1, 0}, {1, 0}}
+	if err := d.Tx(0x50, []byte{0}, nil); err == nil {
+		t.Fatal("expected NAK")
+	}
+	if err := n.SetNAKPolicy(NAKPolicy{Ignore: true}); err != nil {
+		t.Fatal(err)
+	}
+	h.replies = [][]byte{{1, 1, 1, 0x12}}
+	r := []byte{0}
+	if err := d.Tx(0x50, []byte{0}, r); err != nil || r[0] != 0x12 {
+		t.Fatal(err, r)
+	}
+}
+
+func TestI2CBus_SetArbitration(t *testing.T) {
+	idle := []byte{i2cSCL | i2cSDAIn}
+	// Another master drove the second byte low, then the bus is busy, then
+	// the transaction succeeds.
+	h := &recordHandle{replies: [][]byte{idle, {0xA0, 0, 0x00, 0}, {i2cSCL}, idle, {0xA0, 0, 0x12, 0}}}
+	d := newTestI2CBus(h)
+	var a Arbitrator = d
+	if err := a.SetArbitration(ArbitrationPolicy{Retries: -1}); err == nil {
+		t.Fatal("invalid policy")
+	}
+	if err := a.SetArbitration(ArbitrationPolicy{Detect: true, Retries: 2, Backoff: time.Microsecond}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Tx(0x50, []byte{0x12}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(h.replies) != 0 {
+		t.Fatal(len(h.replies))
+	}
+	if !bytes.Contains(h.w, []byte{dataOut | dataOutFall | dataIn, 0, 0, 0x12}) {
+		t.Fatalf("%#x", h.w)
+	}
+	h.replies = [][]byte{idle, {0xA0, 0, 0x12, 0}}
+	if err := a.SetArbitration(ArbitrationPolicy{Detect: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Tx(0x50, []byte{0x13}, nil); !errors.Is(err, ErrArbitrationLost) {
+		t.Fatal(err)
+	}
+	// The data read follows the bytes written.
+	h.replies = [][]byte{idle, {0xA1, 0, 0x34}}
+	r := []byte{0}
+	if err := d.Tx(0x50, []byte{}, r); err != nil || r[0] != 0x34 {
+		t.Fatal(err, r)
+	}
+	d.pullUp = true
+	if err := a.SetArbitration(ArbitrationPolicy{Detect: true}); err == nil {
+		t.Fatal("requires open drain")
+	}
+}
+
+func TestI2CBus_SetTimeout(t *testing.T) {
+	// The device never answers, then the MPSSE is resynchronized.
+	h := &recordHandle{replies: append([][]byte{nil}, mpsseVerifyReplies()...)}
+	d := newTestI2CBus(h)
+	var c ContextTxer = d
+	if err := c.SetTimeout(-1); err == nil {
+		t.Fatal("invalid timeout")
+	}
+	if err := c.SetTimeout(time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	err := d.Tx(0x50, []byte{0}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal(err)
+	}
+	if len(h.replies) != 0 || bitMode(h.mode) != bitModeMpsse || !d.f.usingI2C {
+		t.Fatal("not resynchronized")
+	}
+	// The bus works again.
+	h.replies = [][]byte{{0, 0}}
+	if err := d.Tx(0x50, []byte{0}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestI2CBus_Recover(t *testing.T) {
+	// The writes of resync() that have no reply, then SDA released after 2
+	// clocks.
+	replies := append(mpsseVerifyReplies(), nil, nil, nil, []byte{0}, []byte{0}, []byte{i2cSDAIn})
+	h := &recordHandle{replies: replies}
+	d := newTestI2CBus(h)
+	var r BusRecoverer = d
+	if err := r.Recover(); err != nil {
+		t.Fatal(err)
+	}
+	if len(h.replies) != 0 || bitMode(h.mode) != bitModeMpsse || !d.f.usingI2C {
+		t.Fatal("not resynchronized")
+	}
+	// 2 clocks with SDA released, each followed by a read of SDA, then a STOP.
+	clk := append(d.setI2CLines(i2cSDAOut), d.setI2CLines(i2cSCL|i2cSDAOut)...)
+	clk = append(clk, gpioReadD, flush)
+	want := append([]byte{gpioReadD, flush}, bytes.Repeat(clk, 2)...)
+	want = append(want, d.setI2CStop()...)
+	if !bytes.HasSuffix(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+}
+
+func TestI2CBus_Recover_stuck(t *testing.T) {
+	replies := append(mpsseVerifyReplies(), nil, nil, nil)
+	for i := 0; i < 10; i++ {
+		replies = append(replies, []byte{0})
+	}
+	h := &recordHandle{replies: replies}
+	d := newTestI2CBus(h)
+	if err := d.Recover(); err == nil {
+		t.Fatal("expected error")
+	}
+	if !bytes.HasSuffix(h.w, d.setI2CStop()) {
+		t.Fatalf("%#x", h.w)
+	}
+}
+
+func TestI2CBus_TxContext(t *testing.T) {
+	h := &recordHandle{}
+	d := newTestI2CBus(h)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := d.TxContext(ctx, 0x50, []byte{0}, nil); !errors.Is(err, context.Canceled) {
+		t.Fatal(err)
+	}
+	if len(h.w) != 0 {
+		t.Fatalf("%#x", h.w)
+	}
+}
+
+func TestI2CBus_TxPipelined(t *testing.T) {
+	// A single reply for the 3 transactions: a register read, a write that is
+	// not acknowledged and another register read.
+	h := &recordHandle{replies: [][]byte{{0, 0, 0, 0x12, 1, 0, 0, 0, 0, 0x34, 0x56}}}
+	d := newTestI2CBus(h)
+	var p Pipeliner = d
+	r1 := make([]byte, 1)
+	r3 := make([]byte, 2)
+	txs := []I2CTx{
+		{Addr: 0x76, W: []byte{0xD0}, R: r1},
+		{Addr: 0x40, W: []byte{0x01}},
+		{Addr: 0x77, W: []byte{0xF7}, R: r3},
+	}
+	err := p.TxPipelined(txs)
+	if err == nil || err.Error() != "d2xx: I²C device 0x40 got NAK on the address in transaction 1" {
+		t.Fatal(err)
+	}
+	if r1[0] != 0x12 || !bytes.Equal(r3, []byte{0x34, 0x56}) {
+		t.Fatalf("%#x %#x", r1, r3)
+	}
+	// All the transactions were sent in a single write.
+	if n := bytes.Count(h.w, d.setI2CStop()); n != 3 {
+		t.Fatalf("%d stops", n)
+	}
+	if bytes.IndexByte(h.w, flush) != len(h.w)-1 {
+		t.Fatalf("%#x", h.w)
+	}
+	if err := p.TxPipelined(nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestI2CBus_SetClockStretching(t *testing.T) {
+	h := &recordHandle{}
+	d := newTestI2CBus(h)
+	d.f.dbus.direction |= i2cRTCK
+	var s ClockStretcher = d
+	if err := s.SetClockStretching(true); err != nil {
+		t.Fatal(err)
+	}
+	if d.f.dbus.direction&i2cRTCK != 0 {
+		t.Fatal("D7 must be an input")
+	}
+	if h.w[len(h.w)-1] != clockAdaptive {
+		t.Fatalf("%#x", h.w)
+	}
+	// Closing the bus disables adaptive clocking.
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if h.w[len(h.w)-1] != clockNormal || d.stretch {
+		t.Fatalf("%#x", h.w)
+	}
+}
+
+func TestI2CBus_SetClockTuning(t *testing.T) {
+	h := &recordHandle{}
+	d := newTestI2CBus(h)
+	var c ClockTuner = d
+	got, err := c.SetClockTuning(ClockTuning{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Frequency != 400*physic.KiloHertz || got.Duty != gpio.DutyMax/3 {
+		t.Fatal(got)
+	}
+	// As documented in AN_255.
+	if !bytes.Equal(h.w, []byte{clock30MHz, clock3Phase, clockSetDivisor, 49, 0}) {
+		t.Fatalf("%#x", h.w)
+	}
+	if err := d.SetSpeed(200 * physic.Hertz); err != nil {
+		t.Fatal(err)
+	}
+	h.w = nil
+	if got, err = c.SetClockTuning(ClockTuning{TwoPhase: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Frequency != 200*physic.Hertz || got.Duty != gpio.DutyHalf {
+		t.Fatal(got)
+	}
+	if !bytes.Equal(h.w, []byte{clock6MHz, clock2Phase, clockSetDivisor, 0x2F, 0x75}) {
+		t.Fatalf("%#x", h.w)
+	}
+	// Rounded down.
+	if err := d.SetSpeed(7 * physic.MegaHertz); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = c.SetClockTuning(ClockTuning{TwoPhase: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Frequency != 6*physic.MegaHertz {
+		t.Fatal(got)
+	}
+}
+
+func TestI2CBus_SetDeviceSpeed(t *testing.T) {
+	h := &recordHandle{replies: [][]byte{{0, 0}, {0, 0}, {0, 0}}}
+	d := newTestI2CBus(h)
+	var s DeviceSpeeder = d
+	if err := s.SetDeviceSpeed(0x50, physic.Hertz); err == nil {
+		t.Fatal("invalid speed")
+	}
+	if err := s.SetDeviceSpeed(0x50, 100*physic.KiloHertz); err != nil {
+		t.Fatal(err)
+	}
+	slow, _ := d.clockCmd(100*physic.KiloHertz, false)
+	fast, _ := d.clockCmd(0, false)
+	if err := d.Tx(0x50, []byte{0}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(h.w, slow) {
+		t.Fatalf("%#x", h.w)
+	}
+	// Already at the right speed.
+	h.w = nil
+	if err := d.Tx(0x50, []byte{0}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(h.w, []byte{clockSetDivisor}) {
+		t.Fatalf("%#x", h.w)
+	}
+	// Back to the bus speed.
+	h.w = nil
+	if err := d.Tx(0x51, []byte{0}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(h.w, fast) {
+		t.Fatalf("%#x", h.w)
+	}
+}
+
+func TestI2CBus_pullUp(t *testing.T) {
+	h := &recordHandle{}
+	d := newTestI2CBus(h)
+	if err := d.setupI2C(true); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.IndexByte(h.w, dataTristate) != -1 {
+		t.Fatalf("unexpected tristate: %#x", h.w)
+	}
+	// High is In(PullUp), low is Out(Low).
+	if c := d.setI2CLines(i2cSCL | i2cSDAOut); !bytes.Equal(c[:3], []byte{gpioSetD, 0, 0}) {
+		t.Fatalf("%#x", c)
+	}
+	if c := d.setI2CLines(i2cSCL); !bytes.Equal(c[:3], []byte{gpioSetD, 0, i2cSDAOut}) {
+		t.Fatalf("%#x", c)
+	}
+	// D1 is driven only to shift the bits out.
+	c := d.setI2CWriteBytes([]byte{0xA5})
+	want := []byte{gpioSetD, i2cSDAOut, i2cSCL | i2cSDAOut, dataOut | dataOutFall, 0, 0, 0xA5, gpioSetD, 0, i2cSCL}
+	if !bytes.HasPrefix(c, want) {
+		t.Fatalf("%#x", c)
+	}
+	if err := d.SetClockStretching(true); err == nil {
+		t.Fatal("clock stretching requires open drain")
+	}
+	h.replies = [][]byte{{0, 0, 0, 0x12}}
+	r := make([]byte, 1)
+	if err := d.Tx(0x76, []byte{0xD0}, r); err != nil || r[0] != 0x12 {
+		t.Fatal(r, err)
+	}
+}
+
+//
+
+func newTestI2CBus(h *recordHandle) *i2cBus {
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	f.i.f = f
+	// As set by setupI2C().
+	f.dbus.direction = i2cSCL | i2cSDAOut
+	return &f.i
+}
@@ -0,0 +1,411 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// TAPState is a state of the JTAG test access port controller.
+type TAPState uint8
+
+// TAP controller states, as defined by IEEE 1149.1.
+const (
+	TAPTestLogicReset TAPState = iota
+	TAPRunTestIdle
+	TAPSelectDR
+	TAPCaptureDR
+	TAPShiftDR
+	TAPExit1DR
+	TAPPauseDR
+	TAPExit2DR
+	TAPUpdateDR
+	TAPSelectIR
+	TAPCaptureIR
+	TAPShiftIR
+	TAPExit1IR
+	TAPPauseIR
+	TAPExit2IR
+	TAPUpdateIR
+)
+
+const tapStateName = "TestLogicResetRunTestIdleSelectDRCaptureDRShiftDRExit1DRPauseDRExit2DRUpdateDRSelectIRCaptureIRShiftIRExit1IRPauseIRExit2IRUpdateIR"
+
+var tapStateIndex = [...]uint8{0, 14, 25, 33, 42, 49, 56, 63, 70, 78, 86, 95, 102, 109, 116, 123, 131}
+
+func (i TAPState) String() string {
+	if i >= TAPState(len(tapStateIndex)-1) {
+		return "TAPState(" + strconv.Itoa(int(i)) + ")"
+	}
+	return tapStateName[tapStateIndex[i]:tapStateIndex[i+1]]
+}
+
+// JTAG returns a JTAG probe over the AD bus.
+//
+// It uses D0, D1, D2 and D3. D0 is TCK, D1 is TDI, D2 is TDO and D3 is TMS.
+// TDI and TMS change on the falling edge of TCK and TDO is sampled on the
+// rising edge.
+//
+// The TAP controllers are reset and left in Run-Test/Idle.
+func (f *FT232H) JTAG(freq physic.Frequency) (*JTAG, error) {
+	if freq > 30*physic.MegaHertz {
+		return nil, fmt.Errorf("d2xx: invalid speed %s; maximum supported clock is 30MHz", freq)
+	}
+	if freq < 100*physic.Hertz {
+		return nil, fmt.Errorf("d2xx: invalid speed %s; minimum supported clock is 100Hz; did you forget to multiply by physic.MegaHertz?", freq)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.canUseBitMode(); err != nil {
+		return nil, err
+	}
+	if _, err := f.h.Write([]byte{clock2Phase, clockNormal, internalLoopbackDisable}); err != nil {
+		return nil, err
+	}
+	if _, err := f.h.MPSSEClock(freq); err != nil {
+		return nil, err
+	}
+	// TCK low, TMS high.
+	const mask = 0xFF &^ (jtagTCK | jtagTDI | jtagTDO | jtagTMS)
+	f.dbus.direction = f.dbus.direction&mask | jtagTCK | jtagTDI | jtagTMS
+	f.dbus.value = f.dbus.value&mask | jtagTMS
+	if err := f.h.MPSSEDBus(f.dbus.direction, f.dbus.value); err != nil {
+		return nil, err
+	}
+	f.usingJTAG = true
+	j := &JTAG{f: f}
+	if err := j.resetLocked(); err != nil {
+		f.usingJTAG = false
+		return nil, err
+	}
+	return j, nil
+}
+
+// JTAG is a JTAG probe on the MPSSE of a FT232H.
+//
+// The data buffers hold the bits least significant bit first: bit i is
+// b[i/8]>>(i%8)&1, which is the order they are shifted in and out.
+type JTAG struct {
+	f     *FT232H
+	state TAPState
+}
+
+func (j *JTAG) String() string {
+	return j.f.String()
+}
+
+// Close stops using D0~D3 as a JTAG probe.
+func (j *JTAG) Close() error {
+	j.f.mu.Lock()
+	defer j.f.mu.Unlock()
+	j.f.usingJTAG = false
+	return nil
+}
+
+// State returns the current state of the TAP controllers.
+func (j *JTAG) State() TAPState {
+	j.f.mu.Lock()
+	defer j.f.mu.Unlock()
+	return j.state
+}
+
+// Reset resets the TAP controllers with TMS high for 5 clocks, then goes to
+// Run-Test/Idle.
+//
+// After a reset, the instruction register of each device is IDCODE or BYPASS.
+func (j *JTAG) Reset() error {
+	j.f.mu.Lock()
+	defer j.f.mu.Unlock()
+	return j.resetLocked()
+}
+
+// GoTo moves the TAP controllers to state s by the shortest path.
+func (j *JTAG) GoTo(s TAPState) error {
+	if s > TAPUpdateIR {
+		return errors.New("d2xx: invalid TAP state")
+	}
+	j.f.mu.Lock()
+	defer j.f.mu.Unlock()
+	return j.goTo(s)
+}
+
+// RunTest clocks TCK n times in Run-Test/Idle.
+func (j *JTAG) RunTest(n int) error {
+	j.f.mu.Lock()
+	defer j.f.mu.Unlock()
+	if err := j.goTo(TAPRunTestIdle); err != nil {
+		return err
+	}
+	tms := make([]bool, n)
+	return j.clockTMS(tms)
+}
+
+// ShiftIR shifts bits into the instruction registers, from w, and
+// returns the bits shifted out in r, then goes to Run-Test/Idle.
+//
+// w or r can be nil. When w is nil, ones are shifted in, which selects BYPASS.
+func (j *JTAG) ShiftIR(w, r []byte, bits int) error {
+	j.f.mu.Lock()
+	defer j.f.mu.Unlock()
+	return j.shift(TAPShiftIR, w, r, bits)
+}
+
+// ShiftDR shifts bits into the selected data registers, from w, and returns
+// the bits shifted out in r, then goes to Run-Test/Idle.
+//
+// w or r can be nil. When w is nil, zeros are shifted in.
+func (j *JTAG) ShiftDR(w, r []byte, bits int) error {
+	j.f.mu.Lock()
+	defer j.f.mu.Unlock()
+	return j.shift(TAPShiftDR, w, r, bits)
+}
+
+// ScanIDCodes resets the TAP controllers and returns the IDCODE of each
+// device on the chain, starting with the one closest to TDO.
+//
+// A device without IDCODE register is in BYPASS after reset and is reported
+// as 0.
+func (j *JTAG) ScanIDCodes() ([]uint32, error) {
+	j.f.mu.Lock()
+	defer j.f.mu.Unlock()
+	if err := j.resetLocked(); err != nil {
+		return nil, err
+	}
+	// Shift ones in; the end of the chain is reached when they come out.
+	const bits = 32 * (jtagMaxDevices + 1)
+	w := make([]byte, bits/8)
+	for i := range w {
+		w[i] = 0xFF
+	}
+	r := make([]byte, bits/8)
+	if err := j.shift(TAPShiftDR, w, r, bits); err != nil {
+		return nil, err
+	}
+	var out []uint32
+	for i := 0; i+32 <= bits; {
+		if r[i/8]>>uint(i%8)&1 == 0 {
+			// BYPASS is a single 0 bit.
+			out = append(out, 0)
+			i++
+			continue
+		}
+		var id uint32
+		for k := 0; k < 32; k++ {
+			id |= uint32(r[(i+k)/8]>>uint((i+k)%8)&1) << uint(k)
+		}
+		if id == 0xFFFFFFFF {
+			return out, nil
+		}
+		out = append(out, id)
+		i += 32
+	}
+	return out, fmt.Errorf("d2xx: more than %d devices on the JTAG chain, or TDO is stuck", jtagMaxDevices)
+}
+
+//
+
+// Pins on the AD bus.
+const (
+	jtagTCK byte = 0x01 // D0
+	jtagTDI byte = 0x02 // D1
+	jtagTDO byte = 0x04 // D2
+	jtagTMS byte = 0x08 // D3
+
+	jtagMaxDevices = 32
+)
+
+// tapNext is the next state for TMS low and high.
+var tapNext = [...][2]TAPState{
+	TAPTestLogicReset: {TAPRunTestIdle, TAPTestLogicReset},
+	TAPRunTestIdle:    {TAPRunTestIdle, TAPSelectDR},
+	TAPSelectDR:       {TAPCaptureDR, TAPSelectIR},
+	TAPCaptureDR:      {TAPShiftDR, TAPExit1DR},
+	TAPShiftDR:        {TAPShiftDR, TAPExit1DR},
+	TAPExit1DR:        {TAPPauseDR, TAPUpdateDR},
+	TAPPauseDR:        {TAPPauseDR, TAPExit2DR},
+	TAPExit2DR:        {TAPShiftDR, TAPUpdateDR},
+	TAPUpdateDR:       {TAPRunTestIdle, TAPSelectDR},
+	TAPSelectIR:       {TAPCaptureIR, TAPTestLogicReset},
+	TAPCaptureIR:      {TAPShiftIR, TAPExit1IR},
+	TAPShiftIR:        {TAPShiftIR, TAPExit1IR},
+	TAPExit1IR:        {TAPPauseIR, TAPUpdateIR},
+	TAPPauseIR:        {TAPPauseIR, TAPExit2IR},
+	TAPExit2IR:        {TAPShiftIR, TAPUpdateIR},
+	TAPUpdateIR:       {TAPRunTestIdle, TAPSelectDR},
+}
+
+// tapPath returns the shortest TMS sequence to go from one state to another.
+func tapPath(from, to TAPState) []bool {
+	var prev [len(tapNext)]TAPState
+	var tms [len(tapNext)]bool
+	var seen [len(tapNext)]bool
+	seen[from] = true
+	for q := []TAPState{from}; len(q) != 0; q = q[1:] {
+		for i, n := range tapNext[q[0]] {
+			if !seen[n] {
+				seen[n] = true
+				prev[n] = q[0]
+				tms[n] = i == 1
+				q = append(q, n)
+			}
+		}
+	}
+	var out []bool
+	for s := to; s != from; s = prev[s] {
+		out = append([]bool{tms[s]}, out...)
+	}
+	return out
+}
+
+// resetLocked resets the TAP controllers and goes to Run-Test/Idle.
+func (j *JTAG) resetLocked() error {
+	if err := j.clockTMS([]bool{true, true, true, true, true, false}); err != nil {
+		return err
+	}
+	j.state = TAPRunTestIdle
+	return nil
+}
+
+func (j *JTAG) goTo(s TAPState) error {
+	if !j.f.usingJTAG {
+		return errors.New("d2xx: JTAG is closed")
+	}
+	if s == TAPTestLogicReset {
+		if err := j.clockTMS([]bool{true, true, true, true, true}); err != nil {
+			return err
+		}
+		j.state = s
+		return nil
+	}
+	if err := j.clockTMS(tapPath(j.state, s)); err != nil {
+		return err
+	}
+	j.state = s
+	return nil
+}
+
+// clockTMS clocks the TMS values, with TDI high.
+//
+// It doesn't update j.state.
+func (j *JTAG) clockTMS(tms []bool) error {
+	var cmd []byte
+	for len(tms) != 0 {
+		// Up to 7 bits per command.
+		n := len(tms)
+		if n > 7 {
+			n = 7
+		}
+		v := byte(0x80)
+		for i := 0; i < n; i++ {
+			if tms[i] {
+				v |= 1 << uint(i)
+			}
+		}
+		cmd = append(cmd, tmsOutLSBFFall, byte(n-1), v)
+		tms = tms[n:]
+	}
+	if len(cmd) == 0 {
+		return nil
+	}
+	_, err := j.f.h.Write(cmd)
+	return err
+}
+
+// shift shifts bits through the instruction or data register selected by
+// state, then goes to Run-Test/Idle.
+//
+// The last bit is shifted while leaving the Shift state.
+func (j *JTAG) shift(state TAPState, w, r []byte, bits int) error {
+	if bits <= 0 {
+		return errors.New("d2xx: bits must be positive")
+	}
+	if (w != nil && len(w)*8 < bits) || (r != nil && len(r)*8 < bits) {
+		return errors.New("d2xx: buffer too short")
+	}
+	if err := j.goTo(state); err != nil {
+		return err
+	}
+	fill := byte(0)
+	if state == TAPShiftIR {
+		fill = 0xFF
+	}
+	get := func(i int) byte {
+		if w == nil {
+			return fill >> uint(i%8) & 1
+		}
+		return w[i/8] >> uint(i%8) & 1
+	}
+	op := dataOut | dataOutFall | dataLSBF
+	tmsOp := tmsOutLSBFFall
+	if r != nil {
+		op |= dataIn
+		tmsOp = tmsIOLSBInFall
+	}
+
+	// The first bits-1 bits, in bytes then in bits.
+	var cmd []byte
+	n := bits - 1
+	full := n / 8
+	for i := 0; i < full; {
+		l := full - i
+		if l > 65536 {
+			l = 65536
+		}
+		cmd = append(cmd, op, byte(l-1), byte((l-1)>>8))
+		for k := 0; k < l; k++ {
+			if w == nil {
+				cmd = append(cmd, fill)
+			} else {
+				cmd = append(cmd, w[i+k])
+			}
+		}
+		i += l
+	}
+	rem := n % 8
+	if rem != 0 {
+		v := byte(0)
+		for k := 0; k < rem; k++ {
+			v |= get(full*8+k) << uint(k)
+		}
+		cmd = append(cmd, op|dataBit, byte(rem-1), v)
+	}
+	// The last bit with TMS high to Exit1, then Update and Run-Test/Idle.
+	cmd = append(cmd, tmsOp, 0, get(n)<<7|1)
+	cmd = append(cmd, tmsOutLSBFFall, 1, get(n)<<7|1)
+	if r != nil {
+		cmd = append(cmd, flush)
+	}
+	if _, err := j.f.h.Write(cmd); err != nil {
+		return err
+	}
+	j.state = TAPRunTestIdle
+	if r == nil {
+		return nil
+	}
+
+	in := make([]byte, full+2)
+	if rem == 0 {
+		in = in[:full+1]
+	}
+	ctx, cancel := context200ms()
+	defer cancel()
+	if _, err := j.f.h.mpsseReadAll(ctx, in); err != nil {
+		return err
+	}
+	copy(r, in[:full])
+	for i := full; i < (bits+7)/8; i++ {
+		r[i] = 0
+	}
+	// Bits are shifted in from the most significant bit.
+	if rem != 0 {
+		r[full] = in[full] >> uint(8-rem)
+	}
+	r[n/8] |= in[len(in)-1] >> 7 << uint(n%8)
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestTAPPath(t *testing.T) {
+	data := []struct {
+		from, to TAPState
+		want     []bool
+	}{
+		{TAPRunTestIdle, TAPRunTestIdle, nil},
+		{TAPRunTestIdle, TAPShiftDR, []bool{true, false, false}},
+		{TAPRunTestIdle, TAPShiftIR, []bool{true, true, false, false}},
+		{TAPExit1IR, TAPRunTestIdle, []bool{true, false}},
+		{TAPPauseDR, TAPShiftIR, []bool{true, true, true, true, false, false}},
+		{TAPShiftDR, TAPTestLogicReset, []bool{true, true, true, true, true}},
+	}
+	for i, line := range data {
+		p := tapPath(line.from, line.to)
+		if len(p) != len(line.want) {
+			t.Fatalf("#%d: %v", i, p)
+		}
+		for k := range p {
+			if p[k] != line.want[k] {
+				t.Fatalf("#%d: %v", i, p)
+			}
+		}
+	}
+	if s := TAPShiftIR.String(); s != "ShiftIR" {
+		t.Fatal(s)
+	}
+	if s := TAPState(16).String(); s != "TAPState(16)" {
+		t.Fatal(s)
+	}
+}
+
+func TestJTAG(t *testing.T) {
+	h := &recordHandle{}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	j, err := f.JTAG(physic.MegaHertz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// TMS high for 5 clocks then low.
+	if !bytes.HasSuffix(h.w, []byte{gpioSetD, jtagTMS, jtagTCK | jtagTDI | jtagTMS, tmsOutLSBFFall, 5, 0x9F}) {
+		t.Fatalf("%#x", h.w)
+	}
+	if j.State() != TAPRunTestIdle {
+		t.Fatal(j.State())
+	}
+	if _, err := f.I2C(gpio.PullUp); err == nil || err.Error() != "d2xx: already using JTAG" {
+		t.Fatal(err)
+	}
+
+	// Write only.
+	h.w = nil
+	if err := j.ShiftIR([]byte{0x05}, nil, 4); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		tmsOutLSBFFall, 3, 0x83,
+		dataOut | dataOutFall | dataLSBF | dataBit, 2, 0x05,
+		tmsOutLSBFFall, 0, 0x01,
+		tmsOutLSBFFall, 1, 0x01,
+	}
+	if !bytes.Equal(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	// Read and write.
+	h.w = nil
+	h.replies = [][]byte{{0x3C, 0x80}}
+	r := make([]byte, 2)
+	if err := j.ShiftDR([]byte{0xA5, 0x01}, r, 9); err != nil {
+		t.Fatal(err)
+	}
+	want = []byte{
+		tmsOutLSBFFall, 2, 0x81,
+		dataOut | dataIn | dataOutFall | dataLSBF, 0, 0, 0xA5,
+		tmsIOLSBInFall, 0, 0x81,
+		tmsOutLSBFFall, 1, 0x81,
+		flush,
+	}
+	if !bytes.Equal(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+	if !bytes.Equal(r, []byte{0x3C, 0x01}) {
+		t.Fatalf("%#x", r)
+	}
+	if j.State() != TAPRunTestIdle {
+		t.Fatal(j.State())
+	}
+
+	if err := j.GoTo(TAPPauseDR); err != nil || j.State() != TAPPauseDR {
+		t.Fatal(j.State(), err)
+	}
+	if err := j.RunTest(10); err != nil || j.State() != TAPRunTestIdle {
+		t.Fatal(j.State(), err)
+	}
+
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.ShiftDR(nil, nil, 1); err == nil {
+		t.Fatal("closed")
+	}
+	if _, err := f.SPI(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJTAG_ScanIDCodes(t *testing.T) {
+	// An IDCODE, a device in BYPASS, then the ones shifted in.
+	const bits = 32 * (jtagMaxDevices + 1)
+	dr := make([]byte, bits/8)
+	for i := range dr {
+		dr[i] = 0xFF
+	}
+	dr[0], dr[1], dr[2], dr[3], dr[4] = 0x77, 0x04, 0xA0, 0x4B, 0xFE
+	// The last 8 bits come back as 7 bits then 1 bit, from the top.
+	in := append([]byte{}, dr[:bits/8-1]...)
+	in = append(in, dr[bits/8-1]<<1, dr[bits/8-1]&0x80)
+
+	h := &recordHandle{}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	j, err := f.JTAG(physic.MegaHertz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.replies = [][]byte{nil, in}
+	ids, err := j.ScanIDCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 0x4BA00477 || ids[1] != 0 {
+		t.Fatalf("%#x", ids)
+	}
+}
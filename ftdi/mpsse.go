@@ -141,7 +141,7 @@ const (
 	// <op>, <addrLow>, <data>
 	cpuWriteShort byte = 0x92
 	// <op>, <addrHi>, <addrLow>, <data>
-	cpuWriteFar byte = 0x91
+	cpuWriteFar byte = 0x93
 
 	// Buffer operations.
 	//
@@ -241,6 +241,58 @@ func (h *handle) mpsseVerify() error {
 	return nil
 }
 
+// BadCommandError is returned when the MPSSE rejected a command as invalid,
+// which means the command stream was corrupted, e.g. by a USB error or by
+// another program using the device.
+//
+// The MPSSE replies 0xFA followed by the rejected byte, which shifts the data
+// read. The device is resynchronized before the error is returned, so the
+// next operation can be retried.
+type BadCommandError struct {
+	// Op is the rejected byte, or 0 if it couldn't be determined.
+	Op byte
+}
+
+func (b *BadCommandError) Error() string {
+	return fmt.Sprintf("ftdi: MPSSE rejected bad command %#02x; the data read is invalid", b.Op)
+}
+
+// mpsseReadAll reads the reply to MPSSE commands ending with a flush, which
+// must have been fully read afterward.
+//
+// Bytes left in the queue mean that the MPSSE inserted bad command replies in
+// the stream; see BadCommandError.
+func (h *handle) mpsseReadAll(ctx context.Context, b []byte) (int, error) {
+	n, err := h.ReadAll(ctx, b)
+	if err != nil {
+		return n, err
+	}
+	p, e := h.h.GetQueueStatus()
+	if e != 0 || p == 0 {
+		return n, toErr("Read/GetQueueStatus", e)
+	}
+	extra := make([]byte, p)
+	if _, err := h.ReadAll(ctx, extra); err != nil {
+		return n, err
+	}
+	all := append(append([]byte{}, b...), extra...)
+	err = &BadCommandError{}
+	for i := 0; i+1 < len(all); i++ {
+		if all[i] == 0xFA {
+			err = &BadCommandError{Op: all[i+1]}
+			break
+		}
+	}
+	logf("%v; resynchronizing", err)
+	if err2 := h.Flush(); err2 != nil {
+		return n, err2
+	}
+	if err2 := h.mpsseVerify(); err2 != nil {
+		return n, err2
+	}
+	return n, err
+}
+
 //
 
 // MPSSERegRead reads the memory mapped registers from the device.
@@ -252,7 +304,7 @@ func (h *handle) MPSSERegRead(addr uint16) (byte, error) {
 	}
 	ctx, cancel := context200ms()
 	defer cancel()
-	_, err := h.ReadAll(ctx, b[:1])
+	_, err := h.mpsseReadAll(ctx, b[:1])
 	return b[0], err
 }
 
@@ -330,7 +382,7 @@ func (h *handle) MPSSETx(w, r []byte, ew, er gpio.Edge, lsbf bool) error {
 	if len(r) != 0 {
 		ctx, cancel := context200ms()
 		defer cancel()
-		_, err := h.ReadAll(ctx, r)
+		_, err := h.mpsseReadAll(ctx, r)
 		return err
 	}
 	return nil
@@ -380,7 +432,7 @@ func (h *handle) MPSSETxShort(w byte, wbits, rbits int, ew, er gpio.Edge, lsbf b
 	if rbits != 0 {
 		ctx, cancel := context200ms()
 		defer cancel()
-		_, err := h.ReadAll(ctx, b[:1])
+		_, err := h.mpsseReadAll(ctx, b[:1])
 		return b[0], err
 	}
 	return 0, nil
@@ -412,7 +464,7 @@ func (h *handle) MPSSECBusRead() (byte, error) {
 	}
 	ctx, cancel := context200ms()
 	defer cancel()
-	if _, err := h.ReadAll(ctx, b[:1]); err != nil {
+	if _, err := h.mpsseReadAll(ctx, b[:1]); err != nil {
 		return 0, err
 	}
 	return b[0], nil
@@ -426,7 +478,7 @@ func (h *handle) MPSSEDBusRead() (byte, error) {
 	}
 	ctx, cancel := context200ms()
 	defer cancel()
-	if _, err := h.ReadAll(ctx, b[:1]); err != nil {
+	if _, err := h.mpsseReadAll(ctx, b[:1]); err != nil {
 		return 0, err
 	}
 	return b[0], nil
@@ -0,0 +1,174 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+// SetEdgeSampleRate sets the rate at which the D and C bus pins with edge
+// detection enabled are sampled. It defaults to 1kHz.
+//
+// The chip has no interrupt, so the edges are detected by polling the pins
+// over USB in the background, only while at least one pin has edge detection
+// enabled. A pulse shorter than the sample period may be missed and a USB
+// full speed bus can't keep up with more than a few kHz.
+func (f *FT232H) SetEdgeSampleRate(freq physic.Frequency) error {
+	if freq < physic.Hertz || freq > 100*physic.KiloHertz {
+		return fmt.Errorf("d2xx: invalid edge sample rate %s; must be between 1Hz and 100kHz", freq)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.edgeRate = freq
+	return nil
+}
+
+//
+
+// edgeSampleRate is the default rate used by pollEdges.
+const edgeSampleRate = physic.KiloHertz
+
+// startEdgesLocked starts pollEdges if it is not already running.
+//
+// Must be called with mu held.
+func (f *FT232H) startEdgesLocked() {
+	if f.edgePolling {
+		return
+	}
+	f.edgePolling = true
+	go f.pollEdges()
+}
+
+// pollEdges samples the pins until no pin has edge detection enabled or the
+// device fails.
+func (f *FT232H) pollEdges() {
+	for {
+		d, ok := f.sampleEdges()
+		if !ok {
+			return
+		}
+		time.Sleep(d)
+	}
+}
+
+// sampleEdges samples both buses once and reports their edges.
+//
+// It returns the time to wait until the next sample and false if the polling
+// must stop.
+func (f *FT232H) sampleEdges() (time.Duration, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.dbus.rising|f.dbus.falling|f.cbus.rising|f.cbus.falling == 0 {
+		f.edgePolling = false
+		return 0, false
+	}
+	rate := f.edgeRate
+	if rate == 0 {
+		rate = edgeSampleRate
+	}
+	d := rate.Period()
+	if f.usingBitMode || f.usingUART {
+		// The device is not in MPSSE mode; the pins can't be read until it
+		// returns to it.
+		f.dbus.armed = 0
+		f.cbus.armed = 0
+		return d, true
+	}
+	b, err := f.readBusesLocked()
+	var bad *BadCommandError
+	if errors.As(err, &bad) {
+		// The device was resynchronized; skip this sample.
+		f.dbus.armed = 0
+		f.cbus.armed = 0
+		return d, true
+	}
+	if err != nil {
+		// Most likely unplugged; stop the edge detection.
+		logf("edge detection stopped: %v", err)
+		f.dbus.stopEdges()
+		f.cbus.stopEdges()
+		f.edgePolling = false
+		return 0, false
+	}
+	f.dbus.sample(b[0])
+	f.cbus.sample(b[1])
+	return d, true
+}
+
+// readBusesLocked reads D0~D7 and C0~C7 in a single USB round trip.
+//
+// Must be called with mu held.
+func (f *FT232H) readBusesLocked() ([2]byte, error) {
+	b := [...]byte{gpioReadD, gpioReadC, flush}
+	if _, err := f.h.Write(b[:]); err != nil {
+		return [2]byte{}, err
+	}
+	ctx, cancel := context200ms()
+	defer cancel()
+	var out [2]byte
+	if _, err := f.h.mpsseReadAll(ctx, out[:]); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// setEdge enables the detection of the edge e on pin n, or disables it with
+// gpio.NoEdge. The edges already detected are discarded.
+func (g *gpiosMPSSE) setEdge(n int, e gpio.Edge) error {
+	m := byte(1) << uint(n)
+	g.rising &^= m
+	g.falling &^= m
+	g.armed &^= m
+	switch e {
+	case gpio.NoEdge:
+	case gpio.RisingEdge:
+		g.rising |= m
+	case gpio.FallingEdge:
+		g.falling |= m
+	case gpio.BothEdges:
+		g.rising |= m
+		g.falling |= m
+	default:
+		return errors.New("d2xx: unknown edge")
+	}
+	select {
+	case <-g.pins[n].edge:
+	default:
+	}
+	return nil
+}
+
+// sample reports the edges between the previous sample and v.
+//
+// A pin starts reporting edges from its second sample, once its previous
+// level is known.
+func (g *gpiosMPSSE) sample(v byte) {
+	enabled := g.rising | g.falling
+	changed := (v ^ g.last) & g.armed
+	edges := changed&v&g.rising | changed&^v&g.falling
+	for i := range g.pins {
+		if edges&(1<<uint(i)) != 0 {
+			// The edges not yet waited for are coalesced.
+			select {
+			case g.pins[i].edge <- struct{}{}:
+			default:
+			}
+		}
+	}
+	g.last = v
+	g.armed = enabled
+}
+
+// stopEdges disables edge detection on all the pins.
+func (g *gpiosMPSSE) stopEdges() {
+	g.rising = 0
+	g.falling = 0
+	g.armed = 0
+}
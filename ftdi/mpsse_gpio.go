@@ -17,16 +17,24 @@ import (
 
 // gpiosMPSSE is a slice of 8 GPIO pins driven via MPSSE.
 //
-// This permits keeping a cache.
+// This permits keeping a cache. The mutable fields are protected by f.mu.
 type gpiosMPSSE struct {
 	// Immutable.
 	h    *handle
+	f    *FT232H
 	cbus bool // false if D bus
 	pins [8]gpioMPSSE
 
 	// Cache of values
 	direction byte
 	value     byte
+	openDrain byte // pins only driving low; see gpioMPSSE.SetOpenDrain
+
+	// Edge detection; see FT232H.pollEdges.
+	rising  byte // pins reporting rising edges
+	falling byte // pins reporting falling edges
+	armed   byte // pins whose level in last is valid
+	last    byte // previous sample
 }
 
 func (g *gpiosMPSSE) init(name string) {
@@ -44,6 +52,7 @@ func (g *gpiosMPSSE) init(name string) {
 		g.pins[i].n = name + "." + s + strconv.Itoa(i)
 		g.pins[i].num = i
 		g.pins[i].dp = gpio.PullUp
+		g.pins[i].edge = make(chan struct{}, 1)
 	}
 	if g.cbus {
 		// That's just the default EEPROM value.
@@ -56,10 +65,7 @@ func (g *gpiosMPSSE) in(n int) error {
 		return errors.New("d2xx: device not open")
 	}
 	g.direction = g.direction & ^(1 << uint(n))
-	if g.cbus {
-		return g.h.MPSSECBus(g.direction, g.value)
-	}
-	return g.h.MPSSEDBus(g.direction, g.value)
+	return g.write()
 }
 
 func (g *gpiosMPSSE) read() (byte, error) {
@@ -80,29 +86,121 @@ func (g *gpiosMPSSE) out(n int, l gpio.Level) error {
 		return errors.New("d2xx: device not open")
 	}
 	g.direction = g.direction | (1 << uint(n))
+	g.setLevel(1<<uint(n), l)
+	return g.write()
+}
+
+// setLevel sets the cached value of the pins in m to l.
+func (g *gpiosMPSSE) setLevel(m byte, l gpio.Level) {
 	if l {
-		g.value |= 1 << uint(n)
+		g.value |= m
 	} else {
-		g.value &^= 1 << uint(n)
+		g.value &^= m
 	}
+}
+
+// tristateCmd returns the command setting the open drain pins of both buses,
+// plus extra on the D bus for the protocols using open drain lines.
+func (f *FT232H) tristateCmd(extra byte) []byte {
+	return []byte{dataTristate, f.dbus.openDrain | extra, f.cbus.openDrain}
+}
+
+// write sets the direction and the value of the 8 pins.
+func (g *gpiosMPSSE) write() error {
 	if g.cbus {
 		return g.h.MPSSECBus(g.direction, g.value)
 	}
 	return g.h.MPSSEDBus(g.direction, g.value)
 }
 
+// Group is the 8 pins of the D or C bus of a MPSSE device, as returned by
+// FT232H.DGroup and FT232H.CGroup.
+//
+// Each call is a single MPSSE command, so the pins change together, as needed
+// for parallel bus protocols. It is also much faster than calling Out on each
+// pin, which costs one USB transfer each.
+//
+// Bit n of the values and masks is pin n of the bus.
+type Group struct {
+	g *gpiosMPSSE
+}
+
+func (g *Group) String() string {
+	return g.g.pins[0].n[:len(g.g.pins[0].n)-1] + "0~7"
+}
+
+// Pins returns the 8 pins of the bus.
+func (g *Group) Pins() []gpio.PinIO {
+	out := make([]gpio.PinIO, len(g.g.pins))
+	for i := range g.g.pins {
+		out[i] = &g.g.pins[i]
+	}
+	return out
+}
+
+// ByOffset returns the pin n of the bus, or nil.
+func (g *Group) ByOffset(n int) gpio.PinIO {
+	if n < 0 || n >= len(g.g.pins) {
+		return nil
+	}
+	return &g.g.pins[n]
+}
+
+// Out sets the pins in mask as outputs at the levels of the corresponding
+// bits of value. The other pins are left unchanged.
+//
+// Edge detection is disabled on the pins set as outputs.
+func (g *Group) Out(value, mask byte) error {
+	g.g.f.mu.Lock()
+	defer g.g.f.mu.Unlock()
+	if g.g.h == nil {
+		return errors.New("d2xx: device not open")
+	}
+	for i := range g.g.pins {
+		if mask&(1<<uint(i)) != 0 {
+			if err := g.g.setEdge(i, gpio.NoEdge); err != nil {
+				return err
+			}
+		}
+	}
+	g.g.direction |= mask
+	g.g.value = g.g.value&^mask | value&mask
+	return g.g.write()
+}
+
+// In sets the pins in mask as inputs. The other pins are left unchanged.
+func (g *Group) In(mask byte) error {
+	g.g.f.mu.Lock()
+	defer g.g.f.mu.Unlock()
+	if g.g.h == nil {
+		return errors.New("d2xx: device not open")
+	}
+	g.g.direction &^= mask
+	return g.g.write()
+}
+
+// Read returns the levels of the pins in mask, both inputs and outputs. The
+// other bits are 0.
+func (g *Group) Read(mask byte) (byte, error) {
+	g.g.f.mu.Lock()
+	defer g.g.f.mu.Unlock()
+	v, err := g.g.read()
+	return v & mask, err
+}
+
 //
 
 // gpioMPSSE is a GPIO pin on a FTDI device driven via MPSSE.
 //
 // gpioMPSSE implements gpio.PinIO.
 //
-// It is immutable and stateless.
+// It is immutable and stateless; the edges detected are sent to edge.
 type gpioMPSSE struct {
-	a   *gpiosMPSSE
-	n   string
-	num int
-	dp  gpio.Pull
+	a    *gpiosMPSSE
+	n    string
+	num  int
+	dp   gpio.Pull
+	edge chan struct{}
 }
 
 // String implements pin.Pin.
@@ -122,6 +220,8 @@ func (g *gpioMPSSE) Number() int {
 
 // Function implements pin.Pin.
 func (g *gpioMPSSE) Function() string {
+	g.a.f.mu.Lock()
+	defer g.a.f.mu.Unlock()
 	s := "Out/"
 	m := byte(1 << uint(g.num))
 	if g.a.direction&m == 0 {
@@ -137,11 +237,10 @@ func (g *gpioMPSSE) Halt() error {
 }
 
 // In implements gpio.PinIn.
+//
+// Edges are detected by sampling the pins in the background; see
+// FT232H.SetEdgeSampleRate.
 func (g *gpioMPSSE) In(pull gpio.Pull, e gpio.Edge) error {
-	if e != gpio.NoEdge {
-		// We could support it on D5.
-		return errors.New("d2xx: edge triggering is not supported")
-	}
 	if pull != g.dp && pull != gpio.PullNoChange {
 		// TODO(maruel): This needs to be redone:
 		// - EEPROM values FT232hCBusTristatePullUp and FT232hCBusPwrEnable can be
@@ -151,18 +250,89 @@ func (g *gpioMPSSE) In(pull gpio.Pull, e gpio.Edge) error {
 		//   confirmed.
 		return fmt.Errorf("d2xx: pull %s is not supported; try %s", pull, g.dp)
 	}
-	return g.a.in(g.num)
+	g.a.f.mu.Lock()
+	defer g.a.f.mu.Unlock()
+	if err := g.a.in(g.num); err != nil {
+		return err
+	}
+	if err := g.a.setEdge(g.num, e); err != nil {
+		return err
+	}
+	if e != gpio.NoEdge {
+		g.a.f.startEdgesLocked()
+	}
+	return nil
 }
 
 // Read implements gpio.PinIn.
 func (g *gpioMPSSE) Read() gpio.Level {
+	g.a.f.mu.Lock()
+	defer g.a.f.mu.Unlock()
 	v, _ := g.a.read()
 	return gpio.Level(v&(1<<uint(g.num)) != 0)
 }
 
 // WaitForEdge implements gpio.PinIn.
+//
+// It returns false immediately if edge detection is not enabled with In.
 func (g *gpioMPSSE) WaitForEdge(t time.Duration) bool {
-	return false
+	g.a.f.mu.Lock()
+	enabled := (g.a.rising|g.a.falling)&(1<<uint(g.num)) != 0
+	g.a.f.mu.Unlock()
+	if !enabled {
+		return false
+	}
+	if t < 0 {
+		<-g.edge
+		return true
+	}
+	tm := time.NewTimer(t)
+	defer tm.Stop()
+	select {
+	case <-g.edge:
+		return true
+	case <-tm.C:
+		return false
+	}
+}
+
+// SetOpenDrain sets the pin as open drain when on is true: as an output, it
+// drives the line low on Out(Low) and leaves it floating on Out(High), for the
+// line to be pulled up externally. This permits sharing a line with other
+// devices, like a reset or an interrupt line.
+//
+// The setting is kept when the pin is used as an input and is only supported
+// on the FT232H.
+func (g *gpioMPSSE) SetOpenDrain(on bool) error {
+	g.a.f.mu.Lock()
+	defer g.a.f.mu.Unlock()
+	if g.a.h == nil {
+		return errors.New("d2xx: device not open")
+	}
+	if g.a.h.t != DevTypeFT232H {
+		return errors.New("d2xx: open drain is only supported on FT232H")
+	}
+	m := byte(1) << uint(g.num)
+	if on {
+		g.a.openDrain |= m
+	} else {
+		g.a.openDrain &^= m
+	}
+	var extra byte
+	if g.a.f.usingI2C && !g.a.f.i.pullUp {
+		extra = 0x07
+	} else if g.a.f.usingOneWire {
+		extra = oneWireOut
+	}
+	_, err := g.a.h.Write(g.a.f.tristateCmd(extra))
+	return err
+}
+
+// OpenDrain returns true if the pin is set as open drain with SetOpenDrain.
+func (g *gpioMPSSE) OpenDrain() bool {
+	g.a.f.mu.Lock()
+	defer g.a.f.mu.Unlock()
+	return g.a.openDrain&(1<<uint(g.num)) != 0
 }
 
 // DefaultPull implements gpio.PinIn.
@@ -178,6 +348,11 @@ func (g *gpioMPSSE) Pull() gpio.Pull {
 
 // Out implements gpio.PinOut.
 func (g *gpioMPSSE) Out(l gpio.Level) error {
+	g.a.f.mu.Lock()
+	defer g.a.f.mu.Unlock()
+	if err := g.a.setEdge(g.num, gpio.NoEdge); err != nil {
+		return err
+	}
 	return g.a.out(g.num, l)
 }
 
@@ -186,28 +361,6 @@ func (g *gpioMPSSE) PWM(d gpio.Duty, f physic.Frequency) error {
 	return errors.New("d2xx: not implemented")
 }
 
-// StreamOut implements gpiostream.PinOut.
-//
-// It only works on the D1 pin.
-func (g *gpioMPSSE) StreamOut(s gpiostream.Stream) error {
-	if g.num != 1 || g.a.cbus {
-		return errors.New("d2xx: pin doesn't support gpio stream out")
-	}
-	b, ok := s.(*gpiostream.BitStream)
-	if !ok {
-		return errors.New("d2xx: only BitStream is currently supported")
-	}
-	if b.Freq == 0 {
-		return errors.New("d2xx: BitStream.Freq must be specified")
-	}
-	f, err := g.a.h.MPSSEClock(b.Freq)
-	if err != nil {
-		return err
-	}
-	logf("StreamOut(%d, %s)", len(b.Bits)*8, f)
-	return g.a.h.MPSSETx(b.Bits, nil, gpio.NoEdge, gpio.NoEdge, b.LSBF)
-}
-
 /*
 func (g *gpioMPSSE) Drive() physic.ElectricCurrent {
 	//return g.a.ee.CDriveCurrent * physic.MilliAmpere
@@ -226,3 +379,5 @@ func (g *gpioMPSSE) Hysteresis() bool {
 */
 
 var _ gpio.PinIO = &gpioMPSSE{}
+var _ OpenDrainer = &gpioMPSSE{}
+var _ gpiostream.PinOut = &gpioMPSSE{}
@@ -0,0 +1,358 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+// The tests in this file generate random transactions and check the MPSSE
+// command streams against a model of the MPSSE, which decodes the commands
+// and answers the reads like the chip would. A malformed command, e.g. a
+// wrong length, desynchronizes the stream, which the model reports.
+//
+// The seeds are fixed so failures are reproducible.
+
+func TestI2CBus_Tx_model(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		h := &modelHandle{}
+		d := newTestI2CBus(&recordHandle{})
+		d.f.h = &handle{h: h}
+		d.pullUp = rnd.Intn(2) == 0
+		d.stopBeforeRead = rnd.Intn(2) == 0
+		addr := uint16(rnd.Intn(0x78))
+		if rnd.Intn(4) == 0 {
+			addr = uint16(0x80 + rnd.Intn(0x380))
+		}
+		w := randBytes(rnd, rnd.Intn(20))
+		r := make([]byte, rnd.Intn(20))
+		desc := fmt.Sprintf("#%d: Tx(%#x, %d, %d) pullUp=%t stopBeforeRead=%t", i, addr, len(w), len(r), d.pullUp, d.stopBeforeRead)
+
+		if err := d.Tx(addr, w, r); err != nil {
+			t.Fatalf("%s: %v", desc, err)
+		}
+		m := &h.m
+		if err := m.done(); err != nil {
+			t.Fatalf("%s: %v", desc, err)
+		}
+		// The bytes shifted out are the address, w then the read address.
+		var want []byte
+		tenBits := addr >= 0x80
+		if len(w) != 0 || len(r) == 0 || tenBits {
+			if tenBits {
+				want = append(want, byte(0xF0|(addr>>7)&6), byte(addr))
+			} else {
+				want = append(want, byte(addr<<1))
+			}
+			want = append(want, w...)
+		}
+		if len(r) != 0 {
+			if tenBits {
+				want = append(want, byte(0xF1|(addr>>7)&6))
+			} else {
+				want = append(want, byte(addr<<1|1))
+			}
+		}
+		if !bytes.Equal(m.out, want) {
+			t.Fatalf("%s: wrote %#x, want %#x", desc, m.out, want)
+		}
+		// Each byte written is followed by reading its ACK.
+		if m.bitsIn != len(want) {
+			t.Fatalf("%s: read %d ACKs, want %d", desc, m.bitsIn, len(want))
+		}
+		// Each byte read is acknowledged, except the last one.
+		if !bytes.Equal(r, m.in) {
+			t.Fatalf("%s: got %#x, want %#x", desc, r, m.in)
+		}
+		if len(m.bitsOut) != len(r) {
+			t.Fatalf("%s: sent %d ACKs, want %d", desc, len(m.bitsOut), len(r))
+		}
+		for j, b := range m.bitsOut {
+			if nak := b&0x80 != 0; nak != (j == len(r)-1) {
+				t.Fatalf("%s: byte %d: NAK=%t", desc, j, nak)
+			}
+		}
+		if !bytes.HasSuffix(m.raw, append(d.setI2CStop(), flush)) {
+			t.Fatalf("%s: missing stop", desc)
+		}
+	}
+}
+
+func TestI2CBus_reply_model(t *testing.T) {
+	// NAKs at random positions are reported at the right index.
+	rnd := rand.New(rand.NewSource(2))
+	d := newTestI2CBus(&recordHandle{})
+	for i := 0; i < 500; i++ {
+		addr := uint16(rnd.Intn(0x78))
+		addrLen := 1
+		if rnd.Intn(4) == 0 {
+			addr = uint16(0x80 + rnd.Intn(0x380))
+			addrLen = 2
+		}
+		w := randBytes(rnd, 1+rnd.Intn(10))
+		r := make([]byte, rnd.Intn(3))
+		acks := addrLen + len(w)
+		if len(r) != 0 {
+			acks++
+		}
+		buf := make([]byte, acks+len(r))
+		nak := rnd.Intn(acks)
+		buf[nak] = 1
+		// The ACKs after the NAK are random.
+		for j := nak + 1; j < acks; j++ {
+			buf[j] = byte(rnd.Intn(2))
+		}
+		err := d.reply(buf, addr, r)
+		e, ok := err.(*NAKError)
+		if !ok {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		want := NAKError{Addr: addr, Index: nak - addrLen}
+		switch {
+		case len(r) != 0 && nak == acks-1:
+			want = NAKError{Addr: addr, Index: -1, Read: true}
+		case nak < addrLen:
+			want.Index = -1
+		}
+		if *e != want {
+			t.Fatalf("#%d: %+v, want %+v", i, *e, want)
+		}
+	}
+}
+
+func TestSPI_TxPackets_model(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	for i := 0; i < 200; i++ {
+		h := &modelHandle{}
+		f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+		f.s.c.f = f
+		p, err := f.SPI()
+		if err != nil {
+			t.Fatal(err)
+		}
+		mode := spi.Mode(rnd.Intn(4))
+		c, err := p.Connect(physic.MegaHertz, mode, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkts := make([]spi.Packet, 1+rnd.Intn(4))
+		var wantOut []byte
+		for j := range pkts {
+			l := 1 + rnd.Intn(100)
+			if rnd.Intn(50) == 0 {
+				// Larger than a single MPSSE command.
+				l = 65536 + rnd.Intn(100)
+			}
+			switch rnd.Intn(3) {
+			case 0:
+				pkts[j].W = randBytes(rnd, l)
+			case 1:
+				pkts[j].R = make([]byte, l)
+			default:
+				pkts[j].W = randBytes(rnd, l)
+				pkts[j].R = make([]byte, l)
+			}
+			wantOut = append(wantOut, pkts[j].W...)
+		}
+		desc := fmt.Sprintf("#%d: %s %d packets", i, mode, len(pkts))
+		h.m.reset()
+		if err := c.TxPackets(pkts); err != nil {
+			t.Fatalf("%s: %v", desc, err)
+		}
+		m := &h.m
+		if err := m.done(); err != nil {
+			t.Fatalf("%s: %v", desc, err)
+		}
+		if !bytes.Equal(m.out, wantOut) {
+			t.Fatalf("%s: wrote %d bytes, want %d", desc, len(m.out), len(wantOut))
+		}
+		var got []byte
+		for _, pkt := range pkts {
+			got = append(got, pkt.R...)
+		}
+		if !bytes.Equal(got, m.in) {
+			t.Fatalf("%s: read %d bytes, want %d", desc, len(got), len(m.in))
+		}
+		if m.bitsIn != 0 || len(m.bitsOut) != 0 {
+			t.Fatalf("%s: unexpected bit transfer", desc)
+		}
+		if err := p.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+//
+
+// modelHandle is a handle answering the reads of the MPSSE commands written
+// to it, as decoded by mpsseModel.
+type modelHandle struct {
+	fakeHandle
+	m mpsseModel
+}
+
+func (h *modelHandle) Write(b []byte) (int, d2xxErr) {
+	h.Data = append(h.Data, h.m.write(b))
+	if h.m.err != nil {
+		// Fail instead of waiting forever for the replies.
+		return 0, 4 // FT_IO_ERROR
+	}
+	return len(b), 0
+}
+
+func (h *modelHandle) SetBitMode(mask, mode byte) d2xxErr {
+	return 0
+}
+
+// mpsseModel decodes a stream of MPSSE commands.
+//
+// The data read in is random, except the single bits, which are 0 so they
+// are ACKs for I²C.
+type mpsseModel struct {
+	rnd *rand.Rand
+	// raw is all the bytes written.
+	raw []byte
+	// pending is an incomplete command, completed by the next write.
+	pending []byte
+	// out is the bytes shifted out, in is the bytes shifted in.
+	out, in []byte
+	// bitsOut is the bit transfers shifted out, bitsIn is the number of bit
+	// transfers shifted in.
+	bitsOut []byte
+	bitsIn  int
+	err     error
+}
+
+// reset discards the commands decoded so far, e.g. the setup.
+func (m *mpsseModel) reset() {
+	*m = mpsseModel{rnd: m.rnd}
+}
+
+// done returns an error if a command was malformed or incomplete.
+func (m *mpsseModel) done() error {
+	if m.err != nil {
+		return m.err
+	}
+	if len(m.pending) != 0 {
+		return fmt.Errorf("incomplete command %#x", m.pending)
+	}
+	return nil
+}
+
+// write decodes the commands in b and returns the bytes the MPSSE sends
+// back.
+func (m *mpsseModel) write(b []byte) []byte {
+	if m.rnd == nil {
+		m.rnd = rand.New(rand.NewSource(0))
+	}
+	m.raw = append(m.raw, b...)
+	m.pending = append(m.pending, b...)
+	var reply []byte
+	for len(m.pending) != 0 && m.err == nil {
+		n, r, ok := m.decode(m.pending)
+		if !ok {
+			// Wait for the rest of the command.
+			break
+		}
+		reply = append(reply, r...)
+		m.pending = m.pending[n:]
+	}
+	if len(m.pending) == 0 {
+		m.pending = nil
+	}
+	return reply
+}
+
+// decode decodes the command at the start of c. It returns the length of the
+// command and the data it reads back, or false if c is incomplete.
+func (m *mpsseModel) decode(c []byte) (int, []byte, bool) {
+	op := c[0]
+	if op&0x80 == 0 {
+		if op&0x40 != 0 {
+			m.err = fmt.Errorf("unexpected TMS command %#x", op)
+			return 0, nil, false
+		}
+		if op&(dataOut|dataIn) == 0 {
+			m.err = fmt.Errorf("data command %#x without data", op)
+			return 0, nil, false
+		}
+		if op&dataBit != 0 {
+			// Length of 1 byte, in bits, then 1 byte if shifting out.
+			l := 2
+			if op&dataOut != 0 {
+				l++
+			}
+			if len(c) < l {
+				return 0, nil, false
+			}
+			if c[1] > 7 {
+				m.err = fmt.Errorf("invalid bit count %d", c[1]+1)
+				return 0, nil, false
+			}
+			if op&dataOut != 0 {
+				m.bitsOut = append(m.bitsOut, c[2])
+			}
+			if op&dataIn != 0 {
+				m.bitsIn++
+				return l, []byte{0}, true
+			}
+			return l, nil, true
+		}
+		// Length of 2 bytes, then the data if shifting out.
+		if len(c) < 3 {
+			return 0, nil, false
+		}
+		n := int(c[1]) | int(c[2])<<8 + 1
+		l := 3
+		if op&dataOut != 0 {
+			l += n
+		}
+		if len(c) < l {
+			return 0, nil, false
+		}
+		if op&dataOut != 0 {
+			m.out = append(m.out, c[3:l]...)
+		}
+		if op&dataIn != 0 {
+			r := make([]byte, n)
+			m.rnd.Read(r)
+			m.in = append(m.in, r...)
+			return l, r, true
+		}
+		return l, nil, true
+	}
+	var l int
+	switch op {
+	case clock30MHz, clock6MHz, clock3Phase, clock2Phase, clockAdaptive, clockNormal,
+		internalLoopbackEnable, internalLoopbackDisable, flush, clockUntilHigh, clockUntilLow:
+		l = 1
+	case gpioReadD, gpioReadC:
+		return 1, []byte{0}, true
+	case clockOnShort:
+		l = 2
+	case gpioSetD, gpioSetC, clockSetDivisor, dataTristate, clockOnLong, clockUntilHighLong, clockUntilLowLong:
+		l = 3
+	default:
+		m.err = fmt.Errorf("unknown command %#x", op)
+		return 0, nil, false
+	}
+	if len(c) < l {
+		return 0, nil, false
+	}
+	return l, nil, true
+}
+
+func randBytes(rnd *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	rnd.Read(b)
+	return b
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiostream"
+	"periph.io/x/conn/v3/physic"
+)
+
+// StreamOut implements gpiostream.PinOut.
+//
+// Only BitStream is supported. The bits are output by the MPSSE engine at the
+// stream frequency, so the timing doesn't depend on the host. It returns once
+// the whole stream is output, leaving the pin as an output at the level of the
+// last bit.
+//
+// On D1, the bits are shifted out as MPSSE data clocked on D0, up to 30MHz.
+//
+// On the other pins, each bit is a GPIO command followed by a wait counted in
+// cycles of the 30MHz MPSSE clock. Each bit lasts a bit longer than the period
+// by the time the engine takes to execute the GPIO command, which is the same
+// for every bit. D0 must be an input, as the clock is output on it.
+//
+// The device buffers 1KiB of commands. A longer stream relies on the host to
+// refill the buffer in time, which USB doesn't guarantee.
+//
+// The MPSSE clock is shared with I²C, SPI, JTAG and 1-Wire, so the pins can't
+// stream while one of them is in use.
+func (g *gpioMPSSE) StreamOut(s gpiostream.Stream) error {
+	b, ok := s.(*gpiostream.BitStream)
+	if !ok {
+		return errors.New("d2xx: only BitStream is currently supported")
+	}
+	if b.Freq <= 0 || b.Freq > streamMaxFreq {
+		return errors.New("d2xx: BitStream.Freq must be specified and at most 30MHz")
+	}
+	g.a.f.mu.Lock()
+	defer g.a.f.mu.Unlock()
+	if g.a.h == nil {
+		return errors.New("d2xx: device not open")
+	}
+	if err := g.a.f.canUseBitMode(); err != nil {
+		return err
+	}
+	shift := !g.a.cbus && g.num == 1
+	if !shift {
+		if !g.a.cbus && g.num == 0 {
+			return errors.New("d2xx: D0 outputs the MPSSE clock; it can't stream")
+		}
+		if g.a.f.dbus.direction&1 != 0 {
+			return errors.New("d2xx: D0 must be an input to pace the stream")
+		}
+	}
+	if err := g.a.setEdge(g.num, gpio.NoEdge); err != nil {
+		return err
+	}
+	rate := b.Freq
+	if !shift {
+		rate = streamMaxFreq
+	}
+	clk, err := g.a.h.MPSSEClock(rate)
+	if err != nil {
+		return err
+	}
+	var cmd []byte
+	if shift {
+		logf("StreamOut(%d, %s)", len(b.Bits)*8, clk)
+		cmd = g.shiftCmd(b)
+	} else {
+		n := streamCycles(b.Freq)
+		logf("StreamOut(%d, %s)", len(b.Bits)*8, clk/physic.Frequency(n))
+		cmd = g.pacedCmd(b, n)
+	}
+	// Reading the bus back returns once all the commands before it are
+	// executed.
+	read := gpioReadD
+	if g.a.cbus {
+		read = gpioReadC
+	}
+	cmd = append(cmd, read, flush)
+	if _, err := g.a.h.Write(cmd); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*b.Duration()+200*time.Millisecond)
+	defer cancel()
+	var v [1]byte
+	_, err = g.a.h.mpsseReadAll(ctx, v[:])
+	return err
+}
+
+//
+
+// streamMaxFreq is the MPSSE clock at its fastest, which paces the streams.
+const streamMaxFreq = 30 * physic.MegaHertz
+
+// streamCycles returns the number of streamMaxFreq cycles closest to the
+// period of f, at least 1.
+func streamCycles(f physic.Frequency) int64 {
+	n := int64((streamMaxFreq + f/2) / f)
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// shiftCmd returns the commands shifting out b on D1 as MPSSE data.
+func (g *gpioMPSSE) shiftCmd(b *gpiostream.BitStream) []byte {
+	m := byte(1) << uint(g.num)
+	g.a.direction |= m
+	cmd := []byte{clock2Phase, gpioSetD, g.a.value, g.a.direction}
+	op := mpsseTxOp(true, false, gpio.NoEdge, gpio.NoEdge, b.LSBF)
+	for w := b.Bits; len(w) != 0; {
+		l := len(w)
+		if l > 65536 {
+			l = 65536
+		}
+		cmd = append(cmd, op, byte(l-1), byte((l-1)>>8))
+		cmd = append(cmd, w[:l]...)
+		w = w[l:]
+	}
+	// Keep the level of the last bit once the data is shifted out.
+	if l := len(b.Bits) * 8; l != 0 {
+		g.a.setLevel(m, streamBit(b, l-1))
+		cmd = append(cmd, gpioSetD, g.a.value, g.a.direction)
+	}
+	return cmd
+}
+
+// pacedCmd returns the commands setting each bit of b on the pin, each
+// followed by a wait of n clock cycles.
+func (g *gpioMPSSE) pacedCmd(b *gpiostream.BitStream, n int64) []byte {
+	op := gpioSetD
+	if g.a.cbus {
+		op = gpioSetC
+	}
+	m := byte(1) << uint(g.num)
+	g.a.direction |= m
+	wait := waitCmd(n)
+	l := len(b.Bits) * 8
+	cmd := make([]byte, 0, 1+l*(3+len(wait)))
+	cmd = append(cmd, clock2Phase)
+	for i := 0; i < l; i++ {
+		g.a.setLevel(m, streamBit(b, i))
+		cmd = append(cmd, op, g.a.value, g.a.direction)
+		cmd = append(cmd, wait...)
+	}
+	return cmd
+}
+
+// streamBit returns the ith bit of b in the order it is output.
+func streamBit(b *gpiostream.BitStream, i int) gpio.Level {
+	s := uint(7 - i%8)
+	if b.LSBF {
+		s = uint(i % 8)
+	}
+	return gpio.Level(b.Bits[i/8]&(1<<s) != 0)
+}
+
+// waitCmd returns the commands clocking n cycles without data.
+func waitCmd(n int64) []byte {
+	var cmd []byte
+	for n >= 8 {
+		c := n / 8
+		if c > 65536 {
+			c = 65536
+		}
+		cmd = append(cmd, clockOnLong, byte(c-1), byte((c-1)>>8))
+		n -= 8 * c
+	}
+	if n != 0 {
+		cmd = append(cmd, clockOnShort, byte(n-1))
+	}
+	return cmd
+}
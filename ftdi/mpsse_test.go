@@ -0,0 +1,29 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHandle_mpsseReadAll_badCommand(t *testing.T) {
+	// The reply to a bad command 0xAB is inserted before the data.
+	h := &recordHandle{replies: append([][]byte{{0xFA, 0xAB, 0x34}}, mpsseVerifyReplies()...)}
+	d := &handle{h: h}
+	_, err := d.MPSSEDBusRead()
+	var bad *BadCommandError
+	if !errors.As(err, &bad) || bad.Op != 0xAB {
+		t.Fatal(err)
+	}
+	// The stream was resynchronized.
+	if p, _ := h.GetQueueStatus(); p != 0 || len(h.replies) != 0 {
+		t.Fatal(p, h.replies)
+	}
+	h.replies = [][]byte{{0x34}}
+	if v, err := d.MPSSEDBusRead(); v != 0x34 || err != nil {
+		t.Fatal(v, err)
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/onewire"
+	"periph.io/x/conn/v3/physic"
+)
+
+// The 1-Wire line is driven low by D1 in open drain mode and sampled on D2.
+// Each bit clocked by the MPSSE lasts 1µs.
+const (
+	oneWireOut byte = 0x02 // D1
+	oneWireIn  byte = 0x04 // D2
+
+	// A time slot is 72µs, including the recovery time.
+	oneWireSlot = 9
+	// A reset is 480µs low followed by 480µs released.
+	oneWireReset = 60
+	// The line is sampled 13µs after the start of a read slot.
+	oneWireSample = 13
+	// Number of 1-Wire bytes sent per USB transaction.
+	oneWireChunk = 8
+)
+
+// oneWireBus is a 1-Wire master over the MPSSE of a FT232H.
+type oneWireBus struct {
+	f      *FT232H
+	strong bool
+}
+
+// Close stops using D1 and D2 as a 1-Wire bus.
+func (o *oneWireBus) Close() error {
+	o.f.mu.Lock()
+	defer o.f.mu.Unlock()
+	o.f.usingOneWire = false
+	o.strong = false
+	// Reset to 30MHz and stop the open drain mode.
+	_, err := o.f.h.Write(append([]byte{clock30MHz, clockSetDivisor, 0, 0}, o.f.tristateCmd(0)...))
+	return err
+}
+
+func (o *oneWireBus) String() string {
+	return o.f.String()
+}
+
+// Q implements onewire.Pins.
+func (o *oneWireBus) Q() gpio.PinIO {
+	return o.f.hdr[1]
+}
+
+// Tx implements onewire.Bus.
+//
+// It resets the bus, writes w then reads into r. When power is
+// onewire.StrongPullup, D1 drives the line high until the next transaction.
+func (o *oneWireBus) Tx(w, r []byte, power onewire.Pullup) error {
+	o.f.mu.Lock()
+	defer o.f.mu.Unlock()
+	if err := o.reset(); err != nil {
+		return err
+	}
+	for len(w) != 0 {
+		n := len(w)
+		if n > oneWireChunk {
+			n = oneWireChunk
+		}
+		if _, err := o.slots(w[:n], 8*n); err != nil {
+			return err
+		}
+		w = w[n:]
+	}
+	for i := 0; i < len(r); i += oneWireChunk {
+		n := len(r) - i
+		if n > oneWireChunk {
+			n = oneWireChunk
+		}
+		// Read slots are write 1 slots.
+		ones := [oneWireChunk]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+		bits, err := o.slots(ones[:n], 8*n)
+		if err != nil {
+			return err
+		}
+		for j := 0; j < n; j++ {
+			r[i+j] = 0
+		}
+		for j := range bits {
+			r[i+j/8] |= bits[j] << uint(j%8)
+		}
+	}
+	if power == onewire.StrongPullup {
+		// Disable the open drain mode, D1 was left high.
+		if _, err := o.f.h.Write(o.f.tristateCmd(0)); err != nil {
+			return err
+		}
+		o.strong = true
+	}
+	return nil
+}
+
+// Search implements onewire.Bus.
+func (o *oneWireBus) Search(alarmOnly bool) ([]onewire.Address, error) {
+	return onewire.Search(o, alarmOnly)
+}
+
+// SearchTriplet implements onewire.BusSearcher.
+func (o *oneWireBus) SearchTriplet(direction byte) (onewire.TripletResult, error) {
+	o.f.mu.Lock()
+	defer o.f.mu.Unlock()
+	var tr onewire.TripletResult
+	// Read the bit and its complement; each is pulled low by any device.
+	bits, err := o.slots([]byte{0x03}, 2)
+	if err != nil {
+		return tr, err
+	}
+	tr.GotZero = bits[0] == 0
+	tr.GotOne = bits[1] == 0
+	switch {
+	case tr.GotZero && !tr.GotOne:
+		tr.Taken = 0
+	case tr.GotOne && !tr.GotZero:
+		tr.Taken = 1
+	case tr.GotZero && tr.GotOne:
+		tr.Taken = direction & 1
+	default:
+		// No device responded.
+		tr.Taken = 1
+	}
+	_, err = o.slots([]byte{tr.Taken}, 1)
+	return tr, err
+}
+
+//
+
+// setupOneWire sets D1 as an open drain output and D2 as an input, clocked at
+// 1MHz.
+//
+// f.mu must be held.
+func (o *oneWireBus) setupOneWire() error {
+	const mask = 0xFF &^ (oneWireOut | oneWireIn)
+	d := &o.f.dbus
+	// D0 is the clock; it is not connected.
+	d.direction = d.direction&mask | oneWireOut | 0x01
+	d.value = d.value&mask | oneWireOut
+	cmd := append([]byte{
+		clockNormal,
+		internalLoopbackDisable,
+		gpioSetD, d.value, d.direction,
+	}, o.f.tristateCmd(oneWireOut)...)
+	if _, err := o.f.h.Write(cmd); err != nil {
+		return err
+	}
+	if _, err := o.f.h.MPSSEClock(physic.MegaHertz); err != nil {
+		return err
+	}
+	o.f.usingOneWire = true
+	return nil
+}
+
+// reset sends a reset pulse and looks for a presence pulse.
+//
+// f.mu must be held.
+func (o *oneWireBus) reset() error {
+	if o.strong {
+		// Stop the strong pull up.
+		if _, err := o.f.h.Write(o.f.tristateCmd(oneWireOut)); err != nil {
+			return err
+		}
+		o.strong = false
+	}
+	var w, r [2 * oneWireReset]byte
+	for i := oneWireReset; i < len(w); i++ {
+		w[i] = 0xFF
+	}
+	if err := o.f.h.MPSSETx(w[:], r[:], gpio.FallingEdge, gpio.RisingEdge, false); err != nil {
+		return err
+	}
+	if r[len(r)-1] != 0xFF {
+		return shortedBusError("d2xx: 1-Wire bus is shorted")
+	}
+	// Devices answer 15~60µs after the line is released, for 60~240µs.
+	for _, b := range r[oneWireReset+1 : oneWireReset+40] {
+		if b != 0xFF {
+			return nil
+		}
+	}
+	return noDevicesError("d2xx: no 1-Wire device present")
+}
+
+// slots sends one time slot per bit of w, least significant bit first, and
+// returns the sampled value of each slot.
+//
+// A 1 bit is also a read slot.
+//
+// f.mu must be held.
+func (o *oneWireBus) slots(w []byte, bits int) ([]byte, error) {
+	out := make([]byte, bits*oneWireSlot)
+	for i := 0; i < bits; i++ {
+		s := out[i*oneWireSlot : (i+1)*oneWireSlot]
+		low := 60
+		if w[i/8]&(1<<uint(i%8)) != 0 {
+			low = 6
+		}
+		for j := low; j < 8*oneWireSlot; j++ {
+			s[j/8] |= 0x80 >> uint(j%8)
+		}
+	}
+	in := make([]byte, len(out))
+	if err := o.f.h.MPSSETx(out, in, gpio.FallingEdge, gpio.RisingEdge, false); err != nil {
+		return nil, err
+	}
+	r := make([]byte, bits)
+	for i := range r {
+		if in[i*oneWireSlot+oneWireSample/8]&(0x80>>uint(oneWireSample%8)) != 0 {
+			r[i] = 1
+		}
+	}
+	return r, nil
+}
+
+// noDevicesError implements onewire.NoDevicesError.
+type noDevicesError string
+
+func (e noDevicesError) Error() string   { return string(e) }
+func (e noDevicesError) NoDevices() bool { return true }
+
+// shortedBusError implements onewire.ShortedBusError.
+type shortedBusError string
+
+func (e shortedBusError) Error() string   { return string(e) }
+func (e shortedBusError) IsShorted() bool { return true }
+func (e shortedBusError) BusError() bool  { return true }
+
+var _ onewire.BusCloser = &oneWireBus{}
+var _ onewire.BusSearcher = &oneWireBus{}
+var _ onewire.Pins = &oneWireBus{}
+var _ onewire.NoDevicesError = noDevicesError("")
+var _ onewire.ShortedBusError = shortedBusError("")
@@ -0,0 +1,147 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/onewire"
+)
+
+func TestOneWire(t *testing.T) {
+	h := &recordHandle{}
+	f := newTestFT232H(h, DevTypeFT232H)
+	o, err := f.OneWire()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(h.w, []byte{dataTristate, oneWireOut, 0}) {
+		t.Fatalf("%#x", h.w)
+	}
+	if _, err := f.I2C(gpio.Float); err == nil || err.Error() != "d2xx: already using 1-Wire" {
+		t.Fatal(err)
+	}
+	if _, err := f.OneWire(); err == nil {
+		t.Fatal("already using 1-Wire")
+	}
+
+	// Reset, write 0x81 then read a byte.
+	h.w = nil
+	h.replies = [][]byte{oneWirePresence(), make([]byte, 8*oneWireSlot), oneWireSlots(0xA5)}
+	r := make([]byte, 1)
+	if err := o.Tx([]byte{0x81}, r, onewire.StrongPullup); err != nil {
+		t.Fatal(err)
+	}
+	if r[0] != 0xA5 {
+		t.Fatalf("%#x", r)
+	}
+	// A 1 is 6µs low, a 0 is 60µs low.
+	one := []byte{0x03, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	zero := []byte{0, 0, 0, 0, 0, 0, 0, 0x0F, 0xFF}
+	w := h.w[3+2*oneWireReset+1+3:]
+	if !bytes.Equal(w[:oneWireSlot], one) || !bytes.Equal(w[oneWireSlot:2*oneWireSlot], zero) || !bytes.Equal(w[7*oneWireSlot:8*oneWireSlot], one) {
+		t.Fatalf("%#x", w[:8*oneWireSlot])
+	}
+	if !bytes.HasSuffix(h.w, []byte{dataTristate, 0, 0}) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	// The strong pull up is stopped by the next transaction.
+	h.w = nil
+	h.replies = [][]byte{oneWireAll(0xFF)}
+	err = o.Tx([]byte{0xCC}, nil, onewire.WeakPullup)
+	if e, ok := err.(onewire.NoDevicesError); !ok || !e.NoDevices() {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(h.w, []byte{dataTristate, oneWireOut, 0}) {
+		t.Fatalf("%#x", h.w)
+	}
+	h.replies = [][]byte{oneWireAll(0)}
+	err = o.Tx([]byte{0xCC}, nil, onewire.WeakPullup)
+	if e, ok := err.(onewire.ShortedBusError); !ok || !e.IsShorted() {
+		t.Fatal(err)
+	}
+
+	if err := o.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.SPI(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOneWire_SearchTriplet(t *testing.T) {
+	h := &recordHandle{}
+	f := newTestFT232H(h, DevTypeFT232H)
+	o, err := f.OneWire()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := o.(onewire.BusSearcher)
+	data := []struct {
+		bits byte
+		dir  byte
+		want onewire.TripletResult
+	}{
+		{0x02, 1, onewire.TripletResult{GotZero: true, Taken: 0}},
+		{0x01, 0, onewire.TripletResult{GotOne: true, Taken: 1}},
+		{0x00, 1, onewire.TripletResult{GotZero: true, GotOne: true, Taken: 1}},
+		{0x03, 0, onewire.TripletResult{Taken: 1}},
+	}
+	for i, line := range data {
+		h.replies = [][]byte{oneWireSlots(line.bits)[:2*oneWireSlot], make([]byte, oneWireSlot)}
+		tr, err := b.SearchTriplet(line.dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tr != line.want {
+			t.Fatalf("#%d: %+v", i, tr)
+		}
+	}
+}
+
+func TestOneWire_FT2232H(t *testing.T) {
+	f := newTestFT232H(&recordHandle{}, DevTypeFT2232H)
+	if _, err := f.OneWire(); err == nil {
+		t.Fatal("no open drain")
+	}
+}
+
+//
+
+func newTestFT232H(h *recordHandle, t DevType) *FT232H {
+	f := &FT232H{generic: generic{h: &handle{h: h, t: t}, name: "ft232h"}}
+	f.hdr[1] = &f.dbus.pins[1]
+	f.o.f = f
+	return f
+}
+
+// oneWireSlots returns the line as sampled during the read slots of b.
+func oneWireSlots(b byte) []byte {
+	out := oneWireAll(0xFF)[:8*oneWireSlot]
+	for i := 0; i < 8; i++ {
+		if b&(1<<uint(i)) == 0 {
+			out[i*oneWireSlot+oneWireSample/8] = 0
+		}
+	}
+	return out
+}
+
+// oneWirePresence returns the line as sampled during a reset with a device
+// present.
+func oneWirePresence() []byte {
+	out := oneWireAll(0xFF)
+	for i := 0; i < oneWireReset; i++ {
+		out[i] = 0
+	}
+	out[oneWireReset+10] = 0
+	return out
+}
+
+func oneWireAll(v byte) []byte {
+	return bytes.Repeat([]byte{v}, 2*oneWireReset)
+}
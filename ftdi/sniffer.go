@@ -0,0 +1,370 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/uart"
+)
+
+// SnifferOpts configures SniffUART.
+type SnifferOpts struct {
+	// Baud is the baud rate of the tapped lines. It is required.
+	Baud physic.Frequency
+	// Pins is the mask of the D0~D7 pins to decode, e.g. 0x03 to decode both
+	// directions of a link on D0 and D1. It defaults to D1, the RX pin of the
+	// UART mode.
+	Pins byte
+	// Bits is the number of data bits, between 5 and 8. It defaults to 8.
+	Bits int
+	// Parity is the parity bit, if any. It defaults to uart.NoParity.
+	Parity uart.Parity
+	// Oversample is the number of samples per bit, at least 4. It defaults to
+	// 8.
+	Oversample int
+}
+
+// UARTFrame is a frame decoded by a Sniffer.
+type UARTFrame struct {
+	// Pin is the D pin the frame was received on, between 0 and 7.
+	Pin int
+	// Data is the data bits.
+	Data byte
+	// Time is the beginning of the start bit.
+	Time time.Time
+	// Err is set when the parity bit or the stop bit is invalid.
+	Err error
+}
+
+func (u *UARTFrame) String() string {
+	s := fmt.Sprintf("D%d: %#02x @ %s", u.Pin, u.Data, u.Time.Format("15:04:05.000000"))
+	if u.Err != nil {
+		s += ": " + u.Err.Error()
+	}
+	return s
+}
+
+// SniffUART samples D0~D7 as inputs in synchronous bit-bang mode, leaving
+// MPSSE, and decodes the UART frames on the pins set in o.Pins.
+//
+// The pins are only sampled and never driven, so they can tap a link between
+// other devices. The samples are timestamped from the sample clock, so the
+// time of each frame is accurate to one sample.
+//
+// The sample rate is o.Baud times o.Oversample; the USB bus must keep up with
+// it both ways. When it doesn't, the sampling pauses and the frames in
+// progress are dropped.
+//
+// I²C, SPI and the GPIOs can't be used until Close is called, which returns
+// the device to MPSSE mode.
+func (f *FT232H) SniffUART(o *SnifferOpts) (*Sniffer, error) {
+	c := *o
+	if c.Baud < physic.Hertz {
+		return nil, fmt.Errorf("d2xx: invalid baud rate %s", c.Baud)
+	}
+	if c.Pins == 0 {
+		c.Pins = 0x02
+	}
+	if c.Bits == 0 {
+		c.Bits = 8
+	}
+	if c.Bits < 5 || c.Bits > 8 {
+		return nil, fmt.Errorf("d2xx: invalid number of bits %d; must be between 5 and 8", c.Bits)
+	}
+	switch c.Parity {
+	case 0:
+		c.Parity = uart.NoParity
+	case uart.NoParity, uart.Odd, uart.Even, uart.Mark, uart.Space:
+	default:
+		return nil, fmt.Errorf("d2xx: invalid parity %q", byte(c.Parity))
+	}
+	if c.Oversample == 0 {
+		c.Oversample = 8
+	}
+	if c.Oversample < 4 {
+		return nil, fmt.Errorf("d2xx: invalid oversampling %d; must be at least 4", c.Oversample)
+	}
+	rate := c.Baud * physic.Frequency(c.Oversample)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.canUseBitMode(); err != nil {
+		return nil, err
+	}
+	// In the bit-bang modes, the pins are clocked at 16 times the baud rate.
+	if err := f.h.SetBaudRate(rate / 16); err != nil {
+		return nil, err
+	}
+	if err := f.h.SetBitMode(0, bitModeSyncBitbang); err != nil {
+		return nil, err
+	}
+	f.usingBitMode = true
+	s := &Sniffer{
+		f:    f,
+		c:    make(chan UARTFrame, 256),
+		done: make(chan struct{}),
+		d:    newSniffDecoder(&c, rate),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s, nil
+}
+
+// Sniffer decodes the UART frames on tapped lines.
+type Sniffer struct {
+	f    *FT232H
+	c    chan UARTFrame
+	done chan struct{}
+	wg   sync.WaitGroup
+	d    *sniffDecoder
+
+	mu      sync.Mutex
+	err     error
+	dropped int
+}
+
+func (s *Sniffer) String() string {
+	return s.f.String()
+}
+
+// Frames returns the channel receiving the decoded frames. It is closed by
+// Close or when sampling fails.
+//
+// The frames are dropped if the channel is not drained; see Dropped.
+func (s *Sniffer) Frames() <-chan UARTFrame {
+	return s.c
+}
+
+// Dropped returns the number of frames dropped as the channel was full.
+func (s *Sniffer) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Close stops sampling and returns the device to MPSSE mode.
+//
+// It returns the error that stopped the sampling, if any.
+func (s *Sniffer) Close() error {
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	close(s.done)
+	s.wg.Wait()
+	err := s.f.closeBitMode()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	return err
+}
+
+//
+
+// snifferChunk is the number of samples per USB transfer.
+const snifferChunk = 4096
+
+// run samples until Close is called.
+//
+// Each byte written clocks one sample. Two chunks are kept in flight so the
+// sampling doesn't pause while the previous chunk is decoded.
+func (s *Sniffer) run() {
+	defer s.wg.Done()
+	defer close(s.c)
+	clk := make([]byte, snifferChunk)
+	buf := make([]byte, snifferChunk)
+	if err := s.write(clk); err != nil {
+		s.fail(err)
+		return
+	}
+	for {
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+		if err := s.write(clk); err != nil {
+			s.fail(err)
+			return
+		}
+		if err := s.read(buf); err != nil {
+			s.fail(err)
+			return
+		}
+		s.d.decode(buf, time.Now(), s.emit)
+	}
+}
+
+func (s *Sniffer) write(b []byte) error {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+	_, err := s.f.h.Write(b)
+	return err
+}
+
+func (s *Sniffer) read(b []byte) error {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := s.f.h.ReadAll(ctx, b)
+	return err
+}
+
+func (s *Sniffer) emit(u UARTFrame) {
+	select {
+	case s.c <- u:
+	default:
+		s.mu.Lock()
+		s.dropped++
+		s.mu.Unlock()
+	}
+}
+
+func (s *Sniffer) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// sniffDecoder decodes the UART frames from the samples.
+type sniffDecoder struct {
+	period time.Duration // duration of a chunk of snifferChunk samples
+	rate   int64         // samples per second
+	next   time.Time     // time of the next sample
+	pins   []sniffPin
+}
+
+// sniffPin is the decoding state of a pin.
+type sniffPin struct {
+	pin        int
+	bits       int
+	parity     uart.Parity
+	oversample int
+	ready      bool // the line was seen idle
+	bit        int  // -1 for the start bit, then the data bits, parity and stop; -2 when idle
+	wait       int  // samples until the middle of the next bit
+	data       byte
+	ones       int
+	err        error
+	start      time.Time
+}
+
+func newSniffDecoder(o *SnifferOpts, rate physic.Frequency) *sniffDecoder {
+	hz := int64(rate / physic.Hertz)
+	d := &sniffDecoder{
+		period: time.Duration(snifferChunk * int64(time.Second) / hz),
+		rate:   hz,
+	}
+	for i := 0; i < 8; i++ {
+		if o.Pins&(1<<uint(i)) != 0 {
+			d.pins = append(d.pins, sniffPin{pin: i, bits: o.Bits, parity: o.Parity, oversample: o.Oversample, bit: -2})
+		}
+	}
+	return d
+}
+
+// decode decodes a chunk of samples received at now.
+//
+// The chunks are assumed contiguous unless the chunk ends later than one
+// chunk period after the expected time, which means the sampling paused.
+func (d *sniffDecoder) decode(b []byte, now time.Time, emit func(UARTFrame)) {
+	start := now.Add(-time.Duration(int64(len(b)) * int64(time.Second) / d.rate))
+	if d.next.IsZero() || start.Sub(d.next) > d.period {
+		// The sampling paused; the frames in progress are lost.
+		d.next = start
+		for i := range d.pins {
+			d.pins[i].ready = false
+			d.pins[i].bit = -2
+		}
+	}
+	t0 := d.next
+	for i, v := range b {
+		for j := range d.pins {
+			p := &d.pins[j]
+			if p.sample(v>>uint(p.pin)&1 != 0, emit) {
+				p.start = t0.Add(time.Duration(int64(i) * int64(time.Second) / d.rate))
+			}
+		}
+	}
+	d.next = t0.Add(time.Duration(int64(len(b)) * int64(time.Second) / d.rate))
+}
+
+// sample processes one sample of the pin. It returns true on the falling
+// edge of a start bit, so the caller sets p.start.
+func (p *sniffPin) sample(high bool, emit func(UARTFrame)) bool {
+	if p.bit == -2 {
+		if high {
+			p.ready = true
+		} else if p.ready {
+			// Falling edge of the start bit.
+			p.bit = -1
+			p.wait = p.oversample / 2
+			p.data = 0
+			p.ones = 0
+			p.err = nil
+			return true
+		}
+		return false
+	}
+	if p.wait--; p.wait > 0 {
+		return false
+	}
+	p.wait = p.oversample
+	n := p.bit
+	p.bit++
+	switch {
+	case n == -1:
+		if high {
+			// A glitch, not a start bit.
+			p.bit = -2
+		}
+	case n < p.bits:
+		if high {
+			p.data |= 1 << uint(n)
+			p.ones++
+		}
+	case n == p.bits && p.parity != uart.NoParity:
+		if high {
+			p.ones++
+		}
+		if !p.parityOK(high) {
+			p.err = errors.New("d2xx: parity error")
+		}
+	default:
+		err := p.err
+		if !high {
+			// The line must go back idle before the next frame.
+			p.ready = false
+			err = errors.New("d2xx: framing error")
+		}
+		emit(UARTFrame{Pin: p.pin, Data: p.data, Time: p.start, Err: err})
+		p.bit = -2
+	}
+	return false
+}
+
+// parityOK returns true if the parity bit, whose level is high, is valid.
+// p.ones includes it.
+func (p *sniffPin) parityOK(high bool) bool {
+	switch p.parity {
+	case uart.Odd:
+		return p.ones%2 == 1
+	case uart.Even:
+		return p.ones%2 == 0
+	case uart.Mark:
+		return high
+	default:
+		return !high
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/uart"
+)
+
+func TestFT232H_SniffUART(t *testing.T) {
+	h := &sampleHandle{samples: uartSamples(1, 8, 16, false, 'H', 'i')}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	for _, o := range []SnifferOpts{
+		{},
+		{Baud: 9600 * physic.Hertz, Bits: 9},
+		{Baud: 9600 * physic.Hertz, Parity: 'X'},
+		{Baud: 9600 * physic.Hertz, Oversample: 2},
+	} {
+		if _, err := f.SniffUART(&o); err == nil {
+			t.Fatalf("%#v: expected error", o)
+		}
+	}
+	s, err := f.SniffUART(&SnifferOpts{Baud: 115200 * physic.Hertz})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.SPI(); err == nil {
+		t.Fatal("already in bit mode")
+	}
+	for _, want := range []byte{'H', 'i'} {
+		u := <-s.Frames()
+		if u.Pin != 1 || u.Data != want || u.Err != nil {
+			t.Fatal(u.String())
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if h.mask != 0 || bitMode(h.mode) != bitModeMpsse {
+		t.Fatalf("mask %#x mode %#x", h.mask, h.mode)
+	}
+	if h.baud != 115200*8/16 {
+		t.Fatal(h.baud)
+	}
+	if _, ok := <-s.Frames(); ok {
+		t.Fatal("expected closed")
+	}
+}
+
+func TestSniffDecoder(t *testing.T) {
+	const rate = 9600 * 8
+	d := newSniffDecoder(&SnifferOpts{Pins: 0x03, Bits: 8, Parity: uart.NoParity, Oversample: 8}, rate*physic.Hertz)
+	// D0 sends 0x55 while D1 sends 0xA0 then a frame whose stop bit is low.
+	b := andSamples(uartSamples(0, 8, 10, false, 0x55), uartSamples(1, 8, 20, false, 0xA0))
+	b = append(b, uartSamples(1, 8, 0, true, 0x0F)...)
+	b = append(b, uartSamples(1, 8, 0, false, 0x42)...)
+	var got []UARTFrame
+	emit := func(u UARTFrame) {
+		got = append(got, u)
+	}
+	now := time.Unix(1000, 0)
+	// Split the samples in two contiguous chunks.
+	half := len(b) / 2
+	d.decode(b[:half], now, emit)
+	d.decode(b[half:], now.Add(time.Duration(len(b)-half)*time.Second/rate), emit)
+	t0 := now.Add(-time.Duration(half) * time.Second / rate)
+	want := []UARTFrame{
+		{Pin: 0, Data: 0x55, Time: t0.Add(10 * time.Second / rate)},
+		{Pin: 1, Data: 0xA0, Time: t0.Add(20 * time.Second / rate)},
+		{Pin: 1, Data: 0x0F},
+		{Pin: 1, Data: 0x42},
+	}
+	if len(got) != len(want) {
+		t.Fatal(got)
+	}
+	for i := range want {
+		if got[i].Pin != want[i].Pin || got[i].Data != want[i].Data {
+			t.Fatalf("#%d: %s", i, got[i].String())
+		}
+		if !want[i].Time.IsZero() && !got[i].Time.Equal(want[i].Time) {
+			t.Fatalf("#%d: %s != %s", i, got[i].Time, want[i].Time)
+		}
+	}
+	if got[2].Err == nil {
+		t.Fatal("expected framing error")
+	}
+	if got[0].Err != nil || got[3].Err != nil {
+		t.Fatal(got)
+	}
+
+	// A pause in the sampling drops the frame in progress.
+	got = nil
+	b = uartSamples(1, 8, 0, false, 0x42)
+	d.decode(b[:40], now.Add(time.Hour), emit)
+	d.decode(b[40:], now.Add(2*time.Hour), emit)
+	if len(got) != 0 {
+		t.Fatal(got)
+	}
+}
+
+func TestSniffDecoder_parity(t *testing.T) {
+	d := newSniffDecoder(&SnifferOpts{Pins: 0x01, Bits: 7, Parity: uart.Even, Oversample: 4}, 4800*physic.Hertz)
+	var got []UARTFrame
+	emit := func(u UARTFrame) {
+		got = append(got, u)
+	}
+	// 0x03 has two bits set: the even parity bit is 0. It is sent as 8 bits
+	// so the last one is the parity bit.
+	d.decode(uartSamples(0, 4, 4, false, 0x03, 0x83), time.Now(), emit)
+	if len(got) != 2 || got[0].Data != 0x03 || got[0].Err != nil || got[1].Data != 0x03 || got[1].Err == nil {
+		t.Fatal(got)
+	}
+}
+
+//
+
+// uartSamples returns the samples of 8N1 frames sent on pin, the other pins
+// staying high. The line is idle for idle samples first. When badStop is
+// true, the stop bits are low.
+func uartSamples(pin uint, oversample, idle int, badStop bool, data ...byte) []byte {
+	var out []byte
+	bit := func(high bool) {
+		for i := 0; i < oversample; i++ {
+			if high {
+				out = append(out, 0xFF)
+			} else {
+				out = append(out, 0xFF&^(1<<pin))
+			}
+		}
+	}
+	for i := 0; i < idle; i++ {
+		out = append(out, 0xFF)
+	}
+	for _, d := range data {
+		bit(false)
+		for i := uint(0); i < 8; i++ {
+			bit(d&(1<<i) != 0)
+		}
+		bit(!badStop)
+		if badStop {
+			// Back to idle.
+			bit(true)
+		}
+	}
+	return out
+}
+
+// andSamples combines the samples, padding the shorter with idle samples.
+func andSamples(a, b []byte) []byte {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i]
+		if i < len(b) {
+			out[i] &= b[i]
+		}
+	}
+	return out
+}
+
+// sampleHandle returns one sample per byte written in synchronous bit-bang
+// mode, then idle samples.
+type sampleHandle struct {
+	recordHandle
+	samples []byte
+	baud    uint32
+}
+
+func (s *sampleHandle) Write(b []byte) (int, d2xxErr) {
+	if bitMode(s.mode) != bitModeSyncBitbang {
+		return s.recordHandle.Write(b)
+	}
+	r := make([]byte, len(b))
+	n := copy(r, s.samples)
+	s.samples = s.samples[n:]
+	for i := n; i < len(r); i++ {
+		r[i] = 0xFF
+	}
+	s.Data = append(s.Data, r)
+	return len(b), 0
+}
+
+func (s *sampleHandle) SetBitMode(mask, mode byte) d2xxErr {
+	// Drop the samples not read.
+	s.Data = nil
+	if bitMode(mode) == bitModeMpsse {
+		s.replies = mpsseVerifyReplies()
+	}
+	return s.recordHandle.SetBitMode(mask, mode)
+}
+
+func (s *sampleHandle) SetBaudRate(v uint32) d2xxErr {
+	s.baud = v
+	return 0
+}
@@ -23,19 +23,64 @@ import (
 	"periph.io/x/conn/v3/spi"
 )
 
+// ChipSelecter is implemented by the SPI port returned by FT232H.SPI() to
+// drive several devices on the same bus, each with its own chip select line.
+type ChipSelecter interface {
+	// ConnectCS returns a connection using cs as the chip select line instead
+	// of D3.
+	//
+	// cs must be one of D4~D7 or C0~C7 and must not be used as a GPIO while
+	// the port is open. It is active low unless activeHigh is true. spi.NoCS
+	// is not supported.
+	//
+	// The connections share the port speed, which is the lowest one
+	// requested, and each has its own mode. The port lock serializes their
+	// transactions.
+	ConnectCS(cs gpio.PinOut, activeHigh bool, f physic.Frequency, m spi.Mode, bits int) (spi.Conn, error)
+}
+
+// SPITxError is returned by the SPI connections of a FT232H when a
+// transaction fails midway.
+//
+// Large transfers, e.g. to program a flash memory, can be resumed from Done.
+type SPITxError struct {
+	// Packet is the index of the packet that failed.
+	Packet int
+	// Done is the number of bytes of the packet transferred before the
+	// failure.
+	Done int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *SPITxError) Error() string {
+	return fmt.Sprintf("d2xx: SPI packet %d failed after %d bytes: %v", e.Packet, e.Done, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *SPITxError) Unwrap() error {
+	return e.Err
+}
+
 // spiMPSEEPort is an SPI port over a FTDI device in MPSSE mode using the data
 // command on the AD bus.
 type spiMPSEEPort struct {
 	c spiMPSEEConn
 
 	// Mutable.
-	maxFreq physic.Frequency
+	maxFreq    physic.Frequency
+	threePhase bool            // The clock is set for 3 phase data clocking
+	extra      []*spiMPSEEConn // Connections with their own chip select
 }
 
 func (s *spiMPSEEPort) Close() error {
 	s.c.f.mu.Lock()
 	s.c.f.usingSPI = false
 	s.maxFreq = 0
+	for _, c := range s.extra {
+		c.closed = true
+	}
+	s.extra = nil
 	s.c.edgeInvert = false
 	s.c.clkActiveLow = false
 	s.c.noCS = false
@@ -71,6 +116,9 @@ func (s *spiMPSEEPort) Connect(f physic.Frequency, m spi.Mode, bits int) (spi.Co
 
 	s.c.f.mu.Lock()
 	defer s.c.f.mu.Unlock()
+	if s.c.f.usingBitMode {
+		return nil, errors.New("d2xx: already using a bit mode")
+	}
 	s.c.noCS = m&spi.NoCS != 0
 	s.c.halfDuplex = m&spi.HalfDuplex != 0
 	s.c.lsbFirst = m&spi.LSBFirst != 0
@@ -84,13 +132,13 @@ func (s *spiMPSEEPort) Connect(f physic.Frequency, m spi.Mode, bits int) (spi.Co
 	s.c.edgeInvert = m&1 != 0
 	s.c.clkActiveLow = m&2 != 0
 	if s.maxFreq == 0 || f < s.maxFreq {
-		// TODO(maruel): We could set these only *during* the SPI operation, which
-		// would make more sense.
-		if _, err := s.c.f.h.MPSSEClock(f); err != nil {
-			return nil, err
-		}
 		s.maxFreq = f
 	}
+	// TODO(maruel): We could set these only *during* the SPI operation, which
+	// would make more sense.
+	if err := s.c.setClock(s.maxFreq); err != nil {
+		return nil, err
+	}
 	s.c.resetIdle()
 	if err := s.c.f.h.MPSSEDBus(s.c.f.dbus.direction, s.c.f.dbus.value); err != nil {
 		return nil, err
@@ -99,6 +147,82 @@ func (s *spiMPSEEPort) Connect(f physic.Frequency, m spi.Mode, bits int) (spi.Co
 	return &s.c, nil
 }
 
+// ConnectCS implements ChipSelecter.
+func (s *spiMPSEEPort) ConnectCS(cs gpio.PinOut, activeHigh bool, f physic.Frequency, m spi.Mode, bits int) (spi.Conn, error) {
+	if f > physic.GigaHertz {
+		return nil, fmt.Errorf("d2xx: invalid speed %s; maximum supported clock is 30MHz", f)
+	}
+	if f > 30*physic.MegaHertz {
+		f = 30 * physic.MegaHertz
+	}
+	if f < 100*physic.Hertz {
+		return nil, fmt.Errorf("d2xx: invalid speed %s; minimum supported clock is 100Hz; did you forget to multiply by physic.MegaHertz?", f)
+	}
+	if bits&7 != 0 {
+		return nil, errors.New("d2xx: bits must be multiple of 8")
+	}
+	if bits != 8 {
+		return nil, errors.New("d2xx: implement bits per word above 8")
+	}
+	if m&spi.NoCS != 0 {
+		return nil, errors.New("d2xx: spi.NoCS is not supported with a chip select")
+	}
+	if m&spi.HalfDuplex != 0 {
+		return nil, errors.New("d2xx: spi.HalfDuplex is not yet supported (implementing wouldn't be too hard, please submit a PR")
+	}
+	p, ok := cs.(*gpioMPSSE)
+	if !ok || (p.a != &s.c.f.dbus && p.a != &s.c.f.cbus) || (p.a == &s.c.f.dbus && p.num < 4) {
+		return nil, fmt.Errorf("d2xx: %s can't be used as a chip select; use one of D4~D7 or C0~C7", cs)
+	}
+	mode := m &^ spi.LSBFirst
+	if mode < 0 || mode > 3 {
+		return nil, errors.New("d2xx: unknown spi mode")
+	}
+
+	s.c.f.mu.Lock()
+	defer s.c.f.mu.Unlock()
+	if s.c.f.usingBitMode {
+		return nil, errors.New("d2xx: already using a bit mode")
+	}
+	mask := byte(1) << uint(p.num)
+	for _, e := range s.extra {
+		if (p.a.cbus && e.csC&mask != 0) || (!p.a.cbus && e.csD&mask != 0) {
+			return nil, fmt.Errorf("d2xx: %s is already used as a chip select", cs)
+		}
+	}
+	c := &spiMPSEEConn{
+		f:            s.c.f,
+		edgeInvert:   mode&1 != 0,
+		clkActiveLow: mode&2 != 0,
+		lsbFirst:     m&spi.LSBFirst != 0,
+		csHigh:       activeHigh,
+	}
+	if p.a.cbus {
+		c.csC = mask
+	} else {
+		c.csD = mask
+	}
+	if s.maxFreq == 0 || f < s.maxFreq {
+		s.maxFreq = f
+	}
+	if err := c.setClock(s.maxFreq); err != nil {
+		return nil, err
+	}
+	// Deassert the chip select right away.
+	c.resetIdle()
+	if err := s.c.f.h.MPSSEDBus(s.c.f.dbus.direction, s.c.f.dbus.value); err != nil {
+		return nil, err
+	}
+	if c.csC != 0 {
+		if err := s.c.f.h.MPSSECBus(s.c.f.cbus.direction, s.c.f.cbus.value); err != nil {
+			return nil, err
+		}
+	}
+	s.extra = append(s.extra, c)
+	s.c.f.usingSPI = true
+	return c, nil
+}
+
 // LimitSpeed implements spi.Port.
 func (s *spiMPSEEPort) LimitSpeed(f physic.Frequency) error {
 	if f > physic.GigaHertz {
@@ -118,8 +242,7 @@ func (s *spiMPSEEPort) LimitSpeed(f physic.Frequency) error {
 	s.maxFreq = f
 	// TODO(maruel): We could set these only *during* the SPI operation, which
 	// would make more sense.
-	_, err := s.c.f.h.MPSSEClock(s.maxFreq)
-	return err
+	return s.c.setClock(s.maxFreq)
 }
 
 // CLK returns the SCK (clock) pin.
@@ -152,6 +275,13 @@ type spiMPSEEConn struct {
 	noCS         bool // CS line is not changed
 	lsbFirst     bool // Default is MSB first
 	halfDuplex   bool // 3 wire mode
+
+	// Set by ConnectCS(); the chip select is on one of D4~D7 or C0~C7
+	// instead of D3.
+	csD    byte // Chip select mask on the D bus
+	csC    byte // Chip select mask on the C bus
+	csHigh bool // Chip select is active high
+	closed bool // The port was closed
 }
 
 func (s *spiMPSEEConn) String() string {
@@ -180,39 +310,46 @@ func (s *spiMPSEEConn) TxPackets(pkts []spi.Packet) error {
 		if p.BitsPerWord != 0 && p.BitsPerWord != 8 {
 			return errors.New("d2xx: implement spi.Packet.BitsPerWord")
 		}
-		if err := verifyBuffers(p.W, p.R); err != nil {
+		if err := verifyBuffers(p.W, p.R, 0); err != nil {
 			return err
 		}
 	}
 	s.f.mu.Lock()
 	defer s.f.mu.Unlock()
+	if s.closed {
+		return errors.New("d2xx: SPI port is closed")
+	}
 	const clk = byte(1) << 0
 	const mosi = byte(1) << 1
 	const miso = byte(1) << 2
 	const cs = byte(1) << 3
+	if s.edgeInvert != s.f.s.threePhase {
+		// Another connection uses a different mode.
+		if err := s.setClock(s.f.s.maxFreq); err != nil {
+			return err
+		}
+	}
 	s.resetIdle()
 	idle := s.f.dbus.value
-	start1 := idle
-	if !s.noCS {
-		start1 &^= cs
-	}
-	// In mode 0 and 2, start2 is not needed.
-	start2 := start1
-	stop := idle
-	if s.edgeInvert {
-		// This is needed to 'prime' the clock.
-		start2 ^= clk
-		// With mode 1 and 3, keep the clock steady while CS is being deasserted to
-		// not create a spurious clock.
-		stop ^= clk
-	}
+	start := idle
+	idleC := s.f.cbus.value
+	startC := idleC
+	switch {
+	case s.csD != 0:
+		start ^= s.csD
+	case s.csC != 0:
+		startC ^= s.csC
+	case !s.noCS:
+		start &^= cs
+	}
+	// The MPSSE natively supports only mode 0 and 2: data is written on the
+	// trailing edge and read on the leading one. Mode 1 and 3 use 3 phase data
+	// clocking, which holds the data for an extra half clock, so the data is
+	// stable on the trailing edge where the device samples it. See "Enable 3
+	// Phase Data Clocking" in AN_108.
 	ew := gpio.FallingEdge
 	er := gpio.RisingEdge
-	if s.edgeInvert {
-		ew, er = er, ew
-	}
 	if s.clkActiveLow {
-		// TODO(maruel): Not sure.
 		ew, er = er, ew
 	}
 
@@ -228,36 +365,63 @@ func (s *spiMPSEEConn) TxPackets(pkts []spi.Packet) error {
 	keptCS := false
 
 	// Loop, without increasing the index.
-	for _, p := range pkts {
+	for i, p := range pkts {
 		if len(p.W) == 0 && len(p.R) == 0 {
 			continue
 		}
 		// TODO(maruel): s.halfDuplex.
 
 		if !keptCS {
-			for i := 0; i < 5; i++ {
+			for j := 0; j < 5; j++ {
 				cmd = append(cmd, gpioSetD, idle, s.f.dbus.direction)
 			}
-			for i := 0; i < 5; i++ {
-				cmd = append(cmd, gpioSetD, start1, s.f.dbus.direction)
+			for j := 0; j < 5; j++ {
+				cmd = append(cmd, gpioSetD, start, s.f.dbus.direction)
+			}
+			if s.csC != 0 {
+				cmd = append(cmd, gpioSetC, startC, s.f.cbus.direction)
 			}
 		}
 		if s.edgeInvert {
-			// This is needed to 'prime' the clock.
-			for i := 0; i < 5; i++ {
-				cmd = append(cmd, gpioSetD, start2, s.f.dbus.direction)
-			}
+			cmd = append(cmd, clock3Phase)
 		}
 		op := mpsseTxOp(len(p.W) != 0, len(p.R) != 0, ew, er, s.lsbFirst)
 
+		n := len(p.W)
+		if n == 0 {
+			n = len(p.R)
+		}
+
 		// Do an I/O loop. We can mutate p here because it is a copy.
 		// TODO(maruel): Have the pipeline cross the packet boundary.
-		if len(p.W) == 0 {
-			// Have the write buffer point to the read one. This saves from
-			// allocating memory. The side effect is that it will write whatever
-			// happened to be in the read buffer.
+		write := len(p.W) != 0
+		if !write {
+			// Have the write buffer point to the read one, only to count the
+			// bytes left; it is not sent since the command only reads.
 			p.W = p.R[:]
 		}
+		done := 0
+		if len(p.R) == 0 {
+			// Write only: there's nothing to read back, so send the largest
+			// commands the MPSSE supports, directly from the caller's buffer.
+			// The driver pipelines the USB transfers.
+			for len(p.W) != 0 {
+				chunk := len(p.W)
+				if chunk > 65536 {
+					chunk = 65536
+				}
+				cmd = append(cmd, op, byte(chunk-1), byte((chunk-1)>>8))
+				if _, err := s.f.h.Write(cmd); err != nil {
+					return &SPITxError{Packet: i, Done: done, Err: err}
+				}
+				cmd = buf[:0]
+				if _, err := s.f.h.Write(p.W[:chunk]); err != nil {
+					return &SPITxError{Packet: i, Done: done, Err: err}
+				}
+				p.W = p.W[chunk:]
+				done += chunk
+			}
+		}
 		pendingRead := 0
 		for len(p.W) != 0 {
 			// op, sizelo, sizehi.
@@ -266,10 +430,12 @@ func (s *spiMPSEEConn) TxPackets(pkts []spi.Packet) error {
 				chunk = l
 			}
 			cmd = append(cmd, op, byte(chunk-1), byte((chunk-1)>>8))
-			cmd = append(cmd, p.W[:chunk]...)
+			if write {
+				cmd = append(cmd, p.W[:chunk]...)
+			}
 			p.W = p.W[chunk:]
 			if _, err := s.f.h.WriteFast(cmd); err != nil {
-				return err
+				return &SPITxError{Packet: i, Done: done, Err: err}
 			}
 			cmd = buf[:0]
 
@@ -279,10 +445,11 @@ func (s *spiMPSEEConn) TxPackets(pkts []spi.Packet) error {
 				if len(p.R) != 0 {
 					// Align reads on 512 bytes exactly, aligned on USB packet size.
 					if _, err := s.f.h.ReadAll(context.Background(), p.R[:512]); err != nil {
-						return err
+						return &SPITxError{Packet: i, Done: done, Err: err}
 					}
 					p.R = p.R[512:]
 					pendingRead -= 512
+					done += 512
 				}
 			}
 			pendingRead += chunk
@@ -293,26 +460,32 @@ func (s *spiMPSEEConn) TxPackets(pkts []spi.Packet) error {
 			// Send a flush to not wait for data.
 			cmd = append(cmd, flush)
 			if _, err := s.f.h.WriteFast(cmd); err != nil {
-				return err
+				return &SPITxError{Packet: i, Done: done, Err: err}
 			}
 			cmd = buf[:0]
-			if _, err := s.f.h.ReadAll(context.Background(), p.R); err != nil {
-				return err
+			if _, err := s.f.h.mpsseReadAll(context.Background(), p.R); err != nil {
+				return &SPITxError{Packet: i, Done: done, Err: err}
 			}
 		}
 		// TODO(maruel): Inject this in the write if it fits (it will generally
 		// do). That will save one USB I/O, which is not insignificant.
 		keptCS = p.KeepCS
 		if !keptCS {
+			if s.edgeInvert {
+				cmd = append(cmd, clock2Phase)
+			}
 			cmd = append(cmd, flush)
-			for i := 0; i < 5; i++ {
-				cmd = append(cmd, gpioSetD, stop, s.f.dbus.direction)
+			for j := 0; j < 5; j++ {
+				cmd = append(cmd, gpioSetD, idle, s.f.dbus.direction)
 			}
-			for i := 0; i < 5; i++ {
+			if s.csC != 0 {
+				cmd = append(cmd, gpioSetC, idleC, s.f.cbus.direction)
+			}
+			for j := 0; j < 5; j++ {
 				cmd = append(cmd, gpioSetD, idle, s.f.dbus.direction)
 			}
 			if _, err := s.f.h.WriteFast(cmd); err != nil {
-				return err
+				return &SPITxError{Packet: i, Done: n, Err: err}
 			}
 			cmd = buf[:0]
 		}
@@ -337,17 +510,59 @@ func (s *spiMPSEEConn) MISO() gpio.PinIn {
 
 // CS returns the CSN (chip select) pin.
 func (s *spiMPSEEConn) CS() gpio.PinOut {
+	for i := 0; i < 8; i++ {
+		if s.csD&(1<<uint(i)) != 0 {
+			return &s.f.dbus.pins[i]
+		}
+		if s.csC&(1<<uint(i)) != 0 {
+			return &s.f.cbus.pins[i]
+		}
+	}
 	return s.f.D3
 }
 
+// setClock sets the MPSSE clock for f.
+//
+// In mode 1 and 3, a bit lasts 3 half clocks instead of 2, so the clock is
+// raised to compensate; the maximum is 20MHz instead of 30MHz.
+func (s *spiMPSEEConn) setClock(f physic.Frequency) error {
+	if s.edgeInvert {
+		if f > 20*physic.MegaHertz {
+			f = 20 * physic.MegaHertz
+		}
+		f = f * 3 / 2
+	}
+	if _, err := s.f.h.MPSSEClock(f); err != nil {
+		return err
+	}
+	s.f.s.threePhase = s.edgeInvert
+	return nil
+}
+
 // resetIdle sets D0~D3. D0, D1 and D3 are output but only touch D3 is CS is
-// used.
+// used. When the chip select is another pin, D3 is left as-is and the chip
+// select is set inactive.
 func (s *spiMPSEEConn) resetIdle() {
 	const clk = byte(1) << 0
 	const mosi = byte(1) << 1
 	const miso = byte(1) << 2
 	const cs = byte(1) << 3
-	if !s.noCS {
+	if s.csD != 0 || s.csC != 0 {
+		s.f.dbus.value &= 0xF8
+		s.f.dbus.direction &= 0xF8
+		g := &s.f.dbus
+		mask := s.csD
+		if s.csC != 0 {
+			g = &s.f.cbus
+			mask = s.csC
+		}
+		g.direction |= mask
+		if s.csHigh {
+			g.value &^= mask
+		} else {
+			g.value |= mask
+		}
+	} else if !s.noCS {
 		s.f.dbus.direction &= 0xF0
 		s.f.dbus.direction |= cs
 		s.f.dbus.value &= 0xF0
@@ -539,7 +754,7 @@ func (s *spiSyncConn) TxPackets(pkts []spi.Packet) error {
 		if p.BitsPerWord != 0 && p.BitsPerWord != 8 {
 			return errors.New("d2xx: implement spi.Packet.BitsPerWord")
 		}
-		if err := verifyBuffers(p.W, p.R); err != nil {
+		if err := verifyBuffers(p.W, p.R, 65536); err != nil {
 			return err
 		}
 		// TODO(maruel): Correctly calculate offsets.
@@ -670,28 +885,20 @@ func (s *spiSyncConn) CS() gpio.PinOut {
 
 //
 
-func verifyBuffers(w, r []byte) error {
-	if len(w) != 0 {
-		if len(r) != 0 {
-			if len(w) != len(r) {
-				return errors.New("d2xx: both buffers must have the same size")
-			}
-		}
-		// TODO(maruel): When the buffer is >64Kb, cut it in parts and do not
-		// request a flush. Still try to read though.
-		if len(w) > 65536 {
-			return errors.New("d2xx: maximum buffer size is 64Kb")
-		}
-	} else if len(r) != 0 {
-		// TODO(maruel): Remove, this is not a problem.
-		if len(r) > 65536 {
-			return errors.New("d2xx: maximum buffer size is 64Kb")
-		}
+// verifyBuffers verifies the buffers of a packet. max is the maximum buffer
+// size, 0 means no limit.
+func verifyBuffers(w, r []byte, max int) error {
+	if len(w) != 0 && len(r) != 0 && len(w) != len(r) {
+		return errors.New("d2xx: both buffers must have the same size")
+	}
+	if max != 0 && (len(w) > max || len(r) > max) {
+		return fmt.Errorf("d2xx: maximum buffer size is %dKb", max/1024)
 	}
 	return nil
 }
 
 var _ spi.PortCloser = &spiMPSEEPort{}
 var _ spi.Conn = &spiMPSEEConn{}
+var _ ChipSelecter = &spiMPSEEPort{}
 var _ spi.PortCloser = &spiSyncPort{}
 var _ spi.Conn = &spiSyncConn{}
@@ -0,0 +1,285 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+func TestSPI_Modes(t *testing.T) {
+	data := []struct {
+		mode       spi.Mode
+		div        byte
+		idle, cs   byte
+		op         byte
+		threePhase bool
+	}{
+		{spi.Mode0, 29, 0x08, 0x00, dataOut | dataOutFall | dataIn, false},
+		// 3 phase data clocking, with the clock raised to 1.5MHz.
+		{spi.Mode1, 19, 0x08, 0x00, dataOut | dataOutFall | dataIn, true},
+		// The clock idles high.
+		{spi.Mode2, 29, 0x09, 0x01, dataOut | dataIn | dataInFall, false},
+		{spi.Mode3, 19, 0x09, 0x01, dataOut | dataIn | dataInFall, true},
+	}
+	for i, line := range data {
+		h := &recordHandle{}
+		f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+		f.s.c.f = f
+		p, err := f.SPI()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := p.Connect(physic.MegaHertz, line.mode, 8)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want := []byte{clock30MHz, clockSetDivisor, line.div, 0}; !bytes.HasPrefix(h.w, want) {
+			t.Fatalf("#%d: %#x", i, h.w)
+		}
+
+		h.w = nil
+		h.replies = [][]byte{nil, {0x5A}}
+		r := make([]byte, 1)
+		if err := c.Tx([]byte{0xA5}, r); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if r[0] != 0x5A {
+			t.Fatalf("#%d: %#x", i, r)
+		}
+		var want []byte
+		for j := 0; j < 5; j++ {
+			want = append(want, gpioSetD, line.idle, 0x0B)
+		}
+		for j := 0; j < 5; j++ {
+			want = append(want, gpioSetD, line.cs, 0x0B)
+		}
+		if line.threePhase {
+			want = append(want, clock3Phase)
+		}
+		want = append(want, line.op, 0, 0, 0xA5, flush)
+		if line.threePhase {
+			want = append(want, clock2Phase)
+		}
+		want = append(want, flush)
+		for j := 0; j < 10; j++ {
+			want = append(want, gpioSetD, line.idle, 0x0B)
+		}
+		if !bytes.Equal(h.w, want) {
+			t.Fatalf("#%d:\n%#x\n%#x", i, h.w, want)
+		}
+		if err := p.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSPI_Mode1_MaxSpeed(t *testing.T) {
+	h := &recordHandle{}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	f.s.c.f = f
+	p, err := f.SPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Capped to 20MHz, so the MPSSE runs at 30MHz with a divisor of 1.
+	if _, err := p.Connect(30*physic.MegaHertz, spi.Mode1, 8); err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{clock30MHz, clockSetDivisor, 0, 0}; !bytes.HasPrefix(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+}
+
+func TestSPI_ConnectCS(t *testing.T) {
+	h := &recordHandle{}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	f.s.c.f = f
+	f.cbus.cbus = true
+	f.dbus.init(f.name)
+	f.cbus.init(f.name)
+	f.D4 = &f.dbus.pins[4]
+	f.C2 = &f.cbus.pins[2]
+	p, err := f.SPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c0, err := p.Connect(physic.MegaHertz, spi.Mode0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := p.(ChipSelecter)
+	if _, err := cs.ConnectCS(&f.dbus.pins[2], false, physic.MegaHertz, spi.Mode0, 8); err == nil {
+		t.Fatal("D2 is MISO")
+	}
+	if _, err := cs.ConnectCS(&f.dbus.pins[4], false, physic.MegaHertz, spi.Mode0|spi.NoCS, 8); err == nil {
+		t.Fatal("NoCS")
+	}
+
+	// Active low on D4, in mode 1.
+	h.w = nil
+	c1, err := cs.ConnectCS(&f.dbus.pins[4], false, physic.MegaHertz, spi.Mode1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{clock30MHz, clockSetDivisor, 19, 0, gpioSetD, 0x18, 0x1B}; !bytes.Equal(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+	if _, err := cs.ConnectCS(f.D4, true, physic.MegaHertz, spi.Mode0, 8); err == nil || err.Error() != "d2xx: ft232h.D4 is already used as a chip select" {
+		t.Fatal(err)
+	}
+	if c1.(spi.Pins).CS() != f.D4 {
+		t.Fatal(c1.(spi.Pins).CS())
+	}
+
+	h.w = nil
+	if err := c1.Tx([]byte{0xA5}, nil); err != nil {
+		t.Fatal(err)
+	}
+	var want []byte
+	for j := 0; j < 5; j++ {
+		want = append(want, gpioSetD, 0x18, 0x1B)
+	}
+	for j := 0; j < 5; j++ {
+		want = append(want, gpioSetD, 0x08, 0x1B)
+	}
+	if !bytes.HasPrefix(h.w, want) || !bytes.Contains(h.w, []byte{clock3Phase}) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	// The primary connection restores the clock for mode 0 and keeps D4
+	// deasserted.
+	h.w = nil
+	if err := c0.Tx([]byte{0xA5}, nil); err != nil {
+		t.Fatal(err)
+	}
+	want = []byte{clock30MHz, clockSetDivisor, 29, 0}
+	for j := 0; j < 5; j++ {
+		want = append(want, gpioSetD, 0x18, 0x1B)
+	}
+	for j := 0; j < 5; j++ {
+		want = append(want, gpioSetD, 0x10, 0x1B)
+	}
+	if !bytes.HasPrefix(h.w, want) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	// Active high on C2.
+	h.w = nil
+	c2, err := cs.ConnectCS(f.C2, true, physic.MegaHertz, spi.Mode0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasSuffix(h.w, []byte{gpioSetC, 0x00, 0x04}) {
+		t.Fatalf("%#x", h.w)
+	}
+	h.w = nil
+	if err := c2.Tx([]byte{0xA5}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(h.w, []byte{gpioSetD, 0x18, 0x1B, gpioSetC, 0x04, 0x04, dataOut | dataOutFall, 0, 0, 0xA5}) {
+		t.Fatalf("%#x", h.w)
+	}
+	if !bytes.Contains(h.w, []byte{gpioSetC, 0x00, 0x04}) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c1.Tx([]byte{0xA5}, nil); err == nil || err.Error() != "d2xx: SPI port is closed" {
+		t.Fatal(err)
+	}
+}
+
+func TestSPI_LargeWrite(t *testing.T) {
+	h := &failHandle{}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	f.s.c.f = f
+	p, err := f.SPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := p.Connect(physic.MegaHertz, spi.Mode0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := make([]byte, 2*65536+10)
+	for i := range w {
+		w[i] = byte(i)
+	}
+	h.w = nil
+	if err := c.Tx(w, nil); err != nil {
+		t.Fatal(err)
+	}
+	op := dataOut | dataOutFall
+	// Prefix with the chip select.
+	i := bytes.IndexByte(h.w, op)
+	if i != 30 || !bytes.Equal(h.w[i:i+3], []byte{op, 0xFF, 0xFF}) || !bytes.Equal(h.w[i+3:i+3+65536], w[:65536]) {
+		t.Fatalf("%#x", h.w[:40])
+	}
+	i += 3 + 65536
+	if !bytes.Equal(h.w[i:i+3], []byte{op, 0xFF, 0xFF}) || !bytes.Equal(h.w[i+3:i+3+65536], w[65536:2*65536]) {
+		t.Fatal("second chunk")
+	}
+	i += 3 + 65536
+	if !bytes.Equal(h.w[i:i+3], []byte{op, 9, 0}) || !bytes.Equal(h.w[i+3:i+13], w[2*65536:]) {
+		t.Fatal("last chunk")
+	}
+
+	// Fail during the second chunk.
+	h.w = nil
+	h.left = 30 + 3 + 65536 + 3 + 100
+	err = c.Tx(w, nil)
+	if e, ok := err.(*SPITxError); !ok || e.Packet != 0 || e.Done != 65536 || e.Error() != "d2xx: SPI packet 0 failed after 65536 bytes: ftdi: Write: I/O error" {
+		t.Fatal(err)
+	}
+	// The first packet, 65 bytes including the chip select, succeeds.
+	h.w = nil
+	h.left = 65 + 30 + 3 + 100
+	err = c.TxPackets([]spi.Packet{{W: []byte{1}}, {W: w}})
+	if e, ok := err.(*SPITxError); !ok || e.Packet != 1 || e.Done != 0 || e.Unwrap() == nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSPI_MaxBuffer(t *testing.T) {
+	if err := verifyBuffers(make([]byte, 65537), nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyBuffers(make([]byte, 65537), nil, 65536); err == nil || err.Error() != "d2xx: maximum buffer size is 64Kb" {
+		t.Fatal(err)
+	}
+	if err := verifyBuffers(make([]byte, 2), make([]byte, 1), 0); err == nil {
+		t.Fatal("different sizes")
+	}
+}
+
+//
+
+// failHandle fails writes once left bytes were written, when left is not 0.
+type failHandle struct {
+	recordHandle
+	left int
+}
+
+func (f *failHandle) Write(b []byte) (int, d2xxErr) {
+	if f.left != 0 {
+		if len(b) >= f.left {
+			n := f.left
+			f.left = -1
+			f.w = append(f.w, b[:n]...)
+			return n, 4
+		}
+		if f.left < 0 {
+			return 0, 4
+		}
+		f.left -= len(b)
+	}
+	return f.recordHandle.Write(b)
+}
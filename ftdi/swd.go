@@ -0,0 +1,320 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// SWD returns an ARM Serial Wire Debug probe over the AD bus.
+//
+// D0 is SWCLK. SWDIO is connected to D2 directly and to D1 through a 470Ω
+// resistor. D1 is set as an input while the target drives SWDIO.
+//
+// The target is switched from JTAG to SWD with a line reset, then its DPIDR
+// is read to confirm it answers.
+func (f *FT232H) SWD(freq physic.Frequency) (*SWD, error) {
+	if freq > 30*physic.MegaHertz {
+		return nil, fmt.Errorf("d2xx: invalid speed %s; maximum supported clock is 30MHz", freq)
+	}
+	if freq < 100*physic.Hertz {
+		return nil, fmt.Errorf("d2xx: invalid speed %s; minimum supported clock is 100Hz; did you forget to multiply by physic.MegaHertz?", freq)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.canUseBitMode(); err != nil {
+		return nil, err
+	}
+	if _, err := f.h.Write([]byte{clock2Phase, clockNormal, internalLoopbackDisable}); err != nil {
+		return nil, err
+	}
+	if _, err := f.h.MPSSEClock(freq); err != nil {
+		return nil, err
+	}
+	f.usingSWD = true
+	s := &SWD{f: f}
+	if _, err := s.reset(); err != nil {
+		f.usingSWD = false
+		return nil, err
+	}
+	return s, nil
+}
+
+// SWD is an ARM Serial Wire Debug probe on the MPSSE of a FT232H.
+//
+// It gives access to the debug port (DP) and access port (AP) registers of
+// the target, as defined by the ARM Debug Interface v5.
+type SWD struct {
+	f      *FT232H
+	selVal uint32
+	selOk  bool
+}
+
+func (s *SWD) String() string {
+	return s.f.String()
+}
+
+// Close stops using D0~D2 as a SWD probe.
+func (s *SWD) Close() error {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+	s.f.usingSWD = false
+	d := &s.f.dbus
+	d.direction &^= swdPins
+	return s.f.h.MPSSEDBus(d.direction, d.value)
+}
+
+// Reset does a line reset and returns the DPIDR register.
+//
+// A line reset is needed to recover from a protocol error.
+func (s *SWD) Reset() (uint32, error) {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+	return s.reset()
+}
+
+// ReadDP reads the debug port register at addr: 0x0, 0x4, 0x8 or 0xC.
+func (s *SWD) ReadDP(addr uint8) (uint32, error) {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+	return s.read(false, addr)
+}
+
+// WriteDP writes the debug port register at addr: 0x0, 0x4, 0x8 or 0xC.
+func (s *SWD) WriteDP(addr uint8, v uint32) error {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+	if addr == swdSelect {
+		s.selOk = false
+	}
+	return s.write(false, addr, v)
+}
+
+// ReadAP reads the register at addr of the access port ap.
+//
+// SELECT is updated as needed. The AP read is posted, so the value is fetched
+// from RDBUFF.
+func (s *SWD) ReadAP(ap, addr uint8) (uint32, error) {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+	if err := s.selectAP(ap, addr); err != nil {
+		return 0, err
+	}
+	if _, err := s.read(true, addr&0x0C); err != nil {
+		return 0, err
+	}
+	return s.read(false, swdRDBUFF)
+}
+
+// WriteAP writes the register at addr of the access port ap.
+//
+// SELECT is updated as needed.
+func (s *SWD) WriteAP(ap, addr uint8, v uint32) error {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+	if err := s.selectAP(ap, addr); err != nil {
+		return err
+	}
+	return s.write(true, addr&0x0C, v)
+}
+
+//
+
+// Pins on the AD bus.
+const (
+	swdCLK   byte = 0x01 // D0
+	swdOut   byte = 0x02 // D1
+	swdIn    byte = 0x04 // D2
+	swdPins       = swdCLK | swdOut | swdIn
+	swdRetry      = 8
+)
+
+// DP registers.
+const (
+	swdSelect byte = 0x08
+	swdRDBUFF byte = 0x0C
+)
+
+// Acknowledge values.
+const (
+	swdOK    = 1
+	swdWait  = 2
+	swdFault = 4
+)
+
+// Data commands, clocked LSB first, output on the falling edge and input on
+// the rising edge.
+const (
+	swdBitsOut  = dataOut | dataOutFall | dataLSBF | dataBit
+	swdBitsIn   = dataIn | dataLSBF | dataBit
+	swdBytesOut = dataOut | dataOutFall | dataLSBF
+	swdBytesIn  = dataIn | dataLSBF
+)
+
+// drive returns the command to drive SWDIO through D1 or release it.
+func (s *SWD) drive(on bool) []byte {
+	d := &s.f.dbus
+	d.direction = d.direction&^swdPins | swdCLK
+	d.value = d.value&^swdPins | swdOut
+	if on {
+		d.direction |= swdOut
+	}
+	return []byte{gpioSetD, d.value, d.direction}
+}
+
+// reset sends the JTAG to SWD sequence surrounded by line resets, then reads
+// DPIDR.
+func (s *SWD) reset() (uint32, error) {
+	if !s.f.usingSWD {
+		return 0, errors.New("d2xx: SWD is closed")
+	}
+	cmd := s.drive(true)
+	seq := []byte{
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0x9E, 0xE7,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0x00,
+	}
+	cmd = append(cmd, swdBytesOut, byte(len(seq)-1), 0)
+	cmd = append(cmd, seq...)
+	if _, err := s.f.h.Write(cmd); err != nil {
+		return 0, err
+	}
+	s.selOk = false
+	return s.read(false, 0)
+}
+
+// selectAP sets SELECT for the AP register, if needed.
+func (s *SWD) selectAP(ap, addr uint8) error {
+	v := uint32(ap)<<24 | uint32(addr&0xF0)
+	if s.selOk && s.selVal == v {
+		return nil
+	}
+	if err := s.write(false, swdSelect, v); err != nil {
+		s.selOk = false
+		return err
+	}
+	s.selVal = v
+	s.selOk = true
+	return nil
+}
+
+// read does a read transfer, retrying on WAIT.
+func (s *SWD) read(ap bool, addr uint8) (uint32, error) {
+	for i := 0; ; i++ {
+		if err := s.request(ap, true, addr); err != nil {
+			if err == errSWDWait && i < swdRetry {
+				continue
+			}
+			return 0, err
+		}
+		// 32 bits of data, parity, then turnaround and idle.
+		cmd := []byte{swdBytesIn, 3, 0, swdBitsIn, 0, swdBitsIn, 0}
+		cmd = append(cmd, s.drive(true)...)
+		cmd = append(cmd, swdBitsOut, 7, 0, flush)
+		if _, err := s.f.h.Write(cmd); err != nil {
+			return 0, err
+		}
+		var r [6]byte
+		ctx, cancel := context200ms()
+		_, err := s.f.h.mpsseReadAll(ctx, r[:])
+		cancel()
+		if err != nil {
+			return 0, err
+		}
+		v := uint32(r[0]) | uint32(r[1])<<8 | uint32(r[2])<<16 | uint32(r[3])<<24
+		// Bits are shifted in from the most significant bit.
+		if uint32(r[4]>>7) != uint32(bits.OnesCount32(v)&1) {
+			return 0, errors.New("d2xx: SWD parity error")
+		}
+		return v, nil
+	}
+}
+
+// write does a write transfer, retrying on WAIT.
+func (s *SWD) write(ap bool, addr uint8, v uint32) error {
+	for i := 0; ; i++ {
+		if err := s.request(ap, false, addr); err != nil {
+			if err == errSWDWait && i < swdRetry {
+				continue
+			}
+			return err
+		}
+		// Turnaround, 32 bits of data, parity and idle.
+		cmd := []byte{swdBitsIn, 0}
+		cmd = append(cmd, s.drive(true)...)
+		cmd = append(cmd, swdBytesOut, 3, 0, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+		cmd = append(cmd, swdBitsOut, 0, byte(bits.OnesCount32(v)&1))
+		cmd = append(cmd, swdBitsOut, 7, 0, flush)
+		if _, err := s.f.h.Write(cmd); err != nil {
+			return err
+		}
+		// Discard the turnaround bit.
+		var r [1]byte
+		ctx, cancel := context200ms()
+		_, err := s.f.h.mpsseReadAll(ctx, r[:])
+		cancel()
+		return err
+	}
+}
+
+// request sends the request packet and reads the acknowledge.
+//
+// On success, SWDIO is left released for the data phase of a read or the
+// turnaround of a write.
+func (s *SWD) request(ap, read bool, addr uint8) error {
+	req := byte(0x81) | (addr&0x0C)<<1
+	if ap {
+		req |= 0x02
+	}
+	if read {
+		req |= 0x04
+	}
+	if bits.OnesCount8(req&0x1E)&1 != 0 {
+		req |= 0x20
+	}
+	cmd := s.drive(true)
+	cmd = append(cmd, swdBitsOut, 7, req)
+	cmd = append(cmd, s.drive(false)...)
+	// Turnaround then ACK.
+	cmd = append(cmd, swdBitsIn, 3, flush)
+	if _, err := s.f.h.Write(cmd); err != nil {
+		return err
+	}
+	var r [1]byte
+	ctx, cancel := context200ms()
+	defer cancel()
+	if _, err := s.f.h.mpsseReadAll(ctx, r[:]); err != nil {
+		return err
+	}
+	ack := r[0] >> 5
+	if ack == swdOK {
+		return nil
+	}
+	// Turnaround back to the host.
+	cmd = []byte{swdBitsIn, 0}
+	cmd = append(cmd, s.drive(true)...)
+	cmd = append(cmd, swdBitsOut, 7, 0, flush)
+	if _, err := s.f.h.Write(cmd); err != nil {
+		return err
+	}
+	if _, err := s.f.h.mpsseReadAll(ctx, r[:]); err != nil {
+		return err
+	}
+	switch ack {
+	case swdWait:
+		return errSWDWait
+	case swdFault:
+		return errors.New("d2xx: SWD target replied FAULT; clear the sticky flags with ABORT")
+	default:
+		return fmt.Errorf("d2xx: SWD target didn't acknowledge (%#x); is it powered and connected?", ack)
+	}
+}
+
+var errSWDWait = errors.New("d2xx: SWD target replied WAIT")
@@ -0,0 +1,96 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestSWD(t *testing.T) {
+	h := &recordHandle{}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	// Line reset, then DPIDR.
+	h.replies = [][]byte{nil, nil, nil, swdAck(swdOK), swdData(0x2BA01477)}
+	s, err := f.SWD(physic.MegaHertz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.I2C(gpio.PullUp); err == nil || err.Error() != "d2xx: already using SWD" {
+		t.Fatal(err)
+	}
+	// JTAG to SWD sequence.
+	if !bytes.Contains(h.w, []byte{0xFF, 0x9E, 0xE7, 0xFF}) {
+		t.Fatalf("%#x", h.w)
+	}
+	// Request for a DP read of 0x0.
+	if !bytes.Contains(h.w, []byte{swdBitsOut, 7, 0xA5}) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	// AP read: SELECT write, posted AP read, RDBUFF read.
+	h.w = nil
+	h.replies = [][]byte{swdAck(swdOK), {0}, swdAck(swdOK), swdData(0), swdAck(swdOK), swdData(0x24770011)}
+	v, err := s.ReadAP(0, 0xFC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 0x24770011 {
+		t.Fatalf("%#x", v)
+	}
+	// SELECT = 0x000000F0.
+	if !bytes.Contains(h.w, []byte{swdBytesOut, 3, 0, 0xF0, 0, 0, 0, swdBitsOut, 0, 0}) {
+		t.Fatalf("%#x", h.w)
+	}
+	// SELECT is cached; WAIT is retried.
+	h.w = nil
+	h.replies = [][]byte{swdAck(swdWait), {0}, swdAck(swdOK), {0}}
+	if err := s.WriteAP(0, 0xF4, 0x12345678); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(h.w, []byte{swdBytesOut, 3, 0, 0x78, 0x56, 0x34, 0x12, swdBitsOut, 0, 1}) {
+		t.Fatalf("%#x", h.w)
+	}
+
+	h.replies = [][]byte{swdAck(swdFault), {0}}
+	if err := s.WriteDP(0x4, 0); err == nil {
+		t.Fatal("expected FAULT")
+	}
+	h.replies = [][]byte{swdAck(swdOK), swdData(1)}
+	h.replies[1][4] = 0
+	if _, err := s.ReadDP(0x4); err == nil || err.Error() != "d2xx: SWD parity error" {
+		t.Fatal(err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ReadDP(0); err == nil {
+		t.Fatal("closed")
+	}
+	if _, err := f.SPI(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//
+
+// swdAck returns the reply to a request: the turnaround then the ACK, shifted
+// in from the most significant bit.
+func swdAck(ack byte) []byte {
+	return []byte{ack << 5}
+}
+
+// swdData returns the reply to a read data phase.
+func swdData(v uint32) []byte {
+	p := byte(0)
+	for i := v; i != 0; i &= i - 1 {
+		p ^= 0x80
+	}
+	return []byte{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24), p, 0}
+}
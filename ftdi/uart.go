@@ -0,0 +1,280 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/uart"
+)
+
+// UART returns the AD bus as a UART port, leaving MPSSE.
+//
+// D0 is TX, D1 is RX, D2 is RTS and D3 is CTS.
+//
+// I²C, SPI and the GPIOs can't be used until Close is called, which returns
+// the device to MPSSE mode.
+func (f *FT232H) UART() (uart.PortCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.canUseBitMode(); err != nil {
+		return nil, err
+	}
+	if err := f.h.SetBitMode(0, bitModeReset); err != nil {
+		return nil, err
+	}
+	f.usingUART = true
+	f.u = uartPort{
+		name:    f.name,
+		mu:      &f.mu,
+		h:       f.h,
+		using:   &f.usingUART,
+		pins:    f.hdr[:4],
+		limit:   12 * physic.MegaHertz,
+		maxFreq: 12 * physic.MegaHertz,
+		restore: func() error {
+			if err := f.h.SetBitMode(0, bitModeMpsse); err != nil {
+				return err
+			}
+			// This resets the clock and all the GPIOs as inputs.
+			return f.h.InitMPSSE()
+		},
+	}
+	return &f.u, nil
+}
+
+// UART returns D0~D3 as a UART port, leaving asynchronous bit-bang mode.
+//
+// D0 is TX, D1 is RX, D2 is RTS and D3 is CTS.
+//
+// SPI and D0~D7 can't be used until Close is called, which restores the last
+// direction and value of D0~D7.
+func (f *FT232R) UART() (uart.PortCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.usingSPI {
+		return nil, errors.New("d2xx: already using SPI")
+	}
+	if f.usingUART {
+		return nil, errors.New("d2xx: already using the UART")
+	}
+	if err := f.h.SetBitMode(0, bitModeReset); err != nil {
+		return nil, err
+	}
+	f.usingUART = true
+	// The FT4232H channels C and D are as fast as the FT232H.
+	limit := 3 * physic.MegaHertz
+	if f.h.t == DevTypeFT4232H {
+		limit = 12 * physic.MegaHertz
+	}
+	f.u = uartPort{
+		name:    f.name,
+		mu:      &f.mu,
+		h:       f.h,
+		using:   &f.usingUART,
+		pins:    f.hdr[:4],
+		limit:   limit,
+		maxFreq: limit,
+		restore: func() error {
+			f.usingCBus = false
+			if err := f.h.SetBitMode(f.dmask, bitModeAsyncBitbang); err != nil {
+				return err
+			}
+			return f.dbusWriteLocked(f.dvalue)
+		},
+	}
+	return &f.u, nil
+}
+
+// uartPort is the D bus of a device in UART mode.
+//
+// It implements uart.PortCloser and, once connected, conn.Conn.
+type uartPort struct {
+	// Immutable.
+	name    string
+	mu      *sync.Mutex  // lock of the device
+	h       *handle      // handle of the device
+	using   *bool        // set while the D bus is in UART mode; protected by mu
+	pins    []gpio.PinIO // TX, RX, RTS and CTS
+	limit   physic.Frequency
+	restore func() error // returns the device to its previous mode; mu is held
+
+	// Mutable.
+	maxFreq   physic.Frequency
+	connected bool
+}
+
+// Close returns the device to its previous mode.
+func (u *uartPort) Close() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !*u.using {
+		return nil
+	}
+	*u.using = false
+	return u.restore()
+}
+
+func (u *uartPort) String() string {
+	return u.name
+}
+
+// LimitSpeed implements uart.PortCloser.
+func (u *uartPort) LimitSpeed(f physic.Frequency) error {
+	if f <= 0 {
+		return errors.New("d2xx: invalid speed")
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if f > u.limit {
+		f = u.limit
+	}
+	u.maxFreq = f
+	return nil
+}
+
+// Connect implements uart.Port.
+//
+// bits must be 7 or 8 and stopBit must be uart.One or uart.Two.
+func (u *uartPort) Connect(f physic.Frequency, stopBit uart.Stop, parity uart.Parity, flow uart.Flow, bits int) (conn.Conn, error) {
+	if f <= 0 {
+		return nil, errors.New("d2xx: invalid speed")
+	}
+	if bits != 7 && bits != 8 {
+		return nil, fmt.Errorf("d2xx: invalid number of bits %d; only 7 or 8 are supported", bits)
+	}
+	var s byte
+	switch stopBit {
+	case uart.One:
+	case uart.Two:
+		s = 2
+	default:
+		return nil, fmt.Errorf("d2xx: invalid stop bit %d; only One or Two are supported", stopBit)
+	}
+	var p byte
+	switch parity {
+	case uart.NoParity:
+	case uart.Odd:
+		p = 1
+	case uart.Even:
+		p = 2
+	case uart.Mark:
+		p = 3
+	case uart.Space:
+		p = 4
+	default:
+		return nil, fmt.Errorf("d2xx: invalid parity %q", byte(parity))
+	}
+	fl := flowNone
+	var xon, xoff byte
+	switch {
+	case flow == uart.NoFlow:
+	case flow == uart.RTSCTS:
+		fl = flowRTSCTS
+	case flow&^0xFFFF == uart.XOnXOff:
+		fl = flowXOnXOff
+		xon, xoff = byte(flow>>8), byte(flow)
+	default:
+		return nil, fmt.Errorf("d2xx: invalid flow control %s", flow)
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !*u.using {
+		return nil, errors.New("d2xx: UART is closed")
+	}
+	if u.connected {
+		return nil, errors.New("d2xx: already connected")
+	}
+	if f > u.maxFreq {
+		f = u.maxFreq
+	}
+	if err := u.h.SetBaudRate(f); err != nil {
+		return nil, err
+	}
+	if err := u.h.SetUARTFormat(byte(bits), s, p, fl, xon, xoff); err != nil {
+		return nil, err
+	}
+	u.connected = true
+	return u, nil
+}
+
+// Duplex implements conn.Conn.
+func (u *uartPort) Duplex() conn.Duplex {
+	return conn.Full
+}
+
+// Tx implements conn.Conn.
+//
+// It writes w, then waits for len(r) bytes to be received.
+func (u *uartPort) Tx(w, r []byte) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !*u.using {
+		return errors.New("d2xx: UART is closed")
+	}
+	if len(w) != 0 {
+		if _, err := u.h.Write(w); err != nil {
+			return err
+		}
+	}
+	if len(r) != 0 {
+		ctx, cancel := context200ms()
+		defer cancel()
+		_, err := u.h.ReadAll(ctx, r)
+		return err
+	}
+	return nil
+}
+
+// Write implements io.Writer.
+func (u *uartPort) Write(b []byte) (int, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !*u.using {
+		return 0, errors.New("d2xx: UART is closed")
+	}
+	return u.h.Write(b)
+}
+
+// Read implements io.Reader.
+//
+// It returns the bytes already received without blocking.
+func (u *uartPort) Read(b []byte) (int, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !*u.using {
+		return 0, errors.New("d2xx: UART is closed")
+	}
+	return u.h.Read(b)
+}
+
+// RX implements uart.Pins.
+func (u *uartPort) RX() gpio.PinIn {
+	return u.pins[1]
+}
+
+// TX implements uart.Pins.
+func (u *uartPort) TX() gpio.PinOut {
+	return u.pins[0]
+}
+
+// RTS implements uart.Pins.
+func (u *uartPort) RTS() gpio.PinOut {
+	return u.pins[2]
+}
+
+// CTS implements uart.Pins.
+func (u *uartPort) CTS() gpio.PinIn {
+	return u.pins[3]
+}
+
+var _ uart.PortCloser = &uartPort{}
+var _ uart.Pins = &uartPort{}
+var _ conn.Conn = &uartPort{}
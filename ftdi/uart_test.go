@@ -0,0 +1,186 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/uart"
+)
+
+func TestFT232H_UART(t *testing.T) {
+	h := &uartHandle{recordHandle: recordHandle{fakeHandle: fakeHandle{Data: [][]byte{{0x42, 0x43}}}}}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	u, err := f.UART()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bitMode(h.mode) != bitModeReset {
+		t.Fatalf("mode %#x", h.mode)
+	}
+	if _, err := f.I2C(gpio.PullUp); err == nil || err.Error() != "d2xx: already using the UART" {
+		t.Fatal(err)
+	}
+	if _, err := f.AsyncBitBang(0); err == nil {
+		t.Fatal("already using the UART")
+	}
+	if err := u.LimitSpeed(9600 * physic.Hertz); err != nil {
+		t.Fatal(err)
+	}
+	c, err := u.Connect(115200*physic.Hertz, uart.Two, uart.Even, uart.MakeXOnXOffFlow(0x11, 0x13), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.bits != 7 || h.stop != 2 || h.parity != 2 || h.flow != flowXOnXOff || h.xon != 0x11 || h.xoff != 0x13 {
+		t.Fatalf("%+v", h)
+	}
+	if _, err := u.Connect(115200*physic.Hertz, uart.One, uart.NoParity, uart.NoFlow, 8); err == nil {
+		t.Fatal("already connected")
+	}
+	r := make([]byte, 2)
+	if err := c.Tx([]byte("AT\r"), r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(h.w, []byte("AT\r")) || !bytes.Equal(r, []byte{0x42, 0x43}) {
+		t.Fatalf("%q %#x", h.w, r)
+	}
+	if p := u.(uart.Pins); p.TX() != f.hdr[0] || p.CTS() != f.hdr[3] {
+		t.Fatal("unexpected pins")
+	}
+
+	// Back to MPSSE.
+	h.replies = mpsseVerifyReplies()
+	if err := u.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if bitMode(h.mode) != bitModeMpsse {
+		t.Fatalf("mode %#x", h.mode)
+	}
+	if err := c.Tx([]byte{1}, nil); err == nil {
+		t.Fatal("closed")
+	}
+	if _, err := f.SPI(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFT232H_UART_8N1Only(t *testing.T) {
+	h := &recordHandle{}
+	f := &FT232H{generic: generic{h: &handle{h: h}, name: "ft232h"}}
+	u, err := f.UART()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.Connect(115200*physic.Hertz, uart.One, uart.Even, uart.RTSCTS, 8); err == nil {
+		t.Fatal("the d2xx library only supports 8N1")
+	}
+	if _, err := u.Connect(115200*physic.Hertz, uart.One, uart.NoParity, uart.RTSCTS, 8); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFT232H_UART_Connect_err(t *testing.T) {
+	f := &FT232H{generic: generic{h: &handle{h: &uartHandle{}}, name: "ft232h"}}
+	u, err := f.UART()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		stop   uart.Stop
+		parity uart.Parity
+		flow   uart.Flow
+		bits   int
+	}{
+		{uart.One, uart.NoParity, uart.NoFlow, 9},
+		{uart.OneHalf, uart.NoParity, uart.NoFlow, 8},
+		{uart.One, 'X', uart.NoFlow, 8},
+		{uart.One, uart.NoParity, uart.Flow(1), 8},
+	}
+	for i, line := range data {
+		if _, err := u.Connect(115200*physic.Hertz, line.stop, line.parity, line.flow, line.bits); err == nil {
+			t.Fatalf("#%d: expected error", i)
+		}
+	}
+}
+
+func TestFT4232HSerial_UART(t *testing.T) {
+	h := &uartHandle{}
+	f, err := newFT4232HSerial(generic{h: &handle{h: h, t: DevTypeFT4232H}, name: "ft4232h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bitMode(h.mode) != bitModeAsyncBitbang {
+		t.Fatalf("mode %#x", h.mode)
+	}
+	if err := f.D4.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	u, err := f.UART()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bitMode(h.mode) != bitModeReset {
+		t.Fatalf("mode %#x", h.mode)
+	}
+	if _, err := f.UART(); err == nil {
+		t.Fatal("already using the UART")
+	}
+	if _, err := f.SPI(); err == nil {
+		t.Fatal("already using the UART")
+	}
+	if err := f.DBus(0xFF, 0); err == nil {
+		t.Fatal("already using the UART")
+	}
+	c, err := u.Connect(115200*physic.Hertz, uart.One, uart.NoParity, uart.NoFlow, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Data = [][]byte{{0x42}}
+	r := make([]byte, 1)
+	if err := c.Tx([]byte("AT"), r); err != nil {
+		t.Fatal(err)
+	}
+	if r[0] != 0x42 {
+		t.Fatalf("%#x", r)
+	}
+	if p := u.(uart.Pins); p.TX() != f.TX || p.CTS() != f.CTS {
+		t.Fatal("unexpected pins")
+	}
+
+	// Back to asynchronous bit-bang, with D4 still high.
+	h.w = nil
+	if err := u.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if bitMode(h.mode) != bitModeAsyncBitbang || h.mask != 0x10 || !bytes.Equal(h.w, []byte{0x10}) {
+		t.Fatalf("mode %#x mask %#x %#x", h.mode, h.mask, h.w)
+	}
+	if _, err := f.SPI(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//
+
+// uartHandle records the UART framing.
+type uartHandle struct {
+	recordHandle
+	bits, stop, parity byte
+	flow               uint16
+	xon, xoff          byte
+}
+
+func (u *uartHandle) SetDataCharacteristics(bits, stop, parity byte) d2xxErr {
+	u.bits, u.stop, u.parity = bits, stop, parity
+	return 0
+}
+
+func (u *uartHandle) SetFlowControlMode(flow uint16, xon, xoff byte) d2xxErr {
+	u.flow, u.xon, u.xoff = flow, xon, xoff
+	return 0
+}
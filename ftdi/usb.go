@@ -0,0 +1,81 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// USBConfig is the USB tuning of a device, set with SetUSBConfig.
+//
+// The zero value of each field selects the default.
+type USBConfig struct {
+	// Latency is the time the device waits before sending a partially filled
+	// USB packet, between 1ms and 255ms. It defaults to 1ms.
+	//
+	// The MPSSE flush command sends the data immediately, so the buses of this
+	// package are not affected. A longer latency reduces the USB traffic when
+	// streaming, e.g. with AsyncBitBang or the UART, at the cost of delaying
+	// the last bytes.
+	Latency time.Duration
+	// InTransferSize is the size of the USB transfers from the device, a
+	// multiple of 64 between 64 and 65536 bytes. It defaults to 65536.
+	//
+	// A smaller transfer returns the data sooner at a lower throughput.
+	InTransferSize int
+	// OutTransferSize is the size of the USB transfers to the device, a
+	// multiple of 64 between 64 and 65536 bytes. It is ignored by most drivers
+	// and defaults to theirs.
+	OutTransferSize int
+}
+
+func (c *USBConfig) String() string {
+	return fmt.Sprintf("latency %s, transfers in %d out %d", c.Latency, c.InTransferSize, c.OutTransferSize)
+}
+
+// SetUSBConfig sets the latency timer and the USB transfer sizes of d.
+//
+// The devices are opened with the default configuration. The configuration is
+// kept when the device is reset, e.g. when recovering the MPSSE.
+func SetUSBConfig(d Dev, c USBConfig) error {
+	g := devGeneric(d)
+	if g == nil {
+		return errors.New("d2xx: can't configure " + d.String())
+	}
+	if c.Latency != 0 && (c.Latency < time.Millisecond || c.Latency > 255*time.Millisecond || c.Latency%time.Millisecond != 0) {
+		return fmt.Errorf("d2xx: invalid latency %s; must be a whole number of ms between 1ms and 255ms", c.Latency)
+	}
+	for _, s := range []int{c.InTransferSize, c.OutTransferSize} {
+		if s != 0 && (s < 64 || s > 65536 || s%64 != 0) {
+			return fmt.Errorf("d2xx: invalid transfer size %d; must be a multiple of 64 between 64 and 65536", s)
+		}
+	}
+	return g.h.setUSBConfig(c)
+}
+
+//
+
+// setUSBConfig applies c, with the defaults for its zero fields, and keeps it
+// for the next Init.
+func (h *handle) setUSBConfig(c USBConfig) error {
+	if c.Latency == 0 {
+		c.Latency = time.Millisecond
+	}
+	if c.InTransferSize == 0 {
+		c.InTransferSize = 65536
+	}
+	// Driver: maximum packet size. Note that this clears any data in the buffer,
+	// so it is good to do it immediately after a reset.
+	if e := h.h.SetUSBParameters(c.InTransferSize, c.OutTransferSize); e != 0 {
+		return toErr("SetUSBParameters", e)
+	}
+	if e := h.h.SetLatencyTimer(uint8(c.Latency / time.Millisecond)); e != 0 {
+		return toErr("SetLatencyTimer", e)
+	}
+	h.usb = c
+	return nil
+}
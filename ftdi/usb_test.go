@@ -0,0 +1,69 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetUSBConfig(t *testing.T) {
+	h := &usbHandle{}
+	d := &generic{h: &handle{h: h}, name: "ft232h"}
+	for _, c := range []USBConfig{
+		{Latency: 256 * time.Millisecond},
+		{Latency: 1500 * time.Microsecond},
+		{InTransferSize: 100},
+		{OutTransferSize: 65536 + 64},
+	} {
+		if err := SetUSBConfig(d, c); err == nil {
+			t.Fatalf("%s: expected error", &c)
+		}
+	}
+	if err := SetUSBConfig(d, USBConfig{Latency: 16 * time.Millisecond, InTransferSize: 512}); err != nil {
+		t.Fatal(err)
+	}
+	if h.latency != 16 || h.in != 512 || h.out != 0 {
+		t.Fatal(h.latency, h.in, h.out)
+	}
+	// The configuration is kept across a reset.
+	h.latency, h.in = 0, 0
+	if err := d.h.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if h.latency != 16 || h.in != 512 {
+		t.Fatal(h.latency, h.in)
+	}
+	// Back to the defaults.
+	if err := SetUSBConfig(d, USBConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if h.latency != 1 || h.in != 65536 {
+		t.Fatal(h.latency, h.in)
+	}
+	if err := SetUSBConfig(&broken{name: "broken"}, USBConfig{}); err == nil {
+		t.Fatal("can't configure a broken device")
+	}
+}
+
+//
+
+// usbHandle records the USB configuration.
+type usbHandle struct {
+	fakeHandle
+	latency uint8
+	in, out int
+}
+
+func (u *usbHandle) SetLatencyTimer(delayMS uint8) d2xxErr {
+	u.latency = delayMS
+	return 0
+}
+
+func (u *usbHandle) SetUSBParameters(in, out int) d2xxErr {
+	u.in = in
+	u.out = out
+	return 0
+}
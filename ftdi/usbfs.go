@@ -0,0 +1,465 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Pure Go backend talking to the devices through the Linux usbfs interface,
+// for the hosts where the D2XX library is not available.
+//
+// Protocol as implemented by libftdi:
+// https://www.intra2net.com/en/developer/libftdi/
+
+package ftdi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/s-mobi01/host/hostcfg"
+)
+
+// usbfsUsed returns true if the devices are accessed through usbfs instead of
+// the D2XX library.
+//
+// D2XX is used when it is built in and available, unless usbfs is selected by
+// the build tag periph_host_ftdi_usbfs or by the host configuration. It is
+// built in by default on Windows and macOS, and on other OSes with the build
+// tag periph_host_ftdi_d2xx; usbfs is the default otherwise.
+func usbfsUsed() bool {
+	if usbfsForced {
+		return true
+	}
+	switch cfg, _ := hostcfg.Get(); cfg.FTDIBackend {
+	case "usbfs":
+		return true
+	case "d2xx":
+		return false
+	default:
+		return !d2xxEnabled || !d2xxAvailable()
+	}
+}
+
+// openDevice opens the ith device with the selected backend.
+func openDevice(i int) (d2xxHandle, d2xxErr) {
+	if usbfsUsed() {
+		return usbfsOpen(i)
+	}
+	return d2xxOpenDevice(i)
+}
+
+// usbDev is an opened interface of a FTDI device.
+//
+// It is implemented by usbfs and mocked in tests.
+type usbDev interface {
+	Close() error
+	// control runs a vendor control transfer on the interface. Data is read
+	// from the device into b when in is true, written otherwise.
+	control(in bool, req byte, value, index uint16, b []byte) error
+	// bulkOut sends b on the bulk OUT endpoint.
+	bulkOut(b []byte) error
+	// bulkIn reads up to len(b) bytes from the bulk IN endpoint. It returns 0
+	// on timeout.
+	bulkIn(b []byte) (int, error)
+	// reset resets the USB port, forcing the device to re-enumerate.
+	reset() error
+}
+
+// usbfsInfo describes an interface of a FTDI device found in sysfs.
+type usbfsInfo struct {
+	path  string // e.g. "/dev/bus/usb/001/004"
+	iface int
+	t     DevType
+	venID uint16
+	devID uint16
+	high  bool // USB 2.0 high speed, with 512 bytes packets
+}
+
+// usbfsDevices is the list of interfaces enumerated last by
+// usbfsNumDevices, indexed like D2XX.
+var usbfsDevices struct {
+	mu   sync.Mutex
+	all  []usbfsInfo
+	open func(i *usbfsInfo) (usbDev, error)
+}
+
+// usbfsNumDevices enumerates the FTDI interfaces.
+func usbfsNumDevices() (int, error) {
+	all, err := usbfsEnumerate("/sys/bus/usb/devices")
+	if err != nil {
+		return 0, fmt.Errorf("d2xx: %v", err)
+	}
+	usbfsDevices.mu.Lock()
+	defer usbfsDevices.mu.Unlock()
+	usbfsDevices.all = all
+	return len(all), nil
+}
+
+// usbfsOpen opens the ith interface enumerated last.
+func usbfsOpen(i int) (d2xxHandle, d2xxErr) {
+	usbfsDevices.mu.Lock()
+	defer usbfsDevices.mu.Unlock()
+	if i < 0 || i >= len(usbfsDevices.all) {
+		// FT_DEVICE_NOT_FOUND
+		return nil, 2
+	}
+	info := usbfsDevices.all[i]
+	u, err := usbfsDevices.open(&info)
+	if err != nil {
+		logf("usbfs: %s: %v", info.path, err)
+		// FT_DEVICE_NOT_OPENED
+		return nil, 3
+	}
+	return newUSBFSHandle(u, &info), 0
+}
+
+// usbfsEnumerate returns the interfaces of the FTDI devices listed in root,
+// normally /sys/bus/usb/devices, sorted by device node then interface.
+func usbfsEnumerate(root string) ([]usbfsInfo, error) {
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []usbfsInfo
+	for _, e := range entries {
+		if strings.ContainsRune(e.Name(), ':') {
+			// Interface, not a device.
+			continue
+		}
+		d := filepath.Join(root, e.Name())
+		v, err1 := readHex(filepath.Join(d, "idVendor"))
+		p, err2 := readHex(filepath.Join(d, "idProduct"))
+		bcd, err3 := readHex(filepath.Join(d, "bcdDevice"))
+		bus, err4 := readInt(filepath.Join(d, "busnum"))
+		dev, err5 := readInt(filepath.Join(d, "devnum"))
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil || v != 0x0403 {
+			continue
+		}
+		t, n := usbfsDevType(uint16(bcd))
+		if t == DevTypeUnknown {
+			continue
+		}
+		speed, _ := readInt(filepath.Join(d, "speed"))
+		for i := 0; i < n; i++ {
+			out = append(out, usbfsInfo{
+				path:  fmt.Sprintf("/dev/bus/usb/%03d/%03d", bus, dev),
+				iface: i,
+				t:     t,
+				venID: uint16(v),
+				devID: uint16(p),
+				high:  speed >= 480,
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].path != out[j].path {
+			return out[i].path < out[j].path
+		}
+		return out[i].iface < out[j].iface
+	})
+	return out, nil
+}
+
+// usbfsDevType returns the device type and the number of interfaces from the
+// device release number, as the product ID may be reprogrammed.
+func usbfsDevType(bcd uint16) (DevType, int) {
+	switch bcd {
+	case 0x0600:
+		return DevTypeFT232R, 1
+	case 0x0700:
+		return DevTypeFT2232H, 2
+	case 0x0800:
+		return DevTypeFT4232H, 4
+	case 0x0900:
+		return DevTypeFT232H, 1
+	case 0x1000:
+		return DevTypeFTXSeries, 1
+	default:
+		return DevTypeUnknown, 0
+	}
+}
+
+func readHex(path string) (uint64, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(b)), 16, 16)
+}
+
+func readInt(path string) (int, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(b)))
+}
+
+// FTDI vendor requests.
+const (
+	sioReset           = 0x00
+	sioSetFlowCtrl     = 0x02
+	sioSetBaudRate     = 0x03
+	sioSetData         = 0x04
+	sioSetEventChar    = 0x06
+	sioSetErrorChar    = 0x07
+	sioSetLatencyTimer = 0x09
+	sioSetBitMode      = 0x0B
+	sioReadPins        = 0x0C
+)
+
+// usbfsHandle implements d2xxHandle with the FTDI vendor requests.
+type usbfsHandle struct {
+	u      usbDev
+	t      DevType
+	venID  uint16
+	devID  uint16
+	index  uint16 // interface number used by the vendor requests, starting at 1
+	packet int    // size of the bulk IN packets
+	inSize int    // size of the bulk IN transfers
+	buf    []byte // data received but not read yet, without the status bytes
+	tmp    []byte
+}
+
+func newUSBFSHandle(u usbDev, i *usbfsInfo) *usbfsHandle {
+	h := &usbfsHandle{u: u, t: i.t, venID: i.venID, devID: i.devID, index: uint16(i.iface + 1), packet: 64, inSize: 4096}
+	if i.high {
+		h.packet = 512
+	}
+	return h
+}
+
+func (h *usbfsHandle) Close() d2xxErr {
+	return toD2XXErr(h.u.Close())
+}
+
+func (h *usbfsHandle) ResetDevice() d2xxErr {
+	h.buf = h.buf[:0]
+	return h.out(sioReset, 0, h.index)
+}
+
+func (h *usbfsHandle) GetDeviceInfo() (uint32, uint16, uint16, d2xxErr) {
+	return uint32(h.t), h.venID, h.devID, 0
+}
+
+// The EEPROM layouts returned by D2XX are not the raw content of the EEPROM,
+// so the EEPROM is not supported.
+
+func (h *usbfsHandle) EEPROMRead(devType uint32, e *d2xxEEPROM) d2xxErr {
+	// FT_NOT_SUPPORTED
+	return 17
+}
+
+func (h *usbfsHandle) EEPROMProgram(e *d2xxEEPROM) d2xxErr {
+	return 17
+}
+
+func (h *usbfsHandle) EraseEE() d2xxErr {
+	return 17
+}
+
+func (h *usbfsHandle) WriteEE(offset uint8, value uint16) d2xxErr {
+	return 17
+}
+
+func (h *usbfsHandle) EEUASize() (int, d2xxErr) {
+	return 0, 17
+}
+
+func (h *usbfsHandle) EEUARead(ua []byte) d2xxErr {
+	return 17
+}
+
+func (h *usbfsHandle) EEUAWrite(ua []byte) d2xxErr {
+	return 17
+}
+
+func (h *usbfsHandle) SetChars(eventChar byte, eventEn bool, errorChar byte, errorEn bool) d2xxErr {
+	v := uint16(eventChar)
+	if eventEn {
+		v |= 0x100
+	}
+	if e := h.out(sioSetEventChar, v, h.index); e != 0 {
+		return e
+	}
+	v = uint16(errorChar)
+	if errorEn {
+		v |= 0x100
+	}
+	return h.out(sioSetErrorChar, v, h.index)
+}
+
+// SetUSBParameters sets the size of the bulk IN transfers. The OUT transfers
+// are sent as is.
+func (h *usbfsHandle) SetUSBParameters(in, out int) d2xxErr {
+	if in <= 0 {
+		// FT_INVALID_PARAMETER
+		return 6
+	}
+	// Round up to whole packets.
+	h.inSize = (in + h.packet - 1) / h.packet * h.packet
+	return 0
+}
+
+func (h *usbfsHandle) SetFlowControl() d2xxErr {
+	return h.SetFlowControlMode(flowRTSCTS, 0, 0)
+}
+
+// SetTimeouts is ignored; the transfers use a fixed timeout.
+func (h *usbfsHandle) SetTimeouts(readMS, writeMS int) d2xxErr {
+	return 0
+}
+
+func (h *usbfsHandle) SetLatencyTimer(delayMS uint8) d2xxErr {
+	return h.out(sioSetLatencyTimer, uint16(delayMS), h.index)
+}
+
+func (h *usbfsHandle) SetBaudRate(hz uint32) d2xxErr {
+	if hz == 0 {
+		// FT_INVALID_BAUD_RATE
+		return 7
+	}
+	v, i := baudDivisor(h.t, hz)
+	if h.t == DevTypeFT232H || h.t == DevTypeFT2232H || h.t == DevTypeFT4232H {
+		i = i<<8 | h.index
+	}
+	return h.out(sioSetBaudRate, v, i)
+}
+
+// GetQueueStatus returns the number of bytes received.
+//
+// Unlike D2XX, the data is only received when polled here or in Read. The
+// device replies at least once per latency period.
+func (h *usbfsHandle) GetQueueStatus() (uint32, d2xxErr) {
+	if len(h.buf) == 0 {
+		if e := h.receive(); e != 0 {
+			return 0, e
+		}
+	}
+	return uint32(len(h.buf)), 0
+}
+
+func (h *usbfsHandle) Read(b []byte) (int, d2xxErr) {
+	if len(h.buf) == 0 {
+		if e := h.receive(); e != 0 {
+			return 0, e
+		}
+	}
+	n := copy(b, h.buf)
+	h.buf = h.buf[:copy(h.buf, h.buf[n:])]
+	return n, 0
+}
+
+func (h *usbfsHandle) Write(b []byte) (int, d2xxErr) {
+	if err := h.u.bulkOut(b); err != nil {
+		return 0, toD2XXErr(err)
+	}
+	return len(b), 0
+}
+
+func (h *usbfsHandle) GetBitMode() (byte, d2xxErr) {
+	var b [1]byte
+	if err := h.u.control(true, sioReadPins, 0, h.index, b[:]); err != nil {
+		return 0, toD2XXErr(err)
+	}
+	return b[0], 0
+}
+
+func (h *usbfsHandle) SetBitMode(mask, mode byte) d2xxErr {
+	return h.out(sioSetBitMode, uint16(mode)<<8|uint16(mask), h.index)
+}
+
+// SetDataCharacteristics implements uartFramer.
+func (h *usbfsHandle) SetDataCharacteristics(bits, stop, parity byte) d2xxErr {
+	return h.out(sioSetData, uint16(bits)|uint16(parity)<<8|uint16(stop)<<11, h.index)
+}
+
+// SetFlowControlMode implements uartFramer.
+func (h *usbfsHandle) SetFlowControlMode(flow uint16, xon, xoff byte) d2xxErr {
+	return h.out(sioSetFlowCtrl, uint16(xon)|uint16(xoff)<<8, flow|h.index)
+}
+
+// CyclePort implements portCycler.
+func (h *usbfsHandle) CyclePort() d2xxErr {
+	return toD2XXErr(h.u.reset())
+}
+
+//
+
+// out sends a vendor request without data.
+func (h *usbfsHandle) out(req byte, value, index uint16) d2xxErr {
+	return toD2XXErr(h.u.control(false, req, value, index, nil))
+}
+
+// receive runs a bulk IN transfer and appends the data to h.buf, stripping
+// the two modem status bytes at the start of each packet.
+func (h *usbfsHandle) receive() d2xxErr {
+	if cap(h.tmp) < h.inSize {
+		h.tmp = make([]byte, h.inSize)
+	}
+	b := h.tmp[:h.inSize]
+	n, err := h.u.bulkIn(b)
+	if err != nil {
+		return toD2XXErr(err)
+	}
+	for b = b[:n]; len(b) > 2; {
+		p := h.packet
+		if p > len(b) {
+			p = len(b)
+		}
+		h.buf = append(h.buf, b[2:p]...)
+		b = b[p:]
+	}
+	return 0
+}
+
+// baudDivisor returns the value and index of the SIO_SET_BAUDRATE request for
+// the baud rate hz. The interface number is not included in the index.
+func baudDivisor(t DevType, hz uint32) (uint16, uint16) {
+	var d uint32
+	switch {
+	case (t == DevTypeFT232H || t == DevTypeFT2232H || t == DevTypeFT4232H) && hz*10 > 120000000/0x3FFF:
+		// The H series have a 120MHz clock.
+		d = clockDivisor(120000000, 10, hz) | 0x20000
+	default:
+		d = clockDivisor(48000000, 16, hz)
+	}
+	return uint16(d), uint16(d >> 16)
+}
+
+// clockDivisor returns the encoded divisor of clk/div closest to hz, in
+// eighths with a non-linear encoding of the fraction.
+func clockDivisor(clk, div, hz uint32) uint32 {
+	switch {
+	case hz >= clk/div:
+		return 0
+	case hz >= clk/(div+div/2):
+		return 1
+	case hz >= clk/(2*div):
+		return 2
+	}
+	frac := [8]uint32{0, 3, 2, 4, 1, 5, 6, 7}
+	// Round to the nearest eighth.
+	d := (uint64(clk)*16/uint64(div)/uint64(hz) + 1) / 2
+	if d > 0x1FFFF {
+		d = 0x1FFFF
+	}
+	return uint32(d>>3) | frac[d&7]<<14
+}
+
+// toD2XXErr converts an usbfs error to the closest D2XX error.
+func toD2XXErr(err error) d2xxErr {
+	if err == nil {
+		return 0
+	}
+	logf("usbfs: %v", err)
+	// FT_IO_ERROR
+	return 4
+}
@@ -0,0 +1,11 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !periph_host_ftdi_usbfs
+// +build !periph_host_ftdi_usbfs
+
+package ftdi
+
+// usbfsForced is set by the build tag periph_host_ftdi_usbfs.
+const usbfsForced = false
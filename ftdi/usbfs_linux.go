@@ -0,0 +1,153 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+)
+
+const isLinux = true
+
+// Structures and constants from include/uapi/linux/usbdevice_fs.h.
+
+// ctrlTransfer is struct usbdevfs_ctrltransfer.
+type ctrlTransfer struct {
+	requestType uint8
+	request     uint8
+	value       uint16
+	index       uint16
+	length      uint16
+	timeout     uint32 // in milliseconds
+	data        uintptr
+}
+
+// bulkTransfer is struct usbdevfs_bulktransfer.
+type bulkTransfer struct {
+	ep      uint32
+	len     uint32
+	timeout uint32 // in milliseconds
+	data    uintptr
+}
+
+// ioctlRequest is struct usbdevfs_ioctl.
+type ioctlRequest struct {
+	ifno int32
+	code int32
+	data uintptr
+}
+
+var (
+	ioctlControl          = fs.IOWR('U', 0, uint(unsafe.Sizeof(ctrlTransfer{})))
+	ioctlBulk             = fs.IOWR('U', 2, uint(unsafe.Sizeof(bulkTransfer{})))
+	ioctlClaimInterface   = fs.IOR('U', 15, 4)
+	ioctlReleaseInterface = fs.IOR('U', 16, 4)
+	ioctlIoctl            = fs.IOWR('U', 18, uint(unsafe.Sizeof(ioctlRequest{})))
+	ioctlReset            = fs.IO('U', 20)
+	ioctlDisconnect       = fs.IO('U', 22)
+)
+
+// usbfs is an interface of a /dev/bus/usb/BBB/DDD device.
+type usbfs struct {
+	f     *fs.File
+	iface uint32
+	in    byte
+	out   byte
+}
+
+// openUSBFS opens the device and claims the interface, detaching the kernel
+// driver bound to it, usually ftdi_sio, if any.
+func openUSBFS(i *usbfsInfo) (usbDev, error) {
+	f, err := fs.Open(i.path, os.O_RDWR)
+	if err != nil {
+		return nil, err
+	}
+	// The endpoints of the interface n are 0x81+2n and 0x02+2n.
+	u := &usbfs{f: f, iface: uint32(i.iface), in: byte(0x81 + 2*i.iface), out: byte(0x02 + 2*i.iface)}
+	// It fails with ENODATA when no kernel driver is bound.
+	req := ioctlRequest{ifno: int32(i.iface), code: int32(ioctlDisconnect)}
+	if err := f.Ioctl(ioctlIoctl, uintptr(unsafe.Pointer(&req))); err != nil && err != syscall.ENODATA {
+		_ = f.Close()
+		return nil, err
+	}
+	if err := f.Ioctl(ioctlClaimInterface, uintptr(unsafe.Pointer(&u.iface))); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return u, nil
+}
+
+func (u *usbfs) Close() error {
+	err := u.f.Ioctl(ioctlReleaseInterface, uintptr(unsafe.Pointer(&u.iface)))
+	if err2 := u.f.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+func (u *usbfs) control(in bool, req byte, value, index uint16, b []byte) error {
+	// Vendor request to the device.
+	t := ctrlTransfer{requestType: 0x40, request: req, value: value, index: index, length: uint16(len(b)), timeout: 1000}
+	if in {
+		t.requestType |= 0x80
+	}
+	if len(b) != 0 {
+		t.data = uintptr(unsafe.Pointer(&b[0]))
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, u.f.Fd(), uintptr(ioctlControl), uintptr(unsafe.Pointer(&t)))
+	runtime.KeepAlive(b)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func (u *usbfs) bulkOut(b []byte) error {
+	for len(b) != 0 {
+		n, err := u.bulk(u.out, b, 1000)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func (u *usbfs) bulkIn(b []byte) (int, error) {
+	// The device replies at least once per latency period, up to 255ms.
+	n, err := u.bulk(u.in, b, 500)
+	if err == syscall.ETIMEDOUT {
+		return 0, nil
+	}
+	return n, err
+}
+
+func (u *usbfs) reset() error {
+	return u.f.Ioctl(ioctlReset, 0)
+}
+
+// bulk runs a bulk transfer and returns the number of bytes transferred.
+//
+// fs.File.Ioctl can't be used since it doesn't return the ioctl return value.
+func (u *usbfs) bulk(ep byte, b []byte, timeout uint32) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	t := bulkTransfer{ep: uint32(ep), len: uint32(len(b)), timeout: timeout, data: uintptr(unsafe.Pointer(&b[0]))}
+	n, _, errno := syscall.Syscall(syscall.SYS_IOCTL, u.f.Fd(), uintptr(ioctlBulk), uintptr(unsafe.Pointer(&t)))
+	runtime.KeepAlive(b)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
+
+func init() {
+	usbfsDevices.open = openUSBFS
+}
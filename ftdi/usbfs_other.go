@@ -0,0 +1,18 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package ftdi
+
+import "errors"
+
+const isLinux = false
+
+func init() {
+	usbfsDevices.open = func(i *usbfsInfo) (usbDev, error) {
+		return nil, errors.New("usbfs is only supported on linux")
+	}
+}
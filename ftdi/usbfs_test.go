@@ -0,0 +1,260 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package ftdi
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestUSBFSEnumerate(t *testing.T) {
+	root, err := ioutil.TempDir("", "ftdi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	devices := map[string]map[string]string{
+		"1-1":     {"idVendor": "0403\n", "idProduct": "6010\n", "bcdDevice": "0700\n", "busnum": "1\n", "devnum": "5\n", "speed": "480\n"},
+		"1-1:1.0": {"bInterfaceClass": "ff\n"},
+		"1-2":     {"idVendor": "0403\n", "idProduct": "6001\n", "bcdDevice": "0600\n", "busnum": "1\n", "devnum": "3\n", "speed": "12\n"},
+		"1-3":     {"idVendor": "0403\n", "idProduct": "6001\n", "bcdDevice": "0400\n", "busnum": "1\n", "devnum": "4\n"},
+		"usb1":    {"idVendor": "1d6b\n", "idProduct": "0002\n", "bcdDevice": "0515\n", "busnum": "1\n", "devnum": "1\n"},
+	}
+	for name, files := range devices {
+		d := filepath.Join(root, name)
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for f, content := range files {
+			if err := ioutil.WriteFile(filepath.Join(d, f), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	got, err := usbfsEnumerate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []usbfsInfo{
+		{path: "/dev/bus/usb/001/003", t: DevTypeFT232R, venID: 0x0403, devID: 0x6001},
+		{path: "/dev/bus/usb/001/005", t: DevTypeFT2232H, venID: 0x0403, devID: 0x6010, high: true},
+		{path: "/dev/bus/usb/001/005", iface: 1, t: DevTypeFT2232H, venID: 0x0403, devID: 0x6010, high: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("usbfsEnumerate() = %#v, want %#v", got, want)
+	}
+	if got, err := usbfsEnumerate(filepath.Join(root, "missing")); got != nil || err != nil {
+		t.Fatal(got, err)
+	}
+}
+
+func TestUSBFSOpen(t *testing.T) {
+	defer func(o func(i *usbfsInfo) (usbDev, error)) {
+		usbfsDevices.open = o
+		usbfsDevices.all = nil
+	}(usbfsDevices.open)
+	var opened []usbfsInfo
+	usbfsDevices.open = func(i *usbfsInfo) (usbDev, error) {
+		opened = append(opened, *i)
+		return &fakeUSBDev{}, nil
+	}
+	usbfsDevices.all = []usbfsInfo{{path: "/dev/bus/usb/001/005", iface: 1, t: DevTypeFT2232H, venID: 0x0403, devID: 0x6010, high: true}}
+	if _, e := usbfsOpen(1); e != 2 {
+		t.Fatal(e)
+	}
+	h, e := usbfsOpen(0)
+	if e != 0 {
+		t.Fatal(e)
+	}
+	if typ, v, p, e := h.GetDeviceInfo(); DevType(typ) != DevTypeFT2232H || v != 0x0403 || p != 0x6010 || e != 0 {
+		t.Fatal(typ, v, p, e)
+	}
+	if !reflect.DeepEqual(opened, usbfsDevices.all) {
+		t.Fatal(opened)
+	}
+	// The handle supports the optional features.
+	if _, ok := h.(uartFramer); !ok {
+		t.Fatal("expected uartFramer")
+	}
+	if _, ok := h.(portCycler); !ok {
+		t.Fatal("expected portCycler")
+	}
+}
+
+func TestUSBFSHandle(t *testing.T) {
+	u := &fakeUSBDev{}
+	h := newUSBFSHandle(u, &usbfsInfo{iface: 1, t: DevTypeFT2232H})
+	if c, ok := (&handle{h: h}).channel(); !ok || c != 1 {
+		t.Fatal(c, ok)
+	}
+	if e := h.SetBaudRate(115200); e != 0 {
+		t.Fatal(e)
+	}
+	if e := h.SetBitMode(0x0B, byte(bitModeMpsse)); e != 0 {
+		t.Fatal(e)
+	}
+	if e := h.SetLatencyTimer(16); e != 0 {
+		t.Fatal(e)
+	}
+	if e := h.SetDataCharacteristics(7, 2, 2); e != 0 {
+		t.Fatal(e)
+	}
+	if e := h.SetFlowControl(); e != 0 {
+		t.Fatal(e)
+	}
+	if e := h.SetChars(0, false, 0, false); e != 0 {
+		t.Fatal(e)
+	}
+	want := []fakeControl{
+		{req: sioSetBaudRate, value: 0xC068, index: 0x0202},
+		{req: sioSetBitMode, value: 0x020B, index: 2},
+		{req: sioSetLatencyTimer, value: 16, index: 2},
+		{req: sioSetData, value: 0x1207, index: 2},
+		{req: sioSetFlowCtrl, value: 0, index: 0x0102},
+		{req: sioSetEventChar, value: 0, index: 2},
+		{req: sioSetErrorChar, value: 0, index: 2},
+	}
+	if !reflect.DeepEqual(u.ctrl, want) {
+		t.Fatalf("%#v", u.ctrl)
+	}
+
+	// The modem status bytes at the start of each 64 bytes packet are
+	// stripped.
+	data := make([]byte, 70)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	pkt := append([]byte{0x31, 0x60}, data[:62]...)
+	pkt = append(pkt, 0x31, 0x60)
+	pkt = append(pkt, data[62:]...)
+	u.in = [][]byte{pkt, {0x31, 0x60}}
+	if n, e := h.GetQueueStatus(); n != 70 || e != 0 {
+		t.Fatal(n, e)
+	}
+	b := make([]byte, 50)
+	if n, e := h.Read(b); n != 50 || e != 0 || !bytes.Equal(b, data[:50]) {
+		t.Fatal(n, e, b)
+	}
+	if n, e := h.Read(b); n != 20 || e != 0 || !bytes.Equal(b[:20], data[50:]) {
+		t.Fatal(n, e, b)
+	}
+	// Only the status bytes.
+	if n, e := h.GetQueueStatus(); n != 0 || e != 0 {
+		t.Fatal(n, e)
+	}
+
+	if _, e := h.Write([]byte{1, 2, 3}); e != 0 || !bytes.Equal(u.out, []byte{1, 2, 3}) {
+		t.Fatal(e, u.out)
+	}
+	u.pins = 0xA5
+	if v, e := h.GetBitMode(); v != 0xA5 || e != 0 {
+		t.Fatal(v, e)
+	}
+	if e := h.EEPROMRead(uint32(DevTypeFT2232H), nil); e != 17 {
+		t.Fatal(e)
+	}
+}
+
+func TestUSBFSEEPROMErr(t *testing.T) {
+	// The EEPROM operations that usbfs doesn't support are reported as not
+	// enabled when D2XX, which supports them, was left out of the build.
+	err := toErr("EEPROMRead", 17)
+	if d2xxEnabled && d2xxBuilt {
+		if err == nil || err.Error() != "ftdi: EEPROMRead: not supported" {
+			t.Fatal(err)
+		}
+	} else if !errors.Is(err, ErrD2XXNotEnabled) {
+		t.Fatal(err)
+	}
+	if err := d2xxUnavailable(); (err == nil) != (d2xxEnabled && d2xxBuilt && d2xxAvailable()) {
+		t.Fatal(err)
+	}
+}
+
+func TestD2XXDefault(t *testing.T) {
+	// usbfs doesn't exist on Windows and macOS, so D2XX must be built in there
+	// unless it was left out with no_d2xx.
+	if noUSBFS := runtime.GOOS == "windows" || runtime.GOOS == "darwin"; noUSBFS && d2xxBuilt && !d2xxEnabled {
+		t.Fatal("D2XX is not built in on " + runtime.GOOS)
+	}
+}
+
+func TestBaudDivisor(t *testing.T) {
+	data := []struct {
+		t     DevType
+		hz    uint32
+		value uint16
+		index uint16
+	}{
+		{DevTypeFT232R, 9600, 0x4138, 0},
+		{DevTypeFT232R, 115200, 0x001A, 0},
+		{DevTypeFT232R, 3000000, 0, 0},
+		{DevTypeFT232R, 2000000, 1, 0},
+		{DevTypeFT232R, 1500000, 2, 0},
+		{DevTypeFT232R, 100, 0xFFFF, 1},
+		{DevTypeFT232H, 115200, 0xC068, 2},
+		{DevTypeFT232H, 12000000, 0, 2},
+		// Below 733 bauds, the 48MHz clock is used.
+		{DevTypeFT232H, 300, 0x2710, 0},
+	}
+	for i, line := range data {
+		if v, idx := baudDivisor(line.t, line.hz); v != line.value || idx != line.index {
+			t.Fatalf("#%d: baudDivisor(%s, %d) = %#x, %#x; want %#x, %#x", i, line.t, line.hz, v, idx, line.value, line.index)
+		}
+	}
+}
+
+//
+
+type fakeControl struct {
+	in    bool
+	req   byte
+	value uint16
+	index uint16
+}
+
+// fakeUSBDev records the requests and returns the queued bulk IN transfers.
+type fakeUSBDev struct {
+	ctrl []fakeControl
+	out  []byte
+	in   [][]byte
+	pins byte
+}
+
+func (f *fakeUSBDev) Close() error {
+	return nil
+}
+
+func (f *fakeUSBDev) control(in bool, req byte, value, index uint16, b []byte) error {
+	f.ctrl = append(f.ctrl, fakeControl{in, req, value, index})
+	if req == sioReadPins && len(b) == 1 {
+		b[0] = f.pins
+	}
+	return nil
+}
+
+func (f *fakeUSBDev) bulkOut(b []byte) error {
+	f.out = append(f.out, b...)
+	return nil
+}
+
+func (f *fakeUSBDev) bulkIn(b []byte) (int, error) {
+	if len(f.in) == 0 {
+		return 0, nil
+	}
+	n := copy(b, f.in[0])
+	f.in = f.in[1:]
+	return n, nil
+}
+
+func (f *fakeUSBDev) reset() error {
+	return nil
+}
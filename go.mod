@@ -9,5 +9,4 @@ go 1.13
 require (
 	periph.io/x/conn/v3 v3.6.8
 	periph.io/x/d2xx v0.0.3
-	periph.io/x/host/v3 v3.7.0
 )
@@ -0,0 +1,17 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package gpiobatch applies a set of pin configurations together, restoring
+// the previous configuration of the pins if one of them fails.
+//
+// It is meant for device bring-up sequences, so that a failing pin doesn't
+// leave the board half configured. The configurations are applied one after
+// the other, so it is not atomic from the electrical point of view: another
+// device sees the intermediate states during the few µs it takes.
+//
+// The previous state of a pin is its function as returned by pin.PinFunc,
+// its level when an output and its pull when an input. Pins not implementing
+// pin.PinFunc are restored as inputs with their previous pull, which is the
+// safe high impedance state.
+package gpiobatch
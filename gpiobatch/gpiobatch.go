@@ -0,0 +1,171 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package gpiobatch
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/pin"
+)
+
+// Batch is an ordered list of pin configurations.
+//
+// The zero value is ready to use. It is not safe for concurrent use.
+type Batch struct {
+	steps   []step
+	applied []state // state of each pin before the last Apply, in order
+}
+
+// In adds the configuration of p as an input.
+func (b *Batch) In(p gpio.PinIO, pull gpio.Pull, edge gpio.Edge) *Batch {
+	b.steps = append(b.steps, step{p: p, op: fmt.Sprintf("In(%s, %s)", pull, edge), do: func() error {
+		return p.In(pull, edge)
+	}})
+	return b
+}
+
+// Out adds the configuration of p as an output at level l.
+func (b *Batch) Out(p gpio.PinIO, l gpio.Level) *Batch {
+	b.steps = append(b.steps, step{p: p, op: fmt.Sprintf("Out(%s)", l), do: func() error {
+		return p.Out(l)
+	}})
+	return b
+}
+
+// SetFunc adds the change of the function of p, e.g. to an alternate
+// function like "SPI0_MOSI". p must implement pin.PinFunc.
+func (b *Batch) SetFunc(p gpio.PinIO, f pin.Func) *Batch {
+	b.steps = append(b.steps, step{p: p, op: fmt.Sprintf("SetFunc(%s)", f), do: func() error {
+		pf, ok := p.(pin.PinFunc)
+		if !ok {
+			return errors.New("pin doesn't implement pin.PinFunc")
+		}
+		return pf.SetFunc(f)
+	}})
+	return b
+}
+
+// Debounce adds the change of the debounce period the kernel applies to p
+// when used as an input, e.g. a gpioioctl.GPIOLine. 0 disables debouncing.
+func (b *Batch) Debounce(p gpio.PinIO, period time.Duration) *Batch {
+	b.steps = append(b.steps, step{p: p, op: fmt.Sprintf("Debounce(%s)", period), do: func() error {
+		d, ok := p.(debouncer)
+		if !ok {
+			return errors.New("pin doesn't support debouncing")
+		}
+		return d.SetDebounce(period)
+	}})
+	return b
+}
+
+// Len returns the number of configurations in the batch.
+func (b *Batch) Len() int {
+	return len(b.steps)
+}
+
+// Apply applies the configurations in order.
+//
+// On failure, the pins already configured are restored in the reverse order
+// and the error names the failing configuration.
+func (b *Batch) Apply() error {
+	b.applied = b.applied[:0]
+	for _, s := range b.steps {
+		if !b.saved(s.p) {
+			b.applied = append(b.applied, save(s.p))
+		}
+		if err := s.do(); err != nil {
+			err = fmt.Errorf("gpiobatch: %s: %s: %v", s.p, s.op, err)
+			if err2 := b.Rollback(); err2 != nil {
+				return fmt.Errorf("%v; %v", err, err2)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Rollback restores the pins to their state before the last Apply, in the
+// reverse order.
+//
+// All the pins are restored even if one fails; the first error is returned.
+func (b *Batch) Rollback() error {
+	var err error
+	for i := len(b.applied) - 1; i >= 0; i-- {
+		s := b.applied[i]
+		if err2 := s.restore(); err2 != nil && err == nil {
+			err = fmt.Errorf("gpiobatch: failed to restore %s: %v", s.p, err2)
+		}
+	}
+	b.applied = b.applied[:0]
+	return err
+}
+
+//
+
+// step is a configuration of a pin.
+type step struct {
+	p  gpio.PinIO
+	op string
+	do func() error
+}
+
+// debouncer is implemented by pins debounced by the kernel.
+type debouncer interface {
+	SetDebounce(period time.Duration) error
+	Debounce() time.Duration
+}
+
+// state is the state of a pin before the batch.
+type state struct {
+	p      gpio.PinIO
+	f      pin.Func // pin.FuncNone if unknown
+	level  gpio.Level
+	pull   gpio.Pull
+	period time.Duration // debounce period, if p implements debouncer
+}
+
+func save(p gpio.PinIO) state {
+	s := state{p: p, level: p.Read(), pull: p.Pull()}
+	if pf, ok := p.(pin.PinFunc); ok {
+		s.f = pf.Func()
+	}
+	if d, ok := p.(debouncer); ok {
+		s.period = d.Debounce()
+	}
+	return s
+}
+
+func (s *state) restore() error {
+	if d, ok := s.p.(debouncer); ok && d.Debounce() != s.period {
+		if err := d.SetDebounce(s.period); err != nil {
+			return err
+		}
+	}
+	switch s.f {
+	case gpio.OUT_HIGH:
+		return s.p.Out(gpio.High)
+	case gpio.OUT_LOW:
+		return s.p.Out(gpio.Low)
+	case gpio.OUT:
+		return s.p.Out(s.level)
+	case pin.FuncNone, gpio.IN, gpio.IN_HIGH, gpio.IN_LOW, gpio.FLOAT:
+		return s.p.In(s.pull, gpio.NoEdge)
+	default:
+		return s.p.(pin.PinFunc).SetFunc(s.f)
+	}
+}
+
+// saved returns true if the state of p was saved by the current Apply.
+func (b *Batch) saved(p gpio.PinIO) bool {
+	for i := range b.applied {
+		if b.applied[i].p == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package gpiobatch
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/pin"
+)
+
+func TestBatch_Apply(t *testing.T) {
+	a := newPin("A", gpio.OUT_HIGH)
+	b := newPin("B", gpio.IN)
+	var batch Batch
+	batch.Out(a, gpio.Low).In(b, gpio.PullUp, gpio.NoEdge).SetFunc(b, "SPI0_MOSI")
+	if batch.Len() != 3 {
+		t.Fatal(batch.Len())
+	}
+	if err := batch.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if a.fn != gpio.OUT_LOW || b.fn != "SPI0_MOSI" {
+		t.Fatal(a.fn, b.fn)
+	}
+	if err := batch.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if a.fn != gpio.OUT_HIGH || b.fn != gpio.IN {
+		t.Fatal(a.fn, b.fn)
+	}
+}
+
+func TestBatch_Apply_rollback(t *testing.T) {
+	log = nil
+	a := newPin("A", "I2C1_SDA")
+	b := newPin("B", gpio.OUT_LOW)
+	c := newPin("C", gpio.IN)
+	c.fail = errors.New("oops")
+	var batch Batch
+	batch.In(a, gpio.PullDown, gpio.NoEdge).Out(b, gpio.High).Out(c, gpio.High)
+	err := batch.Apply()
+	if err == nil || !strings.Contains(err.Error(), "C(0): Out(High): oops") {
+		t.Fatal(err)
+	}
+	// Restored in the reverse order.
+	if a.fn != "I2C1_SDA" || b.fn != gpio.OUT_LOW {
+		t.Fatal(a.fn, b.fn)
+	}
+	if s := strings.Join(log, ","); s != "A:In,B:Out,C:Out,C:In,B:Out,A:SetFunc" {
+		t.Fatal(s)
+	}
+	// Nothing left to roll back.
+	if err := batch.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBatch_SetFunc_unsupported(t *testing.T) {
+	p := &gpiotest.Pin{N: "D", Fn: "In/Low"}
+	var batch Batch
+	if err := batch.SetFunc(p, "UART0_TX").Apply(); err == nil {
+		t.Fatal("pin.PinFunc not implemented")
+	}
+}
+
+func TestBatch_Debounce(t *testing.T) {
+	a := &debouncePin{funcPin: *newPin("A", gpio.IN), period: time.Millisecond}
+	var batch Batch
+	if err := batch.In(a, gpio.PullUp, gpio.NoEdge).Debounce(a, 5*time.Millisecond).Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if a.period != 5*time.Millisecond {
+		t.Fatal(a.period)
+	}
+	if err := batch.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if a.period != time.Millisecond {
+		t.Fatal(a.period)
+	}
+	b := newPin("B", gpio.IN)
+	if err := batch.Debounce(b, time.Millisecond).Apply(); err == nil || !strings.Contains(err.Error(), "B(0): Debounce(1ms)") {
+		t.Fatal(err)
+	}
+	// Restored on failure.
+	if a.period != time.Millisecond {
+		t.Fatal(a.period)
+	}
+}
+
+//
+
+// log records the calls in order across pins.
+var log []string
+
+// funcPin tracks its function.
+type funcPin struct {
+	gpiotest.Pin
+	fn   pin.Func
+	fail error
+}
+
+func newPin(name string, fn pin.Func) *funcPin {
+	return &funcPin{Pin: gpiotest.Pin{N: name}, fn: fn}
+}
+
+func (f *funcPin) Func() pin.Func {
+	return f.fn
+}
+
+func (f *funcPin) SetFunc(fn pin.Func) error {
+	log = append(log, f.N+":SetFunc")
+	f.fn = fn
+	return nil
+}
+
+func (f *funcPin) In(pull gpio.Pull, edge gpio.Edge) error {
+	log = append(log, f.N+":In")
+	f.fn = gpio.IN
+	return f.Pin.In(pull, edge)
+}
+
+func (f *funcPin) Out(l gpio.Level) error {
+	log = append(log, f.N+":Out")
+	if f.fail != nil {
+		return f.fail
+	}
+	f.fn = gpio.OUT_LOW
+	if l {
+		f.fn = gpio.OUT_HIGH
+	}
+	return f.Pin.Out(l)
+}
+
+// debouncePin is a funcPin debounced by the kernel.
+type debouncePin struct {
+	funcPin
+	period time.Duration
+}
+
+func (d *debouncePin) SetDebounce(period time.Duration) error {
+	d.period = period
+	return nil
+}
+
+func (d *debouncePin) Debounce() time.Duration {
+	return d.period
+}
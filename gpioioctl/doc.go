@@ -0,0 +1,19 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package gpioioctl implements GPIO access through the Linux GPIO character
+// device, /dev/gpiochipN, using the version 2 of the userland API.
+//
+// Unlike GPIO sysfs, the character device exposes the pins as lines relative
+// to their controller, so the numbering is stable across kernel versions. It
+// also supports the internal pull resistors, open drain outputs and edge
+// detection without the sysfs round trips.
+//
+// The named lines are registered in gpioreg under their kernel line name
+// prefixed with their chip name, e.g. "gpiochip0.GPIO17", so they never
+// shadow the names registered by the SoC and board drivers. All the lines are
+// accessible via Chips.
+//
+// https://www.kernel.org/doc/html/latest/userspace-api/gpio/chardev.html
+package gpioioctl
@@ -0,0 +1,677 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package gpioioctl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/pin"
+)
+
+// Chips is all the GPIO chips found on the host.
+//
+// This global variable is initialized once at driver initialization and isn't
+// mutated afterward. Do not modify it.
+var Chips []*GPIOChip
+
+// ChipByLabel returns the first GPIO chip with the specified label, or nil.
+//
+// The label is the name of the kernel driver instance, e.g. "pinctrl-bcm2711"
+// or "INT33FF:01", and is more stable than the chip name.
+func ChipByLabel(label string) *GPIOChip {
+	for _, c := range Chips {
+		if c.label == label {
+			return c
+		}
+	}
+	return nil
+}
+
+// OpenChip opens the GPIO chip at path, e.g. "/dev/gpiochip5".
+//
+// It is meant for the chips created after the driver initialization, like a
+// sysfs.GPIOAggregator. The chip is not added to Chips and its lines are not
+// registered in gpioreg. Close it once done.
+func OpenChip(path string) (*GPIOChip, error) {
+	return newChip(path)
+}
+
+// GPIOChip is a GPIO controller as exposed by /dev/gpiochipN.
+type GPIOChip struct {
+	name  string
+	label string
+	path  string
+	f     lineFile
+	lines []*GPIOLine
+}
+
+// String implements conn.Resource.
+func (c *GPIOChip) String() string {
+	return c.name
+}
+
+// Name returns the name of the chip, e.g. "gpiochip0".
+func (c *GPIOChip) Name() string {
+	return c.name
+}
+
+// Label returns the label of the chip as set by the kernel driver.
+func (c *GPIOChip) Label() string {
+	return c.label
+}
+
+// Path returns the path of the character device.
+func (c *GPIOChip) Path() string {
+	return c.path
+}
+
+// LineCount returns the number of lines on the chip.
+func (c *GPIOChip) LineCount() int {
+	return len(c.lines)
+}
+
+// Lines returns all the lines of the chip, indexed by offset.
+func (c *GPIOChip) Lines() []*GPIOLine {
+	return c.lines
+}
+
+// ByName returns the line with the specified name, or nil.
+func (c *GPIOChip) ByName(name string) *GPIOLine {
+	for _, l := range c.lines {
+		if l.name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// ByNumber returns the line at the specified offset, or nil.
+func (c *GPIOChip) ByNumber(offset int) *GPIOLine {
+	if offset < 0 || offset >= len(c.lines) {
+		return nil
+	}
+	return c.lines[offset]
+}
+
+// Close releases all the requested lines and the chip handle.
+func (c *GPIOChip) Close() error {
+	var err error
+	for _, l := range c.lines {
+		if err1 := l.Close(); err == nil {
+			err = err1
+		}
+	}
+	if err1 := c.f.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
+
+// GPIOLine is a GPIO line as exposed by the GPIO character device.
+//
+// The line is requested from the kernel on first use and stays requested
+// until Close is called.
+type GPIOLine struct {
+	chip     *GPIOChip
+	offset   uint32
+	name     string
+	consumer string
+	flags    lineFlag // flags as reported by the kernel at initialization
+
+	mu     sync.Mutex
+	f      lineFile      // requested line; nil until first use
+	config lineFlag      // last flags applied
+	pull   gpio.Pull     // last pull applied
+	edge   gpio.Edge     // last edge applied
+	period time.Duration // debounce period applied to inputs
+	event  event         // initialized once edge detection is requested
+	evInit bool
+	buf    [unsafe.Sizeof(lineEvent{})]byte
+}
+
+// String implements conn.Resource.
+func (l *GPIOLine) String() string {
+	return l.Name()
+}
+
+// Halt implements conn.Resource.
+//
+// It stops edge detection if enabled.
+func (l *GPIOLine) Halt() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.edge == gpio.NoEdge {
+		return nil
+	}
+	return l.in(gpio.PullNoChange, gpio.NoEdge)
+}
+
+// Name implements pin.Pin.
+//
+// It is the chip name followed by the line name, e.g. "gpiochip0.GPIO17", or
+// by the offset if the line is unnamed, e.g. "gpiochip0(3)". The chip name
+// keeps the line from shadowing the pins registered by the SoC and board
+// drivers.
+func (l *GPIOLine) Name() string {
+	if l.name != "" {
+		return l.chip.name + "." + l.name
+	}
+	return fmt.Sprintf("%s(%d)", l.chip.name, l.offset)
+}
+
+// LineName returns the line name as set in the device tree or ACPI tables,
+// or "" if the line is unnamed.
+func (l *GPIOLine) LineName() string {
+	return l.name
+}
+
+// Number implements pin.Pin.
+//
+// It returns the offset of the line on its chip.
+func (l *GPIOLine) Number() int {
+	return int(l.offset)
+}
+
+// Function implements pin.Pin.
+func (l *GPIOLine) Function() string {
+	return string(l.Func())
+}
+
+// Chip returns the chip the line belongs to.
+func (l *GPIOLine) Chip() *GPIOChip {
+	return l.chip
+}
+
+// Consumer returns the consumer of the line at initialization, if any.
+//
+// A line with a consumer is in use by a kernel driver or another process.
+func (l *GPIOLine) Consumer() string {
+	return l.consumer
+}
+
+// Func implements pin.PinFunc.
+func (l *GPIOLine) Func() pin.Func {
+	l.mu.Lock()
+	flags := l.config
+	requested := l.f != nil
+	l.mu.Unlock()
+	if !requested {
+		flags = l.flags
+	}
+	switch {
+	case flags&flagOutput != 0:
+		if !requested {
+			return gpio.OUT
+		}
+		if l.Read() {
+			return gpio.OUT_HIGH
+		}
+		return gpio.OUT_LOW
+	case flags&flagInput != 0:
+		if !requested {
+			return gpio.IN
+		}
+		if l.Read() {
+			return gpio.IN_HIGH
+		}
+		return gpio.IN_LOW
+	}
+	return pin.FuncNone
+}
+
+// SupportedFuncs implements pin.PinFunc.
+func (l *GPIOLine) SupportedFuncs() []pin.Func {
+	return []pin.Func{gpio.IN, gpio.OUT}
+}
+
+// SetFunc implements pin.PinFunc.
+func (l *GPIOLine) SetFunc(f pin.Func) error {
+	switch f {
+	case gpio.IN:
+		return l.In(gpio.PullNoChange, gpio.NoEdge)
+	case gpio.OUT_HIGH:
+		return l.Out(gpio.High)
+	case gpio.OUT, gpio.OUT_LOW:
+		return l.Out(gpio.Low)
+	default:
+		return l.wrap(errors.New("unsupported function"))
+	}
+}
+
+// In implements gpio.PinIn.
+func (l *GPIOLine) In(pull gpio.Pull, edge gpio.Edge) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.in(pull, edge)
+}
+
+// Read implements gpio.PinIn.
+//
+// If the line was not configured yet, it is requested as-is.
+func (l *GPIOLine) Read() gpio.Level {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		if err := l.apply(&lineConfig{}); err != nil {
+			return gpio.Low
+		}
+		// Requesting as-is keeps the current direction.
+		l.config = l.flags & (flagInput | flagOutput | flagActiveLow)
+	}
+	v := lineValues{mask: 1}
+	if err := l.f.Ioctl(ioctlLineGetValues, uintptr(unsafe.Pointer(&v))); err != nil {
+		return gpio.Low
+	}
+	return gpio.Level(v.bits&1 != 0)
+}
+
+// WaitForEdge implements gpio.PinIn.
+func (l *GPIOLine) WaitForEdge(timeout time.Duration) bool {
+	// Run lockless, as the normal use is to call in a busy loop.
+	if !l.evInit {
+		return false
+	}
+	var ms int
+	if timeout == -1 {
+		ms = -1
+	} else {
+		ms = int(timeout / time.Millisecond)
+	}
+	start := time.Now()
+	for {
+		nr, err := l.event.wait(ms)
+		if err != nil && err != syscall.EINTR {
+			return false
+		}
+		if nr == 1 {
+			// Consume the event so the file descriptor isn't readable anymore.
+			_, err := l.f.Read(l.buf[:])
+			return err == nil
+		}
+		// A signal occurred.
+		if timeout != -1 {
+			ms = int((timeout - time.Since(start)) / time.Millisecond)
+		}
+		if ms <= 0 {
+			return false
+		}
+	}
+}
+
+// SetDebounce sets the debounce period the kernel applies to the line when
+// used as an input. 0 disables debouncing.
+//
+// It takes effect immediately if the line is an input, otherwise on the next
+// call to In.
+func (l *GPIOLine) SetDebounce(period time.Duration) error {
+	if period < 0 || period/time.Microsecond > math.MaxUint32 {
+		return l.wrap(fmt.Errorf("invalid debounce period %s", period))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.period = period
+	if l.f != nil && l.config&flagInput != 0 {
+		return l.in(gpio.PullNoChange, l.edge)
+	}
+	return nil
+}
+
+// Debounce returns the debounce period applied to the line when used as an
+// input, as set by SetDebounce or reported by the kernel at initialization.
+func (l *GPIOLine) Debounce() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.period
+}
+
+// Pull implements gpio.PinIn.
+func (l *GPIOLine) Pull() gpio.Pull {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pull
+}
+
+// DefaultPull implements gpio.PinIn.
+//
+// It returns gpio.PullNoChange since the character device doesn't expose the
+// default bias of the line.
+func (l *GPIOLine) DefaultPull() gpio.Pull {
+	return gpio.PullNoChange
+}
+
+// Out implements gpio.PinOut.
+func (l *GPIOLine) Out(level gpio.Level) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f != nil && l.config&flagOutput != 0 {
+		v := lineValues{mask: 1}
+		if level {
+			v.bits = 1
+		}
+		if err := l.f.Ioctl(ioctlLineSetValues, uintptr(unsafe.Pointer(&v))); err != nil {
+			return l.wrap(err)
+		}
+		return nil
+	}
+	if err := l.haltEdge(); err != nil {
+		return err
+	}
+	cfg := outConfig(l.config, level)
+	if err := l.apply(&cfg); err != nil {
+		return l.wrap(err)
+	}
+	return nil
+}
+
+// PWM implements gpio.PinOut.
+//
+// This is not supported on the GPIO character device.
+func (l *GPIOLine) PWM(gpio.Duty, physic.Frequency) error {
+	return l.wrap(errors.New("pwm is not supported via the gpio character device"))
+}
+
+// Close releases the line back to the kernel.
+//
+// The lines in use are also closed by pinuse.Cleanup.
+func (l *GPIOLine) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.event.close()
+	l.evInit = false
+	if err1 := l.f.Close(); err == nil {
+		err = err1
+	}
+	l.f = nil
+	l.config = 0
+	l.edge = gpio.NoEdge
+	return err
+}
+
+//
+
+// in configures the line as input.
+//
+// lock must be held.
+func (l *GPIOLine) in(pull gpio.Pull, edge gpio.Edge) error {
+	cfg := inConfig(l.config, pull, edge, l.period)
+	if err := l.apply(&cfg); err != nil {
+		return l.wrap(err)
+	}
+	if pull != gpio.PullNoChange {
+		l.pull = pull
+	}
+	l.edge = edge
+	if edge != gpio.NoEdge {
+		if !l.evInit {
+			if err := l.event.makeEvent(l.f.Fd()); err != nil {
+				return l.wrap(err)
+			}
+			l.evInit = true
+		}
+		// Flush the events accumulated before the reconfiguration.
+		for l.WaitForEdge(0) {
+		}
+	}
+	return nil
+}
+
+// haltEdge stops any on-going edge detection.
+//
+// lock must be held.
+func (l *GPIOLine) haltEdge() error {
+	if l.edge != gpio.NoEdge {
+		return l.in(gpio.PullNoChange, gpio.NoEdge)
+	}
+	return nil
+}
+
+// apply requests the line with the configuration, or reconfigures it if it
+// was already requested.
+//
+// lock must be held.
+func (l *GPIOLine) apply(cfg *lineConfig) error {
+	if l.f != nil {
+		if err := l.f.Ioctl(ioctlLineSetConfig, uintptr(unsafe.Pointer(cfg))); err != nil {
+			return err
+		}
+		l.config = cfg.flags
+		return nil
+	}
+	req := lineRequest{numLines: 1, config: *cfg}
+	req.offsets[0] = l.offset
+	copy(req.consumer[:maxNameSize-1], consumer)
+	if err := l.chip.f.Ioctl(ioctlGetLine, uintptr(unsafe.Pointer(&req))); err != nil {
+		if err == syscall.EBUSY {
+			owner := l.consumer
+			if owner == "" {
+				owner = "another process"
+			}
+			return &pinuse.BusyError{Pin: l.Name(), Owner: owner}
+		}
+		return err
+	}
+	l.f = newLineFile(req.fd, l.Name())
+	l.config = cfg.flags
+	pinuse.AtExit(l, l.Close)
+	return nil
+}
+
+func (l *GPIOLine) wrap(err error) error {
+	if _, ok := err.(*pinuse.BusyError); ok {
+		// Keep it typed; it already names the line.
+		return err
+	}
+	return fmt.Errorf("ioctl-gpio (%s): %v", l, err)
+}
+
+// inFlags returns the flags to configure a line as input.
+//
+// When pull is gpio.PullNoChange, the previous bias is kept.
+func inFlags(prev lineFlag, pull gpio.Pull, edge gpio.Edge) lineFlag {
+	const bias = flagBiasPullUp | flagBiasPullDown | flagBiasDisabled
+	flags := flagInput | prev&(flagActiveLow|bias)
+	switch pull {
+	case gpio.Float:
+		flags = flags&^bias | flagBiasDisabled
+	case gpio.PullDown:
+		flags = flags&^bias | flagBiasPullDown
+	case gpio.PullUp:
+		flags = flags&^bias | flagBiasPullUp
+	}
+	switch edge {
+	case gpio.RisingEdge:
+		flags |= flagEdgeRising
+	case gpio.FallingEdge:
+		flags |= flagEdgeFalling
+	case gpio.BothEdges:
+		flags |= flagEdgeRising | flagEdgeFalling
+	}
+	return flags
+}
+
+// inConfig returns the configuration to set a line as input, with the
+// debounce period if not 0.
+func inConfig(prev lineFlag, pull gpio.Pull, edge gpio.Edge, period time.Duration) lineConfig {
+	cfg := lineConfig{flags: inFlags(prev, pull, edge)}
+	if period != 0 {
+		cfg.numAttrs = 1
+		cfg.attrs[0].attr.id = attrIDDebounce
+		cfg.attrs[0].attr.value = uint64(period / time.Microsecond)
+		cfg.attrs[0].mask = 1
+	}
+	return cfg
+}
+
+// outConfig returns the configuration to set a line as output at the
+// specified level.
+func outConfig(prev lineFlag, level gpio.Level) lineConfig {
+	cfg := lineConfig{
+		flags:    flagOutput | prev&(flagActiveLow|flagOpenDrain|flagOpenSource),
+		numAttrs: 1,
+	}
+	cfg.attrs[0].attr.id = attrIDOutputValues
+	if level {
+		cfg.attrs[0].attr.value = 1
+	}
+	cfg.attrs[0].mask = 1
+	return cfg
+}
+
+// consumer is the name reported to the kernel for requested lines.
+const consumer = "periph"
+
+// lineFile is a handle to a chip or to a requested line.
+type lineFile interface {
+	fs.Ioctler
+	io.ReadCloser
+	Fd() uintptr
+}
+
+var (
+	openChip = func(path string) (lineFile, error) {
+		return fs.Open(path, os.O_RDWR)
+	}
+	newLineFile = func(fd int32, name string) lineFile {
+		return &fs.File{File: os.NewFile(uintptr(fd), name)}
+	}
+)
+
+func newChip(path string) (*GPIOChip, error) {
+	f, err := openChip(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("need more access, try as root or setup udev rules: %v", err)
+		}
+		return nil, err
+	}
+	var info chipInfo
+	if err := f.Ioctl(ioctlGetChipInfo, uintptr(unsafe.Pointer(&info))); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	c := &GPIOChip{
+		name:  cString(info.name[:]),
+		label: cString(info.label[:]),
+		path:  path,
+		f:     f,
+		lines: make([]*GPIOLine, info.lines),
+	}
+	for i := range c.lines {
+		li := lineInfo{offset: uint32(i)}
+		if err := f.Ioctl(ioctlGetLineInfo, uintptr(unsafe.Pointer(&li))); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("%s: line %d: %v", path, i, err)
+		}
+		c.lines[i] = &GPIOLine{
+			chip:     c,
+			offset:   uint32(i),
+			name:     cString(li.name[:]),
+			consumer: cString(li.consumer[:]),
+			flags:    li.flags,
+			pull:     gpio.PullNoChange,
+			period:   debouncePeriod(&li),
+		}
+	}
+	return c, nil
+}
+
+// debouncePeriod returns the debounce period reported in the line info, 0 if
+// none.
+func debouncePeriod(li *lineInfo) time.Duration {
+	for i := uint32(0); i < li.numAttrs && i < lineNumAttrs; i++ {
+		if li.attrs[i].id == attrIDDebounce {
+			return time.Duration(li.attrs[i].value) * time.Microsecond
+		}
+	}
+	return 0
+}
+
+// driverGPIO implements periph.Driver.
+type driverGPIO struct {
+}
+
+func (d *driverGPIO) String() string {
+	return "ioctl-gpio"
+}
+
+func (d *driverGPIO) Prerequisites() []string {
+	return nil
+}
+
+// After returns sysfs-gpio so the GPIOnnn names registered by sysfs are kept
+// as-is.
+func (d *driverGPIO) After() []string {
+	return []string{"sysfs-gpio"}
+}
+
+// Init enumerates the GPIO chips and registers the named lines.
+func (d *driverGPIO) Init() (bool, error) {
+	items, err := filepath.Glob("/dev/gpiochip*")
+	if err != nil {
+		return true, err
+	}
+	if len(items) == 0 {
+		return false, errors.New("no GPIO chip found")
+	}
+	for _, item := range items {
+		c, err := newChip(item)
+		if err != nil {
+			return true, err
+		}
+		Chips = append(Chips, c)
+		if err := registerLines(c); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// registerLines registers the named lines of c under their chip qualified
+// name.
+func registerLines(c *GPIOChip) error {
+	for _, l := range c.lines {
+		// Line names are not guaranteed to be unique within a chip.
+		if l.name == "" || gpioreg.ByName(l.Name()) != nil {
+			continue
+		}
+		if err := gpioreg.Register(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	if isLinux {
+		hostcfg.MustRegister(&drvGPIO)
+	}
+}
+
+var drvGPIO driverGPIO
+
+var _ conn.Resource = &GPIOLine{}
+var _ gpio.PinIn = &GPIOLine{}
+var _ gpio.PinOut = &GPIOLine{}
+var _ gpio.PinIO = &GPIOLine{}
+var _ pin.PinFunc = &GPIOLine{}
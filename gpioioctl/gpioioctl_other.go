@@ -0,0 +1,26 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package gpioioctl
+
+import "errors"
+
+const isLinux = false
+
+type event struct{}
+
+func (e *event) makeEvent(fd uintptr) error {
+	return errors.New("unreachable code")
+}
+
+func (e *event) wait(timeoutms int) (int, error) {
+	return 0, errors.New("unreachable code")
+}
+
+func (e *event) close() error {
+	return nil
+}
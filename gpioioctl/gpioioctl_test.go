@@ -0,0 +1,190 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package gpioioctl
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+)
+
+func TestStructSizes(t *testing.T) {
+	// Sizes from include/uapi/linux/gpio.h; the kernel rejects ioctls with a
+	// mismatched size.
+	data := []struct {
+		name string
+		got  uintptr
+		want uintptr
+	}{
+		{"chipInfo", unsafe.Sizeof(chipInfo{}), 68},
+		{"lineAttribute", unsafe.Sizeof(lineAttribute{}), 16},
+		{"lineConfigAttribute", unsafe.Sizeof(lineConfigAttribute{}), 24},
+		{"lineConfig", unsafe.Sizeof(lineConfig{}), 272},
+		{"lineRequest", unsafe.Sizeof(lineRequest{}), 592},
+		{"lineInfo", unsafe.Sizeof(lineInfo{}), 256},
+		{"lineValues", unsafe.Sizeof(lineValues{}), 16},
+		{"lineEvent", unsafe.Sizeof(lineEvent{}), 48},
+	}
+	for _, line := range data {
+		if line.got != line.want {
+			t.Errorf("%s: got %d, want %d", line.name, line.got, line.want)
+		}
+	}
+}
+
+func TestIoctlNumbers(t *testing.T) {
+	if isLinux && unsafe.Sizeof(uintptr(0)) == 8 {
+		// Values as computed by the C preprocessor on x86-64 and arm64.
+		data := []struct {
+			name string
+			got  uint
+			want uint
+		}{
+			{"GPIO_GET_CHIPINFO_IOCTL", ioctlGetChipInfo, 0x8044B401},
+			{"GPIO_V2_GET_LINEINFO_IOCTL", ioctlGetLineInfo, 0xC100B405},
+			{"GPIO_V2_GET_LINE_IOCTL", ioctlGetLine, 0xC250B407},
+			{"GPIO_V2_LINE_SET_CONFIG_IOCTL", ioctlLineSetConfig, 0xC110B40D},
+			{"GPIO_V2_LINE_GET_VALUES_IOCTL", ioctlLineGetValues, 0xC010B40E},
+			{"GPIO_V2_LINE_SET_VALUES_IOCTL", ioctlLineSetValues, 0xC010B40F},
+		}
+		for _, line := range data {
+			if line.got != line.want {
+				t.Errorf("%s: got %#x, want %#x", line.name, line.got, line.want)
+			}
+		}
+	}
+}
+
+func TestInFlags(t *testing.T) {
+	data := []struct {
+		prev lineFlag
+		pull gpio.Pull
+		edge gpio.Edge
+		want lineFlag
+	}{
+		{0, gpio.PullNoChange, gpio.NoEdge, flagInput},
+		{0, gpio.PullUp, gpio.NoEdge, flagInput | flagBiasPullUp},
+		{0, gpio.PullDown, gpio.RisingEdge, flagInput | flagBiasPullDown | flagEdgeRising},
+		{0, gpio.Float, gpio.FallingEdge, flagInput | flagBiasDisabled | flagEdgeFalling},
+		{0, gpio.PullNoChange, gpio.BothEdges, flagInput | flagEdgeRising | flagEdgeFalling},
+		// The bias is kept, the edges and the direction are not.
+		{flagOutput | flagBiasPullUp | flagEdgeRising, gpio.PullNoChange, gpio.NoEdge, flagInput | flagBiasPullUp},
+		{flagBiasPullUp | flagActiveLow, gpio.PullDown, gpio.NoEdge, flagInput | flagBiasPullDown | flagActiveLow},
+	}
+	for i, line := range data {
+		if got := inFlags(line.prev, line.pull, line.edge); got != line.want {
+			t.Errorf("#%d: got %#x, want %#x", i, got, line.want)
+		}
+	}
+}
+
+func TestOutConfig(t *testing.T) {
+	cfg := outConfig(flagInput|flagBiasPullUp|flagOpenDrain, gpio.High)
+	if cfg.flags != flagOutput|flagOpenDrain {
+		t.Fatalf("unexpected flags %#x", cfg.flags)
+	}
+	if cfg.numAttrs != 1 || cfg.attrs[0].attr.id != attrIDOutputValues || cfg.attrs[0].attr.value != 1 || cfg.attrs[0].mask != 1 {
+		t.Fatalf("unexpected attributes %#v", cfg.attrs[0])
+	}
+	if cfg = outConfig(0, gpio.Low); cfg.attrs[0].attr.value != 0 {
+		t.Fatalf("unexpected value %d", cfg.attrs[0].attr.value)
+	}
+}
+
+func TestCString(t *testing.T) {
+	if s := cString([]byte{'a', 'b', 0, 'c'}); s != "ab" {
+		t.Fatal(s)
+	}
+	if s := cString([]byte{'a', 'b'}); s != "ab" {
+		t.Fatal(s)
+	}
+}
+
+func TestGPIOLine_Name(t *testing.T) {
+	c := &GPIOChip{name: "gpiochip2"}
+	l := &GPIOLine{chip: c, offset: 3}
+	if s := l.Name(); s != "gpiochip2(3)" {
+		t.Fatal(s)
+	}
+	l.name = "BUTTON"
+	if s := l.String(); s != "gpiochip2.BUTTON" {
+		t.Fatal(s)
+	}
+	if s := l.LineName(); s != "BUTTON" {
+		t.Fatal(s)
+	}
+	if n := l.Number(); n != 3 {
+		t.Fatal(n)
+	}
+}
+
+func TestRegisterLines(t *testing.T) {
+	// A SoC driver registered GPIO17 first; the line of the same name must not
+	// shadow it.
+	soc := &gpiotest.Pin{N: "GPIO17", Num: 17}
+	if err := gpioreg.Register(soc); err != nil {
+		t.Fatal(err)
+	}
+	defer gpioreg.Unregister("GPIO17")
+	c := &GPIOChip{name: "gpiochip9"}
+	c.lines = []*GPIOLine{{chip: c, offset: 0, name: "GPIO17"}, {chip: c, offset: 1}, {chip: c, offset: 2, name: "GPIO17"}}
+	if err := registerLines(c); err != nil {
+		t.Fatal(err)
+	}
+	defer gpioreg.Unregister("gpiochip9.GPIO17")
+	if p := gpioreg.ByName("GPIO17"); p != soc {
+		t.Fatal(p)
+	}
+	if p := gpioreg.ByName("gpiochip9.GPIO17"); p != c.lines[0] {
+		t.Fatal(p)
+	}
+	if p := gpioreg.ByName("gpiochip9(1)"); p != nil {
+		t.Fatal("unnamed lines are not registered", p)
+	}
+}
+
+func TestInConfig(t *testing.T) {
+	cfg := inConfig(0, gpio.PullUp, gpio.BothEdges, 0)
+	if cfg.flags != flagInput|flagBiasPullUp|flagEdgeRising|flagEdgeFalling || cfg.numAttrs != 0 {
+		t.Fatalf("unexpected config %#v", cfg)
+	}
+	cfg = inConfig(0, gpio.PullNoChange, gpio.NoEdge, 5*time.Millisecond)
+	if cfg.numAttrs != 1 || cfg.attrs[0].attr.id != attrIDDebounce || cfg.attrs[0].attr.value != 5000 || cfg.attrs[0].mask != 1 {
+		t.Fatalf("unexpected attributes %#v", cfg.attrs[0])
+	}
+}
+
+func TestGPIOLine_SetDebounce(t *testing.T) {
+	l := &GPIOLine{chip: &GPIOChip{name: "gpiochip0"}}
+	if err := l.SetDebounce(-1); err == nil {
+		t.Fatal("negative period")
+	}
+	// The line isn't requested yet so it is applied on the next In.
+	if err := l.SetDebounce(time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if d := l.Debounce(); d != time.Millisecond {
+		t.Fatal(d)
+	}
+}
+
+func TestDebouncePeriod(t *testing.T) {
+	li := lineInfo{numAttrs: 2}
+	li.attrs[0].id = attrIDFlags
+	li.attrs[1].id = attrIDDebounce
+	li.attrs[1].value = 1500
+	if d := debouncePeriod(&li); d != 1500*time.Microsecond {
+		t.Fatal(d)
+	}
+	// Attributes past numAttrs are ignored.
+	li.numAttrs = 1
+	if d := debouncePeriod(&li); d != 0 {
+		t.Fatal(d)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package gpioioctl
+
+import (
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+)
+
+// Structures and constants from include/uapi/linux/gpio.h.
+//
+// Only the version 2 of the API is used. It was added in Linux 5.10.
+
+const (
+	maxNameSize  = 32
+	linesMax     = 64
+	lineNumAttrs = 10
+)
+
+// lineFlag is enum gpio_v2_line_flag.
+type lineFlag uint64
+
+const (
+	flagUsed               lineFlag = 1 << 0
+	flagActiveLow          lineFlag = 1 << 1
+	flagInput              lineFlag = 1 << 2
+	flagOutput             lineFlag = 1 << 3
+	flagEdgeRising         lineFlag = 1 << 4
+	flagEdgeFalling        lineFlag = 1 << 5
+	flagOpenDrain          lineFlag = 1 << 6
+	flagOpenSource         lineFlag = 1 << 7
+	flagBiasPullUp         lineFlag = 1 << 8
+	flagBiasPullDown       lineFlag = 1 << 9
+	flagBiasDisabled       lineFlag = 1 << 10
+	flagEventClockRealtime lineFlag = 1 << 11
+)
+
+// Attribute IDs, enum gpio_v2_line_attr_id.
+const (
+	attrIDFlags          = 1
+	attrIDOutputValues   = 2
+	attrIDDebounce       = 3
+	lineEventRisingEdge  = 1
+	lineEventFallingEdge = 2
+)
+
+// chipInfo is struct gpiochip_info.
+type chipInfo struct {
+	name  [maxNameSize]byte
+	label [maxNameSize]byte
+	lines uint32
+}
+
+// lineAttribute is struct gpio_v2_line_attribute.
+//
+// value is a union of flags, values and debounce_period_us.
+type lineAttribute struct {
+	id      uint32
+	padding uint32
+	value   uint64
+}
+
+// lineConfigAttribute is struct gpio_v2_line_config_attribute.
+type lineConfigAttribute struct {
+	attr lineAttribute
+	mask uint64
+}
+
+// lineConfig is struct gpio_v2_line_config.
+type lineConfig struct {
+	flags    lineFlag
+	numAttrs uint32
+	padding  [5]uint32
+	attrs    [lineNumAttrs]lineConfigAttribute
+}
+
+// lineRequest is struct gpio_v2_line_request.
+type lineRequest struct {
+	offsets         [linesMax]uint32
+	consumer        [maxNameSize]byte
+	config          lineConfig
+	numLines        uint32
+	eventBufferSize uint32
+	padding         [5]uint32
+	fd              int32
+}
+
+// lineInfo is struct gpio_v2_line_info.
+type lineInfo struct {
+	name     [maxNameSize]byte
+	consumer [maxNameSize]byte
+	offset   uint32
+	numAttrs uint32
+	flags    lineFlag
+	attrs    [lineNumAttrs]lineAttribute
+	padding  [4]uint32
+}
+
+// lineValues is struct gpio_v2_line_values.
+type lineValues struct {
+	bits uint64
+	mask uint64
+}
+
+// lineEvent is struct gpio_v2_line_event.
+type lineEvent struct {
+	timestampNs uint64
+	id          uint32
+	offset      uint32
+	seqno       uint32
+	lineSeqno   uint32
+	padding     [6]uint32
+}
+
+var (
+	ioctlGetChipInfo   = fs.IOR(0xB4, 0x01, uint(unsafe.Sizeof(chipInfo{})))
+	ioctlGetLineInfo   = fs.IOWR(0xB4, 0x05, uint(unsafe.Sizeof(lineInfo{})))
+	ioctlGetLine       = fs.IOWR(0xB4, 0x07, uint(unsafe.Sizeof(lineRequest{})))
+	ioctlLineSetConfig = fs.IOWR(0xB4, 0x0D, uint(unsafe.Sizeof(lineConfig{})))
+	ioctlLineGetValues = fs.IOWR(0xB4, 0x0E, uint(unsafe.Sizeof(lineValues{})))
+	ioctlLineSetValues = fs.IOWR(0xB4, 0x0F, uint(unsafe.Sizeof(lineValues{})))
+)
+
+// cString converts a NUL terminated fixed size buffer into a string.
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,333 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/physic"
+)
+
+// CP2112 represents a Silicon Labs CP2112 device.
+//
+// It exposes an I²C bus and the 8 GPIOs GPIO0 to GPIO7. The GPIOs are
+// configured as push-pull when used as outputs.
+type CP2112 struct {
+	name string
+	info Info
+	h    hidDev
+
+	mu      sync.Mutex
+	i2cOpen bool
+	hdr     [8]gpio.PinIO
+}
+
+// newCP2112 initializes the device and sets the I²C bus to 100kHz.
+func newCP2112(h hidDev, name string, info Info) (*CP2112, error) {
+	c := &CP2112{name: name, info: info, h: h}
+	for i := range c.hdr {
+		c.hdr[i] = &hidPin{bank: c, name: name + ".GPIO" + strconv.Itoa(i), num: i}
+	}
+	if err := c.i2cSetSpeed(100 * physic.KiloHertz); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *CP2112) String() string {
+	return c.name
+}
+
+// Halt implements conn.Resource.
+func (c *CP2112) Halt() error {
+	return nil
+}
+
+// Info implements Dev.
+func (c *CP2112) Info(i *Info) {
+	*i = c.info
+}
+
+// Header implements Dev.
+func (c *CP2112) Header() []gpio.PinIO {
+	out := make([]gpio.PinIO, len(c.hdr))
+	copy(out, c.hdr[:])
+	return out
+}
+
+// I2C implements Dev.
+func (c *CP2112) I2C() (i2c.BusCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.i2cOpen {
+		return nil, c.wrap(errors.New("I²C bus already open"))
+	}
+	c.i2cOpen = true
+	return &i2cBus{name: c.name, b: c}, nil
+}
+
+//
+
+// Reports of the CP2112, as documented in AN495.
+const (
+	// Feature reports.
+	cpGPIOConfig = 0x02 // Direction, push-pull and special functions
+	cpGPIOGet    = 0x03 // Latch values
+	cpGPIOSet    = 0x04 // Latch values and mask
+	cpSMBusCfg   = 0x06 // SMBus configuration
+
+	// Interrupt reports.
+	cpReadReq       = 0x10 // Data read request
+	cpWriteReadReq  = 0x11 // Data write read request
+	cpReadForceSend = 0x12 // Data read force send
+	cpReadResponse  = 0x13 // Data read response
+	cpWrite         = 0x14 // Data write
+	cpStatusReq     = 0x15 // Transfer status request
+	cpStatusResp    = 0x16 // Transfer status response
+
+	cpMaxWrite     = 61  // Bytes of a data write
+	cpMaxWriteRead = 16  // Bytes written by a data write read request
+	cpMaxRead      = 512 // Bytes of a read request
+	cpRetries      = 100
+
+	// Transfer status.
+	cpStatusBusy     = 1
+	cpStatusComplete = 2
+	cpStatusError    = 3
+)
+
+// Special functions bits of the GPIO configuration; each steals a pin.
+const (
+	cpSpecialClock = 1 << 0 // GPIO7
+	cpSpecialTxLED = 1 << 1 // GPIO0
+	cpSpecialRxLED = 1 << 2 // GPIO1
+)
+
+// cpErrors are the reasons for a transfer status error.
+var cpErrors = []string{
+	"I²C address NACK",
+	"I²C bus not free",
+	"I²C arbitration lost",
+	"I²C read incomplete",
+	"I²C write incomplete",
+}
+
+// send sends an interrupt report.
+func (c *CP2112) send(b ...byte) error {
+	var out [64]byte
+	copy(out[:], b)
+	return c.h.write(out[:])
+}
+
+// receive returns the next input report with the ID id.
+func (c *CP2112) receive(id byte) ([]byte, error) {
+	in := make([]byte, 64)
+	for i := 0; i < cpRetries; i++ {
+		n, err := c.h.read(in, time.Second)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 && in[0] == id {
+			return in[:n], nil
+		}
+	}
+	return nil, fmt.Errorf("no response to report 0x%02X", id)
+}
+
+func (c *CP2112) i2cSetSpeed(f physic.Frequency) error {
+	if f < 10*physic.KiloHertz || f > 400*physic.KiloHertz {
+		return c.wrap(errors.New("invalid I²C speed; must be between 10kHz and 400kHz"))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cfg := make([]byte, 14)
+	cfg[0] = cpSMBusCfg
+	if err := c.h.getFeature(cfg); err != nil {
+		return c.wrap(err)
+	}
+	hz := uint32(f / physic.Hertz)
+	cfg[1] = byte(hz >> 24)
+	cfg[2] = byte(hz >> 16)
+	cfg[3] = byte(hz >> 8)
+	cfg[4] = byte(hz)
+	// Disable auto send read, so the data is only returned on request.
+	cfg[6] = 0
+	if err := c.h.setFeature(cfg); err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CP2112) i2cTx(addr uint16, w, r []byte) error {
+	if len(r) > cpMaxRead {
+		return c.wrap(fmt.Errorf("I²C read is limited to %d bytes", cpMaxRead))
+	}
+	a := byte(addr << 1)
+	var req []byte
+	switch {
+	case len(r) == 0:
+		if len(w) == 0 || len(w) > cpMaxWrite {
+			return c.wrap(fmt.Errorf("I²C write must be between 1 and %d bytes", cpMaxWrite))
+		}
+		req = append([]byte{cpWrite, a, byte(len(w))}, w...)
+	case len(w) == 0:
+		req = []byte{cpReadReq, a, byte(len(r) >> 8), byte(len(r))}
+	default:
+		if len(w) > cpMaxWriteRead {
+			return c.wrap(fmt.Errorf("I²C write before a read is limited to %d bytes", cpMaxWriteRead))
+		}
+		req = append([]byte{cpWriteReadReq, a, byte(len(r) >> 8), byte(len(r)), byte(len(w))}, w...)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.send(req...); err != nil {
+		return c.wrap(err)
+	}
+	if err := c.waitTransfer(); err != nil {
+		return c.wrap(err)
+	}
+	for off := 0; off < len(r); {
+		n := len(r) - off
+		if n > cpMaxWrite {
+			n = cpMaxWrite
+		}
+		if err := c.send(cpReadForceSend, byte(n>>8), byte(n)); err != nil {
+			return c.wrap(err)
+		}
+		resp, err := c.receive(cpReadResponse)
+		if err != nil {
+			return c.wrap(err)
+		}
+		if resp[1] == cpStatusError {
+			return c.wrap(errors.New("I²C read failed"))
+		}
+		got := int(resp[2])
+		if got == 0 || got > len(resp)-3 {
+			return c.wrap(errors.New("I²C read incomplete"))
+		}
+		off += copy(r[off:], resp[3:3+got])
+	}
+	return nil
+}
+
+func (c *CP2112) i2cClose() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.i2cOpen = false
+}
+
+// waitTransfer polls the transfer status until it completes.
+func (c *CP2112) waitTransfer() error {
+	for i := 0; i < cpRetries; i++ {
+		if err := c.send(cpStatusReq, 1); err != nil {
+			return err
+		}
+		resp, err := c.receive(cpStatusResp)
+		if err != nil {
+			return err
+		}
+		switch resp[1] {
+		case cpStatusComplete:
+			return nil
+		case cpStatusError:
+			if int(resp[2]) < len(cpErrors) {
+				return errors.New(cpErrors[resp[2]])
+			}
+			return fmt.Errorf("I²C transfer error %d", resp[2])
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return errors.New("timed out waiting for the I²C transfer")
+}
+
+// setGPIO changes the direction and the output value of the pin n.
+func (c *CP2112) setGPIO(n int, out bool, l gpio.Level) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cfg := make([]byte, 5)
+	cfg[0] = cpGPIOConfig
+	if err := c.h.getFeature(cfg); err != nil {
+		return c.wrap(err)
+	}
+	mask := byte(1) << uint(n)
+	if out {
+		// Set the latch first to not glitch the output.
+		v := byte(0)
+		if l {
+			v = mask
+		}
+		if err := c.h.setFeature([]byte{cpGPIOSet, v, mask}); err != nil {
+			return c.wrap(err)
+		}
+		cfg[1] |= mask
+		cfg[2] |= mask
+	} else {
+		cfg[1] &^= mask
+	}
+	switch n {
+	case 0:
+		cfg[3] &^= cpSpecialTxLED
+	case 1:
+		cfg[3] &^= cpSpecialRxLED
+	case 7:
+		cfg[3] &^= cpSpecialClock
+	}
+	if err := c.h.setFeature(cfg); err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CP2112) gpioIn(n int) error {
+	return c.setGPIO(n, false, gpio.Low)
+}
+
+func (c *CP2112) gpioOut(n int, l gpio.Level) error {
+	return c.setGPIO(n, true, l)
+}
+
+func (c *CP2112) gpioRead(n int) (gpio.Level, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b := []byte{cpGPIOGet, 0}
+	if err := c.h.getFeature(b); err != nil {
+		return gpio.Low, c.wrap(err)
+	}
+	return b[1]&(1<<uint(n)) != 0, nil
+}
+
+func (c *CP2112) gpioFunction(n int) string {
+	c.mu.Lock()
+	cfg := make([]byte, 5)
+	cfg[0] = cpGPIOConfig
+	err := c.h.getFeature(cfg)
+	c.mu.Unlock()
+	if err != nil {
+		return "N/A"
+	}
+	if (n == 0 && cfg[3]&cpSpecialTxLED != 0) || (n == 1 && cfg[3]&cpSpecialRxLED != 0) || (n == 7 && cfg[3]&cpSpecialClock != 0) {
+		return "ALT"
+	}
+	l, err := c.gpioRead(n)
+	if err != nil {
+		return "N/A"
+	}
+	if cfg[1]&(1<<uint(n)) != 0 {
+		return "Out/" + l.String()
+	}
+	return "In/" + l.String()
+}
+
+func (c *CP2112) wrap(err error) error {
+	return fmt.Errorf("cp2112: %v", err)
+}
+
+var _ Dev = &CP2112{}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+func TestCP2112_I2C(t *testing.T) {
+	c, f := newFakeCP2112(t)
+	if got, want := f.feature[cpSMBusCfg][1:5], []byte{0x00, 0x01, 0x86, 0xA0}; !bytes.Equal(got, want) {
+		t.Fatalf("speed = %x, want %x", got, want)
+	}
+	f.ops = []hidOp{
+		{w: []byte{cpWriteReadReq, 0x48 << 1, 0, 2, 1, 0x10}},
+		{w: []byte{cpStatusReq, 1}, r: []byte{cpStatusResp, cpStatusBusy, 2}},
+		{w: []byte{cpStatusReq, 1}, r: []byte{cpStatusResp, cpStatusComplete, 5}},
+		{w: []byte{cpReadForceSend, 0, 2}, r: []byte{cpReadResponse, cpStatusComplete, 2, 0xAB, 0xCD}},
+	}
+	b, err := c.I2C()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	r := make([]byte, 2)
+	if err := b.Tx(0x48, []byte{0x10}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{0xAB, 0xCD}) {
+		t.Fatalf("Tx() read %x", r)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The address is not acknowledged.
+	f.ops = []hidOp{
+		{w: []byte{cpWrite, 0x49 << 1, 2, 0x10, 0x20}},
+		{w: []byte{cpStatusReq, 1}, r: []byte{cpStatusResp, cpStatusError, 0}},
+	}
+	if err := b.Tx(0x49, []byte{0x10, 0x20}, nil); err == nil || err.Error() != "cp2112: I²C address NACK" {
+		t.Fatalf("Tx() = %v", err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Tx(0x48, make([]byte, 17), r); err == nil {
+		t.Fatal("write read is limited to 16 bytes")
+	}
+}
+
+func TestCP2112_GPIO(t *testing.T) {
+	c, f := newFakeCP2112(t)
+	f.feature[cpGPIOConfig] = []byte{cpGPIOConfig, 0, 0, cpSpecialClock | cpSpecialTxLED | cpSpecialRxLED, 0}
+	f.feature[cpGPIOGet] = []byte{cpGPIOGet, 0x01}
+	p := c.Header()[0]
+	if s := p.Name(); s != "CP2112.GPIO0" {
+		t.Fatal(s)
+	}
+	if s := p.Function(); s != "ALT" {
+		t.Fatal(s)
+	}
+	if err := p.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.feature[cpGPIOSet], []byte{cpGPIOSet, 0x01, 0x01}; !bytes.Equal(got, want) {
+		t.Fatalf("latch = %x, want %x", got, want)
+	}
+	if got, want := f.feature[cpGPIOConfig], []byte{cpGPIOConfig, 0x01, 0x01, cpSpecialClock | cpSpecialRxLED, 0}; !bytes.Equal(got, want) {
+		t.Fatalf("config = %x, want %x", got, want)
+	}
+	if s := p.Function(); s != "Out/High" {
+		t.Fatal(s)
+	}
+	if err := p.In(gpio.PullNoChange, gpio.NoEdge); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.feature[cpGPIOConfig], []byte{cpGPIOConfig, 0, 0x01, cpSpecialClock | cpSpecialRxLED, 0}; !bytes.Equal(got, want) {
+		t.Fatalf("config = %x, want %x", got, want)
+	}
+	if l := c.Header()[1].Read(); l != gpio.Low {
+		t.Fatal(l)
+	}
+}
+
+//
+
+func newFakeCP2112(t *testing.T) (*CP2112, *fakeHID) {
+	f := &fakeHID{feature: map[byte][]byte{cpSMBusCfg: make([]byte, 14)}}
+	c, err := newCP2112(f, "CP2112", Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, f
+}
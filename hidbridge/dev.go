@@ -0,0 +1,188 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import (
+	"errors"
+	"time"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/physic"
+)
+
+// Info is the information gathered about the connected bridge.
+type Info struct {
+	// Type is the bridge type, "MCP2221A" or "CP2112".
+	Type string
+	// VenID is the vendor ID from the USB descriptor information.
+	VenID uint16
+	// DevID is the product ID from the USB descriptor information.
+	DevID uint16
+	// Serial is the serial number from the USB descriptor information. It may
+	// be empty.
+	Serial string
+	// Path is the hidraw device node, e.g. "/dev/hidraw0".
+	Path string
+}
+
+// Dev represents one USB HID bridge.
+//
+// There can be multiple bridges connected to a host.
+type Dev interface {
+	// conn.Resource
+	String() string
+	Halt() error
+
+	// Info returns information about the device.
+	Info(i *Info)
+
+	// Header returns the GPIO pins exposed on the chip.
+	Header() []gpio.PinIO
+
+	// I2C returns the I²C bus of the device.
+	//
+	// Only one handle to the bus can be opened at a time.
+	I2C() (i2c.BusCloser, error)
+}
+
+//
+
+// i2cBridge is implemented by the devices exposing an I²C bus.
+type i2cBridge interface {
+	i2cTx(addr uint16, w, r []byte) error
+	i2cSetSpeed(f physic.Frequency) error
+	i2cClose()
+}
+
+// i2cBus is the I²C bus of a bridge.
+//
+// i2cBus implements i2c.BusCloser.
+type i2cBus struct {
+	name string
+	b    i2cBridge
+}
+
+// Close implements i2c.BusCloser.
+func (i *i2cBus) Close() error {
+	i.b.i2cClose()
+	return nil
+}
+
+// Duplex implements conn.Conn.
+func (i *i2cBus) Duplex() conn.Duplex {
+	return conn.Half
+}
+
+// String implements i2c.Bus.
+func (i *i2cBus) String() string {
+	return i.name
+}
+
+// SetSpeed implements i2c.Bus.
+func (i *i2cBus) SetSpeed(f physic.Frequency) error {
+	return i.b.i2cSetSpeed(f)
+}
+
+// Tx implements i2c.Bus.
+func (i *i2cBus) Tx(addr uint16, w, r []byte) error {
+	if addr >= 0x80 {
+		return errors.New("hidbridge: 10 bits I²C addresses are not supported")
+	}
+	return i.b.i2cTx(addr, w, r)
+}
+
+// gpioBank is implemented by the devices exposing GPIOs.
+type gpioBank interface {
+	gpioIn(n int) error
+	gpioOut(n int, l gpio.Level) error
+	gpioRead(n int) (gpio.Level, error)
+	gpioFunction(n int) string
+}
+
+// hidPin is a GPIO of a bridge.
+//
+// hidPin implements gpio.PinIO.
+type hidPin struct {
+	bank gpioBank
+	name string
+	num  int
+}
+
+// String implements pin.Pin.
+func (p *hidPin) String() string {
+	return p.name
+}
+
+// Name implements pin.Pin.
+func (p *hidPin) Name() string {
+	return p.name
+}
+
+// Number implements pin.Pin.
+func (p *hidPin) Number() int {
+	return p.num
+}
+
+// Function implements pin.Pin.
+func (p *hidPin) Function() string {
+	return p.bank.gpioFunction(p.num)
+}
+
+// Halt implements gpio.PinIO.
+func (p *hidPin) Halt() error {
+	return nil
+}
+
+// In implements gpio.PinIn.
+//
+// The bridges have no configurable pull resistor and no edge detection.
+func (p *hidPin) In(pull gpio.Pull, edge gpio.Edge) error {
+	if pull != gpio.Float && pull != gpio.PullNoChange {
+		return errors.New("hidbridge: pull resistors are not supported")
+	}
+	if edge != gpio.NoEdge {
+		return errors.New("hidbridge: edge detection is not supported")
+	}
+	return p.bank.gpioIn(p.num)
+}
+
+// Read implements gpio.PinIn.
+func (p *hidPin) Read() gpio.Level {
+	l, err := p.bank.gpioRead(p.num)
+	if err != nil {
+		return gpio.Low
+	}
+	return l
+}
+
+// WaitForEdge implements gpio.PinIn.
+func (p *hidPin) WaitForEdge(t time.Duration) bool {
+	return false
+}
+
+// Pull implements gpio.PinIn.
+func (p *hidPin) Pull() gpio.Pull {
+	return gpio.Float
+}
+
+// DefaultPull implements gpio.PinIn.
+func (p *hidPin) DefaultPull() gpio.Pull {
+	return gpio.Float
+}
+
+// Out implements gpio.PinOut.
+func (p *hidPin) Out(l gpio.Level) error {
+	return p.bank.gpioOut(p.num, l)
+}
+
+// PWM implements gpio.PinOut.
+func (p *hidPin) PWM(d gpio.Duty, f physic.Frequency) error {
+	return errors.New("hidbridge: PWM is not supported")
+}
+
+var _ gpio.PinIO = &hidPin{}
+var _ i2c.BusCloser = &i2cBus{}
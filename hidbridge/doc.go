@@ -0,0 +1,30 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package hidbridge implements support for USB HID to I²C and GPIO bridges.
+//
+// The supported devices are the Microchip MCP2221 and MCP2221A, exposing an
+// I²C bus and 4 GPIOs, and the Silicon Labs CP2112, exposing an I²C bus and 8
+// GPIOs. They are driven through the Linux hidraw interface, so no kernel
+// driver or vendor library is needed.
+//
+// The driver is not loaded by host.Init(). Import this package to enumerate
+// the connected devices and register their GPIOs in gpioreg, their header in
+// pinreg and their I²C bus in i2creg, using the device name, e.g. "MCP2221A"
+// or "CP2112". When more than one device is connected, the name is suffixed
+// with its index, e.g. "CP2112(1)".
+//
+// The user needs read and write access to the /dev/hidrawN device nodes,
+// usually granted with an udev rule. When the kernel hid-mcp2221 or hid-cp2112
+// driver is loaded it also drives the bridge; do not use both at the same
+// time.
+//
+// Datasheets
+//
+// https://www.microchip.com/en-us/product/MCP2221A
+//
+// https://www.silabs.com/documents/public/data-sheets/cp2112-datasheet.pdf
+//
+// https://www.silabs.com/documents/public/application-notes/an495-cp2112-interface-specification.pdf
+package hidbridge
@@ -0,0 +1,173 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/s-mobi01/host/hostcfg"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+)
+
+// All enumerates all the connected bridges.
+func All() []Dev {
+	drv.mu.Lock()
+	defer drv.mu.Unlock()
+	out := make([]Dev, len(drv.all))
+	copy(out, drv.all)
+	return out
+}
+
+//
+
+// model describes a supported bridge.
+type model struct {
+	name  string
+	venID uint16
+	devID uint16
+	open  func(h hidDev, name string, info Info) (Dev, error)
+}
+
+var models = []model{
+	{
+		name:  "MCP2221A",
+		venID: 0x04D8,
+		devID: 0x00DD,
+		open:  func(h hidDev, name string, info Info) (Dev, error) { return newMCP2221(h, name, info) },
+	},
+	{
+		name:  "CP2112",
+		venID: 0x10C4,
+		devID: 0xEA90,
+		open:  func(h hidDev, name string, info Info) (Dev, error) { return newCP2112(h, name, info) },
+	},
+}
+
+// registerDev registers the header and the I²C bus in the relevant
+// registries.
+func registerDev(d Dev, multi bool) error {
+	name := d.String()
+	hdr := d.Header()
+
+	// Register the GPIOs.
+	for _, p := range hdr {
+		if err := gpioreg.Register(p); err != nil {
+			return err
+		}
+	}
+	if !multi {
+		// Register shorthands.
+		prefix := len(name) + 1
+		for _, p := range hdr {
+			n := p.Name()
+			if err := gpioreg.RegisterAlias(n[prefix:], n); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Register the header.
+	raw := make([][]pin.Pin, len(hdr))
+	for i := range hdr {
+		raw[i] = []pin.Pin{hdr[i]}
+	}
+	if err := pinreg.Register(name, raw); err != nil {
+		return err
+	}
+	return i2creg.Register(name, nil, -1, func() (i2c.BusCloser, error) { return d.I2C() })
+}
+
+// driver implements driver.Impl.
+type driver struct {
+	mu        sync.Mutex
+	all       []Dev
+	enumerate func() ([]hidInfo, error)
+	open      func(path string) (hidDev, error)
+}
+
+func (d *driver) String() string {
+	return "hidbridge"
+}
+
+func (d *driver) Prerequisites() []string {
+	return nil
+}
+
+func (d *driver) After() []string {
+	return nil
+}
+
+func (d *driver) Init() (bool, error) {
+	infos, err := d.enumerate()
+	if err != nil {
+		return true, err
+	}
+	// Keep only the supported devices.
+	type found struct {
+		m *model
+		i hidInfo
+	}
+	var devs []found
+	for _, i := range infos {
+		for j := range models {
+			if models[j].venID == i.venID && models[j].devID == i.devID {
+				devs = append(devs, found{&models[j], i})
+				break
+			}
+		}
+	}
+	multi := len(devs) > 1
+	count := map[string]int{}
+	for _, f := range devs {
+		name := f.m.name
+		if n := count[f.m.name]; n > 0 {
+			// When more than one device of a type is present, add "(index)"
+			// suffix.
+			name += "(" + strconv.Itoa(n) + ")"
+		}
+		count[f.m.name]++
+		h, err1 := d.open(f.i.path)
+		if err1 != nil {
+			err = fmt.Errorf("hidbridge: %s: %v", f.i.path, err1)
+			continue
+		}
+		info := Info{Type: f.m.name, VenID: f.i.venID, DevID: f.i.devID, Serial: f.i.serial, Path: f.i.path}
+		dev, err1 := f.m.open(h, name, info)
+		if err1 != nil {
+			_ = h.Close()
+			err = fmt.Errorf("hidbridge: %s: %v", f.i.path, err1)
+			continue
+		}
+		d.all = append(d.all, dev)
+		if err1 = registerDev(dev, multi); err1 != nil {
+			return true, err1
+		}
+	}
+	return true, err
+}
+
+func (d *driver) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.all = nil
+	// enumerate and open are mocked in tests.
+	d.enumerate = func() ([]hidInfo, error) { return enumerate("/sys/class/hidraw") }
+	d.open = openHidraw
+}
+
+func init() {
+	if isLinux {
+		drv.reset()
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
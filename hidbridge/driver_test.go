@@ -0,0 +1,183 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c/i2creg"
+)
+
+func TestDriver(t *testing.T) {
+	defer reset(t)
+	drv.enumerate = func() ([]hidInfo, error) {
+		return []hidInfo{
+			{path: "/dev/hidraw0", venID: 0x046D, devID: 0xC52B},
+			{path: "/dev/hidraw1", venID: 0x04D8, devID: 0x00DD, serial: "0001"},
+			{path: "/dev/hidraw2", venID: 0x10C4, devID: 0xEA90},
+		}, nil
+	}
+	drv.open = func(path string) (hidDev, error) {
+		switch path {
+		case "/dev/hidraw1":
+			return &fakeHID{ops: []hidOp{{w: []byte{0, mcpStatus, 0, 0, mcpSetSpeed, 117}, r: mcpResp(mcpStatus)}}}, nil
+		case "/dev/hidraw2":
+			return &fakeHID{feature: map[byte][]byte{cpSMBusCfg: make([]byte, 14)}}, nil
+		default:
+			return nil, fmt.Errorf("unexpected path %q", path)
+		}
+	}
+	if b, err := drv.Init(); !b || err != nil {
+		t.Fatalf("Init() = %t, %v", b, err)
+	}
+	all := All()
+	if len(all) != 2 {
+		t.Fatalf("All() = %v", all)
+	}
+	var i Info
+	all[0].Info(&i)
+	if want := (Info{Type: "MCP2221A", VenID: 0x04D8, DevID: 0x00DD, Serial: "0001", Path: "/dev/hidraw1"}); i != want {
+		t.Fatalf("Info() = %#v, want %#v", i, want)
+	}
+	if p := gpioreg.ByName("MCP2221A.GP3"); p == nil {
+		t.Fatal("MCP2221A.GP3 not registered")
+	}
+	if p := gpioreg.ByName("CP2112.GPIO7"); p == nil {
+		t.Fatal("CP2112.GPIO7 not registered")
+	}
+	b, err := i2creg.Open("CP2112")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := all[1].I2C(); err == nil {
+		t.Fatal("the I²C bus can only be opened once")
+	}
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	root, err := ioutil.TempDir("", "hidbridge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	uevents := map[string]string{
+		"hidraw10": "DRIVER=hid-generic\nHID_ID=0003:000010C4:0000EA90\nHID_NAME=CP2112 HID USB-to-SMBus Bridge\nHID_UNIQ=00A1B2C3\n",
+		"hidraw3":  "DRIVER=hid-generic\nHID_ID=0003:000004D8:000000DD\nHID_UNIQ=\n",
+		"hidraw4":  "DRIVER=hid-generic\nHID_ID=invalid\n",
+		"hidraw5":  "",
+	}
+	for name, content := range uevents {
+		d := filepath.Join(root, name, "device")
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if content != "" {
+			if err := ioutil.WriteFile(filepath.Join(d, "uevent"), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	got, err := enumerate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []hidInfo{
+		{path: "/dev/hidraw3", venID: 0x04D8, devID: 0x00DD},
+		{path: "/dev/hidraw10", venID: 0x10C4, devID: 0xEA90, serial: "00A1B2C3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("enumerate() = %#v, want %#v", got, want)
+	}
+	if got, err := enumerate(filepath.Join(root, "missing")); got != nil || err != nil {
+		t.Fatalf("enumerate() = %v, %v", got, err)
+	}
+}
+
+//
+
+// hidOp is an expected output report and the input report it triggers.
+type hidOp struct {
+	w []byte // Expected prefix of the output report
+	r []byte // Input report queued in response, if any
+}
+
+// fakeHID is a scripted hidDev.
+type fakeHID struct {
+	ops     []hidOp
+	queue   [][]byte
+	feature map[byte][]byte
+}
+
+func (f *fakeHID) Close() error {
+	return nil
+}
+
+func (f *fakeHID) write(b []byte) error {
+	if len(f.ops) == 0 {
+		return fmt.Errorf("unexpected output report %x", b)
+	}
+	op := f.ops[0]
+	f.ops = f.ops[1:]
+	if !bytes.HasPrefix(b, op.w) {
+		return fmt.Errorf("output report %x, want %x", b[:len(op.w)], op.w)
+	}
+	if op.r != nil {
+		f.queue = append(f.queue, op.r)
+	}
+	return nil
+}
+
+func (f *fakeHID) read(b []byte, timeout time.Duration) (int, error) {
+	if len(f.queue) == 0 {
+		return 0, errTimeout
+	}
+	n := copy(b, f.queue[0])
+	f.queue = f.queue[1:]
+	return n, nil
+}
+
+func (f *fakeHID) getFeature(b []byte) error {
+	v, ok := f.feature[b[0]]
+	if !ok {
+		return fmt.Errorf("unexpected feature report 0x%02X", b[0])
+	}
+	copy(b[1:], v[1:])
+	return nil
+}
+
+func (f *fakeHID) setFeature(b []byte) error {
+	if f.feature == nil {
+		f.feature = map[byte][]byte{}
+	}
+	f.feature[b[0]] = append([]byte(nil), b...)
+	return nil
+}
+
+// done returns an error if some expected output reports were not sent.
+func (f *fakeHID) done() error {
+	if len(f.ops) != 0 {
+		return fmt.Errorf("%d output reports not sent, next is %x", len(f.ops), f.ops[0].w)
+	}
+	return nil
+}
+
+func reset(t *testing.T) {
+	drv.reset()
+}
+
+func init() {
+	reset(nil)
+}
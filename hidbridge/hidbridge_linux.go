@@ -0,0 +1,43 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import "syscall"
+
+const isLinux = true
+
+// event waits for a hidraw file descriptor to become readable.
+type event struct {
+	event   [1]syscall.EpollEvent
+	epollFd int
+}
+
+// makeEvent creates a level triggered epoll event on fd.
+//
+// The hidraw file descriptor stays readable as long as there are queued input
+// reports, so edge triggering is not necessary.
+func (e *event) makeEvent(fd uintptr) error {
+	epollFd, err := syscall.EpollCreate1(syscall.EPOLL_CLOEXEC)
+	if err != nil {
+		return err
+	}
+	e.epollFd = epollFd
+	e.event[0].Events = syscall.EPOLLIN
+	e.event[0].Fd = int32(fd)
+	return syscall.EpollCtl(e.epollFd, syscall.EPOLL_CTL_ADD, int(fd), &e.event[0])
+}
+
+func (e *event) wait(timeoutms int) (int, error) {
+	return syscall.EpollWait(e.epollFd, e.event[:], timeoutms)
+}
+
+func (e *event) close() error {
+	if e.epollFd == 0 {
+		return nil
+	}
+	err := syscall.Close(e.epollFd)
+	e.epollFd = 0
+	return err
+}
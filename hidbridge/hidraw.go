@@ -0,0 +1,166 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+)
+
+// hidDev is an opened HID device.
+//
+// It is implemented by hidraw and mocked in tests.
+type hidDev interface {
+	io.Closer
+	// write sends an output report. b[0] is the report ID, 0 if the device
+	// doesn't use numbered reports.
+	write(b []byte) error
+	// read reads the next input report in b. b[0] is the report ID if the
+	// device uses numbered reports.
+	read(b []byte, timeout time.Duration) (int, error)
+	// getFeature reads the feature report b[0] into b.
+	getFeature(b []byte) error
+	// setFeature sends the feature report b[0].
+	setFeature(b []byte) error
+}
+
+// hidraw is a /dev/hidrawN device.
+type hidraw struct {
+	f  *fs.File
+	ev event
+}
+
+func openHidraw(path string) (hidDev, error) {
+	f, err := fs.Open(path, os.O_RDWR)
+	if err != nil {
+		return nil, err
+	}
+	h := &hidraw{f: f}
+	if err := h.ev.makeEvent(f.Fd()); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *hidraw) Close() error {
+	err := h.ev.close()
+	if err2 := h.f.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+func (h *hidraw) write(b []byte) error {
+	_, err := h.f.Write(b)
+	return err
+}
+
+func (h *hidraw) read(b []byte, timeout time.Duration) (int, error) {
+	n, err := h.ev.wait(int(timeout / time.Millisecond))
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, errTimeout
+	}
+	return h.f.Read(b)
+}
+
+func (h *hidraw) getFeature(b []byte) error {
+	return h.f.Ioctl(fs.IOWR('H', 0x07, uint(len(b))), uintptr(unsafe.Pointer(&b[0])))
+}
+
+func (h *hidraw) setFeature(b []byte) error {
+	return h.f.Ioctl(fs.IOWR('H', 0x06, uint(len(b))), uintptr(unsafe.Pointer(&b[0])))
+}
+
+var errTimeout = errors.New("timed out waiting for the device")
+
+// hidInfo describes a hidraw device found in sysfs.
+type hidInfo struct {
+	path   string // e.g. "/dev/hidraw0"
+	venID  uint16
+	devID  uint16
+	serial string
+}
+
+// enumerate returns the hidraw devices listed in root, normally
+// /sys/class/hidraw, sorted by device node name.
+func enumerate(root string) ([]hidInfo, error) {
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []hidInfo
+	for _, e := range entries {
+		raw, err := ioutil.ReadFile(filepath.Join(root, e.Name(), "device", "uevent"))
+		if err != nil {
+			continue
+		}
+		if i, ok := parseUevent(raw); ok {
+			i.path = "/dev/" + e.Name()
+			out = append(out, i)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return hidrawIndex(out[i].path) < hidrawIndex(out[j].path) })
+	return out, nil
+}
+
+// parseUevent parses the HID_ID and HID_UNIQ keys of a HID device uevent
+// file.
+//
+// HID_ID has the form "<bus>:<vendor>:<product>" in hexadecimal, e.g.
+// "0003:000004D8:000000DD".
+func parseUevent(raw []byte) (hidInfo, bool) {
+	var i hidInfo
+	found := false
+	s := bufio.NewScanner(bytes.NewReader(raw))
+	for s.Scan() {
+		l := s.Text()
+		switch {
+		case strings.HasPrefix(l, "HID_ID="):
+			parts := strings.Split(l[len("HID_ID="):], ":")
+			if len(parts) != 3 {
+				return i, false
+			}
+			v, err1 := strconv.ParseUint(parts[1], 16, 16)
+			p, err2 := strconv.ParseUint(parts[2], 16, 16)
+			if err1 != nil || err2 != nil {
+				return i, false
+			}
+			i.venID = uint16(v)
+			i.devID = uint16(p)
+			found = true
+		case strings.HasPrefix(l, "HID_UNIQ="):
+			i.serial = l[len("HID_UNIQ="):]
+		}
+	}
+	return i, found
+}
+
+// hidrawIndex returns N in "/dev/hidrawN".
+func hidrawIndex(path string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(path), "hidraw"))
+	if err != nil {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,392 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/physic"
+)
+
+// MCP2221 represents a Microchip MCP2221 or MCP2221A device.
+//
+// It exposes an I²C bus and the 4 GPIOs GP0 to GP3.
+type MCP2221 struct {
+	name string
+	info Info
+	h    hidDev
+
+	mu      sync.Mutex
+	i2cOpen bool
+	isGPIO  [4]bool
+	hdr     [4]gpio.PinIO
+}
+
+// newMCP2221 initializes the device and sets the I²C bus to 100kHz.
+func newMCP2221(h hidDev, name string, info Info) (*MCP2221, error) {
+	m := &MCP2221{name: name, info: info, h: h}
+	for i := range m.hdr {
+		m.hdr[i] = &hidPin{bank: m, name: name + ".GP" + strconv.Itoa(i), num: i}
+	}
+	if err := m.i2cSetSpeed(100 * physic.KiloHertz); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *MCP2221) String() string {
+	return m.name
+}
+
+// Halt implements conn.Resource.
+func (m *MCP2221) Halt() error {
+	return nil
+}
+
+// Info implements Dev.
+func (m *MCP2221) Info(i *Info) {
+	*i = m.info
+}
+
+// Header implements Dev.
+func (m *MCP2221) Header() []gpio.PinIO {
+	out := make([]gpio.PinIO, len(m.hdr))
+	copy(out, m.hdr[:])
+	return out
+}
+
+// I2C implements Dev.
+func (m *MCP2221) I2C() (i2c.BusCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.i2cOpen {
+		return nil, m.wrap(errors.New("I²C bus already open"))
+	}
+	m.i2cOpen = true
+	return &i2cBus{name: m.name, b: m}, nil
+}
+
+//
+
+// Commands of the MCP2221, all sent as a 64 bytes output report and answered
+// with a 64 bytes input report echoing the command code.
+const (
+	mcpStatus         = 0x10 // Status and set parameters
+	mcpI2CWrite       = 0x90 // I²C write with START and STOP
+	mcpI2CRead        = 0x91 // I²C read with START and STOP
+	mcpI2CReadRepeat  = 0x93 // I²C read with repeated START
+	mcpI2CWriteNoStop = 0x94 // I²C write without STOP
+	mcpI2CGetData     = 0x40 // Fetch the data of an I²C read
+	mcpGPIOSet        = 0x50 // Set GPIO output values and directions
+	mcpGPIOGet        = 0x51 // Get GPIO values and directions
+	mcpSRAMSet        = 0x60 // Set the runtime settings
+	mcpSRAMGet        = 0x61 // Get the runtime settings
+	mcpMaxChunk       = 60   // Data bytes per I²C report
+	mcpRetries        = 50
+	mcpNotGPIO        = 0xEE // Value reported for a pin not in GPIO mode
+	mcpCancel         = 0x10 // Status byte 2: cancel the current transfer
+	mcpSetSpeed       = 0x20 // Status byte 3: set the I²C speed
+	mcpSpeedNotSet    = 0x21 // Status response byte 3: speed change refused
+	mcpAddrNACK       = 0x40 // Status response byte 20 bit: address NACKed
+	mcpStateIdle      = 0x00
+	mcpStatePartial   = 0x41
+	mcpStateNoStop    = 0x45
+	mcpStateAddrNACK  = 0x25
+	mcpReadComplete   = 0x55
+	mcpReadError      = 0x7F
+)
+
+// xfer sends a command and returns its response.
+func (m *MCP2221) xfer(cmd ...byte) ([]byte, error) {
+	// The MCP2221 doesn't use numbered reports, so the report ID is 0.
+	var out [65]byte
+	copy(out[1:], cmd)
+	if err := m.h.write(out[:]); err != nil {
+		return nil, err
+	}
+	in := make([]byte, 64)
+	n, err := m.h.read(in, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(in) || in[0] != cmd[0] {
+		return nil, fmt.Errorf("unexpected response to command 0x%02X", cmd[0])
+	}
+	return in, nil
+}
+
+func (m *MCP2221) status() ([]byte, error) {
+	return m.xfer(mcpStatus, 0, 0, 0, 0)
+}
+
+func (m *MCP2221) cancel() error {
+	_, err := m.xfer(mcpStatus, 0, mcpCancel, 0, 0)
+	return err
+}
+
+func (m *MCP2221) i2cSetSpeed(f physic.Frequency) error {
+	if f < 47*physic.KiloHertz || f > 400*physic.KiloHertz {
+		return m.wrap(errors.New("invalid I²C speed; must be between 47kHz and 400kHz"))
+	}
+	div := byte(12*physic.MegaHertz/f - 3)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := 0; i < 2; i++ {
+		resp, err := m.xfer(mcpStatus, 0, 0, mcpSetSpeed, div)
+		if err != nil {
+			return m.wrap(err)
+		}
+		if resp[3] != mcpSpeedNotSet {
+			return nil
+		}
+		// The speed can't be changed while a transfer is in progress.
+		if err := m.cancel(); err != nil {
+			return m.wrap(err)
+		}
+	}
+	return m.wrap(errors.New("failed to set the I²C speed"))
+}
+
+func (m *MCP2221) i2cTx(addr uint16, w, r []byte) error {
+	if len(w) > 0xFFFF || len(r) > 0xFFFF {
+		return m.wrap(errors.New("I²C transfer too long"))
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var err error
+	switch {
+	case len(r) == 0:
+		err = m.i2cWrite(mcpI2CWrite, addr, w)
+	case len(w) == 0:
+		err = m.i2cRead(mcpI2CRead, addr, r)
+	default:
+		if err = m.i2cWrite(mcpI2CWriteNoStop, addr, w); err == nil {
+			err = m.i2cRead(mcpI2CReadRepeat, addr, r)
+		}
+	}
+	if err != nil {
+		// Release the bus for the next transaction.
+		_ = m.cancel()
+		return m.wrap(err)
+	}
+	return nil
+}
+
+func (m *MCP2221) i2cClose() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.i2cOpen = false
+}
+
+// i2cWrite writes w in chunks of up to 60 bytes, then waits for the transfer
+// to complete.
+func (m *MCP2221) i2cWrite(cmd byte, addr uint16, w []byte) error {
+	if resp, err := m.status(); err != nil {
+		return err
+	} else if resp[8] != mcpStateIdle {
+		if err := m.cancel(); err != nil {
+			return err
+		}
+	}
+	for off, retries := 0, 0; off < len(w) || (off == 0 && len(w) == 0); {
+		chunk := len(w) - off
+		if chunk > mcpMaxChunk {
+			chunk = mcpMaxChunk
+		}
+		req := append([]byte{cmd, byte(len(w)), byte(len(w) >> 8), byte(addr << 1)}, w[off:off+chunk]...)
+		resp, err := m.xfer(req...)
+		if err != nil {
+			return err
+		}
+		if resp[1] != 0 {
+			// The I²C engine is busy.
+			if retries++; retries >= mcpRetries {
+				return errors.New("I²C engine busy")
+			}
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		retries = 0
+		if err := m.waitState(func(s byte) bool { return s != mcpStatePartial }); err != nil {
+			return err
+		}
+		if len(w) == 0 {
+			break
+		}
+		off += chunk
+	}
+	return m.waitState(func(s byte) bool {
+		return s == mcpStateIdle || (s == mcpStateNoStop && cmd == mcpI2CWriteNoStop)
+	})
+}
+
+// i2cRead requests len(r) bytes, then fetches them in chunks of up to 60
+// bytes.
+func (m *MCP2221) i2cRead(cmd byte, addr uint16, r []byte) error {
+	resp, err := m.xfer(cmd, byte(len(r)), byte(len(r)>>8), byte(addr<<1)|1)
+	if err != nil {
+		return err
+	}
+	if resp[1] != 0 {
+		return errors.New("I²C read refused")
+	}
+	for off := 0; off < len(r); {
+		i := 0
+		for ; i < mcpRetries; i++ {
+			if resp, err = m.xfer(mcpI2CGetData); err != nil {
+				return err
+			}
+			if resp[1] == mcpStatePartial || resp[3] == mcpReadError {
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			if resp[1] != 0 {
+				return errors.New("I²C read failed")
+			}
+			if resp[2] == mcpStateAddrNACK {
+				return errors.New("I²C address NACK")
+			}
+			break
+		}
+		if i == mcpRetries {
+			return errors.New("timed out reading I²C data")
+		}
+		n := int(resp[3])
+		if n > mcpMaxChunk {
+			n = mcpMaxChunk
+		}
+		if n == 0 {
+			return errors.New("I²C read incomplete")
+		}
+		off += copy(r[off:], resp[4:4+n])
+	}
+	return nil
+}
+
+// waitState polls the I²C engine state until done returns true.
+func (m *MCP2221) waitState(done func(s byte) bool) error {
+	for i := 0; i < mcpRetries; i++ {
+		resp, err := m.status()
+		if err != nil {
+			return err
+		}
+		if resp[20]&mcpAddrNACK != 0 {
+			return errors.New("I²C address NACK")
+		}
+		if done(resp[8]) {
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return errors.New("timed out waiting for the I²C transfer")
+}
+
+// setGPIOMode switches the pin n to GPIO mode if it is assigned to an
+// alternate function.
+//
+// Must be called with mu held.
+func (m *MCP2221) setGPIOMode(n int) error {
+	if m.isGPIO[n] {
+		return nil
+	}
+	resp, err := m.xfer(mcpSRAMGet)
+	if err != nil {
+		return err
+	}
+	if resp[22+n]&7 != 0 {
+		req := []byte{mcpSRAMSet, 0, 0, 0, 0, 0, 0, 0x80, resp[22], resp[23], resp[24], resp[25]}
+		req[8+n] &^= 7
+		if _, err := m.xfer(req...); err != nil {
+			return err
+		}
+	}
+	m.isGPIO[n] = true
+	return nil
+}
+
+// setGPIO changes the direction and the output value of the pin n.
+func (m *MCP2221) setGPIO(n int, out bool, l gpio.Level) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.setGPIOMode(n); err != nil {
+		return m.wrap(err)
+	}
+	req := make([]byte, 18)
+	req[0] = mcpGPIOSet
+	o := 2 + 4*n
+	if out {
+		req[o] = 1
+		if l {
+			req[o+1] = 1
+		}
+	} else {
+		req[o+3] = 1
+	}
+	req[o+2] = 1
+	resp, err := m.xfer(req...)
+	if err != nil {
+		return m.wrap(err)
+	}
+	if resp[1] != 0 {
+		return m.wrap(errors.New("failed to set GPIO"))
+	}
+	return nil
+}
+
+func (m *MCP2221) gpioIn(n int) error {
+	return m.setGPIO(n, false, gpio.Low)
+}
+
+func (m *MCP2221) gpioOut(n int, l gpio.Level) error {
+	return m.setGPIO(n, true, l)
+}
+
+// gpioGet returns the value and the direction of the pin n.
+func (m *MCP2221) gpioGet(n int) (byte, byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	resp, err := m.xfer(mcpGPIOGet)
+	if err != nil {
+		return 0, 0, m.wrap(err)
+	}
+	return resp[2+2*n], resp[3+2*n], nil
+}
+
+func (m *MCP2221) gpioRead(n int) (gpio.Level, error) {
+	v, _, err := m.gpioGet(n)
+	if err != nil {
+		return gpio.Low, err
+	}
+	if v == mcpNotGPIO {
+		return gpio.Low, m.wrap(errors.New("pin is not in GPIO mode"))
+	}
+	return v != 0, nil
+}
+
+func (m *MCP2221) gpioFunction(n int) string {
+	v, dir, err := m.gpioGet(n)
+	switch {
+	case err != nil:
+		return "N/A"
+	case v == mcpNotGPIO:
+		return "ALT"
+	case dir != 0:
+		return "In/" + gpio.Level(v != 0).String()
+	default:
+		return "Out/" + gpio.Level(v != 0).String()
+	}
+}
+
+func (m *MCP2221) wrap(err error) error {
+	return fmt.Errorf("mcp2221: %v", err)
+}
+
+var _ Dev = &MCP2221{}
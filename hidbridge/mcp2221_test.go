@@ -0,0 +1,123 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hidbridge
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestMCP2221_I2C(t *testing.T) {
+	m, f := newFakeMCP2221(t)
+	f.ops = []hidOp{
+		// Write the register address without STOP.
+		{w: []byte{0, mcpStatus, 0, 0, 0, 0}, r: mcpStatusResp(mcpStateIdle, 0)},
+		{w: []byte{0, mcpI2CWriteNoStop, 1, 0, 0x48 << 1, 0x10}, r: mcpResp(mcpI2CWriteNoStop)},
+		{w: []byte{0, mcpStatus, 0, 0, 0, 0}, r: mcpStatusResp(mcpStateNoStop, 0)},
+		{w: []byte{0, mcpStatus, 0, 0, 0, 0}, r: mcpStatusResp(mcpStateNoStop, 0)},
+		// Read 2 bytes with a repeated START.
+		{w: []byte{0, mcpI2CReadRepeat, 2, 0, 0x48<<1 | 1}, r: mcpResp(mcpI2CReadRepeat)},
+		{w: []byte{0, mcpI2CGetData}, r: mcpResp(mcpI2CGetData, 0, mcpReadComplete, 2, 0xAB, 0xCD)},
+	}
+	b, err := m.I2C()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	r := make([]byte, 2)
+	if err := b.Tx(0x48, []byte{0x10}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{0xAB, 0xCD}) {
+		t.Fatalf("Tx() read %x", r)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The address is not acknowledged.
+	f.ops = []hidOp{
+		{w: []byte{0, mcpStatus, 0, 0, 0, 0}, r: mcpStatusResp(mcpStateIdle, 0)},
+		{w: []byte{0, mcpI2CWrite, 1, 0, 0x49 << 1, 0x10}, r: mcpResp(mcpI2CWrite)},
+		{w: []byte{0, mcpStatus, 0, 0, 0, 0}, r: mcpStatusResp(mcpStateAddrNACK, mcpAddrNACK)},
+		{w: []byte{0, mcpStatus, 0, mcpCancel}, r: mcpResp(mcpStatus)},
+	}
+	if err := b.Tx(0x49, []byte{0x10}, nil); err == nil {
+		t.Fatal("expected NACK")
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.SetSpeed(physic.MegaHertz); err == nil {
+		t.Fatal("1MHz is not supported")
+	}
+	if err := b.Tx(0x100, nil, r); err == nil {
+		t.Fatal("10 bits addresses are not supported")
+	}
+}
+
+func TestMCP2221_GPIO(t *testing.T) {
+	m, f := newFakeMCP2221(t)
+	sram := mcpResp(mcpSRAMGet)
+	sram[23] = 0x01 // GP1 is the clock output.
+	f.ops = []hidOp{
+		{w: []byte{0, mcpSRAMGet}, r: sram},
+		{w: []byte{0, mcpSRAMSet, 0, 0, 0, 0, 0, 0, 0x80, 0, 0, 0, 0}, r: mcpResp(mcpSRAMSet)},
+		{w: []byte{0, mcpGPIOSet, 0, 0, 0, 0, 0, 1, 1, 1, 0, 0}, r: mcpResp(mcpGPIOSet)},
+		{w: []byte{0, mcpGPIOGet}, r: mcpResp(mcpGPIOGet, 0, mcpNotGPIO, mcpNotGPIO, 1, 0)},
+		// GP1 is now in GPIO mode, the SRAM settings are not read again.
+		{w: []byte{0, mcpGPIOSet, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0}, r: mcpResp(mcpGPIOSet)},
+	}
+	p := m.Header()[1]
+	if s := p.Name(); s != "MCP2221A.GP1" {
+		t.Fatal(s)
+	}
+	if err := p.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if l := p.Read(); l != gpio.High {
+		t.Fatal(l)
+	}
+	if err := p.In(gpio.Float, gpio.NoEdge); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.In(gpio.PullUp, gpio.NoEdge); err == nil {
+		t.Fatal("pull resistors are not supported")
+	}
+}
+
+//
+
+func newFakeMCP2221(t *testing.T) (*MCP2221, *fakeHID) {
+	f := &fakeHID{ops: []hidOp{{w: []byte{0, mcpStatus, 0, 0, mcpSetSpeed, 117}, r: mcpResp(mcpStatus)}}}
+	m, err := newMCP2221(f, "MCP2221A", Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, f
+}
+
+// mcpResp returns a 64 bytes input report starting with b.
+func mcpResp(b ...byte) []byte {
+	out := make([]byte, 64)
+	copy(out, b)
+	return out
+}
+
+// mcpStatusResp returns a status response with the I²C engine state s and the
+// NACK flags nack.
+func mcpStatusResp(s, nack byte) []byte {
+	out := mcpResp(mcpStatus)
+	out[8] = s
+	out[20] = nack
+	return out
+}
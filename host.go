@@ -5,8 +5,9 @@
 package host
 
 import (
+	_ "github.com/s-mobi01/host/ftdi"
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3/driver/driverreg"
-	_ "periph.io/x/host/v3/ftdi"
 )
 
 // Init calls driverreg.Init() and returns it as-is.
@@ -14,6 +15,12 @@ import (
 // The only difference is that by calling host.Init(), you are guaranteed to
 // have all the host drivers implemented in this library to be implicitly
 // loaded.
+//
+// The drivers can be selected without code changes with the environment; see
+// package hostcfg. An invalid configuration is returned as an error.
 func Init() (*driverreg.State, error) {
+	if _, err := hostcfg.Get(); err != nil {
+		return nil, err
+	}
 	return driverreg.Init()
 }
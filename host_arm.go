@@ -6,16 +6,25 @@ package host
 
 import (
 	// Make sure CPU and board drivers are registered.
-	_ "periph.io/x/host/v3/allwinner"
-	_ "periph.io/x/host/v3/am335x"
-	_ "periph.io/x/host/v3/bcm283x"
-	_ "periph.io/x/host/v3/beagle/bone"
-	_ "periph.io/x/host/v3/beagle/green"
-	_ "periph.io/x/host/v3/chip"
-	_ "periph.io/x/host/v3/odroidc1"
+	_ "github.com/s-mobi01/host/allwinner"
+	_ "github.com/s-mobi01/host/am335x"
+	_ "github.com/s-mobi01/host/am62x"
+	_ "github.com/s-mobi01/host/bcm283x"
+	_ "github.com/s-mobi01/host/beagle/ai64"
+	_ "github.com/s-mobi01/host/beagle/bone"
+	_ "github.com/s-mobi01/host/beagle/green"
+	_ "github.com/s-mobi01/host/beagle/play"
+	_ "github.com/s-mobi01/host/beagle/pocket"
+	_ "github.com/s-mobi01/host/chip"
+	_ "github.com/s-mobi01/host/khadas"
+	_ "github.com/s-mobi01/host/microchip"
+	_ "github.com/s-mobi01/host/nanopi"
+	_ "github.com/s-mobi01/host/odroid"
+	_ "github.com/s-mobi01/host/odroidc1"
+	_ "github.com/s-mobi01/host/orangepi"
 
 	// While this board is ARM64, it may run ARM 32 bits binaries so load it on
 	// 32 bits builds too.
-	_ "periph.io/x/host/v3/pine64"
-	_ "periph.io/x/host/v3/rpi"
+	_ "github.com/s-mobi01/host/pine64"
+	_ "github.com/s-mobi01/host/rpi"
 )
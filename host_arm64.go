@@ -6,8 +6,15 @@ package host
 
 import (
 	// Make sure CPU and board drivers are registered.
-	_ "periph.io/x/host/v3/allwinner"
-	_ "periph.io/x/host/v3/bcm283x"
-	_ "periph.io/x/host/v3/pine64"
-	_ "periph.io/x/host/v3/rpi"
+	_ "github.com/s-mobi01/host/allwinner"
+	_ "github.com/s-mobi01/host/am62x"
+	_ "github.com/s-mobi01/host/bcm283x"
+	_ "github.com/s-mobi01/host/beagle/ai64"
+	_ "github.com/s-mobi01/host/beagle/play"
+	_ "github.com/s-mobi01/host/khadas"
+	_ "github.com/s-mobi01/host/nanopi"
+	_ "github.com/s-mobi01/host/odroid"
+	_ "github.com/s-mobi01/host/orangepi"
+	_ "github.com/s-mobi01/host/pine64"
+	_ "github.com/s-mobi01/host/rpi"
 )
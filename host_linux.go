@@ -5,6 +5,7 @@
 package host
 
 import (
-	// Make sure sysfs drivers are registered.
-	_ "periph.io/x/host/v3/sysfs"
+	// Make sure sysfs and GPIO character device drivers are registered.
+	_ "github.com/s-mobi01/host/gpioioctl"
+	_ "github.com/s-mobi01/host/sysfs"
 )
@@ -5,6 +5,7 @@
 package host
 
 import (
+	"os"
 	"testing"
 )
 
@@ -13,3 +14,36 @@ func TestInit(t *testing.T) {
 		t.Fatalf("failed to initialize periph: %v", err)
 	}
 }
+
+func TestInit_Skip(t *testing.T) {
+	s, err := Init()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ftdi checked the configuration in its Init(), sysfs-i2c never did.
+	for _, name := range []string{"ftdi", "sysfs-i2c"} {
+		found := false
+		for _, f := range s.Skipped {
+			if f.D.String() == name {
+				if e := f.Err.Error(); e != "disabled by host configuration" {
+					t.Fatal(name, e)
+				}
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("%s is not skipped: %v", name, s)
+		}
+	}
+	for _, d := range s.Loaded {
+		if d.String() == "sysfs-i2c" {
+			t.Fatal("sysfs-i2c is loaded")
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	// The configuration is loaded once, before the first Init().
+	os.Setenv("PERIPH_HOST_SKIP", "ftdi, sysfs-i2c")
+	os.Exit(m.Run())
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package hostcfg loads the host driver preferences from the environment, so
+// the same binary can run across different hosts without code changes.
+//
+// The configuration file is named by PERIPH_HOST_CONFIG. It contains one
+// "key = value" setting per line; empty lines and lines starting with '#' are
+// ignored:
+//
+//	# Drivers not to load, by name.
+//	skip = bcm283x-dma, ftdi
+//	# GPIO driver to use: "chardev" (ioctl-gpio) or "sysfs" (sysfs-gpio).
+//	# Both are loaded when unset.
+//	gpio = chardev
+//	# USB IDs the ftdi driver may open. All are allowed when unset.
+//	ftdi = 0403:6014, 0403:6010
+//	# Backend of the ftdi driver: "d2xx" (FTDI's library, needs the build tag
+//	# periph_host_ftdi_d2xx on Linux) or "usbfs" (pure Go, Linux only). d2xx
+//	# is used when unset if it is enabled and available, usbfs otherwise.
+//	ftdi_backend = usbfs
+//	# Lock the buses and GPIO lines against other processes.
+//	lock = true
+//
+// Each setting can also be set with an environment variable, which takes
+// precedence over the file: PERIPH_HOST_SKIP, PERIPH_HOST_GPIO,
+// PERIPH_HOST_FTDI, PERIPH_HOST_FTDI_BACKEND and PERIPH_HOST_LOCK.
+//
+// The drivers of this module register with MustRegister(), so the skip list
+// applies to all of them before their Init() is called.
+package hostcfg
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"periph.io/x/conn/v3/driver"
+	"periph.io/x/conn/v3/driver/driverreg"
+)
+
+// USBID is a USB vendor and product ID.
+type USBID struct {
+	VID uint16
+	PID uint16
+}
+
+func (u USBID) String() string {
+	return fmt.Sprintf("%04x:%04x", u.VID, u.PID)
+}
+
+// Config is the host configuration.
+type Config struct {
+	// Skip lists the drivers not to load, by name.
+	Skip []string
+	// GPIO is "chardev", "sysfs" or empty.
+	GPIO string
+	// FTDI lists the USB IDs the ftdi driver may open. Empty allows all.
+	FTDI []USBID
+	// FTDIBackend is "d2xx", "usbfs" or empty.
+	FTDIBackend string
+	// Lock enables the advisory locking of the buses and GPIO lines, so
+	// processes using this module don't interleave their transactions.
+	Lock bool
+}
+
+// Skipped returns true if the driver name must not be loaded.
+func (c *Config) Skipped(name string) bool {
+	switch {
+	case c.GPIO == "chardev" && name == "sysfs-gpio":
+		return true
+	case c.GPIO == "sysfs" && name == "ioctl-gpio":
+		return true
+	}
+	for _, s := range c.Skip {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FTDIAllowed returns true if the ftdi driver may open the device.
+func (c *Config) FTDIAllowed(vid, pid uint16) bool {
+	if len(c.FTDI) == 0 {
+		return true
+	}
+	for _, u := range c.FTDI {
+		if u.VID == vid && u.PID == pid {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the configuration, loaded on first use.
+//
+// On error, the returned Config is empty so everything is allowed.
+func Get() (*Config, error) {
+	once.Do(func() {
+		cfg, errCfg = load(os.Getenv, os.Open)
+		if errCfg != nil {
+			cfg = &Config{}
+		}
+	})
+	return cfg, errCfg
+}
+
+// Skip returns an error if the driver name is disabled by the configuration,
+// or if the configuration is invalid.
+//
+// Drivers return (false, err) from their Init() when it fails.
+func Skip(name string) error {
+	c, err := Get()
+	if err != nil {
+		return err
+	}
+	if c.Skipped(name) {
+		return errors.New("disabled by host configuration")
+	}
+	return nil
+}
+
+// MustRegister calls driverreg.MustRegister() with d wrapped so that d is
+// skipped without calling its Init() when Skip() returns an error.
+//
+// This is the function to call in a driver's package init() function.
+func MustRegister(d driver.Impl) {
+	driverreg.MustRegister(&skipper{d})
+}
+
+//
+
+var (
+	once   sync.Once
+	cfg    *Config
+	errCfg error
+)
+
+// skipper applies the configuration before initializing the driver.
+type skipper struct {
+	driver.Impl
+}
+
+func (s *skipper) Init() (bool, error) {
+	if err := Skip(s.String()); err != nil {
+		return false, err
+	}
+	return s.Impl.Init()
+}
+
+func load(getenv func(string) string, open func(string) (*os.File, error)) (*Config, error) {
+	c := &Config{}
+	if p := getenv("PERIPH_HOST_CONFIG"); p != "" {
+		f, err := open(p)
+		if err != nil {
+			return nil, fmt.Errorf("hostcfg: %v", err)
+		}
+		err = parse(c, f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("hostcfg: %s:%v", p, err)
+		}
+	}
+	for _, k := range []string{"skip", "gpio", "ftdi", "ftdi_backend", "lock"} {
+		e := "PERIPH_HOST_" + strings.ToUpper(k)
+		if v := getenv(e); v != "" {
+			if err := c.set(k, v); err != nil {
+				return nil, fmt.Errorf("hostcfg: %s: %v", e, err)
+			}
+		}
+	}
+	return c, nil
+}
+
+// parse reads the settings in r into c.
+func parse(c *Config, r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		l := strings.TrimSpace(s.Text())
+		if l == "" || l[0] == '#' {
+			continue
+		}
+		i := strings.IndexByte(l, '=')
+		if i == -1 {
+			return fmt.Errorf("%d: expected key = value", n)
+		}
+		if err := c.set(strings.TrimSpace(l[:i]), strings.TrimSpace(l[i+1:])); err != nil {
+			return fmt.Errorf("%d: %v", n, err)
+		}
+	}
+	return s.Err()
+}
+
+// set replaces the setting k with the value v.
+func (c *Config) set(k, v string) error {
+	switch k {
+	case "skip":
+		c.Skip = split(v)
+	case "gpio":
+		if v != "" && v != "chardev" && v != "sysfs" {
+			return fmt.Errorf("gpio must be chardev or sysfs, got %q", v)
+		}
+		c.GPIO = v
+	case "ftdi":
+		c.FTDI = nil
+		for _, s := range split(v) {
+			u, err := parseUSBID(s)
+			if err != nil {
+				return err
+			}
+			c.FTDI = append(c.FTDI, u)
+		}
+	case "ftdi_backend":
+		if v != "" && v != "d2xx" && v != "usbfs" {
+			return fmt.Errorf("ftdi_backend must be d2xx or usbfs, got %q", v)
+		}
+		c.FTDIBackend = v
+	case "lock":
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("lock must be true or false, got %q", v)
+		}
+		c.Lock = b
+	default:
+		return fmt.Errorf("unknown setting %q", k)
+	}
+	return nil
+}
+
+// split splits a comma separated list.
+func split(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// parseUSBID parses "vvvv:pppp" in hexadecimal.
+func parseUSBID(s string) (USBID, error) {
+	i := strings.IndexByte(s, ':')
+	if i == -1 {
+		return USBID{}, fmt.Errorf("invalid USB ID %q; expected vvvv:pppp", s)
+	}
+	v, err1 := strconv.ParseUint(s[:i], 16, 16)
+	p, err2 := strconv.ParseUint(s[i+1:], 16, 16)
+	if err1 != nil || err2 != nil {
+		return USBID{}, fmt.Errorf("invalid USB ID %q; expected vvvv:pppp", s)
+	}
+	return USBID{VID: uint16(v), PID: uint16(p)}, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package hostcfg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad_empty(t *testing.T) {
+	c, err := load(env(nil), os.Open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Skipped("ftdi") || c.Skipped("sysfs-gpio") || c.Skipped("ioctl-gpio") || !c.FTDIAllowed(0x0403, 0x6014) {
+		t.Fatal(c)
+	}
+}
+
+func TestLoad_file(t *testing.T) {
+	d, err := ioutil.TempDir("", "hostcfg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	p := filepath.Join(d, "host.cfg")
+	data := "# Fleet defaults.\n\nskip = bcm283x-dma, ftdi\ngpio = chardev\nftdi = 0403:6014,0403:6010\nftdi_backend = usbfs\nlock = true\n"
+	if err := ioutil.WriteFile(p, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := load(env(map[string]string{"PERIPH_HOST_CONFIG": p}), os.Open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Config{
+		Skip:        []string{"bcm283x-dma", "ftdi"},
+		GPIO:        "chardev",
+		FTDI:        []USBID{{0x0403, 0x6014}, {0x0403, 0x6010}},
+		FTDIBackend: "usbfs",
+		Lock:        true,
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Fatalf("%#v != %#v", c, want)
+	}
+	if !c.Skipped("ftdi") || !c.Skipped("sysfs-gpio") || c.Skipped("ioctl-gpio") {
+		t.Fatal(c)
+	}
+	if !c.FTDIAllowed(0x0403, 0x6010) || c.FTDIAllowed(0x0403, 0x6001) {
+		t.Fatal(c)
+	}
+
+	// The environment overrides the file.
+	c, err = load(env(map[string]string{"PERIPH_HOST_CONFIG": p, "PERIPH_HOST_GPIO": "sysfs", "PERIPH_HOST_SKIP": ",", "PERIPH_HOST_FTDI_BACKEND": "d2xx", "PERIPH_HOST_LOCK": "0"}), os.Open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Skipped("ftdi") || c.Skipped("sysfs-gpio") || !c.Skipped("ioctl-gpio") || len(c.FTDI) != 2 || c.FTDIBackend != "d2xx" || c.Lock {
+		t.Fatal(c)
+	}
+}
+
+func TestLoad_err(t *testing.T) {
+	data := []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{"PERIPH_HOST_CONFIG": "/does/not/exist"}, "hostcfg: open /does/not/exist"},
+		{map[string]string{"PERIPH_HOST_GPIO": "mmap"}, "hostcfg: PERIPH_HOST_GPIO: gpio must be chardev or sysfs"},
+		{map[string]string{"PERIPH_HOST_FTDI": "0403"}, "hostcfg: PERIPH_HOST_FTDI: invalid USB ID \"0403\""},
+		{map[string]string{"PERIPH_HOST_FTDI": "0403:xyz"}, "hostcfg: PERIPH_HOST_FTDI: invalid USB ID \"0403:xyz\""},
+		{map[string]string{"PERIPH_HOST_FTDI_BACKEND": "libusb"}, "hostcfg: PERIPH_HOST_FTDI_BACKEND: ftdi_backend must be d2xx or usbfs"},
+		{map[string]string{"PERIPH_HOST_LOCK": "maybe"}, "hostcfg: PERIPH_HOST_LOCK: lock must be true or false, got \"maybe\""},
+	}
+	for i, line := range data {
+		if _, err := load(env(line.env), os.Open); err == nil || !strings.HasPrefix(err.Error(), line.want) {
+			t.Fatalf("#%d: %v", i, err)
+		}
+	}
+}
+
+func TestParse_err(t *testing.T) {
+	data := []struct {
+		in   string
+		want string
+	}{
+		{"skip\n", "1: expected key = value"},
+		{"\n# comment\nspeed = 1\n", "3: unknown setting \"speed\""},
+	}
+	for i, line := range data {
+		if err := parse(&Config{}, strings.NewReader(line.in)); err == nil || err.Error() != line.want {
+			t.Fatalf("#%d: %v", i, err)
+		}
+	}
+}
+
+func TestUSBID(t *testing.T) {
+	if s := (USBID{0x403, 0x6014}).String(); s != "0403:6014" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+func env(m map[string]string) func(string) string {
+	return func(k string) string {
+		return m[k]
+	}
+}
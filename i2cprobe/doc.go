@@ -0,0 +1,12 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package i2cprobe detects the presence of devices on I²C buses.
+//
+// It probes an address the same way i2cdetect does in its automatic mode: a
+// quick write where it is safe and supported by the bus, a receive byte
+// otherwise. Results are cached per bus, so a device package can call Find()
+// on every initialization to auto-detect on which registered bus its sensor
+// lives without generating traffic each time.
+package i2cprobe
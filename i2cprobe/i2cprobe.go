@@ -0,0 +1,109 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package i2cprobe
+
+import (
+	"fmt"
+	"sync"
+
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+)
+
+// QuickWriter is implemented by buses that can send the SMBus quick command,
+// like sysfs.I2C.
+type QuickWriter interface {
+	QuickWrite(addr uint16) error
+}
+
+// Probe returns true if a device acknowledges addr on b.
+//
+// Reserved addresses, 0x00~0x07 and 0x78~0x7F, are never probed. The EEPROM
+// ranges 0x30~0x37 and 0x50~0x5F are probed with a receive byte, since a
+// quick write can corrupt some of them. Other addresses are probed with a
+// quick write when b implements QuickWriter, with a receive byte otherwise or
+// when the quick write fails.
+//
+// The result is cached until Invalidate() is called for b.
+func Probe(b i2c.Bus, addr uint16) bool {
+	if addr < 0x08 || addr > 0x77 {
+		return false
+	}
+	name := b.String()
+	mu.Lock()
+	defer mu.Unlock()
+	if r, ok := cache[name]; ok {
+		if v, ok := r[addr]; ok {
+			return v
+		}
+	}
+	v := probe(b, addr)
+	r := cache[name]
+	if r == nil {
+		r = map[uint16]bool{}
+		cache[name] = r
+	}
+	r[addr] = v
+	return v
+}
+
+// Invalidate discards the cached results for b, e.g. after a device was
+// plugged in or powered up.
+//
+// If b is nil, the results of all the buses are discarded.
+func Invalidate(b i2c.Bus) {
+	mu.Lock()
+	defer mu.Unlock()
+	if b == nil {
+		cache = map[string]map[uint16]bool{}
+		return
+	}
+	delete(cache, b.String())
+}
+
+// Find returns the name of the first registered bus, as in i2creg.All(), on
+// which a device acknowledges addr.
+//
+// The name can be passed to i2creg.Open().
+func Find(addr uint16) (string, error) {
+	for _, ref := range i2creg.All() {
+		b, err := ref.Open()
+		if err != nil {
+			continue
+		}
+		found := Probe(b, addr)
+		if err := b.Close(); err != nil {
+			return "", err
+		}
+		if found {
+			return ref.Name, nil
+		}
+	}
+	return "", fmt.Errorf("i2cprobe: no device found at %#x", addr)
+}
+
+//
+
+var (
+	mu    sync.Mutex
+	cache = map[string]map[uint16]bool{}
+)
+
+// probe does the actual probe.
+func probe(b i2c.Bus, addr uint16) bool {
+	if q, ok := b.(QuickWriter); ok && !isEEPROM(addr) {
+		// The error doesn't tell a NACK from a bus without quick command
+		// support, so fall back to a receive byte.
+		if q.QuickWrite(addr) == nil {
+			return true
+		}
+	}
+	var r [1]byte
+	return b.Tx(addr, nil, r[:]) == nil
+}
+
+func isEEPROM(addr uint16) bool {
+	return (addr >= 0x30 && addr <= 0x37) || (addr >= 0x50 && addr <= 0x5F)
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package i2cprobe
+
+import (
+	"errors"
+	"testing"
+
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestProbe(t *testing.T) {
+	Invalidate(nil)
+	b := &fakeBus{name: "fake", present: map[uint16]bool{0x40: true, 0x50: true}}
+	if !Probe(b, 0x40) || b.quick != 1 || b.tx != 0 {
+		t.Fatal(b.quick, b.tx)
+	}
+	// Cached.
+	if !Probe(b, 0x40) || b.quick != 1 {
+		t.Fatal(b.quick)
+	}
+	// EEPROM range uses receive byte.
+	if !Probe(b, 0x50) || b.quick != 1 || b.tx != 1 {
+		t.Fatal(b.quick, b.tx)
+	}
+	// Falls back to receive byte when the quick write fails.
+	if Probe(b, 0x41) || b.quick != 2 || b.tx != 2 {
+		t.Fatal(b.quick, b.tx)
+	}
+	// Reserved.
+	if Probe(b, 0x03) || Probe(b, 0x78) || Probe(b, 0x200) || b.quick != 2 || b.tx != 2 {
+		t.Fatal(b.quick, b.tx)
+	}
+
+	b.present[0x41] = true
+	if Probe(b, 0x41) {
+		t.Fatal("cached")
+	}
+	Invalidate(b)
+	if !Probe(b, 0x41) || b.quick != 3 {
+		t.Fatal(b.quick)
+	}
+}
+
+func TestProbe_noQuick(t *testing.T) {
+	Invalidate(nil)
+	f := &fakeBus{name: "fake-tx", present: map[uint16]bool{0x40: true}}
+	b := &txBus{f}
+	if !Probe(b, 0x40) || Probe(b, 0x41) || f.quick != 0 || f.tx != 2 {
+		t.Fatal(f.quick, f.tx)
+	}
+}
+
+func TestFind(t *testing.T) {
+	Invalidate(nil)
+	a := &fakeBus{name: "i2cprobe-a", present: map[uint16]bool{}}
+	b := &fakeBus{name: "i2cprobe-b", present: map[uint16]bool{0x76: true}}
+	for _, f := range []*fakeBus{a, b} {
+		f := f
+		if err := i2creg.Register(f.name, nil, -1, func() (i2c.BusCloser, error) { return f, nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer func() {
+		_ = i2creg.Unregister(a.name)
+		_ = i2creg.Unregister(b.name)
+	}()
+	if n, err := Find(0x76); err != nil || n != b.name {
+		t.Fatal(n, err)
+	}
+	if _, err := Find(0x77); err == nil || err.Error() != "i2cprobe: no device found at 0x77" {
+		t.Fatal(err)
+	}
+}
+
+//
+
+type fakeBus struct {
+	name    string
+	present map[uint16]bool
+	quick   int
+	tx      int
+}
+
+func (f *fakeBus) String() string {
+	return f.name
+}
+
+func (f *fakeBus) Close() error {
+	return nil
+}
+
+func (f *fakeBus) Tx(addr uint16, w, r []byte) error {
+	f.tx++
+	if !f.present[addr] {
+		return errors.New("nack")
+	}
+	return nil
+}
+
+func (f *fakeBus) SetSpeed(freq physic.Frequency) error {
+	return nil
+}
+
+func (f *fakeBus) QuickWrite(addr uint16) error {
+	f.quick++
+	if !f.present[addr] {
+		return errors.New("nack")
+	}
+	return nil
+}
+
+// txBus doesn't implement QuickWriter.
+type txBus struct {
+	f *fakeBus
+}
+
+func (t *txBus) String() string {
+	return t.f.String()
+}
+
+func (t *txBus) Tx(addr uint16, w, r []byte) error {
+	return t.f.Tx(addr, w, r)
+}
+
+func (t *txBus) SetSpeed(freq physic.Frequency) error {
+	return t.f.SetSpeed(freq)
+}
@@ -0,0 +1,12 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package i2cretry wraps an i2c.Bus to retry the failed transactions with an
+// exponential backoff and to bound the time a transaction can take.
+//
+// It works with any bus: sysfs, ftdi, bit banged. It is meant for flaky
+// cabling, where a transaction occasionally fails with a NACK or an I/O
+// error. Retrying a write is only safe when the device tolerates receiving
+// it twice, which is the case of most register writes.
+package i2cretry
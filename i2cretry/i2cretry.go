@@ -0,0 +1,186 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package i2cretry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/physic"
+)
+
+// ErrTimeout is returned when a transaction didn't complete within
+// Policy.Timeout.
+var ErrTimeout = errors.New("i2cretry: transaction timed out")
+
+// Policy is the retry and timeout policy.
+type Policy struct {
+	// Retries is the number of attempts after the first one fails.
+	Retries int
+	// Backoff is the delay before the first retry; it doubles on each retry.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries. 0 means no cap.
+	MaxBackoff time.Duration
+	// Timeout bounds each attempt. 0 means no timeout.
+	//
+	// A transaction that times out can't be aborted; it is left running in
+	// the background, the bus isn't used until it completes and it is not
+	// retried.
+	Timeout time.Duration
+}
+
+// DefaultPolicy retries twice, after 1ms and 2ms, and times out after one
+// second.
+var DefaultPolicy = Policy{Retries: 2, Backoff: time.Millisecond, Timeout: time.Second}
+
+// New returns b with the policy applied.
+func New(b i2c.Bus, p Policy) (*Bus, error) {
+	if p.Retries < 0 || p.Backoff < 0 || p.MaxBackoff < 0 || p.Timeout < 0 {
+		return nil, errors.New("i2cretry: invalid policy")
+	}
+	return &Bus{b: b, p: p}, nil
+}
+
+// Open opens the registered bus name, as in i2creg.Open, and applies the
+// policy.
+func Open(name string, p Policy) (*Bus, error) {
+	b, err := i2creg.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	r, err := New(b, p)
+	if err != nil {
+		_ = b.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Bus is an i2c.Bus with retries and timeouts.
+//
+// It is safe for concurrent use.
+type Bus struct {
+	b i2c.Bus
+	p Policy
+
+	// mu is held by the attempt in flight, including one that timed out.
+	mu sync.Mutex
+}
+
+func (b *Bus) String() string {
+	return fmt.Sprintf("i2cretry(%s)", b.b)
+}
+
+// Close implements i2c.BusCloser.
+//
+// It closes the underlying bus if it implements io.Closer.
+func (b *Bus) Close() error {
+	if c, ok := b.b.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Tx implements i2c.Bus.
+//
+// When all the attempts fail, the error of the last one is wrapped, so it can
+// be checked with errors.Is and errors.As.
+func (b *Bus) Tx(addr uint16, w, r []byte) error {
+	delay := b.p.Backoff
+	var err error
+	for i := 0; ; i++ {
+		if err = b.tx(addr, w, r); err == nil || err == ErrTimeout {
+			return err
+		}
+		if i == b.p.Retries {
+			break
+		}
+		time.Sleep(delay)
+		if delay *= 2; b.p.MaxBackoff != 0 && delay > b.p.MaxBackoff {
+			delay = b.p.MaxBackoff
+		}
+	}
+	if b.p.Retries == 0 {
+		return err
+	}
+	return fmt.Errorf("i2cretry: failed after %d attempts: %w", b.p.Retries+1, err)
+}
+
+// SetSpeed implements i2c.Bus.
+func (b *Bus) SetSpeed(f physic.Frequency) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.SetSpeed(f)
+}
+
+// SCL implements i2c.Pins.
+func (b *Bus) SCL() gpio.PinIO {
+	if p, ok := b.b.(i2c.Pins); ok {
+		return p.SCL()
+	}
+	return gpio.INVALID
+}
+
+// SDA implements i2c.Pins.
+func (b *Bus) SDA() gpio.PinIO {
+	if p, ok := b.b.(i2c.Pins); ok {
+		return p.SDA()
+	}
+	return gpio.INVALID
+}
+
+//
+
+// tx runs a single attempt.
+func (b *Bus) tx(addr uint16, w, r []byte) error {
+	if b.p.Timeout == 0 {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.b.Tx(addr, w, r)
+	}
+	t := time.NewTimer(b.p.Timeout)
+	defer t.Stop()
+	locked := make(chan struct{})
+	go func() {
+		b.mu.Lock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-t.C:
+		// A previous attempt is still stuck. The goroutine releases the lock
+		// right away once it gets it.
+		go func() {
+			<-locked
+			b.mu.Unlock()
+		}()
+		return ErrTimeout
+	}
+	// Buffers owned by the attempt, so the caller's are never touched after
+	// a timeout.
+	wc := append([]byte(nil), w...)
+	rc := make([]byte, len(r))
+	done := make(chan error, 1)
+	go func() {
+		defer b.mu.Unlock()
+		done <- b.b.Tx(addr, wc, rc)
+	}()
+	select {
+	case err := <-done:
+		copy(r, rc)
+		return err
+	case <-t.C:
+		return ErrTimeout
+	}
+}
+
+var _ i2c.BusCloser = &Bus{}
+var _ i2c.Pins = &Bus{}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package i2cretry
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestBus_Tx_retry(t *testing.T) {
+	f := &fakeBus{fails: 2}
+	b, err := New(f, Policy{Retries: 2, Backoff: time.Microsecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := make([]byte, 2)
+	if err := b.Tx(0x40, []byte{1}, r); err != nil {
+		t.Fatal(err)
+	}
+	if f.count() != 3 || r[0] != 0x40 {
+		t.Fatal(f.count(), r)
+	}
+	f.setFails(3)
+	err = b.Tx(0x40, nil, r)
+	if err == nil || err.Error() != "i2cretry: failed after 3 attempts: nack" {
+		t.Fatal(err)
+	}
+	if !errors.Is(err, errNack) {
+		t.Fatal("the bus error must be wrapped")
+	}
+	if s := b.String(); s != "i2cretry(fake)" {
+		t.Fatal(s)
+	}
+}
+
+func TestBus_Tx_noRetry(t *testing.T) {
+	f := &fakeBus{fails: 1}
+	b, err := New(f, Policy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Tx(0x40, nil, nil); err == nil || err.Error() != "nack" {
+		t.Fatal(err)
+	}
+}
+
+func TestBus_Tx_timeout(t *testing.T) {
+	f := &fakeBus{block: make(chan struct{})}
+	b, err := New(f, Policy{Retries: 3, Timeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := make([]byte, 1)
+	if err := b.Tx(0x40, nil, r); err != ErrTimeout {
+		t.Fatal(err)
+	}
+	// Not retried.
+	if f.count() != 1 {
+		t.Fatal(f.count())
+	}
+	// The bus is still busy with the stuck transaction.
+	if err := b.Tx(0x40, nil, r); err != ErrTimeout {
+		t.Fatal(err)
+	}
+	close(f.block)
+	f.setBlock(nil)
+	if err := b.Tx(0x41, nil, r); err != nil {
+		t.Fatal(err)
+	}
+	// The buffer of the stuck transaction was never written to.
+	if r[0] != 0x41 {
+		t.Fatal(r)
+	}
+}
+
+func TestBus_passthrough(t *testing.T) {
+	f := &fakeBus{}
+	b, err := New(f, DefaultPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetSpeed(400 * physic.KiloHertz); err != nil || f.speed != 400*physic.KiloHertz {
+		t.Fatal(err)
+	}
+	if b.SCL() != gpio.INVALID || b.SDA() != gpio.INVALID {
+		t.Fatal("expected no pins")
+	}
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(f, Policy{Retries: -1}); err == nil {
+		t.Fatal("invalid policy")
+	}
+	if _, err := Open("i2cretry-missing", DefaultPolicy); err == nil {
+		t.Fatal("no such bus")
+	}
+}
+
+//
+
+// errNack is the error returned by fakeBus.
+var errNack = errors.New("nack")
+
+type fakeBus struct {
+	mu    sync.Mutex
+	fails int
+	n     int
+	block chan struct{}
+	speed physic.Frequency
+}
+
+func (f *fakeBus) String() string {
+	return "fake"
+}
+
+func (f *fakeBus) Tx(addr uint16, w, r []byte) error {
+	f.mu.Lock()
+	f.n++
+	block := f.block
+	fail := f.fails > 0
+	if fail {
+		f.fails--
+	}
+	f.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+	if fail {
+		return errNack
+	}
+	if len(r) != 0 {
+		r[0] = byte(addr)
+	}
+	return nil
+}
+
+func (f *fakeBus) SetSpeed(freq physic.Frequency) error {
+	f.speed = freq
+	return nil
+}
+
+func (f *fakeBus) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.n
+}
+
+func (f *fakeBus) setFails(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fails = n
+	f.n = 0
+}
+
+func (f *fakeBus) setBlock(c chan struct{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.block = c
+}
@@ -0,0 +1,11 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package i2ctrace wraps an i2c.Bus to trace every transaction: address,
+// data written and read, duration and result.
+//
+// The records are sent to a pluggable Sink and tracing can be switched on
+// and off at runtime, so it can be left in the code of a device in the field
+// and enabled when debugging the communication with a sensor.
+package i2ctrace
@@ -0,0 +1,165 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package i2ctrace
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/physic"
+)
+
+// Record is a traced transaction.
+type Record struct {
+	Bus      string
+	Addr     uint16
+	W        []byte
+	R        []byte // data read; undefined on error
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+func (r *Record) String() string {
+	res := "ok"
+	if r.Err != nil {
+		res = r.Err.Error()
+	}
+	return fmt.Sprintf("%s 0x%02x w=[% x] r=[% x] %s %s", r.Bus, r.Addr, r.W, r.R, r.Duration, res)
+}
+
+// Sink receives the records.
+//
+// Trace is called synchronously after each transaction and must not retain
+// r.
+type Sink interface {
+	Trace(r *Record)
+}
+
+// SinkFunc is a function implementing Sink.
+type SinkFunc func(r *Record)
+
+// Trace implements Sink.
+func (s SinkFunc) Trace(r *Record) {
+	s(r)
+}
+
+// LogSink returns a Sink printing the records to l, one per line.
+func LogSink(l *log.Logger) Sink {
+	return SinkFunc(func(r *Record) {
+		l.Print(r)
+	})
+}
+
+// WriterSink returns a Sink writing the records to w, one per line.
+func WriterSink(w io.Writer) Sink {
+	var mu sync.Mutex
+	return SinkFunc(func(r *Record) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(w, "%s %s\n", r.Start.Format("15:04:05.000000"), r)
+	})
+}
+
+// New returns b traced to s. Tracing is enabled.
+func New(b i2c.Bus, s Sink) *Bus {
+	return &Bus{b: b, s: s}
+}
+
+// Open opens the registered bus name, as in i2creg.Open, traced to s.
+// Tracing is enabled.
+func Open(name string, s Sink) (*Bus, error) {
+	b, err := i2creg.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return New(b, s), nil
+}
+
+// Bus is a traced i2c.Bus.
+//
+// It is safe for concurrent use.
+type Bus struct {
+	b i2c.Bus
+
+	mu       sync.Mutex
+	s        Sink
+	disabled bool
+}
+
+func (b *Bus) String() string {
+	return b.b.String()
+}
+
+// Enable switches tracing on or off.
+func (b *Bus) Enable(on bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.disabled = !on
+}
+
+// SetSink replaces the sink. A nil sink disables tracing.
+func (b *Bus) SetSink(s Sink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.s = s
+}
+
+// Close implements i2c.BusCloser.
+//
+// It closes the underlying bus if it implements io.Closer.
+func (b *Bus) Close() error {
+	if c, ok := b.b.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Tx implements i2c.Bus.
+func (b *Bus) Tx(addr uint16, w, r []byte) error {
+	b.mu.Lock()
+	s := b.s
+	if b.disabled {
+		s = nil
+	}
+	b.mu.Unlock()
+	if s == nil {
+		return b.b.Tx(addr, w, r)
+	}
+	start := time.Now()
+	err := b.b.Tx(addr, w, r)
+	s.Trace(&Record{Bus: b.b.String(), Addr: addr, W: w, R: r, Start: start, Duration: time.Since(start), Err: err})
+	return err
+}
+
+// SetSpeed implements i2c.Bus.
+func (b *Bus) SetSpeed(f physic.Frequency) error {
+	return b.b.SetSpeed(f)
+}
+
+// SCL implements i2c.Pins.
+func (b *Bus) SCL() gpio.PinIO {
+	if p, ok := b.b.(i2c.Pins); ok {
+		return p.SCL()
+	}
+	return gpio.INVALID
+}
+
+// SDA implements i2c.Pins.
+func (b *Bus) SDA() gpio.PinIO {
+	if p, ok := b.b.(i2c.Pins); ok {
+		return p.SDA()
+	}
+	return gpio.INVALID
+}
+
+var _ i2c.BusCloser = &Bus{}
+var _ i2c.Pins = &Bus{}
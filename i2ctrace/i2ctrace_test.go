@@ -0,0 +1,100 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package i2ctrace
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestBus_Tx(t *testing.T) {
+	var records []Record
+	b := New(&fakeBus{}, SinkFunc(func(r *Record) {
+		records = append(records, *r)
+	}))
+	r := make([]byte, 2)
+	if err := b.Tx(0x76, []byte{0xD0}, r); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Tx(0x77, []byte{0xD0}, r); err == nil {
+		t.Fatal("expected nack")
+	}
+	if len(records) != 2 {
+		t.Fatal(records)
+	}
+	records[0].Duration = 0
+	if s := records[0].String(); s != "I2C1 0x76 w=[d0] r=[58 01] 0s ok" {
+		t.Fatal(s)
+	}
+	records[1].Duration = 0
+	if s := records[1].String(); s != "I2C1 0x77 w=[d0] r=[58 01] 0s nack" {
+		t.Fatal(s)
+	}
+	// Switched off at runtime.
+	b.Enable(false)
+	if err := b.Tx(0x76, nil, r); err != nil || len(records) != 2 {
+		t.Fatal(err, records)
+	}
+	b.Enable(true)
+	b.SetSink(nil)
+	if err := b.Tx(0x76, nil, r); err != nil || len(records) != 2 {
+		t.Fatal(err, records)
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	b := New(&fakeBus{}, WriterSink(&buf))
+	if err := b.Tx(0x76, []byte{1, 2}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s := buf.String(); !strings.Contains(s, " I2C1 0x76 w=[01 02] r=[] ") || !strings.HasSuffix(s, " ok\n") {
+		t.Fatal(s)
+	}
+}
+
+func TestBus_passthrough(t *testing.T) {
+	f := &fakeBus{}
+	b := New(f, nil)
+	if s := b.String(); s != "I2C1" {
+		t.Fatal(s)
+	}
+	if err := b.SetSpeed(physic.MegaHertz); err != nil || f.speed != physic.MegaHertz {
+		t.Fatal(err)
+	}
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open("i2ctrace-missing", nil); err == nil {
+		t.Fatal("no such bus")
+	}
+}
+
+//
+
+type fakeBus struct {
+	speed physic.Frequency
+}
+
+func (f *fakeBus) String() string {
+	return "I2C1"
+}
+
+func (f *fakeBus) Tx(addr uint16, w, r []byte) error {
+	if addr != 0x76 {
+		return errors.New("nack")
+	}
+	copy(r, []byte{0x58, 0x01})
+	return nil
+}
+
+func (f *fakeBus) SetSpeed(freq physic.Frequency) error {
+	f.speed = freq
+	return nil
+}
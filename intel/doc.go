@@ -0,0 +1,26 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package intel contains header definitions for x86 single board computers
+// with a 40-pin header.
+//
+// The supported boards are the AAEON UP family (UP, UP Squared, UP Core Plus,
+// UP Xtreme) and the DFRobot LattePanda. The board is detected via the DMI
+// information exposed in /sys/class/dmi/id/.
+//
+// The GPIO pins are accessed through the GPIO character device, see package
+// gpioioctl. On the UP boards the header is driven by the upboard-pinctrl
+// driver, which exposes the lines using the Raspberry Pi BCM numbering, so the
+// line offset of pin P1_11 is 17.
+//
+// The I²C buses and SPI ports routed to the header are registered under their
+// header name, e.g. "P1_I2C" for the I²C bus on pins 3 and 5, since their
+// /dev/i2c-N number depends on the probe order.
+//
+// Physical
+//
+// https://github.com/up-board/up-community/wiki/Pinout
+//
+// https://docs.lattepanda.com/content/1st_edition/io_playability/
+package intel
@@ -0,0 +1,380 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package intel
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/s-mobi01/host/gpioioctl"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+	"periph.io/x/conn/v3/spi"
+	"periph.io/x/conn/v3/spi/spireg"
+)
+
+// Model is a supported x86 board.
+type Model int
+
+// Supported models.
+const (
+	Unknown    Model = iota
+	UP               // Intel Atom x5-Z8350 (Cherry Trail)
+	UPSquared        // Intel Atom/Celeron/Pentium (Apollo Lake)
+	UPCorePlus       // Intel Atom (Apollo Lake)
+	UPXtreme         // Intel Core (Whiskey Lake)
+	LattePanda       // Intel Atom x5-Z8350 (Cherry Trail)
+)
+
+func (m Model) String() string {
+	switch m {
+	case UP:
+		return "UP"
+	case UPSquared:
+		return "UP Squared"
+	case UPCorePlus:
+		return "UP Core Plus"
+	case UPXtreme:
+		return "UP Xtreme"
+	case LattePanda:
+		return "LattePanda"
+	default:
+		return "Unknown"
+	}
+}
+
+// Present returns true if running on a supported x86 board.
+func Present() bool {
+	return Detect() != Unknown
+}
+
+// Detect returns the board the host is running on.
+func Detect() Model {
+	if !isX86 {
+		return Unknown
+	}
+	vendor := readDMI("board_vendor")
+	name := readDMI("board_name")
+	if vendor == "AAEON" {
+		switch {
+		case strings.HasPrefix(name, "UP-CHT"):
+			return UP
+		case strings.HasPrefix(name, "UPS-APL"):
+			return UPSquared
+		case strings.HasPrefix(name, "UPC-APL"), strings.HasPrefix(name, "UP-APL"):
+			return UPCorePlus
+		case strings.HasPrefix(name, "UPX-WHL"):
+			return UPXtreme
+		}
+	}
+	if strings.Contains(readDMI("product_name"), "LattePanda") {
+		return LattePanda
+	}
+	return Unknown
+}
+
+// readDMI returns the content of a DMI attribute, or an empty string.
+func readDMI(name string) string {
+	b, err := ioutil.ReadFile("/sys/class/dmi/id/" + name)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// The 40-pin header. It follows the Raspberry Pi layout.
+//
+// The pins are initialized once the board is detected. The comments list the
+// function on the UP boards.
+var (
+	P1_1             = pin.V3_3     //
+	P1_2             = pin.V5       //
+	P1_3  gpio.PinIO = gpio.INVALID // I2C_SDA
+	P1_4             = pin.V5       //
+	P1_5  gpio.PinIO = gpio.INVALID // I2C_SCL
+	P1_6             = pin.GROUND   //
+	P1_7  gpio.PinIO = gpio.INVALID // ADC0
+	P1_8  gpio.PinIO = gpio.INVALID // UART_TX
+	P1_9             = pin.GROUND   //
+	P1_10 gpio.PinIO = gpio.INVALID // UART_RX
+	P1_11 gpio.PinIO = gpio.INVALID // UART_RTS
+	P1_12 gpio.PinIO = gpio.INVALID // I2S_CLK
+	P1_13 gpio.PinIO = gpio.INVALID //
+	P1_14            = pin.GROUND   //
+	P1_15 gpio.PinIO = gpio.INVALID //
+	P1_16 gpio.PinIO = gpio.INVALID //
+	P1_17            = pin.V3_3     //
+	P1_18 gpio.PinIO = gpio.INVALID //
+	P1_19 gpio.PinIO = gpio.INVALID // SPI_MOSI
+	P1_20            = pin.GROUND   //
+	P1_21 gpio.PinIO = gpio.INVALID // SPI_MISO
+	P1_22 gpio.PinIO = gpio.INVALID //
+	P1_23 gpio.PinIO = gpio.INVALID // SPI_CLK
+	P1_24 gpio.PinIO = gpio.INVALID // SPI_CS0
+	P1_25            = pin.GROUND   //
+	P1_26 gpio.PinIO = gpio.INVALID // SPI_CS1
+	P1_27 gpio.PinIO = gpio.INVALID // ID_SDA
+	P1_28 gpio.PinIO = gpio.INVALID // ID_SCL
+	P1_29 gpio.PinIO = gpio.INVALID //
+	P1_30            = pin.GROUND   //
+	P1_31 gpio.PinIO = gpio.INVALID //
+	P1_32 gpio.PinIO = gpio.INVALID // PWM0
+	P1_33 gpio.PinIO = gpio.INVALID // PWM1
+	P1_34            = pin.GROUND   //
+	P1_35 gpio.PinIO = gpio.INVALID // I2S_FRM
+	P1_36 gpio.PinIO = gpio.INVALID // UART_CTS
+	P1_37 gpio.PinIO = gpio.INVALID //
+	P1_38 gpio.PinIO = gpio.INVALID // I2S_DIN
+	P1_39            = pin.GROUND   //
+	P1_40 gpio.PinIO = gpio.INVALID // I2S_DOUT
+)
+
+// line identifies a GPIO line by the label of its chip and its offset.
+type line struct {
+	chip   string
+	offset int
+}
+
+// board describes the mapping of a model.
+type board struct {
+	// p1 maps the header pin number to the GPIO line.
+	p1 map[int]line
+	// i2c maps the friendly name of an I²C bus to the kernel device of its
+	// controller, as found in the sysfs device path.
+	i2c map[string]string
+	// spi maps the friendly name of a SPI controller to the kernel device of
+	// its controller, as found in the sysfs device path.
+	spi map[string]string
+}
+
+// upPinctrl is the label of the gpiochip exposed by the upboard-pinctrl
+// driver.
+const upPinctrl = "upboard-pinctrl"
+
+// upHeader is the header mapping of all the UP boards; the line offset is the
+// BCM number of the equivalent pin on a Raspberry Pi.
+var upHeader = map[int]line{
+	3: {upPinctrl, 2}, 5: {upPinctrl, 3}, 7: {upPinctrl, 4}, 8: {upPinctrl, 14},
+	10: {upPinctrl, 15}, 11: {upPinctrl, 17}, 12: {upPinctrl, 18},
+	13: {upPinctrl, 27}, 15: {upPinctrl, 22}, 16: {upPinctrl, 23},
+	18: {upPinctrl, 24}, 19: {upPinctrl, 10}, 21: {upPinctrl, 9},
+	22: {upPinctrl, 25}, 23: {upPinctrl, 11}, 24: {upPinctrl, 8},
+	26: {upPinctrl, 7}, 27: {upPinctrl, 0}, 28: {upPinctrl, 1},
+	29: {upPinctrl, 5}, 31: {upPinctrl, 6}, 32: {upPinctrl, 12},
+	33: {upPinctrl, 13}, 35: {upPinctrl, 19}, 36: {upPinctrl, 16},
+	37: {upPinctrl, 26}, 38: {upPinctrl, 20}, 40: {upPinctrl, 21},
+}
+
+var boards = map[Model]*board{
+	UP: {
+		p1: upHeader,
+		i2c: map[string]string{
+			"P1_I2C":    "808622C1:06",
+			"P1_ID_I2C": "808622C1:05",
+		},
+		spi: map[string]string{
+			"P1_SPI": "8086228E:01",
+		},
+	},
+	UPSquared: {
+		p1: upHeader,
+		i2c: map[string]string{
+			"P1_I2C":    "0000:00:16.0",
+			"P1_ID_I2C": "0000:00:16.1",
+		},
+		spi: map[string]string{
+			"P1_SPI": "0000:00:19.0",
+		},
+	},
+	UPCorePlus: {
+		p1: upHeader,
+		i2c: map[string]string{
+			"P1_I2C":    "0000:00:16.0",
+			"P1_ID_I2C": "0000:00:16.1",
+		},
+		spi: map[string]string{
+			"P1_SPI": "0000:00:19.0",
+		},
+	},
+	UPXtreme: {
+		p1: upHeader,
+		i2c: map[string]string{
+			"P1_I2C":    "0000:00:15.0",
+			"P1_ID_I2C": "0000:00:15.1",
+		},
+		spi: map[string]string{
+			"P1_SPI": "0000:00:1e.2",
+		},
+	},
+	// The LattePanda routes a few Cherry Trail pins to the 2x10 "CPU GPIO"
+	// header. They are mapped as P1 pins 7, 11, 13, 15, 29 and 31.
+	LattePanda: {
+		p1: map[int]line{
+			7: {"INT33FF:01", 15}, 11: {"INT33FF:01", 16}, 13: {"INT33FF:01", 17},
+			15: {"INT33FF:01", 18}, 29: {"INT33FF:01", 19}, 31: {"INT33FF:01", 20},
+		},
+		i2c: map[string]string{
+			"P1_I2C": "808622C1:06",
+		},
+	},
+}
+
+// gpioLine returns the GPIO line, registering it in gpioreg if needed, or
+// gpio.INVALID.
+func gpioLine(l line) (gpio.PinIO, error) {
+	c := gpioioctl.ChipByLabel(l.chip)
+	if c == nil {
+		return gpio.INVALID, nil
+	}
+	p := c.ByNumber(l.offset)
+	if p == nil {
+		return gpio.INVALID, nil
+	}
+	if gpioreg.ByName(p.Name()) == nil {
+		if err := gpioreg.Register(p); err != nil {
+			return gpio.INVALID, err
+		}
+	}
+	return p, nil
+}
+
+// findDevices returns the entries matching pattern whose resolved path
+// under sub is a child of the kernel device dev.
+func findDevices(pattern, sub, dev string) []string {
+	var out []string
+	items, _ := filepath.Glob(pattern)
+	for _, item := range items {
+		if p, err := filepath.EvalSymlinks(item + sub); err == nil && strings.Contains(p, "/"+dev+"/") {
+			out = append(out, filepath.Base(item))
+		}
+	}
+	return out
+}
+
+// registerI2C registers the I²C bus of the controller dev by its friendly
+// name.
+func registerI2C(name, dev string) error {
+	for _, item := range findDevices("/sys/bus/i2c/devices/i2c-*", "", dev) {
+		var n int
+		if _, err := fmt.Sscanf(item, "i2c-%d", &n); err != nil {
+			continue
+		}
+		opener := func() (i2c.BusCloser, error) {
+			return sysfs.NewI2C(n)
+		}
+		return i2creg.Register(name, nil, -1, opener)
+	}
+	return nil
+}
+
+// registerSPI registers each chip select of the SPI controller dev by its
+// friendly name, e.g. "P1_SPI.0".
+func registerSPI(name, dev string) error {
+	for _, item := range findDevices("/sys/class/spidev/spidev*", "/device", dev) {
+		var bus, cs int
+		if _, err := fmt.Sscanf(item, "spidev%d.%d", &bus, &cs); err != nil {
+			continue
+		}
+		opener := func() (spi.PortCloser, error) {
+			return sysfs.NewSPI(bus, cs)
+		}
+		if err := spireg.Register(fmt.Sprintf("%s.%d", name, cs), nil, -1, opener); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// driver implements periph.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "intel-sbc"
+}
+
+func (d *driver) Prerequisites() []string {
+	return []string{"ioctl-gpio"}
+}
+
+func (d *driver) After() []string {
+	return []string{"sysfs-i2c", "sysfs-spi"}
+}
+
+func (d *driver) Init() (bool, error) {
+	b := boards[Detect()]
+	if b == nil {
+		return false, errors.New("x86 board not detected")
+	}
+	pins := map[int]*gpio.PinIO{
+		3: &P1_3, 5: &P1_5, 7: &P1_7, 8: &P1_8, 10: &P1_10, 11: &P1_11,
+		12: &P1_12, 13: &P1_13, 15: &P1_15, 16: &P1_16, 18: &P1_18,
+		19: &P1_19, 21: &P1_21, 22: &P1_22, 23: &P1_23, 24: &P1_24,
+		26: &P1_26, 27: &P1_27, 28: &P1_28, 29: &P1_29, 31: &P1_31,
+		32: &P1_32, 33: &P1_33, 35: &P1_35, 36: &P1_36, 37: &P1_37,
+		38: &P1_38, 40: &P1_40,
+	}
+	for n, l := range b.p1 {
+		p, err := gpioLine(l)
+		if err != nil {
+			return true, err
+		}
+		*pins[n] = p
+	}
+
+	hdr := [][]pin.Pin{
+		{P1_1, P1_2},
+		{P1_3, P1_4},
+		{P1_5, P1_6},
+		{P1_7, P1_8},
+		{P1_9, P1_10},
+		{P1_11, P1_12},
+		{P1_13, P1_14},
+		{P1_15, P1_16},
+		{P1_17, P1_18},
+		{P1_19, P1_20},
+		{P1_21, P1_22},
+		{P1_23, P1_24},
+		{P1_25, P1_26},
+		{P1_27, P1_28},
+		{P1_29, P1_30},
+		{P1_31, P1_32},
+		{P1_33, P1_34},
+		{P1_35, P1_36},
+		{P1_37, P1_38},
+		{P1_39, P1_40},
+	}
+	if err := pinreg.Register("P1", hdr); err != nil {
+		return true, err
+	}
+	for name, dev := range b.i2c {
+		if err := registerI2C(name, dev); err != nil {
+			return true, err
+		}
+	}
+	for name, dev := range b.spi {
+		if err := registerSPI(name, dev); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func init() {
+	if isX86 {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
@@ -0,0 +1,215 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package host
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/onewire/onewirereg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+	"periph.io/x/conn/v3/spi/spireg"
+	"periph.io/x/conn/v3/uart/uartreg"
+)
+
+// Resources is a snapshot of the hardware resources registered on the host,
+// as returned by Inventory.
+type Resources struct {
+	Drivers []DriverInfo
+	Pins    []PinInfo
+	Headers []HeaderInfo
+	I2C     []BusInfo
+	SPI     []BusInfo
+	OneWire []BusInfo
+	UART    []BusInfo
+}
+
+// DriverInfo is the state of a driver.
+type DriverInfo struct {
+	Name string
+	// State is "loaded", "skipped" or "failed".
+	State string
+	// Err is the reason the driver was skipped or failed.
+	Err error
+}
+
+// PinInfo describes a GPIO pin registered in gpioreg.
+type PinInfo struct {
+	Name   string
+	Number int
+	// Aliases are the alternative names registered in gpioreg.
+	Aliases []string
+	// Driver is the package implementing the pin, e.g. "bcm283x".
+	Driver string
+	// Func is the current function, including the level for a GPIO, e.g.
+	// "In/High" or "SPI0_CLK".
+	Func string
+	// Funcs are the supported functions, if known.
+	Funcs []string
+	// PWM is true if the pin can output PWM.
+	PWM bool
+	// Pull is the current pull resistor, if known, and DefaultPull the one
+	// at boot.
+	Pull        gpio.Pull
+	DefaultPull gpio.Pull
+	// Owner is the owner claiming the pin in pinuse, or "" if free.
+	Owner string
+}
+
+// HeaderInfo describes a header registered in pinreg.
+type HeaderInfo struct {
+	Name string
+	// Pins are the pin names, row by row.
+	Pins [][]string
+}
+
+// BusInfo describes a bus or port registered in i2creg, spireg, onewirereg
+// or uartreg.
+//
+// The buses are not opened, so their speed and pins are not reported.
+type BusInfo struct {
+	Name    string
+	Aliases []string
+	Number  int
+	// Driver is the package implementing the bus, e.g. "sysfs".
+	Driver string
+}
+
+// Inventory returns all the registered drivers, pins, headers, buses and
+// ports with their metadata and current state, for a live view of the
+// hardware.
+//
+// It calls Init() first. The pins and buses are only queried, not opened nor
+// reconfigured.
+func Inventory() (*Resources, error) {
+	s, err := Init()
+	if err != nil {
+		return nil, err
+	}
+	r := &Resources{}
+	for _, d := range s.Loaded {
+		r.Drivers = append(r.Drivers, DriverInfo{Name: d.String(), State: "loaded"})
+	}
+	for _, d := range s.Skipped {
+		r.Drivers = append(r.Drivers, DriverInfo{Name: d.D.String(), State: "skipped", Err: d.Err})
+	}
+	for _, d := range s.Failed {
+		r.Drivers = append(r.Drivers, DriverInfo{Name: d.D.String(), State: "failed", Err: d.Err})
+	}
+	sort.Slice(r.Drivers, func(i, j int) bool { return r.Drivers[i].Name < r.Drivers[j].Name })
+
+	aliases := map[string][]string{}
+	for _, a := range gpioreg.Aliases() {
+		if p, ok := a.(gpio.RealPin); ok {
+			n := p.Real().Name()
+			aliases[n] = append(aliases[n], a.Name())
+		}
+	}
+	for _, p := range gpioreg.All() {
+		r.Pins = append(r.Pins, pinInfo(p, aliases[p.Name()]))
+	}
+
+	hdrs := pinreg.All()
+	names := make([]string, 0, len(hdrs))
+	for n := range hdrs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		h := HeaderInfo{Name: n}
+		for _, row := range hdrs[n] {
+			l := make([]string, 0, len(row))
+			for _, p := range row {
+				l = append(l, p.Name())
+			}
+			h.Pins = append(h.Pins, l)
+		}
+		r.Headers = append(r.Headers, h)
+	}
+
+	for _, b := range i2creg.All() {
+		r.I2C = append(r.I2C, BusInfo{Name: b.Name, Aliases: b.Aliases, Number: b.Number, Driver: funcPackage(b.Open)})
+	}
+	for _, b := range spireg.All() {
+		r.SPI = append(r.SPI, BusInfo{Name: b.Name, Aliases: b.Aliases, Number: b.Number, Driver: funcPackage(b.Open)})
+	}
+	for _, b := range onewirereg.All() {
+		r.OneWire = append(r.OneWire, BusInfo{Name: b.Name, Aliases: b.Aliases, Number: b.Number, Driver: funcPackage(b.Open)})
+	}
+	for _, b := range uartreg.All() {
+		r.UART = append(r.UART, BusInfo{Name: b.Name, Aliases: b.Aliases, Number: b.Number, Driver: funcPackage(b.Open)})
+	}
+	return r, nil
+}
+
+//
+
+func pinInfo(p gpio.PinIO, aliases []string) PinInfo {
+	i := PinInfo{
+		Name:        p.Name(),
+		Number:      p.Number(),
+		Aliases:     aliases,
+		Driver:      typePackage(p),
+		Func:        p.Function(),
+		Pull:        p.Pull(),
+		DefaultPull: p.DefaultPull(),
+		Owner:       pinuse.Owner(p),
+	}
+	if f, ok := p.(pin.PinFunc); ok {
+		i.Func = string(f.Func())
+		for _, s := range f.SupportedFuncs() {
+			i.Funcs = append(i.Funcs, string(s))
+			if s == gpio.PWM {
+				i.PWM = true
+			}
+		}
+	}
+	return i
+}
+
+// typePackage returns the last element of the package path of the type of v.
+func typePackage(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return lastElement(t.PkgPath())
+}
+
+// funcPackage returns the last element of the package path of the function f,
+// which may be a closure.
+func funcPackage(f interface{}) string {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return ""
+	}
+	// e.g. "github.com/s-mobi01/host/ftdi.registerDev.func1".
+	n := fn.Name()
+	if i := strings.LastIndexByte(n, '/'); i != -1 {
+		n = n[i+1:]
+	}
+	if i := strings.IndexByte(n, '.'); i != -1 {
+		n = n[:i]
+	}
+	return n
+}
+
+func lastElement(p string) string {
+	if i := strings.LastIndexByte(p, '/'); i != -1 {
+		return p[i+1:]
+	}
+	return p
+}
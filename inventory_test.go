@@ -0,0 +1,113 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package host
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+)
+
+func TestInventory(t *testing.T) {
+	p := &gpiotest.Pin{N: "INVENTORY0", Num: 1000, Fn: "In/Low", P: gpio.PullUp}
+	if err := gpioreg.Register(p); err != nil {
+		t.Fatal(err)
+	}
+	defer gpioreg.Unregister(p.N)
+	if err := gpioreg.RegisterAlias("INV0", p.N); err != nil {
+		t.Fatal(err)
+	}
+	defer gpioreg.Unregister("INV0")
+	if err := pinreg.Register("INVENTORY", [][]pin.Pin{{p, gpio.INVALID}}); err != nil {
+		t.Fatal(err)
+	}
+	defer pinreg.Unregister("INVENTORY")
+	if err := i2creg.Register("INVENTORY", []string{"INV"}, -1, openI2C); err != nil {
+		t.Fatal(err)
+	}
+	defer i2creg.Unregister("INVENTORY")
+	if err := pinuse.Claim("test", p); err != nil {
+		t.Fatal(err)
+	}
+	defer pinuse.Release("test", p)
+
+	r, err := Inventory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got *PinInfo
+	for i := range r.Pins {
+		if r.Pins[i].Name == p.N {
+			got = &r.Pins[i]
+		}
+	}
+	// pinreg registers the alias INVENTORY_1 for the header position.
+	want := &PinInfo{
+		Name:        "INVENTORY0",
+		Number:      1000,
+		Aliases:     []string{"INV0", "INVENTORY_1"},
+		Driver:      "gpiotest",
+		Func:        "In/Low",
+		Funcs:       []string{"IN", "OUT"},
+		Pull:        gpio.PullUp,
+		DefaultPull: gpio.PullUp,
+		Owner:       "test",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("%#v != %#v", got, want)
+	}
+	found := false
+	for _, h := range r.Headers {
+		if h.Name == "INVENTORY" {
+			found = true
+			if w := [][]string{{"INVENTORY0", "INVALID"}}; !reflect.DeepEqual(h.Pins, w) {
+				t.Fatal(h.Pins)
+			}
+		}
+	}
+	if !found {
+		t.Fatal(r.Headers)
+	}
+	found = false
+	for _, b := range r.I2C {
+		if b.Name == "INVENTORY" {
+			found = true
+			if w := (BusInfo{Name: "INVENTORY", Aliases: []string{"INV"}, Number: -1, Driver: "host"}); !reflect.DeepEqual(b, w) {
+				t.Fatalf("%#v", b)
+			}
+		}
+	}
+	if !found {
+		t.Fatal(r.I2C)
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	if s := funcPackage(gpioreg.All); s != "gpioreg" {
+		t.Fatal(s)
+	}
+	if s := funcPackage(func() {}); s != "host" {
+		t.Fatal(s)
+	}
+	var f func()
+	if s := funcPackage(f); s != "" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+func openI2C() (i2c.BusCloser, error) {
+	return nil, errors.New("not implemented")
+}
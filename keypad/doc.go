@@ -0,0 +1,25 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package keypad scans a matrix keypad, like the membrane 3x4 and 4x4 keypads,
+// connected to any GPIO pins.
+//
+// The keys connect a row to a column. The columns are inputs with a pull up
+// and the rows are driven low one at a time; a column reading low while a row
+// is driven means the key at their intersection is pressed. The rows not being
+// scanned are left floating, so pressing two keys in the same column doesn't
+// short two outputs.
+//
+// Each key is debounced in software and the changes are reported as Events,
+// either from a manual Scan or from a goroutine scanning periodically.
+//
+// Ghosting
+//
+// Without a diode per key, pressing three keys at the corners of a rectangle
+// makes the fourth corner read as pressed, since the current flows backward
+// through the three keys. This is indistinguishable from four keys being
+// pressed, so the keys of such a rectangle keep their state until it is
+// broken and Ghosting reports it. Set Opts.Diodes for keypads with diodes,
+// which don't ghost.
+package keypad
@@ -0,0 +1,373 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package keypad
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/pin"
+)
+
+// Opts configures New.
+type Opts struct {
+	// Rows are the pins connected to the rows of the keypad. They are driven
+	// low one at a time and left floating otherwise.
+	Rows []gpio.PinIO
+	// Cols are the pins connected to the columns of the keypad. They are
+	// pulled up.
+	Cols []gpio.PinIn
+	// Keys optionally maps the keys to runes, one string per row with one rune
+	// per column, e.g. {"123A", "456B", "789C", "*0#D"}.
+	Keys []string
+	// Interval is the period between two scans by Events. It defaults to 10ms.
+	Interval time.Duration
+	// Debounce is how long a key must be stable before a change is reported.
+	// It is rounded up to a number of scans of Interval. It defaults to 20ms;
+	// use a negative value to disable debouncing.
+	Debounce time.Duration
+	// Diodes disables the ghosting detection, for keypads with a diode per
+	// key.
+	Diodes bool
+}
+
+// Event is a key being pressed or released.
+type Event struct {
+	// Row and Col are the position of the key in the matrix.
+	Row, Col int
+	// Key is the rune of the key in Opts.Keys, or 0 without a key map.
+	Key rune
+	// Pressed is true when the key was pressed, false when released.
+	Pressed bool
+	// Time is when the scan confirming the change started.
+	Time time.Time
+}
+
+func (e Event) String() string {
+	s := "released"
+	if e.Pressed {
+		s = "pressed"
+	}
+	if e.Key != 0 {
+		return strconv.QuoteRune(e.Key) + " " + s
+	}
+	return fmt.Sprintf("(%d,%d) %s", e.Row, e.Col, s)
+}
+
+// New returns a keypad scanning the matrix of o.
+//
+// The pins are claimed in package pinuse until Close is called.
+func New(o *Opts) (*Dev, error) {
+	if len(o.Rows) == 0 || len(o.Cols) == 0 {
+		return nil, errors.New("keypad: rows and columns are required")
+	}
+	if o.Interval < 0 {
+		return nil, errors.New("keypad: invalid interval")
+	}
+	if o.Keys != nil {
+		if len(o.Keys) != len(o.Rows) {
+			return nil, fmt.Errorf("keypad: %d key map rows for %d rows", len(o.Keys), len(o.Rows))
+		}
+		for i, k := range o.Keys {
+			if n := len([]rune(k)); n != len(o.Cols) {
+				return nil, fmt.Errorf("keypad: key map row %d has %d keys for %d columns", i, n, len(o.Cols))
+			}
+		}
+	}
+	d := &Dev{
+		rows:     o.Rows,
+		cols:     o.Cols,
+		interval: o.Interval,
+		diodes:   o.Diodes,
+		keys:     make([][]rune, len(o.Rows)),
+		down:     newMatrix(len(o.Rows), len(o.Cols)),
+		cnt:      make([][]int, len(o.Rows)),
+	}
+	if d.interval == 0 {
+		d.interval = 10 * time.Millisecond
+	}
+	debounce := o.Debounce
+	if debounce == 0 {
+		debounce = 20 * time.Millisecond
+	}
+	d.scans = 1
+	if debounce > 0 {
+		d.scans = int((debounce + d.interval - 1) / d.interval)
+	}
+	for i := range d.cnt {
+		d.cnt[i] = make([]int, len(o.Cols))
+		if o.Keys != nil {
+			d.keys[i] = []rune(o.Keys[i])
+		}
+	}
+	pins := d.pins()
+	if err := pinuse.Claim(d.String(), pins...); err != nil {
+		return nil, fmt.Errorf("keypad: %v", err)
+	}
+	for _, c := range d.cols {
+		if err := c.In(gpio.PullUp, gpio.NoEdge); err != nil {
+			pinuse.Release(d.String(), pins...)
+			return nil, fmt.Errorf("keypad: %v", err)
+		}
+	}
+	for _, r := range d.rows {
+		if err := r.In(gpio.Float, gpio.NoEdge); err != nil {
+			pinuse.Release(d.String(), pins...)
+			return nil, fmt.Errorf("keypad: %v", err)
+		}
+	}
+	return d, nil
+}
+
+// Dev is a matrix keypad.
+//
+// Scan must not be called while Events is used.
+type Dev struct {
+	rows     []gpio.PinIO
+	cols     []gpio.PinIn
+	keys     [][]rune
+	interval time.Duration
+	scans    int
+	diodes   bool
+
+	mu     sync.Mutex
+	down   [][]bool // debounced state
+	cnt    [][]int  // consecutive scans that differ from down
+	ghost  bool
+	closed bool
+	events chan Event
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func (d *Dev) String() string {
+	return fmt.Sprintf("keypad(%dx%d)", len(d.rows), len(d.cols))
+}
+
+// Scan scans the matrix once and returns the keys pressed or released since
+// the previous scan, once debounced.
+func (d *Dev) Scan() ([]Event, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil, errors.New("keypad: closed")
+	}
+	return d.scan()
+}
+
+// Pressed returns the keys currently pressed, as debounced.
+func (d *Dev) Pressed() []Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var out []Event
+	for r, row := range d.down {
+		for c, down := range row {
+			if down {
+				out = append(out, d.event(r, c, true, time.Time{}))
+			}
+		}
+	}
+	return out
+}
+
+// Ghosting returns true if the last scan found keys at the corners of a
+// rectangle, which can't be told apart from ghost keys. These keys keep their
+// state until the rectangle is broken.
+func (d *Dev) Ghosting() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.ghost
+}
+
+// Events returns a channel receiving each key pressed or released.
+//
+// A goroutine scans the matrix every Opts.Interval until Close is called,
+// which closes the channel. Events returns the same channel when called
+// again. The events are dropped if the channel is not drained. The goroutine
+// stops, closing the channel, if reading the pins fails.
+func (d *Dev) Events() <-chan Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.events == nil {
+		d.events = make(chan Event, 16)
+		d.done = make(chan struct{})
+		if d.closed {
+			close(d.events)
+		} else {
+			d.wg.Add(1)
+			go d.run(d.events, d.done)
+		}
+	}
+	return d.events
+}
+
+// Close stops the scanning, leaves the rows floating and releases the pins.
+func (d *Dev) Close() error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.closed = true
+	if d.done != nil {
+		close(d.done)
+	}
+	d.mu.Unlock()
+	d.wg.Wait()
+	var err error
+	for _, r := range d.rows {
+		if err2 := r.In(gpio.Float, gpio.NoEdge); err == nil {
+			err = err2
+		}
+	}
+	pinuse.Release(d.String(), d.pins()...)
+	return err
+}
+
+//
+
+// settle is the time given to the columns to settle after driving a row, as
+// they are only pulled up.
+const settle = 10 * time.Microsecond
+
+func (d *Dev) pins() []pin.Pin {
+	out := make([]pin.Pin, 0, len(d.rows)+len(d.cols))
+	for _, r := range d.rows {
+		out = append(out, r)
+	}
+	for _, c := range d.cols {
+		out = append(out, c)
+	}
+	return out
+}
+
+// run sends the events on c until done is closed.
+func (d *Dev) run(c chan<- Event, done <-chan struct{}) {
+	defer d.wg.Done()
+	defer close(c)
+	t := time.NewTicker(d.interval)
+	defer t.Stop()
+	for {
+		d.mu.Lock()
+		events, err := d.scan()
+		d.mu.Unlock()
+		if err != nil {
+			return
+		}
+		for _, e := range events {
+			select {
+			case c <- e:
+			default:
+			}
+		}
+		select {
+		case <-done:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// scan reads the matrix and debounces it.
+//
+// d.mu must be held.
+func (d *Dev) scan() ([]Event, error) {
+	now := time.Now()
+	raw, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	var ghosts [][]bool
+	if !d.diodes {
+		ghosts = findGhosts(raw)
+	}
+	d.ghost = false
+	var out []Event
+	for r, row := range raw {
+		for c, down := range row {
+			if ghosts != nil && ghosts[r][c] {
+				d.ghost = true
+				d.cnt[r][c] = 0
+				continue
+			}
+			if down == d.down[r][c] {
+				d.cnt[r][c] = 0
+				continue
+			}
+			if d.cnt[r][c]++; d.cnt[r][c] >= d.scans {
+				d.cnt[r][c] = 0
+				d.down[r][c] = down
+				out = append(out, d.event(r, c, down, now))
+			}
+		}
+	}
+	return out, nil
+}
+
+// read drives each row low in turn and returns the keys read as pressed.
+func (d *Dev) read() ([][]bool, error) {
+	raw := newMatrix(len(d.rows), len(d.cols))
+	for r, row := range d.rows {
+		if err := row.Out(gpio.Low); err != nil {
+			return nil, fmt.Errorf("keypad: %v", err)
+		}
+		time.Sleep(settle)
+		for c, col := range d.cols {
+			raw[r][c] = col.Read() == gpio.Low
+		}
+		if err := row.In(gpio.Float, gpio.NoEdge); err != nil {
+			return nil, fmt.Errorf("keypad: %v", err)
+		}
+	}
+	return raw, nil
+}
+
+func (d *Dev) event(r, c int, pressed bool, t time.Time) Event {
+	e := Event{Row: r, Col: c, Pressed: pressed, Time: t}
+	if d.keys[r] != nil {
+		e.Key = d.keys[r][c]
+	}
+	return e
+}
+
+// findGhosts returns the keys at the corners of a rectangle of keys read as
+// pressed, or nil if there is none.
+func findGhosts(raw [][]bool) [][]bool {
+	var ghosts [][]bool
+	for r1 := range raw {
+		for r2 := r1 + 1; r2 < len(raw); r2++ {
+			var common []int
+			for c := range raw[r1] {
+				if raw[r1][c] && raw[r2][c] {
+					common = append(common, c)
+				}
+			}
+			if len(common) < 2 {
+				continue
+			}
+			if ghosts == nil {
+				ghosts = newMatrix(len(raw), len(raw[0]))
+			}
+			for _, c := range common {
+				ghosts[r1][c] = true
+				ghosts[r2][c] = true
+			}
+		}
+	}
+	return ghosts
+}
+
+func newMatrix(rows, cols int) [][]bool {
+	m := make([][]bool, rows)
+	for i := range m {
+		m[i] = make([]bool, cols)
+	}
+	return m
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package keypad
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+)
+
+func TestNew(t *testing.T) {
+	m := newFakeMatrix(4, 3)
+	if _, err := New(&Opts{Rows: m.rows}); err == nil {
+		t.Fatal("no columns")
+	}
+	if _, err := New(&Opts{Rows: m.rows, Cols: m.cols, Keys: []string{"123"}}); err == nil {
+		t.Fatal("short key map")
+	}
+	if _, err := New(&Opts{Rows: m.rows, Cols: m.cols, Keys: []string{"123", "456", "789", "*0"}}); err == nil {
+		t.Fatal("short key map row")
+	}
+	d, err := New(&Opts{Rows: m.rows, Cols: m.cols})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.scans != 2 {
+		t.Fatal(d.scans)
+	}
+	if _, err := New(&Opts{Rows: m.rows, Cols: m.cols}); err == nil {
+		t.Fatal("pins already in use")
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Scan(); err == nil {
+		t.Fatal("closed")
+	}
+	if _, ok := <-d.Events(); ok {
+		t.Fatal("closed")
+	}
+}
+
+func TestDev_Scan(t *testing.T) {
+	m := newFakeMatrix(4, 3)
+	d, err := New(&Opts{Rows: m.rows, Cols: m.cols, Keys: []string{"123", "456", "789", "*0#"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	scan := func(want ...string) {
+		t.Helper()
+		events, err := d.Scan()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, e := range events {
+			got = append(got, e.String())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+	scan()
+	// A press is reported on the second scan.
+	m.keys[1][1] = true
+	scan()
+	scan("'5' pressed")
+	scan()
+	// A bounce is ignored.
+	m.keys[1][1] = false
+	scan()
+	m.keys[1][1] = true
+	scan()
+	// Two keys in the same column.
+	m.keys[3][1] = true
+	scan()
+	scan("'0' pressed")
+	if p := d.Pressed(); len(p) != 2 || p[0].Key != '5' || p[1].Key != '0' {
+		t.Fatal(p)
+	}
+	m.keys[1][1] = false
+	m.keys[3][1] = false
+	scan()
+	scan("'5' released", "'0' released")
+}
+
+func TestDev_Scan_ghosting(t *testing.T) {
+	m := newFakeMatrix(4, 4)
+	d, err := New(&Opts{Rows: m.rows, Cols: m.cols, Debounce: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	m.keys[0][0] = true
+	m.keys[0][2] = true
+	if e, err := d.Scan(); err != nil || len(e) != 2 || d.Ghosting() {
+		t.Fatal(e, err)
+	}
+	// The third corner of a rectangle makes (2,2) read as pressed.
+	m.keys[2][0] = true
+	if raw, _ := d.read(); !raw[2][2] {
+		t.Fatal("the fake matrix should ghost")
+	}
+	if e, err := d.Scan(); err != nil || len(e) != 0 || !d.Ghosting() {
+		t.Fatal(e, err)
+	}
+	m.keys[0][2] = false
+	e, err := d.Scan()
+	if err != nil || d.Ghosting() {
+		t.Fatal(err)
+	}
+	want := []Event{
+		{Row: 0, Col: 2, Pressed: false},
+		{Row: 2, Col: 0, Pressed: true},
+	}
+	for i := range e {
+		e[i].Time = time.Time{}
+	}
+	if !reflect.DeepEqual(e, want) {
+		t.Fatal(e)
+	}
+	if s := e[0].String(); s != "(0,2) released" {
+		t.Fatal(s)
+	}
+}
+
+func TestDev_Scan_diodes(t *testing.T) {
+	m := newFakeMatrix(2, 2)
+	m.diodes = true
+	d, err := New(&Opts{Rows: m.rows, Cols: m.cols, Debounce: -1, Diodes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	m.keys[0][0] = true
+	m.keys[0][1] = true
+	m.keys[1][0] = true
+	m.keys[1][1] = true
+	if e, err := d.Scan(); err != nil || len(e) != 4 || d.Ghosting() {
+		t.Fatal(e, err)
+	}
+}
+
+func TestDev_Events(t *testing.T) {
+	m := newFakeMatrix(1, 2)
+	d, err := New(&Opts{Rows: m.rows, Cols: m.cols, Interval: time.Millisecond, Debounce: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.mu <- struct{}{}
+	m.keys[0][1] = true
+	<-m.mu
+	c := d.Events()
+	if d.Events() != c {
+		t.Fatal("expected the same channel")
+	}
+	e := <-c
+	if e.Row != 0 || e.Col != 1 || !e.Pressed || e.Time.IsZero() {
+		t.Fatal(e)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for range c {
+	}
+	for _, r := range m.rows {
+		if r.(*rowPin).driven {
+			t.Fatal("row left driven")
+		}
+	}
+}
+
+//
+
+// fakeMatrix simulates the keys of a matrix without diodes, where the current
+// flows through any path of pressed keys.
+type fakeMatrix struct {
+	mu     chan struct{}
+	keys   [][]bool
+	diodes bool
+	rows   []gpio.PinIO
+	cols   []gpio.PinIn
+}
+
+func newFakeMatrix(rows, cols int) *fakeMatrix {
+	m := &fakeMatrix{mu: make(chan struct{}, 1), keys: newMatrix(rows, cols)}
+	for i := 0; i < rows; i++ {
+		m.rows = append(m.rows, &rowPin{Pin: gpiotest.Pin{N: fmt.Sprintf("R%d", i), Num: i}})
+	}
+	for i := 0; i < cols; i++ {
+		m.cols = append(m.cols, &colPin{Pin: gpiotest.Pin{N: fmt.Sprintf("C%d", i), Num: rows + i}, m: m, col: i})
+	}
+	return m
+}
+
+// low returns true if the column c is connected to a driven row.
+func (m *fakeMatrix) low(c int) bool {
+	m.mu <- struct{}{}
+	defer func() { <-m.mu }()
+	rows := make([]bool, len(m.rows))
+	cols := make([]bool, len(m.keys[0]))
+	cols[c] = true
+	// Propagate from the column through the pressed keys.
+	for changed := true; changed; {
+		changed = false
+		for r := range m.keys {
+			for k, down := range m.keys[r] {
+				if !down {
+					continue
+				}
+				if cols[k] && !rows[r] {
+					rows[r] = true
+					changed = true
+				}
+				if rows[r] && !cols[k] && !m.diodes {
+					cols[k] = true
+					changed = true
+				}
+			}
+		}
+	}
+	for r, ok := range rows {
+		if ok && m.rows[r].(*rowPin).driven {
+			return true
+		}
+	}
+	return false
+}
+
+type rowPin struct {
+	gpiotest.Pin
+	driven bool
+}
+
+func (r *rowPin) Out(l gpio.Level) error {
+	r.driven = l == gpio.Low
+	return r.Pin.Out(l)
+}
+
+func (r *rowPin) In(pull gpio.Pull, edge gpio.Edge) error {
+	r.driven = false
+	return r.Pin.In(pull, edge)
+}
+
+type colPin struct {
+	gpiotest.Pin
+	m   *fakeMatrix
+	col int
+}
+
+func (c *colPin) Read() gpio.Level {
+	return gpio.Level(!c.m.low(c.col))
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package khadas contains header definitions for the Khadas VIM boards and a
+// driver for their onboard microcontroller.
+//
+// The supported models are the VIM1 (Amlogic S905X), VIM3 (Amlogic A311D),
+// VIM3L (Amlogic S905D3) and VIM4 (Amlogic A311D2).
+//
+// The 40 pins header is registered as "P1", with the pins 1 to 20 in the first
+// row and 21 to 40 in the second row, as numbered on the board silkscreen.
+//
+// The Amlogic GPIO numbering changes between kernel versions, so the header
+// pins are resolved through the GPIO character device from the line names set
+// by the board device tree, e.g. "PIN_37". The positions without a named GPIO
+// line are exposed as basic pins.
+//
+// The VIM3, VIM3L and VIM4 have a microcontroller on the I²C bus that
+// controls the fan and the system LED, see MCU. Only the LED modes are
+// supported, not the LED color. When the kernel khadas-mcu driver is loaded it
+// owns the microcontroller and MCU cannot be used.
+//
+// Physical
+//
+// https://docs.khadas.com/products/sbc/vim1/hardware/gpio-pinout
+//
+// https://docs.khadas.com/products/sbc/vim3/hardware/gpio-pinout
+//
+// https://docs.khadas.com/products/sbc/vim4/hardware/gpio-pinout
+package khadas
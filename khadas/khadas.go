@@ -0,0 +1,203 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package khadas
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/gpioioctl"
+	"github.com/s-mobi01/host/hostcfg"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+)
+
+// Model is a Khadas board model.
+type Model int
+
+// Supported models.
+const (
+	Unknown Model = iota
+	VIM1          // Amlogic S905X
+	VIM3          // Amlogic A311D
+	VIM3L         // Amlogic S905D3
+	VIM4          // Amlogic A311D2
+)
+
+func (m Model) String() string {
+	switch m {
+	case VIM1:
+		return "Khadas VIM1"
+	case VIM3:
+		return "Khadas VIM3"
+	case VIM3L:
+		return "Khadas VIM3L"
+	case VIM4:
+		return "Khadas VIM4"
+	default:
+		return "Unknown"
+	}
+}
+
+// HasMCU returns true if the model has the microcontroller supported by MCU.
+func (m Model) HasMCU() bool {
+	return m == VIM3 || m == VIM3L || m == VIM4
+}
+
+// Present returns true if running on a supported Khadas board.
+func Present() bool {
+	return Detect() != Unknown
+}
+
+// Detect returns the Khadas model the host is running on.
+func Detect() Model {
+	if !isArm {
+		return Unknown
+	}
+	for _, c := range distro.DTCompatible() {
+		switch c {
+		case "khadas,vim":
+			return VIM1
+		case "khadas,vim3":
+			return VIM3
+		case "khadas,vim3l":
+			return VIM3L
+		case "khadas,vim4":
+			return VIM4
+		}
+	}
+	return Unknown
+}
+
+// All the individual pins on the P1 header.
+//
+// They are populated at driver initialization.
+var (
+	P1_1  pin.Pin = pin.INVALID
+	P1_2  pin.Pin = pin.INVALID
+	P1_3  pin.Pin = pin.INVALID
+	P1_4  pin.Pin = pin.INVALID
+	P1_5  pin.Pin = pin.INVALID
+	P1_6  pin.Pin = pin.INVALID
+	P1_7  pin.Pin = pin.INVALID
+	P1_8  pin.Pin = pin.INVALID
+	P1_9  pin.Pin = pin.INVALID
+	P1_10 pin.Pin = pin.INVALID
+	P1_11 pin.Pin = pin.INVALID
+	P1_12 pin.Pin = pin.INVALID
+	P1_13 pin.Pin = pin.INVALID
+	P1_14 pin.Pin = pin.INVALID
+	P1_15 pin.Pin = pin.INVALID
+	P1_16 pin.Pin = pin.INVALID
+	P1_17 pin.Pin = pin.INVALID
+	P1_18 pin.Pin = pin.INVALID
+	P1_19 pin.Pin = pin.INVALID
+	P1_20 pin.Pin = pin.INVALID
+	P1_21 pin.Pin = pin.INVALID
+	P1_22 pin.Pin = pin.INVALID
+	P1_23 pin.Pin = pin.INVALID
+	P1_24 pin.Pin = pin.INVALID
+	P1_25 pin.Pin = pin.INVALID
+	P1_26 pin.Pin = pin.INVALID
+	P1_27 pin.Pin = pin.INVALID
+	P1_28 pin.Pin = pin.INVALID
+	P1_29 pin.Pin = pin.INVALID
+	P1_30 pin.Pin = pin.INVALID
+	P1_31 pin.Pin = pin.INVALID
+	P1_32 pin.Pin = pin.INVALID
+	P1_33 pin.Pin = pin.INVALID
+	P1_34 pin.Pin = pin.INVALID
+	P1_35 pin.Pin = pin.INVALID
+	P1_36 pin.Pin = pin.INVALID
+	P1_37 pin.Pin = pin.INVALID
+	P1_38 pin.Pin = pin.INVALID
+	P1_39 pin.Pin = pin.INVALID
+	P1_40 pin.Pin = pin.INVALID
+)
+
+// lineName matches the GPIO line names used by the Khadas device trees for the
+// header pins, e.g. "PIN_37" or "J4 Header Pin37".
+var lineName = regexp.MustCompile(`(?i)pin[ _]?(\d+)$`)
+
+// headerLines returns the GPIO lines routed to the header, keyed by pin
+// number.
+//
+// Only the lines registered in gpioreg under their own name are returned, so
+// that the header aliases resolve to the right line.
+func headerLines() map[int]pin.Pin {
+	out := map[int]pin.Pin{}
+	for _, c := range gpioioctl.Chips {
+		for _, l := range c.Lines() {
+			m := lineName.FindStringSubmatch(l.LineName())
+			if m == nil {
+				continue
+			}
+			n, err := strconv.Atoi(m[1])
+			if err != nil || n < 1 || n > 40 {
+				continue
+			}
+			if gpioreg.ByName(l.Name()) != l {
+				continue
+			}
+			out[n] = l
+		}
+	}
+	return out
+}
+
+// driver implements periph.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "khadas"
+}
+
+func (d *driver) Prerequisites() []string {
+	return []string{"ioctl-gpio"}
+}
+
+func (d *driver) After() []string {
+	return nil
+}
+
+func (d *driver) Init() (bool, error) {
+	if !Present() {
+		return false, errors.New("Khadas board not detected")
+	}
+	pins := []*pin.Pin{
+		&P1_1, &P1_2, &P1_3, &P1_4, &P1_5, &P1_6, &P1_7, &P1_8, &P1_9, &P1_10,
+		&P1_11, &P1_12, &P1_13, &P1_14, &P1_15, &P1_16, &P1_17, &P1_18, &P1_19, &P1_20,
+		&P1_21, &P1_22, &P1_23, &P1_24, &P1_25, &P1_26, &P1_27, &P1_28, &P1_29, &P1_30,
+		&P1_31, &P1_32, &P1_33, &P1_34, &P1_35, &P1_36, &P1_37, &P1_38, &P1_39, &P1_40,
+	}
+	lines := headerLines()
+	for i, p := range pins {
+		if l, ok := lines[i+1]; ok {
+			*p = l
+		} else {
+			*p = &pin.BasicPin{N: "P1_" + strconv.Itoa(i+1)}
+		}
+	}
+	hdr := [][]pin.Pin{
+		{P1_1, P1_2, P1_3, P1_4, P1_5, P1_6, P1_7, P1_8, P1_9, P1_10, P1_11, P1_12, P1_13, P1_14, P1_15, P1_16, P1_17, P1_18, P1_19, P1_20},
+		{P1_21, P1_22, P1_23, P1_24, P1_25, P1_26, P1_27, P1_28, P1_29, P1_30, P1_31, P1_32, P1_33, P1_34, P1_35, P1_36, P1_37, P1_38, P1_39, P1_40},
+	}
+	if err := pinreg.Register("P1", hdr); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+func init() {
+	if isArm {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
@@ -0,0 +1,134 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package khadas
+
+import (
+	"errors"
+	"fmt"
+
+	"periph.io/x/conn/v3/i2c"
+)
+
+// MCUAddr is the I²C address of the microcontroller.
+const MCUAddr = 0x18
+
+// LEDMode is the behavior of the system LED controlled by the
+// microcontroller.
+type LEDMode uint8
+
+// Supported LED modes.
+const (
+	LEDOff       LEDMode = 0
+	LEDOn        LEDMode = 1
+	LEDBreathe   LEDMode = 2
+	LEDHeartbeat LEDMode = 3
+)
+
+func (l LEDMode) String() string {
+	switch l {
+	case LEDOff:
+		return "Off"
+	case LEDOn:
+		return "On"
+	case LEDBreathe:
+		return "Breathe"
+	case LEDHeartbeat:
+		return "Heartbeat"
+	default:
+		return fmt.Sprintf("LEDMode(%d)", uint8(l))
+	}
+}
+
+// FanMax is the highest fan level.
+const FanMax = 3
+
+// MCU is a handle to the microcontroller found on the VIM3, VIM3L and VIM4.
+//
+// It controls the fan and the system LED.
+type MCU struct {
+	c i2c.Dev
+}
+
+// NewMCU returns a handle to the microcontroller on the I²C bus b.
+//
+// The bus is normally the AO I²C controller, i2c-0 on the Khadas images.
+func NewMCU(b i2c.Bus) (*MCU, error) {
+	m := &MCU{c: i2c.Dev{Bus: b, Addr: MCUAddr}}
+	if _, err := m.Version(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *MCU) String() string {
+	return fmt.Sprintf("khadas-mcu{%s}", &m.c)
+}
+
+// Halt implements conn.Resource.
+//
+// It stops the fan.
+func (m *MCU) Halt() error {
+	return m.SetFan(0)
+}
+
+// Version returns the firmware version of the microcontroller.
+func (m *MCU) Version() (uint16, error) {
+	var b [2]byte
+	if err := m.c.Tx([]byte{mcuVersion0}, b[:]); err != nil {
+		return 0, m.wrap(err)
+	}
+	return uint16(b[0])<<8 | uint16(b[1]), nil
+}
+
+// SetFan sets the fan level, from 0 (off) to FanMax.
+func (m *MCU) SetFan(level int) error {
+	if level < 0 || level > FanMax {
+		return m.wrap(errors.New("invalid fan level"))
+	}
+	return m.write(mcuFanCtrl, uint8(level))
+}
+
+// SetLED sets the behavior of the system LED when the board is running and
+// when it is powered off.
+func (m *MCU) SetLED(running, off LEDMode) error {
+	if running > LEDHeartbeat || off > LEDHeartbeat {
+		return m.wrap(errors.New("invalid LED mode"))
+	}
+	if err := m.write(mcuLEDModeOn, uint8(running)); err != nil {
+		return err
+	}
+	return m.write(mcuLEDModeOff, uint8(off))
+}
+
+// LED returns the behavior of the system LED when the board is running and
+// when it is powered off.
+func (m *MCU) LED() (LEDMode, LEDMode, error) {
+	var b [2]byte
+	if err := m.c.Tx([]byte{mcuLEDModeOn}, b[:]); err != nil {
+		return 0, 0, m.wrap(err)
+	}
+	return LEDMode(b[0]), LEDMode(b[1]), nil
+}
+
+//
+
+// Registers of the microcontroller.
+const (
+	mcuVersion0   = 0x12 // RO, 2 bytes
+	mcuLEDModeOn  = 0x28 // RW
+	mcuLEDModeOff = 0x29 // RW
+	mcuFanCtrl    = 0x88 // WO
+)
+
+func (m *MCU) write(reg, v uint8) error {
+	if err := m.c.Tx([]byte{reg, v}, nil); err != nil {
+		return m.wrap(err)
+	}
+	return nil
+}
+
+func (m *MCU) wrap(err error) error {
+	return fmt.Errorf("khadas-mcu: %v", err)
+}
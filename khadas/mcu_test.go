@@ -0,0 +1,57 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package khadas
+
+import (
+	"testing"
+
+	"periph.io/x/conn/v3/i2c/i2ctest"
+)
+
+func TestMCU(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: []i2ctest.IO{
+			{Addr: MCUAddr, W: []byte{0x12}, R: []byte{0x01, 0x05}},
+			{Addr: MCUAddr, W: []byte{0x88, 0x02}},
+			{Addr: MCUAddr, W: []byte{0x28, 0x03}},
+			{Addr: MCUAddr, W: []byte{0x29, 0x00}},
+			{Addr: MCUAddr, W: []byte{0x28}, R: []byte{0x03, 0x00}},
+			{Addr: MCUAddr, W: []byte{0x88, 0x00}},
+		},
+	}
+	m, err := NewMCU(&bus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetFan(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetFan(FanMax + 1); err == nil {
+		t.Fatal("expected invalid fan level")
+	}
+	if err := m.SetLED(LEDHeartbeat, LEDOff); err != nil {
+		t.Fatal(err)
+	}
+	running, off, err := m.LED()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if running != LEDHeartbeat || off != LEDOff {
+		t.Fatalf("%s %s", running, off)
+	}
+	if err := m.Halt(); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewMCU_err(t *testing.T) {
+	bus := i2ctest.Playback{DontPanic: true}
+	if _, err := NewMCU(&bus); err == nil {
+		t.Fatal("expected error")
+	}
+}
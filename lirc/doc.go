@@ -0,0 +1,21 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package lirc implements raw infrared receive and transmit through the Linux
+// LIRC character devices /dev/lircN.
+//
+// The devices are created by the rc-core drivers, e.g. gpio-ir-recv and
+// gpio-ir-tx or pwm-ir-tx on a Raspberry Pi with the matching device tree
+// overlays, or by USB receivers. Received signals are returned as a stream
+// of pulses and spaces with their duration (mode2); the protocol decoding is
+// left to the application. Signals are transmitted as a train of pulses and
+// spaces modulated by a configurable carrier.
+//
+// The in-kernel protocol decoders do not interfere with the raw stream, but
+// the decoded key presses go to the input subsystem, not to this package.
+//
+// Reference
+//
+// https://www.kernel.org/doc/html/latest/userspace-api/media/rc/lirc-dev.html
+package lirc
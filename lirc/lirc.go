@@ -0,0 +1,352 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package lirc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+// Enumerate returns the LIRC devices N as in /dev/lircN.
+func Enumerate() ([]int, error) {
+	const prefix = "/dev/lirc"
+	items, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int, 0, len(items))
+	for _, item := range items {
+		i, err := strconv.Atoi(item[len(prefix):])
+		if err != nil {
+			continue
+		}
+		out = append(out, i)
+	}
+	sort.Ints(out)
+	return out, nil
+}
+
+// Open opens the LIRC device /dev/lirc<n>.
+//
+// The receiver is put in mode2 and the transmitter in pulse mode, when
+// supported.
+func Open(n int) (*Dev, error) {
+	if !isLinux {
+		return nil, errors.New("lirc: is not supported on this platform")
+	}
+	name := fmt.Sprintf("lirc%d", n)
+	// The file is left non-blocking so that Read can be interrupted by Close
+	// and SetReadDeadline.
+	f, err := os.OpenFile("/dev/"+name, os.O_RDWR, 0)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("lirc: need more access, try as root or add the user to the video group: %v", err)
+		}
+		return nil, fmt.Errorf("lirc: %v", err)
+	}
+	d := &Dev{name: name, f: f}
+	if err := d.init(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// Kind is the kind of a Sample.
+type Kind uint8
+
+// Kinds of samples.
+const (
+	// Space is a period without IR light.
+	Space Kind = iota
+	// Pulse is a period with the IR light modulated by the carrier.
+	Pulse
+	// Frequency is the carrier frequency measured by the receiver, reported
+	// when enabled with SetMeasureCarrier.
+	Frequency
+	// Timeout is reported after a space longer than the receive timeout, at
+	// the end of a message.
+	Timeout
+	// Overflow is reported when the receiver lost samples.
+	Overflow
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Space:
+		return "Space"
+	case Pulse:
+		return "Pulse"
+	case Frequency:
+		return "Frequency"
+	case Timeout:
+		return "Timeout"
+	case Overflow:
+		return "Overflow"
+	default:
+		return fmt.Sprintf("Kind(%d)", k)
+	}
+}
+
+// Sample is an element of a received IR stream.
+type Sample struct {
+	Kind Kind
+	// Duration is the duration of a Pulse, Space or Timeout.
+	Duration time.Duration
+	// Carrier is the measured frequency of a Frequency sample.
+	Carrier physic.Frequency
+}
+
+func (s Sample) String() string {
+	if s.Kind == Frequency {
+		return fmt.Sprintf("%s %s", s.Kind, s.Carrier)
+	}
+	return fmt.Sprintf("%s %s", s.Kind, s.Duration)
+}
+
+// Dev is an open LIRC device.
+type Dev struct {
+	name     string
+	f        *os.File
+	features uint32
+	buf      []uint32
+}
+
+func (d *Dev) String() string {
+	return d.name
+}
+
+// Close closes the device, interrupting a pending Read.
+func (d *Dev) Close() error {
+	return d.f.Close()
+}
+
+// CanReceive returns true if the device can receive raw IR.
+func (d *Dev) CanReceive() bool {
+	return d.features&canRecMode2 != 0
+}
+
+// CanSend returns true if the device can send raw IR.
+func (d *Dev) CanSend() bool {
+	return d.features&canSendPulse != 0
+}
+
+// Read reads the received samples into s and returns the number of samples
+// read. It blocks until at least one sample is available.
+//
+// A message is usually terminated by a Timeout sample.
+func (d *Dev) Read(s []Sample) (int, error) {
+	if !d.CanReceive() {
+		return 0, errors.New("lirc: device can't receive")
+	}
+	if len(s) == 0 {
+		return 0, nil
+	}
+	if cap(d.buf) < len(s) {
+		d.buf = make([]uint32, len(s))
+	}
+	buf := d.buf[:len(s)]
+	n, err := d.f.Read(u32Bytes(buf))
+	n /= 4
+	for i := 0; i < n; i++ {
+		s[i] = decode(buf[i])
+	}
+	return n, err
+}
+
+// SetReadDeadline sets the deadline of Read. A zero value means Read doesn't
+// time out.
+func (d *Dev) SetReadDeadline(t time.Time) error {
+	return d.f.SetReadDeadline(t)
+}
+
+// Send transmits the pulse train p, which alternates pulses and spaces and
+// starts with a pulse. The last space can be omitted. It blocks until the
+// signal is sent.
+func (d *Dev) Send(p []time.Duration) error {
+	if !d.CanSend() {
+		return errors.New("lirc: device can't send")
+	}
+	if len(p)%2 == 0 {
+		// The kernel wants an odd number of values, ending with a pulse.
+		p = p[:len(p)-1]
+	}
+	if len(p) == 0 {
+		return nil
+	}
+	buf := make([]uint32, len(p))
+	for i, v := range p {
+		us, err := toMicroseconds(v)
+		if err != nil {
+			return err
+		}
+		buf[i] = us
+	}
+	if _, err := d.f.Write(u32Bytes(buf)); err != nil {
+		return fmt.Errorf("lirc: %v", err)
+	}
+	return nil
+}
+
+// SetCarrier sets the carrier frequency used by Send, usually 36kHz to
+// 40kHz.
+func (d *Dev) SetCarrier(f physic.Frequency) error {
+	if d.features&canSetSendCarrier == 0 {
+		return errors.New("lirc: device can't set the carrier")
+	}
+	if f < physic.KiloHertz || f > physic.MegaHertz {
+		return fmt.Errorf("lirc: invalid carrier %s", f)
+	}
+	return d.set(setSendCarrier, uint32(f/physic.Hertz))
+}
+
+// SetDutyCycle sets the duty cycle of the carrier used by Send.
+func (d *Dev) SetDutyCycle(duty gpio.Duty) error {
+	if d.features&canSetSendDutyCycle == 0 {
+		return errors.New("lirc: device can't set the duty cycle")
+	}
+	pct := uint32((int64(duty)*100 + int64(gpio.DutyMax)/2) / int64(gpio.DutyMax))
+	if pct < 1 || pct > 99 {
+		return fmt.Errorf("lirc: invalid duty cycle %s", duty)
+	}
+	return d.set(setSendDutyCycle, pct)
+}
+
+// SetTransmitters selects the transmitters used by Send as a bitmask, for
+// devices with multiple outputs.
+func (d *Dev) SetTransmitters(mask uint32) error {
+	if d.features&canSetTransmitterMask == 0 {
+		return errors.New("lirc: device can't select the transmitters")
+	}
+	return d.set(setTransmitterMask, mask)
+}
+
+// SetReceiveTimeout sets the duration of the space after which a Timeout
+// sample is reported and the message is considered complete.
+func (d *Dev) SetReceiveTimeout(t time.Duration) error {
+	if d.features&canSetRecTimeout == 0 {
+		return errors.New("lirc: device can't set the timeout")
+	}
+	us, err := toMicroseconds(t)
+	if err != nil {
+		return err
+	}
+	return d.set(setRecTimeout, us)
+}
+
+// SetMeasureCarrier enables reporting Frequency samples.
+func (d *Dev) SetMeasureCarrier(on bool) error {
+	if d.features&canMeasureCarrier == 0 {
+		return errors.New("lirc: device can't measure the carrier")
+	}
+	var v uint32
+	if on {
+		v = 1
+	}
+	return d.set(setMeasureCarrierMode, v)
+}
+
+//
+
+// Features, modes and ioctls from include/uapi/linux/lirc.h.
+const (
+	modePulse = 0x00000002
+	modeMode2 = 0x00000004
+
+	canSendPulse          = modePulse
+	canSetSendCarrier     = 0x00000100
+	canSetSendDutyCycle   = 0x00000200
+	canSetTransmitterMask = 0x00000400
+	canRecMode2           = modeMode2 << 16
+	canMeasureCarrier     = 0x02000000
+	canSetRecTimeout      = 0x10000000
+
+	mode2Space     = 0x00000000
+	mode2Pulse     = 0x01000000
+	mode2Frequency = 0x02000000
+	mode2Timeout   = 0x03000000
+	mode2Overflow  = 0x04000000
+	mode2Mask      = 0xFF000000
+	mode2Value     = 0x00FFFFFF
+)
+
+var (
+	getFeatures           = fs.IOR('i', 0x00, 4)
+	setSendMode           = fs.IOW('i', 0x11, 4)
+	setRecMode            = fs.IOW('i', 0x12, 4)
+	setSendCarrier        = fs.IOW('i', 0x13, 4)
+	setSendDutyCycle      = fs.IOW('i', 0x15, 4)
+	setTransmitterMask    = fs.IOW('i', 0x17, 4)
+	setRecTimeout         = fs.IOW('i', 0x18, 4)
+	setMeasureCarrierMode = fs.IOW('i', 0x1D, 4)
+)
+
+func (d *Dev) init() error {
+	if err := ioctl(d.f, getFeatures, &d.features); err != nil {
+		return fmt.Errorf("lirc: %s is not a LIRC device: %v", d.name, err)
+	}
+	if d.CanReceive() {
+		if err := d.set(setRecMode, modeMode2); err != nil {
+			return err
+		}
+	}
+	if d.CanSend() {
+		if err := d.set(setSendMode, modePulse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dev) set(op uint, v uint32) error {
+	if err := ioctl(d.f, op, &v); err != nil {
+		return fmt.Errorf("lirc: %v", err)
+	}
+	return nil
+}
+
+// decode decodes a mode2 value.
+func decode(v uint32) Sample {
+	val := v & mode2Value
+	switch v & mode2Mask {
+	case mode2Pulse:
+		return Sample{Kind: Pulse, Duration: time.Duration(val) * time.Microsecond}
+	case mode2Frequency:
+		return Sample{Kind: Frequency, Carrier: physic.Frequency(val) * physic.Hertz}
+	case mode2Timeout:
+		return Sample{Kind: Timeout, Duration: time.Duration(val) * time.Microsecond}
+	case mode2Overflow:
+		return Sample{Kind: Overflow}
+	default:
+		return Sample{Kind: Space, Duration: time.Duration(val) * time.Microsecond}
+	}
+}
+
+func toMicroseconds(t time.Duration) (uint32, error) {
+	us := (t + time.Microsecond/2) / time.Microsecond
+	if us <= 0 || us > mode2Value {
+		return 0, fmt.Errorf("lirc: invalid duration %s", t)
+	}
+	return uint32(us), nil
+}
+
+// u32Bytes returns the memory of b as bytes, in native endianness as
+// expected by the kernel.
+func u32Bytes(b []uint32) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return (*[1 << 28]byte)(unsafe.Pointer(&b[0]))[: 4*len(b) : 4*len(b)]
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package lirc
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const isLinux = true
+
+// ioctl runs the ioctl op on f with a pointer to v.
+//
+// It doesn't use f.Fd() as it would switch the file to blocking mode.
+func ioctl(f *os.File, op uint, v *uint32) error {
+	c, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var errno syscall.Errno
+	if err := c.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(op), uintptr(unsafe.Pointer(v)))
+	}); err != nil {
+		return err
+	}
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package lirc
+
+import (
+	"errors"
+	"os"
+)
+
+const isLinux = false
+
+func ioctl(f *os.File, op uint, v *uint32) error {
+	return errors.New("unreachable code")
+}
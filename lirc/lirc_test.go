@@ -0,0 +1,102 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package lirc
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestDecode(t *testing.T) {
+	data := []struct {
+		v    uint32
+		want Sample
+	}{
+		{0x01002328, Sample{Kind: Pulse, Duration: 9 * time.Millisecond}},
+		{0x00001194, Sample{Kind: Space, Duration: 4500 * time.Microsecond}},
+		{0x02009470, Sample{Kind: Frequency, Carrier: 38 * physic.KiloHertz}},
+		{0x0301ADB0, Sample{Kind: Timeout, Duration: 110 * time.Millisecond}},
+		{0x04000000, Sample{Kind: Overflow}},
+	}
+	for i, line := range data {
+		if got := decode(line.v); got != line.want {
+			t.Fatalf("#%d: decode(%#x) = %s, want %s", i, line.v, got, line.want)
+		}
+	}
+	if s := decode(0x01000230).String(); s != "Pulse 560µs" {
+		t.Fatal(s)
+	}
+	if s := Kind(10).String(); s != "Kind(10)" {
+		t.Fatal(s)
+	}
+}
+
+func TestDev_Read(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	d := &Dev{name: "lirc0", f: r, features: canRecMode2}
+	defer d.Close()
+	if _, err := w.Write(u32Bytes([]uint32{0x01000230, 0x00000230, 0x03010000})); err != nil {
+		t.Fatal(err)
+	}
+	s := make([]Sample, 4)
+	n, err := d.Read(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Sample{
+		{Kind: Pulse, Duration: 560 * time.Microsecond},
+		{Kind: Space, Duration: 560 * time.Microsecond},
+		{Kind: Timeout, Duration: 65536 * time.Microsecond},
+	}
+	if !reflect.DeepEqual(s[:n], want) {
+		t.Fatalf("Read() = %v", s[:n])
+	}
+	if err := d.Send([]time.Duration{time.Millisecond}); err == nil {
+		t.Fatal("can't send")
+	}
+}
+
+func TestDev_Send(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	d := &Dev{name: "lirc0", f: w, features: canSendPulse}
+	defer d.Close()
+	// The trailing space is dropped.
+	p := []time.Duration{9 * time.Millisecond, 4500 * time.Microsecond, 560 * time.Microsecond, 40 * time.Millisecond}
+	if err := d.Send(p); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]uint32, 4)
+	n, err := r.Read(u32Bytes(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint32{9000, 4500, 560}; !reflect.DeepEqual(got[:n/4], want) {
+		t.Fatalf("sent %v", got[:n/4])
+	}
+	if err := d.Send([]time.Duration{-time.Millisecond}); err == nil {
+		t.Fatal("invalid duration")
+	}
+	if err := d.Send([]time.Duration{20 * time.Second}); err == nil {
+		t.Fatal("duration too long")
+	}
+	if _, err := d.Read(make([]Sample, 1)); err == nil {
+		t.Fatal("can't receive")
+	}
+	if err := d.SetCarrier(38 * physic.KiloHertz); err == nil {
+		t.Fatal("can't set the carrier")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package mdio
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/s-mobi01/host/pmem"
+)
+
+// Physical base addresses of the Davinci MDIO controllers.
+const (
+	AM335xMDIO = 0x4A101000 // CPSW MDIO
+	AM62xMDIO  = 0x08000F00 // CPSW3G MDIO
+)
+
+// Davinci is the MDIO controller found in the TI Davinci, Sitara and K3
+// SoCs.
+//
+// The controller must already be enabled and clocked, which the kernel
+// davinci_mdio driver does when the Ethernet switch is enabled in the device
+// tree. Davinci uses the user access register 0; accesses are serialized
+// within the process but not with the kernel.
+type Davinci struct {
+	base uint64
+
+	mu sync.Mutex
+	r  *davinciRegs
+}
+
+// NewDavinci maps the Davinci MDIO controller at the physical address base,
+// e.g. AM335xMDIO.
+//
+// This function requires access to /dev/mem, so it normally requires running
+// as root.
+func NewDavinci(base uint64) (*Davinci, error) {
+	d := &Davinci{base: base}
+	if err := pmem.MapAsPOD(base, &d.r); err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("mdio: need more access, try as root: %v", err)
+		}
+		return nil, fmt.Errorf("mdio: %v", err)
+	}
+	if d.r.control&davinciEnable == 0 {
+		return nil, errors.New("mdio: controller is disabled")
+	}
+	return d, nil
+}
+
+func (d *Davinci) String() string {
+	return fmt.Sprintf("davinci-mdio@%x", d.base)
+}
+
+// Alive returns the PHYs that answered the controller's periodic polling, as
+// a bitmask indexed by PHY address.
+func (d *Davinci) Alive() uint32 {
+	return d.r.alive
+}
+
+// Link returns the PHYs with a link as reported by the controller's periodic
+// polling, as a bitmask indexed by PHY address.
+func (d *Davinci) Link() uint32 {
+	return d.r.link
+}
+
+// Read implements Bus.
+func (d *Davinci) Read(phy, reg uint8) (uint16, error) {
+	if err := checkAddr(phy, reg); err != nil {
+		return 0, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.wait(); err != nil {
+		return 0, err
+	}
+	d.r.userAccess0 = davinciGo | uint32(reg)<<21 | uint32(phy)<<16
+	if err := d.wait(); err != nil {
+		return 0, err
+	}
+	v := d.r.userAccess0
+	if v&davinciAck == 0 {
+		return 0, fmt.Errorf("mdio: PHY %d did not acknowledge", phy)
+	}
+	return uint16(v), nil
+}
+
+// Write implements Bus.
+func (d *Davinci) Write(phy, reg uint8, v uint16) error {
+	if err := checkAddr(phy, reg); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.wait(); err != nil {
+		return err
+	}
+	d.r.userAccess0 = davinciGo | davinciWrite | uint32(reg)<<21 | uint32(phy)<<16 | uint32(v)
+	return d.wait()
+}
+
+//
+
+// Bits of the Davinci MDIO registers.
+const (
+	davinciEnable = 1 << 30 // CONTROL
+	davinciGo     = 1 << 31 // USERACCESS
+	davinciWrite  = 1 << 30 // USERACCESS
+	davinciAck    = 1 << 29 // USERACCESS
+)
+
+// davinciRegs is the register map of the Davinci MDIO controller.
+type davinciRegs struct {
+	version     uint32     // 0x00 MDIO_VER
+	control     uint32     // 0x04 MDIO_CONTROL
+	alive       uint32     // 0x08 MDIO_ALIVE
+	link        uint32     // 0x0C MDIO_LINK
+	_           [28]uint32 // 0x10-0x7C
+	userAccess0 uint32     // 0x80 MDIO_USERACCESS0
+	userPhySel0 uint32     // 0x84 MDIO_USERPHYSEL0
+}
+
+// wait waits for the previous access to complete. A frame takes 64 MDC
+// cycles, 25.6µs at 2.5MHz.
+func (d *Davinci) wait() error {
+	for start := time.Now(); d.r.userAccess0&davinciGo != 0; {
+		if time.Since(start) > 10*time.Millisecond {
+			return errors.New("mdio: timed out waiting for the controller")
+		}
+	}
+	return nil
+}
+
+var _ Bus = &Davinci{}
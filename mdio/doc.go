@@ -0,0 +1,24 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package mdio implements access to the MDIO bus (also known as SMI) used to
+// manage Ethernet PHYs and switches.
+//
+// Two implementations of Bus are provided. NetBus goes through the
+// SIOCGMIIREG and SIOCSMIIREG ioctls of a network interface and works with
+// any Ethernet driver using phylib; the interface usually has to be up.
+// Davinci drives the MDIO controller of the TI SoCs (AM335x, AM62x) directly
+// through its registers, which requires access to /dev/mem.
+//
+// Only clause 22 accesses are supported.
+//
+// Writing to the PHY registers while the kernel manages the PHY can confuse
+// its state machine; reading is safe.
+//
+// Reference
+//
+// IEEE 802.3 clause 22.2.4, Management functions.
+//
+// https://www.kernel.org/doc/html/latest/networking/phy.html
+package mdio
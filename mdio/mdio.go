@@ -0,0 +1,91 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package mdio
+
+import "errors"
+
+// Bus is an MDIO bus.
+type Bus interface {
+	String() string
+	// Read reads the register reg of the PHY at address phy.
+	Read(phy, reg uint8) (uint16, error)
+	// Write writes v to the register reg of the PHY at address phy.
+	Write(phy, reg uint8, v uint16) error
+}
+
+// Standard registers defined by IEEE 802.3 clause 22.
+const (
+	BMCR      = 0x00 // Basic mode control
+	BMSR      = 0x01 // Basic mode status
+	PHYSID1   = 0x02 // PHY identifier, OUI bits 3-18
+	PHYSID2   = 0x03 // PHY identifier, OUI bits 19-24, model and revision
+	ADVERTISE = 0x04 // Auto-negotiation advertisement
+	LPA       = 0x05 // Link partner ability
+	CTRL1000  = 0x09 // 1000BASE-T control
+	STAT1000  = 0x0A // 1000BASE-T status
+)
+
+// Bits of the BMCR register.
+const (
+	BMCRReset     = 1 << 15
+	BMCRLoopback  = 1 << 14
+	BMCRANEnable  = 1 << 12
+	BMCRPowerDown = 1 << 11
+	BMCRANRestart = 1 << 9
+)
+
+// Bits of the BMSR register.
+const (
+	BMSRANComplete = 1 << 5
+	BMSRLinkStatus = 1 << 2
+)
+
+// MaxAddr is the highest PHY address and the highest register number.
+const MaxAddr = 31
+
+// PHYID returns the 32 bits identifier of the PHY at address phy.
+//
+// The identifier is 0xFFFFFFFF when no PHY answers.
+func PHYID(b Bus, phy uint8) (uint32, error) {
+	hi, err := b.Read(phy, PHYSID1)
+	if err != nil {
+		return 0, err
+	}
+	lo, err := b.Read(phy, PHYSID2)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(hi)<<16 | uint32(lo), nil
+}
+
+// Scan returns the addresses of the PHYs answering on the bus.
+//
+// A PHY is considered present when its identifier is neither all zeros nor
+// all ones.
+func Scan(b Bus) []uint8 {
+	var out []uint8
+	for phy := uint8(0); phy <= MaxAddr; phy++ {
+		id, err := PHYID(b, phy)
+		if err != nil {
+			// Some buses return an error for an absent PHY.
+			continue
+		}
+		if id != 0 && id != 0xFFFFFFFF {
+			out = append(out, phy)
+		}
+	}
+	return out
+}
+
+//
+
+var errAddr = errors.New("mdio: PHY address and register must be at most 31")
+
+func checkAddr(phy, reg uint8) error {
+	if phy > MaxAddr || reg > MaxAddr {
+		return errAddr
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package mdio
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// ioctlSocket is a datagram socket used to send ioctls to network interfaces.
+type ioctlSocket struct {
+	fd int
+}
+
+func newIoctlSocket() (*ioctlSocket, error) {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &ioctlSocket{fd: fd}, nil
+}
+
+func (s *ioctlSocket) ioctl(op uint, r *ifreq) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(s.fd), uintptr(op), uintptr(unsafe.Pointer(r))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func (s *ioctlSocket) close() error {
+	return syscall.Close(s.fd)
+}
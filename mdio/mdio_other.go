@@ -0,0 +1,24 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package mdio
+
+import "errors"
+
+type ioctlSocket struct{}
+
+func newIoctlSocket() (*ioctlSocket, error) {
+	return nil, errors.New("network interface ioctls are not supported")
+}
+
+func (s *ioctlSocket) ioctl(op uint, r *ifreq) error {
+	return errors.New("not implemented")
+}
+
+func (s *ioctlSocket) close() error {
+	return nil
+}
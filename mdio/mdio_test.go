@@ -0,0 +1,93 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package mdio
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func TestPHYID(t *testing.T) {
+	b := &fakeBus{regs: map[uint8]map[uint8]uint16{1: {PHYSID1: 0x0022, PHYSID2: 0x1622}}}
+	id, err := PHYID(b, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 0x00221622 {
+		t.Fatalf("PHYID() = %#x", id)
+	}
+}
+
+func TestScan(t *testing.T) {
+	b := &fakeBus{
+		regs: map[uint8]map[uint8]uint16{
+			0: {PHYSID1: 0x0022, PHYSID2: 0x1622},
+			4: {PHYSID1: 0x001C, PHYSID2: 0xC916},
+			5: {PHYSID1: 0x0000, PHYSID2: 0x0000},
+		},
+		// Absent PHYs read as all ones, except 7 which returns an error.
+		missing: 0xFFFF,
+		errPHY:  7,
+	}
+	if got, want := Scan(b), []uint8{0, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Scan() = %v, want %v", got, want)
+	}
+}
+
+func TestNetBus_invalid(t *testing.T) {
+	if _, err := Open(""); err == nil {
+		t.Fatal("empty name")
+	}
+	if _, err := Open("0123456789abcdef"); err == nil {
+		t.Fatal("name too long")
+	}
+	n := &NetBus{name: "eth0"}
+	if _, err := n.Read(32, 0); err == nil {
+		t.Fatal("invalid PHY address")
+	}
+	if err := n.Write(0, 32, 0); err == nil {
+		t.Fatal("invalid register")
+	}
+	if _, err := n.Read(0, 0); err == nil {
+		t.Fatal("closed bus")
+	}
+}
+
+func TestSizes(t *testing.T) {
+	if s := unsafe.Sizeof(ifreq{}); s != 40 {
+		t.Fatalf("ifreq is %d bytes", s)
+	}
+	if s := unsafe.Offsetof(davinciRegs{}.userAccess0); s != 0x80 {
+		t.Fatalf("userAccess0 is at 0x%x", s)
+	}
+}
+
+//
+
+type fakeBus struct {
+	regs    map[uint8]map[uint8]uint16
+	missing uint16
+	errPHY  uint8
+}
+
+func (f *fakeBus) String() string {
+	return "fake"
+}
+
+func (f *fakeBus) Read(phy, reg uint8) (uint16, error) {
+	if phy == f.errPHY {
+		return 0, errors.New("no PHY")
+	}
+	if r, ok := f.regs[phy]; ok {
+		return r[reg], nil
+	}
+	return f.missing, nil
+}
+
+func (f *fakeBus) Write(phy, reg uint8, v uint16) error {
+	return errors.New("read only")
+}
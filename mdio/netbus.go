@@ -0,0 +1,127 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package mdio
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// NetBus is the MDIO bus of a network interface, accessed through the
+// SIOCGMIIREG and SIOCSMIIREG ioctls.
+type NetBus struct {
+	name string
+
+	mu sync.Mutex
+	s  *ioctlSocket
+}
+
+// Open returns the MDIO bus of the network interface ifname, e.g. "eth0".
+//
+// Accessing the PHYs requires the CAP_NET_ADMIN capability, so it normally
+// requires running as root.
+func Open(ifname string) (*NetBus, error) {
+	if len(ifname) == 0 || len(ifname) >= ifNameSize {
+		return nil, errors.New("mdio: invalid interface name")
+	}
+	s, err := newIoctlSocket()
+	if err != nil {
+		return nil, fmt.Errorf("mdio: %v", err)
+	}
+	return &NetBus{name: ifname, s: s}, nil
+}
+
+// Close closes the socket used for the ioctls.
+func (n *NetBus) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.s == nil {
+		return nil
+	}
+	err := n.s.close()
+	n.s = nil
+	return err
+}
+
+func (n *NetBus) String() string {
+	return n.name
+}
+
+// PHY returns the address of the PHY attached to the network interface.
+func (n *NetBus) PHY() (uint8, error) {
+	var d miiData
+	if err := n.ioctl(siocGMIIPHY, &d); err != nil {
+		return 0, err
+	}
+	return uint8(d.phyID), nil
+}
+
+// Read implements Bus.
+func (n *NetBus) Read(phy, reg uint8) (uint16, error) {
+	if err := checkAddr(phy, reg); err != nil {
+		return 0, err
+	}
+	d := miiData{phyID: uint16(phy), regNum: uint16(reg)}
+	if err := n.ioctl(siocGMIIREG, &d); err != nil {
+		return 0, err
+	}
+	return d.valOut, nil
+}
+
+// Write implements Bus.
+func (n *NetBus) Write(phy, reg uint8, v uint16) error {
+	if err := checkAddr(phy, reg); err != nil {
+		return err
+	}
+	d := miiData{phyID: uint16(phy), regNum: uint16(reg), valIn: v}
+	return n.ioctl(siocSMIIREG, &d)
+}
+
+//
+
+// Socket ioctls from include/uapi/linux/sockios.h.
+const (
+	siocGMIIPHY = 0x8947
+	siocGMIIREG = 0x8948
+	siocSMIIREG = 0x8949
+)
+
+// ifNameSize is IFNAMSIZ.
+const ifNameSize = 16
+
+// miiData is struct mii_ioctl_data from include/uapi/linux/mii.h.
+type miiData struct {
+	phyID  uint16
+	regNum uint16
+	valIn  uint16
+	valOut uint16
+}
+
+// ifreq is struct ifreq with the mii_ioctl_data in the union. The union is
+// padded to its largest size on 64 bits platforms.
+type ifreq struct {
+	name [ifNameSize]byte
+	mii  miiData
+	_    [16]byte
+}
+
+func (n *NetBus) ioctl(op uint, d *miiData) error {
+	var r ifreq
+	copy(r.name[:], n.name)
+	r.mii = *d
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.s == nil {
+		return errors.New("mdio: bus closed")
+	}
+	if err := n.s.ioctl(op, &r); err != nil {
+		return fmt.Errorf("mdio: %s: %v", n.name, err)
+	}
+	*d = r.mii
+	return nil
+}
+
+var _ Bus = &NetBus{}
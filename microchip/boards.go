@@ -0,0 +1,54 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package microchip
+
+import (
+	"github.com/s-mobi01/host/distro"
+)
+
+// boards lists the on-board LEDs and buttons of the Microchip evaluation
+// boards, keyed by device tree compatible string.
+//
+// The mapping comes from the board device tree in the Linux kernel,
+// arch/arm/boot/dts/microchip/ and arch/riscv/boot/dts/microchip/.
+var boards = map[string]map[string]string{
+	// SAMA5D2 Xplained Ultra. The LEDs are active low.
+	"atmel,sama5d2-xplained": {
+		"LED_RED":     "PB6",
+		"LED_GREEN":   "PB5",
+		"LED_BLUE":    "PB0",
+		"BUTTON_USER": "PB9",
+	},
+	// SAMA5D3 Xplained.
+	"atmel,sama5d3-xplained": {
+		"LED_D2":      "PE23",
+		"LED_D3":      "PE24",
+		"BUTTON_USER": "PE29",
+	},
+	// SAM9X60-EK.
+	"microchip,sam9x60ek": {
+		"LED_RED":     "PB11",
+		"LED_GREEN":   "PB12",
+		"LED_BLUE":    "PB13",
+		"BUTTON_USER": "PD18",
+	},
+	// PolarFire SoC Icicle Kit.
+	"microchip,mpfs-icicle-kit": {
+		"LED1": "GPIO2_16",
+		"LED2": "GPIO2_17",
+		"LED3": "GPIO2_18",
+		"LED4": "GPIO2_19",
+	},
+}
+
+// boardAliases returns the aliases of the detected board, if any.
+func boardAliases() map[string]string {
+	for _, c := range distro.DTCompatible() {
+		if a, ok := boards[c]; ok {
+			return a
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package microchip
+
+import (
+	"sync"
+
+	"github.com/s-mobi01/host/distro"
+)
+
+// Present detects whether the host CPU is a supported Microchip CPU.
+func Present() bool {
+	detection.do()
+	return detection.isMicrochip
+}
+
+// IsSAM9X5 detects whether the host CPU is a Microchip SAM9X5 (SAM9G25,
+// SAM9G35, SAM9X25, SAM9X35) or SAM9X60 CPU.
+//
+// It looks for the string "atmel,at91sam9x5" or "microchip,sam9x60" in
+// /proc/device-tree/compatible.
+func IsSAM9X5() bool {
+	detection.do()
+	return detection.isSAM9X5
+}
+
+// IsSAMA5D2 detects whether the host CPU is a Microchip SAMA5D2 CPU.
+//
+// It looks for the string "atmel,sama5d2" in /proc/device-tree/compatible.
+func IsSAMA5D2() bool {
+	detection.do()
+	return detection.isSAMA5D2
+}
+
+// IsSAMA5D3 detects whether the host CPU is a Microchip SAMA5D3 CPU.
+//
+// It looks for the string "atmel,sama5d3" in /proc/device-tree/compatible.
+func IsSAMA5D3() bool {
+	detection.do()
+	return detection.isSAMA5D3
+}
+
+// IsSAMA5D4 detects whether the host CPU is a Microchip SAMA5D4 CPU.
+//
+// It looks for the string "atmel,sama5d4" in /proc/device-tree/compatible.
+func IsSAMA5D4() bool {
+	detection.do()
+	return detection.isSAMA5D4
+}
+
+// IsPolarFire detects whether the host CPU is a Microchip PolarFire SoC.
+//
+// It looks for the string "microchip,mpfs" in /proc/device-tree/compatible.
+func IsPolarFire() bool {
+	detection.do()
+	return detection.isPolarFire
+}
+
+//
+
+type detectionS struct {
+	mu          sync.Mutex
+	done        bool
+	isMicrochip bool
+	isSAM9X5    bool
+	isSAMA5D2   bool
+	isSAMA5D3   bool
+	isSAMA5D4   bool
+	isPolarFire bool
+}
+
+var detection detectionS
+
+// do contains the CPU detection logic that determines whether we have a
+// Microchip CPU and if so, which family.
+func (d *detectionS) do() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.done {
+		d.done = true
+		if isArm || isRISCV {
+			for _, c := range distro.DTCompatible() {
+				switch c {
+				case "atmel,at91sam9x5", "microchip,sam9x60":
+					d.isSAM9X5 = isArm
+				case "atmel,sama5d2":
+					d.isSAMA5D2 = isArm
+				case "atmel,sama5d3":
+					d.isSAMA5D3 = isArm
+				case "atmel,sama5d4":
+					d.isSAMA5D4 = isArm
+				case "microchip,mpfs":
+					d.isPolarFire = isRISCV
+				}
+			}
+			d.isMicrochip = d.isSAM9X5 || d.isSAMA5D2 || d.isSAMA5D3 || d.isSAMA5D4 || d.isPolarFire
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package microchip exposes the GPIO functionality of the Linux capable
+// Microchip (formerly Atmel) processors.
+//
+// The supported families are the ARM SAM9X5/SAM9X60 and SAMA5D2/D3/D4, and the
+// RISC-V PolarFire SoC (MPFS).
+//
+// This driver implements memory-mapped GPIO pin manipulation and leverages
+// sysfs-gpio for edge detection. When /dev/mem is not accessible, the pins fall
+// back to sysfs-gpio for everything.
+//
+// Three different GPIO controllers are supported:
+//
+// - the PIO controller of the SAM9X5, SAM9X60, SAMA5D3 and SAMA5D4, with one
+// controller per port of 32 lines.
+//
+// - the PIO4 controller of the SAMA5D2, a single controller for all the ports
+// where the lines are configured through a mask register.
+//
+// - the MSS GPIO controllers of the PolarFire SoC, named GPIO0 to GPIO2. Pull
+// resistors are configured in the I/O banks and are not accessible from this
+// driver.
+//
+// The on-board LEDs and buttons of the evaluation boards are registered as
+// aliases, e.g. "LED_RED" on the SAMA5D2 Xplained Ultra.
+//
+// Datasheets
+//
+// SAM9X60: https://www.microchip.com/en-us/product/SAM9X60
+//
+// SAMA5D2: https://www.microchip.com/en-us/product/ATSAMA5D27
+//
+// SAMA5D3: https://www.microchip.com/en-us/product/ATSAMA5D36
+//
+// SAMA5D4: https://www.microchip.com/en-us/product/ATSAMA5D44
+//
+// PolarFire SoC: https://www.microchip.com/en-us/product/MPFS250T
+package microchip
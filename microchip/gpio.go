@@ -0,0 +1,752 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// This file contains the implementation of the Microchip pins using a
+// combination of sysfs and memory-mapped I/O.
+
+package microchip
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pmem"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/pin"
+)
+
+// Pin implements the gpio.PinIO interface for Microchip CPU pins using memory
+// mapping for gpio in/out functionality.
+type Pin struct {
+	// Immutable.
+	group  uint8  // port (PA is 0) or MSS GPIO controller index
+	offset uint8  // line in the port or controller
+	name   string // name as per datasheet
+
+	// Immutable after driver initialization.
+	number    int        // sysfs GPIO number, -1 if unknown
+	available bool       // Set when the pin is available on this CPU.
+	sysfsPin  *sysfs.Pin // Set to the corresponding sysfs.Pin, if any.
+	pio3      *pio3Port  // Set on SAM9X5, SAM9X60, SAMA5D3 and SAMA5D4.
+	pio4      *pio4Port  // Set on SAMA5D2.
+	mss       *mssGPIO   // Set on PolarFire SoC.
+
+	// Mutable.
+	usingEdge bool // Set when edge detection is enabled.
+}
+
+// String implements conn.Resource.
+//
+// It returns the pin name and number, ex: "PB5(37)".
+func (p *Pin) String() string {
+	return fmt.Sprintf("%s(%d)", p.name, p.number)
+}
+
+// Halt implements conn.Resource.
+//
+// It stops edge detection if enabled.
+func (p *Pin) Halt() error {
+	if p.usingEdge {
+		if err := p.sysfsPin.Halt(); err != nil {
+			return p.wrap(err)
+		}
+		p.usingEdge = false
+	}
+	return nil
+}
+
+// Name implements pin.Pin.
+//
+// It returns the pin name, ex: "PB5".
+func (p *Pin) Name() string {
+	return p.name
+}
+
+// Number implements pin.Pin.
+//
+// It returns the GPIO pin number as represented by gpio sysfs.
+func (p *Pin) Number() int {
+	return p.number
+}
+
+// Function implements pin.Pin.
+func (p *Pin) Function() string {
+	return string(p.Func())
+}
+
+// Func implements pin.PinFunc.
+func (p *Pin) Func() pin.Func {
+	if !p.available {
+		return pin.FuncNone
+	}
+	if !p.mapped() {
+		if p.sysfsPin == nil {
+			return pin.FuncNone
+		}
+		return p.sysfsPin.Func()
+	}
+	bit := uint32(1) << p.offset
+	switch {
+	case p.pio3 != nil:
+		if p.pio3.psr&bit == 0 {
+			// The line is controlled by a peripheral.
+			sel := 0
+			if p.pio3.abcdsr[0]&bit != 0 {
+				sel |= 1
+			}
+			if p.pio3.abcdsr[1]&bit != 0 {
+				sel |= 2
+			}
+			return periphFunc(sel)
+		}
+		if p.pio3.osr&bit != 0 {
+			if p.FastRead() {
+				return gpio.OUT_HIGH
+			}
+			return gpio.OUT_LOW
+		}
+	case p.pio4 != nil:
+		cfg := p.pio4Config()
+		if f := cfg & pio4Func; f != 0 {
+			return periphFunc(int(f) - 1)
+		}
+		if cfg&pio4Dir != 0 {
+			if p.FastRead() {
+				return gpio.OUT_HIGH
+			}
+			return gpio.OUT_LOW
+		}
+	default:
+		cfg := p.mss.cfg[p.offset]
+		if cfg&mssEnOut != 0 {
+			if p.FastRead() {
+				return gpio.OUT_HIGH
+			}
+			return gpio.OUT_LOW
+		}
+		if cfg&mssEnIn == 0 {
+			return pin.FuncNone
+		}
+	}
+	if p.FastRead() {
+		return gpio.IN_HIGH
+	}
+	return gpio.IN_LOW
+}
+
+// SupportedFuncs implements pin.PinFunc.
+//
+// The peripheral functions are not exposed since they depend on the exact CPU
+// model.
+func (p *Pin) SupportedFuncs() []pin.Func {
+	return []pin.Func{gpio.IN, gpio.OUT}
+}
+
+// SetFunc implements pin.PinFunc.
+func (p *Pin) SetFunc(f pin.Func) error {
+	switch f {
+	case gpio.FLOAT:
+		return p.In(gpio.Float, gpio.NoEdge)
+	case gpio.IN:
+		return p.In(gpio.PullNoChange, gpio.NoEdge)
+	case gpio.IN_LOW:
+		return p.In(gpio.PullDown, gpio.NoEdge)
+	case gpio.IN_HIGH:
+		return p.In(gpio.PullUp, gpio.NoEdge)
+	case gpio.OUT_HIGH:
+		return p.Out(gpio.High)
+	case gpio.OUT_LOW:
+		return p.Out(gpio.Low)
+	default:
+		return p.wrap(errors.New("unsupported function"))
+	}
+}
+
+// In implements gpio.PinIn.
+//
+// It sets the pin direction to input and optionally enables a pull-up/down
+// resistor as well as edge detection.
+//
+// Edge detection requires opening a gpio sysfs file handle. The pin will be
+// exported at /sys/class/gpio/gpio*/. Note that the pin will not be unexported
+// at shutdown.
+//
+// The PolarFire SoC MSS GPIO controllers do not control the pull resistors,
+// only gpio.PullNoChange and gpio.Float are accepted.
+func (p *Pin) In(pull gpio.Pull, edge gpio.Edge) error {
+	if !p.available {
+		// We do not want the error message about uninitialized system.
+		return p.wrap(errors.New("not available on this CPU"))
+	}
+	if p.usingEdge && edge == gpio.NoEdge {
+		if err := p.sysfsPin.Halt(); err != nil {
+			return p.wrap(err)
+		}
+		p.usingEdge = false
+	}
+	if !p.mapped() {
+		if p.sysfsPin == nil {
+			return p.wrap(errors.New("subsystem gpiomem not initialized and sysfs not accessible; try running as root?"))
+		}
+		if pull != gpio.PullNoChange {
+			return p.wrap(errors.New("pull cannot be used when subsystem gpiomem not initialized; try running as root?"))
+		}
+		if err := p.sysfsPin.In(pull, edge); err != nil {
+			return p.wrap(err)
+		}
+		p.usingEdge = edge != gpio.NoEdge
+		return nil
+	}
+	bit := uint32(1) << p.offset
+	switch {
+	case p.pio3 != nil:
+		p.pio3.odr = bit
+		p.pio3.per = bit
+		switch pull {
+		case gpio.Float:
+			p.pio3.pudr = bit
+			p.pio3.ppddr = bit
+		case gpio.PullDown:
+			p.pio3.pudr = bit
+			p.pio3.ppder = bit
+		case gpio.PullUp:
+			p.pio3.ppddr = bit
+			p.pio3.puer = bit
+		default:
+		}
+	case p.pio4 != nil:
+		clr := pio4Func | pio4Dir
+		set := uint32(0)
+		switch pull {
+		case gpio.Float:
+			clr |= pio4PullUp | pio4PullDown
+		case gpio.PullDown:
+			clr |= pio4PullUp
+			set |= pio4PullDown
+		case gpio.PullUp:
+			clr |= pio4PullDown
+			set |= pio4PullUp
+		default:
+		}
+		p.setPIO4Config(clr, set)
+	default:
+		if pull != gpio.PullNoChange && pull != gpio.Float {
+			return p.wrap(errors.New("pull resistors are not controlled by the MSS GPIO controller"))
+		}
+		p.mss.cfg[p.offset] = p.mss.cfg[p.offset]&^(mssEnOut|mssEnOutBuf) | mssEnIn
+	}
+	if edge != gpio.NoEdge {
+		if p.sysfsPin == nil {
+			return p.wrap(fmt.Errorf("pin %d is not exported by sysfs", p.number))
+		}
+		// This resets pending edges.
+		if err := p.sysfsPin.In(gpio.PullNoChange, edge); err != nil {
+			return p.wrap(err)
+		}
+		p.usingEdge = true
+	}
+	return nil
+}
+
+// Read implements gpio.PinIn.
+//
+// It returns the current pin level. This function is fast.
+func (p *Pin) Read() gpio.Level {
+	if !p.available {
+		return gpio.Low
+	}
+	if !p.mapped() {
+		if p.sysfsPin == nil {
+			return gpio.Low
+		}
+		return p.sysfsPin.Read()
+	}
+	return p.FastRead()
+}
+
+// FastRead return the current pin level without any error checking.
+//
+// This function is very fast.
+func (p *Pin) FastRead() gpio.Level {
+	bit := uint32(1) << p.offset
+	switch {
+	case p.pio3 != nil:
+		return gpio.Level(p.pio3.pdsr&bit != 0)
+	case p.pio4 != nil:
+		return gpio.Level(p.pio4.pdsr&bit != 0)
+	default:
+		// The input buffer is disabled when the line is an output.
+		if p.mss.cfg[p.offset]&mssEnOut != 0 {
+			return gpio.Level(p.mss.out&bit != 0)
+		}
+		return gpio.Level(p.mss.in&bit != 0)
+	}
+}
+
+// WaitForEdge implements gpio.PinIn.
+//
+// It waits for an edge as previously set using In() or the expiration of a
+// timeout.
+func (p *Pin) WaitForEdge(timeout time.Duration) bool {
+	if p.sysfsPin != nil {
+		return p.sysfsPin.WaitForEdge(timeout)
+	}
+	return false
+}
+
+// Pull implements gpio.PinIn.
+func (p *Pin) Pull() gpio.Pull {
+	if !p.available || !p.mapped() {
+		return gpio.PullNoChange
+	}
+	bit := uint32(1) << p.offset
+	switch {
+	case p.pio3 != nil:
+		// The status registers are active low.
+		if p.pio3.pusr&bit == 0 {
+			return gpio.PullUp
+		}
+		if p.pio3.ppdsr&bit == 0 {
+			return gpio.PullDown
+		}
+		return gpio.Float
+	case p.pio4 != nil:
+		cfg := p.pio4Config()
+		if cfg&pio4PullUp != 0 {
+			return gpio.PullUp
+		}
+		if cfg&pio4PullDown != 0 {
+			return gpio.PullDown
+		}
+		return gpio.Float
+	default:
+		return gpio.PullNoChange
+	}
+}
+
+// DefaultPull implements gpio.PinIn.
+//
+// The default pull depends on the CPU model and on the boot loader, so it is
+// unknown.
+func (p *Pin) DefaultPull() gpio.Pull {
+	return gpio.PullNoChange
+}
+
+// Out implements gpio.PinOut.
+func (p *Pin) Out(l gpio.Level) error {
+	if !p.available {
+		// We do not want the error message about uninitialized system.
+		return p.wrap(errors.New("not available on this CPU"))
+	}
+	if !p.mapped() {
+		if p.sysfsPin == nil {
+			return p.wrap(errors.New("subsystem gpiomem not initialized and sysfs not accessible; try running as root?"))
+		}
+		return p.sysfsPin.Out(l)
+	}
+	// First disable edges.
+	if err := p.Halt(); err != nil {
+		return err
+	}
+	p.FastOut(l)
+	switch {
+	case p.pio3 != nil:
+		bit := uint32(1) << p.offset
+		p.pio3.oer = bit
+		p.pio3.per = bit
+	case p.pio4 != nil:
+		p.setPIO4Config(pio4Func, pio4Dir)
+	default:
+		p.mss.cfg[p.offset] = p.mss.cfg[p.offset]&^mssEnIn | mssEnOut | mssEnOutBuf
+	}
+	return nil
+}
+
+// FastOut sets a pin output level with Absolutely No error checking.
+//
+// Out() Must be called once first before calling FastOut(), otherwise the
+// behavior is undefined. Then FastOut() can be used for minimal CPU overhead
+// to reach Mhz scale bit banging.
+func (p *Pin) FastOut(l gpio.Level) {
+	bit := uint32(1) << p.offset
+	switch {
+	case p.pio3 != nil:
+		if l {
+			p.pio3.sodr = bit
+		} else {
+			p.pio3.codr = bit
+		}
+	case p.pio4 != nil:
+		if l {
+			p.pio4.sodr = bit
+		} else {
+			p.pio4.codr = bit
+		}
+	default:
+		if l {
+			p.mss.setBits = bit
+		} else {
+			p.mss.clearBits = bit
+		}
+	}
+}
+
+// PWM implements gpio.PinOut.
+func (p *Pin) PWM(gpio.Duty, physic.Frequency) error {
+	return p.wrap(errors.New("not available on this CPU"))
+}
+
+//
+
+// mapped returns true if the registers controlling this pin are memory
+// mapped.
+func (p *Pin) mapped() bool {
+	return p.pio3 != nil || p.pio4 != nil || p.mss != nil
+}
+
+// pio4Config returns the configuration of the line on a PIO4 controller.
+func (p *Pin) pio4Config() uint32 {
+	drvGPIO.mu.Lock()
+	defer drvGPIO.mu.Unlock()
+	p.pio4.mskr = 1 << p.offset
+	return p.pio4.cfgr
+}
+
+// setPIO4Config changes the configuration of the line on a PIO4 controller.
+//
+// The configuration register applies to all the lines selected in the mask
+// register, so the update is serialized.
+func (p *Pin) setPIO4Config(clr, set uint32) {
+	drvGPIO.mu.Lock()
+	defer drvGPIO.mu.Unlock()
+	p.pio4.mskr = 1 << p.offset
+	p.pio4.cfgr = p.pio4.cfgr&^clr | set
+}
+
+func (p *Pin) wrap(err error) error {
+	return fmt.Errorf("microchip-gpio (%s): %v", p, err)
+}
+
+// periphFunc returns the name of the peripheral function, where 0 is the
+// peripheral A.
+func periphFunc(sel int) pin.Func {
+	return pin.Func("PERIPH_" + string(rune('A'+sel)))
+}
+
+// pio3Port is a memory-mapped structure for the hardware registers of the PIO
+// controller of one port of 32 lines.
+//
+// SAMA5D3 datasheet, section 31.7 "Parallel Input/Output Controller (PIO)
+// User Interface". Only the first 0xA0 bytes are mapped.
+type pio3Port struct {
+	per    uint32    // 0x00 PIO Enable Register
+	pdr    uint32    // 0x04 PIO Disable Register
+	psr    uint32    // 0x08 PIO Status Register
+	_      uint32    // 0x0C
+	oer    uint32    // 0x10 Output Enable Register
+	odr    uint32    // 0x14 Output Disable Register
+	osr    uint32    // 0x18 Output Status Register
+	_      uint32    // 0x1C
+	ifer   uint32    // 0x20 Glitch Input Filter Enable Register
+	ifdr   uint32    // 0x24 Glitch Input Filter Disable Register
+	ifsr   uint32    // 0x28 Glitch Input Filter Status Register
+	_      uint32    // 0x2C
+	sodr   uint32    // 0x30 Set Output Data Register
+	codr   uint32    // 0x34 Clear Output Data Register
+	odsr   uint32    // 0x38 Output Data Status Register
+	pdsr   uint32    // 0x3C Pin Data Status Register
+	ier    uint32    // 0x40 Interrupt Enable Register
+	idr    uint32    // 0x44 Interrupt Disable Register
+	imr    uint32    // 0x48 Interrupt Mask Register
+	isr    uint32    // 0x4C Interrupt Status Register
+	mder   uint32    // 0x50 Multi-driver Enable Register
+	mddr   uint32    // 0x54 Multi-driver Disable Register
+	mdsr   uint32    // 0x58 Multi-driver Status Register
+	_      uint32    // 0x5C
+	pudr   uint32    // 0x60 Pull-up Disable Register
+	puer   uint32    // 0x64 Pull-up Enable Register
+	pusr   uint32    // 0x68 Pad Pull-up Status Register, 0 means enabled
+	_      uint32    // 0x6C
+	abcdsr [2]uint32 // 0x70 Peripheral Select Register 1 and 2
+	_      [2]uint32 // 0x78
+	ifscdr uint32    // 0x80 Input Filter Slow Clock Disable Register
+	ifscer uint32    // 0x84 Input Filter Slow Clock Enable Register
+	ifscsr uint32    // 0x88 Input Filter Slow Clock Status Register
+	scdr   uint32    // 0x8C Slow Clock Divider Debouncing Register
+	ppddr  uint32    // 0x90 Pad Pull-down Disable Register
+	ppder  uint32    // 0x94 Pad Pull-down Enable Register
+	ppdsr  uint32    // 0x98 Pad Pull-down Status Register, 0 means enabled
+	_      uint32    // 0x9C
+}
+
+// pio4Port is a memory-mapped structure for the hardware registers of one port
+// of the SAMA5D2 PIO4 controller.
+//
+// SAMA5D2 datasheet, section 34.7 "Parallel Input/Output Controller (PIO)
+// User Interface". Size is 64 bytes.
+type pio4Port struct {
+	mskr   uint32    // 0x00 PIO Mask Register
+	cfgr   uint32    // 0x04 PIO Configuration Register
+	pdsr   uint32    // 0x08 PIO Pin Data Status Register
+	locksr uint32    // 0x0C PIO Lock Status Register
+	sodr   uint32    // 0x10 PIO Set Output Data Register
+	codr   uint32    // 0x14 PIO Clear Output Data Register
+	odsr   uint32    // 0x18 PIO Output Data Status Register
+	_      uint32    // 0x1C
+	ier    uint32    // 0x20 PIO Interrupt Enable Register
+	idr    uint32    // 0x24 PIO Interrupt Disable Register
+	imr    uint32    // 0x28 PIO Interrupt Mask Register
+	isr    uint32    // 0x2C PIO Interrupt Status Register
+	_      [4]uint32 // 0x30
+}
+
+// pio4Map memory-maps the ports PA to PD of the SAMA5D2 PIO4 controller.
+type pio4Map struct {
+	ports [4]pio4Port
+}
+
+// Bits of pio4Port.cfgr.
+const (
+	pio4Func     uint32 = 7 << 0 // 0 is GPIO, 1 to 6 are peripherals A to F
+	pio4Dir      uint32 = 1 << 8 // 1 is output
+	pio4PullUp   uint32 = 1 << 9
+	pio4PullDown uint32 = 1 << 10
+)
+
+// mssGPIO is a memory-mapped structure for the hardware registers of one
+// PolarFire SoC MSS GPIO controller.
+//
+// PolarFire SoC MSS Technical Reference Manual, section "GPIO".
+type mssGPIO struct {
+	cfg       [32]uint32 // 0x00 Configuration Register per line
+	irq       uint32     // 0x80 Interrupt Register
+	in        uint32     // 0x84 Input Register
+	out       uint32     // 0x88 Output Register
+	_         [5]uint32  // 0x8C
+	clearBits uint32     // 0xA0 Clear Output Bits Register
+	setBits   uint32     // 0xA4 Set Output Bits Register
+}
+
+// Bits of mssGPIO.cfg.
+const (
+	mssEnOut    uint32 = 1 << 0
+	mssEnIn     uint32 = 1 << 1
+	mssEnOutBuf uint32 = 1 << 2
+)
+
+// Physical base addresses of the PIO controller ports, from PA onward.
+var (
+	sam9x5Ports  = []uint64{0xFFFFF400, 0xFFFFF600, 0xFFFFF800, 0xFFFFFA00}
+	sama5d3Ports = []uint64{0xFFFFF200, 0xFFFFF400, 0xFFFFF600, 0xFFFFF800, 0xFFFFFA00}
+	sama5d4Ports = []uint64{0xFC06A000, 0xFC06B000, 0xFC06C000, 0xFC068000, 0xFC06D000}
+)
+
+// sama5d2PIO4 is the physical base address of the SAMA5D2 PIO4 controller.
+const sama5d2PIO4 = 0xFC038000
+
+// mssControllers are the physical base addresses and the number of lines of
+// the PolarFire SoC MSS GPIO controllers.
+var mssControllers = []struct {
+	base  uint64
+	lines int
+}{
+	{0x20120000, 14},
+	{0x20121000, 24},
+	{0x20122000, 32},
+}
+
+// driverGPIO implements periph.Driver.
+type driverGPIO struct {
+	// mu serializes the accesses to the PIO4 mask and configuration registers.
+	mu sync.Mutex
+}
+
+func (d *driverGPIO) String() string {
+	return "microchip-gpio"
+}
+
+func (d *driverGPIO) Prerequisites() []string {
+	return nil
+}
+
+func (d *driverGPIO) After() []string {
+	return []string{"sysfs-gpio"}
+}
+
+// Init does nothing if a Microchip processor is not detected. If one is
+// detected, it memory maps gpio CPU registers and then registers the pins
+// available on the exact processor family detected.
+//
+// The pins are registered even if the memory map fails, so they can fallback
+// to sysfs.Pins.
+func (d *driverGPIO) Init() (bool, error) {
+	if !Present() {
+		return false, errors.New("no Microchip CPU detected")
+	}
+	var err error
+	switch {
+	case IsSAMA5D2():
+		err = initPIO4()
+	case IsSAMA5D3():
+		err = initPIO3(sama5d3Ports)
+	case IsSAMA5D4():
+		err = initPIO3(sama5d4Ports)
+	case IsSAM9X5():
+		err = initPIO3(sam9x5Ports)
+	case IsPolarFire():
+		err = initMSS()
+	default:
+		return false, errors.New("unknown Microchip CPU model")
+	}
+	if err != nil && os.IsPermission(err) {
+		err = fmt.Errorf("need more access, try as root: %v", err)
+	}
+	if err2 := initPins(); err2 != nil {
+		return true, err2
+	}
+	return true, err
+}
+
+// initPIO3 marks the ports pins as available and memory maps each port.
+func initPIO3(bases []uint64) error {
+	ports := make([]*pio3Port, len(bases))
+	for i := range bases {
+		markPort(i)
+	}
+	for i, base := range bases {
+		if err := pmem.MapAsPOD(base, &ports[i]); err != nil {
+			return err
+		}
+	}
+	for _, p := range cpupins {
+		if p.available {
+			p.pio3 = ports[p.group]
+		}
+	}
+	return nil
+}
+
+// initPIO4 marks the ports pins as available and memory maps the controller.
+func initPIO4() error {
+	for i := 0; i < 4; i++ {
+		markPort(i)
+	}
+	var m *pio4Map
+	if err := pmem.MapAsPOD(sama5d2PIO4, &m); err != nil {
+		return err
+	}
+	for _, p := range cpupins {
+		if p.available {
+			p.pio4 = &m.ports[p.group]
+		}
+	}
+	return nil
+}
+
+// initMSS marks the MSS GPIO pins as available and memory maps each
+// controller.
+//
+// The sysfs GPIO numbers are dynamically allocated on this platform, so they
+// are retrieved from the controller label.
+func initMSS() error {
+	bases := sysfs.GPIOChipBases()
+	ctrls := make([]*mssGPIO, len(mssControllers))
+	for i, c := range mssControllers {
+		base, ok := bases[strconv.FormatUint(c.base, 16)+".gpio"]
+		for j := 0; j < c.lines; j++ {
+			p := cpupins["GPIO"+strconv.Itoa(i)+"_"+strconv.Itoa(j)]
+			p.available = true
+			p.number = -1
+			if ok {
+				p.number = base + j
+				p.sysfsPin = sysfs.Pins[p.number]
+			}
+		}
+	}
+	for i, c := range mssControllers {
+		if err := pmem.MapAsPOD(c.base, &ctrls[i]); err != nil {
+			return err
+		}
+	}
+	for _, p := range cpupins {
+		if p.available {
+			p.mss = ctrls[p.group]
+		}
+	}
+	return nil
+}
+
+// markPort marks the 32 pins of a PIO port as available. The kernel numbers
+// the lines of these controllers from 0, 32 lines per port.
+func markPort(group int) {
+	prefix := "P" + string(rune('A'+group))
+	for j := 0; j < 32; j++ {
+		p := cpupins[prefix+strconv.Itoa(j)]
+		p.available = true
+		p.number = group*32 + j
+		p.sysfsPin = sysfs.Pins[p.number]
+	}
+}
+
+// initPins registers all the available pins with gpio, followed by the board
+// aliases.
+func initPins() error {
+	for name, p := range cpupins {
+		if !p.available {
+			continue
+		}
+		if p.number < 0 {
+			if err := gpioreg.Register(p); err != nil {
+				return err
+			}
+			continue
+		}
+		num := strconv.Itoa(p.number)
+		gpion := "GPIO" + num
+
+		// Unregister the pin if already registered. This happens with sysfs-gpio.
+		// Do not error on it, since sysfs-gpio may have failed to load.
+		_ = gpioreg.Unregister(gpion)
+		_ = gpioreg.Unregister(num)
+
+		// Register the pin with gpio.
+		if err := gpioreg.Register(p); err != nil {
+			return err
+		}
+		if err := gpioreg.RegisterAlias(gpion, name); err != nil {
+			return err
+		}
+		if err := gpioreg.RegisterAlias(num, name); err != nil {
+			return err
+		}
+	}
+	for alias, name := range boardAliases() {
+		if err := gpioreg.RegisterAlias(alias, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	if isArm || isRISCV {
+		hostcfg.MustRegister(&drvGPIO)
+	}
+}
+
+var drvGPIO driverGPIO
+
+// Ensure that the various structs implement the interfaces they're supposed to.
+var _ gpio.PinIO = &Pin{}
+var _ gpio.PinIn = &Pin{}
+var _ gpio.PinOut = &Pin{}
+var _ pin.PinFunc = &Pin{}
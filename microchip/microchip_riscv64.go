@@ -0,0 +1,13 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build riscv64
+// +build riscv64
+
+package microchip
+
+const (
+	isArm   = false
+	isRISCV = true
+)
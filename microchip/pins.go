@@ -0,0 +1,493 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package microchip
+
+// List of all known pins. These global variables can be used directly.
+//
+// The ports PA to PE are used by the SAM9X5, SAM9X60 and SAMA5D2/D3/D4, the
+// pins GPIOn_m by the PolarFire SoC.
+//
+// The availability of each gpio differs between CPUs. For example the SAMA5D2
+// has 4 ports but the SAMA5D3 has 5. Make sure to read the datasheet for the
+// exact right CPU.
+var (
+	PA0, PA1, PA2, PA3, PA4, PA5, PA6, PA7, PA8, PA9, PA10, PA11, PA12, PA13, PA14, PA15, PA16, PA17, PA18, PA19, PA20, PA21, PA22, PA23, PA24, PA25, PA26, PA27, PA28, PA29, PA30, PA31                                                                                                                                 *Pin
+	PB0, PB1, PB2, PB3, PB4, PB5, PB6, PB7, PB8, PB9, PB10, PB11, PB12, PB13, PB14, PB15, PB16, PB17, PB18, PB19, PB20, PB21, PB22, PB23, PB24, PB25, PB26, PB27, PB28, PB29, PB30, PB31                                                                                                                                 *Pin
+	PC0, PC1, PC2, PC3, PC4, PC5, PC6, PC7, PC8, PC9, PC10, PC11, PC12, PC13, PC14, PC15, PC16, PC17, PC18, PC19, PC20, PC21, PC22, PC23, PC24, PC25, PC26, PC27, PC28, PC29, PC30, PC31                                                                                                                                 *Pin
+	PD0, PD1, PD2, PD3, PD4, PD5, PD6, PD7, PD8, PD9, PD10, PD11, PD12, PD13, PD14, PD15, PD16, PD17, PD18, PD19, PD20, PD21, PD22, PD23, PD24, PD25, PD26, PD27, PD28, PD29, PD30, PD31                                                                                                                                 *Pin
+	PE0, PE1, PE2, PE3, PE4, PE5, PE6, PE7, PE8, PE9, PE10, PE11, PE12, PE13, PE14, PE15, PE16, PE17, PE18, PE19, PE20, PE21, PE22, PE23, PE24, PE25, PE26, PE27, PE28, PE29, PE30, PE31                                                                                                                                 *Pin
+	GPIO0_0, GPIO0_1, GPIO0_2, GPIO0_3, GPIO0_4, GPIO0_5, GPIO0_6, GPIO0_7, GPIO0_8, GPIO0_9, GPIO0_10, GPIO0_11, GPIO0_12, GPIO0_13                                                                                                                                                                                     *Pin
+	GPIO1_0, GPIO1_1, GPIO1_2, GPIO1_3, GPIO1_4, GPIO1_5, GPIO1_6, GPIO1_7, GPIO1_8, GPIO1_9, GPIO1_10, GPIO1_11, GPIO1_12, GPIO1_13, GPIO1_14, GPIO1_15, GPIO1_16, GPIO1_17, GPIO1_18, GPIO1_19, GPIO1_20, GPIO1_21, GPIO1_22, GPIO1_23                                                                                 *Pin
+	GPIO2_0, GPIO2_1, GPIO2_2, GPIO2_3, GPIO2_4, GPIO2_5, GPIO2_6, GPIO2_7, GPIO2_8, GPIO2_9, GPIO2_10, GPIO2_11, GPIO2_12, GPIO2_13, GPIO2_14, GPIO2_15, GPIO2_16, GPIO2_17, GPIO2_18, GPIO2_19, GPIO2_20, GPIO2_21, GPIO2_22, GPIO2_23, GPIO2_24, GPIO2_25, GPIO2_26, GPIO2_27, GPIO2_28, GPIO2_29, GPIO2_30, GPIO2_31 *Pin
+)
+
+// cpupins that may be implemented by a Microchip CPU. Not all pins will be
+// present on all models and even if the CPU model supports them they may not
+// be connected to anything on the board.
+var cpupins = map[string]*Pin{
+	"PA0":      {group: 0, offset: 0, name: "PA0"},
+	"PA1":      {group: 0, offset: 1, name: "PA1"},
+	"PA2":      {group: 0, offset: 2, name: "PA2"},
+	"PA3":      {group: 0, offset: 3, name: "PA3"},
+	"PA4":      {group: 0, offset: 4, name: "PA4"},
+	"PA5":      {group: 0, offset: 5, name: "PA5"},
+	"PA6":      {group: 0, offset: 6, name: "PA6"},
+	"PA7":      {group: 0, offset: 7, name: "PA7"},
+	"PA8":      {group: 0, offset: 8, name: "PA8"},
+	"PA9":      {group: 0, offset: 9, name: "PA9"},
+	"PA10":     {group: 0, offset: 10, name: "PA10"},
+	"PA11":     {group: 0, offset: 11, name: "PA11"},
+	"PA12":     {group: 0, offset: 12, name: "PA12"},
+	"PA13":     {group: 0, offset: 13, name: "PA13"},
+	"PA14":     {group: 0, offset: 14, name: "PA14"},
+	"PA15":     {group: 0, offset: 15, name: "PA15"},
+	"PA16":     {group: 0, offset: 16, name: "PA16"},
+	"PA17":     {group: 0, offset: 17, name: "PA17"},
+	"PA18":     {group: 0, offset: 18, name: "PA18"},
+	"PA19":     {group: 0, offset: 19, name: "PA19"},
+	"PA20":     {group: 0, offset: 20, name: "PA20"},
+	"PA21":     {group: 0, offset: 21, name: "PA21"},
+	"PA22":     {group: 0, offset: 22, name: "PA22"},
+	"PA23":     {group: 0, offset: 23, name: "PA23"},
+	"PA24":     {group: 0, offset: 24, name: "PA24"},
+	"PA25":     {group: 0, offset: 25, name: "PA25"},
+	"PA26":     {group: 0, offset: 26, name: "PA26"},
+	"PA27":     {group: 0, offset: 27, name: "PA27"},
+	"PA28":     {group: 0, offset: 28, name: "PA28"},
+	"PA29":     {group: 0, offset: 29, name: "PA29"},
+	"PA30":     {group: 0, offset: 30, name: "PA30"},
+	"PA31":     {group: 0, offset: 31, name: "PA31"},
+	"PB0":      {group: 1, offset: 0, name: "PB0"},
+	"PB1":      {group: 1, offset: 1, name: "PB1"},
+	"PB2":      {group: 1, offset: 2, name: "PB2"},
+	"PB3":      {group: 1, offset: 3, name: "PB3"},
+	"PB4":      {group: 1, offset: 4, name: "PB4"},
+	"PB5":      {group: 1, offset: 5, name: "PB5"},
+	"PB6":      {group: 1, offset: 6, name: "PB6"},
+	"PB7":      {group: 1, offset: 7, name: "PB7"},
+	"PB8":      {group: 1, offset: 8, name: "PB8"},
+	"PB9":      {group: 1, offset: 9, name: "PB9"},
+	"PB10":     {group: 1, offset: 10, name: "PB10"},
+	"PB11":     {group: 1, offset: 11, name: "PB11"},
+	"PB12":     {group: 1, offset: 12, name: "PB12"},
+	"PB13":     {group: 1, offset: 13, name: "PB13"},
+	"PB14":     {group: 1, offset: 14, name: "PB14"},
+	"PB15":     {group: 1, offset: 15, name: "PB15"},
+	"PB16":     {group: 1, offset: 16, name: "PB16"},
+	"PB17":     {group: 1, offset: 17, name: "PB17"},
+	"PB18":     {group: 1, offset: 18, name: "PB18"},
+	"PB19":     {group: 1, offset: 19, name: "PB19"},
+	"PB20":     {group: 1, offset: 20, name: "PB20"},
+	"PB21":     {group: 1, offset: 21, name: "PB21"},
+	"PB22":     {group: 1, offset: 22, name: "PB22"},
+	"PB23":     {group: 1, offset: 23, name: "PB23"},
+	"PB24":     {group: 1, offset: 24, name: "PB24"},
+	"PB25":     {group: 1, offset: 25, name: "PB25"},
+	"PB26":     {group: 1, offset: 26, name: "PB26"},
+	"PB27":     {group: 1, offset: 27, name: "PB27"},
+	"PB28":     {group: 1, offset: 28, name: "PB28"},
+	"PB29":     {group: 1, offset: 29, name: "PB29"},
+	"PB30":     {group: 1, offset: 30, name: "PB30"},
+	"PB31":     {group: 1, offset: 31, name: "PB31"},
+	"PC0":      {group: 2, offset: 0, name: "PC0"},
+	"PC1":      {group: 2, offset: 1, name: "PC1"},
+	"PC2":      {group: 2, offset: 2, name: "PC2"},
+	"PC3":      {group: 2, offset: 3, name: "PC3"},
+	"PC4":      {group: 2, offset: 4, name: "PC4"},
+	"PC5":      {group: 2, offset: 5, name: "PC5"},
+	"PC6":      {group: 2, offset: 6, name: "PC6"},
+	"PC7":      {group: 2, offset: 7, name: "PC7"},
+	"PC8":      {group: 2, offset: 8, name: "PC8"},
+	"PC9":      {group: 2, offset: 9, name: "PC9"},
+	"PC10":     {group: 2, offset: 10, name: "PC10"},
+	"PC11":     {group: 2, offset: 11, name: "PC11"},
+	"PC12":     {group: 2, offset: 12, name: "PC12"},
+	"PC13":     {group: 2, offset: 13, name: "PC13"},
+	"PC14":     {group: 2, offset: 14, name: "PC14"},
+	"PC15":     {group: 2, offset: 15, name: "PC15"},
+	"PC16":     {group: 2, offset: 16, name: "PC16"},
+	"PC17":     {group: 2, offset: 17, name: "PC17"},
+	"PC18":     {group: 2, offset: 18, name: "PC18"},
+	"PC19":     {group: 2, offset: 19, name: "PC19"},
+	"PC20":     {group: 2, offset: 20, name: "PC20"},
+	"PC21":     {group: 2, offset: 21, name: "PC21"},
+	"PC22":     {group: 2, offset: 22, name: "PC22"},
+	"PC23":     {group: 2, offset: 23, name: "PC23"},
+	"PC24":     {group: 2, offset: 24, name: "PC24"},
+	"PC25":     {group: 2, offset: 25, name: "PC25"},
+	"PC26":     {group: 2, offset: 26, name: "PC26"},
+	"PC27":     {group: 2, offset: 27, name: "PC27"},
+	"PC28":     {group: 2, offset: 28, name: "PC28"},
+	"PC29":     {group: 2, offset: 29, name: "PC29"},
+	"PC30":     {group: 2, offset: 30, name: "PC30"},
+	"PC31":     {group: 2, offset: 31, name: "PC31"},
+	"PD0":      {group: 3, offset: 0, name: "PD0"},
+	"PD1":      {group: 3, offset: 1, name: "PD1"},
+	"PD2":      {group: 3, offset: 2, name: "PD2"},
+	"PD3":      {group: 3, offset: 3, name: "PD3"},
+	"PD4":      {group: 3, offset: 4, name: "PD4"},
+	"PD5":      {group: 3, offset: 5, name: "PD5"},
+	"PD6":      {group: 3, offset: 6, name: "PD6"},
+	"PD7":      {group: 3, offset: 7, name: "PD7"},
+	"PD8":      {group: 3, offset: 8, name: "PD8"},
+	"PD9":      {group: 3, offset: 9, name: "PD9"},
+	"PD10":     {group: 3, offset: 10, name: "PD10"},
+	"PD11":     {group: 3, offset: 11, name: "PD11"},
+	"PD12":     {group: 3, offset: 12, name: "PD12"},
+	"PD13":     {group: 3, offset: 13, name: "PD13"},
+	"PD14":     {group: 3, offset: 14, name: "PD14"},
+	"PD15":     {group: 3, offset: 15, name: "PD15"},
+	"PD16":     {group: 3, offset: 16, name: "PD16"},
+	"PD17":     {group: 3, offset: 17, name: "PD17"},
+	"PD18":     {group: 3, offset: 18, name: "PD18"},
+	"PD19":     {group: 3, offset: 19, name: "PD19"},
+	"PD20":     {group: 3, offset: 20, name: "PD20"},
+	"PD21":     {group: 3, offset: 21, name: "PD21"},
+	"PD22":     {group: 3, offset: 22, name: "PD22"},
+	"PD23":     {group: 3, offset: 23, name: "PD23"},
+	"PD24":     {group: 3, offset: 24, name: "PD24"},
+	"PD25":     {group: 3, offset: 25, name: "PD25"},
+	"PD26":     {group: 3, offset: 26, name: "PD26"},
+	"PD27":     {group: 3, offset: 27, name: "PD27"},
+	"PD28":     {group: 3, offset: 28, name: "PD28"},
+	"PD29":     {group: 3, offset: 29, name: "PD29"},
+	"PD30":     {group: 3, offset: 30, name: "PD30"},
+	"PD31":     {group: 3, offset: 31, name: "PD31"},
+	"PE0":      {group: 4, offset: 0, name: "PE0"},
+	"PE1":      {group: 4, offset: 1, name: "PE1"},
+	"PE2":      {group: 4, offset: 2, name: "PE2"},
+	"PE3":      {group: 4, offset: 3, name: "PE3"},
+	"PE4":      {group: 4, offset: 4, name: "PE4"},
+	"PE5":      {group: 4, offset: 5, name: "PE5"},
+	"PE6":      {group: 4, offset: 6, name: "PE6"},
+	"PE7":      {group: 4, offset: 7, name: "PE7"},
+	"PE8":      {group: 4, offset: 8, name: "PE8"},
+	"PE9":      {group: 4, offset: 9, name: "PE9"},
+	"PE10":     {group: 4, offset: 10, name: "PE10"},
+	"PE11":     {group: 4, offset: 11, name: "PE11"},
+	"PE12":     {group: 4, offset: 12, name: "PE12"},
+	"PE13":     {group: 4, offset: 13, name: "PE13"},
+	"PE14":     {group: 4, offset: 14, name: "PE14"},
+	"PE15":     {group: 4, offset: 15, name: "PE15"},
+	"PE16":     {group: 4, offset: 16, name: "PE16"},
+	"PE17":     {group: 4, offset: 17, name: "PE17"},
+	"PE18":     {group: 4, offset: 18, name: "PE18"},
+	"PE19":     {group: 4, offset: 19, name: "PE19"},
+	"PE20":     {group: 4, offset: 20, name: "PE20"},
+	"PE21":     {group: 4, offset: 21, name: "PE21"},
+	"PE22":     {group: 4, offset: 22, name: "PE22"},
+	"PE23":     {group: 4, offset: 23, name: "PE23"},
+	"PE24":     {group: 4, offset: 24, name: "PE24"},
+	"PE25":     {group: 4, offset: 25, name: "PE25"},
+	"PE26":     {group: 4, offset: 26, name: "PE26"},
+	"PE27":     {group: 4, offset: 27, name: "PE27"},
+	"PE28":     {group: 4, offset: 28, name: "PE28"},
+	"PE29":     {group: 4, offset: 29, name: "PE29"},
+	"PE30":     {group: 4, offset: 30, name: "PE30"},
+	"PE31":     {group: 4, offset: 31, name: "PE31"},
+	"GPIO0_0":  {group: 0, offset: 0, name: "GPIO0_0"},
+	"GPIO0_1":  {group: 0, offset: 1, name: "GPIO0_1"},
+	"GPIO0_2":  {group: 0, offset: 2, name: "GPIO0_2"},
+	"GPIO0_3":  {group: 0, offset: 3, name: "GPIO0_3"},
+	"GPIO0_4":  {group: 0, offset: 4, name: "GPIO0_4"},
+	"GPIO0_5":  {group: 0, offset: 5, name: "GPIO0_5"},
+	"GPIO0_6":  {group: 0, offset: 6, name: "GPIO0_6"},
+	"GPIO0_7":  {group: 0, offset: 7, name: "GPIO0_7"},
+	"GPIO0_8":  {group: 0, offset: 8, name: "GPIO0_8"},
+	"GPIO0_9":  {group: 0, offset: 9, name: "GPIO0_9"},
+	"GPIO0_10": {group: 0, offset: 10, name: "GPIO0_10"},
+	"GPIO0_11": {group: 0, offset: 11, name: "GPIO0_11"},
+	"GPIO0_12": {group: 0, offset: 12, name: "GPIO0_12"},
+	"GPIO0_13": {group: 0, offset: 13, name: "GPIO0_13"},
+	"GPIO1_0":  {group: 1, offset: 0, name: "GPIO1_0"},
+	"GPIO1_1":  {group: 1, offset: 1, name: "GPIO1_1"},
+	"GPIO1_2":  {group: 1, offset: 2, name: "GPIO1_2"},
+	"GPIO1_3":  {group: 1, offset: 3, name: "GPIO1_3"},
+	"GPIO1_4":  {group: 1, offset: 4, name: "GPIO1_4"},
+	"GPIO1_5":  {group: 1, offset: 5, name: "GPIO1_5"},
+	"GPIO1_6":  {group: 1, offset: 6, name: "GPIO1_6"},
+	"GPIO1_7":  {group: 1, offset: 7, name: "GPIO1_7"},
+	"GPIO1_8":  {group: 1, offset: 8, name: "GPIO1_8"},
+	"GPIO1_9":  {group: 1, offset: 9, name: "GPIO1_9"},
+	"GPIO1_10": {group: 1, offset: 10, name: "GPIO1_10"},
+	"GPIO1_11": {group: 1, offset: 11, name: "GPIO1_11"},
+	"GPIO1_12": {group: 1, offset: 12, name: "GPIO1_12"},
+	"GPIO1_13": {group: 1, offset: 13, name: "GPIO1_13"},
+	"GPIO1_14": {group: 1, offset: 14, name: "GPIO1_14"},
+	"GPIO1_15": {group: 1, offset: 15, name: "GPIO1_15"},
+	"GPIO1_16": {group: 1, offset: 16, name: "GPIO1_16"},
+	"GPIO1_17": {group: 1, offset: 17, name: "GPIO1_17"},
+	"GPIO1_18": {group: 1, offset: 18, name: "GPIO1_18"},
+	"GPIO1_19": {group: 1, offset: 19, name: "GPIO1_19"},
+	"GPIO1_20": {group: 1, offset: 20, name: "GPIO1_20"},
+	"GPIO1_21": {group: 1, offset: 21, name: "GPIO1_21"},
+	"GPIO1_22": {group: 1, offset: 22, name: "GPIO1_22"},
+	"GPIO1_23": {group: 1, offset: 23, name: "GPIO1_23"},
+	"GPIO2_0":  {group: 2, offset: 0, name: "GPIO2_0"},
+	"GPIO2_1":  {group: 2, offset: 1, name: "GPIO2_1"},
+	"GPIO2_2":  {group: 2, offset: 2, name: "GPIO2_2"},
+	"GPIO2_3":  {group: 2, offset: 3, name: "GPIO2_3"},
+	"GPIO2_4":  {group: 2, offset: 4, name: "GPIO2_4"},
+	"GPIO2_5":  {group: 2, offset: 5, name: "GPIO2_5"},
+	"GPIO2_6":  {group: 2, offset: 6, name: "GPIO2_6"},
+	"GPIO2_7":  {group: 2, offset: 7, name: "GPIO2_7"},
+	"GPIO2_8":  {group: 2, offset: 8, name: "GPIO2_8"},
+	"GPIO2_9":  {group: 2, offset: 9, name: "GPIO2_9"},
+	"GPIO2_10": {group: 2, offset: 10, name: "GPIO2_10"},
+	"GPIO2_11": {group: 2, offset: 11, name: "GPIO2_11"},
+	"GPIO2_12": {group: 2, offset: 12, name: "GPIO2_12"},
+	"GPIO2_13": {group: 2, offset: 13, name: "GPIO2_13"},
+	"GPIO2_14": {group: 2, offset: 14, name: "GPIO2_14"},
+	"GPIO2_15": {group: 2, offset: 15, name: "GPIO2_15"},
+	"GPIO2_16": {group: 2, offset: 16, name: "GPIO2_16"},
+	"GPIO2_17": {group: 2, offset: 17, name: "GPIO2_17"},
+	"GPIO2_18": {group: 2, offset: 18, name: "GPIO2_18"},
+	"GPIO2_19": {group: 2, offset: 19, name: "GPIO2_19"},
+	"GPIO2_20": {group: 2, offset: 20, name: "GPIO2_20"},
+	"GPIO2_21": {group: 2, offset: 21, name: "GPIO2_21"},
+	"GPIO2_22": {group: 2, offset: 22, name: "GPIO2_22"},
+	"GPIO2_23": {group: 2, offset: 23, name: "GPIO2_23"},
+	"GPIO2_24": {group: 2, offset: 24, name: "GPIO2_24"},
+	"GPIO2_25": {group: 2, offset: 25, name: "GPIO2_25"},
+	"GPIO2_26": {group: 2, offset: 26, name: "GPIO2_26"},
+	"GPIO2_27": {group: 2, offset: 27, name: "GPIO2_27"},
+	"GPIO2_28": {group: 2, offset: 28, name: "GPIO2_28"},
+	"GPIO2_29": {group: 2, offset: 29, name: "GPIO2_29"},
+	"GPIO2_30": {group: 2, offset: 30, name: "GPIO2_30"},
+	"GPIO2_31": {group: 2, offset: 31, name: "GPIO2_31"},
+}
+
+func init() {
+	PA0 = cpupins["PA0"]
+	PA1 = cpupins["PA1"]
+	PA2 = cpupins["PA2"]
+	PA3 = cpupins["PA3"]
+	PA4 = cpupins["PA4"]
+	PA5 = cpupins["PA5"]
+	PA6 = cpupins["PA6"]
+	PA7 = cpupins["PA7"]
+	PA8 = cpupins["PA8"]
+	PA9 = cpupins["PA9"]
+	PA10 = cpupins["PA10"]
+	PA11 = cpupins["PA11"]
+	PA12 = cpupins["PA12"]
+	PA13 = cpupins["PA13"]
+	PA14 = cpupins["PA14"]
+	PA15 = cpupins["PA15"]
+	PA16 = cpupins["PA16"]
+	PA17 = cpupins["PA17"]
+	PA18 = cpupins["PA18"]
+	PA19 = cpupins["PA19"]
+	PA20 = cpupins["PA20"]
+	PA21 = cpupins["PA21"]
+	PA22 = cpupins["PA22"]
+	PA23 = cpupins["PA23"]
+	PA24 = cpupins["PA24"]
+	PA25 = cpupins["PA25"]
+	PA26 = cpupins["PA26"]
+	PA27 = cpupins["PA27"]
+	PA28 = cpupins["PA28"]
+	PA29 = cpupins["PA29"]
+	PA30 = cpupins["PA30"]
+	PA31 = cpupins["PA31"]
+	PB0 = cpupins["PB0"]
+	PB1 = cpupins["PB1"]
+	PB2 = cpupins["PB2"]
+	PB3 = cpupins["PB3"]
+	PB4 = cpupins["PB4"]
+	PB5 = cpupins["PB5"]
+	PB6 = cpupins["PB6"]
+	PB7 = cpupins["PB7"]
+	PB8 = cpupins["PB8"]
+	PB9 = cpupins["PB9"]
+	PB10 = cpupins["PB10"]
+	PB11 = cpupins["PB11"]
+	PB12 = cpupins["PB12"]
+	PB13 = cpupins["PB13"]
+	PB14 = cpupins["PB14"]
+	PB15 = cpupins["PB15"]
+	PB16 = cpupins["PB16"]
+	PB17 = cpupins["PB17"]
+	PB18 = cpupins["PB18"]
+	PB19 = cpupins["PB19"]
+	PB20 = cpupins["PB20"]
+	PB21 = cpupins["PB21"]
+	PB22 = cpupins["PB22"]
+	PB23 = cpupins["PB23"]
+	PB24 = cpupins["PB24"]
+	PB25 = cpupins["PB25"]
+	PB26 = cpupins["PB26"]
+	PB27 = cpupins["PB27"]
+	PB28 = cpupins["PB28"]
+	PB29 = cpupins["PB29"]
+	PB30 = cpupins["PB30"]
+	PB31 = cpupins["PB31"]
+	PC0 = cpupins["PC0"]
+	PC1 = cpupins["PC1"]
+	PC2 = cpupins["PC2"]
+	PC3 = cpupins["PC3"]
+	PC4 = cpupins["PC4"]
+	PC5 = cpupins["PC5"]
+	PC6 = cpupins["PC6"]
+	PC7 = cpupins["PC7"]
+	PC8 = cpupins["PC8"]
+	PC9 = cpupins["PC9"]
+	PC10 = cpupins["PC10"]
+	PC11 = cpupins["PC11"]
+	PC12 = cpupins["PC12"]
+	PC13 = cpupins["PC13"]
+	PC14 = cpupins["PC14"]
+	PC15 = cpupins["PC15"]
+	PC16 = cpupins["PC16"]
+	PC17 = cpupins["PC17"]
+	PC18 = cpupins["PC18"]
+	PC19 = cpupins["PC19"]
+	PC20 = cpupins["PC20"]
+	PC21 = cpupins["PC21"]
+	PC22 = cpupins["PC22"]
+	PC23 = cpupins["PC23"]
+	PC24 = cpupins["PC24"]
+	PC25 = cpupins["PC25"]
+	PC26 = cpupins["PC26"]
+	PC27 = cpupins["PC27"]
+	PC28 = cpupins["PC28"]
+	PC29 = cpupins["PC29"]
+	PC30 = cpupins["PC30"]
+	PC31 = cpupins["PC31"]
+	PD0 = cpupins["PD0"]
+	PD1 = cpupins["PD1"]
+	PD2 = cpupins["PD2"]
+	PD3 = cpupins["PD3"]
+	PD4 = cpupins["PD4"]
+	PD5 = cpupins["PD5"]
+	PD6 = cpupins["PD6"]
+	PD7 = cpupins["PD7"]
+	PD8 = cpupins["PD8"]
+	PD9 = cpupins["PD9"]
+	PD10 = cpupins["PD10"]
+	PD11 = cpupins["PD11"]
+	PD12 = cpupins["PD12"]
+	PD13 = cpupins["PD13"]
+	PD14 = cpupins["PD14"]
+	PD15 = cpupins["PD15"]
+	PD16 = cpupins["PD16"]
+	PD17 = cpupins["PD17"]
+	PD18 = cpupins["PD18"]
+	PD19 = cpupins["PD19"]
+	PD20 = cpupins["PD20"]
+	PD21 = cpupins["PD21"]
+	PD22 = cpupins["PD22"]
+	PD23 = cpupins["PD23"]
+	PD24 = cpupins["PD24"]
+	PD25 = cpupins["PD25"]
+	PD26 = cpupins["PD26"]
+	PD27 = cpupins["PD27"]
+	PD28 = cpupins["PD28"]
+	PD29 = cpupins["PD29"]
+	PD30 = cpupins["PD30"]
+	PD31 = cpupins["PD31"]
+	PE0 = cpupins["PE0"]
+	PE1 = cpupins["PE1"]
+	PE2 = cpupins["PE2"]
+	PE3 = cpupins["PE3"]
+	PE4 = cpupins["PE4"]
+	PE5 = cpupins["PE5"]
+	PE6 = cpupins["PE6"]
+	PE7 = cpupins["PE7"]
+	PE8 = cpupins["PE8"]
+	PE9 = cpupins["PE9"]
+	PE10 = cpupins["PE10"]
+	PE11 = cpupins["PE11"]
+	PE12 = cpupins["PE12"]
+	PE13 = cpupins["PE13"]
+	PE14 = cpupins["PE14"]
+	PE15 = cpupins["PE15"]
+	PE16 = cpupins["PE16"]
+	PE17 = cpupins["PE17"]
+	PE18 = cpupins["PE18"]
+	PE19 = cpupins["PE19"]
+	PE20 = cpupins["PE20"]
+	PE21 = cpupins["PE21"]
+	PE22 = cpupins["PE22"]
+	PE23 = cpupins["PE23"]
+	PE24 = cpupins["PE24"]
+	PE25 = cpupins["PE25"]
+	PE26 = cpupins["PE26"]
+	PE27 = cpupins["PE27"]
+	PE28 = cpupins["PE28"]
+	PE29 = cpupins["PE29"]
+	PE30 = cpupins["PE30"]
+	PE31 = cpupins["PE31"]
+	GPIO0_0 = cpupins["GPIO0_0"]
+	GPIO0_1 = cpupins["GPIO0_1"]
+	GPIO0_2 = cpupins["GPIO0_2"]
+	GPIO0_3 = cpupins["GPIO0_3"]
+	GPIO0_4 = cpupins["GPIO0_4"]
+	GPIO0_5 = cpupins["GPIO0_5"]
+	GPIO0_6 = cpupins["GPIO0_6"]
+	GPIO0_7 = cpupins["GPIO0_7"]
+	GPIO0_8 = cpupins["GPIO0_8"]
+	GPIO0_9 = cpupins["GPIO0_9"]
+	GPIO0_10 = cpupins["GPIO0_10"]
+	GPIO0_11 = cpupins["GPIO0_11"]
+	GPIO0_12 = cpupins["GPIO0_12"]
+	GPIO0_13 = cpupins["GPIO0_13"]
+	GPIO1_0 = cpupins["GPIO1_0"]
+	GPIO1_1 = cpupins["GPIO1_1"]
+	GPIO1_2 = cpupins["GPIO1_2"]
+	GPIO1_3 = cpupins["GPIO1_3"]
+	GPIO1_4 = cpupins["GPIO1_4"]
+	GPIO1_5 = cpupins["GPIO1_5"]
+	GPIO1_6 = cpupins["GPIO1_6"]
+	GPIO1_7 = cpupins["GPIO1_7"]
+	GPIO1_8 = cpupins["GPIO1_8"]
+	GPIO1_9 = cpupins["GPIO1_9"]
+	GPIO1_10 = cpupins["GPIO1_10"]
+	GPIO1_11 = cpupins["GPIO1_11"]
+	GPIO1_12 = cpupins["GPIO1_12"]
+	GPIO1_13 = cpupins["GPIO1_13"]
+	GPIO1_14 = cpupins["GPIO1_14"]
+	GPIO1_15 = cpupins["GPIO1_15"]
+	GPIO1_16 = cpupins["GPIO1_16"]
+	GPIO1_17 = cpupins["GPIO1_17"]
+	GPIO1_18 = cpupins["GPIO1_18"]
+	GPIO1_19 = cpupins["GPIO1_19"]
+	GPIO1_20 = cpupins["GPIO1_20"]
+	GPIO1_21 = cpupins["GPIO1_21"]
+	GPIO1_22 = cpupins["GPIO1_22"]
+	GPIO1_23 = cpupins["GPIO1_23"]
+	GPIO2_0 = cpupins["GPIO2_0"]
+	GPIO2_1 = cpupins["GPIO2_1"]
+	GPIO2_2 = cpupins["GPIO2_2"]
+	GPIO2_3 = cpupins["GPIO2_3"]
+	GPIO2_4 = cpupins["GPIO2_4"]
+	GPIO2_5 = cpupins["GPIO2_5"]
+	GPIO2_6 = cpupins["GPIO2_6"]
+	GPIO2_7 = cpupins["GPIO2_7"]
+	GPIO2_8 = cpupins["GPIO2_8"]
+	GPIO2_9 = cpupins["GPIO2_9"]
+	GPIO2_10 = cpupins["GPIO2_10"]
+	GPIO2_11 = cpupins["GPIO2_11"]
+	GPIO2_12 = cpupins["GPIO2_12"]
+	GPIO2_13 = cpupins["GPIO2_13"]
+	GPIO2_14 = cpupins["GPIO2_14"]
+	GPIO2_15 = cpupins["GPIO2_15"]
+	GPIO2_16 = cpupins["GPIO2_16"]
+	GPIO2_17 = cpupins["GPIO2_17"]
+	GPIO2_18 = cpupins["GPIO2_18"]
+	GPIO2_19 = cpupins["GPIO2_19"]
+	GPIO2_20 = cpupins["GPIO2_20"]
+	GPIO2_21 = cpupins["GPIO2_21"]
+	GPIO2_22 = cpupins["GPIO2_22"]
+	GPIO2_23 = cpupins["GPIO2_23"]
+	GPIO2_24 = cpupins["GPIO2_24"]
+	GPIO2_25 = cpupins["GPIO2_25"]
+	GPIO2_26 = cpupins["GPIO2_26"]
+	GPIO2_27 = cpupins["GPIO2_27"]
+	GPIO2_28 = cpupins["GPIO2_28"]
+	GPIO2_29 = cpupins["GPIO2_29"]
+	GPIO2_30 = cpupins["GPIO2_30"]
+	GPIO2_31 = cpupins["GPIO2_31"]
+}
@@ -8,7 +8,7 @@ package mt7688
 import (
 	"errors"
 
-	"periph.io/x/host/v3/sysfs"
+	"github.com/s-mobi01/host/sysfs"
 )
 
 // driverGPIO implements periph.Driver.
@@ -0,0 +1,32 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package nanopi contains header definitions for the FriendlyElec NanoPi
+// boards.
+//
+// The supported models are the NanoPi NEO (Allwinner H3), NEO2 (Allwinner H5),
+// R2S (Rockchip RK3328) and R4S (Rockchip RK3399).
+//
+// The pins are mapped through sysfs-gpio using the SoC naming. On the
+// Allwinner boards the pin PG7 is GPIO 6*32+7 = 199. On the Rockchip boards
+// the pin GPIO2_B7 is GPIO 2*32+1*8+7 = 79.
+//
+// The 24 pins header of the NEO and NEO2 is registered as "P1". The R2S and
+// R4S have no GPIO header.
+//
+// The onboard LEDs and buttons are registered as aliases, e.g. LED_STATUS on
+// the NEO or LED_WAN and BUTTON_RESET on the R2S. Note that the LEDs are
+// usually claimed by the kernel LED driver; use package sysfs' LEDs to control
+// them in that case.
+//
+// Physical
+//
+// https://wiki.friendlyelec.com/wiki/index.php/NanoPi_NEO
+//
+// https://wiki.friendlyelec.com/wiki/index.php/NanoPi_NEO2
+//
+// https://wiki.friendlyelec.com/wiki/index.php/NanoPi_R2S
+//
+// https://wiki.friendlyelec.com/wiki/index.php/NanoPi_R4S
+package nanopi
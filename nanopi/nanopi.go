@@ -0,0 +1,280 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package nanopi
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+)
+
+// Model is a NanoPi board model.
+type Model int
+
+// Supported models.
+const (
+	Unknown Model = iota
+	NEO           // Allwinner H3
+	NEO2          // Allwinner H5
+	R2S           // Rockchip RK3328
+	R4S           // Rockchip RK3399
+)
+
+func (m Model) String() string {
+	switch m {
+	case NEO:
+		return "NanoPi NEO"
+	case NEO2:
+		return "NanoPi NEO2"
+	case R2S:
+		return "NanoPi R2S"
+	case R4S:
+		return "NanoPi R4S"
+	default:
+		return "Unknown"
+	}
+}
+
+// Present returns true if running on a supported NanoPi board.
+func Present() bool {
+	return Detect() != Unknown
+}
+
+// Detect returns the NanoPi model the host is running on.
+func Detect() Model {
+	if !isArm {
+		return Unknown
+	}
+	for _, c := range distro.DTCompatible() {
+		switch c {
+		case "friendlyarm,nanopi-neo":
+			return NEO
+		case "friendlyarm,nanopi-neo2":
+			return NEO2
+		case "friendlyarm,nanopi-r2s":
+			return R2S
+		case "friendlyarm,nanopi-r4s":
+			return R4S
+		}
+	}
+	return Unknown
+}
+
+// All the individual pins on the P1 header of the NEO and NEO2.
+var (
+	P1_1             = pin.V3_3     //
+	P1_2             = pin.V5       //
+	P1_3  gpio.PinIO = gpio.INVALID // I2C0_SDA
+	P1_4             = pin.V5       //
+	P1_5  gpio.PinIO = gpio.INVALID // I2C0_SCL
+	P1_6             = pin.GROUND   //
+	P1_7  gpio.PinIO = gpio.INVALID //
+	P1_8  gpio.PinIO = gpio.INVALID // UART1_TX
+	P1_9             = pin.GROUND   //
+	P1_10 gpio.PinIO = gpio.INVALID // UART1_RX
+	P1_11 gpio.PinIO = gpio.INVALID // UART2_TX
+	P1_12 gpio.PinIO = gpio.INVALID //
+	P1_13 gpio.PinIO = gpio.INVALID // UART2_RTS
+	P1_14            = pin.GROUND   //
+	P1_15 gpio.PinIO = gpio.INVALID // UART2_CTS
+	P1_16 gpio.PinIO = gpio.INVALID // UART1_RTS
+	P1_17            = pin.V3_3     //
+	P1_18 gpio.PinIO = gpio.INVALID // UART1_CTS
+	P1_19 gpio.PinIO = gpio.INVALID // SPI0_MOSI
+	P1_20            = pin.GROUND   //
+	P1_21 gpio.PinIO = gpio.INVALID // SPI0_MISO
+	P1_22 gpio.PinIO = gpio.INVALID // UART2_RX
+	P1_23 gpio.PinIO = gpio.INVALID // SPI0_CLK
+	P1_24 gpio.PinIO = gpio.INVALID // SPI0_CS0
+)
+
+// board describes a model.
+type board struct {
+	// p1 maps the header pin number to the SoC pin name. It is nil when the
+	// board has no GPIO header.
+	p1 map[int]string
+	// aliases maps the function name to the SoC pin name. It includes the
+	// onboard LEDs and buttons.
+	aliases map[string]string
+}
+
+// neoP1 is the header shared by the NEO and NEO2.
+var neoP1 = map[int]string{
+	3: "PA12", 5: "PA11", 7: "PG11", 8: "PG6", 10: "PG7", 11: "PA0",
+	12: "PA6", 13: "PA2", 15: "PA3", 16: "PG8", 18: "PG9", 19: "PC0",
+	21: "PC1", 22: "PA1", 23: "PC2", 24: "PC3",
+}
+
+var boards = map[Model]*board{
+	NEO: {
+		p1: neoP1,
+		aliases: map[string]string{
+			"I2C0_SDA":    "PA12",
+			"I2C0_SCL":    "PA11",
+			"SPI0_MOSI":   "PC0",
+			"SPI0_MISO":   "PC1",
+			"SPI0_CLK":    "PC2",
+			"SPI0_CS0":    "PC3",
+			"UART1_TX":    "PG6",
+			"UART1_RX":    "PG7",
+			"UART2_TX":    "PA0",
+			"UART2_RX":    "PA1",
+			"LED_STATUS":  "PA10",
+			"LED_POWER":   "PL10",
+			"BUTTON_USER": "PL3",
+		},
+	},
+	NEO2: {
+		p1: neoP1,
+		aliases: map[string]string{
+			"I2C0_SDA":   "PA12",
+			"I2C0_SCL":   "PA11",
+			"SPI0_MOSI":  "PC0",
+			"SPI0_MISO":  "PC1",
+			"SPI0_CLK":   "PC2",
+			"SPI0_CS0":   "PC3",
+			"UART1_TX":   "PG6",
+			"UART1_RX":   "PG7",
+			"UART2_TX":   "PA0",
+			"UART2_RX":   "PA1",
+			"LED_STATUS": "PA10",
+			"LED_POWER":  "PL10",
+		},
+	},
+	R2S: {
+		aliases: map[string]string{
+			"LED_SYS":      "GPIO0_A2",
+			"LED_LAN":      "GPIO2_B7",
+			"LED_WAN":      "GPIO2_C2",
+			"BUTTON_RESET": "GPIO0_A0",
+		},
+	},
+	R4S: {
+		aliases: map[string]string{
+			"LED_SYS":      "GPIO0_B5",
+			"LED_LAN":      "GPIO1_A1",
+			"LED_WAN":      "GPIO1_A0",
+			"BUTTON_RESET": "GPIO1_C6",
+		},
+	},
+}
+
+// sysfsNumber converts a SoC pin name into its sysfs GPIO number.
+//
+// It supports the Allwinner naming like "PG7" and the Rockchip naming like
+// "GPIO2_B7".
+func sysfsNumber(name string) (int, bool) {
+	if len(name) == 8 && name[:4] == "GPIO" && name[5] == '_' {
+		bank := int(name[4] - '0')
+		group := int(name[6] - 'A')
+		index := int(name[7] - '0')
+		if bank < 0 || bank > 9 || group < 0 || group > 3 || index < 0 || index > 7 {
+			return 0, false
+		}
+		return bank*32 + group*8 + index, true
+	}
+	if len(name) < 3 || name[0] != 'P' || name[1] < 'A' || name[1] > 'Z' {
+		return 0, false
+	}
+	n, err := strconv.Atoi(name[2:])
+	if err != nil || n < 0 || n >= 32 {
+		return 0, false
+	}
+	return int(name[1]-'A')*32 + n, true
+}
+
+// sysfsPin is a safe way to get a sysfs pin.
+func sysfsPin(name string) gpio.PinIO {
+	if n, ok := sysfsNumber(name); ok {
+		if p, ok := sysfs.Pins[n]; ok {
+			return p
+		}
+	}
+	return gpio.INVALID
+}
+
+// driver implements periph.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "nanopi"
+}
+
+func (d *driver) Prerequisites() []string {
+	return nil
+}
+
+func (d *driver) After() []string {
+	return []string{"sysfs-gpio"}
+}
+
+func (d *driver) Init() (bool, error) {
+	b := boards[Detect()]
+	if b == nil {
+		return false, errors.New("NanoPi board not detected")
+	}
+	if b.p1 != nil {
+		P1_3 = sysfsPin(b.p1[3])
+		P1_5 = sysfsPin(b.p1[5])
+		P1_7 = sysfsPin(b.p1[7])
+		P1_8 = sysfsPin(b.p1[8])
+		P1_10 = sysfsPin(b.p1[10])
+		P1_11 = sysfsPin(b.p1[11])
+		P1_12 = sysfsPin(b.p1[12])
+		P1_13 = sysfsPin(b.p1[13])
+		P1_15 = sysfsPin(b.p1[15])
+		P1_16 = sysfsPin(b.p1[16])
+		P1_18 = sysfsPin(b.p1[18])
+		P1_19 = sysfsPin(b.p1[19])
+		P1_21 = sysfsPin(b.p1[21])
+		P1_22 = sysfsPin(b.p1[22])
+		P1_23 = sysfsPin(b.p1[23])
+		P1_24 = sysfsPin(b.p1[24])
+
+		hdr := [][]pin.Pin{
+			{P1_1, P1_2},
+			{P1_3, P1_4},
+			{P1_5, P1_6},
+			{P1_7, P1_8},
+			{P1_9, P1_10},
+			{P1_11, P1_12},
+			{P1_13, P1_14},
+			{P1_15, P1_16},
+			{P1_17, P1_18},
+			{P1_19, P1_20},
+			{P1_21, P1_22},
+			{P1_23, P1_24},
+		}
+		if err := pinreg.Register("P1", hdr); err != nil {
+			return true, err
+		}
+	}
+	for alias, name := range b.aliases {
+		n, ok := sysfsNumber(name)
+		if !ok {
+			continue
+		}
+		if err := gpioreg.RegisterAlias(alias, strconv.Itoa(n)); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func init() {
+	if isArm {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
@@ -0,0 +1,18 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package netdev controls network interfaces via rtnetlink: bring a link up
+// or down, read its state and speed, set its MAC address or its CAN bitrate
+// and monitor its changes.
+//
+// It is meant for gateways that must watch their uplink alongside their
+// sensor buses. Changing an interface requires the CAP_NET_ADMIN capability,
+// normally running as root.
+//
+// Reference
+//
+// https://man7.org/linux/man-pages/man7/rtnetlink.7.html
+//
+// https://www.kernel.org/doc/Documentation/networking/operstates.txt
+package netdev
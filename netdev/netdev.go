@@ -0,0 +1,464 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package netdev
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// ByName returns the network interface with this name, e.g. "eth0".
+func ByName(name string) (*Interface, error) {
+	i, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("netdev: %v", err)
+	}
+	return &Interface{name: i.Name, index: i.Index}, nil
+}
+
+// OperState is the RFC 2863 operational state of a link.
+type OperState uint8
+
+// Operational states.
+const (
+	Unknown OperState = iota
+	NotPresent
+	Down
+	LowerLayerDown
+	Testing
+	Dormant
+	Up
+)
+
+func (o OperState) String() string {
+	switch o {
+	case Unknown:
+		return "Unknown"
+	case NotPresent:
+		return "NotPresent"
+	case Down:
+		return "Down"
+	case LowerLayerDown:
+		return "LowerLayerDown"
+	case Testing:
+		return "Testing"
+	case Dormant:
+		return "Dormant"
+	case Up:
+		return "Up"
+	default:
+		return fmt.Sprintf("OperState(%d)", o)
+	}
+}
+
+// LinkState is the state of a link.
+type LinkState struct {
+	Name  string
+	Index int
+	// AdminUp is true when the interface was brought up.
+	AdminUp bool
+	// Carrier is true when the physical link is established, e.g. the cable
+	// is plugged in.
+	Carrier bool
+	// OperState is the resulting operational state; Up means the link is
+	// usable.
+	OperState OperState
+	MAC       net.HardwareAddr
+	MTU       int
+}
+
+func (l LinkState) String() string {
+	return fmt.Sprintf("%s: %s carrier=%t admin=%t %s mtu=%d", l.Name, l.OperState, l.Carrier, l.AdminUp, l.MAC, l.MTU)
+}
+
+// Interface is a network interface.
+type Interface struct {
+	name  string
+	index int
+}
+
+func (i *Interface) String() string {
+	return i.name
+}
+
+// State returns the current state of the link.
+func (i *Interface) State() (LinkState, error) {
+	s, err := openSocket(0)
+	if err != nil {
+		return LinkState{}, fmt.Errorf("netdev: %v", err)
+	}
+	defer s.close()
+	seq := nextSeq()
+	if err := s.send(newLinkMsg(rtmGetLink, nlmFRequest, seq, i.index, 0, 0, nil)); err != nil {
+		return LinkState{}, fmt.Errorf("netdev: %v", err)
+	}
+	var buf [4096]byte
+	for {
+		n, err := s.recv(buf[:])
+		if err != nil {
+			return LinkState{}, fmt.Errorf("netdev: %v", err)
+		}
+		msgs, err := parseMessages(buf[:n])
+		if err != nil {
+			return LinkState{}, err
+		}
+		for _, m := range msgs {
+			if m.seq != seq {
+				continue
+			}
+			switch m.typ {
+			case nlmsgError:
+				return LinkState{}, parseError(m.data)
+			case rtmNewLink:
+				return parseLink(m.data)
+			}
+		}
+	}
+}
+
+// Speed returns the link speed in Mb/s as reported by the driver. It returns
+// an error when the link is down or the driver doesn't report it, e.g. for
+// wireless interfaces.
+func (i *Interface) Speed() (int, error) {
+	b, err := ioutil.ReadFile(sysClassNet + i.name + "/speed")
+	if err != nil {
+		return 0, fmt.Errorf("netdev: speed unavailable: %v", err)
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || v <= 0 {
+		return 0, errors.New("netdev: speed unknown")
+	}
+	return v, nil
+}
+
+// SetUp brings the interface up or down.
+func (i *Interface) SetUp(up bool) error {
+	var flags uint32
+	if up {
+		flags = iffUp
+	}
+	return i.change(flags, iffUp, nil)
+}
+
+// SetMAC sets the hardware address of the interface. Most drivers require
+// the interface to be down.
+func (i *Interface) SetMAC(mac net.HardwareAddr) error {
+	if len(mac) == 0 {
+		return errors.New("netdev: empty MAC address")
+	}
+	return i.change(0, 0, attr(iflaAddress, mac))
+}
+
+// SetCANBitrate sets the bitrate of a SocketCAN interface in bits per
+// second; the driver derives the bit timing from it. The interface must be
+// down.
+//
+// restart is the delay before the controller restarts automatically after a
+// bus-off condition; 0 disables the automatic restart.
+func (i *Interface) SetCANBitrate(bitrate int, restart time.Duration) error {
+	if bitrate <= 0 || bitrate > 1000000 {
+		return fmt.Errorf("netdev: invalid CAN bitrate %d", bitrate)
+	}
+	if restart < 0 {
+		return errors.New("netdev: invalid CAN restart delay")
+	}
+	// struct can_bittiming; only the bitrate is set.
+	var bt [32]byte
+	binary.LittleEndian.PutUint32(bt[0:], uint32(bitrate))
+	var ms [4]byte
+	binary.LittleEndian.PutUint32(ms[:], uint32(restart/time.Millisecond))
+	data := append(attr(iflaCANBitTiming, bt[:]), attr(iflaCANRestartMs, ms[:])...)
+	info := append(attr(iflaInfoKind, []byte("can")), attr(iflaInfoData, data)...)
+	return i.change(0, 0, attr(iflaLinkInfo, info))
+}
+
+// Monitor reports link state changes.
+type Monitor struct {
+	s      socket
+	events chan LinkState
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// Watch returns a Monitor reporting the state changes of all the links.
+func Watch() (*Monitor, error) {
+	s, err := openSocket(rtmgrpLink)
+	if err != nil {
+		return nil, fmt.Errorf("netdev: %v", err)
+	}
+	m := &Monitor{s: s, events: make(chan LinkState, 16), done: make(chan struct{})}
+	m.wg.Add(1)
+	go m.run()
+	return m, nil
+}
+
+// Events returns the channel receiving the state of a link each time it
+// changes. Events are dropped if the channel is not drained.
+func (m *Monitor) Events() <-chan LinkState {
+	return m.events
+}
+
+// Close stops the monitor and closes the channel.
+func (m *Monitor) Close() error {
+	select {
+	case <-m.done:
+		return nil
+	default:
+	}
+	close(m.done)
+	m.wg.Wait()
+	if err := m.s.close(); err != nil {
+		return fmt.Errorf("netdev: %v", err)
+	}
+	return nil
+}
+
+//
+
+func (m *Monitor) run() {
+	defer m.wg.Done()
+	defer close(m.events)
+	var buf [8192]byte
+	for {
+		select {
+		case <-m.done:
+			return
+		default:
+		}
+		// The socket has a receive timeout so that done is checked.
+		n, err := m.s.recv(buf[:])
+		if err == syscall.EAGAIN || err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		msgs, err := parseMessages(buf[:n])
+		if err != nil {
+			continue
+		}
+		for _, msg := range msgs {
+			if msg.typ != rtmNewLink {
+				continue
+			}
+			l, err := parseLink(msg.data)
+			if err != nil {
+				continue
+			}
+			select {
+			case m.events <- l:
+			default:
+			}
+		}
+	}
+}
+
+// change sends a RTM_NEWLINK request and waits for the acknowledgement.
+func (i *Interface) change(flags, mask uint32, attrs []byte) error {
+	s, err := openSocket(0)
+	if err != nil {
+		return fmt.Errorf("netdev: %v", err)
+	}
+	defer s.close()
+	seq := nextSeq()
+	if err := s.send(newLinkMsg(rtmNewLink, nlmFRequest|nlmFAck, seq, i.index, flags, mask, attrs)); err != nil {
+		return fmt.Errorf("netdev: %v", err)
+	}
+	var buf [4096]byte
+	for {
+		n, err := s.recv(buf[:])
+		if err != nil {
+			return fmt.Errorf("netdev: %v", err)
+		}
+		msgs, err := parseMessages(buf[:n])
+		if err != nil {
+			return err
+		}
+		for _, m := range msgs {
+			if m.seq == seq && m.typ == nlmsgError {
+				return parseError(m.data)
+			}
+		}
+	}
+}
+
+// socket is a NETLINK_ROUTE socket; it is mocked in tests.
+type socket interface {
+	send(b []byte) error
+	recv(b []byte) (int, error)
+	close() error
+}
+
+// openSocket opens a socket subscribed to the multicast groups.
+var openSocket = openRouteSocket
+
+// sysClassNet is overridden in tests.
+var sysClassNet = "/sys/class/net/"
+
+var seq uint32
+
+func nextSeq() uint32 {
+	return atomic.AddUint32(&seq, 1)
+}
+
+// message is a netlink message.
+type message struct {
+	typ  uint16
+	seq  uint32
+	data []byte
+}
+
+// newLinkMsg returns a netlink message with an ifinfomsg header followed by
+// the attributes.
+func newLinkMsg(typ, flags uint16, seq uint32, index int, ifFlags, ifChange uint32, attrs []byte) []byte {
+	b := make([]byte, nlmsgHdrLen+ifInfoMsgLen+len(attrs))
+	binary.LittleEndian.PutUint32(b[0:], uint32(len(b)))
+	binary.LittleEndian.PutUint16(b[4:], typ)
+	binary.LittleEndian.PutUint16(b[6:], flags)
+	binary.LittleEndian.PutUint32(b[8:], seq)
+	// ifinfomsg; family is AF_UNSPEC and type is unused.
+	i := b[nlmsgHdrLen:]
+	binary.LittleEndian.PutUint32(i[4:], uint32(int32(index)))
+	binary.LittleEndian.PutUint32(i[8:], ifFlags)
+	binary.LittleEndian.PutUint32(i[12:], ifChange)
+	copy(i[ifInfoMsgLen:], attrs)
+	return b
+}
+
+// attr returns an encoded rtattr.
+func attr(typ uint16, data []byte) []byte {
+	l := 4 + len(data)
+	b := make([]byte, align(l))
+	binary.LittleEndian.PutUint16(b[0:], uint16(l))
+	binary.LittleEndian.PutUint16(b[2:], typ)
+	copy(b[4:], data)
+	return b
+}
+
+// parseMessages splits a datagram into netlink messages.
+func parseMessages(b []byte) ([]message, error) {
+	var out []message
+	for len(b) >= nlmsgHdrLen {
+		l := int(binary.LittleEndian.Uint32(b[0:]))
+		if l < nlmsgHdrLen || l > len(b) {
+			return nil, errors.New("netdev: invalid netlink message")
+		}
+		out = append(out, message{
+			typ:  binary.LittleEndian.Uint16(b[4:]),
+			seq:  binary.LittleEndian.Uint32(b[8:]),
+			data: b[nlmsgHdrLen:l],
+		})
+		if align(l) >= len(b) {
+			break
+		}
+		b = b[align(l):]
+	}
+	return out, nil
+}
+
+// parseError decodes a NLMSG_ERROR payload; a zero error is an
+// acknowledgement.
+func parseError(b []byte) error {
+	if len(b) < 4 {
+		return errors.New("netdev: invalid netlink error")
+	}
+	if e := int32(binary.LittleEndian.Uint32(b)); e != 0 {
+		err := syscall.Errno(-e)
+		if err == syscall.EPERM {
+			return fmt.Errorf("netdev: need more access, try as root: %v", err)
+		}
+		return fmt.Errorf("netdev: %v", err)
+	}
+	return nil
+}
+
+// parseLink decodes a RTM_NEWLINK payload.
+func parseLink(b []byte) (LinkState, error) {
+	var l LinkState
+	if len(b) < ifInfoMsgLen {
+		return l, errors.New("netdev: invalid link message")
+	}
+	l.Index = int(int32(binary.LittleEndian.Uint32(b[4:])))
+	flags := binary.LittleEndian.Uint32(b[8:])
+	l.AdminUp = flags&iffUp != 0
+	l.Carrier = flags&iffLowerUp != 0
+	for a := b[ifInfoMsgLen:]; len(a) >= 4; {
+		al := int(binary.LittleEndian.Uint16(a[0:]))
+		if al < 4 || al > len(a) {
+			return l, errors.New("netdev: invalid link attribute")
+		}
+		data := a[4:al]
+		switch binary.LittleEndian.Uint16(a[2:]) {
+		case iflaAddress:
+			l.MAC = append(net.HardwareAddr(nil), data...)
+		case iflaIfname:
+			l.Name = strings.TrimRight(string(data), "\x00")
+		case iflaMTU:
+			if len(data) >= 4 {
+				l.MTU = int(binary.LittleEndian.Uint32(data))
+			}
+		case iflaOperState:
+			if len(data) >= 1 {
+				l.OperState = OperState(data[0])
+			}
+		case iflaCarrier:
+			if len(data) >= 1 {
+				l.Carrier = data[0] != 0
+			}
+		}
+		if align(al) >= len(a) {
+			break
+		}
+		a = a[align(al):]
+	}
+	return l, nil
+}
+
+func align(l int) int {
+	return (l + 3) &^ 3
+}
+
+// Constants from include/uapi/linux/netlink.h, rtnetlink.h, if_link.h and
+// if.h. Netlink uses the native endianness, little endian on all the
+// supported hosts.
+const (
+	nlmsgHdrLen  = 16
+	ifInfoMsgLen = 16
+
+	nlmFRequest = 0x1
+	nlmFAck     = 0x4
+
+	nlmsgError = 0x2
+	rtmNewLink = 16
+	rtmGetLink = 18
+
+	rtmgrpLink = 0x1
+
+	iflaAddress   = 1
+	iflaIfname    = 3
+	iflaMTU       = 4
+	iflaOperState = 16
+	iflaLinkInfo  = 18
+	iflaCarrier   = 33
+
+	iflaInfoKind = 1
+	iflaInfoData = 2
+
+	// From include/uapi/linux/can/netlink.h.
+	iflaCANBitTiming = 1
+	iflaCANRestartMs = 6
+
+	iffUp      = 0x1
+	iffLowerUp = 0x10000
+)
@@ -0,0 +1,52 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package netdev
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// routeSocket is a NETLINK_ROUTE socket.
+type routeSocket struct {
+	fd int
+}
+
+// openRouteSocket returns a socket subscribed to the multicast groups. A
+// subscribed socket has a receive timeout so the reader can be stopped.
+func openRouteSocket(groups uint32) (socket, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netlink socket: %v", err)
+	}
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: groups}); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("failed to bind netlink socket: %v", err)
+	}
+	if groups != 0 {
+		tv := syscall.NsecToTimeval(200 * 1000 * 1000)
+		if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv); err != nil {
+			syscall.Close(fd)
+			return nil, fmt.Errorf("failed to set netlink socket timeout: %v", err)
+		}
+	}
+	return &routeSocket{fd: fd}, nil
+}
+
+func (s *routeSocket) send(w []byte) error {
+	return syscall.Sendto(s.fd, w, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK})
+}
+
+func (s *routeSocket) recv(r []byte) (int, error) {
+	n, _, err := syscall.Recvfrom(s.fd, r, 0)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *routeSocket) close() error {
+	return syscall.Close(s.fd)
+}
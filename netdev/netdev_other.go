@@ -0,0 +1,14 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package netdev
+
+import "errors"
+
+func openRouteSocket(groups uint32) (socket, error) {
+	return nil, errors.New("netlink sockets are not supported")
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package netdev
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestInterface_State(t *testing.T) {
+	f := &fakeSocket{}
+	f.reply = func(req []byte) [][]byte {
+		mac := attr(iflaAddress, []byte{2, 0, 0, 0, 0, 1})
+		name := attr(iflaIfname, []byte("eth0\x00"))
+		mtu := attr(iflaMTU, []byte{0xDC, 0x05, 0, 0})
+		oper := attr(iflaOperState, []byte{byte(Up)})
+		attrs := append(append(append(mac, name...), mtu...), oper...)
+		// An unrelated message precedes the reply.
+		return [][]byte{
+			newLinkMsg(rtmNewLink, 0, 0, 1, 0, 0, nil),
+			newLinkMsg(rtmNewLink, 0, seqOf(req), 2, iffUp|iffLowerUp, 0, attrs),
+		}
+	}
+	defer f.install()()
+	i := &Interface{name: "eth0", index: 2}
+	l, err := i.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Index != 2 {
+		t.Fatal(l.Index)
+	}
+	if s := l.String(); s != "eth0: Up carrier=true admin=true 02:00:00:00:00:01 mtu=1500" {
+		t.Fatal(s)
+	}
+	if typ := binary.LittleEndian.Uint16(f.sent[0][4:]); typ != rtmGetLink {
+		t.Fatal(typ)
+	}
+	if !f.closed {
+		t.Fatal("socket not closed")
+	}
+}
+
+func TestInterface_SetUp(t *testing.T) {
+	f := &fakeSocket{}
+	f.reply = func(req []byte) [][]byte {
+		return [][]byte{errMsg(seqOf(req), 0)}
+	}
+	defer f.install()()
+	i := &Interface{name: "eth0", index: 3}
+	if err := i.SetUp(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := i.SetUp(false); err != nil {
+		t.Fatal(err)
+	}
+	up, down := f.sent[0][nlmsgHdrLen:], f.sent[1][nlmsgHdrLen:]
+	if binary.LittleEndian.Uint32(up[4:]) != 3 || binary.LittleEndian.Uint32(up[8:]) != iffUp || binary.LittleEndian.Uint32(up[12:]) != iffUp {
+		t.Fatalf("%x", up)
+	}
+	if binary.LittleEndian.Uint32(down[8:]) != 0 || binary.LittleEndian.Uint32(down[12:]) != iffUp {
+		t.Fatalf("%x", down)
+	}
+}
+
+func TestInterface_SetMAC(t *testing.T) {
+	f := &fakeSocket{}
+	f.reply = func(req []byte) [][]byte {
+		return [][]byte{errMsg(seqOf(req), -int32(syscall.EPERM))}
+	}
+	defer f.install()()
+	i := &Interface{name: "eth0", index: 3}
+	if err := i.SetMAC(nil); err == nil {
+		t.Fatal("empty MAC")
+	}
+	if err := i.SetMAC(net.HardwareAddr{2, 0, 0, 0, 0, 1}); err == nil {
+		t.Fatal("permission denied")
+	}
+	a := f.sent[0][nlmsgHdrLen+ifInfoMsgLen:]
+	if !bytes.Equal(a, []byte{10, 0, iflaAddress, 0, 2, 0, 0, 0, 0, 1, 0, 0}) {
+		t.Fatalf("%x", a)
+	}
+}
+
+func TestInterface_SetCANBitrate(t *testing.T) {
+	f := &fakeSocket{}
+	f.reply = func(req []byte) [][]byte {
+		return [][]byte{errMsg(seqOf(req), 0)}
+	}
+	defer f.install()()
+	i := &Interface{name: "can0", index: 4}
+	if err := i.SetCANBitrate(0, 0); err == nil {
+		t.Fatal("invalid bitrate")
+	}
+	if err := i.SetCANBitrate(500000, -1); err == nil {
+		t.Fatal("invalid restart")
+	}
+	if err := i.SetCANBitrate(500000, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	a := f.sent[0][nlmsgHdrLen+ifInfoMsgLen:]
+	want := []byte{
+		// IFLA_LINKINFO
+		60, 0, iflaLinkInfo, 0,
+		// IFLA_INFO_KIND
+		7, 0, iflaInfoKind, 0, 'c', 'a', 'n', 0,
+		// IFLA_INFO_DATA
+		48, 0, iflaInfoData, 0,
+		// IFLA_CAN_BITTIMING
+		36, 0, iflaCANBitTiming, 0, 0x20, 0xA1, 0x07, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		// IFLA_CAN_RESTART_MS
+		8, 0, iflaCANRestartMs, 0, 100, 0, 0, 0,
+	}
+	if !bytes.Equal(a, want) {
+		t.Fatalf("%x", a)
+	}
+}
+
+func TestInterface_Speed(t *testing.T) {
+	d, err := ioutil.TempDir("", "netdev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	old := sysClassNet
+	sysClassNet = d + "/"
+	defer func() { sysClassNet = old }()
+	if err := os.MkdirAll(filepath.Join(d, "eth0"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	i := &Interface{name: "eth0"}
+	if _, err := i.Speed(); err == nil {
+		t.Fatal("missing file")
+	}
+	if err := ioutil.WriteFile(filepath.Join(d, "eth0", "speed"), []byte("-1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := i.Speed(); err == nil {
+		t.Fatal("link down")
+	}
+	if err := ioutil.WriteFile(filepath.Join(d, "eth0", "speed"), []byte("1000\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := i.Speed(); err != nil || v != 1000 {
+		t.Fatal(v, err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	f := &fakeSocket{
+		pending: [][]byte{
+			newLinkMsg(rtmNewLink, 0, 0, 2, iffUp, 0, attr(iflaOperState, []byte{byte(LowerLayerDown)})),
+		},
+	}
+	defer f.install()()
+	m, err := Watch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case l := <-m.Events():
+		if l.Index != 2 || l.OperState != LowerLayerDown || l.Carrier {
+			t.Fatal(l)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("no event")
+	}
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-m.Events(); ok {
+		t.Fatal("channel not closed")
+	}
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseMessages(t *testing.T) {
+	if _, err := parseMessages([]byte{100, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}); err == nil {
+		t.Fatal("truncated")
+	}
+	if _, err := parseLink([]byte{0}); err == nil {
+		t.Fatal("truncated")
+	}
+	if _, err := parseLink(append(make([]byte, ifInfoMsgLen), 2, 0, 0, 0)); err == nil {
+		t.Fatal("invalid attribute")
+	}
+	if err := parseError(nil); err == nil {
+		t.Fatal("truncated")
+	}
+}
+
+func TestOperState_String(t *testing.T) {
+	if s := Dormant.String(); s != "Dormant" {
+		t.Fatal(s)
+	}
+	if s := OperState(10).String(); s != "OperState(10)" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+// fakeSocket answers each request with reply() and otherwise returns the
+// pending messages, then times out.
+type fakeSocket struct {
+	reply   func(req []byte) [][]byte
+	sent    [][]byte
+	pending [][]byte
+	closed  bool
+}
+
+func (f *fakeSocket) install() func() {
+	old := openSocket
+	openSocket = func(groups uint32) (socket, error) {
+		return f, nil
+	}
+	return func() { openSocket = old }
+}
+
+func (f *fakeSocket) send(b []byte) error {
+	f.sent = append(f.sent, append([]byte(nil), b...))
+	if f.reply == nil {
+		return errors.New("unexpected request")
+	}
+	f.pending = append(f.pending, f.reply(b)...)
+	return nil
+}
+
+func (f *fakeSocket) recv(b []byte) (int, error) {
+	if len(f.pending) == 0 {
+		time.Sleep(time.Millisecond)
+		return 0, syscall.EAGAIN
+	}
+	n := copy(b, f.pending[0])
+	f.pending = f.pending[1:]
+	return n, nil
+}
+
+func (f *fakeSocket) close() error {
+	f.closed = true
+	return nil
+}
+
+func seqOf(req []byte) uint32 {
+	return binary.LittleEndian.Uint32(req[8:])
+}
+
+func errMsg(seq uint32, errno int32) []byte {
+	b := make([]byte, nlmsgHdrLen+4)
+	binary.LittleEndian.PutUint32(b[0:], uint32(len(b)))
+	binary.LittleEndian.PutUint16(b[4:], nlmsgError)
+	binary.LittleEndian.PutUint32(b[8:], seq)
+	binary.LittleEndian.PutUint32(b[nlmsgHdrLen:], uint32(errno))
+	return b
+}
@@ -10,7 +10,7 @@ import (
 	"fmt"
 	"sync"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3/onewire"
 	"periph.io/x/conn/v3/onewire/onewirereg"
 )
@@ -517,7 +517,7 @@ func (o openerOneWire) Open() (onewire.BusCloser, error) {
 
 func init() {
 	if isLinux {
-		driverreg.MustRegister(&drvOneWire)
+		hostcfg.MustRegister(&drvOneWire)
 	}
 }
 
@@ -0,0 +1,31 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package odroid contains header definitions for Hardkernel's ODROID boards
+// released after the C1: ODROID-C2, C4, N2/N2+ and M1.
+//
+// The C0/C1/C1+ are handled by package odroidc1.
+//
+// The C2 uses an Amlogic S905 ("meson-gxbb"), the C4 an Amlogic S905X3
+// ("meson-sm1"), the N2 an Amlogic S922X ("meson-g12b") and the M1 a Rockchip
+// RK3568. No memory-mapped GPIO driver exists for these processors, so the
+// header pins are mapped onto the pins exported by sysfs-gpio. The pins are
+// resolved from the label of their GPIO chip and their offset on it, since
+// the kernel assigns the sysfs GPIO numbers dynamically.
+//
+// All these boards have a 40 pins J2 header which is rPi compatible, except
+// for the two analog pins (37 and 40) and the 1.8V output on pin 38. The two
+// I²C buses are on pins 3/5 and 27/28 and the SPI bus is on pins
+// 19/21/23/24.
+//
+// # References
+//
+// C2: https://wiki.odroid.com/odroid-c2/hardware/expansion_connectors
+//
+// C4: https://wiki.odroid.com/odroid-c4/hardware/expansion_connectors
+//
+// N2: https://wiki.odroid.com/odroid-n2/hardware/expansion_connectors
+//
+// M1: https://wiki.odroid.com/odroid-m1/hardware/expansion_connectors
+package odroid
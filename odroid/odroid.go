@@ -0,0 +1,379 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package odroid
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+)
+
+// Model is an ODROID board model supported by this package.
+type Model int
+
+// Supported models.
+const (
+	Unknown Model = iota
+	C2
+	C4
+	N2
+	M1
+)
+
+func (m Model) String() string {
+	switch m {
+	case C2:
+		return "ODROID-C2"
+	case C4:
+		return "ODROID-C4"
+	case N2:
+		return "ODROID-N2"
+	case M1:
+		return "ODROID-M1"
+	default:
+		return "Unknown"
+	}
+}
+
+// The J2 header is rPi compatible, except for the two analog pins and the 1.8V
+// output.
+//
+// The pins are initialized once the board is detected; the comments list the
+// function as labeled on the C4, which is shared with the N2 and M1.
+var (
+	J2_1             = pin.V3_3     // 3.3V
+	J2_2             = pin.V5       // 5V
+	J2_3  gpio.PinIO = gpio.INVALID // I2C0_SDA
+	J2_4             = pin.V5       // 5V
+	J2_5  gpio.PinIO = gpio.INVALID // I2C0_SCL
+	J2_6             = pin.GROUND   //
+	J2_7  gpio.PinIO = gpio.INVALID //
+	J2_8  gpio.PinIO = gpio.INVALID // UART_TX
+	J2_9             = pin.GROUND   //
+	J2_10 gpio.PinIO = gpio.INVALID // UART_RX
+	J2_11 gpio.PinIO = gpio.INVALID //
+	J2_12 gpio.PinIO = gpio.INVALID // PWM
+	J2_13 gpio.PinIO = gpio.INVALID //
+	J2_14            = pin.GROUND   //
+	J2_15 gpio.PinIO = gpio.INVALID //
+	J2_16 gpio.PinIO = gpio.INVALID //
+	J2_17            = pin.V3_3     //
+	J2_18 gpio.PinIO = gpio.INVALID //
+	J2_19 gpio.PinIO = gpio.INVALID // SPI0_MOSI
+	J2_20            = pin.GROUND   //
+	J2_21 gpio.PinIO = gpio.INVALID // SPI0_MISO
+	J2_22 gpio.PinIO = gpio.INVALID //
+	J2_23 gpio.PinIO = gpio.INVALID // SPI0_CLK
+	J2_24 gpio.PinIO = gpio.INVALID // SPI0_CS0
+	J2_25            = pin.GROUND   //
+	J2_26 gpio.PinIO = gpio.INVALID //
+	J2_27 gpio.PinIO = gpio.INVALID // I2C1_SDA
+	J2_28 gpio.PinIO = gpio.INVALID // I2C1_SCL
+	J2_29 gpio.PinIO = gpio.INVALID //
+	J2_30            = pin.GROUND   //
+	J2_31 gpio.PinIO = gpio.INVALID //
+	J2_32 gpio.PinIO = gpio.INVALID //
+	J2_33 gpio.PinIO = gpio.INVALID // PWM
+	J2_34            = pin.GROUND   //
+	J2_35 gpio.PinIO = gpio.INVALID // PWM
+	J2_36 gpio.PinIO = gpio.INVALID //
+	J2_37            = pin.INVALID  // Analog input; not supported yet.
+	J2_38            = pin.V1_8     //
+	J2_39            = pin.GROUND   //
+	J2_40            = pin.INVALID  // See above.
+)
+
+// Present returns true if running on a Hardkernel ODROID-C2, C4, N2 or M1
+// board.
+//
+// Use package odroidc1 for the older C0, C1 and C1+ boards.
+func Present() bool {
+	return Detect() != Unknown
+}
+
+// Detect returns the ODROID model the host is running on.
+//
+// It looks for the "hardkernel,odroid-*" compatible string in the device tree
+// and falls back to the model string, then to the "Hardware" line in cpuinfo
+// as found in the vendor kernels.
+func Detect() Model {
+	if !isArm {
+		return Unknown
+	}
+	for _, c := range distro.DTCompatible() {
+		if m := modelFromString(c); m != Unknown {
+			return m
+		}
+	}
+	if m := modelFromString(distro.DTModel()); m != Unknown {
+		return m
+	}
+	return modelFromString(distro.CPUInfo()["Hardware"])
+}
+
+// modelFromString returns the model matching a device tree compatible or
+// model string.
+func modelFromString(s string) Model {
+	s = strings.ToLower(s)
+	if !strings.Contains(s, "odroid") {
+		return Unknown
+	}
+	switch {
+	case strings.Contains(s, "odroid-c2"):
+		return C2
+	case strings.Contains(s, "odroid-c4"):
+		return C4
+	case strings.Contains(s, "odroid-n2"):
+		// Includes the N2+ ("odroid-n2-plus").
+		return N2
+	case strings.Contains(s, "odroid-m1"):
+		return M1
+	}
+	return Unknown
+}
+
+// line is a GPIO line, as the label of its chip and its offset on the chip.
+//
+// The zero value means the pin is not a GPIO.
+type line struct {
+	chip   string
+	offset int
+}
+
+// number returns the sysfs GPIO number of l, given the sysfs GPIO number of
+// the first line of each chip keyed by chip label, or -1 if the chip is not
+// found.
+func (l line) number(bases map[string]int) int {
+	if l.chip == "" {
+		return -1
+	}
+	base, ok := bases[l.chip]
+	if !ok {
+		return -1
+	}
+	return base + l.offset
+}
+
+// board describes the mapping of the J2 header onto GPIO lines.
+//
+// The index is the header pin number.
+type board struct {
+	j2      [41]line
+	aliases map[string]line
+}
+
+// periphs is the label of the Amlogic GPIO chip of the peripheral banks.
+const periphs = "periphs-banks"
+
+var boards = map[Model]*board{
+	// GPIODV_0 is at offset 45, GPIOY_0 at 75 and GPIOX_0 at 92.
+	C2: {
+		j2: [41]line{
+			3: {periphs, 69}, 5: {periphs, 70}, 7: {periphs, 113},
+			8: {periphs, 104}, 10: {periphs, 105}, 11: {periphs, 111},
+			12: {periphs, 102}, 13: {periphs, 103}, 15: {periphs, 101},
+			16: {periphs, 100}, 18: {periphs, 97}, 19: {periphs, 99},
+			21: {periphs, 96}, 22: {periphs, 95}, 23: {periphs, 94},
+			24: {periphs, 93}, 26: {periphs, 89}, 27: {periphs, 71},
+			28: {periphs, 72}, 29: {periphs, 92}, 31: {periphs, 83},
+			32: {periphs, 88}, 33: {periphs, 98}, 35: {periphs, 78},
+			36: {periphs, 82},
+		},
+		aliases: map[string]line{
+			"I2C0_SDA": {periphs, 69},
+			"I2C0_SCL": {periphs, 70},
+			"I2C1_SDA": {periphs, 71},
+			"I2C1_SCL": {periphs, 72},
+			"I2CA_SDA": {periphs, 69},
+			"I2CA_SCL": {periphs, 70},
+			"I2CB_SDA": {periphs, 71},
+			"I2CB_SCL": {periphs, 72},
+		},
+	},
+	// GPIOA_0 is at offset 49 and GPIOX_0 at 65.
+	C4: {
+		j2: [41]line{
+			3: {periphs, 82}, 5: {periphs, 83}, 7: {periphs, 70}, 8: {periphs, 77},
+			10: {periphs, 78}, 11: {periphs, 68}, 12: {periphs, 81},
+			13: {periphs, 69}, 15: {periphs, 72}, 16: {periphs, 65},
+			18: {periphs, 66}, 19: {periphs, 73}, 21: {periphs, 74},
+			22: {periphs, 67}, 23: {periphs, 76}, 24: {periphs, 75},
+			26: {periphs, 53}, 27: {periphs, 63}, 28: {periphs, 64},
+			29: {periphs, 79}, 31: {periphs, 80}, 32: {periphs, 61},
+			33: {periphs, 71}, 35: {periphs, 84}, 36: {periphs, 62},
+		},
+		aliases: map[string]line{
+			"I2C0_SDA":  {periphs, 82},
+			"I2C0_SCL":  {periphs, 83},
+			"I2C1_SDA":  {periphs, 63},
+			"I2C1_SCL":  {periphs, 64},
+			"SPI0_MOSI": {periphs, 73},
+			"SPI0_MISO": {periphs, 74},
+			"SPI0_CLK":  {periphs, 76},
+			"SPI0_CS0":  {periphs, 75},
+		},
+	},
+	N2: {
+		j2: [41]line{
+			3: {periphs, 82}, 5: {periphs, 83}, 7: {periphs, 62}, 8: {periphs, 77},
+			10: {periphs, 78}, 11: {periphs, 68}, 12: {periphs, 81},
+			13: {periphs, 69}, 15: {periphs, 72}, 16: {periphs, 65},
+			18: {periphs, 66}, 19: {periphs, 73}, 21: {periphs, 74},
+			22: {periphs, 67}, 23: {periphs, 76}, 24: {periphs, 75},
+			26: {periphs, 53}, 27: {periphs, 63}, 28: {periphs, 64},
+			29: {periphs, 79}, 31: {periphs, 80}, 32: {periphs, 61},
+			33: {periphs, 70}, 35: {periphs, 71}, 36: {periphs, 84},
+		},
+		aliases: map[string]line{
+			"I2C0_SDA":  {periphs, 82},
+			"I2C0_SCL":  {periphs, 83},
+			"I2C1_SDA":  {periphs, 63},
+			"I2C1_SCL":  {periphs, 64},
+			"SPI0_MOSI": {periphs, 73},
+			"SPI0_MISO": {periphs, 74},
+			"SPI0_CLK":  {periphs, 76},
+			"SPI0_CS0":  {periphs, 75},
+		},
+	},
+	// One chip per bank of 32 lines, e.g. GPIO3_B6 is gpio3 offset 14.
+	M1: {
+		j2: [41]line{
+			3: {"gpio3", 14}, 5: {"gpio3", 13}, 7: {"gpio0", 14}, 8: {"gpio3", 24},
+			10: {"gpio3", 25}, 11: {"gpio0", 16}, 12: {"gpio0", 18},
+			13: {"gpio0", 17}, 15: {"gpio3", 17}, 16: {"gpio3", 18},
+			18: {"gpio3", 19}, 19: {"gpio4", 10}, 21: {"gpio4", 8},
+			22: {"gpio3", 20}, 23: {"gpio4", 11}, 24: {"gpio4", 12},
+			26: {"gpio3", 21}, 27: {"gpio3", 16}, 28: {"gpio3", 15},
+			29: {"gpio3", 23}, 31: {"gpio3", 22}, 32: {"gpio0", 19},
+			33: {"gpio0", 20}, 35: {"gpio0", 15}, 36: {"gpio3", 29},
+		},
+		aliases: map[string]line{
+			"I2C0_SDA":  {"gpio3", 14},
+			"I2C0_SCL":  {"gpio3", 13},
+			"I2C1_SDA":  {"gpio3", 16},
+			"I2C1_SCL":  {"gpio3", 15},
+			"SPI0_MOSI": {"gpio4", 10},
+			"SPI0_MISO": {"gpio4", 8},
+			"SPI0_CLK":  {"gpio4", 11},
+			"SPI0_CS0":  {"gpio4", 12},
+		},
+	},
+}
+
+// sysfsPin is a safe way to get a sysfs pin.
+func sysfsPin(n int) gpio.PinIO {
+	if n < 0 {
+		return gpio.INVALID
+	}
+	if p, ok := sysfs.Pins[n]; ok {
+		return p
+	}
+	return gpio.INVALID
+}
+
+// driver implements drivers.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "odroid"
+}
+
+func (d *driver) Prerequisites() []string {
+	return nil
+}
+
+func (d *driver) After() []string {
+	return []string{"sysfs-gpio"}
+}
+
+func (d *driver) Init() (bool, error) {
+	m := Detect()
+	if m == Unknown {
+		return false, errors.New("board Hardkernel ODROID-C2/C4/N2/M1 not detected")
+	}
+	b := boards[m]
+	// The kernel assigns the sysfs GPIO numbers dynamically, so the lines are
+	// resolved from their chip.
+	bases := sysfs.GPIOChipBases()
+	J2_3 = sysfsPin(b.j2[3].number(bases))
+	J2_5 = sysfsPin(b.j2[5].number(bases))
+	J2_7 = sysfsPin(b.j2[7].number(bases))
+	J2_8 = sysfsPin(b.j2[8].number(bases))   // usually taken by the UART driver
+	J2_10 = sysfsPin(b.j2[10].number(bases)) // usually taken by the UART driver
+	J2_11 = sysfsPin(b.j2[11].number(bases))
+	J2_12 = sysfsPin(b.j2[12].number(bases))
+	J2_13 = sysfsPin(b.j2[13].number(bases))
+	J2_15 = sysfsPin(b.j2[15].number(bases))
+	J2_16 = sysfsPin(b.j2[16].number(bases))
+	J2_18 = sysfsPin(b.j2[18].number(bases))
+	J2_19 = sysfsPin(b.j2[19].number(bases))
+	J2_21 = sysfsPin(b.j2[21].number(bases))
+	J2_22 = sysfsPin(b.j2[22].number(bases))
+	J2_23 = sysfsPin(b.j2[23].number(bases))
+	J2_24 = sysfsPin(b.j2[24].number(bases))
+	J2_26 = sysfsPin(b.j2[26].number(bases))
+	J2_27 = sysfsPin(b.j2[27].number(bases))
+	J2_28 = sysfsPin(b.j2[28].number(bases))
+	J2_29 = sysfsPin(b.j2[29].number(bases))
+	J2_31 = sysfsPin(b.j2[31].number(bases))
+	J2_32 = sysfsPin(b.j2[32].number(bases))
+	J2_33 = sysfsPin(b.j2[33].number(bases))
+	J2_35 = sysfsPin(b.j2[35].number(bases))
+	J2_36 = sysfsPin(b.j2[36].number(bases))
+
+	// J2 is the 40-pin rPi-compatible header.
+	J2 := [][]pin.Pin{
+		{J2_1, J2_2},
+		{J2_3, J2_4},
+		{J2_5, J2_6},
+		{J2_7, J2_8},
+		{J2_9, J2_10},
+		{J2_11, J2_12},
+		{J2_13, J2_14},
+		{J2_15, J2_16},
+		{J2_17, J2_18},
+		{J2_19, J2_20},
+		{J2_21, J2_22},
+		{J2_23, J2_24},
+		{J2_25, J2_26},
+		{J2_27, J2_28},
+		{J2_29, J2_30},
+		{J2_31, J2_32},
+		{J2_33, J2_34},
+		{J2_35, J2_36},
+		{J2_37, J2_38},
+		{J2_39, J2_40},
+	}
+	if err := pinreg.Register("J2", J2); err != nil {
+		return true, err
+	}
+	for alias, l := range b.aliases {
+		n := l.number(bases)
+		if n < 0 {
+			continue
+		}
+		if err := gpioreg.RegisterAlias(alias, strconv.Itoa(n)); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func init() {
+	if isArm {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
@@ -0,0 +1,58 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package odroid
+
+import "testing"
+
+func TestLine_number(t *testing.T) {
+	data := []struct {
+		m     Model
+		bases map[string]int
+		pin   int
+		want  int
+	}{
+		// Legacy numbering.
+		{C2, map[string]int{periphs: 136}, 3, 205},
+		{C4, map[string]int{periphs: 411, "aobus-banks": 496}, 3, 493},
+		{N2, map[string]int{periphs: 411, "aobus-banks": 496}, 36, 495},
+		{M1, map[string]int{"gpio0": 0, "gpio3": 96}, 3, 110},
+		{M1, map[string]int{"gpio0": 0, "gpio3": 96}, 7, 14},
+		// Dynamic bases, from 512 on kernels 6.x.
+		{C2, map[string]int{"aobus-banks": 512, periphs: 526}, 7, 639},
+		{C4, map[string]int{"aobus-banks": 512, periphs: 528}, 3, 610},
+		{C4, map[string]int{"aobus-banks": 512, periphs: 528}, 26, 581},
+		{N2, map[string]int{periphs: 528}, 7, 590},
+		{M1, map[string]int{"gpio0": 512, "gpio3": 608, "gpio4": 640}, 3, 622},
+		{M1, map[string]int{"gpio0": 512, "gpio3": 608, "gpio4": 640}, 19, 650},
+		// Not a GPIO.
+		{C4, map[string]int{periphs: 528}, 1, -1},
+		// Chip not found.
+		{M1, map[string]int{"gpio0": 512}, 3, -1},
+		{C4, map[string]int{}, 3, -1},
+	}
+	for i, d := range data {
+		if n := boards[d.m].j2[d.pin].number(d.bases); n != d.want {
+			t.Fatalf("#%d: %s J2_%d = %d, want %d", i, d.m, d.pin, n, d.want)
+		}
+	}
+}
+
+func TestBoards_aliases(t *testing.T) {
+	// The aliases point to header pins.
+	for m, b := range boards {
+		for alias, l := range b.aliases {
+			found := false
+			for _, p := range b.j2 {
+				if p == l {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("%s: %s is not on the header", m, alias)
+			}
+		}
+	}
+}
@@ -9,13 +9,13 @@ import (
 	"strconv"
 	"strings"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/pin"
 	"periph.io/x/conn/v3/pin/pinreg"
-	"periph.io/x/host/v3/distro"
-	"periph.io/x/host/v3/sysfs"
 )
 
 // The J2 header is rPi compatible, except for the two analog pins and the 1.8V
@@ -188,7 +188,7 @@ func (d *driver) Init() (bool, error) {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drv)
+		hostcfg.MustRegister(&drv)
 	}
 }
 
@@ -13,10 +13,10 @@ import (
 	"sort"
 	"strconv"
 
+	"github.com/s-mobi01/host/odroidc1"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/pin/pinreg"
-	"periph.io/x/host/v3/odroidc1"
 )
 
 // SmokeTest is imported by periph-smoketest.
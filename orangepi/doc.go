@@ -0,0 +1,26 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package orangepi contains header definitions for the Shenzhen Xunlong
+// Orange Pi boards.
+//
+// The supported models are the Orange Pi Zero (H2+), Zero 2 (H616), PC (H3)
+// and 3 LTS (H6). They all use Allwinner processors but only the A20, A64 and
+// R8 have a memory-mapped driver in package allwinner, so the pins are mapped
+// through sysfs-gpio using the Allwinner naming: the pin PG7 is GPIO
+// 6*32+7 = 199.
+//
+// The header is registered as "P1". The onboard LEDs and buttons are
+// registered as the aliases LED_STATUS, LED_POWER and BUTTON_POWER when the
+// board has them. Note that the LEDs are usually claimed by the kernel LED
+// driver; use package sysfs' LEDs to control them in that case.
+//
+// Physical
+//
+// http://www.orangepi.org/html/hardWare/computerAndMicrocontrollers/index.html
+//
+// https://linux-sunxi.org/Xunlong_Orange_Pi_Zero
+//
+// https://linux-sunxi.org/Xunlong_Orange_Pi_PC
+package orangepi
@@ -0,0 +1,336 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package orangepi
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+)
+
+// Model is an Orange Pi board model.
+type Model int
+
+// Supported models.
+const (
+	Unknown  Model = iota
+	Zero           // Allwinner H2+
+	Zero2          // Allwinner H616
+	PC             // Allwinner H3
+	ThreeLTS       // Allwinner H6
+)
+
+func (m Model) String() string {
+	switch m {
+	case Zero:
+		return "Orange Pi Zero"
+	case Zero2:
+		return "Orange Pi Zero 2"
+	case PC:
+		return "Orange Pi PC"
+	case ThreeLTS:
+		return "Orange Pi 3 LTS"
+	default:
+		return "Unknown"
+	}
+}
+
+// Present returns true if running on a supported Orange Pi board.
+func Present() bool {
+	return Detect() != Unknown
+}
+
+// Detect returns the Orange Pi model the host is running on.
+func Detect() Model {
+	if !isArm {
+		return Unknown
+	}
+	for _, c := range distro.DTCompatible() {
+		switch c {
+		case "xunlong,orangepi-zero":
+			return Zero
+		case "xunlong,orangepi-zero2":
+			return Zero2
+		case "xunlong,orangepi-pc":
+			return PC
+		case "xunlong,orangepi-3-lts":
+			return ThreeLTS
+		}
+	}
+	return Unknown
+}
+
+// All the individual pins on the P1 header.
+//
+// The Zero, Zero 2 and 3 LTS have a 26 pins header; P1_27 to P1_40 are only
+// present on the PC. The comments list the function on the Orange Pi PC.
+var (
+	P1_1             = pin.V3_3     //
+	P1_2             = pin.V5       //
+	P1_3  gpio.PinIO = gpio.INVALID // TWI0_SDA
+	P1_4             = pin.V5       //
+	P1_5  gpio.PinIO = gpio.INVALID // TWI0_SCK
+	P1_6             = pin.GROUND   //
+	P1_7  gpio.PinIO = gpio.INVALID // PWM1
+	P1_8  gpio.PinIO = gpio.INVALID // UART3_TX
+	P1_9             = pin.GROUND   //
+	P1_10 gpio.PinIO = gpio.INVALID // UART3_RX
+	P1_11 gpio.PinIO = gpio.INVALID // UART2_RX
+	P1_12 gpio.PinIO = gpio.INVALID //
+	P1_13 gpio.PinIO = gpio.INVALID // UART2_TX
+	P1_14            = pin.GROUND   //
+	P1_15 gpio.PinIO = gpio.INVALID // UART2_CTS
+	P1_16 gpio.PinIO = gpio.INVALID //
+	P1_17            = pin.V3_3     //
+	P1_18 gpio.PinIO = gpio.INVALID //
+	P1_19 gpio.PinIO = gpio.INVALID // SPI0_MOSI
+	P1_20            = pin.GROUND   //
+	P1_21 gpio.PinIO = gpio.INVALID // SPI0_MISO
+	P1_22 gpio.PinIO = gpio.INVALID // UART2_RTS
+	P1_23 gpio.PinIO = gpio.INVALID // SPI0_CLK
+	P1_24 gpio.PinIO = gpio.INVALID // SPI0_CS0
+	P1_25            = pin.GROUND   //
+	P1_26 gpio.PinIO = gpio.INVALID //
+	P1_27 gpio.PinIO = gpio.INVALID // TWI1_SDA
+	P1_28 gpio.PinIO = gpio.INVALID // TWI1_SCK
+	P1_29 gpio.PinIO = gpio.INVALID //
+	P1_30            = pin.GROUND   //
+	P1_31 gpio.PinIO = gpio.INVALID //
+	P1_32 gpio.PinIO = gpio.INVALID //
+	P1_33 gpio.PinIO = gpio.INVALID //
+	P1_34            = pin.GROUND   //
+	P1_35 gpio.PinIO = gpio.INVALID //
+	P1_36 gpio.PinIO = gpio.INVALID //
+	P1_37 gpio.PinIO = gpio.INVALID //
+	P1_38 gpio.PinIO = gpio.INVALID //
+	P1_39            = pin.GROUND   //
+	P1_40 gpio.PinIO = gpio.INVALID //
+)
+
+// board describes a model.
+type board struct {
+	// size is the number of pins on the P1 header.
+	size int
+	// p1 maps the header pin number to the Allwinner pin name.
+	p1 map[int]string
+	// aliases maps the function name to the Allwinner pin name. It includes
+	// the onboard LEDs and buttons.
+	aliases map[string]string
+}
+
+var boards = map[Model]*board{
+	Zero: {
+		size: 26,
+		p1: map[int]string{
+			3: "PA12", 5: "PA11", 7: "PA6", 8: "PG6", 10: "PG7", 11: "PA1",
+			12: "PA7", 13: "PA0", 15: "PA3", 16: "PA19", 18: "PA18", 19: "PA15",
+			21: "PA16", 22: "PA2", 23: "PA14", 24: "PA13", 26: "PA10",
+		},
+		aliases: map[string]string{
+			"I2C0_SDA":   "PA12",
+			"I2C0_SCL":   "PA11",
+			"I2C1_SDA":   "PA19",
+			"I2C1_SCL":   "PA18",
+			"SPI1_MOSI":  "PA15",
+			"SPI1_MISO":  "PA16",
+			"SPI1_CLK":   "PA14",
+			"SPI1_CS0":   "PA13",
+			"UART1_TX":   "PG6",
+			"UART1_RX":   "PG7",
+			"LED_STATUS": "PA17",
+			"LED_POWER":  "PL10",
+		},
+	},
+	Zero2: {
+		size: 26,
+		p1: map[int]string{
+			3: "PH5", 5: "PH4", 7: "PC9", 8: "PH2", 10: "PH3", 11: "PC6",
+			12: "PC11", 13: "PC5", 15: "PC8", 16: "PC15", 18: "PC14", 19: "PH7",
+			21: "PH8", 22: "PC7", 23: "PH6", 24: "PH9", 26: "PC10",
+		},
+		aliases: map[string]string{
+			"I2C3_SDA":   "PH5",
+			"I2C3_SCL":   "PH4",
+			"SPI1_MOSI":  "PH7",
+			"SPI1_MISO":  "PH8",
+			"SPI1_CLK":   "PH6",
+			"SPI1_CS0":   "PH9",
+			"UART5_TX":   "PH2",
+			"UART5_RX":   "PH3",
+			"LED_STATUS": "PC12",
+			"LED_POWER":  "PC13",
+		},
+	},
+	PC: {
+		size: 40,
+		p1: map[int]string{
+			3: "PA12", 5: "PA11", 7: "PA6", 8: "PA13", 10: "PA14", 11: "PA1",
+			12: "PD14", 13: "PA0", 15: "PA3", 16: "PC4", 18: "PC7", 19: "PC0",
+			21: "PC1", 22: "PA2", 23: "PC2", 24: "PC3", 26: "PA21", 27: "PA19",
+			28: "PA18", 29: "PA7", 31: "PA8", 32: "PG8", 33: "PA9", 35: "PA10",
+			36: "PG9", 37: "PA20", 38: "PG6", 40: "PG7",
+		},
+		aliases: map[string]string{
+			"I2C0_SDA":     "PA12",
+			"I2C0_SCL":     "PA11",
+			"I2C1_SDA":     "PA19",
+			"I2C1_SCL":     "PA18",
+			"SPI0_MOSI":    "PC0",
+			"SPI0_MISO":    "PC1",
+			"SPI0_CLK":     "PC2",
+			"SPI0_CS0":     "PC3",
+			"UART3_TX":     "PA13",
+			"UART3_RX":     "PA14",
+			"LED_STATUS":   "PA15",
+			"LED_POWER":    "PL10",
+			"BUTTON_POWER": "PL3",
+		},
+	},
+	ThreeLTS: {
+		size: 26,
+		p1: map[int]string{
+			3: "PD26", 5: "PD25", 7: "PD22", 8: "PL2", 10: "PL3", 11: "PD24",
+			12: "PL10", 13: "PD23", 15: "PL8", 16: "PD15", 18: "PD16", 19: "PH5",
+			21: "PH6", 22: "PD21", 23: "PH4", 24: "PH3", 26: "PL9",
+		},
+		aliases: map[string]string{
+			"I2C0_SDA":   "PD26",
+			"I2C0_SCL":   "PD25",
+			"SPI1_MOSI":  "PH5",
+			"SPI1_MISO":  "PH6",
+			"SPI1_CLK":   "PH4",
+			"SPI1_CS0":   "PH3",
+			"UART_TX":    "PL2",
+			"UART_RX":    "PL3",
+			"LED_STATUS": "PL4",
+			"LED_POWER":  "PL7",
+		},
+	},
+}
+
+// sysfsNumber converts an Allwinner pin name like "PG7" into its sysfs GPIO
+// number.
+func sysfsNumber(name string) (int, bool) {
+	if len(name) < 3 || name[0] != 'P' || name[1] < 'A' || name[1] > 'Z' {
+		return 0, false
+	}
+	n, err := strconv.Atoi(name[2:])
+	if err != nil || n < 0 || n >= 32 {
+		return 0, false
+	}
+	return int(name[1]-'A')*32 + n, true
+}
+
+// sysfsPin is a safe way to get a sysfs pin.
+func sysfsPin(name string) gpio.PinIO {
+	if n, ok := sysfsNumber(name); ok {
+		if p, ok := sysfs.Pins[n]; ok {
+			return p
+		}
+	}
+	return gpio.INVALID
+}
+
+// driver implements periph.Driver.
+type driver struct {
+}
+
+func (d *driver) String() string {
+	return "orangepi"
+}
+
+func (d *driver) Prerequisites() []string {
+	return nil
+}
+
+func (d *driver) After() []string {
+	return []string{"sysfs-gpio"}
+}
+
+func (d *driver) Init() (bool, error) {
+	b := boards[Detect()]
+	if b == nil {
+		return false, errors.New("Orange Pi board not detected")
+	}
+	P1_3 = sysfsPin(b.p1[3])
+	P1_5 = sysfsPin(b.p1[5])
+	P1_7 = sysfsPin(b.p1[7])
+	P1_8 = sysfsPin(b.p1[8])
+	P1_10 = sysfsPin(b.p1[10])
+	P1_11 = sysfsPin(b.p1[11])
+	P1_12 = sysfsPin(b.p1[12])
+	P1_13 = sysfsPin(b.p1[13])
+	P1_15 = sysfsPin(b.p1[15])
+	P1_16 = sysfsPin(b.p1[16])
+	P1_18 = sysfsPin(b.p1[18])
+	P1_19 = sysfsPin(b.p1[19])
+	P1_21 = sysfsPin(b.p1[21])
+	P1_22 = sysfsPin(b.p1[22])
+	P1_23 = sysfsPin(b.p1[23])
+	P1_24 = sysfsPin(b.p1[24])
+	P1_26 = sysfsPin(b.p1[26])
+	P1_27 = sysfsPin(b.p1[27])
+	P1_28 = sysfsPin(b.p1[28])
+	P1_29 = sysfsPin(b.p1[29])
+	P1_31 = sysfsPin(b.p1[31])
+	P1_32 = sysfsPin(b.p1[32])
+	P1_33 = sysfsPin(b.p1[33])
+	P1_35 = sysfsPin(b.p1[35])
+	P1_36 = sysfsPin(b.p1[36])
+	P1_37 = sysfsPin(b.p1[37])
+	P1_38 = sysfsPin(b.p1[38])
+	P1_40 = sysfsPin(b.p1[40])
+
+	hdr := [][]pin.Pin{
+		{P1_1, P1_2},
+		{P1_3, P1_4},
+		{P1_5, P1_6},
+		{P1_7, P1_8},
+		{P1_9, P1_10},
+		{P1_11, P1_12},
+		{P1_13, P1_14},
+		{P1_15, P1_16},
+		{P1_17, P1_18},
+		{P1_19, P1_20},
+		{P1_21, P1_22},
+		{P1_23, P1_24},
+		{P1_25, P1_26},
+		{P1_27, P1_28},
+		{P1_29, P1_30},
+		{P1_31, P1_32},
+		{P1_33, P1_34},
+		{P1_35, P1_36},
+		{P1_37, P1_38},
+		{P1_39, P1_40},
+	}
+	if err := pinreg.Register("P1", hdr[:b.size/2]); err != nil {
+		return true, err
+	}
+	for alias, name := range b.aliases {
+		n, ok := sysfsNumber(name)
+		if !ok {
+			continue
+		}
+		if err := gpioreg.RegisterAlias(alias, strconv.Itoa(n)); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func init() {
+	if isArm {
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
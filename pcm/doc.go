@@ -0,0 +1,18 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package pcm plays and captures PCM audio through the kernel ALSA devices
+// /dev/snd/pcmC*D*, without alsa-lib.
+//
+// Only interleaved read/write transfers are supported, which is enough for
+// alert tones and audio sampling on embedded devices. The sound card can be
+// any ALSA driver, including the I²S controllers of the bcm283x and am335x
+// once enabled in the device tree with a codec.
+//
+// Reference
+//
+// https://www.kernel.org/doc/html/latest/sound/designs/index.html
+//
+// include/uapi/sound/asound.h in the kernel sources.
+package pcm
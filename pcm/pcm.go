@@ -0,0 +1,446 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package pcm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+	"periph.io/x/conn/v3/physic"
+)
+
+// Info describes a PCM device.
+type Info struct {
+	Card    int
+	Device  int
+	Capture bool
+}
+
+func (i Info) String() string {
+	return fmt.Sprintf("pcmC%dD%d%c", i.Card, i.Device, direction(i.Capture))
+}
+
+// Enumerate returns the PCM devices in /dev/snd.
+func Enumerate() ([]Info, error) {
+	items, err := filepath.Glob("/dev/snd/pcmC*D*")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(items)
+	out := make([]Info, 0, len(items))
+	for _, item := range items {
+		var i Info
+		var d byte
+		if _, err := fmt.Sscanf(filepath.Base(item), "pcmC%dD%d%c", &i.Card, &i.Device, &d); err != nil {
+			continue
+		}
+		if d != 'p' && d != 'c' {
+			continue
+		}
+		i.Capture = d == 'c'
+		out = append(out, i)
+	}
+	return out, nil
+}
+
+// Format is the format of a sample.
+type Format uint8
+
+// Supported formats.
+const (
+	S16LE Format = iota // Signed 16 bits little endian; the most common
+	S32LE               // Signed 32 bits little endian
+	U8                  // Unsigned 8 bits
+)
+
+func (f Format) String() string {
+	switch f {
+	case S16LE:
+		return "S16LE"
+	case S32LE:
+		return "S32LE"
+	case U8:
+		return "U8"
+	default:
+		return fmt.Sprintf("Format(%d)", f)
+	}
+}
+
+// Bytes returns the size of a sample.
+func (f Format) Bytes() int {
+	switch f {
+	case S32LE:
+		return 4
+	case U8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Config is the configuration of the stream.
+type Config struct {
+	Format   Format
+	Channels int
+	Rate     physic.Frequency
+	// PeriodFrames is the number of frames transferred per hardware interrupt.
+	// Defaults to 1024.
+	PeriodFrames int
+	// Periods is the number of periods in the buffer. Defaults to 4.
+	Periods int
+}
+
+// OpenPlayback opens the PCM playback device /dev/snd/pcmC<card>D<device>p.
+func OpenPlayback(card, device int, c *Config) (*Dev, error) {
+	return open(Info{Card: card, Device: device}, c)
+}
+
+// OpenCapture opens the PCM capture device /dev/snd/pcmC<card>D<device>c.
+func OpenCapture(card, device int, c *Config) (*Dev, error) {
+	return open(Info{Card: card, Device: device, Capture: true}, c)
+}
+
+// Dev is an open PCM device.
+//
+// The resulting object is safe for concurrent use.
+type Dev struct {
+	info Info
+	cfg  Config
+
+	mu sync.Mutex
+	f  pcmDev
+}
+
+func (d *Dev) String() string {
+	return d.info.String()
+}
+
+// Config returns the configuration accepted by the driver.
+func (d *Dev) Config() Config {
+	return d.cfg
+}
+
+// FrameSize returns the size of a frame: one sample per channel.
+func (d *Dev) FrameSize() int {
+	return d.cfg.Format.Bytes() * d.cfg.Channels
+}
+
+// Close stops the stream immediately, dropping pending frames, and closes the
+// device. Call Drain first to play the end of the stream.
+func (d *Dev) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_ = d.f.drop()
+	if err := d.f.Close(); err != nil {
+		return fmt.Errorf("pcm: %v", err)
+	}
+	return nil
+}
+
+// Write plays the interleaved frames in b. It blocks until they are queued.
+//
+// The stream starts with the first write. On underrun, the stream is
+// restarted.
+func (d *Dev) Write(b []byte) (int, error) {
+	if d.info.Capture {
+		return 0, errors.New("pcm: can't write to a capture device")
+	}
+	return d.transfer(b, d.f.writei)
+}
+
+// Read captures interleaved frames in b. It blocks until b is filled.
+//
+// The stream starts with the first read. On overrun, the stream is restarted.
+func (d *Dev) Read(b []byte) (int, error) {
+	if !d.info.Capture {
+		return 0, errors.New("pcm: can't read from a playback device")
+	}
+	return d.transfer(b, d.f.readi)
+}
+
+// Drain blocks until the queued frames are played, then stops the stream.
+func (d *Dev) Drain() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.f.drain(); err != nil {
+		return fmt.Errorf("pcm: %v", err)
+	}
+	// Be ready for the next Write.
+	if err := d.f.prepare(); err != nil {
+		return fmt.Errorf("pcm: %v", err)
+	}
+	return nil
+}
+
+// PlayTone plays a sine wave of frequency f on all channels for the duration
+// d, at half the full scale, and waits for it to be played.
+func (d *Dev) PlayTone(f physic.Frequency, duration time.Duration) error {
+	if f <= 0 || f > d.cfg.Rate/2 {
+		return fmt.Errorf("pcm: invalid tone frequency %s", f)
+	}
+	n := int(int64(duration) * int64(d.cfg.Rate/physic.Hertz) / int64(time.Second))
+	if _, err := d.Write(tone(d.cfg, f, n)); err != nil {
+		return err
+	}
+	return d.Drain()
+}
+
+//
+
+func open(i Info, c *Config) (*Dev, error) {
+	cfg := *c
+	if cfg.PeriodFrames == 0 {
+		cfg.PeriodFrames = 1024
+	}
+	if cfg.Periods == 0 {
+		cfg.Periods = 4
+	}
+	if cfg.Format > U8 {
+		return nil, fmt.Errorf("pcm: invalid format %s", cfg.Format)
+	}
+	if cfg.Channels < 1 || cfg.Rate < physic.Hertz || cfg.PeriodFrames < 1 || cfg.Periods < 2 {
+		return nil, errors.New("pcm: invalid config")
+	}
+	f, err := fs.Open("/dev/snd/"+i.String(), os.O_RDWR)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("pcm: need more access, try as root or add the user to the audio group: %v", err)
+		}
+		return nil, fmt.Errorf("pcm: %v", err)
+	}
+	d := &Dev{info: i, cfg: cfg, f: &pcmFile{f}}
+	if err := d.init(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Dev) init() error {
+	var p hwParams
+	p.setAny()
+	p.setMask(paramAccess, accessRWInterleaved)
+	p.setMask(paramFormat, formats[d.cfg.Format])
+	p.setMask(paramSubformat, 0)
+	p.setInterval(paramChannels, uint32(d.cfg.Channels))
+	p.setInterval(paramRate, uint32(d.cfg.Rate/physic.Hertz))
+	p.setInterval(paramPeriodSize, uint32(d.cfg.PeriodFrames))
+	p.setInterval(paramPeriods, uint32(d.cfg.Periods))
+	if err := d.f.hwParams(&p); err != nil {
+		return fmt.Errorf("pcm: %s doesn't support %d channels %s at %s: %v", d, d.cfg.Channels, d.cfg.Format, d.cfg.Rate, err)
+	}
+	d.cfg.Channels = int(p.interval(paramChannels))
+	d.cfg.Rate = physic.Frequency(p.interval(paramRate)) * physic.Hertz
+	d.cfg.PeriodFrames = int(p.interval(paramPeriodSize))
+	d.cfg.Periods = int(p.interval(paramPeriods))
+	if err := d.f.prepare(); err != nil {
+		return fmt.Errorf("pcm: %v", err)
+	}
+	return nil
+}
+
+// transfer runs op until all of b is transferred, recovering from xruns.
+func (d *Dev) transfer(b []byte, op func(x *xferi) error) (int, error) {
+	size := d.FrameSize()
+	if len(b)%size != 0 {
+		return 0, fmt.Errorf("pcm: buffer must be a multiple of the frame size %d bytes, got %d bytes", size, len(b))
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	done := 0
+	for done < len(b) {
+		x := xferi{buf: uintptr(unsafe.Pointer(&b[done])), frames: uint((len(b) - done) / size)}
+		err := op(&x)
+		switch err {
+		case nil:
+			done += x.result * size
+		case syscall.EINTR, syscall.EAGAIN:
+		case syscall.EPIPE:
+			// Underrun or overrun.
+			if err := d.f.prepare(); err != nil {
+				return done, fmt.Errorf("pcm: %v", err)
+			}
+		default:
+			return done, fmt.Errorf("pcm: %v", err)
+		}
+	}
+	return done, nil
+}
+
+// tone returns n frames of a sine wave of frequency f.
+func tone(c Config, f physic.Frequency, n int) []byte {
+	size := c.Format.Bytes() * c.Channels
+	b := make([]byte, n*size)
+	step := 2 * math.Pi * float64(f) / float64(c.Rate)
+	for i := 0; i < n; i++ {
+		v := 0.5 * math.Sin(step*float64(i))
+		s := b[i*size:]
+		for ch := 0; ch < c.Channels; ch++ {
+			switch c.Format {
+			case S16LE:
+				binary.LittleEndian.PutUint16(s[2*ch:], uint16(int16(v*math.MaxInt16)))
+			case S32LE:
+				binary.LittleEndian.PutUint32(s[4*ch:], uint32(int32(v*math.MaxInt32)))
+			case U8:
+				s[ch] = uint8(128 + int(v*127))
+			}
+		}
+	}
+	return b
+}
+
+func direction(capture bool) byte {
+	if capture {
+		return 'c'
+	}
+	return 'p'
+}
+
+// pcmDev is implemented by pcmFile and mocked in tests.
+type pcmDev interface {
+	hwParams(p *hwParams) error
+	prepare() error
+	writei(x *xferi) error
+	readi(x *xferi) error
+	drain() error
+	drop() error
+	Close() error
+}
+
+// pcmFile is a /dev/snd/pcmC*D* device.
+type pcmFile struct {
+	*fs.File
+}
+
+func (p *pcmFile) hwParams(h *hwParams) error {
+	return p.Ioctl(ioctlHWParams, uintptr(unsafe.Pointer(h)))
+}
+
+func (p *pcmFile) prepare() error {
+	return p.Ioctl(ioctlPrepare, 0)
+}
+
+func (p *pcmFile) writei(x *xferi) error {
+	return p.Ioctl(ioctlWriteIFrames, uintptr(unsafe.Pointer(x)))
+}
+
+func (p *pcmFile) readi(x *xferi) error {
+	return p.Ioctl(ioctlReadIFrames, uintptr(unsafe.Pointer(x)))
+}
+
+func (p *pcmFile) drain() error {
+	return p.Ioctl(ioctlDrain, 0)
+}
+
+func (p *pcmFile) drop() error {
+	return p.Ioctl(ioctlDrop, 0)
+}
+
+// Structures and constants from include/uapi/sound/asound.h.
+
+const (
+	accessRWInterleaved = 3
+
+	// Masks.
+	paramAccess    = 0
+	paramFormat    = 1
+	paramSubformat = 2
+	// Intervals.
+	paramFirstInterval = 8
+	paramChannels      = 10
+	paramRate          = 11
+	paramPeriodSize    = 13
+	paramPeriods       = 15
+
+	intervalInteger = 1 << 2
+)
+
+// formats maps Format to snd_pcm_format_t.
+var formats = [...]uint32{S16LE: 2, S32LE: 10, U8: 1}
+
+var (
+	ioctlHWParams     = fs.IOWR('A', 0x11, uint(unsafe.Sizeof(hwParams{})))
+	ioctlPrepare      = fs.IO('A', 0x40)
+	ioctlDrop         = fs.IO('A', 0x43)
+	ioctlDrain        = fs.IO('A', 0x44)
+	ioctlWriteIFrames = fs.IOW('A', 0x50, uint(unsafe.Sizeof(xferi{})))
+	ioctlReadIFrames  = fs.IOR('A', 0x51, uint(unsafe.Sizeof(xferi{})))
+)
+
+// mask is struct snd_mask.
+type mask [8]uint32
+
+// interval is struct snd_interval; flags holds the openmin, openmax, integer
+// and empty bit fields.
+type interval struct {
+	min   uint32
+	max   uint32
+	flags uint32
+}
+
+// hwParams is struct snd_pcm_hw_params.
+type hwParams struct {
+	flags     uint32
+	masks     [3]mask
+	mres      [5]mask
+	intervals [12]interval
+	ires      [9]interval
+	rmask     uint32
+	cmask     uint32
+	info      uint32
+	msbits    uint32
+	rateNum   uint32
+	rateDen   uint32
+	fifoSize  uint // snd_pcm_uframes_t
+	reserved  [64]byte
+}
+
+// setAny allows every value, so the driver refines all the parameters.
+func (h *hwParams) setAny() {
+	for i := range h.masks {
+		for j := range h.masks[i] {
+			h.masks[i][j] = 0xFFFFFFFF
+		}
+	}
+	for i := range h.intervals {
+		h.intervals[i] = interval{max: 0xFFFFFFFF}
+	}
+	h.rmask = 0xFFFFFFFF
+	h.cmask = 0
+	h.info = 0xFFFFFFFF
+}
+
+func (h *hwParams) setMask(param int, v uint32) {
+	m := &h.masks[param]
+	for i := range m {
+		m[i] = 0
+	}
+	m[v/32] = 1 << (v % 32)
+}
+
+func (h *hwParams) setInterval(param int, v uint32) {
+	h.intervals[param-paramFirstInterval] = interval{min: v, max: v, flags: intervalInteger}
+}
+
+func (h *hwParams) interval(param int) uint32 {
+	return h.intervals[param-paramFirstInterval].min
+}
+
+// xferi is struct snd_xferi.
+type xferi struct {
+	result int     // snd_pcm_sframes_t
+	buf    uintptr // void *
+	frames uint    // snd_pcm_uframes_t
+}
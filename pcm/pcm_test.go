@@ -0,0 +1,202 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package pcm
+
+import (
+	"encoding/binary"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestSizes(t *testing.T) {
+	// snd_pcm_hw_params has an unsigned long before the reserved bytes.
+	want := uintptr(604)
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		want = 608
+	}
+	if s := unsafe.Sizeof(hwParams{}); s != want {
+		t.Fatalf("snd_pcm_hw_params is %d bytes", s)
+	}
+	if s := unsafe.Sizeof(xferi{}); s != 3*unsafe.Sizeof(uintptr(0)) {
+		t.Fatalf("snd_xferi is %d bytes", s)
+	}
+}
+
+func TestDev_init(t *testing.T) {
+	f := &fakePCM{rate: 44100}
+	d := &Dev{info: Info{Card: 1}, cfg: Config{Format: S16LE, Channels: 2, Rate: 48 * physic.KiloHertz, PeriodFrames: 256, Periods: 4}, f: f}
+	if err := d.init(); err != nil {
+		t.Fatal(err)
+	}
+	p := &f.params
+	if p.masks[paramAccess][0] != 1<<accessRWInterleaved || p.masks[paramFormat][0] != 1<<2 {
+		t.Fatalf("masks = %#v", p.masks)
+	}
+	if i := p.intervals[paramPeriodSize-paramFirstInterval]; i != (interval{min: 256, max: 256, flags: intervalInteger}) {
+		t.Fatalf("period size = %#v", i)
+	}
+	if p.rmask != 0xFFFFFFFF {
+		t.Fatal("not all parameters are refined")
+	}
+	// The driver picked another rate.
+	if d.cfg.Rate != 44100*physic.Hertz {
+		t.Fatal(d.cfg.Rate)
+	}
+	if f.prepared != 1 {
+		t.Fatal("not prepared")
+	}
+	if s := d.String(); s != "pcmC1D0p" {
+		t.Fatal(s)
+	}
+}
+
+func TestDev_Write(t *testing.T) {
+	// Queue 2 frames at a time and underrun once.
+	f := &fakePCM{max: 2, errs: []error{nil, syscall.EPIPE}}
+	d := &Dev{cfg: Config{Format: S16LE, Channels: 2, Rate: 8 * physic.KiloHertz}, f: f}
+	n, err := d.Write(make([]byte, 5*4))
+	if err != nil || n != 20 {
+		t.Fatal(n, err)
+	}
+	if f.frames != 5 || f.prepared != 1 {
+		t.Fatal(f.frames, f.prepared)
+	}
+	if _, err := d.Write(make([]byte, 3)); err == nil {
+		t.Fatal("partial frame")
+	}
+	if _, err := d.Read(make([]byte, 4)); err == nil {
+		t.Fatal("can't read from a playback device")
+	}
+	f.errs = []error{syscall.EIO}
+	if _, err := d.Write(make([]byte, 4)); err == nil {
+		t.Fatal("I/O error")
+	}
+	if err := d.PlayTone(physic.KiloHertz, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if f.frames != 5+80 || !f.drained {
+		t.Fatal(f.frames, f.drained)
+	}
+	if err := d.PlayTone(5*physic.KiloHertz, time.Millisecond); err == nil {
+		t.Fatal("above Nyquist")
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDev_Read(t *testing.T) {
+	f := &fakePCM{}
+	d := &Dev{info: Info{Capture: true}, cfg: Config{Format: U8, Channels: 1}, f: f}
+	if n, err := d.Read(make([]byte, 16)); err != nil || n != 16 {
+		t.Fatal(n, err)
+	}
+	if _, err := d.Write(make([]byte, 1)); err == nil {
+		t.Fatal("can't write to a capture device")
+	}
+}
+
+func TestOpen_invalid(t *testing.T) {
+	if _, err := OpenPlayback(0, 0, &Config{Channels: 0, Rate: physic.KiloHertz}); err == nil {
+		t.Fatal("invalid channels")
+	}
+	if _, err := OpenCapture(0, 0, &Config{Format: 10, Channels: 1, Rate: physic.KiloHertz}); err == nil {
+		t.Fatal("invalid format")
+	}
+}
+
+func TestTone(t *testing.T) {
+	c := Config{Format: S16LE, Channels: 2, Rate: 4 * physic.Hertz}
+	b := tone(c, physic.Hertz, 4)
+	if len(b) != 16 {
+		t.Fatal(len(b))
+	}
+	// A quarter period later, both channels are at half the full scale.
+	if v := int16(binary.LittleEndian.Uint16(b[4:])); v != 16383 {
+		t.Fatal(v)
+	}
+	if v := int16(binary.LittleEndian.Uint16(b[6:])); v != 16383 {
+		t.Fatal(v)
+	}
+	if v := tone(Config{Format: U8, Channels: 1, Rate: 4 * physic.Hertz}, physic.Hertz, 4); v[0] != 128 || v[1] != 191 {
+		t.Fatal(v)
+	}
+}
+
+func TestFormat_String(t *testing.T) {
+	if s := Format(10).String(); s != "Format(10)" {
+		t.Fatal(s)
+	}
+	if s := (Info{Card: 1, Device: 2, Capture: true}).String(); s != "pcmC1D2c" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+type fakePCM struct {
+	params   hwParams
+	rate     uint32 // rate picked by the driver, if set
+	max      uint   // maximum frames per transfer, if set
+	errs     []error
+	frames   uint
+	prepared int
+	drained  bool
+}
+
+func (f *fakePCM) hwParams(p *hwParams) error {
+	f.params = *p
+	if f.rate != 0 {
+		p.intervals[paramRate-paramFirstInterval].min = f.rate
+	}
+	return nil
+}
+
+func (f *fakePCM) prepare() error {
+	f.prepared++
+	return nil
+}
+
+func (f *fakePCM) writei(x *xferi) error {
+	return f.transfer(x)
+}
+
+func (f *fakePCM) readi(x *xferi) error {
+	return f.transfer(x)
+}
+
+func (f *fakePCM) transfer(x *xferi) error {
+	if len(f.errs) != 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	n := x.frames
+	if f.max != 0 && n > f.max {
+		n = f.max
+	}
+	f.frames += n
+	x.result = int(n)
+	return nil
+}
+
+func (f *fakePCM) drain() error {
+	f.drained = true
+	return nil
+}
+
+func (f *fakePCM) drop() error {
+	return nil
+}
+
+func (f *fakePCM) Close() error {
+	return nil
+}
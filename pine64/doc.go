@@ -2,14 +2,22 @@
 // Use of this source code is governed under the Apache License, Version 2.0
 // that can be found in the LICENSE file.
 
-// Package pine64 contains Pine64 hardware logic. It is intrinsically
-// related to package a64.
+// Package pine64 contains Pine64 hardware logic.
 //
-// Requires Armbian Jessie Server.
+// The original Pine A64 and the Pinebook use an Allwinner A64 and are
+// intrinsically related to package allwinner. The Rock64, Quartz64 and
+// Pinebook Pro use Rockchip processors; their Pi-2 bus header is exposed
+// through sysfs-gpio.
+//
+// Requires Armbian Jessie Server on the Pine A64.
 //
 // Physical
 //
 // http://files.pine64.org/doc/Pine%20A64%20Schematic/Pine%20A64%20Pin%20Assignment%20160119.pdf
 //
 // http://wiki.pine64.org/images/2/2e/Pine64_Board_Connector_heatsink.png
+//
+// https://wiki.pine64.org/wiki/ROCK64
+//
+// https://wiki.pine64.org/wiki/Quartz64
 package pine64
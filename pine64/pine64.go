@@ -8,21 +8,88 @@ import (
 	"errors"
 	"strings"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/allwinner"
+	"github.com/s-mobi01/host/distro"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/sysfs"
+	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/pin"
 	"periph.io/x/conn/v3/pin/pinreg"
-	"periph.io/x/host/v3/allwinner"
-	"periph.io/x/host/v3/distro"
 )
 
+// Model is a Pine64 board model.
+type Model int
+
+// Supported models.
+const (
+	Unknown     Model = iota
+	PineA64           // Pine A64, A64+ and A64-LTS; Allwinner A64
+	Pinebook          // Allwinner A64
+	Rock64            // Rockchip RK3328
+	Quartz64          // Quartz64 model A; Rockchip RK3566
+	PinebookPro       // Rockchip RK3399
+)
+
+func (m Model) String() string {
+	switch m {
+	case PineA64:
+		return "Pine A64"
+	case Pinebook:
+		return "Pinebook"
+	case Rock64:
+		return "Rock64"
+	case Quartz64:
+		return "Quartz64"
+	case PinebookPro:
+		return "Pinebook Pro"
+	default:
+		return "Unknown"
+	}
+}
+
 // Present returns true if running on a Pine64 board.
 //
 // https://www.pine64.org/
 func Present() bool {
-	if isArm {
-		return strings.HasPrefix(distro.DTModel(), "Pine64")
+	return Detect() != Unknown
+}
+
+// Detect returns the Pine64 board model the host is running on.
+//
+// The device tree compatible strings are checked first, then the model
+// string as found on older Armbian images.
+func Detect() Model {
+	if !isArm {
+		return Unknown
 	}
-	return false
+	for _, c := range distro.DTCompatible() {
+		switch c {
+		case "pine64,pine64", "pine64,pine64-plus", "pine64,pine64-lts":
+			return PineA64
+		case "pine64,pinebook":
+			return Pinebook
+		case "pine64,rock64":
+			return Rock64
+		case "pine64,quartz64-a":
+			return Quartz64
+		case "pine64,pinebook-pro":
+			return PinebookPro
+		}
+	}
+	m := distro.DTModel()
+	switch {
+	case strings.Contains(m, "Pinebook Pro"):
+		return PinebookPro
+	case strings.Contains(m, "Pinebook"):
+		return Pinebook
+	case strings.Contains(m, "Rock64"):
+		return Rock64
+	case strings.Contains(m, "Quartz64"):
+		return Quartz64
+	case strings.HasPrefix(m, "Pine64"):
+		return PineA64
+	}
+	return Unknown
 }
 
 // Pine64 specific pins.
@@ -156,7 +223,81 @@ var (
 	AUDIO_RIGHT = pin.INVALID //
 )
 
+// PI2 is the 40-pin "Pi-2 bus" header found on the Rockchip based boards
+// (Rock64 and Quartz64). It follows the Raspberry Pi layout.
 //
+// There is no Rockchip specific driver yet, so the pins are initialized from
+// sysfs-gpio once the board is detected.
+var (
+	PI2_1             = pin.V3_3     //
+	PI2_2             = pin.V5       //
+	PI2_3  gpio.PinIO = gpio.INVALID // I2C_SDA
+	PI2_4             = pin.V5       //
+	PI2_5  gpio.PinIO = gpio.INVALID // I2C_SCL
+	PI2_6             = pin.GROUND   //
+	PI2_7  gpio.PinIO = gpio.INVALID //
+	PI2_8  gpio.PinIO = gpio.INVALID // UART_TX
+	PI2_9             = pin.GROUND   //
+	PI2_10 gpio.PinIO = gpio.INVALID // UART_RX
+	PI2_11 gpio.PinIO = gpio.INVALID //
+	PI2_12 gpio.PinIO = gpio.INVALID //
+	PI2_13 gpio.PinIO = gpio.INVALID //
+	PI2_14            = pin.GROUND   //
+	PI2_15 gpio.PinIO = gpio.INVALID //
+	PI2_16 gpio.PinIO = gpio.INVALID //
+	PI2_17            = pin.V3_3     //
+	PI2_18 gpio.PinIO = gpio.INVALID //
+	PI2_19 gpio.PinIO = gpio.INVALID // SPI_MOSI
+	PI2_20            = pin.GROUND   //
+	PI2_21 gpio.PinIO = gpio.INVALID // SPI_MISO
+	PI2_22 gpio.PinIO = gpio.INVALID //
+	PI2_23 gpio.PinIO = gpio.INVALID // SPI_CLK
+	PI2_24 gpio.PinIO = gpio.INVALID // SPI_CS0
+	PI2_25            = pin.GROUND   //
+	PI2_26 gpio.PinIO = gpio.INVALID //
+	PI2_27 gpio.PinIO = gpio.INVALID //
+	PI2_28 gpio.PinIO = gpio.INVALID //
+	PI2_29 gpio.PinIO = gpio.INVALID //
+	PI2_30            = pin.GROUND   //
+	PI2_31 gpio.PinIO = gpio.INVALID //
+	PI2_32 gpio.PinIO = gpio.INVALID //
+	PI2_33 gpio.PinIO = gpio.INVALID //
+	PI2_34            = pin.GROUND   //
+	PI2_35 gpio.PinIO = gpio.INVALID //
+	PI2_36 gpio.PinIO = gpio.INVALID //
+	PI2_37 gpio.PinIO = gpio.INVALID //
+	PI2_38 gpio.PinIO = gpio.INVALID //
+	PI2_39            = pin.GROUND   //
+	PI2_40 gpio.PinIO = gpio.INVALID //
+)
+
+// pi2Pins maps the PI2 header pin number to the sysfs GPIO number, using the
+// Rockchip numbering: bank*32 + group*8 + index. 0 means the pin is not a
+// GPIO.
+var pi2Pins = map[Model][41]int{
+	Rock64: {
+		3: 89, 5: 88, 7: 60, 8: 64, 10: 65, 12: 67, 15: 100, 16: 101, 18: 102,
+		19: 97, 21: 98, 22: 103, 23: 96, 24: 104, 26: 76, 27: 68, 28: 69,
+		29: 70, 31: 71, 32: 73, 33: 80, 35: 81, 36: 74, 37: 82, 40: 83,
+	},
+	Quartz64: {
+		3: 32, 5: 33, 7: 116, 8: 25, 10: 24, 11: 97, 12: 120, 13: 98, 15: 99,
+		16: 104, 18: 105, 19: 147, 21: 148, 22: 106, 23: 146, 24: 150, 26: 151,
+		27: 138, 28: 139, 29: 107, 31: 108, 32: 113, 33: 114, 35: 117, 36: 109,
+		37: 110, 38: 118, 40: 119,
+	},
+}
+
+// sysfsPin is a safe way to get a sysfs pin.
+func sysfsPin(n int) gpio.PinIO {
+	if n == 0 {
+		return gpio.INVALID
+	}
+	if p, ok := sysfs.Pins[n]; ok {
+		return p
+	}
+	return gpio.INVALID
+}
 
 // driver implements periph.Driver.
 type driver struct {
@@ -171,13 +312,25 @@ func (d *driver) Prerequisites() []string {
 }
 
 func (d *driver) After() []string {
-	return []string{"allwinner-gpio", "allwinner-gpio-pl"}
+	return []string{"allwinner-gpio", "allwinner-gpio-pl", "sysfs-gpio"}
 }
 
 func (d *driver) Init() (bool, error) {
-	if !Present() {
+	switch m := Detect(); m {
+	case PineA64:
+		return true, registerPineA64()
+	case Rock64, Quartz64:
+		return true, registerPI2(m)
+	case Pinebook, PinebookPro:
+		// Laptops; there is no user accessible header.
+		return true, nil
+	default:
 		return false, errors.New("pine64 board not detected")
 	}
+}
+
+// registerPineA64 registers the headers found on the original Pine A64.
+func registerPineA64() error {
 	if err := pinreg.Register("P1", [][]pin.Pin{
 		{P1_1, P1_2},
 		{P1_3, P1_4},
@@ -200,7 +353,7 @@ func (d *driver) Init() (bool, error) {
 		{P1_37, P1_38},
 		{P1_39, P1_40},
 	}); err != nil {
-		return true, err
+		return err
 	}
 	if err := pinreg.Register("EULER", [][]pin.Pin{
 		{EULER_1, EULER_2},
@@ -221,7 +374,7 @@ func (d *driver) Init() (bool, error) {
 		{EULER_31, EULER_32},
 		{EULER_33, EULER_34},
 	}); err != nil {
-		return true, err
+		return err
 	}
 
 	if err := pinreg.Register("EXP", [][]pin.Pin{
@@ -231,7 +384,7 @@ func (d *driver) Init() (bool, error) {
 		{EXP_7, EXP_8},
 		{EXP_9, EXP_10},
 	}); err != nil {
-		return true, err
+		return err
 	}
 
 	if err := pinreg.Register("WIFI_BT", [][]pin.Pin{
@@ -249,22 +402,78 @@ func (d *driver) Init() (bool, error) {
 		{WIFI_BT_23, WIFI_BT_24},
 		{WIFI_BT_25, WIFI_BT_26},
 	}); err != nil {
-		return true, err
+		return err
 	}
 
 	if err := pinreg.Register("AUDIO", [][]pin.Pin{
 		{AUDIO_LEFT},
 		{AUDIO_RIGHT},
 	}); err != nil {
-		return true, err
+		return err
 	}
 
-	return true, nil
+	return nil
+}
+
+// registerPI2 initializes and registers the Pi-2 bus header of the Rockchip
+// based boards.
+func registerPI2(m Model) error {
+	n := pi2Pins[m]
+	PI2_3 = sysfsPin(n[3])
+	PI2_5 = sysfsPin(n[5])
+	PI2_7 = sysfsPin(n[7])
+	PI2_8 = sysfsPin(n[8])
+	PI2_10 = sysfsPin(n[10])
+	PI2_11 = sysfsPin(n[11])
+	PI2_12 = sysfsPin(n[12])
+	PI2_13 = sysfsPin(n[13])
+	PI2_15 = sysfsPin(n[15])
+	PI2_16 = sysfsPin(n[16])
+	PI2_18 = sysfsPin(n[18])
+	PI2_19 = sysfsPin(n[19])
+	PI2_21 = sysfsPin(n[21])
+	PI2_22 = sysfsPin(n[22])
+	PI2_23 = sysfsPin(n[23])
+	PI2_24 = sysfsPin(n[24])
+	PI2_26 = sysfsPin(n[26])
+	PI2_27 = sysfsPin(n[27])
+	PI2_28 = sysfsPin(n[28])
+	PI2_29 = sysfsPin(n[29])
+	PI2_31 = sysfsPin(n[31])
+	PI2_32 = sysfsPin(n[32])
+	PI2_33 = sysfsPin(n[33])
+	PI2_35 = sysfsPin(n[35])
+	PI2_36 = sysfsPin(n[36])
+	PI2_37 = sysfsPin(n[37])
+	PI2_38 = sysfsPin(n[38])
+	PI2_40 = sysfsPin(n[40])
+	return pinreg.Register("PI2", [][]pin.Pin{
+		{PI2_1, PI2_2},
+		{PI2_3, PI2_4},
+		{PI2_5, PI2_6},
+		{PI2_7, PI2_8},
+		{PI2_9, PI2_10},
+		{PI2_11, PI2_12},
+		{PI2_13, PI2_14},
+		{PI2_15, PI2_16},
+		{PI2_17, PI2_18},
+		{PI2_19, PI2_20},
+		{PI2_21, PI2_22},
+		{PI2_23, PI2_24},
+		{PI2_25, PI2_26},
+		{PI2_27, PI2_28},
+		{PI2_29, PI2_30},
+		{PI2_31, PI2_32},
+		{PI2_33, PI2_34},
+		{PI2_35, PI2_36},
+		{PI2_37, PI2_38},
+		{PI2_39, PI2_40},
+	})
 }
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drv)
+		hostcfg.MustRegister(&drv)
 	}
 }
 
@@ -0,0 +1,92 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package pinuse
+
+import (
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"periph.io/x/conn/v3/pin"
+)
+
+// AtExit registers f to release p when the process exits, replacing the
+// function previously registered for p, if any.
+//
+// Drivers in this module register the pins they hold a kernel resource for,
+// e.g. a GPIO exported through sysfs that would otherwise stay exported after
+// the process exits.
+func AtExit(p pin.Pin, f func() error) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	atExit[p] = f
+}
+
+// CancelAtExit unregisters the function registered for p with AtExit.
+func CancelAtExit(p pin.Pin) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	delete(atExit, p)
+}
+
+// Cleanup calls the functions registered with AtExit, sorted by pin name,
+// and unregisters them. It returns the first error.
+//
+// Go has no exit hook, so a program must call it before returning from main(),
+// or call CleanupOnSignal. A program that crashes or is killed with SIGKILL
+// can't clean up; the kernel still releases the GPIO character device lines
+// on exit, but not the sysfs exports.
+func Cleanup() error {
+	exitMu.Lock()
+	l := make([]exitFunc, 0, len(atExit))
+	for p, f := range atExit {
+		l = append(l, exitFunc{p.Name(), f})
+	}
+	atExit = map[pin.Pin]func() error{}
+	exitMu.Unlock()
+	sort.Slice(l, func(i, j int) bool { return l[i].name < l[j].name })
+	var err error
+	for _, e := range l {
+		if err1 := e.f(); err == nil {
+			err = err1
+		}
+	}
+	return err
+}
+
+// CleanupOnSignal calls Cleanup when the process receives SIGINT or SIGTERM,
+// then exits with the status 128 plus the signal number, like the default
+// handler. It can be called multiple times.
+func CleanupOnSignal() {
+	sigOnce.Do(func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			s := <-c
+			_ = Cleanup()
+			code := 1
+			if n, ok := s.(syscall.Signal); ok {
+				code = 128 + int(n)
+			}
+			exit(code)
+		}()
+	})
+}
+
+//
+
+var (
+	exitMu  sync.Mutex
+	atExit  = map[pin.Pin]func() error{}
+	sigOnce sync.Once
+	exit    = os.Exit
+)
+
+type exitFunc struct {
+	name string
+	f    func() error
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package pinuse
+
+import (
+	"errors"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio/gpiotest"
+)
+
+func TestCleanup(t *testing.T) {
+	a := &gpiotest.Pin{N: "A"}
+	b := &gpiotest.Pin{N: "B"}
+	c := &gpiotest.Pin{N: "C"}
+	var calls []string
+	AtExit(b, func() error { calls = append(calls, "B"); return errors.New("b") })
+	AtExit(a, func() error { calls = append(calls, "A0"); return nil })
+	AtExit(a, func() error { calls = append(calls, "A"); return nil })
+	AtExit(c, func() error { calls = append(calls, "C"); return errors.New("c") })
+	CancelAtExit(c)
+	if err := Cleanup(); err == nil || err.Error() != "b" {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || calls[0] != "A" || calls[1] != "B" {
+		t.Fatal(calls)
+	}
+	// Unregistered.
+	if err := Cleanup(); err != nil || len(calls) != 2 {
+		t.Fatal(err, calls)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package pinuse tracks which driver or consumer owns each pin, similar to
+// the consumer labels of the kernel GPIO lines.
+//
+// Drivers in this module claim the pins they configure and release them when
+// closed, so that a conflicting configuration fails with a *BusyError instead
+// of silently breaking the other user. Pins are identified by their name,
+// aliases being resolved to the real pin.
+//
+// The drivers also register with AtExit the kernel resources held for the
+// pins, like the GPIOs exported through sysfs, so Cleanup releases them when
+// the program exits or, with CleanupOnSignal, is interrupted.
+package pinuse
@@ -0,0 +1,124 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package pinuse
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/pin"
+)
+
+// BusyError is returned when a pin is already owned by someone else.
+type BusyError struct {
+	Pin   string
+	Owner string
+}
+
+func (b *BusyError) Error() string {
+	return fmt.Sprintf("pin %s busy, owned by %s", b.Pin, b.Owner)
+}
+
+// Assignment is a pin and its owner.
+type Assignment struct {
+	Pin   string
+	Owner string
+}
+
+func (a Assignment) String() string {
+	return a.Pin + ": " + a.Owner
+}
+
+// Claim records owner as the owner of all the pins.
+//
+// Like requesting a kernel GPIO line, claiming a pin already owned fails,
+// even by the same owner: two instances of a driver must not share a pin. If
+// any pin is owned, none is claimed and a *BusyError is returned.
+func Claim(owner string, pins ...pin.Pin) error {
+	if owner == "" {
+		return errors.New("pinuse: owner is required")
+	}
+	names := make([]string, 0, len(pins))
+	for _, p := range pins {
+		names = append(names, name(p))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, n := range names {
+		if o, ok := owners[n]; ok {
+			return &BusyError{Pin: n, Owner: o}
+		}
+	}
+	for i, n := range names {
+		for _, m := range names[:i] {
+			if m == n {
+				return fmt.Errorf("pinuse: pin %s listed twice", n)
+			}
+		}
+	}
+	for _, n := range names {
+		owners[n] = owner
+	}
+	return nil
+}
+
+// Release releases the pins owned by owner. The pins owned by someone else
+// are left untouched.
+func Release(owner string, pins ...pin.Pin) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, p := range pins {
+		n := name(p)
+		if owners[n] == owner {
+			delete(owners, n)
+		}
+	}
+}
+
+// Owner returns the owner of the pin, or "" if it is free.
+func Owner(p pin.Pin) string {
+	n := name(p)
+	mu.Lock()
+	defer mu.Unlock()
+	return owners[n]
+}
+
+// All returns all the current assignments, sorted by pin name.
+func All() []Assignment {
+	mu.Lock()
+	defer mu.Unlock()
+	out := make([]Assignment, 0, len(owners))
+	for p, o := range owners {
+		out = append(out, Assignment{Pin: p, Owner: o})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Pin < out[j].Pin })
+	return out
+}
+
+//
+
+var (
+	mu     sync.Mutex
+	owners = map[string]string{}
+)
+
+// name returns the name of the real pin behind an alias.
+func name(p pin.Pin) string {
+	for {
+		r, ok := p.(gpio.RealPin)
+		if !ok {
+			break
+		}
+		q := r.Real()
+		if q == nil || q == p {
+			break
+		}
+		p = q
+	}
+	return p.Name()
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package pinuse
+
+import (
+	"testing"
+
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+)
+
+func TestClaim(t *testing.T) {
+	defer reset()
+	a := &gpiotest.Pin{N: "GPIO1"}
+	b := &gpiotest.Pin{N: "GPIO2"}
+	if err := Claim("", a); err == nil {
+		t.Fatal("owner is required")
+	}
+	if err := Claim("spi", a); err != nil {
+		t.Fatal(err)
+	}
+	// Even the same owner can't claim twice.
+	if err := Claim("spi", a); err == nil {
+		t.Fatal("pin is busy")
+	}
+	if err := Claim("counter", b, b); err == nil {
+		t.Fatal("duplicate pin")
+	}
+	// All or nothing.
+	err := Claim("counter", b, a)
+	if e, ok := err.(*BusyError); !ok || e.Pin != "GPIO1" || e.Owner != "spi" {
+		t.Fatal(err)
+	}
+	if s := err.Error(); s != "pin GPIO1 busy, owned by spi" {
+		t.Fatal(s)
+	}
+	if o := Owner(b); o != "" {
+		t.Fatal(o)
+	}
+	if err := Claim("counter", b); err != nil {
+		t.Fatal(err)
+	}
+	all := All()
+	if len(all) != 2 || all[0].String() != "GPIO1: spi" || all[1].String() != "GPIO2: counter" {
+		t.Fatal(all)
+	}
+	// Only the owner releases.
+	Release("counter", a, b)
+	if o := Owner(a); o != "spi" {
+		t.Fatal(o)
+	}
+	if o := Owner(b); o != "" {
+		t.Fatal(o)
+	}
+}
+
+func TestClaim_alias(t *testing.T) {
+	defer reset()
+	p := &gpiotest.Pin{N: "PINUSE4", Num: 4}
+	if err := gpioreg.Register(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := gpioreg.RegisterAlias("LED", "PINUSE4"); err != nil {
+		t.Fatal(err)
+	}
+	alias := gpioreg.ByName("LED")
+	if alias.Name() != "LED" {
+		t.Fatal(alias.Name())
+	}
+	if err := Claim("pwm", alias); err != nil {
+		t.Fatal(err)
+	}
+	if o := Owner(p); o != "pwm" {
+		t.Fatal(o)
+	}
+}
+
+//
+
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	owners = map[string]string{}
+}
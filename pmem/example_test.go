@@ -7,7 +7,7 @@ package pmem_test
 import (
 	"log"
 
-	"periph.io/x/host/v3/pmem"
+	"github.com/s-mobi01/host/pmem"
 )
 
 func ExampleMapAsPOD() {
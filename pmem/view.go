@@ -12,7 +12,7 @@ import (
 	"sync"
 	"unsafe"
 
-	"periph.io/x/host/v3/fs"
+	"github.com/s-mobi01/host/fs"
 )
 
 // Slice can be transparently viewed as []byte, []uint32 or a struct.
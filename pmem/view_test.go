@@ -10,7 +10,7 @@ import (
 	"errors"
 	"testing"
 
-	"periph.io/x/host/v3/fs"
+	"github.com/s-mobi01/host/fs"
 )
 
 func TestSlice(t *testing.T) {
@@ -0,0 +1,21 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package pps implements access to the Linux pulse per second (PPS) devices
+// /dev/ppsN.
+//
+// The PPS sources, e.g. the pps-gpio driver connected to the PPS output of a
+// GPS receiver, timestamp each pulse in the kernel interrupt handler. This
+// package returns these timestamps, either one at a time with Fetch or as a
+// stream with Events.
+//
+// Disciplining the system clock is left to the time daemon (chrony, ntpd);
+// this package is for applications that need the timestamps themselves.
+//
+// Reference
+//
+// https://www.kernel.org/doc/html/latest/driver-api/pps.html
+//
+// RFC 2783, Pulse-Per-Second API for UNIX-like Operating Systems.
+package pps
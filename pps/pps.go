@@ -0,0 +1,363 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package pps
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+)
+
+// Enumerate returns the PPS devices N as in /dev/ppsN.
+func Enumerate() ([]int, error) {
+	const prefix = "/dev/pps"
+	items, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int, 0, len(items))
+	for _, item := range items {
+		i, err := strconv.Atoi(item[len(prefix):])
+		if err != nil {
+			continue
+		}
+		out = append(out, i)
+	}
+	sort.Ints(out)
+	return out, nil
+}
+
+// Open opens the PPS device /dev/pps<n>.
+func Open(n int) (*Dev, error) {
+	name := fmt.Sprintf("pps%d", n)
+	f, err := fs.Open("/dev/"+name, os.O_RDWR)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("pps: need more access, try as root: %v", err)
+		}
+		return nil, fmt.Errorf("pps: %v", err)
+	}
+	d := &Dev{name: name, f: &ppsFile{f}}
+	if err := f.Ioctl(ppsGetCap, uintptr(unsafe.Pointer(&d.caps))); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("pps: %s is not a PPS device: %v", name, err)
+	}
+	return d, nil
+}
+
+// Edge is the edge of the pulse.
+type Edge uint8
+
+// Edges of a pulse. Assert is usually the rising edge, marking the start of
+// the second.
+const (
+	Assert Edge = 1
+	Clear  Edge = 2
+)
+
+func (e Edge) String() string {
+	switch e {
+	case Assert:
+		return "Assert"
+	case Clear:
+		return "Clear"
+	default:
+		return fmt.Sprintf("Edge(%d)", e)
+	}
+}
+
+// Event is a timestamped pulse edge.
+type Event struct {
+	Edge Edge
+	// Sequence is the number of edges of this kind captured since the source
+	// was registered.
+	Sequence uint32
+	// Time is the time of the edge according to the system clock.
+	Time time.Time
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("%s #%d %s", e.Edge, e.Sequence, e.Time.Format(time.RFC3339Nano))
+}
+
+// Info is the state of a PPS source: the last captured assert and clear
+// edges.
+type Info struct {
+	AssertSequence uint32
+	ClearSequence  uint32
+	Assert         time.Time
+	Clear          time.Time
+}
+
+// Dev is an open PPS device.
+type Dev struct {
+	name string
+	f    ppsDev
+	caps int32
+
+	mu     sync.Mutex
+	events chan Event
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func (d *Dev) String() string {
+	return d.name
+}
+
+// Close stops Events, if it was started, and closes the device.
+func (d *Dev) Close() error {
+	d.mu.Lock()
+	if d.done != nil {
+		close(d.done)
+		d.done = nil
+	}
+	d.mu.Unlock()
+	d.wg.Wait()
+	if err := d.f.Close(); err != nil {
+		return fmt.Errorf("pps: %v", err)
+	}
+	return nil
+}
+
+// CanCaptureClear returns true if the source can timestamp the clear edge.
+func (d *Dev) CanCaptureClear() bool {
+	return d.caps&ppsCaptureClear != 0
+}
+
+// SetCapture selects the edges being timestamped by the source.
+//
+// This changes the source for all its users and requires the CAP_SYS_TIME
+// capability, normally running as root. The default depends on the driver,
+// usually assert only.
+func (d *Dev) SetCapture(edges ...Edge) error {
+	var p ppsKParams
+	if err := d.f.getParams(&p); err != nil {
+		return fmt.Errorf("pps: %v", err)
+	}
+	p.apiVersion = ppsAPIVers1
+	p.mode &^= ppsCaptureBoth
+	for _, e := range edges {
+		switch e {
+		case Assert:
+			p.mode |= ppsCaptureAssert
+		case Clear:
+			if !d.CanCaptureClear() {
+				return errors.New("pps: source can't capture the clear edge")
+			}
+			p.mode |= ppsCaptureClear
+		default:
+			return fmt.Errorf("pps: invalid edge %s", e)
+		}
+	}
+	if err := d.f.setParams(&p); err != nil {
+		return fmt.Errorf("pps: %v", err)
+	}
+	return nil
+}
+
+// Fetch waits up to timeout for the next edge and returns the state of the
+// source. A timeout of 0 returns the current state without waiting.
+//
+// It returns an error if no edge was captured before the timeout.
+func (d *Dev) Fetch(timeout time.Duration) (Info, error) {
+	i, err := d.fetch(timeout)
+	if err == errTimeout {
+		return i, errors.New("pps: timed out waiting for a pulse")
+	}
+	return i, err
+}
+
+// Events returns a channel receiving each captured edge.
+//
+// A goroutine fetches the edges until Close is called, which closes the
+// channel. Events returns the same channel when called again. The edges are
+// dropped if the channel is not drained; the Sequence field allows detecting
+// it.
+func (d *Dev) Events() <-chan Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.events == nil {
+		d.events = make(chan Event, 16)
+		d.done = make(chan struct{})
+		d.wg.Add(1)
+		go d.run(d.events, d.done)
+	}
+	return d.events
+}
+
+//
+
+// pollPeriod is the maximum time a blocking fetch is waiting, as Close can't
+// interrupt an ioctl.
+const pollPeriod = 200 * time.Millisecond
+
+// run sends the edges on c until done is closed.
+func (d *Dev) run(c chan<- Event, done <-chan struct{}) {
+	defer d.wg.Done()
+	defer close(c)
+	last, err := d.fetch(0)
+	if err != nil {
+		return
+	}
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		i, err := d.fetch(pollPeriod)
+		if err == errTimeout {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		for _, e := range newEvents(&last, &i) {
+			select {
+			case c <- e:
+			default:
+			}
+		}
+		last = i
+	}
+}
+
+func (d *Dev) fetch(timeout time.Duration) (Info, error) {
+	var fd ppsFData
+	fd.timeout.sec = int64(timeout / time.Second)
+	fd.timeout.nsec = int32(timeout % time.Second)
+	for {
+		err := d.f.fetch(&fd)
+		if err == syscall.EINTR {
+			// Interrupted by a signal, e.g. the Go runtime preemption.
+			continue
+		}
+		if err == syscall.ETIMEDOUT {
+			return Info{}, errTimeout
+		}
+		if err != nil {
+			return Info{}, fmt.Errorf("pps: %v", err)
+		}
+		break
+	}
+	return Info{
+		AssertSequence: fd.info.assertSequence,
+		ClearSequence:  fd.info.clearSequence,
+		Assert:         fd.info.assertTu.time(),
+		Clear:          fd.info.clearTu.time(),
+	}, nil
+}
+
+// newEvents returns the edges captured in cur since prev, in chronological
+// order.
+func newEvents(prev, cur *Info) []Event {
+	var out []Event
+	if cur.AssertSequence != prev.AssertSequence {
+		out = append(out, Event{Edge: Assert, Sequence: cur.AssertSequence, Time: cur.Assert})
+	}
+	if cur.ClearSequence != prev.ClearSequence {
+		e := Event{Edge: Clear, Sequence: cur.ClearSequence, Time: cur.Clear}
+		if len(out) != 0 && e.Time.Before(out[0].Time) {
+			out = []Event{e, out[0]}
+		} else {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+var errTimeout = errors.New("pps: timeout")
+
+// ppsDev is implemented by ppsFile and mocked in tests.
+type ppsDev interface {
+	fetch(fd *ppsFData) error
+	getParams(p *ppsKParams) error
+	setParams(p *ppsKParams) error
+	Close() error
+}
+
+// ppsFile is a /dev/ppsN device.
+type ppsFile struct {
+	*fs.File
+}
+
+func (p *ppsFile) fetch(fd *ppsFData) error {
+	return p.Ioctl(ppsFetch, uintptr(unsafe.Pointer(fd)))
+}
+
+func (p *ppsFile) getParams(k *ppsKParams) error {
+	return p.Ioctl(ppsGetParams, uintptr(unsafe.Pointer(k)))
+}
+
+func (p *ppsFile) setParams(k *ppsKParams) error {
+	return p.Ioctl(ppsSetParams, uintptr(unsafe.Pointer(k)))
+}
+
+// Structures and constants from include/uapi/linux/pps.h.
+
+const (
+	ppsAPIVers1 = 1
+
+	ppsCaptureAssert = 0x01
+	ppsCaptureClear  = 0x02
+	ppsCaptureBoth   = 0x03
+)
+
+// The ioctls were defined with a pointer type, so their size is the pointer
+// size.
+var (
+	ppsGetParams = fs.IOR('p', 0xA1, uint(unsafe.Sizeof(uintptr(0))))
+	ppsSetParams = fs.IOW('p', 0xA2, uint(unsafe.Sizeof(uintptr(0))))
+	ppsGetCap    = fs.IOR('p', 0xA3, uint(unsafe.Sizeof(uintptr(0))))
+	ppsFetch     = fs.IOWR('p', 0xA4, uint(unsafe.Sizeof(uintptr(0))))
+)
+
+// ppsKTime is struct pps_ktime.
+type ppsKTime struct {
+	sec   int64
+	nsec  int32
+	flags uint32
+}
+
+func (k *ppsKTime) time() time.Time {
+	if k.sec == 0 && k.nsec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(k.sec, int64(k.nsec))
+}
+
+// ppsKInfo is struct pps_kinfo.
+type ppsKInfo struct {
+	assertSequence uint32
+	clearSequence  uint32
+	assertTu       ppsKTime
+	clearTu        ppsKTime
+	currentMode    int32
+	_              int32 // 64 bits alignment, as on 64 bits and ARM EABI
+}
+
+// ppsFData is struct pps_fdata.
+type ppsFData struct {
+	info    ppsKInfo
+	timeout ppsKTime
+}
+
+// ppsKParams is struct pps_kparams.
+type ppsKParams struct {
+	apiVersion  int32
+	mode        int32
+	assertOffTu ppsKTime
+	clearOffTu  ppsKTime
+}
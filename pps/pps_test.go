@@ -0,0 +1,162 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package pps
+
+import (
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestSizes(t *testing.T) {
+	if s := unsafe.Sizeof(ppsFData{}); s != 64 {
+		t.Fatalf("pps_fdata is %d bytes", s)
+	}
+	if s := unsafe.Sizeof(ppsKParams{}); s != 40 {
+		t.Fatalf("pps_kparams is %d bytes", s)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := &fakePPS{infos: []ppsKInfo{
+		{assertSequence: 7, assertTu: ppsKTime{sec: 1700000000, nsec: 123}},
+	}}
+	d := &Dev{name: "pps0", f: f}
+	i, err := d.Fetch(1500 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Info{AssertSequence: 7, Assert: time.Unix(1700000000, 123)}
+	if !reflect.DeepEqual(i, want) {
+		t.Fatalf("Fetch() = %#v", i)
+	}
+	if f.timeout != (ppsKTime{sec: 1, nsec: 500000000}) {
+		t.Fatalf("timeout = %#v", f.timeout)
+	}
+	if _, err := d.Fetch(time.Second); err == nil {
+		t.Fatal("expected timeout")
+	}
+}
+
+func TestEvents(t *testing.T) {
+	t0 := ppsKTime{sec: 100}
+	t1 := ppsKTime{sec: 101}
+	t2 := ppsKTime{sec: 101, nsec: 100000000}
+	f := &fakePPS{infos: []ppsKInfo{
+		{assertSequence: 1, assertTu: t0},
+		{assertSequence: 2, assertTu: t1},
+		{assertSequence: 2, assertTu: t1, clearSequence: 1, clearTu: t2},
+	}}
+	d := &Dev{name: "pps0", f: f}
+	c := d.Events()
+	var got []Event
+	for e := range c {
+		got = append(got, e)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := []Event{
+		{Edge: Assert, Sequence: 2, Time: t1.time()},
+		{Edge: Clear, Sequence: 1, Time: t2.time()},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Events() = %v", got)
+	}
+	if _, ok := <-c; ok {
+		t.Fatal("channel not closed")
+	}
+}
+
+func TestNewEvents(t *testing.T) {
+	prev := Info{AssertSequence: 1, ClearSequence: 1}
+	cur := Info{AssertSequence: 2, ClearSequence: 2, Assert: time.Unix(11, 0), Clear: time.Unix(10, 5e8)}
+	got := newEvents(&prev, &cur)
+	want := []Event{
+		{Edge: Clear, Sequence: 2, Time: cur.Clear},
+		{Edge: Assert, Sequence: 2, Time: cur.Assert},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("newEvents() = %v", got)
+	}
+	if got := newEvents(&cur, &cur); len(got) != 0 {
+		t.Fatalf("newEvents() = %v", got)
+	}
+}
+
+func TestSetCapture(t *testing.T) {
+	f := &fakePPS{params: ppsKParams{mode: 0x1011}}
+	d := &Dev{name: "pps0", f: f, caps: ppsCaptureAssert}
+	if err := d.SetCapture(Assert, Clear); err == nil {
+		t.Fatal("clear isn't supported")
+	}
+	d.caps |= ppsCaptureClear
+	if err := d.SetCapture(Clear); err != nil {
+		t.Fatal(err)
+	}
+	if f.params.mode != 0x1012 || f.params.apiVersion != ppsAPIVers1 {
+		t.Fatalf("params = %#v", f.params)
+	}
+}
+
+func TestEdge_String(t *testing.T) {
+	if s := Edge(3).String(); s != "Edge(3)" {
+		t.Fatal(s)
+	}
+	e := Event{Edge: Assert, Sequence: 3, Time: time.Unix(1, 5).UTC()}
+	if s := e.String(); s != "Assert #3 1970-01-01T00:00:01.000000005Z" {
+		t.Fatal(s)
+	}
+}
+
+//
+
+// fakePPS returns infos one at a time on fetch, then times out.
+type fakePPS struct {
+	mu      sync.Mutex
+	infos   []ppsKInfo
+	timeout ppsKTime
+	params  ppsKParams
+	eintr   bool
+}
+
+func (f *fakePPS) fetch(fd *ppsFData) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.timeout = fd.timeout
+	if !f.eintr {
+		// Exercise the retry on signal once.
+		f.eintr = true
+		return syscall.EINTR
+	}
+	if len(f.infos) == 0 {
+		// Don't spin in Events.
+		time.Sleep(time.Millisecond)
+		return syscall.ETIMEDOUT
+	}
+	fd.info = f.infos[0]
+	f.infos = f.infos[1:]
+	return nil
+}
+
+func (f *fakePPS) getParams(p *ppsKParams) error {
+	*p = f.params
+	return nil
+}
+
+func (f *fakePPS) setParams(p *ppsKParams) error {
+	f.params = *p
+	return nil
+}
+
+func (f *fakePPS) Close() error {
+	return nil
+}
@@ -7,7 +7,7 @@ package pru
 import (
 	"errors"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/hostcfg"
 )
 
 // Present returns true if an Texas Instrument PRU-ICSS processor is detected.
@@ -45,7 +45,7 @@ func (d *driver) Init() (bool, error) {
 
 func init() {
 	if isArm {
-		driverreg.MustRegister(&drv)
+		hostcfg.MustRegister(&drv)
 	}
 }
 
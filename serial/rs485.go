@@ -0,0 +1,114 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package serial
+
+import (
+	"errors"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+// RS485 is the RS-485 configuration of a port.
+//
+// In RS-485 half-duplex mode the transceiver driver must be enabled while
+// sending and disabled afterward so that the other nodes can answer. This is
+// done with the RTS line, or with a GPIO connected to the transceiver DE
+// input.
+type RS485 struct {
+	// Enabled enables the RS-485 mode.
+	Enabled bool
+	// RTSOnSend is the level of RTS (or DE) while sending. The opposite level
+	// is used when not sending. Most transceivers need High.
+	RTSOnSend gpio.Level
+	// DelayBeforeSend is the delay between enabling the driver and sending
+	// the first bit.
+	DelayBeforeSend time.Duration
+	// DelayAfterSend is the delay between sending the last bit and disabling
+	// the driver.
+	DelayAfterSend time.Duration
+	// RXDuringTX keeps the receiver enabled while sending, to read back the
+	// data sent. Only supported by the kernel mode.
+	RXDuringTX bool
+	// DE, if set, is the GPIO driving the transceiver instead of RTS. It
+	// always uses the software mode.
+	DE gpio.PinOut
+}
+
+// SetRS485 configures the RS-485 mode.
+//
+// The kernel mode, using the TIOCSRS485 ioctl, is used when the UART driver
+// supports it and DE is not set; the direction is then switched by the
+// driver with an exact timing. Otherwise the direction is switched by Write
+// and Tx, which block until the data is sent. The kernel delays have a
+// millisecond resolution.
+//
+// It is only supported on Linux.
+func (p *Port) SetRS485(c RS485) error {
+	if c.DelayBeforeSend < 0 || c.DelayAfterSend < 0 {
+		return errors.New("sysfs-uart: invalid RS-485 delay")
+	}
+	p.conn.mu.Lock()
+	defer p.conn.mu.Unlock()
+	if p.conn.f == nil {
+		return errors.New("sysfs-uart: already closed")
+	}
+	p.conn.rs485 = RS485{}
+	if c.DE == nil {
+		err := setRS485Kernel(p.conn.f, &c)
+		if err == nil || !c.Enabled {
+			return err
+		}
+		if err != errNoKernelRS485 {
+			return err
+		}
+	} else if c.RXDuringTX {
+		return errors.New("sysfs-uart: RXDuringTX requires the kernel RS-485 mode")
+	}
+	if !c.Enabled {
+		return nil
+	}
+	// Software mode; start in receive mode.
+	p.conn.rs485 = c
+	return p.conn.setDirection(false)
+}
+
+//
+
+var errNoKernelRS485 = errors.New("sysfs-uart: the UART driver doesn't support RS-485")
+
+// setDirection enables the transceiver driver when sending is true.
+//
+// Must be called with mu held.
+func (s *serialConn) setDirection(sending bool) error {
+	l := gpio.Level(sending == bool(s.rs485.RTSOnSend))
+	if s.rs485.DE != nil {
+		return s.rs485.DE.Out(l)
+	}
+	return setRTS(s.f, l)
+}
+
+// write writes b, switching the RS-485 direction around it in software mode.
+func (s *serialConn) write(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.rs485.Enabled {
+		return s.f.Write(b)
+	}
+	if err := s.setDirection(true); err != nil {
+		return 0, err
+	}
+	time.Sleep(s.rs485.DelayBeforeSend)
+	n, err := s.f.Write(b)
+	if err == nil {
+		// Wait for the last bit to be shifted out.
+		err = drain(s.f)
+	}
+	time.Sleep(s.rs485.DelayAfterSend)
+	if err2 := s.setDirection(false); err == nil {
+		err = err2
+	}
+	return n, err
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package serial
+
+import (
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+// Structures and constants from include/uapi/linux/serial.h and
+// include/uapi/asm-generic/ioctls.h.
+
+const (
+	ioctlTCSBRK     = 0x5409
+	ioctlTIOCMBIS   = 0x5416
+	ioctlTIOCMBIC   = 0x5417
+	ioctlTIOCSRS485 = 0x542F
+	tiocmRTS        = 0x004
+
+	rs485Enabled      = 1 << 0
+	rs485RTSOnSend    = 1 << 1
+	rs485RTSAfterSend = 1 << 2
+	rs485RXDuringTX   = 1 << 4
+)
+
+// serialRS485 is struct serial_rs485.
+type serialRS485 struct {
+	flags              uint32
+	delayRTSBeforeSend uint32 // in milliseconds
+	delayRTSAfterSend  uint32 // in milliseconds
+	_                  [5]uint32
+}
+
+// setRS485Kernel configures the RS-485 mode of the UART driver.
+//
+// It returns errNoKernelRS485 when the driver doesn't support it.
+func setRS485Kernel(f *os.File, c *RS485) error {
+	var r serialRS485
+	if c.Enabled {
+		r.flags = rs485Enabled
+		if c.RTSOnSend {
+			r.flags |= rs485RTSOnSend
+		} else {
+			r.flags |= rs485RTSAfterSend
+		}
+		if c.RXDuringTX {
+			r.flags |= rs485RXDuringTX
+		}
+		r.delayRTSBeforeSend = uint32((c.DelayBeforeSend + time.Millisecond - 1) / time.Millisecond)
+		r.delayRTSAfterSend = uint32((c.DelayAfterSend + time.Millisecond - 1) / time.Millisecond)
+	}
+	switch err := ioctl(f, ioctlTIOCSRS485, uintptr(unsafe.Pointer(&r))); err {
+	case nil:
+		return nil
+	case syscall.ENOTTY, syscall.EINVAL:
+		if !c.Enabled {
+			// Nothing to disable.
+			return nil
+		}
+		return errNoKernelRS485
+	default:
+		return err
+	}
+}
+
+// setRTS sets the RTS modem line.
+func setRTS(f *os.File, l gpio.Level) error {
+	op := uint(ioctlTIOCMBIC)
+	if l {
+		op = ioctlTIOCMBIS
+	}
+	bits := uint32(tiocmRTS)
+	return ioctl(f, op, uintptr(unsafe.Pointer(&bits)))
+}
+
+// drain waits until all the output has been transmitted, like tcdrain(3).
+func drain(f *os.File) error {
+	return ioctl(f, ioctlTCSBRK, 1)
+}
+
+func ioctl(f *os.File, op uint, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(op), arg); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package serial
+
+import (
+	"errors"
+	"os"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+var errRS485 = errors.New("sysfs-uart: RS-485 is only supported on Linux")
+
+func setRS485Kernel(f *os.File, c *RS485) error {
+	if !c.Enabled {
+		return nil
+	}
+	return errRS485
+}
+
+func setRTS(f *os.File, l gpio.Level) error {
+	return errRS485
+}
+
+func drain(f *os.File) error {
+	return errRS485
+}
@@ -17,8 +17,8 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3"
-	"periph.io/x/conn/v3/driver/driverreg"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/physic"
@@ -49,6 +49,11 @@ func Enumerate() ([]int, error) {
 	return out, nil
 }
 
+// Open opens the serial port /dev/ttyS<portNumber>, as returned by Enumerate.
+func Open(portNumber int) (*Port, error) {
+	return newPortDevFs(portNumber)
+}
+
 func newPortDevFs(portNumber int) (*Port, error) {
 	// Use the devfs path for now.
 	name := fmt.Sprintf("ttyS%d", portNumber)
@@ -166,6 +171,7 @@ type serialConn struct {
 	freqConn    physic.Frequency // Frequency specified at Connect()
 	bitsPerWord uint8
 	connected   bool
+	rs485       RS485 // Only set in software RS-485 mode
 
 	// Use a separate lock for the pins, so that they can be queried while a
 	// transaction is happening.
@@ -183,6 +189,11 @@ func (s *serialConn) String() string {
 
 // Duplex implements conn.Conn.
 func (s *serialConn) Duplex() conn.Duplex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rs485.Enabled {
+		return conn.Half
+	}
 	return conn.Full
 }
 
@@ -193,13 +204,13 @@ func (s *serialConn) Read(b []byte) (int, error) {
 
 // Write implements io.Writer.
 func (s *serialConn) Write(b []byte) (int, error) {
-	return s.f.Write(b)
+	return s.write(b)
 }
 
 // Tx implements conn.Conn.
 func (s *serialConn) Tx(w, r []byte) error {
 	if len(w) != 0 {
-		if _, err := s.f.Write(w); err != nil {
+		if _, err := s.write(w); err != nil {
 			return err
 		}
 	}
@@ -280,7 +291,7 @@ func (d *driverSerial) Init() (bool, error) {
 }
 
 func init() {
-	driverreg.MustRegister(&drv)
+	hostcfg.MustRegister(&drv)
 }
 
 var drv driverSerial
@@ -2,8 +2,8 @@
 // Use of this source code is governed under the Apache License, Version 2.0
 // that can be found in the LICENSE file.
 
-//go:build freebsd || linux || netbsd || solaris
-// +build freebsd linux netbsd solaris
+//go:build linux
+// +build linux
 
 package serial
 
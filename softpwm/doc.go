@@ -0,0 +1,22 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package softpwm generates PWM on any gpio.PinOut in software, for boards
+// with one or no hardware PWM channel.
+//
+// All the pins share a single goroutine locked to an OS thread, which is
+// switched to the real-time scheduler with cpu.SetHighPriority when running
+// with enough privilege.
+//
+// Frequencies up to MaxFrequency are accepted. The edges jitter by the
+// scheduling latency of the host: on a Raspberry Pi 3 it is typically in the
+// tens of µs with the real-time scheduler and can exceed 1ms without it
+// under load. The engine busy-waits the last 200µs before each edge, so
+// above 1kHz it keeps a CPU core busy.
+//
+// The pin is claimed in package pinuse while the PWM runs.
+//
+// This is fine for LED dimming and hobby servos but not for precise timing;
+// use a hardware PWM channel for that.
+package softpwm
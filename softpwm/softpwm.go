@@ -0,0 +1,241 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package softpwm
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/s-mobi01/host/cpu"
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+// MaxFrequency is the highest accepted PWM frequency.
+const MaxFrequency = 5 * physic.KiloHertz
+
+// New returns p with PWM implemented in software.
+//
+// p must not be used directly afterward.
+func New(p gpio.PinOut) *Pin {
+	return &Pin{PinOut: p}
+}
+
+// Pin is a gpio.PinOut with a software PWM.
+type Pin struct {
+	gpio.PinOut
+
+	mu      sync.Mutex
+	claimed bool
+
+	// The following are guarded by eng.mu.
+	high, low time.Duration
+	next      time.Time
+	level     gpio.Level
+}
+
+func (p *Pin) String() string {
+	return fmt.Sprintf("SoftPWM(%s)", p.PinOut)
+}
+
+// Out implements gpio.PinOut.
+//
+// It stops the PWM.
+func (p *Pin) Out(l gpio.Level) error {
+	p.stop()
+	return p.PinOut.Out(l)
+}
+
+// PWM implements gpio.PinOut.
+//
+// A duty of 0 or gpio.DutyMax sets the pin to a steady level without the
+// engine.
+func (p *Pin) PWM(duty gpio.Duty, f physic.Frequency) error {
+	if f <= 0 || f > MaxFrequency {
+		return fmt.Errorf("softpwm: frequency %s must be above 0 and at most %s", f, MaxFrequency)
+	}
+	if duty < 0 || duty > gpio.DutyMax {
+		return errors.New("softpwm: invalid duty")
+	}
+	if duty == 0 {
+		return p.Out(gpio.Low)
+	}
+	if duty == gpio.DutyMax {
+		return p.Out(gpio.High)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.claimed {
+		if err := pinuse.Claim(p.String(), p.PinOut); err != nil {
+			return fmt.Errorf("softpwm: %v", err)
+		}
+		p.claimed = true
+	}
+	high, low := split(duty, f)
+	eng.add(p, high, low)
+	return nil
+}
+
+// Halt implements conn.Resource.
+//
+// It stops the PWM, leaving the pin at its current level.
+func (p *Pin) Halt() error {
+	p.stop()
+	return p.PinOut.Halt()
+}
+
+//
+
+// stop removes the pin from the engine and releases it.
+func (p *Pin) stop() {
+	eng.remove(p)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.claimed {
+		pinuse.Release(p.String(), p.PinOut)
+		p.claimed = false
+	}
+}
+
+// split returns the high and low times of a PWM period.
+func split(duty gpio.Duty, f physic.Frequency) (time.Duration, time.Duration) {
+	period := f.Period()
+	high := time.Duration(int64(period) * int64(duty) / int64(gpio.DutyMax))
+	return high, period - high
+}
+
+// spin is how long before an edge the engine stops sleeping and busy-waits.
+const spin = 200 * time.Microsecond
+
+// engine toggles all the pins from a single goroutine.
+type engine struct {
+	mu      sync.Mutex
+	pins    []*Pin
+	running bool
+	wake    chan struct{}
+}
+
+var eng = engine{wake: make(chan struct{}, 1)}
+
+func (e *engine) add(p *Pin, high, low time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	p.high = high
+	p.low = low
+	if !e.has(p) {
+		e.pins = append(e.pins, p)
+		p.next = time.Now()
+		p.level = gpio.Low
+	}
+	if !e.running {
+		e.running = true
+		go e.run()
+	}
+	e.signal()
+}
+
+func (e *engine) remove(p *Pin) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, q := range e.pins {
+		if q == p {
+			copy(e.pins[i:], e.pins[i+1:])
+			e.pins = e.pins[:len(e.pins)-1]
+			e.signal()
+			return
+		}
+	}
+}
+
+// has returns true if the pin is driven by the engine.
+//
+// lock must be held.
+func (e *engine) has(p *Pin) bool {
+	for _, q := range e.pins {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
+
+// signal wakes up the goroutine so it reevaluates the next edge.
+//
+// lock must be held.
+func (e *engine) signal() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (e *engine) run() {
+	// The thread is never unlocked, so it is destroyed when the goroutine
+	// exits instead of returning to the pool with a real-time priority.
+	runtime.LockOSThread()
+	// Best effort; it requires privilege.
+	_ = cpu.SetHighPriority()
+	t := time.NewTimer(time.Hour)
+	for {
+		e.mu.Lock()
+		next, ok := e.toggle(time.Now())
+		if !ok {
+			e.running = false
+			e.mu.Unlock()
+			t.Stop()
+			return
+		}
+		e.mu.Unlock()
+		if d := time.Until(next) - spin; d > 0 {
+			if !t.Stop() {
+				select {
+				case <-t.C:
+				default:
+				}
+			}
+			t.Reset(d)
+			select {
+			case <-t.C:
+			case <-e.wake:
+				continue
+			}
+		}
+		for time.Now().Before(next) {
+		}
+	}
+}
+
+// toggle flips the pins whose edge is due and returns the time of the next
+// edge. It returns false when there is no pin left.
+//
+// lock must be held.
+func (e *engine) toggle(now time.Time) (time.Time, bool) {
+	if len(e.pins) == 0 {
+		return time.Time{}, false
+	}
+	var next time.Time
+	for i, p := range e.pins {
+		if !p.next.After(now) {
+			p.level = !p.level
+			_ = p.PinOut.Out(p.level)
+			d := p.low
+			if p.level {
+				d = p.high
+			}
+			// Skip the missed edges instead of catching up.
+			if p.next = p.next.Add(d); p.next.Before(now) {
+				p.next = now.Add(d)
+			}
+		}
+		if i == 0 || p.next.Before(next) {
+			next = p.next
+		}
+	}
+	return next, true
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package softpwm
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/s-mobi01/host/pinuse"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/physic"
+)
+
+func TestPin_PWM(t *testing.T) {
+	r := &recordPin{Pin: gpiotest.Pin{N: "GPIO1"}}
+	p := New(r)
+	if s := p.String(); s != "SoftPWM(GPIO1(0))" {
+		t.Fatal(s)
+	}
+	if err := p.PWM(gpio.DutyHalf, physic.KiloHertz); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := p.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	n := r.count()
+	// 100 edges are expected; be lenient for loaded hosts.
+	if n < 10 {
+		t.Fatalf("only %d edges", n)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if r.count() != n || !r.Read() {
+		t.Fatal("PWM not stopped")
+	}
+	waitStopped(t)
+}
+
+func TestPin_PWM_steady(t *testing.T) {
+	r := &recordPin{}
+	p := New(r)
+	if err := p.PWM(gpio.DutyMax, physic.KiloHertz); err != nil || !r.Read() {
+		t.Fatal(err)
+	}
+	if err := p.PWM(0, physic.KiloHertz); err != nil || r.Read() {
+		t.Fatal(err)
+	}
+	if err := p.PWM(gpio.DutyHalf, 10*physic.KiloHertz); err == nil {
+		t.Fatal("above MaxFrequency")
+	}
+	if err := p.PWM(gpio.DutyMax+1, physic.KiloHertz); err == nil {
+		t.Fatal("invalid duty")
+	}
+	if err := p.PWM(gpio.DutyHalf, 100*physic.Hertz); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Halt(); err != nil {
+		t.Fatal(err)
+	}
+	waitStopped(t)
+	if err := pinuse.Claim("other", r); err != nil {
+		t.Fatal(err)
+	}
+	defer pinuse.Release("other", r)
+	if err := p.PWM(gpio.DutyHalf, 100*physic.Hertz); err == nil {
+		t.Fatal("pin is busy")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	high, low := split(gpio.DutyMax/4, physic.KiloHertz)
+	if high != 250*time.Microsecond || low != 750*time.Microsecond {
+		t.Fatal(high, low)
+	}
+}
+
+func TestEngine_toggle(t *testing.T) {
+	now := time.Now()
+	a := &Pin{PinOut: &recordPin{}, high: time.Millisecond, low: 3 * time.Millisecond, next: now}
+	b := &Pin{PinOut: &recordPin{}, high: time.Millisecond, low: time.Millisecond, next: now.Add(time.Microsecond)}
+	e := engine{pins: []*Pin{a, b}}
+	next, ok := e.toggle(now)
+	if !ok || !next.Equal(now.Add(time.Microsecond)) || a.level != gpio.High || b.level != gpio.Low {
+		t.Fatal(next, a.level, b.level)
+	}
+	// b is late by more than a period; its missed edges are skipped.
+	later := now.Add(5 * time.Millisecond)
+	if next, _ = e.toggle(later); !next.Equal(later.Add(time.Millisecond)) {
+		t.Fatal(next.Sub(now))
+	}
+	if _, ok := (&engine{}).toggle(now); ok {
+		t.Fatal("no pin")
+	}
+}
+
+//
+
+// recordPin counts the level changes.
+type recordPin struct {
+	gpiotest.Pin
+	mu    sync.Mutex
+	edges int
+}
+
+func (r *recordPin) Out(l gpio.Level) error {
+	r.mu.Lock()
+	if l != r.Read() {
+		r.edges++
+	}
+	r.mu.Unlock()
+	return r.Pin.Out(l)
+}
+
+func (r *recordPin) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.edges
+}
+
+func waitStopped(t *testing.T) {
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		eng.mu.Lock()
+		running := eng.running
+		eng.mu.Unlock()
+		if !running {
+			return
+		}
+	}
+	t.Fatal("engine still running")
+}
@@ -0,0 +1,16 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package spimux shares one SPI port between many devices, each selected by
+// its own GPIO chip select or by a 74HC138-style 3-to-8 line decoder.
+//
+// Each device is exposed as an independent spi.Port. The transactions are
+// serialized: the chip select of a device is asserted only for the duration
+// of its own transaction.
+//
+// The port is connected once for all the devices, so they share the clock
+// frequency, mode and word size. The hardware chip select of the port must
+// be left unconnected, or disabled by opening the port with spi.NoCS when
+// the driver supports it.
+package spimux
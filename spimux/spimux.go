@@ -0,0 +1,250 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package spimux
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+// Selector asserts the chip select of one device at a time.
+type Selector interface {
+	// Len returns the number of devices that can be selected.
+	Len() int
+	// Select asserts the chip select of device n.
+	Select(n int) error
+	// Deselect deasserts all the chip selects.
+	Deselect() error
+}
+
+// GPIOSelect returns a Selector with one active low chip select pin per
+// device.
+func GPIOSelect(cs ...gpio.PinOut) Selector {
+	return gpioSelect(cs)
+}
+
+// Decoder returns a Selector driving a 3-to-8 line decoder like the 74HC138,
+// whose outputs are active low chip selects.
+//
+// addr are the address pins, A0 first; there can be up to 3 of them for
+// respectively 2 to 8 devices. enable is the active low enable pin, G2A or
+// G2B with G1 tied high.
+func Decoder(enable gpio.PinOut, addr ...gpio.PinOut) (Selector, error) {
+	if enable == nil {
+		return nil, errors.New("spimux: enable pin is required")
+	}
+	if len(addr) == 0 || len(addr) > 3 {
+		return nil, errors.New("spimux: 1 to 3 address pins are required")
+	}
+	return &decoder{enable: enable, addr: addr}, nil
+}
+
+// New connects the port once for all the devices.
+//
+// The mode is used as-is; add spi.NoCS to disable the hardware chip select
+// if the port supports it.
+func New(p spi.Port, f physic.Frequency, mode spi.Mode, bits int, s Selector) (*Mux, error) {
+	if s == nil || s.Len() == 0 {
+		return nil, errors.New("spimux: selector is required")
+	}
+	if err := s.Deselect(); err != nil {
+		return nil, fmt.Errorf("spimux: %v", err)
+	}
+	c, err := p.Connect(f, mode, bits)
+	if err != nil {
+		return nil, fmt.Errorf("spimux: %v", err)
+	}
+	m := &Mux{p: p, c: c, s: s, f: f, mode: mode, bits: bits}
+	for i := 0; i < s.Len(); i++ {
+		m.ports = append(m.ports, &Port{m: m, n: i})
+	}
+	return m, nil
+}
+
+// Mux is an SPI port shared between devices.
+type Mux struct {
+	p    spi.Port
+	c    spi.Conn
+	s    Selector
+	f    physic.Frequency
+	mode spi.Mode
+	bits int
+
+	mu    sync.Mutex
+	ports []*Port
+}
+
+func (m *Mux) String() string {
+	return fmt.Sprintf("spimux(%s)", m.p)
+}
+
+// Port returns the port of device n.
+func (m *Mux) Port(n int) (*Port, error) {
+	if n < 0 || n >= len(m.ports) {
+		return nil, fmt.Errorf("spimux: invalid device %d", n)
+	}
+	return m.ports[n], nil
+}
+
+// Close deselects all the devices and closes the underlying port if it
+// implements io.Closer.
+func (m *Mux) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.s.Deselect()
+	if c, ok := m.p.(io.Closer); ok {
+		if err2 := c.Close(); err == nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// Port is the port of one device behind the multiplexer.
+//
+// It implements spi.PortCloser.
+type Port struct {
+	m *Mux
+	n int
+}
+
+func (p *Port) String() string {
+	return fmt.Sprintf("%s/%d", p.m, p.n)
+}
+
+// Close implements spi.PortCloser.
+//
+// It is a no-op; close the Mux to release the port.
+func (p *Port) Close() error {
+	return nil
+}
+
+// LimitSpeed implements spi.PortCloser.
+//
+// The frequency is shared by all the devices, so it fails if f is below it.
+func (p *Port) LimitSpeed(f physic.Frequency) error {
+	if f < p.m.f {
+		return fmt.Errorf("spimux: %s is below the shared frequency %s", f, p.m.f)
+	}
+	return nil
+}
+
+// Connect implements spi.Port.
+//
+// The mode and word size must match the ones of the Mux, and the frequency
+// must be at least the shared frequency.
+func (p *Port) Connect(f physic.Frequency, mode spi.Mode, bits int) (spi.Conn, error) {
+	if mode&^spi.NoCS != p.m.mode&^spi.NoCS || bits != p.m.bits {
+		return nil, fmt.Errorf("spimux: device %d wants mode %s and %d bits but the port is shared with mode %s and %d bits", p.n, mode, bits, p.m.mode, p.m.bits)
+	}
+	if err := p.LimitSpeed(f); err != nil {
+		return nil, err
+	}
+	return &devConn{p: p}, nil
+}
+
+//
+
+// devConn is the connection to one device.
+type devConn struct {
+	p *Port
+}
+
+func (d *devConn) String() string {
+	return d.p.String()
+}
+
+func (d *devConn) Duplex() conn.Duplex {
+	return d.p.m.c.Duplex()
+}
+
+func (d *devConn) Tx(w, r []byte) error {
+	return d.p.m.tx(d.p.n, func() error {
+		return d.p.m.c.Tx(w, r)
+	})
+}
+
+func (d *devConn) TxPackets(p []spi.Packet) error {
+	return d.p.m.tx(d.p.n, func() error {
+		return d.p.m.c.TxPackets(p)
+	})
+}
+
+// tx runs f with device n selected.
+func (m *Mux) tx(n int, f func() error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.s.Select(n); err != nil {
+		_ = m.s.Deselect()
+		return fmt.Errorf("spimux: %v", err)
+	}
+	err := f()
+	if err2 := m.s.Deselect(); err == nil && err2 != nil {
+		err = fmt.Errorf("spimux: %v", err2)
+	}
+	return err
+}
+
+type gpioSelect []gpio.PinOut
+
+func (g gpioSelect) Len() int {
+	return len(g)
+}
+
+func (g gpioSelect) Select(n int) error {
+	if n < 0 || n >= len(g) {
+		return fmt.Errorf("invalid device %d", n)
+	}
+	return g[n].Out(gpio.Low)
+}
+
+func (g gpioSelect) Deselect() error {
+	var err error
+	for _, p := range g {
+		if err2 := p.Out(gpio.High); err == nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+type decoder struct {
+	enable gpio.PinOut
+	addr   []gpio.PinOut
+}
+
+func (d *decoder) Len() int {
+	return 1 << uint(len(d.addr))
+}
+
+func (d *decoder) Select(n int) error {
+	if n < 0 || n >= d.Len() {
+		return fmt.Errorf("invalid device %d", n)
+	}
+	// Set the address while disabled so no other device glitches.
+	if err := d.enable.Out(gpio.High); err != nil {
+		return err
+	}
+	for i, p := range d.addr {
+		if err := p.Out(gpio.Level(n&(1<<uint(i)) != 0)); err != nil {
+			return err
+		}
+	}
+	return d.enable.Out(gpio.Low)
+}
+
+func (d *decoder) Deselect() error {
+	return d.enable.Out(gpio.High)
+}
+
+var _ spi.PortCloser = &Port{}
+var _ spi.Conn = &devConn{}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package spimux
+
+import (
+	"testing"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+func TestMux_gpio(t *testing.T) {
+	cs := []*gpiotest.Pin{{N: "CS0"}, {N: "CS1"}}
+	f := &fakePort{}
+	f.c.onTx = func() {
+		f.c.selected = []gpio.Level{cs[0].Read(), cs[1].Read()}
+	}
+	m, err := New(f, physic.MegaHertz, spi.Mode0|spi.NoCS, 8, GPIOSelect(cs[0], cs[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.mode != spi.Mode0|spi.NoCS || !cs[0].Read() || !cs[1].Read() {
+		t.Fatal("expected deselected devices")
+	}
+	p, err := m.Port(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := p.String(); s != "spimux(SPI0)/1" {
+		t.Fatal(s)
+	}
+	c, err := p.Connect(2*physic.MegaHertz, spi.Mode0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Tx([]byte{1}, make([]byte, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if s := f.c.selected; s[0] != gpio.High || s[1] != gpio.Low {
+		t.Fatal(s)
+	}
+	if !cs[1].Read() {
+		t.Fatal("not deselected")
+	}
+	if err := c.TxPackets([]spi.Packet{{W: []byte{1}}}); err != nil {
+		t.Fatal(err)
+	}
+	if c.Duplex() != conn.Full || c.String() != "spimux(SPI0)/1" {
+		t.Fatal(c.Duplex(), c)
+	}
+	if err := m.Close(); err != nil || !f.closed {
+		t.Fatal(err)
+	}
+}
+
+func TestMux_decoder(t *testing.T) {
+	en := &gpiotest.Pin{N: "EN"}
+	a := []*gpiotest.Pin{{N: "A0"}, {N: "A1"}, {N: "A2"}}
+	s, err := Decoder(en, a[0], a[1], a[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 8 {
+		t.Fatal(s.Len())
+	}
+	f := &fakePort{}
+	f.c.onTx = func() {
+		f.c.selected = []gpio.Level{en.Read(), a[0].Read(), a[1].Read(), a[2].Read()}
+	}
+	m, err := New(f, physic.MegaHertz, spi.Mode3, 8, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := m.Port(5)
+	c, err := p.Connect(physic.MegaHertz, spi.Mode3, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Tx([]byte{1}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s := f.c.selected; s[0] != gpio.Low || s[1] != gpio.High || s[2] != gpio.Low || s[3] != gpio.High {
+		t.Fatal(s)
+	}
+	if !en.Read() {
+		t.Fatal("not disabled")
+	}
+}
+
+func TestMux_invalid(t *testing.T) {
+	cs := &gpiotest.Pin{N: "CS0"}
+	if _, err := New(&fakePort{}, physic.MegaHertz, spi.Mode0, 8, GPIOSelect()); err == nil {
+		t.Fatal("no device")
+	}
+	m, err := New(&fakePort{}, physic.MegaHertz, spi.Mode0, 8, GPIOSelect(cs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Port(1); err == nil {
+		t.Fatal("invalid device")
+	}
+	p, _ := m.Port(0)
+	if _, err := p.Connect(physic.MegaHertz, spi.Mode1, 8); err == nil {
+		t.Fatal("mode mismatch")
+	}
+	if _, err := p.Connect(physic.KiloHertz, spi.Mode0, 8); err == nil {
+		t.Fatal("device too slow")
+	}
+	if _, err := Decoder(nil, cs); err == nil {
+		t.Fatal("enable pin required")
+	}
+	if _, err := Decoder(cs); err == nil {
+		t.Fatal("address pins required")
+	}
+}
+
+//
+
+type fakePort struct {
+	c      fakeConn
+	mode   spi.Mode
+	closed bool
+}
+
+func (f *fakePort) String() string {
+	return "SPI0"
+}
+
+func (f *fakePort) Connect(freq physic.Frequency, mode spi.Mode, bits int) (spi.Conn, error) {
+	f.mode = mode
+	return &f.c, nil
+}
+
+func (f *fakePort) Close() error {
+	f.closed = true
+	return nil
+}
+
+type fakeConn struct {
+	onTx     func()
+	selected []gpio.Level
+}
+
+func (f *fakeConn) String() string {
+	return "SPI0"
+}
+
+func (f *fakeConn) Duplex() conn.Duplex {
+	return conn.Full
+}
+
+func (f *fakeConn) Tx(w, r []byte) error {
+	if f.onTx != nil {
+		f.onTx()
+	}
+	return nil
+}
+
+func (f *fakeConn) TxPackets(p []spi.Packet) error {
+	return f.Tx(nil, nil)
+}
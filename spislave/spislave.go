@@ -0,0 +1,42 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package spislave defines the API to use the host as an SPI peripheral
+// (slave), driven by an external SPI master.
+//
+// This can be used to emulate a device when testing a driver running on
+// another board, or as a board-to-board link.
+//
+// The implementations are sysfs.SPISlave, for the spidev driver bound to an
+// SPI controller supporting the slave mode, and bcm283x.SPISlave for the BSC
+// slave controller of the Raspberry Pi.
+package spislave
+
+import (
+	"io"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/spi"
+)
+
+// Port is an SPI port in peripheral mode.
+type Port interface {
+	String() string
+	// Connect sets the mode and the word size used by the master. The clock is
+	// provided by the master.
+	//
+	// The returned Conn's Tx blocks until the master has clocked the data. w is
+	// shifted out on MISO while r is received from MOSI; when both are set,
+	// they must have the same length.
+	Connect(m spi.Mode, bits int) (conn.Conn, error)
+}
+
+// PortCloser is an SPI port in peripheral mode that can be closed.
+//
+// Closing the port aborts a pending Tx, when supported by the
+// implementation.
+type PortCloser interface {
+	io.Closer
+	Port
+}
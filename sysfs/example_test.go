@@ -8,9 +8,9 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/s-mobi01/host"
+	"github.com/s-mobi01/host/sysfs"
 	"periph.io/x/conn/v3/gpio"
-	"periph.io/x/host/v3"
-	"periph.io/x/host/v3/sysfs"
 )
 
 func ExampleLEDByName() {
@@ -14,13 +14,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/s-mobi01/host/fs"
+	"github.com/s-mobi01/host/hostcfg"
+	"github.com/s-mobi01/host/pinuse"
 	"periph.io/x/conn/v3"
-	"periph.io/x/conn/v3/driver/driverreg"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/pin"
-	"periph.io/x/host/v3/fs"
 )
 
 // Pins is all the pins exported by GPIO sysfs.
@@ -34,6 +35,9 @@ import (
 var Pins map[int]*Pin
 
 // Pin represents one GPIO pin as found by sysfs.
+//
+// A pin exported by the process stays exported after it exits, unless
+// pinuse.Cleanup is called.
 type Pin struct {
 	number int
 	name   string
@@ -42,6 +46,7 @@ type Pin struct {
 	mu         sync.Mutex
 	err        error     // If open() failed
 	direction  direction // Cache of the last known direction
+	exported   bool      // Exported by this process
 	edge       gpio.Edge // Cache of the last edge used.
 	fDirection fileIO    // handle to /sys/class/gpio/gpio*/direction; never closed
 	fEdge      fileIO    // handle to /sys/class/gpio/gpio*/edge; never closed
@@ -338,6 +343,8 @@ func (p *Pin) open() error {
 		}
 		return p.err
 	}
+	// When busy, it was exported by someone else in the meantime.
+	p.exported = p.err == nil
 
 	// There's a race condition where the file may be created but udev is still
 	// running the Raspbian udev rule to make it readable to the current user.
@@ -360,8 +367,57 @@ direction:
 	if p.fDirection, p.err = fileIOOpen(p.root+"direction", os.O_RDWR); p.err != nil {
 		_ = p.fValue.Close()
 		p.fValue = nil
+		return p.err
+	}
+	if drvGPIO.lock {
+		// The line is locked until the process exits. Don't cache the failure,
+		// the other process may release it.
+		ok, err := tryLock(p.fValue.Fd())
+		if err == nil && !ok {
+			err = ErrLocked
+		}
+		if err != nil {
+			_ = p.fValue.Close()
+			_ = p.fDirection.Close()
+			p.fValue = nil
+			p.fDirection = nil
+			return err
+		}
 	}
-	return p.err
+	if p.exported {
+		pinuse.AtExit(p, p.unexport)
+	}
+	return nil
+}
+
+// unexport closes the pin and unexports it, as registered with pinuse.AtExit
+// when the pin was exported by this process.
+func (p *Pin) unexport() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.exported {
+		return nil
+	}
+	_ = p.haltEdge()
+	for _, f := range []fileIO{p.fValue, p.fDirection, p.fEdge} {
+		if f != nil {
+			_ = f.Close()
+		}
+	}
+	p.fValue = nil
+	p.fDirection = nil
+	p.fEdge = nil
+	p.direction = dUnknown
+	p.exported = false
+	f, err := fileIOOpen("/sys/class/gpio/unexport", os.O_WRONLY)
+	if err != nil {
+		return p.wrap(err)
+	}
+	defer f.Close()
+	if _, err := f.Write([]byte(strconv.Itoa(p.number))); err != nil {
+		return p.wrap(err)
+	}
+	return nil
 }
 
 // haltEdge stops any on-going edge detection.
@@ -378,7 +434,7 @@ func (p *Pin) haltEdge() error {
 }
 
 func (p *Pin) wrap(err error) error {
-	return fmt.Errorf("sysfs-gpio (%s): %v", p, err)
+	return fmt.Errorf("sysfs-gpio (%s): %w", p, err)
 }
 
 //
@@ -424,6 +480,7 @@ func readInt(path string) (int, error) {
 // driverGPIO implements periph.Driver.
 type driverGPIO struct {
 	exportHandle io.Writer // handle to /sys/class/gpio/export
+	lock         bool      // Lock the lines against other processes
 }
 
 func (d *driverGPIO) String() string {
@@ -465,6 +522,7 @@ func (d *driverGPIO) Init() (bool, error) {
 			return true, err
 		}
 	}
+	drvGPIO.lock = lockEnabled()
 	drvGPIO.exportHandle, err = fileIOOpen("/sys/class/gpio/export", os.O_WRONLY)
 	if os.IsPermission(err) {
 		return true, fmt.Errorf("need more access, try as root or setup udev rules: %v", err)
@@ -507,7 +565,7 @@ func (d *driverGPIO) parseGPIOChip(path string) error {
 
 func init() {
 	if isLinux {
-		driverreg.MustRegister(&drvGPIO)
+		hostcfg.MustRegister(&drvGPIO)
 	}
 }
 
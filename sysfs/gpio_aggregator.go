@@ -0,0 +1,185 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AggregatorLine selects lines to add to a GPIO aggregator.
+//
+// Either Name is the name of a line, or Chip is the label of a GPIO chip and
+// Offsets are lines on it.
+type AggregatorLine struct {
+	Name    string
+	Chip    string
+	Offsets []int
+}
+
+// GPIOAggregator is a GPIO chip created by the kernel gpio-aggregator driver
+// out of lines of other chips.
+//
+// The aggregated lines are numbered from 0 in the order they were given, so
+// the group can be handed over as a whole, e.g. to a container with only the
+// new /dev/gpiochipN, and used with gpioioctl.OpenChip().
+//
+// It requires CONFIG_GPIO_AGGREGATOR and root level access.
+type GPIOAggregator struct {
+	name string
+	chip string
+}
+
+// NewGPIOAggregator creates a GPIO aggregator out of lines.
+//
+// The lines must not be in use.
+func NewGPIOAggregator(lines ...AggregatorLine) (*GPIOAggregator, error) {
+	if len(lines) == 0 {
+		return nil, errors.New("sysfs-gpio-aggregator: no line")
+	}
+	var spec []string
+	for _, l := range lines {
+		switch {
+		case l.Name != "" && l.Chip == "" && len(l.Offsets) == 0:
+			spec = append(spec, l.Name)
+		case l.Name == "" && l.Chip != "" && len(l.Offsets) != 0:
+			o := make([]string, len(l.Offsets))
+			for i, n := range l.Offsets {
+				o[i] = strconv.Itoa(n)
+			}
+			spec = append(spec, l.Chip, strings.Join(o, ","))
+		default:
+			return nil, fmt.Errorf("sysfs-gpio-aggregator: invalid line %+v; use either Name or Chip and Offsets", l)
+		}
+	}
+	before, err := aggregatorDevices()
+	if err != nil {
+		return nil, err
+	}
+	if err := aggregatorWrite("new_device", strings.Join(spec, " ")); err != nil {
+		return nil, err
+	}
+	after, err := aggregatorDevices()
+	if err != nil {
+		return nil, err
+	}
+	a := &GPIOAggregator{name: newDevice(before, after)}
+	if a.name == "" {
+		return nil, errors.New("sysfs-gpio-aggregator: the device was not created")
+	}
+	// The chip is probed asynchronously when the lines' chips are not ready
+	// yet.
+	for end := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if a.chip = aggregatorChip(a.name); a.chip != "" {
+			return a, nil
+		}
+		if time.Now().After(end) {
+			_ = a.Close()
+			return nil, fmt.Errorf("sysfs-gpio-aggregator: %s: no GPIO chip; are the lines in use?", a.name)
+		}
+	}
+}
+
+// GPIOAggregators returns the GPIO aggregators present, including the ones
+// created by other processes.
+func GPIOAggregators() ([]*GPIOAggregator, error) {
+	names, err := aggregatorDevices()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*GPIOAggregator, 0, len(names))
+	for _, n := range names {
+		out = append(out, &GPIOAggregator{name: n, chip: aggregatorChip(n)})
+	}
+	return out, nil
+}
+
+func (a *GPIOAggregator) String() string {
+	return fmt.Sprintf("%s(%s)", a.name, a.chip)
+}
+
+// Name returns the name of the platform device, e.g. "gpio-aggregator.0".
+func (a *GPIOAggregator) Name() string {
+	return a.name
+}
+
+// Chip returns the name of the GPIO chip, e.g. "gpiochip5". It is empty if
+// the chip wasn't probed.
+func (a *GPIOAggregator) Chip() string {
+	return a.chip
+}
+
+// Path returns the path of the GPIO chip character device, e.g.
+// "/dev/gpiochip5".
+func (a *GPIOAggregator) Path() string {
+	if a.chip == "" {
+		return ""
+	}
+	return "/dev/" + a.chip
+}
+
+// Close removes the aggregator, which releases its lines.
+func (a *GPIOAggregator) Close() error {
+	return aggregatorWrite("delete_device", a.name)
+}
+
+//
+
+// sysfs paths.
+var (
+	aggregatorDriver   = "/sys/bus/platform/drivers/gpio-aggregator/"
+	aggregatorPlatform = "/sys/bus/platform/devices/"
+)
+
+// aggregatorWrite writes to a control file of the gpio-aggregator driver.
+var aggregatorWrite = func(file, v string) error {
+	if err := ioutil.WriteFile(aggregatorDriver+file, []byte(v), 0200); err != nil {
+		if os.IsNotExist(err) {
+			return errors.New("sysfs-gpio-aggregator: not supported; is CONFIG_GPIO_AGGREGATOR enabled?")
+		}
+		return fmt.Errorf("sysfs-gpio-aggregator: %v", err)
+	}
+	return nil
+}
+
+// aggregatorDevices returns the gpio-aggregator platform devices, sorted.
+func aggregatorDevices() ([]string, error) {
+	m, err := filepath.Glob(aggregatorPlatform + "gpio-aggregator.*")
+	if err != nil {
+		return nil, fmt.Errorf("sysfs-gpio-aggregator: %v", err)
+	}
+	for i := range m {
+		m[i] = filepath.Base(m[i])
+	}
+	sort.Strings(m)
+	return m, nil
+}
+
+// aggregatorChip returns the name of the GPIO chip of the aggregator name.
+func aggregatorChip(name string) string {
+	m, _ := filepath.Glob(aggregatorPlatform + name + "/gpiochip*")
+	if len(m) == 0 {
+		return ""
+	}
+	return filepath.Base(m[0])
+}
+
+// newDevice returns the device in after that is not in before.
+func newDevice(before, after []string) string {
+	for _, a := range after {
+		i := sort.SearchStrings(before, a)
+		if i == len(before) || before[i] != a {
+			return a
+		}
+	}
+	return ""
+}
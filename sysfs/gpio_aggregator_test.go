@@ -0,0 +1,94 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGPIOAggregator(t *testing.T) {
+	d, err := ioutil.TempDir("", "sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	defer setAggregatorRoot(d + "/")()
+	if err := os.MkdirAll(filepath.Join(d, "gpio-aggregator.0", "gpiochip4"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Fake the kernel.
+	var writes []string
+	aggregatorWrite = func(file, v string) error {
+		writes = append(writes, file+": "+v)
+		if file == "new_device" {
+			return os.MkdirAll(filepath.Join(d, "gpio-aggregator.1", "gpiochip5"), 0755)
+		}
+		return os.RemoveAll(filepath.Join(d, v))
+	}
+
+	a, err := NewGPIOAggregator(AggregatorLine{Name: "LED"}, AggregatorLine{Chip: "pinctrl-bcm2711", Offsets: []int{17, 18}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name() != "gpio-aggregator.1" || a.Chip() != "gpiochip5" || a.Path() != "/dev/gpiochip5" {
+		t.Fatal(a)
+	}
+	if s := a.String(); s != "gpio-aggregator.1(gpiochip5)" {
+		t.Fatal(s)
+	}
+	all, err := GPIOAggregators()
+	if err != nil || len(all) != 2 || all[0].Chip() != "gpiochip4" {
+		t.Fatal(all, err)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"new_device: LED pinctrl-bcm2711 17,18", "delete_device: gpio-aggregator.1"}
+	if len(writes) != 2 || writes[0] != want[0] || writes[1] != want[1] {
+		t.Fatal(writes)
+	}
+}
+
+func TestGPIOAggregator_err(t *testing.T) {
+	d, err := ioutil.TempDir("", "sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	defer setAggregatorRoot(d + "/")()
+	if _, err := NewGPIOAggregator(); err == nil {
+		t.Fatal("no line")
+	}
+	if _, err := NewGPIOAggregator(AggregatorLine{Name: "LED", Chip: "gpiochip0"}); err == nil {
+		t.Fatal("invalid line")
+	}
+	// The driver is not present.
+	if _, err := NewGPIOAggregator(AggregatorLine{Name: "LED"}); err == nil || err.Error() != "sysfs-gpio-aggregator: not supported; is CONFIG_GPIO_AGGREGATOR enabled?" {
+		t.Fatal(err)
+	}
+	// The kernel rejected the lines.
+	aggregatorWrite = func(file, v string) error {
+		return nil
+	}
+	if _, err := NewGPIOAggregator(AggregatorLine{Name: "LED"}); err == nil || err.Error() != "sysfs-gpio-aggregator: the device was not created" {
+		t.Fatal(err)
+	}
+}
+
+//
+
+// setAggregatorRoot points the gpio-aggregator paths to root and returns a
+// function to restore them.
+func setAggregatorRoot(root string) func() {
+	oldDriver, oldPlatform, oldWrite := aggregatorDriver, aggregatorPlatform, aggregatorWrite
+	aggregatorDriver = root + "driver/"
+	aggregatorPlatform = root
+	return func() {
+		aggregatorDriver, aggregatorPlatform, aggregatorWrite = oldDriver, oldPlatform, oldWrite
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+)
+
+// GPIOChipInfo describes a GPIO chip and its lines, as reported by the
+// kernel.
+type GPIOChipInfo struct {
+	// Name is the name of the chip, e.g. "gpiochip0".
+	Name string
+	// Label is the label set by the driver, e.g. "pinctrl-bcm2711".
+	Label string
+	// Lines are all the lines of the chip, ordered by offset. Its length is
+	// the ngpio of the chip.
+	Lines []GPIOLineInfo
+}
+
+func (c *GPIOChipInfo) String() string {
+	return fmt.Sprintf("%s(%s): %d lines", c.Name, c.Label, len(c.Lines))
+}
+
+// GPIOLineInfo describes a line of a GPIO chip.
+type GPIOLineInfo struct {
+	// Offset is the offset of the line on its chip.
+	Offset int
+	// Name is the name of the line, usually from the device tree. It is empty
+	// if the line has no name.
+	Name string
+	// Consumer is the label of the user of the line, e.g. "sysfs" or
+	// "spi0 CS0". It is empty if the line is not in use or the user didn't
+	// set a label.
+	Consumer string
+	// Used is true when the line is in use, by the kernel or a userland
+	// process.
+	Used bool
+	// Output is true when the line is configured as an output.
+	Output bool
+	// ActiveLow is true when the line is active low.
+	ActiveLow bool
+}
+
+func (l *GPIOLineInfo) String() string {
+	s := strconv.Itoa(l.Offset)
+	if l.Name != "" {
+		s += "(" + l.Name + ")"
+	}
+	if l.Output {
+		s += " out"
+	} else {
+		s += " in"
+	}
+	if l.ActiveLow {
+		s += " active-low"
+	}
+	if l.Used {
+		s += " used"
+		if l.Consumer != "" {
+			s += " by " + strconv.Quote(l.Consumer)
+		}
+	}
+	return s
+}
+
+// GPIOChips returns all the GPIO chips of the host with their lines, sorted
+// by name.
+//
+// It uses the GPIO character devices /dev/gpiochipN, so it works even when
+// the legacy /sys/class/gpio interface is not available. It doesn't change
+// the state of any line.
+//
+// It is meant to print a full pin inventory of an unknown board.
+func GPIOChips() ([]GPIOChipInfo, error) {
+	items, err := filepath.Glob(gpioChipDev + "gpiochip*")
+	if err != nil {
+		return nil, fmt.Errorf("sysfs-gpio: %v", err)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, _ := strconv.Atoi(strings.TrimPrefix(filepath.Base(items[i]), "gpiochip"))
+		b, _ := strconv.Atoi(strings.TrimPrefix(filepath.Base(items[j]), "gpiochip"))
+		return a < b
+	})
+	out := make([]GPIOChipInfo, 0, len(items))
+	for _, item := range items {
+		c, err := readGPIOChip(item)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// GPIOChipBases returns the sysfs GPIO number of the first line of each GPIO
+// chip, keyed by the chip label, e.g. "4201000.gpio": 512.
+//
+// It uses the legacy /sys/class/gpio interface. The chips that can't be read
+// are omitted.
+func GPIOChipBases() map[string]int {
+	out := map[string]int{}
+	chips, _ := filepath.Glob(gpioClass + "gpiochip*")
+	for _, c := range chips {
+		l, err := ioutil.ReadFile(filepath.Join(c, "label"))
+		if err != nil {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(c, "base"))
+		if err != nil {
+			continue
+		}
+		base, err := strconv.Atoi(strings.TrimSpace(string(b)))
+		if err != nil {
+			continue
+		}
+		out[strings.TrimSpace(string(l))] = base
+	}
+	return out
+}
+
+//
+
+// gpioChipDev is where the GPIO character devices are.
+var gpioChipDev = "/dev/"
+
+// gpioClass is where the legacy sysfs GPIO chips are.
+var gpioClass = "/sys/class/gpio/"
+
+// Structures and constants from include/uapi/linux/gpio.h.
+//
+// The version 1 of the API is used since it is sufficient to query the lines
+// and is supported by older kernels.
+const (
+	gpioMaxNameSize = 32
+
+	gpioLineFlagKernel    = 1 << 0
+	gpioLineFlagIsOut     = 1 << 1
+	gpioLineFlagActiveLow = 1 << 2
+)
+
+// gpioChipInfo is struct gpiochip_info.
+type gpioChipInfo struct {
+	name  [gpioMaxNameSize]byte
+	label [gpioMaxNameSize]byte
+	lines uint32
+}
+
+// gpioLineInfo is struct gpioline_info.
+type gpioLineInfo struct {
+	offset   uint32
+	flags    uint32
+	name     [gpioMaxNameSize]byte
+	consumer [gpioMaxNameSize]byte
+}
+
+var (
+	gpioGetChipInfoIoctl = fs.IOR(0xB4, 0x01, uint(unsafe.Sizeof(gpioChipInfo{})))
+	gpioGetLineInfoIoctl = fs.IOWR(0xB4, 0x02, uint(unsafe.Sizeof(gpioLineInfo{})))
+)
+
+// readGPIOChip queries the chip at path and all its lines.
+func readGPIOChip(path string) (GPIOChipInfo, error) {
+	f, err := ioctlOpen(path, os.O_RDONLY)
+	if err != nil {
+		return GPIOChipInfo{}, fmt.Errorf("sysfs-gpio: %v", err)
+	}
+	defer f.Close()
+	var ci gpioChipInfo
+	if err := f.Ioctl(gpioGetChipInfoIoctl, uintptr(unsafe.Pointer(&ci))); err != nil {
+		return GPIOChipInfo{}, fmt.Errorf("sysfs-gpio (%s): %v", path, err)
+	}
+	c := GPIOChipInfo{
+		Name:  cString(ci.name[:]),
+		Label: cString(ci.label[:]),
+		Lines: make([]GPIOLineInfo, ci.lines),
+	}
+	for i := range c.Lines {
+		li := gpioLineInfo{offset: uint32(i)}
+		if err := f.Ioctl(gpioGetLineInfoIoctl, uintptr(unsafe.Pointer(&li))); err != nil {
+			return GPIOChipInfo{}, fmt.Errorf("sysfs-gpio (%s): %v", path, err)
+		}
+		c.Lines[i] = GPIOLineInfo{
+			Offset:    i,
+			Name:      cString(li.name[:]),
+			Consumer:  cString(li.consumer[:]),
+			Used:      li.flags&gpioLineFlagKernel != 0,
+			Output:    li.flags&gpioLineFlagIsOut != 0,
+			ActiveLow: li.flags&gpioLineFlagActiveLow != 0,
+		}
+	}
+	return c, nil
+}
+
+// cString returns the NUL terminated string in b.
+func cString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i != -1 {
+		b = b[:i]
+	}
+	return string(b)
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"unsafe"
+)
+
+func TestGPIOChips(t *testing.T) {
+	d, err := ioutil.TempDir("", "sysfs-gpiochips")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	for _, n := range []string{"gpiochip10", "gpiochip2"} {
+		if err := ioutil.WriteFile(filepath.Join(d, n), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gpioChipDev = d + "/"
+	defer func() {
+		gpioChipDev = "/dev/"
+		ioctlOpen = ioctlOpenDefault
+	}()
+	ioctlOpen = func(path string, flag int) (ioctlCloser, error) {
+		return &fakeGPIOChip{name: filepath.Base(path)}, nil
+	}
+
+	chips, err := GPIOChips()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chips) != 2 || chips[0].Name != "gpiochip2" || chips[1].Name != "gpiochip10" {
+		t.Fatalf("%v", chips)
+	}
+	c := chips[0]
+	if s := c.String(); s != "gpiochip2(pinctrl): 2 lines" {
+		t.Fatal(s)
+	}
+	if s := c.Lines[0].String(); s != "0(LED) out active-low used by \"heartbeat\"" {
+		t.Fatal(s)
+	}
+	if s := c.Lines[1].String(); s != "1 in" {
+		t.Fatal(s)
+	}
+}
+
+func TestGPIOChips_fail(t *testing.T) {
+	defer func() {
+		ioctlOpen = ioctlOpenDefault
+	}()
+	ioctlOpen = func(path string, flag int) (ioctlCloser, error) {
+		return &ioctlClose{ioctlErr: os.ErrPermission}, nil
+	}
+	if _, err := readGPIOChip("/dev/gpiochip0"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGPIOChipBases(t *testing.T) {
+	d, err := ioutil.TempDir("", "sysfs-gpiochips")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	files := map[string]string{
+		"gpiochip512/label": "4201000.gpio\n",
+		"gpiochip512/base":  "512\n",
+		"gpiochip539/label": "600000.gpio\n",
+		"gpiochip539/base":  "539\n",
+		// Chips that can't be read are omitted.
+		"gpiochip600/label": "601000.gpio\n",
+		"gpiochip601/base":  "601\n",
+		"gpiochip602/label": "602000.gpio\n",
+		"gpiochip602/base":  "x\n",
+	}
+	for n, c := range files {
+		p := filepath.Join(d, n)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(c), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gpioClass = d + "/"
+	defer func() {
+		gpioClass = "/sys/class/gpio/"
+	}()
+
+	got := GPIOChipBases()
+	if len(got) != 2 || got["4201000.gpio"] != 512 || got["600000.gpio"] != 539 {
+		t.Fatal(got)
+	}
+}
+
+//
+
+type fakeGPIOChip struct {
+	ioctlClose
+	name string
+}
+
+func (f *fakeGPIOChip) Ioctl(op uint, data uintptr) error {
+	// data points to memory owned by the caller, which is alive for the
+	// duration of the call.
+	p := *(*unsafe.Pointer)(unsafe.Pointer(&data))
+	switch op {
+	case gpioGetChipInfoIoctl:
+		ci := (*gpioChipInfo)(p)
+		copy(ci.name[:], f.name)
+		copy(ci.label[:], "pinctrl")
+		ci.lines = 2
+	case gpioGetLineInfoIoctl:
+		li := (*gpioLineInfo)(p)
+		if li.offset == 0 {
+			copy(li.name[:], "LED")
+			copy(li.consumer[:], "heartbeat")
+			li.flags = gpioLineFlagKernel | gpioLineFlagIsOut | gpioLineFlagActiveLow
+		}
+	}
+	return nil
+}
@@ -6,8 +6,12 @@ package sysfs
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/s-mobi01/host/pinuse"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/pin"
@@ -203,6 +207,64 @@ func TestPin_readInt(t *testing.T) {
 	}
 }
 
+func TestPin_unexport(t *testing.T) {
+	d, err := ioutil.TempDir("", "sysfs-gpio")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	unexport := &ledFile{}
+	defer func() {
+		fileIOOpen = fileIOOpenDefault
+		drvGPIO.exportHandle = nil
+	}()
+	fileIOOpen = func(path string, flag int) (fileIO, error) {
+		if path == "/sys/class/gpio/unexport" {
+			return unexport, nil
+		}
+		f, err := os.OpenFile(path, flag, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &lockFile{f: f}, nil
+	}
+	// Exporting creates the files.
+	drvGPIO.exportHandle = exportFunc(func(b []byte) (int, error) {
+		for _, n := range []string{"value", "direction"} {
+			if err := ioutil.WriteFile(filepath.Join(d, n), nil, 0644); err != nil {
+				return 0, err
+			}
+		}
+		return len(b), nil
+	})
+
+	p := &Pin{number: 42, name: "GPIO42", root: d + "/"}
+	if err := p.Out(gpio.Low); err != nil {
+		t.Fatal(err)
+	}
+	if err := pinuse.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if w := unexport.get(); len(w) != 1 || w[0] != "42" {
+		t.Fatal(w)
+	}
+	if p.fValue != nil || p.exported {
+		t.Fatal("not closed")
+	}
+
+	// Not unexported when exported by someone else.
+	p = &Pin{number: 43, name: "GPIO43", root: d + "/"}
+	if err := p.Out(gpio.Low); err != nil {
+		t.Fatal(err)
+	}
+	if err := pinuse.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if w := unexport.get(); len(w) != 1 {
+		t.Fatal(w)
+	}
+}
+
 func TestGPIODriver(t *testing.T) {
 	if len((&driverGPIO{}).Prerequisites()) != 0 {
 		t.Fatal("unexpected GPIO prerequisites")
@@ -211,6 +273,12 @@ func TestGPIODriver(t *testing.T) {
 
 //
 
+type exportFunc func(b []byte) (int, error)
+
+func (e exportFunc) Write(b []byte) (int, error) {
+	return e(b)
+}
+
 type fakeGPIOFile struct {
 	data []byte
 }
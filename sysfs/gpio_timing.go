@@ -0,0 +1,125 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// MonotonicRaw returns the time elapsed since an arbitrary point, as read
+// from the CLOCK_MONOTONIC_RAW clock.
+//
+// Unlike time.Now(), the clock is not slewed by NTP, so the intervals it
+// measures are not distorted while the system time is adjusted. It doesn't
+// need /dev/mem. It is meant to time bit banged protocols. On OSes other than
+// linux, the Go monotonic clock is used instead.
+func MonotonicRaw() time.Duration {
+	return monotonicRaw()
+}
+
+// SpinUntil busy loops until MonotonicRaw() returns t or later.
+//
+// It is meant for delays of a few µs between two operations on a pin, which
+// time.Sleep() can't do.
+func SpinUntil(t time.Duration) {
+	for MonotonicRaw() < t {
+	}
+}
+
+// GPIOTiming is the median overhead of the operations used to bit bang a
+// pin, as measured by Pin.Calibrate().
+//
+// It mostly depends on the cost of a syscall on the platform and varies from
+// about a µs to tens of µs.
+type GPIOTiming struct {
+	// Clock is the duration of a call to MonotonicRaw().
+	Clock time.Duration
+	// Read is the duration of a call to Pin.Read().
+	Read time.Duration
+	// Out is the duration of a call to Pin.Out(). It is 0 when the pin was not
+	// an output and it couldn't be measured.
+	Out time.Duration
+}
+
+// Compensate returns the delay to wait for d to elapse between two edges,
+// when reads calls to Pin.Read() and outs calls to Pin.Out() are done in
+// between.
+//
+// It returns 0 if the operations alone take longer than d.
+func (t *GPIOTiming) Compensate(d time.Duration, reads, outs int) time.Duration {
+	d -= time.Duration(reads)*t.Read + time.Duration(outs)*t.Out
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// Calibrate measures the overhead of the operations on the pin over n
+// iterations, so software protocol drivers can compensate for it.
+//
+// The level of the pin is not changed: Pin.Out() is only measured when the
+// pin is already an output, and is then called with the current level.
+func (p *Pin) Calibrate(n int) (GPIOTiming, error) {
+	var t GPIOTiming
+	if n <= 0 {
+		return t, p.wrap(errors.New("calibration needs at least one iteration"))
+	}
+	p.mu.Lock()
+	err := p.open()
+	if err == nil {
+		// Refresh the direction, as Func() does.
+		if _, err = seekRead(p.fDirection, p.buf[:]); err == nil {
+			if p.buf[0] == 'i' && p.buf[1] == 'n' {
+				p.direction = dIn
+			} else if p.buf[0] == 'o' && p.buf[1] == 'u' && p.buf[2] == 't' {
+				p.direction = dOut
+			}
+		}
+	}
+	out := p.direction == dOut
+	p.mu.Unlock()
+	if err != nil {
+		return t, p.wrap(err)
+	}
+
+	samples := make([]time.Duration, n)
+	t.Clock = median(samples, func() {})
+	t.Read = median(samples, func() { p.Read() }) - t.Clock
+	if out {
+		l := p.Read()
+		var outErr error
+		t.Out = median(samples, func() {
+			if outErr == nil {
+				outErr = p.Out(l)
+			}
+		}) - t.Clock
+		if outErr != nil {
+			return GPIOTiming{}, outErr
+		}
+	}
+	if t.Read < 0 {
+		t.Read = 0
+	}
+	if t.Out < 0 {
+		t.Out = 0
+	}
+	return t, nil
+}
+
+//
+
+// median times op once per item of samples and returns the median duration,
+// including one call to MonotonicRaw().
+func median(samples []time.Duration, op func()) time.Duration {
+	for i := range samples {
+		start := MonotonicRaw()
+		op()
+		samples[i] = MonotonicRaw() - start
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return samples[len(samples)/2]
+}
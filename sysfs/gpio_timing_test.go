@@ -0,0 +1,66 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonotonicRaw(t *testing.T) {
+	start := MonotonicRaw()
+	SpinUntil(start + time.Millisecond)
+	if d := MonotonicRaw() - start; d < time.Millisecond || d > time.Second {
+		t.Fatal(d)
+	}
+}
+
+func TestGPIOTiming_Compensate(t *testing.T) {
+	g := GPIOTiming{Read: 2 * time.Microsecond, Out: 3 * time.Microsecond}
+	if d := g.Compensate(10*time.Microsecond, 1, 2); d != 2*time.Microsecond {
+		t.Fatal(d)
+	}
+	if d := g.Compensate(time.Microsecond, 1, 0); d != 0 {
+		t.Fatal(d)
+	}
+}
+
+func TestPin_Calibrate(t *testing.T) {
+	p := Pin{number: 42, name: "foo", root: "/tmp/gpio/priv/"}
+	// Fails because open is not mocked.
+	if _, err := p.Calibrate(10); err == nil {
+		t.Fatal("expected error")
+	}
+	p = Pin{
+		number:     42,
+		name:       "foo",
+		root:       "/tmp/gpio/priv/",
+		fDirection: &fakeGPIOFile{data: []byte("in")},
+		fValue:     &fakeGPIOFile{data: []byte("1")},
+	}
+	if _, err := p.Calibrate(0); err == nil {
+		t.Fatal("expected error")
+	}
+	g, err := p.Calibrate(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Clock <= 0 || g.Read < 0 || g.Out != 0 {
+		t.Fatal(g)
+	}
+	// As an output, the current level is written back.
+	p.fDirection = &fakeGPIOFile{data: []byte("out")}
+	if g, err = p.Calibrate(10); err != nil {
+		t.Fatal(err)
+	}
+	if p.fValue.(*fakeGPIOFile).data[0] != '1' {
+		t.Fatal("level changed")
+	}
+	// Errors from Out() are returned.
+	p.fValue = &fakeGPIOFile{}
+	if _, err := p.Calibrate(10); err == nil {
+		t.Fatal("expected error")
+	}
+}
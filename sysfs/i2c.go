@@ -15,7 +15,7 @@ import (
 	"sync"
 	"unsafe"
 
-	"periph.io/x/conn/v3/driver/driverreg"
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/i2c"
@@ -67,10 +67,11 @@ type I2C struct {
 	f         ioctlCloser
 	busNumber int
 
-	mu  sync.Mutex // In theory the kernel probably has an internal lock but not taking any chance.
-	fn  functionality
-	scl gpio.PinIO
-	sda gpio.PinIO
+	mu   sync.Mutex // In theory the kernel probably has an internal lock but not taking any chance.
+	fn   functionality
+	lock bool // Lock the bus against other processes
+	scl  gpio.PinIO
+	sda  gpio.PinIO
 }
 
 // Close closes the handle to the I²C driver. It is not a requirement to close
@@ -118,13 +119,28 @@ func (i *I2C) Tx(addr uint16, w, r []byte) error {
 		msgs:  uintptr(unsafe.Pointer(&msgs[0])),
 		nmsgs: uint32(len(msgs)),
 	}
-	pp := uintptr(unsafe.Pointer(&p))
-	i.mu.Lock()
-	defer i.mu.Unlock()
-	if err := i.f.Ioctl(ioctlRdwr, pp); err != nil {
-		return fmt.Errorf("sysfs-i2c: %v", err)
+	return i.rdwr(&p)
+}
+
+// QuickWrite sends the address with the write bit set and no data, as the
+// SMBus quick command.
+//
+// It returns an error if no device acknowledged the address. It is the least
+// intrusive way to detect a device, yet some write-only devices interpret it
+// as a command.
+func (i *I2C) QuickWrite(addr uint16) error {
+	if addr >= 0x400 || (addr >= 0x80 && i.fn&func10BitAddr == 0) {
+		return errors.New("sysfs-i2c: invalid address")
 	}
-	return nil
+	if i.fn&funcSMBusQuick == 0 {
+		return errors.New("sysfs-i2c: quick command is not supported by this bus")
+	}
+	msg := i2cMsg{addr: addr}
+	p := rdwrIoctlData{
+		msgs:  uintptr(unsafe.Pointer(&msg)),
+		nmsgs: 1,
+	}
+	return i.rdwr(&p)
 }
 
 // SetSpeed implements i2c.Bus.
@@ -157,6 +173,22 @@ func (i *I2C) SDA() gpio.PinIO {
 
 // Private details.
 
+// rdwr runs the I2C_RDWR ioctl, holding the bus lock if enabled.
+func (i *I2C) rdwr(p *rdwrIoctlData) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.lock {
+		if err := lockBus(i.f); err != nil {
+			return fmt.Errorf("sysfs-i2c: %s: %w", i, err)
+		}
+		defer unlockBus(i.f)
+	}
+	if err := i.f.Ioctl(ioctlRdwr, uintptr(unsafe.Pointer(p))); err != nil {
+		return fmt.Errorf("sysfs-i2c: %v", err)
+	}
+	return nil
+}
+
 func newI2C(busNumber int) (*I2C, error) {
 	// Use the devfs path for now instead of sysfs path.
 	f, err := ioctlOpen(fmt.Sprintf("/dev/i2c-%d", busNumber), os.O_RDWR)
@@ -171,7 +203,7 @@ func newI2C(busNumber int) (*I2C, error) {
 		// TODO(maruel): This is a debianism.
 		return nil, fmt.Errorf("sysfs-i2c: are you member of group 'plugdev'? %v", err)
 	}
-	i := &I2C{f: f, busNumber: busNumber}
+	i := &I2C{f: f, busNumber: busNumber, lock: lockEnabled()}
 
 	// TODO(maruel): Changing the speed is currently doing this for all devices.
 	// https://github.com/raspberrypi/linux/issues/215
@@ -378,7 +410,7 @@ func (o openerI2C) Open() (i2c.BusCloser, error) {
 
 func init() {
 	if isLinux {
-		driverreg.MustRegister(&drvI2C)
+		hostcfg.MustRegister(&drvI2C)
 	}
 }
 
@@ -5,6 +5,7 @@
 package sysfs
 
 import (
+	"errors"
 	"testing"
 
 	"periph.io/x/conn/v3/i2c/i2creg"
@@ -51,6 +52,24 @@ func TestI2C_faked(t *testing.T) {
 	}
 }
 
+func TestI2C_QuickWrite(t *testing.T) {
+	bus := I2C{f: &ioctlClose{}, busNumber: 24}
+	if err := bus.QuickWrite(0x40); err == nil || err.Error() != "sysfs-i2c: quick command is not supported by this bus" {
+		t.Fatal(err)
+	}
+	bus.fn = funcSMBusQuick
+	if bus.QuickWrite(0x401) == nil {
+		t.Fatal("invalid address")
+	}
+	if err := bus.QuickWrite(0x40); err != nil {
+		t.Fatal(err)
+	}
+	bus.f = &ioctlClose{ioctlErr: errors.New("nack")}
+	if err := bus.QuickWrite(0x40); err == nil || err.Error() != "sysfs-i2c: nack" {
+		t.Fatal(err)
+	}
+}
+
 func TestI2C_functionality(t *testing.T) {
 	expected := "I2C|10BIT_ADDR|PROTOCOL_MANGLING|SMBUS_PEC|NOSTART|SMBUS_BLOCK_PROC_CALL|SMBUS_QUICK|SMBUS_READ_BYTE|SMBUS_WRITE_BYTE|SMBUS_READ_BYTE_DATA|SMBUS_WRITE_BYTE_DATA|SMBUS_READ_WORD_DATA|SMBUS_WRITE_WORD_DATA|SMBUS_PROC_CALL|SMBUS_READ_BLOCK_DATA|SMBUS_WRITE_BLOCK_DATA|SMBUS_READ_I2C_BLOCK|SMBUS_WRITE_I2C_BLOCK"
 	if s := functionality(0xFFFFFFFF).String(); s != expected {
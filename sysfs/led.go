@@ -11,15 +11,15 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3"
-	"periph.io/x/conn/v3/driver/driverreg"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/pin"
-	"periph.io/x/host/v3/fs"
 )
 
 // LEDs is all the leds discovered on this host via sysfs.
@@ -51,7 +51,9 @@ type LED struct {
 	root   string
 
 	mu          sync.Mutex
-	fBrightness *fs.File // handle to /sys/class/gpio/gpio*/direction; never closed
+	fBrightness fileIO        // handle to /sys/class/leds/*/brightness; never closed
+	fadeDone    chan struct{} // closed to stop the software fade in progress
+	pattern     bool          // the pattern trigger is set by Fade
 }
 
 // String implements conn.Resource.
@@ -154,6 +156,9 @@ func (l *LED) Out(level gpio.Level) error {
 	}
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if err = l.stopFadeLocked(); err != nil {
+		return err
+	}
 	if _, err = l.fBrightness.Seek(0, 0); err != nil {
 		return err
 	}
@@ -175,12 +180,44 @@ func (l *LED) PWM(d gpio.Duty, f physic.Frequency) error {
 	}
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	if _, err = l.fBrightness.Seek(0, 0); err != nil {
+	if err = l.stopFadeLocked(); err != nil {
 		return err
 	}
 	v := (d + gpio.DutyMax/512) / (gpio.DutyMax / 256)
-	_, err = l.fBrightness.Write([]byte(strconv.Itoa(int(v))))
-	return err
+	return seekWrite(l.fBrightness, []byte(strconv.Itoa(int(v))))
+}
+
+// Fade ramps the brightness from from to to over d, then leaves the LED at to.
+//
+// It returns immediately. When the LED supports the pattern trigger, the ramp
+// is offloaded to the kernel. Otherwise, a goroutine updates the brightness
+// every 20ms.
+//
+// Out, PWM, Halt and Fade stop the ramp in progress.
+func (l *LED) Fade(from, to gpio.Duty, d time.Duration) error {
+	if from < 0 || from > gpio.DutyMax || to < 0 || to > gpio.DutyMax || d <= 0 {
+		return errors.New("sysfs-led: invalid fade")
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.stopFadeLocked(); err != nil {
+		return err
+	}
+	max := l.maxBrightness()
+	b0 := int((int64(from)*int64(max) + int64(gpio.DutyMax)/2) / int64(gpio.DutyMax))
+	b1 := int((int64(to)*int64(max) + int64(gpio.DutyMax)/2) / int64(gpio.DutyMax))
+	if l.hasTrigger("pattern") {
+		return l.fadeKernelLocked(b0, b1, d)
+	}
+	if err := seekWrite(l.fBrightness, []byte(strconv.Itoa(b0))); err != nil {
+		return err
+	}
+	l.fadeDone = make(chan struct{})
+	go l.fade(l.fadeDone, b0, b1, d)
+	return nil
 }
 
 //
@@ -188,14 +225,132 @@ func (l *LED) PWM(d gpio.Duty, f physic.Frequency) error {
 func (l *LED) open() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	// trigger, max_brightness.
 	var err error
 	if l.fBrightness == nil {
 		p := l.root + "brightness"
-		if l.fBrightness, err = fs.Open(p, os.O_RDWR); err != nil {
+		if l.fBrightness, err = fileIOOpen(p, os.O_RDWR); err != nil {
 			// Retry with read-only. This is the default setting.
-			l.fBrightness, err = fs.Open(p, os.O_RDONLY)
+			l.fBrightness, err = fileIOOpen(p, os.O_RDONLY)
+		}
+	}
+	return err
+}
+
+// fadeStep is the period at which the software fade updates the brightness.
+const fadeStep = 20 * time.Millisecond
+
+// fade updates the brightness from b0 to b1 over d, until done is closed.
+func (l *LED) fade(done <-chan struct{}, b0, b1 int, d time.Duration) {
+	t := time.NewTicker(fadeStep)
+	defer t.Stop()
+	start := time.Now()
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
 		}
+		e := time.Since(start)
+		if e > d {
+			e = d
+		}
+		v := b0 + int(int64(b1-b0)*int64(e)/int64(d))
+		// done is closed with mu held, so the brightness is never written after
+		// the fade is stopped.
+		l.mu.Lock()
+		select {
+		case <-done:
+			l.mu.Unlock()
+			return
+		default:
+		}
+		err := seekWrite(l.fBrightness, []byte(strconv.Itoa(v)))
+		l.mu.Unlock()
+		if err != nil || e == d {
+			return
+		}
+	}
+}
+
+// fadeKernelLocked offloads the ramp to the pattern trigger, which changes the
+// brightness gradually between the two steps.
+//
+// https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-class-led-trigger-pattern
+func (l *LED) fadeKernelLocked(b0, b1 int, d time.Duration) error {
+	ms := d / time.Millisecond
+	if ms == 0 {
+		ms = 1
+	}
+	if err := l.writeFile("trigger", "pattern"); err != nil {
+		return err
+	}
+	l.pattern = true
+	if err := l.writeFile("repeat", "1"); err != nil {
+		return err
+	}
+	return l.writeFile("pattern", fmt.Sprintf("%d %d %d 0", b0, ms, b1))
+}
+
+// stopFadeLocked stops the fade in progress, if any.
+func (l *LED) stopFadeLocked() error {
+	if l.fadeDone != nil {
+		close(l.fadeDone)
+		l.fadeDone = nil
+	}
+	if l.pattern {
+		l.pattern = false
+		return l.writeFile("trigger", "none")
+	}
+	return nil
+}
+
+// maxBrightness returns the maximum brightness, 255 if unknown.
+func (l *LED) maxBrightness() int {
+	if b, err := l.readFile("max_brightness"); err == nil {
+		if i, err := strconv.Atoi(strings.TrimSpace(b)); err == nil && i > 0 {
+			return i
+		}
+	}
+	return 255
+}
+
+// hasTrigger returns true if the LED supports the trigger name.
+func (l *LED) hasTrigger(name string) bool {
+	b, err := l.readFile("trigger")
+	if err != nil {
+		return false
+	}
+	// The current trigger is in brackets, e.g. "none [mmc0] timer pattern".
+	for _, t := range strings.Fields(b) {
+		if strings.Trim(t, "[]") == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LED) readFile(name string) (string, error) {
+	f, err := fileIOOpen(l.root+name, os.O_RDONLY)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	var b [4096]byte
+	n, err := f.Read(b[:])
+	if err != nil {
+		return "", err
+	}
+	return string(b[:n]), nil
+}
+
+func (l *LED) writeFile(name, v string) error {
+	f, err := fileIOOpen(l.root+name, os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write([]byte(v))
+	if err2 := f.Close(); err == nil {
+		err = err2
 	}
 	return err
 }
@@ -244,7 +399,7 @@ func (d *driverLED) Init() (bool, error) {
 
 func init() {
 	if isLinux {
-		driverreg.MustRegister(&drvLED)
+		hostcfg.MustRegister(&drvLED)
 	}
 }
 
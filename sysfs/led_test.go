@@ -5,7 +5,11 @@
 package sysfs
 
 import (
+	"errors"
+	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/physic"
@@ -67,3 +71,129 @@ func TestLEDDriver(t *testing.T) {
 		t.Fatal("unexpected LED prerequisites")
 	}
 }
+
+func TestLED_Fade_pattern(t *testing.T) {
+	defer func() {
+		fileIOOpen = fileIOOpenDefault
+	}()
+	files := ledFiles{
+		"brightness":     {},
+		"max_brightness": {content: "100\n"},
+		"trigger":        {content: "[none] timer pattern mmc0\n"},
+		"repeat":         {},
+		"pattern":        {},
+	}
+	fileIOOpen = files.open
+	l := LED{number: 42, name: "Glow", root: "/tmp/led/priv/"}
+	if err := l.Fade(0, gpio.DutyMax+1, time.Second); err == nil {
+		t.Fatal("invalid duty")
+	}
+	if err := l.Fade(0, gpio.DutyMax, 500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if w := files["trigger"].get(); len(w) != 1 || w[0] != "pattern" {
+		t.Fatal(w)
+	}
+	if w := files["repeat"].get(); len(w) != 1 || w[0] != "1" {
+		t.Fatal(w)
+	}
+	if w := files["pattern"].get(); len(w) != 1 || w[0] != "0 500 100 0" {
+		t.Fatal(w)
+	}
+	// Out stops the kernel fade.
+	if err := l.Out(gpio.Low); err != nil {
+		t.Fatal(err)
+	}
+	if w := files["trigger"].get(); len(w) != 2 || w[1] != "none" {
+		t.Fatal(w)
+	}
+	if w := files["brightness"].get(); len(w) != 1 || w[0] != "0" {
+		t.Fatal(w)
+	}
+}
+
+func TestLED_Fade_software(t *testing.T) {
+	defer func() {
+		fileIOOpen = fileIOOpenDefault
+	}()
+	files := ledFiles{
+		"brightness": {},
+		"trigger":    {content: "[none] timer\n"},
+	}
+	fileIOOpen = files.open
+	l := LED{number: 42, name: "Glow", root: "/tmp/led/priv/"}
+	if err := l.Fade(gpio.DutyMax, 0, 60*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		w := files["brightness"].get()
+		if len(w) != 0 && w[len(w)-1] == "0" {
+			if w[0] != "255" || len(w) < 3 {
+				t.Fatal(w)
+			}
+			break
+		}
+		if time.Since(start) > time.Second {
+			t.Fatal(w)
+		}
+	}
+	if w := files["trigger"].get(); len(w) != 0 {
+		t.Fatal(w)
+	}
+
+	// A fade in progress is stopped by Out.
+	if err := l.Fade(0, gpio.DutyMax, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	n := len(files["brightness"].get())
+	time.Sleep(3 * fadeStep)
+	if w := files["brightness"].get(); len(w) != n || w[n-1] != "255" {
+		t.Fatal(w)
+	}
+}
+
+//
+
+// ledFiles are fake LED sysfs files, by name.
+type ledFiles map[string]*ledFile
+
+func (l ledFiles) open(path string, flag int) (fileIO, error) {
+	const root = "/tmp/led/priv/"
+	if f := l[path[len(root):]]; f != nil {
+		return f, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ledFile records each write and reads back content.
+type ledFile struct {
+	file
+	mu      sync.Mutex
+	content string
+	writes  []string
+}
+
+func (l *ledFile) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.content == "" {
+		return 0, errors.New("write-only")
+	}
+	return copy(p, l.content), nil
+}
+
+func (l *ledFile) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writes = append(l.writes, string(p))
+	return len(p), nil
+}
+
+func (l *ledFile) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.writes...)
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/s-mobi01/host/hostcfg"
+)
+
+// ErrLocked is wrapped by the errors returned when a bus or a GPIO line is
+// locked by another process.
+//
+// Locking is enabled with the hostcfg lock setting. The I²C and SPI buses are
+// locked for the duration of each transaction, waiting up to a second for the
+// other process to release them. A GPIO line is locked as long as the process
+// uses it; the lock is not waited for. The lines requested with package
+// gpioioctl don't need it, since the kernel already grants a line to a single
+// requester.
+//
+// The locks are advisory: only the processes using this module, or flock(2)
+// on the same files, honor them.
+var ErrLocked = errors.New("locked by another process")
+
+//
+
+// lockWait is how long a transaction waits for a bus locked by another
+// process.
+var lockWait = time.Second
+
+// fder is implemented by the open files that can be locked.
+type fder interface {
+	Fd() uintptr
+}
+
+// lockEnabled returns true if locking is enabled by the host configuration.
+func lockEnabled() bool {
+	c, _ := hostcfg.Get()
+	return c.Lock
+}
+
+// lockBus takes the lock on f, waiting up to lockWait. It does nothing if f
+// can't be locked.
+func lockBus(f interface{}) error {
+	l, ok := f.(fder)
+	if !ok {
+		return nil
+	}
+	for start := time.Now(); ; {
+		ok, err := tryLock(l.Fd())
+		if err != nil || ok {
+			return err
+		}
+		if time.Since(start) >= lockWait {
+			return ErrLocked
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// unlockBus reverts lockBus.
+func unlockBus(f interface{}) {
+	if l, ok := f.(fder); ok {
+		_ = unlock(l.Fd())
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+func TestI2C_lock(t *testing.T) {
+	if !isLinux {
+		t.Skip("flock is only used on linux")
+	}
+	defer func(d time.Duration) { lockWait = d }(lockWait)
+	lockWait = 10 * time.Millisecond
+	other, f := openTwice(t)
+	defer other.Close()
+	defer f.Close()
+
+	bus := I2C{f: &lockFile{f: f}, busNumber: 1, lock: true}
+	if ok, err := tryLock(other.Fd()); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+	err := bus.Tx(1, []byte{0}, nil)
+	if !errors.Is(err, ErrLocked) || err.Error() != "sysfs-i2c: I2C1: locked by another process" {
+		t.Fatal(err)
+	}
+	if err := unlock(other.Fd()); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Tx(1, []byte{0}, nil); err != nil {
+		t.Fatal(err)
+	}
+	// Released after the transaction.
+	if ok, err := tryLock(other.Fd()); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+}
+
+func TestSPI_lock(t *testing.T) {
+	if !isLinux {
+		t.Skip("flock is only used on linux")
+	}
+	defer func(d time.Duration) { lockWait = d }(lockWait)
+	lockWait = 10 * time.Millisecond
+	other, f := openTwice(t)
+	defer other.Close()
+	defer f.Close()
+
+	s := spiConn{name: "SPI0.1", f: &lockFile{f: f}, lock: true}
+	if ok, err := tryLock(other.Fd()); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+	err := s.Tx([]byte{0}, nil)
+	if !errors.Is(err, ErrLocked) || err.Error() != "sysfs-spi: Tx() failed: SPI0.1: locked by another process" {
+		t.Fatal(err)
+	}
+	if err := unlock(other.Fd()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Tx([]byte{0}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPin_lock(t *testing.T) {
+	if !isLinux {
+		t.Skip("flock is only used on linux")
+	}
+	d, err := ioutil.TempDir("", "sysfs-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	for _, n := range []string{"value", "direction"} {
+		if err := ioutil.WriteFile(filepath.Join(d, n), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer func() {
+		fileIOOpen = fileIOOpenDefault
+		drvGPIO.exportHandle = nil
+		drvGPIO.lock = false
+	}()
+	fileIOOpen = func(path string, flag int) (fileIO, error) {
+		f, err := os.OpenFile(path, flag, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &lockFile{f: f}, nil
+	}
+	drvGPIO.exportHandle = &bytes.Buffer{}
+	drvGPIO.lock = true
+	other, err := os.Open(filepath.Join(d, "value"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if ok, err := tryLock(other.Fd()); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+
+	p := Pin{number: 42, name: "GPIO42", root: d + "/"}
+	if err := p.Out(gpio.Low); !errors.Is(err, ErrLocked) {
+		t.Fatal(err)
+	}
+	if err := unlock(other.Fd()); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Out(gpio.Low); err != nil {
+		t.Fatal(err)
+	}
+	// Kept as long as the pin is used.
+	if ok, err := tryLock(other.Fd()); ok || err != nil {
+		t.Fatal(ok, err)
+	}
+}
+
+//
+
+// openTwice opens a temporary file twice, to lock it from two open files as
+// two processes would.
+func openTwice(t *testing.T) (*os.File, *os.File) {
+	f1, err := ioutil.TempFile("", "sysfs-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f1.Name())
+	f2, err := os.OpenFile(f1.Name(), os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f1, f2
+}
+
+// lockFile is a real file for flock, faking the ioctls.
+type lockFile struct {
+	f *os.File
+}
+
+func (l *lockFile) Close() error                      { return l.f.Close() }
+func (l *lockFile) Fd() uintptr                       { return l.f.Fd() }
+func (l *lockFile) Ioctl(op uint, data uintptr) error { return nil }
+func (l *lockFile) Read(b []byte) (int, error)        { return l.f.Read(b) }
+func (l *lockFile) Seek(o int64, w int) (int64, error) {
+	return l.f.Seek(o, w)
+}
+func (l *lockFile) Write(b []byte) (int, error) { return l.f.Write(b) }
@@ -17,14 +17,14 @@ import (
 	"sync"
 	"unsafe"
 
+	"github.com/s-mobi01/host/fs"
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3"
-	"periph.io/x/conn/v3/driver/driverreg"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/spi"
 	"periph.io/x/conn/v3/spi/spireg"
-	"periph.io/x/host/v3/fs"
 )
 
 // NewSPI opens a SPI port via its devfs interface as described at
@@ -143,6 +143,58 @@ func (s *SPI) MaxTxSize() int {
 	return drvSPI.bufSize
 }
 
+// WriteFrame writes w in a single chip select assertion however large it is,
+// e.g. a framebuffer pushed to a SPI display.
+//
+// spidev limits each SPI_IOC_MESSAGE to MaxTxSize() bytes, so w is written in
+// chunks of this size, one ioctl each, with CS kept asserted in between. w is
+// passed to the kernel in place, without being copied nor any allocation, so
+// the same buffer can be reused for each frame. When locking is enabled, the
+// bus is locked for the whole frame.
+//
+// spidev supports neither mmap nor vmsplice, so it still copies each chunk
+// into its own buffer. Raising the bufsiz parameter of the spidev module,
+// e.g. to 65536 with spidev.bufsiz=65536 on the kernel command line, reduces
+// the number of ioctls per frame.
+//
+// Connect must have been called. When a chunk fails, CS may be left asserted
+// until the next transaction.
+func (s *SPI) WriteFrame(w []byte) error {
+	if len(w) == 0 {
+		return errors.New("sysfs-spi: WriteFrame() with empty buffer")
+	}
+	c := &s.conn
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.connected {
+		return errors.New("sysfs-spi: WriteFrame() requires Connect()")
+	}
+	chunk := drvSPI.bufSize
+	if chunk == 0 {
+		chunk = len(w)
+	}
+	if c.lock {
+		if err := lockBus(c.f); err != nil {
+			return fmt.Errorf("sysfs-spi: WriteFrame() failed: %s: %w", c.name, err)
+		}
+		defer unlockBus(c.f)
+	}
+	f := c.freq()
+	m := c.io[:1]
+	for off := 0; off < len(w); off += chunk {
+		end := off + chunk
+		if end > len(w) {
+			end = len(w)
+		}
+		// Keep CS asserted after each chunk but the last.
+		m[0].reset(w[off:end], nil, f, c.bitsPerWord, !c.noCS && end != len(w))
+		if err := c.f.Ioctl(spiIOCTx(1), uintptr(unsafe.Pointer(&m[0]))); err != nil {
+			return fmt.Errorf("sysfs-spi: WriteFrame() failed after %d bytes: %w", off, err)
+		}
+	}
+	return nil
+}
+
 // CLK implements spi.Pins.
 func (s *SPI) CLK() gpio.PinOut {
 	return s.conn.CLK()
@@ -183,6 +235,7 @@ func newSPI(busNumber, chipSelect int) (*SPI, error) {
 			f:          f,
 			busNumber:  busNumber,
 			chipSelect: chipSelect,
+			lock:       lockEnabled(),
 		},
 	}, nil
 }
@@ -204,6 +257,7 @@ type spiConn struct {
 	connected   bool
 	halfDuplex  bool
 	noCS        bool
+	lock        bool // Lock the bus against other processes
 	// Heap optimization: reduce the amount of memory allocations during
 	// transactions.
 	io [4]spiIOCTransfer
@@ -235,7 +289,7 @@ func (s *spiConn) Read(b []byte) (int, error) {
 	s.p[0].W = nil
 	s.p[0].R = b
 	if err := s.txPackets(s.p[:1]); err != nil {
-		return 0, fmt.Errorf("sysfs-spi: Read() failed: %v", err)
+		return 0, fmt.Errorf("sysfs-spi: Read() failed: %w", err)
 	}
 	return len(b), nil
 }
@@ -253,7 +307,7 @@ func (s *spiConn) Write(b []byte) (int, error) {
 	s.p[0].W = b
 	s.p[0].R = nil
 	if err := s.txPackets(s.p[:1]); err != nil {
-		return 0, fmt.Errorf("sysfs-spi: Write() failed: %v", err)
+		return 0, fmt.Errorf("sysfs-spi: Write() failed: %w", err)
 	}
 	return len(b), nil
 }
@@ -297,7 +351,7 @@ func (s *spiConn) Tx(w, r []byte) error {
 		s.p[0].KeepCS = false
 	}
 	if err := s.txPackets(p); err != nil {
-		return fmt.Errorf("sysfs-spi: Tx() failed: %v", err)
+		return fmt.Errorf("sysfs-spi: Tx() failed: %w", err)
 	}
 	return nil
 }
@@ -338,7 +392,7 @@ func (s *spiConn) TxPackets(p []spi.Packet) error {
 		}
 	}
 	if err := s.txPackets(p); err != nil {
-		return fmt.Errorf("sysfs-spi: TxPackets() failed: %v", err)
+		return fmt.Errorf("sysfs-spi: TxPackets() failed: %w", err)
 	}
 	return nil
 }
@@ -384,10 +438,7 @@ func (s *spiConn) CS() gpio.PinOut {
 
 func (s *spiConn) txPackets(p []spi.Packet) error {
 	// Convert the packets.
-	f := s.freqPort
-	if s.freqConn != 0 && (s.freqPort == 0 || s.freqConn < s.freqPort) {
-		f = s.freqConn
-	}
+	f := s.freq()
 	var m []spiIOCTransfer
 	if len(p) > len(s.io) {
 		m = make([]spiIOCTransfer, len(p))
@@ -408,9 +459,24 @@ func (s *spiConn) txPackets(p []spi.Packet) error {
 		}
 		m[i].reset(p[i].W, p[i].R, f, bits, csInvert)
 	}
+	if s.lock {
+		if err := lockBus(s.f); err != nil {
+			return fmt.Errorf("%s: %w", s.name, err)
+		}
+		defer unlockBus(s.f)
+	}
 	return s.f.Ioctl(spiIOCTx(len(m)), uintptr(unsafe.Pointer(&m[0])))
 }
 
+// freq returns the clock speed, the lowest of the port and connection ones.
+func (s *spiConn) freq() physic.Frequency {
+	f := s.freqPort
+	if s.freqConn != 0 && (s.freqPort == 0 || s.freqConn < s.freqPort) {
+		f = s.freqConn
+	}
+	return f
+}
+
 func (s *spiConn) setFlag(op uint, arg uint64) error {
 	return s.f.Ioctl(op, uintptr(unsafe.Pointer(&arg)))
 }
@@ -604,7 +670,7 @@ func (o *openerSPI) Open() (spi.PortCloser, error) {
 
 func init() {
 	if isLinux {
-		driverreg.MustRegister(&drvSPI)
+		hostcfg.MustRegister(&drvSPI)
 	}
 }
 
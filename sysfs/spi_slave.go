@@ -0,0 +1,177 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"unsafe"
+
+	"github.com/s-mobi01/host/spislave"
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/spi"
+)
+
+// NewSPISlave opens the SPI controller busNumber in slave mode via spidev, as
+// described at https://www.kernel.org/doc/Documentation/spi/spi-summary
+//
+// The controller must support the slave mode and be declared as such in the
+// device tree with the spi-slave property; it then shows up in
+// /sys/class/spi_slave. If /dev/spidev<busNumber>.0 doesn't exist yet, the
+// spidev protocol driver is bound to the controller by writing "spidev" to
+// /sys/class/spi_slave/spi<busNumber>/slave, which requires root.
+//
+// The resulting object is safe for concurrent use.
+func NewSPISlave(busNumber int) (*SPISlave, error) {
+	if isLinux {
+		return newSPISlave(busNumber)
+	}
+	return nil, errors.New("sysfs-spi-slave: not implemented on non-linux OSes")
+}
+
+// SPISlave is an SPI controller open in slave mode.
+type SPISlave struct {
+	// Immutable
+	name      string
+	busNumber int
+
+	mu          sync.Mutex
+	f           ioctlCloser
+	bitsPerWord uint8
+	connected   bool
+	io          [1]spiIOCTransfer
+}
+
+// Close closes the handle to the spidev driver. It waits for a pending Tx.
+//
+// Note that the object is not reusable afterward.
+func (s *SPISlave) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.f.Close(); err != nil {
+		return fmt.Errorf("sysfs-spi-slave: %v", err)
+	}
+	s.f = nil
+	return nil
+}
+
+func (s *SPISlave) String() string {
+	return s.name
+}
+
+// Connect implements spislave.Port.
+//
+// It must be called before any I/O.
+func (s *SPISlave) Connect(mode spi.Mode, bits int) (conn.Conn, error) {
+	if mode&^(spi.Mode3|spi.LSBFirst) != 0 {
+		return nil, fmt.Errorf("sysfs-spi-slave: invalid mode %v", mode)
+	}
+	if bits < 1 || bits >= 256 {
+		return nil, fmt.Errorf("sysfs-spi-slave: invalid bits %d", bits)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.connected {
+		return nil, errors.New("sysfs-spi-slave: Connect() can only be called exactly once")
+	}
+	m := mode & spi.Mode3
+	if mode&spi.LSBFirst != 0 {
+		m |= lSBFirst
+	}
+	// Only the first 8 bits are used. This only works because the system is
+	// running in little endian.
+	arg := uint64(m)
+	if err := s.f.Ioctl(spiIOCMode, uintptr(unsafe.Pointer(&arg))); err != nil {
+		return nil, fmt.Errorf("sysfs-spi-slave: setting mode %v failed: %v", mode, err)
+	}
+	s.connected = true
+	s.bitsPerWord = uint8(bits)
+	return &spiSlaveConn{s}, nil
+}
+
+// Private details.
+
+func newSPISlave(busNumber int) (*SPISlave, error) {
+	if busNumber < 0 || busNumber >= 1<<16 {
+		return nil, fmt.Errorf("sysfs-spi-slave: invalid bus %d", busNumber)
+	}
+	// The slave controller has a single device, always at chip select 0.
+	p := fmt.Sprintf("/dev/spidev%d.0", busNumber)
+	f, err := ioctlOpen(p, os.O_RDWR)
+	if os.IsNotExist(err) {
+		if err = bindSPISlave(busNumber); err == nil {
+			f, err = ioctlOpen(p, os.O_RDWR)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sysfs-spi-slave: %v", err)
+	}
+	return &SPISlave{
+		name:      fmt.Sprintf("SPISlave%d", busNumber),
+		busNumber: busNumber,
+		f:         f,
+	}, nil
+}
+
+// bindSPISlave binds the spidev protocol driver to the slave controller.
+func bindSPISlave(busNumber int) error {
+	f, err := fileIOOpen(fmt.Sprintf("/sys/class/spi_slave/spi%d/slave", busNumber), os.O_WRONLY)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("spi%d is not a slave controller", busNumber)
+		}
+		return err
+	}
+	defer f.Close()
+	return seekWrite(f, []byte("spidev"))
+}
+
+// spiSlaveConn implements conn.Conn.
+type spiSlaveConn struct {
+	s *SPISlave
+}
+
+func (c *spiSlaveConn) String() string {
+	return c.s.name
+}
+
+// Tx implements conn.Conn.
+//
+// It blocks until the master has clocked len(w) or len(r) bytes.
+func (c *spiSlaveConn) Tx(w, r []byte) error {
+	l := len(w)
+	if l == 0 {
+		if l = len(r); l == 0 {
+			return errors.New("sysfs-spi-slave: Tx() with empty buffers")
+		}
+	} else if len(r) != 0 && len(r) != l {
+		return fmt.Errorf("sysfs-spi-slave: Tx(): when both w and r are used, they must be the same size; got %d and %d bytes", len(w), len(r))
+	}
+	if drvSPI.bufSize != 0 && l > drvSPI.bufSize {
+		return fmt.Errorf("sysfs-spi-slave: maximum Tx length is %d, got %d bytes", drvSPI.bufSize, l)
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return errors.New("sysfs-spi-slave: port is closed")
+	}
+	// The clock is provided by the master, so the speed is left to 0.
+	s.io[0].reset(w, r, 0, s.bitsPerWord, false)
+	if err := s.f.Ioctl(spiIOCTx(1), uintptr(unsafe.Pointer(&s.io[0]))); err != nil {
+		return fmt.Errorf("sysfs-spi-slave: Tx() failed: %v", err)
+	}
+	return nil
+}
+
+// Duplex implements conn.Conn.
+func (c *spiSlaveConn) Duplex() conn.Duplex {
+	return conn.Full
+}
+
+var _ spislave.PortCloser = &SPISlave{}
+var _ conn.Conn = &spiSlaveConn{}
@@ -0,0 +1,126 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/spi"
+)
+
+func TestNewSPISlave(t *testing.T) {
+	if p, err := NewSPISlave(-1); p != nil || err == nil {
+		t.Fatal("invalid bus number")
+	}
+}
+
+func TestNewSPISlaveinternal(t *testing.T) {
+	defer reset()
+	var paths []string
+	ioctlOpen = func(path string, flag int) (ioctlCloser, error) {
+		paths = append(paths, path)
+		return &ioctlClose{}, nil
+	}
+	s, err := newSPISlave(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := s.String(); v != "SPISlave1" {
+		t.Fatal(v)
+	}
+	if len(paths) != 1 || paths[0] != "/dev/spidev1.0" {
+		t.Fatal(paths)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewSPISlaveinternal_Err(t *testing.T) {
+	if _, err := newSPISlave(65536); err == nil {
+		t.Fatal("bad bus number")
+	}
+	defer reset()
+	ioctlOpen = func(path string, flag int) (ioctlCloser, error) {
+		return nil, errors.New("foo")
+	}
+	if _, err := newSPISlave(1); err.Error() != "sysfs-spi-slave: foo" {
+		t.Fatal(err)
+	}
+	ioctlOpen = func(path string, flag int) (ioctlCloser, error) {
+		return nil, os.ErrNotExist
+	}
+	fileIOOpen = func(path string, flag int) (fileIO, error) {
+		return nil, os.ErrNotExist
+	}
+	if _, err := newSPISlave(1); err.Error() != "sysfs-spi-slave: spi1 is not a slave controller" {
+		t.Fatal(err)
+	}
+}
+
+func TestSPISlave_Connect(t *testing.T) {
+	p := SPISlave{name: "SPISlave0", f: &ioctlClose{}}
+	if _, err := p.Connect(spi.HalfDuplex, 8); err == nil {
+		t.Fatal("invalid mode")
+	}
+	if _, err := p.Connect(spi.Mode0, 0); err == nil {
+		t.Fatal("invalid bits")
+	}
+	c, err := p.Connect(spi.Mode3|spi.LSBFirst, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Connect(spi.Mode3, 8); err == nil {
+		t.Fatal("second Connect")
+	}
+	if s := c.String(); s != "SPISlave0" {
+		t.Fatal(s)
+	}
+	if d := c.Duplex(); d != conn.Full {
+		t.Fatal(d)
+	}
+}
+
+func TestSPISlave_Connect_Err(t *testing.T) {
+	p := SPISlave{f: &ioctlClose{ioctlErr: errors.New("foo")}}
+	if _, err := p.Connect(spi.Mode0, 8); err == nil {
+		t.Fatal("ioctl failed")
+	}
+}
+
+func TestSPISlave_Tx(t *testing.T) {
+	f := ioctlClose{}
+	p := SPISlave{f: &f}
+	c, err := p.Connect(spi.Mode0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Tx(nil, nil); err == nil {
+		t.Fatal("nil values")
+	}
+	if err := c.Tx([]byte{0}, []byte{0, 1}); err == nil {
+		t.Fatal("different lengths")
+	}
+	if err := c.Tx([]byte{0}, []byte{0}); err != nil {
+		t.Fatal(err)
+	}
+	if p.io[0].speedHz != 0 || p.io[0].bitsPerWord != 8 || p.io[0].length != 1 {
+		t.Fatalf("%#v", p.io[0])
+	}
+	f.ioctlErr = errors.New("foo")
+	if err := c.Tx(nil, []byte{0}); err == nil {
+		t.Fatal("ioctl failed")
+	}
+	f.ioctlErr = nil
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Tx([]byte{0}, nil); err == nil {
+		t.Fatal("closed")
+	}
+}
@@ -6,8 +6,11 @@ package sysfs
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"math/rand"
 	"testing"
+	"unsafe"
 
 	"periph.io/x/conn/v3"
 	"periph.io/x/conn/v3/gpio"
@@ -124,6 +127,112 @@ func TestSPI_TxPackets(t *testing.T) {
 	}
 }
 
+func TestSPI_TxPackets_model(t *testing.T) {
+	// Random packets are packed as the kernel expects them.
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		f := &spiCapture{}
+		noCS := rnd.Intn(4) == 0
+		p := SPI{spiConn{f: f, busNumber: 24, noCS: noCS}}
+		c, err := p.Connect(physic.MegaHertz, spi.Mode0, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkts := make([]spi.Packet, 1+rnd.Intn(6))
+		for j := range pkts {
+			l := 1 + rnd.Intn(64)
+			switch rnd.Intn(3) {
+			case 0:
+				pkts[j].W = make([]byte, l)
+			case 1:
+				pkts[j].R = make([]byte, l)
+			default:
+				pkts[j].W = make([]byte, l)
+				pkts[j].R = make([]byte, l)
+			}
+			if rnd.Intn(2) == 0 {
+				pkts[j].BitsPerWord = 16
+			}
+			pkts[j].KeepCS = rnd.Intn(2) == 0
+		}
+		desc := fmt.Sprintf("#%d: %d packets noCS=%t", i, len(pkts), noCS)
+		if err := c.TxPackets(pkts); err != nil {
+			t.Fatalf("%s: %v", desc, err)
+		}
+		if len(f.m) != len(pkts) {
+			t.Fatalf("%s: %d transfers", desc, len(f.m))
+		}
+		for j, pkt := range pkts {
+			m := f.m[j]
+			want := spiIOCTransfer{speedHz: 1000000, bitsPerWord: 8}
+			if len(pkt.W) != 0 {
+				want.tx = uint64(uintptr(unsafe.Pointer(&pkt.W[0])))
+				want.length = uint32(len(pkt.W))
+			}
+			if len(pkt.R) != 0 {
+				want.rx = uint64(uintptr(unsafe.Pointer(&pkt.R[0])))
+				want.length = uint32(len(pkt.R))
+			}
+			if pkt.BitsPerWord != 0 {
+				want.bitsPerWord = pkt.BitsPerWord
+			}
+			// CS is toggled after a packet without KeepCS, and kept after the
+			// last packet with KeepCS.
+			if !noCS && pkt.KeepCS == (j == len(pkts)-1) {
+				want.csChange = 1
+			}
+			if m != want {
+				t.Fatalf("%s: packet %d: %+v, want %+v", desc, j, m, want)
+			}
+		}
+	}
+}
+
+func TestSPI_WriteFrame(t *testing.T) {
+	for _, noCS := range []bool{false, true} {
+		f := &spiCapture{}
+		p := SPI{spiConn{f: f, busNumber: 24}}
+		w := make([]byte, 2*drvSPI.bufSize+100)
+		if err := p.WriteFrame(w); err == nil {
+			t.Fatal("not connected")
+		}
+		mode := spi.Mode0
+		if noCS {
+			mode |= spi.NoCS
+		}
+		if _, err := p.Connect(physic.MegaHertz, mode, 8); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.WriteFrame(nil); err == nil {
+			t.Fatal("empty frame")
+		}
+		if err := p.WriteFrame(w); err != nil {
+			t.Fatal(err)
+		}
+		if len(f.all) != 3 {
+			t.Fatalf("%d transfers", len(f.all))
+		}
+		for i, m := range f.all {
+			off := i * drvSPI.bufSize
+			want := spiIOCTransfer{
+				tx:          uint64(uintptr(unsafe.Pointer(&w[off]))),
+				length:      uint32(drvSPI.bufSize),
+				speedHz:     1000000,
+				bitsPerWord: 8,
+			}
+			if i == 2 {
+				want.length = 100
+			} else if !noCS {
+				// CS is kept asserted between the chunks.
+				want.csChange = 1
+			}
+			if m != want {
+				t.Fatalf("noCS=%t: chunk %d: %+v, want %+v", noCS, i, m, want)
+			}
+		}
+	}
+}
+
 func TestSPI_Read(t *testing.T) {
 	f := ioctlClose{}
 	p := SPI{spiConn{f: &f, busNumber: 24}}
@@ -365,8 +474,47 @@ func BenchmarkSPI_TxPackets5(b *testing.B) {
 	}
 }
 
+func BenchmarkSPI_WriteFrame(b *testing.B) {
+	// A 320x240 RGB565 display frame.
+	b.ReportAllocs()
+	p := SPI{spiConn{f: &ioctlClose{}}}
+	if _, err := p.Connect(physic.MegaHertz, spi.Mode0, 8); err != nil {
+		b.Fatal(err)
+	}
+	w := make([]byte, 320*240*2)
+	b.SetBytes(int64(len(w)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := p.WriteFrame(w); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 //
 
+// spiCapture captures the transfers passed to SPI_IOC_MESSAGE.
+type spiCapture struct {
+	ioctlClose
+	m []spiIOCTransfer
+	// all is the transfers of all the messages.
+	all []spiIOCTransfer
+}
+
+func (s *spiCapture) Ioctl(op uint, data uintptr) error {
+	n := int(op>>16&0x3FFF) / int(unsafe.Sizeof(spiIOCTransfer{}))
+	if op&0xFF00 != spiIOCMagic<<8 || op&0xFF != 0 || n == 0 {
+		// Not SPI_IOC_MESSAGE.
+		return nil
+	}
+	// data points to memory owned by the caller, which is alive for the
+	// duration of the call.
+	p := *(*unsafe.Pointer)(unsafe.Pointer(&data))
+	s.m = append([]spiIOCTransfer(nil), (*[1 << 10]spiIOCTransfer)(p)[:n:n]...)
+	s.all = append(s.all, s.m...)
+	return nil
+}
+
 func init() {
 	drvSPI.bufSize = 4096
 }
@@ -7,7 +7,7 @@ package sysfs
 import (
 	"io"
 
-	"periph.io/x/host/v3/fs"
+	"github.com/s-mobi01/host/fs"
 )
 
 var ioctlOpen = ioctlOpenDefault
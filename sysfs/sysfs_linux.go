@@ -7,6 +7,8 @@ package sysfs
 import (
 	"os"
 	"syscall"
+	"time"
+	"unsafe"
 )
 
 const isLinux = true
@@ -15,3 +17,31 @@ func isErrBusy(err error) bool {
 	e, ok := err.(*os.PathError)
 	return ok && e.Err == syscall.EBUSY
 }
+
+// tryLock takes the exclusive advisory lock on fd. It returns false if
+// another open file holds it.
+func tryLock(fd uintptr) (bool, error) {
+	switch err := syscall.Flock(int(fd), syscall.LOCK_EX|syscall.LOCK_NB); err {
+	case nil:
+		return true, nil
+	case syscall.EWOULDBLOCK:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// unlock releases the advisory lock on fd.
+func unlock(fd uintptr) error {
+	return syscall.Flock(int(fd), syscall.LOCK_UN)
+}
+
+// clockMonotonicRaw is CLOCK_MONOTONIC_RAW from include/uapi/linux/time.h.
+const clockMonotonicRaw = 4
+
+func monotonicRaw() time.Duration {
+	var ts syscall.Timespec
+	// It can't fail with a valid clock and pointer.
+	_, _, _ = syscall.Syscall(syscall.SYS_CLOCK_GETTIME, clockMonotonicRaw, uintptr(unsafe.Pointer(&ts)), 0)
+	return time.Duration(ts.Nano())
+}
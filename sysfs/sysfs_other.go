@@ -7,9 +7,28 @@
 
 package sysfs
 
+import "time"
+
 const isLinux = false
 
+// monotonicStart is the origin of monotonicRaw().
+var monotonicStart = time.Now()
+
 func isErrBusy(err error) bool {
 	// This function is not used on non-linux.
 	return false
 }
+
+func tryLock(fd uintptr) (bool, error) {
+	// This function is not used on non-linux.
+	return true, nil
+}
+
+func unlock(fd uintptr) error {
+	// This function is not used on non-linux.
+	return nil
+}
+
+func monotonicRaw() time.Duration {
+	return time.Since(monotonicStart)
+}
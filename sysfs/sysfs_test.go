@@ -8,7 +8,7 @@ import (
 	"errors"
 	"io"
 
-	"periph.io/x/host/v3/fs"
+	"github.com/s-mobi01/host/fs"
 )
 
 func init() {
@@ -10,8 +10,8 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/s-mobi01/host/sysfs"
 	"periph.io/x/conn/v3/gpio"
-	"periph.io/x/host/v3/sysfs"
 )
 
 // Benchmark is imported by periph-smoketest.
@@ -0,0 +1,110 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/s-mobi01/host/distro"
+)
+
+// ThermalRole is a stable name for what a thermal sensor measures, independent
+// of the board.
+type ThermalRole string
+
+// Thermal sensor roles.
+const (
+	ThermalCPU  ThermalRole = "cpu"
+	ThermalGPU  ThermalRole = "gpu"
+	ThermalPMIC ThermalRole = "pmic"
+	ThermalNVMe ThermalRole = "nvme"
+)
+
+// ThermalSensorByRole returns the first *ThermalSensor measuring role, if any.
+//
+// Thermal zones are preferred over hwmon devices. For example
+// ThermalSensorByRole(ThermalCPU) returns "cpu-thermal" on a Raspberry Pi,
+// "soc-thermal" on a RK3588 and "x86_pkg_temp" on a PC.
+func ThermalSensorByRole(role ThermalRole) (*ThermalSensor, error) {
+	for _, t := range ThermalSensors {
+		if t.Role() == role {
+			if err := t.open(); err != nil {
+				return nil, err
+			}
+			return t, nil
+		}
+	}
+	return nil, errors.New("sysfs-thermal: no sensor for " + string(role))
+}
+
+// Role returns the role of the sensor, derived from its type and the board.
+//
+// It returns an empty string when the role is unknown.
+func (t *ThermalSensor) Role() ThermalRole {
+	return thermalRole(t.Type(), thermalCompatible())
+}
+
+//
+
+// thermalRoles maps the sensor types, as reported by the kernel, to roles.
+//
+// typ matches the type lower cased with '_' replaced with '-', either in full
+// or as a prefix followed by a digit or '-'. compatible limits the entry to
+// the boards with this device tree compatible string; the board specific
+// entries are listed first.
+var thermalRoles = []struct {
+	compatible string
+	typ        string
+	role       ThermalRole
+}{
+	// TI K3 zones are named after the location of the sensor.
+	{"ti,am625", "main0", ThermalCPU},
+	{"ti,j721e", "mpu", ThermalCPU},
+	{"ti,j721e", "gpu", ThermalGPU},
+
+	// Thermal zones.
+	{"", "cpu", ThermalCPU},
+	{"", "soc", ThermalCPU},
+	{"", "x86-pkg-temp", ThermalCPU},
+	{"", "gpu", ThermalGPU},
+	{"", "pmic", ThermalPMIC},
+	// hwmon devices.
+	{"", "coretemp", ThermalCPU},
+	{"", "k10temp", ThermalCPU},
+	{"", "amdgpu", ThermalGPU},
+	{"", "nouveau", ThermalGPU},
+	{"", "nvme", ThermalNVMe},
+}
+
+// thermalRole returns the role of the sensor type typ on a board with the
+// device tree compatible strings compatible.
+func thermalRole(typ string, compatible []string) ThermalRole {
+	typ = strings.Replace(strings.ToLower(typ), "_", "-", -1)
+	for _, r := range thermalRoles {
+		if r.compatible != "" && !contains(compatible, r.compatible) {
+			continue
+		}
+		if !strings.HasPrefix(typ, r.typ) {
+			continue
+		}
+		if rest := typ[len(r.typ):]; rest == "" || rest[0] == '-' || (rest[0] >= '0' && rest[0] <= '9') {
+			return r.role
+		}
+	}
+	return ""
+}
+
+func contains(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// thermalCompatible returns the device tree compatible strings of the board.
+var thermalCompatible = distro.DTCompatible
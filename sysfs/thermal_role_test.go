@@ -0,0 +1,61 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package sysfs
+
+import (
+	"testing"
+)
+
+func TestThermalRole(t *testing.T) {
+	data := []struct {
+		typ        string
+		compatible []string
+		want       ThermalRole
+	}{
+		{"cpu-thermal", nil, ThermalCPU},
+		{"cpu_thermal", nil, ThermalCPU},
+		{"cpu0-thermal", nil, ThermalCPU},
+		{"CPU-therm", nil, ThermalCPU},
+		{"soc-thermal", nil, ThermalCPU},
+		{"x86_pkg_temp", nil, ThermalCPU},
+		{"k10temp", nil, ThermalCPU},
+		{"gpu_thermal_zone", nil, ThermalGPU},
+		{"amdgpu", nil, ThermalGPU},
+		{"PMIC-Die", nil, ThermalPMIC},
+		{"nvme", nil, ThermalNVMe},
+		{"cpufreq", nil, ""},
+		{"acpitz", nil, ""},
+		{"<unknown>", nil, ""},
+		{"main0-thermal", nil, ""},
+		{"main0-thermal", []string{"ti,am625-sk", "ti,am625"}, ThermalCPU},
+		{"mpu-thermal", []string{"beagle,j721e-beagleboneai64", "ti,j721e"}, ThermalCPU},
+	}
+	for i, line := range data {
+		if r := thermalRole(line.typ, line.compatible); r != line.want {
+			t.Fatalf("#%d: %q: %q != %q", i, line.typ, r, line.want)
+		}
+	}
+}
+
+func TestThermalSensorByRole(t *testing.T) {
+	defer resetThermal()
+	defer func(f func() []string) { thermalCompatible = f }(thermalCompatible)
+	thermalCompatible = func() []string { return nil }
+	ThermalSensors = []*ThermalSensor{
+		{name: "thermal_zone0", root: "//\000/", nameType: "acpitz"},
+		{name: "thermal_zone1", root: "//\000/", nameType: "x86_pkg_temp", f: &file{}},
+		{name: "hwmon0", root: "//\000/", nameType: "coretemp"},
+	}
+	s, err := ThermalSensorByRole(ThermalCPU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.String() != "thermal_zone1" || s.Role() != ThermalCPU {
+		t.Fatal(s)
+	}
+	if _, err := ThermalSensorByRole(ThermalGPU); err == nil || err.Error() != "sysfs-thermal: no sensor for gpu" {
+		t.Fatal(err)
+	}
+}
@@ -14,8 +14,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/s-mobi01/host/hostcfg"
 	"periph.io/x/conn/v3"
-	"periph.io/x/conn/v3/driver/driverreg"
 	"periph.io/x/conn/v3/physic"
 )
 
@@ -251,7 +251,7 @@ func (d *driverThermalSensor) discoverDevices(glob, typeFilename string) error {
 
 func init() {
 	if isLinux {
-		driverreg.MustRegister(&drvThermalSensor)
+		hostcfg.MustRegister(&drvThermalSensor)
 	}
 }
 
@@ -0,0 +1,18 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package tpm exchanges TPM 2.0 commands and responses with the kernel
+// resource manager /dev/tpmrmN.
+//
+// It is only a transport: commands are marshaled by the caller, e.g. with
+// github.com/google/go-tpm, since Dev implements io.ReadWriter. The resource
+// manager flushes the transient objects of each file handle, so several
+// processes can share the TPM.
+//
+// Reference
+//
+// https://trustedcomputinggroup.org/resource/tpm-library-specification/
+//
+// https://www.kernel.org/doc/html/latest/security/tpm/index.html
+package tpm
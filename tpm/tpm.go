@@ -0,0 +1,183 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package tpm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Enumerate returns the TPM resource manager devices N as in /dev/tpmrmN.
+func Enumerate() ([]int, error) {
+	const prefix = "/dev/tpmrm"
+	items, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int, 0, len(items))
+	for _, item := range items {
+		i, err := strconv.Atoi(item[len(prefix):])
+		if err != nil {
+			continue
+		}
+		out = append(out, i)
+	}
+	sort.Ints(out)
+	return out, nil
+}
+
+// Open opens the TPM resource manager device /dev/tpmrm<n>.
+func Open(n int) (*Dev, error) {
+	name := fmt.Sprintf("tpmrm%d", n)
+	f, err := os.OpenFile("/dev/"+name, os.O_RDWR, 0)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("tpm: need more access, try as root or a member of group tss: %v", err)
+		}
+		return nil, fmt.Errorf("tpm: %v", err)
+	}
+	return &Dev{name: name, f: f}, nil
+}
+
+// ResponseCode is a TPM_RC response code other than TPM_RC_SUCCESS.
+type ResponseCode uint32
+
+func (r ResponseCode) Error() string {
+	return fmt.Sprintf("tpm: response code %#x", uint32(r))
+}
+
+// Dev is an open TPM.
+type Dev struct {
+	name string
+
+	mu sync.Mutex
+	f  io.ReadWriteCloser
+}
+
+func (d *Dev) String() string {
+	return d.name
+}
+
+// Close closes the device. The resource manager flushes the transient
+// objects and sessions created through it.
+func (d *Dev) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.f.Close(); err != nil {
+		return fmt.Errorf("tpm: %v", err)
+	}
+	return nil
+}
+
+// Send sends a marshaled command and returns the response.
+//
+// When the TPM fails the command, the response is returned along with a
+// ResponseCode error.
+func (d *Dev) Send(cmd []byte) ([]byte, error) {
+	if err := checkHeader(cmd); err != nil {
+		return nil, fmt.Errorf("tpm: invalid command: %v", err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.f.Write(cmd); err != nil {
+		return nil, fmt.Errorf("tpm: %v", err)
+	}
+	// The response is read in a single read.
+	buf := make([]byte, maxSize)
+	n, err := d.f.Read(buf)
+	if err != nil {
+		return nil, fmt.Errorf("tpm: %v", err)
+	}
+	resp := buf[:n]
+	if err := checkHeader(resp); err != nil {
+		return nil, fmt.Errorf("tpm: invalid response: %v", err)
+	}
+	if rc := binary.BigEndian.Uint32(resp[6:]); rc != 0 {
+		return resp, ResponseCode(rc)
+	}
+	return resp, nil
+}
+
+// Write implements io.Writer.
+//
+// It writes a command; the response must be read with Read. Use Send
+// instead unless a library requires an io.ReadWriter.
+func (d *Dev) Write(b []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.f.Write(b)
+}
+
+// Read implements io.Reader.
+//
+// It reads the response to the last command written with Write. b should
+// be at least 4096 bytes long.
+func (d *Dev) Read(b []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.f.Read(b)
+}
+
+// GetRandom fills b with random bytes from the TPM.
+func (d *Dev) GetRandom(b []byte) error {
+	for len(b) != 0 {
+		n := len(b)
+		if n > 0xFFFF {
+			n = 0xFFFF
+		}
+		var cmd [12]byte
+		binary.BigEndian.PutUint16(cmd[0:], stNoSessions)
+		binary.BigEndian.PutUint32(cmd[2:], uint32(len(cmd)))
+		binary.BigEndian.PutUint32(cmd[6:], ccGetRandom)
+		binary.BigEndian.PutUint16(cmd[10:], uint16(n))
+		resp, err := d.Send(cmd[:])
+		if err != nil {
+			return err
+		}
+		// TPM2B_DIGEST; the TPM may return fewer bytes than requested.
+		if len(resp) < headerSize+2 {
+			return errors.New("tpm: short GetRandom response")
+		}
+		l := int(binary.BigEndian.Uint16(resp[headerSize:]))
+		if l == 0 || l > n || len(resp) < headerSize+2+l {
+			return errors.New("tpm: invalid GetRandom response")
+		}
+		b = b[copy(b, resp[headerSize+2:headerSize+2+l]):]
+	}
+	return nil
+}
+
+//
+
+// checkHeader verifies the size field of a command or response header.
+func checkHeader(b []byte) error {
+	if len(b) < headerSize {
+		return fmt.Errorf("%d bytes is shorter than the header", len(b))
+	}
+	if len(b) > maxSize {
+		return fmt.Errorf("%d bytes is over the maximum of %d", len(b), maxSize)
+	}
+	if s := binary.BigEndian.Uint32(b[2:]); s != uint32(len(b)) {
+		return fmt.Errorf("size field %d doesn't match length %d", s, len(b))
+	}
+	return nil
+}
+
+const (
+	// headerSize is tag, size and command or response code.
+	headerSize = 10
+	// maxSize is TPM_BUFSIZE in drivers/char/tpm/tpm.h.
+	maxSize = 4096
+
+	stNoSessions = 0x8001
+	ccGetRandom  = 0x17B
+)
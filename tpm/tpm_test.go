@@ -0,0 +1,107 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package tpm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestDev_Send(t *testing.T) {
+	f := &fakeTPM{responses: [][]byte{response(0, nil), response(0x101, nil)}}
+	d := &Dev{name: "tpmrm0", f: f}
+	cmd := command(0x144, nil)
+	resp, err := d.Send(cmd)
+	if err != nil || len(resp) != headerSize {
+		t.Fatal(resp, err)
+	}
+	if !bytes.Equal(f.written, cmd) {
+		t.Fatal(f.written)
+	}
+	resp, err = d.Send(cmd)
+	if rc, ok := err.(ResponseCode); !ok || rc != 0x101 || resp == nil {
+		t.Fatal(resp, err)
+	}
+	if s := err.Error(); s != "tpm: response code 0x101" {
+		t.Fatal(s)
+	}
+	if _, err := d.Send(cmd[:5]); err == nil {
+		t.Fatal("short command")
+	}
+	if _, err := d.Send(append(cmd, 0)); err == nil {
+		t.Fatal("size mismatch")
+	}
+	f.responses = [][]byte{{0x80, 0x01, 0, 0, 0, 20, 0, 0, 0, 0}}
+	if _, err := d.Send(cmd); err == nil {
+		t.Fatal("invalid response")
+	}
+	if s := d.String(); s != "tpmrm0" {
+		t.Fatal(s)
+	}
+	if err := d.Close(); err != nil || !f.closed {
+		t.Fatal(err)
+	}
+}
+
+func TestDev_GetRandom(t *testing.T) {
+	// The TPM returns fewer bytes than requested.
+	f := &fakeTPM{responses: [][]byte{
+		response(0, []byte{0, 2, 1, 2}),
+		response(0, []byte{0, 1, 3}),
+	}}
+	d := &Dev{f: f}
+	b := make([]byte, 3)
+	if err := d.GetRandom(b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, []byte{1, 2, 3}) {
+		t.Fatal(b)
+	}
+	if c := binary.BigEndian.Uint16(f.written[10:]); c != 1 {
+		t.Fatal("second request", c)
+	}
+	f.responses = [][]byte{response(0, []byte{0, 9, 1})}
+	if err := d.GetRandom(b); err == nil {
+		t.Fatal("truncated response")
+	}
+}
+
+//
+
+type fakeTPM struct {
+	written   []byte
+	responses [][]byte
+	closed    bool
+}
+
+func (f *fakeTPM) Write(b []byte) (int, error) {
+	f.written = append([]byte(nil), b...)
+	return len(b), nil
+}
+
+func (f *fakeTPM) Read(b []byte) (int, error) {
+	n := copy(b, f.responses[0])
+	f.responses = f.responses[1:]
+	return n, nil
+}
+
+func (f *fakeTPM) Close() error {
+	f.closed = true
+	return nil
+}
+
+func command(cc uint32, params []byte) []byte {
+	b := make([]byte, headerSize+len(params))
+	binary.BigEndian.PutUint16(b[0:], stNoSessions)
+	binary.BigEndian.PutUint32(b[2:], uint32(len(b)))
+	binary.BigEndian.PutUint32(b[6:], cc)
+	copy(b[headerSize:], params)
+	return b
+}
+
+func response(rc uint32, params []byte) []byte {
+	return command(rc, params)
+}
@@ -7,7 +7,7 @@ package videocore_test
 import (
 	"log"
 
-	"periph.io/x/host/v3/videocore"
+	"github.com/s-mobi01/host/videocore"
 )
 
 func ExampleAlloc() {
@@ -20,8 +20,8 @@ import (
 	"sync"
 	"unsafe"
 
-	"periph.io/x/host/v3/fs"
-	"periph.io/x/host/v3/pmem"
+	"github.com/s-mobi01/host/fs"
+	"github.com/s-mobi01/host/pmem"
 )
 
 // Mem represents contiguous physically locked memory that was allocated by
@@ -88,6 +88,60 @@ func Alloc(size int) (*Mem, error) {
 	return &Mem{View: b, handle: handle}, nil
 }
 
+// Region is a range of physical memory.
+type Region struct {
+	Base uint32 // Physical address
+	Size uint32 // In bytes
+}
+
+func (r Region) String() string {
+	return fmt.Sprintf("[0x%08x, 0x%08x)", r.Base, uint64(r.Base)+uint64(r.Size))
+}
+
+// Contains returns true if the size bytes at physical address p are in the
+// region.
+func (r Region) Contains(p uint64, size int) bool {
+	return size >= 0 && p >= uint64(r.Base) && p+uint64(size) <= uint64(r.Base)+uint64(r.Size)
+}
+
+// ARMMemory returns the memory split given to the ARM CPU.
+//
+// On boards with more than 1GiB of RAM, only the first block is reported.
+func ARMMemory() (Region, error) {
+	return memoryRegion(mbARMMemory)
+}
+
+// VCMemory returns the memory split reserved to the VideoCore GPU.
+//
+// Alloc returns memory from this region.
+func VCMemory() (Region, error) {
+	return memoryRegion(mbVCMemory)
+}
+
+// CheckDMA returns an error if the size bytes at physical address p are not
+// accessible to the DMA controller.
+//
+// This is the case when the buffer is outside of the ARM and VideoCore memory
+// splits, e.g. above the first GiB on a Raspberry Pi 4.
+func CheckDMA(p uint64, size int) error {
+	arm, err := ARMMemory()
+	if err != nil {
+		return err
+	}
+	vc, err := VCMemory()
+	if err != nil {
+		return err
+	}
+	if arm.Contains(p, size) || vc.Contains(p, size) {
+		return nil
+	}
+	// The GPU memory usually directly follows the ARM memory.
+	if arm.Base+arm.Size == vc.Base && (Region{arm.Base, arm.Size + vc.Size}).Contains(p, size) {
+		return nil
+	}
+	return wrapf("physical memory 0x%08x+%d is outside of ARM memory %s and VideoCore memory %s", p, size, arm, vc)
+}
+
 //
 
 var (
@@ -196,24 +250,29 @@ func mailboxTx32(cmd uint32, args ...uint32) (uint32, error) {
 	return b[5], nil
 }
 
-/*
-// mailboxTx is the generic version of mailboxTx32. It is not currently needed.
-func mailboxTx(cmd uint32, reply []byte, args ...uint32) error {
-	b := genPacket(cmd, uint32(len(reply)), args...)
+// mailboxTx is the generic version of mailboxTx32.
+func mailboxTx(cmd uint32, reply []uint32, args ...uint32) error {
+	b := genPacket(cmd, uint32(len(reply)*4), args...)
 	if err := sendPacket(b); err != nil {
 		return err
 	}
-	rep := b[4]
-	if rep&mbReply == 0 {
-		return fmt.Errorf("got unexpected reply size 0x%08x", b[4])
-	}
-	rep &^= mbReply
-	if rep == 0 || rep > uint32(len(reply)) {
+	if b[4] != mbReply|uint32(len(reply)*4) {
 		return fmt.Errorf("got unexpected reply size 0x%08x", b[4])
 	}
+	copy(reply, b[5:])
 	return nil
 }
-*/
+
+func memoryRegion(tag uint32) (Region, error) {
+	if err := openMailbox(); err != nil {
+		return Region{}, wrapf("failed to open the mailbox to the GPU: %v", err)
+	}
+	var r [2]uint32
+	if err := mailboxTx(tag, r[:]); err != nil {
+		return Region{}, wrapf("failed request to get memory split: %v", err)
+	}
+	return Region{Base: r[0], Size: r[1]}, nil
+}
 
 func smokeTest() error {
 	// It returns 0 on a RPi3 but don't assert this in case the VC firmware gets
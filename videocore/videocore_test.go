@@ -8,8 +8,8 @@ import (
 	"errors"
 	"testing"
 
-	"periph.io/x/host/v3/fs"
-	"periph.io/x/host/v3/pmem"
+	"github.com/s-mobi01/host/fs"
+	"github.com/s-mobi01/host/pmem"
 )
 
 func TestClose(t *testing.T) {
@@ -120,6 +120,67 @@ func TestGenPacket(t *testing.T) {
 	}
 }
 
+func TestMemorySplit(t *testing.T) {
+	defer reset(t)
+	mailbox = &memSplit{}
+	arm, err := ARMMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if arm != (Region{0, 0x3C000000}) {
+		t.Fatal(arm)
+	}
+	vc, err := VCMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vc != (Region{0x3C000000, 0x4000000}) {
+		t.Fatal(vc)
+	}
+	if s := vc.String(); s != "[0x3c000000, 0x40000000)" {
+		t.Fatal(s)
+	}
+	data := []struct {
+		p    uint64
+		size int
+		ok   bool
+	}{
+		{0x1000, 4096, true},
+		{0x3C000000, 4096, true},
+		{0x3BFFF000, 8192, true},
+		{0x3FFFF000, 4096, true},
+		{0x3FFFF000, 8192, false},
+		{0x40000000, 4096, false},
+		{0x1000, -1, false},
+	}
+	for i, line := range data {
+		if err := CheckDMA(line.p, line.size); (err == nil) != line.ok {
+			t.Fatalf("#%d: %v", i, err)
+		}
+	}
+}
+
+func TestMemorySplit_fail(t *testing.T) {
+	defer reset(t)
+	mailboxErr = errors.New("error")
+	if _, err := ARMMemory(); err == nil {
+		t.Fatal("mailboxErr is not nil")
+	}
+	if err := CheckDMA(0, 4096); err == nil {
+		t.Fatal("mailboxErr is not nil")
+	}
+	mailboxErr = nil
+	// Replies 4 bytes instead of 8.
+	mailbox = &dummy{}
+	if _, err := VCMemory(); err == nil {
+		t.Fatal("unexpected reply size")
+	}
+	mailbox = &playback{}
+	if _, err := VCMemory(); err == nil {
+		t.Fatal("mailbox failed")
+	}
+}
+
 //
 
 type dummy struct{}
@@ -135,6 +196,24 @@ type playback struct {
 	count int
 }
 
+// memSplit replies to the memory split tags of a 1GiB board with 64MiB
+// reserved to the GPU.
+type memSplit struct{}
+
+func (m *memSplit) sendMessage(b []uint32) error {
+	b[1] = mbReply
+	b[4] = mbReply | 8
+	switch b[2] {
+	case mbARMMemory:
+		b[5], b[6] = 0, 0x3C000000
+	case mbVCMemory:
+		b[5], b[6] = 0x3C000000, 0x4000000
+	default:
+		b[4] = mbReply | 4
+	}
+	return nil
+}
+
 const failReply uint32 = 0xFFFFFFFE
 const failReplyLen uint32 = 0xFFFFFFFF
 
@@ -0,0 +1,405 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+// CH341 represents a WCH CH341A device in I²C/SPI mode (product ID 0x5512).
+//
+// It exposes an I²C bus, an SPI port and the 8 GPIOs D0 to D7. D6 and D7 are
+// input only. The SPI port uses D0 as CS, D3 as CLK, D5 as MOSI and D7 as
+// MISO.
+type CH341 struct {
+	name string
+	info Info
+	u    usbDev
+
+	mu      sync.Mutex
+	i2cOpen bool
+	spiOpen bool
+	lsb     bool // SPI LSB first
+	noCS    bool // SPI CS is not changed
+	dir     byte // D0-D5 directions, 1 is output
+	out     byte // D0-D5 output values
+	hdr     [8]gpio.PinIO
+}
+
+// newCH341 sets all the GPIOs as inputs and the I²C bus to 100kHz.
+func newCH341(u usbDev, name string, info Info) (*CH341, error) {
+	c := &CH341{name: name, info: info, u: u}
+	for i := range c.hdr {
+		c.hdr[i] = &wchPin{bank: c, name: name + ".D" + strconv.Itoa(i), num: i}
+	}
+	if err := c.uio(); err != nil {
+		return nil, c.wrap(err)
+	}
+	if err := c.i2cSetSpeed(100 * physic.KiloHertz); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *CH341) String() string {
+	return c.name
+}
+
+// Halt implements conn.Resource.
+func (c *CH341) Halt() error {
+	return nil
+}
+
+// Info implements Dev.
+func (c *CH341) Info(i *Info) {
+	*i = c.info
+}
+
+// Header implements Dev.
+func (c *CH341) Header() []gpio.PinIO {
+	out := make([]gpio.PinIO, len(c.hdr))
+	copy(out, c.hdr[:])
+	return out
+}
+
+// I2C returns an I²C bus over the CH341A.
+//
+// Only the address byte acknowledgement is checked; a NACK on a data byte is
+// not reported.
+func (c *CH341) I2C() (i2c.BusCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.i2cOpen {
+		return nil, c.wrap(errors.New("I²C bus already open"))
+	}
+	c.i2cOpen = true
+	return &i2cBus{name: c.name, b: c}, nil
+}
+
+// SPI returns an SPI port over the CH341A.
+//
+// Only mode 0 is supported and the clock is fixed by the chip, the requested
+// frequency is ignored.
+func (c *CH341) SPI() (spi.PortCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.spiOpen {
+		return nil, c.wrap(errors.New("SPI port already open"))
+	}
+	c.spiOpen = true
+	return &spiPort{c: spiConn{name: c.name, b: c}}, nil
+}
+
+//
+
+// Commands of the CH341A. Each bulk transfer is at most 32 bytes.
+const (
+	ch341PacketLen = 32
+	ch341CmdSPI    = 0xA8 // SPI stream, followed by the data
+	ch341CmdI2C    = 0xAA // I²C stream, followed by sub-commands
+	ch341CmdUIO    = 0xAB // GPIO stream, followed by sub-commands
+
+	ch341I2CStart = 0x74
+	ch341I2CStop  = 0x75
+	ch341I2COut   = 0x80 // Low 6 bits: length; 0 sends one byte and returns the ACK
+	ch341I2CIn    = 0xC0 // Low 6 bits: length; 0 reads one byte and sends a NACK
+	ch341I2CSet   = 0x60 // Low 2 bits: speed
+	ch341I2CEnd   = 0x00
+	ch341I2CNACK  = 0x80 // Set in the ACK byte when not acknowledged
+
+	ch341UIOIn  = 0x00
+	ch341UIODir = 0x40 // Low 6 bits: D0-D5 directions
+	ch341UIOOut = 0x80 // Low 6 bits: D0-D5 values
+	ch341UIOEnd = 0x20
+
+	ch341SPIPins = 1<<0 | 1<<3 | 1<<5 // CS, CLK and MOSI
+)
+
+// uio sends the GPIO directions and output values.
+//
+// Must be called with mu held.
+func (c *CH341) uio() error {
+	return c.u.bulkOut([]byte{ch341CmdUIO, ch341UIOOut | c.out&0x3F, ch341UIODir | c.dir&0x3F, ch341UIOEnd})
+}
+
+// readFull reads exactly len(b) bytes.
+func (c *CH341) readFull(b []byte) error {
+	for len(b) != 0 {
+		n, err := c.u.bulkIn(b)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errors.New("short read")
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func (c *CH341) i2cSetSpeed(f physic.Frequency) error {
+	s, ok := i2cSpeed(f)
+	if !ok {
+		return c.wrap(errors.New("invalid I²C speed; minimum supported is 20kHz"))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.u.bulkOut([]byte{ch341CmdI2C, ch341I2CSet | s, ch341I2CEnd}); err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CH341) i2cTx(addr uint16, w, r []byte) error {
+	if addr >= 0x80 {
+		return c.wrap(errors.New("10 bits I²C addresses are not supported"))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := c.i2cTxLocked(addr, w, r)
+	// Always release the bus.
+	if err2 := c.u.bulkOut([]byte{ch341CmdI2C, ch341I2CStop, ch341I2CEnd}); err == nil {
+		err = err2
+	}
+	if err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CH341) i2cTxLocked(addr uint16, w, r []byte) error {
+	if len(w) != 0 || len(r) == 0 {
+		if err := c.i2cStart(byte(addr << 1)); err != nil {
+			return err
+		}
+		for len(w) != 0 {
+			n := len(w)
+			if n > ch341PacketLen-3 {
+				n = ch341PacketLen - 3
+			}
+			pkt := append([]byte{ch341CmdI2C, ch341I2COut | byte(n)}, w[:n]...)
+			if err := c.u.bulkOut(append(pkt, ch341I2CEnd)); err != nil {
+				return err
+			}
+			w = w[n:]
+		}
+	}
+	if len(r) != 0 {
+		if err := c.i2cStart(byte(addr<<1) | 1); err != nil {
+			return err
+		}
+		for off := 0; off < len(r); {
+			// All bytes are acknowledged except the last one.
+			n := len(r) - off - 1
+			if n > ch341PacketLen-1 {
+				n = ch341PacketLen - 1
+			}
+			k := n
+			if n == 0 {
+				k = 1
+			}
+			if err := c.u.bulkOut([]byte{ch341CmdI2C, ch341I2CIn | byte(n), ch341I2CEnd}); err != nil {
+				return err
+			}
+			if err := c.readFull(r[off : off+k]); err != nil {
+				return err
+			}
+			off += k
+		}
+	}
+	return nil
+}
+
+// i2cStart sends a START or repeated START followed by the address byte a,
+// and checks that it is acknowledged.
+func (c *CH341) i2cStart(a byte) error {
+	if err := c.u.bulkOut([]byte{ch341CmdI2C, ch341I2CStart, ch341I2COut, a, ch341I2CEnd}); err != nil {
+		return err
+	}
+	var ack [1]byte
+	if err := c.readFull(ack[:]); err != nil {
+		return err
+	}
+	if ack[0]&ch341I2CNACK != 0 {
+		return errors.New("I²C address NACK")
+	}
+	return nil
+}
+
+func (c *CH341) i2cClose() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.i2cOpen = false
+}
+
+func (c *CH341) spiConnect(f physic.Frequency, m spi.Mode) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.noCS = m&spi.NoCS != 0
+	c.lsb = m&spi.LSBFirst != 0
+	if m&^(spi.NoCS|spi.LSBFirst) != spi.Mode0 {
+		return c.wrap(errors.New("only SPI mode 0 is supported"))
+	}
+	// CS idles high, CLK low.
+	c.dir |= ch341SPIPins
+	c.out = c.out&^(1<<3) | 1<<0
+	if err := c.uio(); err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CH341) spiTxPackets(pkts []spi.Packet) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	asserted := false
+	for _, p := range pkts {
+		if !asserted && !c.noCS {
+			c.out &^= 1 << 0
+			if err := c.uio(); err != nil {
+				return c.wrap(err)
+			}
+			asserted = true
+		}
+		if err := c.spiTx(p.W, p.R); err != nil {
+			if asserted {
+				c.out |= 1 << 0
+				_ = c.uio()
+			}
+			return c.wrap(err)
+		}
+		if asserted && !p.KeepCS {
+			c.out |= 1 << 0
+			if err := c.uio(); err != nil {
+				return c.wrap(err)
+			}
+			asserted = false
+		}
+	}
+	return nil
+}
+
+// spiTx runs a full duplex transfer in chunks of 31 bytes.
+//
+// The CH341A shifts the bits LSB first, so the bytes are reversed unless
+// spi.LSBFirst was requested.
+func (c *CH341) spiTx(w, r []byte) error {
+	l := len(w)
+	if len(r) > l {
+		l = len(r)
+	}
+	var buf [ch341PacketLen]byte
+	for off := 0; off < l; {
+		n := l - off
+		if n > ch341PacketLen-1 {
+			n = ch341PacketLen - 1
+		}
+		buf[0] = ch341CmdSPI
+		for i := 0; i < n; i++ {
+			var b byte
+			if off+i < len(w) {
+				b = w[off+i]
+			}
+			if !c.lsb {
+				b = reverse(b)
+			}
+			buf[1+i] = b
+		}
+		if err := c.u.bulkOut(buf[:1+n]); err != nil {
+			return err
+		}
+		if err := c.readFull(buf[:n]); err != nil {
+			return err
+		}
+		for i := 0; i < n && off+i < len(r); i++ {
+			b := buf[i]
+			if !c.lsb {
+				b = reverse(b)
+			}
+			r[off+i] = b
+		}
+		off += n
+	}
+	return nil
+}
+
+func (c *CH341) spiClose() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spiOpen = false
+}
+
+func (c *CH341) gpioIn(n int) error {
+	if n > 5 {
+		// D6 and D7 are always inputs.
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dir &^= 1 << uint(n)
+	if err := c.uio(); err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CH341) gpioOut(n int, l gpio.Level) error {
+	if n > 5 {
+		return c.wrap(fmt.Errorf("D%d is input only", n))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dir |= 1 << uint(n)
+	if l {
+		c.out |= 1 << uint(n)
+	} else {
+		c.out &^= 1 << uint(n)
+	}
+	if err := c.uio(); err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CH341) gpioRead(n int) (gpio.Level, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.u.bulkOut([]byte{ch341CmdUIO, ch341UIODir | c.dir&0x3F, ch341UIOIn, ch341UIOEnd}); err != nil {
+		return gpio.Low, c.wrap(err)
+	}
+	var b [1]byte
+	if err := c.readFull(b[:]); err != nil {
+		return gpio.Low, c.wrap(err)
+	}
+	return b[0]&(1<<uint(n)) != 0, nil
+}
+
+func (c *CH341) gpioFunction(n int) string {
+	l, err := c.gpioRead(n)
+	if err != nil {
+		return "N/A"
+	}
+	c.mu.Lock()
+	out := c.dir&(1<<uint(n)) != 0
+	c.mu.Unlock()
+	if out {
+		return "Out/" + l.String()
+	}
+	return "In/" + l.String()
+}
+
+func (c *CH341) wrap(err error) error {
+	return fmt.Errorf("ch341: %v", err)
+}
+
+var _ Dev = &CH341{}
@@ -0,0 +1,153 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+func TestCH341_I2C(t *testing.T) {
+	c, f := newFakeCH341(t)
+	f.ops = []usbOp{
+		{w: []byte{ch341CmdI2C, ch341I2CStart, ch341I2COut, 0x50 << 1, ch341I2CEnd}, r: []byte{0}},
+		{w: []byte{ch341CmdI2C, ch341I2COut | 1, 0x10, ch341I2CEnd}},
+		{w: []byte{ch341CmdI2C, ch341I2CStart, ch341I2COut, 0x50<<1 | 1, ch341I2CEnd}, r: []byte{0}},
+		{w: []byte{ch341CmdI2C, ch341I2CIn | 1, ch341I2CEnd}, r: []byte{0x12}},
+		{w: []byte{ch341CmdI2C, ch341I2CIn, ch341I2CEnd}, r: []byte{0x34}},
+		{w: []byte{ch341CmdI2C, ch341I2CStop, ch341I2CEnd}},
+	}
+	b, err := c.I2C()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	r := make([]byte, 2)
+	if err := b.Tx(0x50, []byte{0x10}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{0x12, 0x34}) {
+		t.Fatalf("Tx() read %x", r)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The address is not acknowledged; the bus is still released.
+	f.ops = []usbOp{
+		{w: []byte{ch341CmdI2C, ch341I2CStart, ch341I2COut, 0x51 << 1, ch341I2CEnd}, r: []byte{ch341I2CNACK}},
+		{w: []byte{ch341CmdI2C, ch341I2CStop, ch341I2CEnd}},
+	}
+	if err := b.Tx(0x51, nil, nil); err == nil || err.Error() != "ch341: I²C address NACK" {
+		t.Fatalf("Tx() = %v", err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.ops = []usbOp{{w: []byte{ch341CmdI2C, ch341I2CSet | 2, ch341I2CEnd}}}
+	if err := b.SetSpeed(physic.MegaHertz / 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCH341_SPI(t *testing.T) {
+	c, f := newFakeCH341(t)
+	f.ops = []usbOp{
+		// CS idles high.
+		{w: []byte{ch341CmdUIO, ch341UIOOut | 0x01, ch341UIODir | ch341SPIPins, ch341UIOEnd}},
+		{w: []byte{ch341CmdUIO, ch341UIOOut, ch341UIODir | ch341SPIPins, ch341UIOEnd}},
+		// The bits are shifted LSB first.
+		{w: []byte{ch341CmdSPI, 0x80, 0x01}, r: []byte{0x01, 0x0F}},
+		{w: []byte{ch341CmdUIO, ch341UIOOut | 0x01, ch341UIODir | ch341SPIPins, ch341UIOEnd}},
+	}
+	p, err := c.SPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if _, err := p.Connect(physic.MegaHertz, spi.Mode3, 8); err == nil {
+		t.Fatal("only mode 0 is supported")
+	}
+	s, err := p.Connect(physic.MegaHertz, spi.Mode0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := make([]byte, 2)
+	if err := s.Tx([]byte{0x01, 0x80}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{0x80, 0xF0}) {
+		t.Fatalf("Tx() read %x", r)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	// CS is deasserted when the transfer fails.
+	f.ops = []usbOp{
+		{w: []byte{ch341CmdUIO, ch341UIOOut, ch341UIODir | ch341SPIPins, ch341UIOEnd}},
+		{w: []byte{ch341CmdSPI, 0}},
+		{w: []byte{ch341CmdUIO, ch341UIOOut | 0x01, ch341UIODir | ch341SPIPins, ch341UIOEnd}},
+	}
+	if err := s.Tx(nil, r[:1]); err == nil {
+		t.Fatal("no reply")
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCH341_GPIO(t *testing.T) {
+	c, f := newFakeCH341(t)
+	f.ops = []usbOp{
+		{w: []byte{ch341CmdUIO, ch341UIOOut | 0x04, ch341UIODir | 0x04, ch341UIOEnd}},
+		{w: []byte{ch341CmdUIO, ch341UIODir | 0x04, ch341UIOIn, ch341UIOEnd}, r: []byte{0x44}},
+	}
+	hdr := c.Header()
+	if s := hdr[2].Name(); s != "CH341A.D2" {
+		t.Fatal(s)
+	}
+	if err := hdr[2].Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if l := hdr[6].Read(); l != gpio.High {
+		t.Fatal(l)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+	if err := hdr[7].Out(gpio.High); err == nil {
+		t.Fatal("D7 is input only")
+	}
+}
+
+//
+
+func ch341InitOps() []usbOp {
+	return []usbOp{
+		{w: []byte{ch341CmdUIO, ch341UIOOut, ch341UIODir, ch341UIOEnd}},
+		{w: []byte{ch341CmdI2C, ch341I2CSet | 1, ch341I2CEnd}},
+	}
+}
+
+func newFakeCH341(t *testing.T) (*CH341, *fakeUSB) {
+	f := &fakeUSB{ops: ch341InitOps()}
+	c, err := newCH341(f, "CH341A", Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+	return c, f
+}
@@ -0,0 +1,470 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+// CH347 represents a WCH CH347T (product ID 0x55DB, mode 1) or CH347F
+// (product ID 0x55DE) device.
+//
+// It exposes an I²C bus, an SPI port using CS0 and the 8 GPIOs GP0 to GP7,
+// named GPIO0 to GPIO7 in the datasheet. Depending on the package, some GPIOs
+// share their pin with the other functions.
+type CH347 struct {
+	name string
+	info Info
+	u    usbDev
+
+	mu      sync.Mutex
+	i2cOpen bool
+	spiOpen bool
+	noCS    bool // SPI CS is not changed
+	dir     byte // GPIO directions, 1 is output
+	out     byte // GPIO output values
+	hdr     [8]gpio.PinIO
+}
+
+// newCH347 sets the I²C bus to 100kHz. The GPIOs are left untouched.
+func newCH347(u usbDev, name string, info Info) (*CH347, error) {
+	c := &CH347{name: name, info: info, u: u}
+	for i := range c.hdr {
+		c.hdr[i] = &wchPin{bank: c, name: name + ".GP" + strconv.Itoa(i), num: i}
+	}
+	if err := c.i2cSetSpeed(100 * physic.KiloHertz); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *CH347) String() string {
+	return c.name
+}
+
+// Halt implements conn.Resource.
+func (c *CH347) Halt() error {
+	return nil
+}
+
+// Info implements Dev.
+func (c *CH347) Info(i *Info) {
+	*i = c.info
+}
+
+// Header implements Dev.
+func (c *CH347) Header() []gpio.PinIO {
+	out := make([]gpio.PinIO, len(c.hdr))
+	copy(out, c.hdr[:])
+	return out
+}
+
+// I2C returns an I²C bus over the CH347.
+//
+// The acknowledgement of every byte sent is checked.
+func (c *CH347) I2C() (i2c.BusCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.i2cOpen {
+		return nil, c.wrap(errors.New("I²C bus already open"))
+	}
+	c.i2cOpen = true
+	return &i2cBus{name: c.name, b: c}, nil
+}
+
+// SPI returns an SPI port over the CH347.
+//
+// The clock is 60MHz divided by a power of two, from 60MHz down to
+// 468.75kHz; the requested frequency is rounded down.
+func (c *CH347) SPI() (spi.PortCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.spiOpen {
+		return nil, c.wrap(errors.New("SPI port already open"))
+	}
+	c.spiOpen = true
+	return &spiPort{c: spiConn{name: c.name, b: c}}, nil
+}
+
+//
+
+// Commands of the CH347. Each command is followed by a 16 bits little endian
+// payload length.
+const (
+	ch347CmdSPIConfig = 0xC0
+	ch347CmdSPICS     = 0xC1
+	ch347CmdSPIOutIn  = 0xC2
+	ch347CmdI2C       = 0xAA // I²C stream, followed by sub-commands
+	ch347CmdGPIO      = 0xCC // One byte per GPIO
+	ch347MaxData      = 507
+
+	ch347CSAssert   = 0x00
+	ch347CSDeassert = 0x40
+	ch347CSChange   = 0x80
+
+	ch347I2CStart = 0x74
+	ch347I2CStop  = 0x75
+	ch347I2COut   = 0x80 // Low 6 bits: length; returns one ACK byte per byte
+	ch347I2CIn    = 0xC0 // Low 6 bits: length; 0 reads one byte and sends a NACK
+	ch347I2CSet   = 0x60 // Low 2 bits: speed
+	ch347I2CEnd   = 0x00
+	ch347I2CMax   = 0x3F // Maximum length of an I²C sub-command
+	ch347I2CACK   = 0x01 // ACK byte when acknowledged
+
+	ch347GPIOChange = 0x80 // Apply the direction and the output value
+	ch347GPIOOutput = 0x40
+	ch347GPIOHigh   = 0x08
+	ch347GPIOLevel  = 0x40 // Input level in the reply
+)
+
+// readFull reads exactly len(b) bytes.
+func (c *CH347) readFull(b []byte) error {
+	for len(b) != 0 {
+		n, err := c.u.bulkIn(b)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errors.New("short read")
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func (c *CH347) i2cSetSpeed(f physic.Frequency) error {
+	s, ok := i2cSpeed(f)
+	if !ok {
+		return c.wrap(errors.New("invalid I²C speed; minimum supported is 20kHz"))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.i2cStream([]byte{ch347I2CSet | s}, nil); err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CH347) i2cTx(addr uint16, w, r []byte) error {
+	if addr >= 0x80 {
+		return c.wrap(errors.New("10 bits I²C addresses are not supported"))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := c.i2cTxLocked(addr, w, r)
+	// Always release the bus.
+	if err2 := c.i2cStream([]byte{ch347I2CStop}, nil); err == nil {
+		err = err2
+	}
+	if err != nil {
+		return c.wrap(err)
+	}
+	return nil
+}
+
+func (c *CH347) i2cTxLocked(addr uint16, w, r []byte) error {
+	if len(w) != 0 || len(r) == 0 {
+		if err := c.i2cStart(byte(addr << 1)); err != nil {
+			return err
+		}
+		ack := make([]byte, ch347I2CMax)
+		for len(w) != 0 {
+			n := len(w)
+			if n > ch347I2CMax {
+				n = ch347I2CMax
+			}
+			if err := c.i2cStream(append([]byte{ch347I2COut | byte(n)}, w[:n]...), ack[:n]); err != nil {
+				return err
+			}
+			for _, a := range ack[:n] {
+				if a != ch347I2CACK {
+					return errors.New("I²C data NACK")
+				}
+			}
+			w = w[n:]
+		}
+	}
+	if len(r) != 0 {
+		if err := c.i2cStart(byte(addr<<1) | 1); err != nil {
+			return err
+		}
+		for off := 0; off < len(r); {
+			// All bytes are acknowledged except the last one.
+			n := len(r) - off - 1
+			if n > ch347I2CMax {
+				n = ch347I2CMax
+			}
+			k := n
+			if n == 0 {
+				k = 1
+			}
+			if err := c.i2cStream([]byte{ch347I2CIn | byte(n)}, r[off:off+k]); err != nil {
+				return err
+			}
+			off += k
+		}
+	}
+	return nil
+}
+
+// i2cStart sends a START or repeated START followed by the address byte a,
+// and checks that it is acknowledged.
+func (c *CH347) i2cStart(a byte) error {
+	var ack [1]byte
+	if err := c.i2cStream([]byte{ch347I2CStart, ch347I2COut | 1, a}, ack[:]); err != nil {
+		return err
+	}
+	if ack[0] != ch347I2CACK {
+		return errors.New("I²C address NACK")
+	}
+	return nil
+}
+
+// i2cStream sends the I²C sub-commands s and reads the len(r) bytes they
+// return.
+func (c *CH347) i2cStream(s, r []byte) error {
+	l := len(s) + 1
+	pkt := append([]byte{ch347CmdI2C, byte(l), byte(l >> 8)}, s...)
+	if err := c.u.bulkOut(append(pkt, ch347I2CEnd)); err != nil {
+		return err
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	buf := make([]byte, 3+len(r))
+	if err := c.readFull(buf); err != nil {
+		return err
+	}
+	if buf[0] != ch347CmdI2C || int(buf[1])|int(buf[2])<<8 != len(r) {
+		return errors.New("unexpected I²C response")
+	}
+	copy(r, buf[3:])
+	return nil
+}
+
+func (c *CH347) i2cClose() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.i2cOpen = false
+}
+
+func (c *CH347) spiConnect(f physic.Frequency, m spi.Mode) error {
+	d := 0
+	for ; d < 8 && 60*physic.MegaHertz>>uint(d) > f; d++ {
+	}
+	if d == 8 {
+		return c.wrap(errors.New("invalid SPI speed; minimum supported is 468.75kHz"))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.noCS = m&spi.NoCS != 0
+	cfg := make([]byte, 29)
+	cfg[0] = ch347CmdSPIConfig
+	cfg[1] = byte(len(cfg) - 3)
+	// The bytes 5, 6, 14 and 19 are always set to these values by the vendor
+	// library.
+	cfg[5] = 4
+	cfg[6] = 1
+	if m&2 != 0 {
+		cfg[9] = 2 // CPOL
+	}
+	if m&1 != 0 {
+		cfg[11] = 1 // CPHA
+	}
+	cfg[14] = 2
+	cfg[15] = byte(d) << 3
+	if m&spi.LSBFirst != 0 {
+		cfg[17] = 0x80
+	}
+	cfg[19] = 7
+	if err := c.u.bulkOut(cfg); err != nil {
+		return c.wrap(err)
+	}
+	var resp [4]byte
+	if err := c.readFull(resp[:]); err != nil {
+		return c.wrap(err)
+	}
+	if resp[0] != ch347CmdSPIConfig || resp[3] != 0 {
+		return c.wrap(errors.New("failed to configure SPI"))
+	}
+	return nil
+}
+
+func (c *CH347) spiTxPackets(pkts []spi.Packet) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	asserted := false
+	for _, p := range pkts {
+		if !asserted && !c.noCS {
+			if err := c.cs(ch347CSAssert); err != nil {
+				return c.wrap(err)
+			}
+			asserted = true
+		}
+		if err := c.spiTx(p.W, p.R); err != nil {
+			if asserted {
+				_ = c.cs(ch347CSDeassert)
+			}
+			return c.wrap(err)
+		}
+		if asserted && !p.KeepCS {
+			if err := c.cs(ch347CSDeassert); err != nil {
+				return c.wrap(err)
+			}
+			asserted = false
+		}
+	}
+	return nil
+}
+
+// cs changes the CS0 state; CS1 is left untouched.
+func (c *CH347) cs(state byte) error {
+	b := make([]byte, 13)
+	b[0] = ch347CmdSPICS
+	b[1] = byte(len(b) - 3)
+	b[3] = ch347CSChange | state
+	return c.u.bulkOut(b)
+}
+
+// spiTx runs a full duplex transfer in chunks of up to 507 bytes.
+func (c *CH347) spiTx(w, r []byte) error {
+	l := len(w)
+	if len(r) > l {
+		l = len(r)
+	}
+	buf := make([]byte, 3+ch347MaxData)
+	for off := 0; off < l; {
+		n := l - off
+		if n > ch347MaxData {
+			n = ch347MaxData
+		}
+		buf[0] = ch347CmdSPIOutIn
+		buf[1] = byte(n)
+		buf[2] = byte(n >> 8)
+		for i := 0; i < n; i++ {
+			buf[3+i] = 0
+			if off+i < len(w) {
+				buf[3+i] = w[off+i]
+			}
+		}
+		if err := c.u.bulkOut(buf[:3+n]); err != nil {
+			return err
+		}
+		if err := c.readFull(buf[:3+n]); err != nil {
+			return err
+		}
+		if buf[0] != ch347CmdSPIOutIn || int(buf[1])|int(buf[2])<<8 != n {
+			return errors.New("unexpected SPI response")
+		}
+		if off < len(r) {
+			copy(r[off:], buf[3:3+n])
+		}
+		off += n
+	}
+	return nil
+}
+
+func (c *CH347) spiClose() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spiOpen = false
+}
+
+// gpio sends one command byte per GPIO, 0 leaving the GPIO unchanged, and
+// returns the input levels.
+//
+// Must be called with mu held.
+func (c *CH347) gpio(g [8]byte) (byte, error) {
+	b := make([]byte, 3+len(g))
+	b[0] = ch347CmdGPIO
+	b[1] = byte(len(g))
+	copy(b[3:], g[:])
+	if err := c.u.bulkOut(b); err != nil {
+		return 0, err
+	}
+	if err := c.readFull(b); err != nil {
+		return 0, err
+	}
+	if b[0] != ch347CmdGPIO || b[1] != byte(len(g)) || b[2] != 0 {
+		return 0, errors.New("unexpected GPIO response")
+	}
+	var l byte
+	for i := range g {
+		if b[3+i]&ch347GPIOLevel != 0 {
+			l |= 1 << uint(i)
+		}
+	}
+	return l, nil
+}
+
+func (c *CH347) gpioIn(n int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var g [8]byte
+	g[n] = ch347GPIOChange
+	if _, err := c.gpio(g); err != nil {
+		return c.wrap(err)
+	}
+	c.dir &^= 1 << uint(n)
+	return nil
+}
+
+func (c *CH347) gpioOut(n int, l gpio.Level) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var g [8]byte
+	g[n] = ch347GPIOChange | ch347GPIOOutput
+	if l {
+		g[n] |= ch347GPIOHigh
+	}
+	if _, err := c.gpio(g); err != nil {
+		return c.wrap(err)
+	}
+	c.dir |= 1 << uint(n)
+	if l {
+		c.out |= 1 << uint(n)
+	} else {
+		c.out &^= 1 << uint(n)
+	}
+	return nil
+}
+
+func (c *CH347) gpioRead(n int) (gpio.Level, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, err := c.gpio([8]byte{})
+	if err != nil {
+		return gpio.Low, c.wrap(err)
+	}
+	return l&(1<<uint(n)) != 0, nil
+}
+
+func (c *CH347) gpioFunction(n int) string {
+	l, err := c.gpioRead(n)
+	if err != nil {
+		return "N/A"
+	}
+	c.mu.Lock()
+	out := c.dir&(1<<uint(n)) != 0
+	c.mu.Unlock()
+	if out {
+		return "Out/" + l.String()
+	}
+	return "In/" + l.String()
+}
+
+func (c *CH347) wrap(err error) error {
+	return fmt.Errorf("ch347: %v", err)
+}
+
+var _ Dev = &CH347{}
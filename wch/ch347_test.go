@@ -0,0 +1,182 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+func TestCH347_I2C(t *testing.T) {
+	c, f := newFakeCH347(t)
+	f.ops = []usbOp{
+		{w: []byte{ch347CmdI2C, 4, 0, ch347I2CStart, ch347I2COut | 1, 0x50 << 1, ch347I2CEnd}, r: []byte{ch347CmdI2C, 1, 0, ch347I2CACK}},
+		{w: []byte{ch347CmdI2C, 3, 0, ch347I2COut | 1, 0x10, ch347I2CEnd}, r: []byte{ch347CmdI2C, 1, 0, ch347I2CACK}},
+		{w: []byte{ch347CmdI2C, 4, 0, ch347I2CStart, ch347I2COut | 1, 0x50<<1 | 1, ch347I2CEnd}, r: []byte{ch347CmdI2C, 1, 0, ch347I2CACK}},
+		{w: []byte{ch347CmdI2C, 2, 0, ch347I2CIn | 1, ch347I2CEnd}, r: []byte{ch347CmdI2C, 1, 0, 0x12}},
+		{w: []byte{ch347CmdI2C, 2, 0, ch347I2CIn, ch347I2CEnd}, r: []byte{ch347CmdI2C, 1, 0, 0x34}},
+		{w: []byte{ch347CmdI2C, 2, 0, ch347I2CStop, ch347I2CEnd}},
+	}
+	b, err := c.I2C()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	if _, err := c.I2C(); err == nil {
+		t.Fatal("the I²C bus can only be opened once")
+	}
+	r := make([]byte, 2)
+	if err := b.Tx(0x50, []byte{0x10}, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{0x12, 0x34}) {
+		t.Fatalf("Tx() read %x", r)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A data byte is not acknowledged; the bus is still released.
+	f.ops = []usbOp{
+		{w: []byte{ch347CmdI2C, 4, 0, ch347I2CStart, ch347I2COut | 1, 0x50 << 1, ch347I2CEnd}, r: []byte{ch347CmdI2C, 1, 0, ch347I2CACK}},
+		{w: []byte{ch347CmdI2C, 4, 0, ch347I2COut | 2, 0x10, 0x11, ch347I2CEnd}, r: []byte{ch347CmdI2C, 2, 0, ch347I2CACK, 0}},
+		{w: []byte{ch347CmdI2C, 2, 0, ch347I2CStop, ch347I2CEnd}},
+	}
+	if err := b.Tx(0x50, []byte{0x10, 0x11}, nil); err == nil || err.Error() != "ch347: I²C data NACK" {
+		t.Fatalf("Tx() = %v", err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.ops = []usbOp{{w: []byte{ch347CmdI2C, 2, 0, ch347I2CSet | 2, ch347I2CEnd}}}
+	if err := b.SetSpeed(physic.MegaHertz / 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetSpeed(10 * physic.KiloHertz); err == nil {
+		t.Fatal("10kHz is too slow")
+	}
+}
+
+func TestCH347_GPIO(t *testing.T) {
+	c, f := newFakeCH347(t)
+	f.ops = []usbOp{
+		{
+			w: []byte{ch347CmdGPIO, 8, 0, 0, 0, ch347GPIOChange | ch347GPIOOutput | ch347GPIOHigh, 0, 0, 0, 0, 0},
+			r: []byte{ch347CmdGPIO, 8, 0, 0, 0, ch347GPIOLevel, 0, 0, 0, 0, 0},
+		},
+		{
+			w: []byte{ch347CmdGPIO, 8, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			r: []byte{ch347CmdGPIO, 8, 0, 0, 0, ch347GPIOLevel, 0, 0, 0, 0, ch347GPIOLevel},
+		},
+		{
+			w: []byte{ch347CmdGPIO, 8, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			r: []byte{ch347CmdGPIO, 8, 0, 0, 0, ch347GPIOLevel, 0, 0, 0, 0, 0},
+		},
+		{
+			w: []byte{ch347CmdGPIO, 8, 0, 0, 0, ch347GPIOChange, 0, 0, 0, 0, 0},
+			r: []byte{ch347CmdGPIO, 8, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+	hdr := c.Header()
+	if s := hdr[2].Name(); s != "CH347.GP2" {
+		t.Fatal(s)
+	}
+	if err := hdr[2].Out(gpio.High); err != nil {
+		t.Fatal(err)
+	}
+	if l := hdr[7].Read(); l != gpio.High {
+		t.Fatal(l)
+	}
+	if s := hdr[2].Function(); s != "Out/High" {
+		t.Fatal(s)
+	}
+	if err := hdr[2].In(gpio.Float, gpio.NoEdge); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCH347_SPI(t *testing.T) {
+	c, f := newFakeCH347(t)
+	cfg := make([]byte, 29)
+	cfg[0] = ch347CmdSPIConfig
+	cfg[1] = 26
+	cfg[5], cfg[6], cfg[14], cfg[19] = 4, 1, 2, 7
+	cfg[11] = 1      // CPHA
+	cfg[15] = 2 << 3 // 60MHz / 4
+	csAssert := []byte{ch347CmdSPICS, 10, 0, ch347CSChange | ch347CSAssert, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	csDeassert := []byte{ch347CmdSPICS, 10, 0, ch347CSChange | ch347CSDeassert, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	f.ops = []usbOp{
+		{w: cfg, r: []byte{ch347CmdSPIConfig, 1, 0, 0}},
+		{w: csAssert},
+		{w: []byte{ch347CmdSPIOutIn, 2, 0, 0xAA, 0x55}, r: []byte{ch347CmdSPIOutIn, 2, 0, 0x11, 0x22}},
+		{w: []byte{ch347CmdSPIOutIn, 1, 0, 0}, r: []byte{ch347CmdSPIOutIn, 1, 0, 0x33}},
+		{w: csDeassert},
+	}
+	p, err := c.SPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	s, err := p.Connect(20*physic.MegaHertz, spi.Mode1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := make([]byte, 1)
+	pkts := []spi.Packet{{W: []byte{0xAA, 0x55}, KeepCS: true}, {R: r}}
+	if err := s.TxPackets(pkts); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r, []byte{0x33}) {
+		t.Fatalf("TxPackets() read %x", r)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+
+	// CS is deasserted when the transfer fails.
+	f.ops = []usbOp{
+		{w: csAssert},
+		{w: []byte{ch347CmdSPIOutIn, 1, 0, 0}, r: []byte{ch347CmdSPIOutIn, 2, 0, 0}},
+		{w: csDeassert},
+	}
+	if err := s.Tx(nil, r); err == nil || err.Error() != "ch347: unexpected SPI response" {
+		t.Fatalf("Tx() = %v", err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Connect(100*physic.KiloHertz, spi.Mode0, 8); err == nil {
+		t.Fatal("100kHz is too slow")
+	}
+}
+
+//
+
+func ch347InitOps() []usbOp {
+	return []usbOp{{w: []byte{ch347CmdI2C, 2, 0, ch347I2CSet | 1, ch347I2CEnd}}}
+}
+
+func newFakeCH347(t *testing.T) (*CH347, *fakeUSB) {
+	f := &fakeUSB{ops: ch347InitOps()}
+	c, err := newCH347(f, "CH347", Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.done(); err != nil {
+		t.Fatal(err)
+	}
+	return c, f
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"errors"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+// Info is the information gathered about the connected device.
+type Info struct {
+	// Type is the device type, "CH341A" or "CH347".
+	Type string
+	// VenID is the vendor ID from the USB descriptor information. It is
+	// expected to be 0x1A86 (WCH).
+	VenID uint16
+	// DevID is the product ID from the USB descriptor information.
+	DevID uint16
+	// Serial is the serial number from the USB descriptor information. It is
+	// usually empty.
+	Serial string
+	// Path is the usbfs device node, e.g. "/dev/bus/usb/001/004".
+	Path string
+}
+
+// Dev represents one WCH device.
+//
+// There can be multiple WCH devices connected to a host.
+//
+// The device may also export one or multiple of I²C, SPI buses. You need to
+// either cast into the right hardware, but more simply use the i2creg / spireg
+// bus/port registries.
+type Dev interface {
+	// conn.Resource
+	String() string
+	Halt() error
+
+	// Info returns information about the device.
+	Info(i *Info)
+
+	// Header returns the GPIO pins exposed on the chip.
+	Header() []gpio.PinIO
+}
+
+//
+
+// gpioBank is implemented by the devices exposing GPIOs.
+type gpioBank interface {
+	gpioIn(n int) error
+	gpioOut(n int, l gpio.Level) error
+	gpioRead(n int) (gpio.Level, error)
+	gpioFunction(n int) string
+}
+
+// wchPin is a GPIO of a device.
+//
+// wchPin implements gpio.PinIO.
+type wchPin struct {
+	bank gpioBank
+	name string
+	num  int
+}
+
+// String implements pin.Pin.
+func (p *wchPin) String() string {
+	return p.name
+}
+
+// Name implements pin.Pin.
+func (p *wchPin) Name() string {
+	return p.name
+}
+
+// Number implements pin.Pin.
+func (p *wchPin) Number() int {
+	return p.num
+}
+
+// Function implements pin.Pin.
+func (p *wchPin) Function() string {
+	return p.bank.gpioFunction(p.num)
+}
+
+// Halt implements gpio.PinIO.
+func (p *wchPin) Halt() error {
+	return nil
+}
+
+// In implements gpio.PinIn.
+//
+// The devices have no configurable pull resistor and no edge detection.
+func (p *wchPin) In(pull gpio.Pull, edge gpio.Edge) error {
+	if pull != gpio.Float && pull != gpio.PullNoChange {
+		return errors.New("wch: pull resistors are not supported")
+	}
+	if edge != gpio.NoEdge {
+		return errors.New("wch: edge detection is not supported")
+	}
+	return p.bank.gpioIn(p.num)
+}
+
+// Read implements gpio.PinIn.
+func (p *wchPin) Read() gpio.Level {
+	l, err := p.bank.gpioRead(p.num)
+	if err != nil {
+		return gpio.Low
+	}
+	return l
+}
+
+// WaitForEdge implements gpio.PinIn.
+func (p *wchPin) WaitForEdge(t time.Duration) bool {
+	return false
+}
+
+// Pull implements gpio.PinIn.
+func (p *wchPin) Pull() gpio.Pull {
+	return gpio.PullNoChange
+}
+
+// DefaultPull implements gpio.PinIn.
+func (p *wchPin) DefaultPull() gpio.Pull {
+	return gpio.PullNoChange
+}
+
+// Out implements gpio.PinOut.
+func (p *wchPin) Out(l gpio.Level) error {
+	return p.bank.gpioOut(p.num, l)
+}
+
+// PWM implements gpio.PinOut.
+func (p *wchPin) PWM(d gpio.Duty, f physic.Frequency) error {
+	return errors.New("wch: PWM is not supported")
+}
+
+var _ gpio.PinIO = &wchPin{}
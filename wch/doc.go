@@ -0,0 +1,26 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+// Package wch implements support for the WCH CH341A and CH347 USB bridges.
+//
+// The CH341A in I²C/SPI mode and the CH347 each expose an I²C bus, an SPI
+// port and 8 GPIOs. The devices are driven with USB bulk transfers through the
+// Linux usbfs interface, so no kernel driver or vendor library is needed.
+//
+// The driver is not loaded by host.Init(). Import this package to enumerate
+// the connected devices and register their GPIOs in gpioreg, their header in
+// pinreg, their I²C bus in i2creg and their SPI port in spireg, using the
+// device name, e.g. "CH341A" or "CH347". When more than one device of a type
+// is connected, the name is suffixed with its index, e.g. "CH341A(1)".
+//
+// The user needs read and write access to the /dev/bus/usb/BBB/DDD device
+// nodes, usually granted with an udev rule. A kernel driver bound to the
+// vendor specific interface is detached when the device is opened.
+//
+// Datasheets
+//
+// https://www.wch-ic.com/products/CH341.html
+//
+// https://www.wch-ic.com/products/CH347.html
+package wch
@@ -0,0 +1,187 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/s-mobi01/host/hostcfg"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/pin"
+	"periph.io/x/conn/v3/pin/pinreg"
+	"periph.io/x/conn/v3/spi/spireg"
+)
+
+// All enumerates all the connected WCH devices.
+func All() []Dev {
+	drv.mu.Lock()
+	defer drv.mu.Unlock()
+	out := make([]Dev, len(drv.all))
+	copy(out, drv.all)
+	return out
+}
+
+//
+
+// model describes a supported device.
+type model struct {
+	name  string
+	devID uint16
+	open  func(u usbDev, name string, info Info) (Dev, error)
+}
+
+// venID is the WCH USB vendor ID.
+const venID = 0x1A86
+
+var models = []model{
+	{name: "CH341A", devID: 0x5512, open: func(u usbDev, name string, info Info) (Dev, error) { return newCH341(u, name, info) }},
+	{name: "CH347", devID: 0x55DB, open: func(u usbDev, name string, info Info) (Dev, error) { return newCH347(u, name, info) }},
+	{name: "CH347", devID: 0x55DE, open: func(u usbDev, name string, info Info) (Dev, error) { return newCH347(u, name, info) }},
+}
+
+// registerDev registers the header and supported buses and ports in the
+// relevant registries.
+func registerDev(d Dev, multi bool) error {
+	name := d.String()
+	hdr := d.Header()
+
+	// Register the GPIOs.
+	for _, p := range hdr {
+		if err := gpioreg.Register(p); err != nil {
+			return err
+		}
+	}
+	if !multi {
+		// Register shorthands.
+		prefix := len(name) + 1
+		for _, p := range hdr {
+			n := p.Name()
+			if err := gpioreg.RegisterAlias(n[prefix:], n); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Register the header.
+	if len(hdr) != 0 {
+		raw := make([][]pin.Pin, len(hdr))
+		for i := range hdr {
+			raw[i] = []pin.Pin{hdr[i]}
+		}
+		if err := pinreg.Register(name, raw); err != nil {
+			return err
+		}
+	}
+	switch t := d.(type) {
+	case *CH341:
+		if err := i2creg.Register(name, nil, -1, t.I2C); err != nil {
+			return err
+		}
+		if err := spireg.Register(name, nil, -1, t.SPI); err != nil {
+			return err
+		}
+	case *CH347:
+		if err := i2creg.Register(name, nil, -1, t.I2C); err != nil {
+			return err
+		}
+		if err := spireg.Register(name, nil, -1, t.SPI); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// driver implements driver.Impl.
+type driver struct {
+	mu        sync.Mutex
+	all       []Dev
+	enumerate func() ([]usbInfo, error)
+	open      func(path string) (usbDev, error)
+}
+
+func (d *driver) String() string {
+	return "wch"
+}
+
+func (d *driver) Prerequisites() []string {
+	return nil
+}
+
+func (d *driver) After() []string {
+	return nil
+}
+
+func (d *driver) Init() (bool, error) {
+	infos, err := d.enumerate()
+	if err != nil {
+		return true, err
+	}
+	// Keep only the supported devices.
+	type found struct {
+		m *model
+		i usbInfo
+	}
+	var devs []found
+	for _, i := range infos {
+		if i.venID != venID {
+			continue
+		}
+		for j := range models {
+			if models[j].devID == i.devID {
+				devs = append(devs, found{&models[j], i})
+				break
+			}
+		}
+	}
+	multi := len(devs) > 1
+	count := map[string]int{}
+	for _, f := range devs {
+		name := f.m.name
+		if n := count[f.m.name]; n > 0 {
+			// When more than one device of a type is present, add "(index)"
+			// suffix.
+			name += "(" + strconv.Itoa(n) + ")"
+		}
+		count[f.m.name]++
+		u, err1 := d.open(f.i.path)
+		if err1 != nil {
+			err = fmt.Errorf("wch: %s: %v", f.i.path, err1)
+			continue
+		}
+		info := Info{Type: f.m.name, VenID: f.i.venID, DevID: f.i.devID, Serial: f.i.serial, Path: f.i.path}
+		dev, err1 := f.m.open(u, name, info)
+		if err1 != nil {
+			_ = u.Close()
+			err = fmt.Errorf("wch: %s: %v", f.i.path, err1)
+			continue
+		}
+		d.all = append(d.all, dev)
+		if err1 = registerDev(dev, multi); err1 != nil {
+			return true, err1
+		}
+	}
+	return true, err
+}
+
+func (d *driver) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.all = nil
+	// enumerate and open are mocked in tests.
+	d.enumerate = func() ([]usbInfo, error) { return enumerate("/sys/bus/usb/devices") }
+	d.open = openUSB
+}
+
+func init() {
+	if isLinux {
+		drv.reset()
+		hostcfg.MustRegister(&drv)
+	}
+}
+
+var drv driver
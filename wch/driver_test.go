@@ -0,0 +1,246 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/spi/spireg"
+)
+
+func TestDriver(t *testing.T) {
+	defer reset(t)
+	drv.enumerate = func() ([]usbInfo, error) {
+		return []usbInfo{
+			{path: "/dev/bus/usb/001/002", venID: 0x1A86, devID: 0x7523},
+			{path: "/dev/bus/usb/001/003", venID: venID, devID: 0x5512},
+			{path: "/dev/bus/usb/001/004", venID: venID, devID: 0x55DB},
+		}, nil
+	}
+	drv.open = func(path string) (usbDev, error) {
+		switch path {
+		case "/dev/bus/usb/001/003":
+			return &fakeUSB{ops: ch341InitOps()}, nil
+		case "/dev/bus/usb/001/004":
+			return &fakeUSB{ops: ch347InitOps()}, nil
+		default:
+			return nil, fmt.Errorf("unexpected path %q", path)
+		}
+	}
+	if b, err := drv.Init(); !b || err != nil {
+		t.Fatalf("Init() = %t, %v", b, err)
+	}
+	all := All()
+	if len(all) != 2 {
+		t.Fatalf("All() = %v", all)
+	}
+	var i Info
+	all[1].Info(&i)
+	if want := (Info{Type: "CH347", VenID: venID, DevID: 0x55DB, Path: "/dev/bus/usb/001/004"}); i != want {
+		t.Fatalf("Info() = %#v, want %#v", i, want)
+	}
+	if p := gpioreg.ByName("CH341A.D7"); p == nil {
+		t.Fatal("CH341A.D7 not registered")
+	}
+	if p := gpioreg.ByName("CH347.GP7"); p == nil {
+		t.Fatal("CH347.GP7 not registered")
+	}
+	for _, name := range []string{"CH341A", "CH347"} {
+		b, err := i2creg.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p, err := spireg.Open("CH347")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := all[1].(*CH347).SPI(); err == nil {
+		t.Fatal("the SPI port can only be opened once")
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	root, err := ioutil.TempDir("", "wch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	devices := map[string]map[string]string{
+		"1-1.2":     {"idVendor": "1a86\n", "idProduct": "5512\n", "busnum": "1\n", "devnum": "12\n"},
+		"1-1.2:1.0": {"bInterfaceClass": "ff\n"},
+		"usb1":      {"idVendor": "1d6b\n", "idProduct": "0002\n", "busnum": "1\n", "devnum": "1\n", "serial": "0000:00:14.0\n"},
+		"2-1":       {"idVendor": "1a86\n"},
+	}
+	for name, files := range devices {
+		d := filepath.Join(root, name)
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for f, content := range files {
+			if err := ioutil.WriteFile(filepath.Join(d, f), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	got, err := enumerate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []usbInfo{
+		{path: "/dev/bus/usb/001/001", venID: 0x1D6B, devID: 0x0002, serial: "0000:00:14.0"},
+		{path: "/dev/bus/usb/001/012", venID: 0x1A86, devID: 0x5512},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("enumerate() = %#v, want %#v", got, want)
+	}
+}
+
+func TestFindBulk(t *testing.T) {
+	data := []struct {
+		name  string
+		desc  []byte
+		iface int
+		in    byte
+		out   byte
+		ok    bool
+	}{
+		{
+			"CH341A",
+			concat(
+				devDesc, cfgDesc,
+				ifDesc(0, 0xFF), epDesc(0x82, 2), epDesc(0x02, 2), epDesc(0x81, 3),
+			),
+			0, 0x82, 0x02, true,
+		},
+		{
+			"CH347T",
+			concat(
+				devDesc, cfgDesc,
+				ifDesc(0, 0x02), epDesc(0x83, 3),
+				ifDesc(1, 0x0A), epDesc(0x02, 2), epDesc(0x82, 2),
+				ifDesc(2, 0xFF), epDesc(0x06, 2), epDesc(0x86, 2),
+			),
+			2, 0x86, 0x06, true,
+		},
+		{
+			"no bulk",
+			concat(devDesc, cfgDesc, ifDesc(0, 0xFF), epDesc(0x81, 3)),
+			-1, 0, 0, false,
+		},
+		{"truncated", []byte{9, 4, 0}, -1, 0, 0, false},
+	}
+	for _, line := range data {
+		iface, in, out, ok := findBulk(line.desc)
+		if iface != line.iface || in != line.in || out != line.out || ok != line.ok {
+			t.Errorf("%s: findBulk() = %d, %#x, %#x, %t", line.name, iface, in, out, ok)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		var want byte
+		for j := uint(0); j < 8; j++ {
+			if i&(1<<j) != 0 {
+				want |= 0x80 >> j
+			}
+		}
+		if got := reverse(byte(i)); got != want {
+			t.Fatalf("reverse(%#x) = %#x, want %#x", i, got, want)
+		}
+	}
+}
+
+//
+
+var (
+	devDesc = []byte{18, 1, 0x00, 0x02, 0xFF, 0, 0, 64, 0x86, 0x1A, 0x12, 0x55, 0x04, 0x03, 0, 2, 0, 1}
+	cfgDesc = []byte{9, 2, 0, 0, 1, 1, 0, 0x80, 49}
+)
+
+func ifDesc(n, class byte) []byte {
+	return []byte{9, 4, n, 0, 2, class, 0, 0, 0}
+}
+
+func epDesc(addr, attr byte) []byte {
+	return []byte{7, 5, addr, attr, 32, 0, 0}
+}
+
+func concat(b ...[]byte) []byte {
+	return bytes.Join(b, nil)
+}
+
+// usbOp is an expected bulk OUT transfer and the data it makes available on
+// the bulk IN endpoint.
+type usbOp struct {
+	w []byte
+	r []byte
+}
+
+// fakeUSB is a scripted usbDev.
+type fakeUSB struct {
+	ops     []usbOp
+	pending []byte
+}
+
+func (f *fakeUSB) Close() error {
+	return nil
+}
+
+func (f *fakeUSB) bulkOut(b []byte) error {
+	if len(f.ops) == 0 {
+		return fmt.Errorf("unexpected bulk out %x", b)
+	}
+	op := f.ops[0]
+	f.ops = f.ops[1:]
+	if !bytes.Equal(b, op.w) {
+		return fmt.Errorf("bulk out %x, want %x", b, op.w)
+	}
+	f.pending = append(f.pending, op.r...)
+	return nil
+}
+
+func (f *fakeUSB) bulkIn(b []byte) (int, error) {
+	if len(f.pending) == 0 {
+		return 0, fmt.Errorf("unexpected bulk in")
+	}
+	n := copy(b, f.pending)
+	f.pending = f.pending[n:]
+	return n, nil
+}
+
+// done returns an error if some expected transfers were not done.
+func (f *fakeUSB) done() error {
+	if len(f.ops) != 0 {
+		return fmt.Errorf("%d bulk out not sent, next is %x", len(f.ops), f.ops[0].w)
+	}
+	if len(f.pending) != 0 {
+		return fmt.Errorf("%d bytes not read", len(f.pending))
+	}
+	return nil
+}
+
+func reset(t *testing.T) {
+	drv.reset()
+}
+
+func init() {
+	reset(nil)
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/physic"
+)
+
+// i2cBridge is implemented by the devices exposing an I²C bus.
+type i2cBridge interface {
+	i2cSetSpeed(f physic.Frequency) error
+	i2cTx(addr uint16, w, r []byte) error
+	i2cClose()
+}
+
+// i2cBus is the I²C bus of a device.
+//
+// i2cBus implements i2c.BusCloser.
+type i2cBus struct {
+	name string
+	b    i2cBridge
+}
+
+// Close implements i2c.BusCloser.
+func (i *i2cBus) Close() error {
+	i.b.i2cClose()
+	return nil
+}
+
+// Duplex implements conn.Conn.
+func (i *i2cBus) Duplex() conn.Duplex {
+	return conn.Half
+}
+
+// String implements i2c.Bus.
+func (i *i2cBus) String() string {
+	return i.name
+}
+
+// SetSpeed implements i2c.Bus.
+//
+// The supported speeds are 20kHz, 100kHz, 400kHz and 750kHz; f is rounded
+// down to one of them.
+func (i *i2cBus) SetSpeed(f physic.Frequency) error {
+	return i.b.i2cSetSpeed(f)
+}
+
+// Tx implements i2c.Bus.
+func (i *i2cBus) Tx(addr uint16, w, r []byte) error {
+	return i.b.i2cTx(addr, w, r)
+}
+
+// i2cSpeed returns the speed selector common to the devices, from 0 for 20kHz
+// to 3 for 750kHz.
+func i2cSpeed(f physic.Frequency) (byte, bool) {
+	switch {
+	case f >= 750*physic.KiloHertz:
+		return 3, true
+	case f >= 400*physic.KiloHertz:
+		return 2, true
+	case f >= 100*physic.KiloHertz:
+		return 1, true
+	case f >= 20*physic.KiloHertz:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+var _ i2c.BusCloser = &i2cBus{}
@@ -0,0 +1,116 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"errors"
+	"fmt"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+// spiBridge is implemented by the devices exposing an SPI port.
+type spiBridge interface {
+	// spiConnect configures the port. f is never 0.
+	spiConnect(f physic.Frequency, m spi.Mode) error
+	// spiTxPackets runs the packets, which are already validated.
+	spiTxPackets(pkts []spi.Packet) error
+	spiClose()
+}
+
+// spiPort is the SPI port of a device.
+//
+// spiPort implements spi.PortCloser.
+type spiPort struct {
+	c spiConn
+
+	// Mutable.
+	maxFreq physic.Frequency
+}
+
+func (s *spiPort) Close() error {
+	s.c.b.spiClose()
+	s.maxFreq = 0
+	return nil
+}
+
+func (s *spiPort) String() string {
+	return s.c.name
+}
+
+// Connect implements spi.Port.
+func (s *spiPort) Connect(f physic.Frequency, m spi.Mode, bits int) (spi.Conn, error) {
+	if f < 0 || (f != 0 && f < 100*physic.Hertz) {
+		return nil, fmt.Errorf("wch: invalid speed %s; did you forget to multiply by physic.MegaHertz?", f)
+	}
+	if bits != 8 {
+		return nil, errors.New("wch: only 8 bits per word is supported")
+	}
+	if m&spi.HalfDuplex != 0 {
+		return nil, errors.New("wch: spi.HalfDuplex is not supported")
+	}
+	if s.maxFreq != 0 && (f == 0 || f > s.maxFreq) {
+		f = s.maxFreq
+	}
+	if f == 0 {
+		f = physic.MegaHertz
+	}
+	if err := s.c.b.spiConnect(f, m); err != nil {
+		return nil, err
+	}
+	return &s.c, nil
+}
+
+// LimitSpeed implements spi.Port.
+func (s *spiPort) LimitSpeed(f physic.Frequency) error {
+	if f < 100*physic.Hertz {
+		return errors.New("wch: minimum supported clock is 100Hz; did you forget to multiply by physic.MegaHertz?")
+	}
+	s.maxFreq = f
+	return nil
+}
+
+// spiConn implements spi.Conn.
+type spiConn struct {
+	name string
+	b    spiBridge
+}
+
+func (s *spiConn) String() string {
+	return s.name
+}
+
+func (s *spiConn) Duplex() conn.Duplex {
+	return conn.Full
+}
+
+func (s *spiConn) Tx(w, r []byte) error {
+	var p = [1]spi.Packet{{W: w, R: r}}
+	return s.TxPackets(p[:])
+}
+
+func (s *spiConn) TxPackets(pkts []spi.Packet) error {
+	for _, p := range pkts {
+		if p.BitsPerWord != 0 && p.BitsPerWord != 8 {
+			return errors.New("wch: only 8 bits per word is supported")
+		}
+		if len(p.W) != 0 && len(p.R) != 0 && len(p.W) != len(p.R) {
+			return errors.New("wch: both buffers must have the same size")
+		}
+	}
+	return s.b.spiTxPackets(pkts)
+}
+
+// reverse returns b with the bit order of each byte reversed.
+func reverse(b byte) byte {
+	b = b>>4 | b<<4
+	b = (b&0xCC)>>2 | (b&0x33)<<2
+	return (b&0xAA)>>1 | (b&0x55)<<1
+}
+
+var _ spi.PortCloser = &spiPort{}
+var _ spi.Conn = &spiConn{}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// usbDev is an opened vendor specific USB interface with a pair of bulk
+// endpoints.
+//
+// It is implemented by usbfs and mocked in tests.
+type usbDev interface {
+	io.Closer
+	// bulkOut sends b on the bulk OUT endpoint.
+	bulkOut(b []byte) error
+	// bulkIn reads up to len(b) bytes from the bulk IN endpoint.
+	bulkIn(b []byte) (int, error)
+}
+
+// usbInfo describes a USB device found in sysfs.
+type usbInfo struct {
+	path   string // e.g. "/dev/bus/usb/001/004"
+	venID  uint16
+	devID  uint16
+	serial string
+}
+
+// enumerate returns the USB devices listed in root, normally
+// /sys/bus/usb/devices, sorted by device node.
+func enumerate(root string) ([]usbInfo, error) {
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []usbInfo
+	for _, e := range entries {
+		if strings.ContainsRune(e.Name(), ':') {
+			// Interface, not a device.
+			continue
+		}
+		d := filepath.Join(root, e.Name())
+		v, err1 := readHex(filepath.Join(d, "idVendor"))
+		p, err2 := readHex(filepath.Join(d, "idProduct"))
+		bus, err3 := readInt(filepath.Join(d, "busnum"))
+		dev, err4 := readInt(filepath.Join(d, "devnum"))
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+		i := usbInfo{
+			path:  fmt.Sprintf("/dev/bus/usb/%03d/%03d", bus, dev),
+			venID: uint16(v),
+			devID: uint16(p),
+		}
+		if s, err := ioutil.ReadFile(filepath.Join(d, "serial")); err == nil {
+			i.serial = strings.TrimSpace(string(s))
+		}
+		out = append(out, i)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].path < out[j].path })
+	return out, nil
+}
+
+func readHex(path string) (uint64, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(b)), 16, 16)
+}
+
+func readInt(path string) (int, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(b)))
+}
+
+// findBulk returns the first vendor specific interface with a bulk IN and a
+// bulk OUT endpoint in the raw USB descriptors desc, as read from usbfs.
+//
+// The CH341A exposes it as interface 0, the CH347T as interface 2 and the
+// CH347F as interface 4.
+func findBulk(desc []byte) (iface int, in, out byte, ok bool) {
+	const (
+		typeInterface = 4
+		typeEndpoint  = 5
+		classVendor   = 0xFF
+		bulk          = 2
+	)
+	iface = -1
+	for len(desc) >= 2 {
+		l := int(desc[0])
+		if l < 2 || l > len(desc) {
+			break
+		}
+		d := desc[:l]
+		desc = desc[l:]
+		switch d[1] {
+		case typeInterface:
+			if in != 0 && out != 0 {
+				return iface, in, out, true
+			}
+			iface, in, out = -1, 0, 0
+			if l >= 9 && d[3] == 0 && d[5] == classVendor {
+				iface = int(d[2])
+			}
+		case typeEndpoint:
+			if iface < 0 || l < 7 || d[3]&3 != bulk {
+				continue
+			}
+			if d[2]&0x80 != 0 {
+				if in == 0 {
+					in = d[2]
+				}
+			} else if out == 0 {
+				out = d[2]
+			}
+		}
+	}
+	if iface >= 0 && in != 0 && out != 0 {
+		return iface, in, out, true
+	}
+	return -1, 0, 0, false
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package wch
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"github.com/s-mobi01/host/fs"
+)
+
+const isLinux = true
+
+// Structures and constants from include/uapi/linux/usbdevice_fs.h.
+
+// bulkTransfer is struct usbdevfs_bulktransfer.
+type bulkTransfer struct {
+	ep      uint32
+	len     uint32
+	timeout uint32 // in milliseconds
+	data    uintptr
+}
+
+// ioctlRequest is struct usbdevfs_ioctl.
+type ioctlRequest struct {
+	ifno int32
+	code int32
+	data uintptr
+}
+
+var (
+	ioctlBulk             = fs.IOWR('U', 2, uint(unsafe.Sizeof(bulkTransfer{})))
+	ioctlClaimInterface   = fs.IOR('U', 15, 4)
+	ioctlReleaseInterface = fs.IOR('U', 16, 4)
+	ioctlIoctl            = fs.IOWR('U', 18, uint(unsafe.Sizeof(ioctlRequest{})))
+	ioctlDisconnect       = fs.IO('U', 22)
+)
+
+// usbfs is a vendor specific interface of a /dev/bus/usb/BBB/DDD device.
+type usbfs struct {
+	f     *fs.File
+	iface uint32
+	in    byte
+	out   byte
+}
+
+// openUSB opens the device at path and claims its vendor specific interface,
+// detaching the kernel driver bound to it if any.
+func openUSB(path string) (usbDev, error) {
+	f, err := fs.Open(path, os.O_RDWR)
+	if err != nil {
+		return nil, err
+	}
+	// Reading the device node returns the device and configuration
+	// descriptors.
+	desc, err := ioutil.ReadAll(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	iface, in, out, ok := findBulk(desc)
+	if !ok {
+		_ = f.Close()
+		return nil, errors.New("no vendor specific interface with bulk endpoints")
+	}
+	u := &usbfs{f: f, iface: uint32(iface), in: in, out: out}
+	// It fails with ENODATA when no kernel driver is bound.
+	req := ioctlRequest{ifno: int32(iface), code: int32(ioctlDisconnect)}
+	if err := f.Ioctl(ioctlIoctl, uintptr(unsafe.Pointer(&req))); err != nil && err != syscall.ENODATA {
+		_ = f.Close()
+		return nil, err
+	}
+	if err := f.Ioctl(ioctlClaimInterface, uintptr(unsafe.Pointer(&u.iface))); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return u, nil
+}
+
+func (u *usbfs) Close() error {
+	err := u.f.Ioctl(ioctlReleaseInterface, uintptr(unsafe.Pointer(&u.iface)))
+	if err2 := u.f.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+func (u *usbfs) bulkOut(b []byte) error {
+	for len(b) != 0 {
+		n, err := u.bulk(u.out, b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func (u *usbfs) bulkIn(b []byte) (int, error) {
+	return u.bulk(u.in, b)
+}
+
+// bulk runs a bulk transfer and returns the number of bytes transferred.
+//
+// fs.File.Ioctl can't be used since it doesn't return the ioctl return value.
+func (u *usbfs) bulk(ep byte, b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	t := bulkTransfer{ep: uint32(ep), len: uint32(len(b)), timeout: 1000, data: uintptr(unsafe.Pointer(&b[0]))}
+	n, _, errno := syscall.Syscall(syscall.SYS_IOCTL, u.f.Fd(), uintptr(ioctlBulk), uintptr(unsafe.Pointer(&t)))
+	runtime.KeepAlive(b)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
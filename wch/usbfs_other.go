@@ -0,0 +1,16 @@
+// Copyright 2026 The Periph Authors. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package wch
+
+import "errors"
+
+const isLinux = false
+
+func openUSB(path string) (usbDev, error) {
+	return nil, errors.New("unreachable code")
+}